@@ -0,0 +1,224 @@
+// Copyright (c) 2018, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package atspi
+
+import (
+	"bufio"
+	"bytes"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/goki/gi/gi"
+	"github.com/goki/ki/ki"
+)
+
+func TestMarshal(t *testing.T) {
+	m := &Message{Type: MsgMethodCall, Serial: 3, Path: "/a/b", Interface: "x.Y", Member: "Z",
+		Signature: "ya(so)a{sv}dxb",
+		Body: []interface{}{byte(7), []interface{}{Struct{"n", ObjectPath("/p")}},
+			[]DictEntry{{"k", MakeVariant("i", int32(-2))}}, 1.5, int64(-9), true}}
+	b, err := m.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rm, err := ReadMessage(bytes.NewReader(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rm.Path != m.Path || rm.Interface != m.Interface || rm.Member != m.Member || rm.Signature != m.Signature || rm.Serial != 3 {
+		t.Errorf("header not read back: %+v", rm)
+	}
+	if !reflect.DeepEqual(rm.Body, m.Body) {
+		t.Errorf("body read back as %#v, not %#v", rm.Body, m.Body)
+	}
+}
+
+// startBus starts a private dbus-daemon, returning its address and a
+// function to stop it -- the test is skipped if there is no dbus-daemon
+func startBus(t *testing.T) (string, func()) {
+	daemon, err := exec.LookPath("dbus-daemon")
+	if err != nil {
+		t.Skip("dbus-daemon not found")
+	}
+	dir, err := ioutil.TempDir("", "atspi")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := `<!DOCTYPE busconfig PUBLIC "-//freedesktop//DTD D-Bus Bus Configuration 1.0//EN"
+ "http://www.freedesktop.org/standards/dbus/1.0/busconfig.dtd">
+<busconfig>
+  <type>session</type>
+  <listen>unix:path=` + filepath.Join(dir, "bus") + `</listen>
+  <auth>EXTERNAL</auth>
+  <policy context="default">
+    <allow send_destination="*" eavesdrop="true"/>
+    <allow eavesdrop="true"/>
+    <allow own="*"/>
+  </policy>
+</busconfig>
+`
+	cfn := filepath.Join(dir, "bus.conf")
+	if err := ioutil.WriteFile(cfn, []byte(cfg), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cmd := exec.Command(daemon, "--config-file="+cfn, "--nofork", "--print-address")
+	out, err := cmd.StdoutPipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.Start(); err != nil {
+		os.RemoveAll(dir)
+		t.Skipf("dbus-daemon could not be started: %v", err)
+	}
+	stop := func() {
+		cmd.Process.Kill()
+		cmd.Wait()
+		os.RemoveAll(dir)
+	}
+	addr, err := bufio.NewReader(out).ReadString('\n')
+	if err != nil {
+		stop()
+		t.Skipf("dbus-daemon did not start: %v", err)
+	}
+	return strings.TrimSpace(addr), stop
+}
+
+func TestBridge(t *testing.T) {
+	addr, stop := startBus(t)
+	defer stop()
+
+	// there is no window, so fields are set directly and the viewport is
+	// kept updating, to avoid any styling or rendering
+	vp := gi.NewViewport2D(400, 300)
+	vp.InitName(vp, "vp")
+	fr := gi.AddNewFrame(vp, "fr", gi.LayoutVert)
+	lbl := gi.AddNewLabel(fr, "lbl", "Name:")
+	tf := gi.AddNewTextField(fr, "tf")
+	tf.EditTxt = []rune("Ann")
+	lbl.AccessFor = tf
+	bt := gi.AddNewButton(fr, "ok")
+	bt.Text = "OK"
+	vp.UpdateStart()
+	clicked := make(chan bool, 1)
+	bt.ButtonSig.Connect(vp.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+		if sig == int64(gi.ButtonClicked) {
+			clicked <- true
+		}
+	})
+
+	at := gi.NewAccessTree(vp, nil)
+	at.Update()
+
+	aconn, err := Dial(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer aconn.Close()
+	br := NewBridge(aconn, "test")
+	br.AddTree(at)
+
+	cl, err := Dial(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cl.Close()
+	sigs := make(chan *Message, 100)
+	cl.SetSignalHandler(func(c *Conn, m *Message) {
+		sigs <- m
+	})
+	if err := cl.AddMatch("type='signal',interface='" + IfaceEventObject + "'"); err != nil {
+		t.Fatal(err)
+	}
+
+	call := func(path ObjectPath, iface, member, sig string, args ...interface{}) []interface{} {
+		t.Helper()
+		rep, err := cl.Call(aconn.Name, path, iface, member, sig, args...)
+		if err != nil {
+			t.Fatalf("%v.%v on %v: %v", iface, member, path, err)
+		}
+		return rep
+	}
+	children := func(path ObjectPath) []ObjectPath {
+		t.Helper()
+		var ps []ObjectPath
+		for _, k := range call(path, IfaceAccessible, "GetChildren", "")[0].([]interface{}) {
+			ps = append(ps, k.(Struct)[1].(ObjectPath))
+		}
+		return ps
+	}
+
+	if role := call(RootPath, IfaceAccessible, "GetRole", "")[0]; role != uint32(RoleApplication) {
+		t.Errorf("application role is %v", role)
+	}
+	wins := children(RootPath)
+	if len(wins) != 1 {
+		t.Fatalf("application has %v children, not 1", len(wins))
+	}
+	kids := children(wins[0])
+	if len(kids) != 3 {
+		t.Fatalf("root has %v children, not 3 -- the frame should be flattened", len(kids))
+	}
+	for i, role := range []uint32{RoleLabel, RoleEntry, RolePushButton} {
+		if r := call(kids[i], IfaceAccessible, "GetRole", "")[0]; r != role {
+			t.Errorf("child %v has role %v, not %v", i, r, role)
+		}
+	}
+
+	name := func(path ObjectPath) string {
+		t.Helper()
+		return call(path, IfaceProperties, "Get", "ss", IfaceAccessible, "Name")[0].(Variant).Value.(string)
+	}
+	if nm := name(kids[1]); nm != "Name:" {
+		t.Errorf("text field is named %q, not from its label", nm)
+	}
+	rels := call(kids[1], IfaceAccessible, "GetRelationSet", "")[0].([]interface{})
+	if len(rels) != 1 || rels[0].(Struct)[0] != uint32(2) || rels[0].(Struct)[1].([]interface{})[0].(Struct)[1] != kids[0] {
+		t.Errorf("text field relations are %v, not labelled by the label", rels)
+	}
+	if txt := call(kids[1], IfaceText, "GetText", "ii", 0, -1)[0]; txt != "Ann" {
+		t.Errorf("text field text is %q", txt)
+	}
+
+	if nm := name(kids[2]); nm != "OK" {
+		t.Errorf("button is named %q", nm)
+	}
+	if act := call(kids[2], IfaceAction, "GetName", "i", 0)[0]; act != gi.AccessActClick {
+		t.Errorf("button action is %v", act)
+	}
+	if ok := call(kids[2], IfaceAction, "DoAction", "i", 0)[0]; ok != true {
+		t.Errorf("DoAction returned %v", ok)
+	}
+	select {
+	case <-clicked:
+	case <-time.After(5 * time.Second):
+		t.Errorf("DoAction did not click the button")
+	}
+
+	lbl.Text = "Full name:"
+	at.Update()
+	timeout := time.After(5 * time.Second)
+	for got := false; !got; {
+		select {
+		case m := <-sigs:
+			if m.Member == "PropertyChange" && m.Path == kids[1] && m.Body[0] == "accessible-name" {
+				if nm := m.Body[3].(Variant).Value; nm != "Full name:" {
+					t.Errorf("name changed to %q", nm)
+				}
+				got = true
+			}
+		case <-timeout:
+			t.Fatal("no accessible-name change event for the text field")
+		}
+	}
+	if nm := name(kids[1]); nm != "Full name:" {
+		t.Errorf("text field is named %q after the label changed", nm)
+	}
+}
@@ -0,0 +1,847 @@
+// Copyright (c) 2018, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package atspi
+
+import (
+	"fmt"
+	"image"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/goki/gi/gi"
+	"github.com/goki/ki/ki"
+	"github.com/goki/ki/kit"
+)
+
+// Object paths of the AT-SPI objects
+const (
+	// PathPrefix is the prefix of the paths of all accessible objects
+	PathPrefix = "/org/a11y/atspi/accessible/"
+
+	// RootPath is the path of the application object, whose children are
+	// the roots of the trees (windows)
+	RootPath = ObjectPath(PathPrefix + "root")
+
+	// NullPath is the path of the null object, for references to nothing
+	NullPath = ObjectPath("/org/a11y/atspi/null")
+)
+
+// AT-SPI interface names
+const (
+	IfaceAccessible  = "org.a11y.atspi.Accessible"
+	IfaceApplication = "org.a11y.atspi.Application"
+	IfaceComponent   = "org.a11y.atspi.Component"
+	IfaceAction      = "org.a11y.atspi.Action"
+	IfaceValue       = "org.a11y.atspi.Value"
+	IfaceText        = "org.a11y.atspi.Text"
+	IfaceEventObject = "org.a11y.atspi.Event.Object"
+	IfaceEventFocus  = "org.a11y.atspi.Event.Focus"
+	IfaceProperties  = "org.freedesktop.DBus.Properties"
+	IfaceIntrospect  = "org.freedesktop.DBus.Introspectable"
+)
+
+// D-Bus error names returned by the bridge
+const (
+	ErrUnknownMethod = "org.freedesktop.DBus.Error.UnknownMethod"
+	ErrUnknownObject = "org.freedesktop.DBus.Error.UnknownObject"
+	ErrInvalidArgs   = "org.freedesktop.DBus.Error.InvalidArgs"
+	ErrNotSupported  = "org.freedesktop.DBus.Error.NotSupported"
+)
+
+// Bridge exports the nodes of one or more gi.AccessTree's as AT-SPI objects
+// on a connection to the accessibility bus, and sends AT-SPI events for the
+// changes in the trees.  It is a ki.Node only to receive the AccessSig
+// signals of the trees.
+type Bridge struct {
+	ki.Node
+	Conn    *Conn            `desc:"connection to the accessibility bus"`
+	AppName string           `desc:"name of the application, as shown to assistive technology"`
+	AppID   int32            `desc:"id of the application, as set by the registry"`
+	Desktop Struct           `desc:"reference (bus name, path) to the desktop object that the application is embedded in -- nil if not embedded"`
+	Trees   []*gi.AccessTree `desc:"the trees exported, in order -- the roots are the children of the application object"`
+	mu      sync.Mutex
+	prev    map[*gi.AccessNode]prevInfo
+}
+
+var KiT_Bridge = kit.Types.AddType(&Bridge{}, nil)
+
+// prevInfo is the info of a node as last sent in events, for sending the
+// differences when it changes
+type prevInfo struct {
+	info   gi.AccessInfo
+	states uint64
+}
+
+// NewBridge returns a new bridge handling the method calls received on
+// given connection, for application of given name -- use AddTree to add
+// trees to it, and Register to register it with the desktop
+func NewBridge(conn *Conn, appName string) *Bridge {
+	br := &Bridge{Conn: conn, AppName: appName}
+	br.InitName(br, appName)
+	br.prev = make(map[*gi.AccessNode]prevInfo)
+	conn.SetHandler(br.HandleCall)
+	return br
+}
+
+// Start enables the accessibility tree of given window, and exports it on
+// the accessibility bus of the desktop session, registering the application
+// with the AT-SPI registry so that screen readers can find it.  Returns an
+// error if there is no accessibility bus.
+func Start(win *gi.Window) (*Bridge, error) {
+	addr, err := BusAddress()
+	if err != nil {
+		return nil, err
+	}
+	conn, err := Dial(addr)
+	if err != nil {
+		return nil, err
+	}
+	br := NewBridge(conn, gi.AppName())
+	br.AddTree(win.EnableAccess())
+	if err := br.Register(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return br, nil
+}
+
+// BusAddress returns the address of the accessibility bus -- from the
+// AT_SPI_BUS_ADDRESS environment variable if set, otherwise from the
+// org.a11y.Bus service on the session bus
+func BusAddress() (string, error) {
+	if addr := os.Getenv("AT_SPI_BUS_ADDRESS"); addr != "" {
+		return addr, nil
+	}
+	sess, err := Dial(SessionBusAddress())
+	if err != nil {
+		return "", err
+	}
+	defer sess.Close()
+	rep, err := sess.Call("org.a11y.Bus", "/org/a11y/bus", "org.a11y.Bus", "GetAddress", "")
+	if err != nil {
+		return "", err
+	}
+	if len(rep) == 0 {
+		return "", fmt.Errorf("atspi: no accessibility bus address")
+	}
+	addr, _ := rep[0].(string)
+	return addr, nil
+}
+
+// Register registers the application with the AT-SPI registry, embedding it
+// as a child of the desktop
+func (br *Bridge) Register() error {
+	rep, err := br.Conn.Call("org.a11y.atspi.Registry", RootPath, "org.a11y.atspi.Socket", "Embed", "(so)", br.ref(RootPath))
+	if err != nil {
+		return err
+	}
+	if len(rep) > 0 {
+		br.mu.Lock()
+		br.Desktop, _ = rep[0].(Struct)
+		br.mu.Unlock()
+	}
+	return nil
+}
+
+// AddTree adds given tree to those exported by the bridge, as a new child of
+// the application object
+func (br *Bridge) AddTree(at *gi.AccessTree) {
+	br.mu.Lock()
+	ti := len(br.Trees)
+	br.Trees = append(br.Trees, at)
+	br.mu.Unlock()
+	at.Mu.RLock()
+	br.primeNode(at.Root)
+	root := at.Root
+	at.Mu.RUnlock()
+	at.AccessSig.Connect(br.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+		brr := recv.Embed(KiT_Bridge).(*Bridge)
+		an, _ := data.(*gi.AccessNode)
+		brr.TreeEvent(at, gi.AccessTreeSignals(sig), an)
+	})
+	if root != nil {
+		br.emitObject(RootPath, "ChildrenChanged", "add", ti, 0, MakeVariant("(so)", br.ref(br.nodePath(ti, root))))
+	}
+}
+
+// primeNode records the info of given node and its children, as if sent
+func (br *Bridge) primeNode(an *gi.AccessNode) {
+	if an == nil {
+		return
+	}
+	br.mu.Lock()
+	br.prev[an] = prevInfo{an.Info, StateSet(&an.Info)}
+	br.mu.Unlock()
+	for _, k := range an.Kids {
+		br.primeNode(k)
+	}
+}
+
+// treeIndex returns the index of given tree -- -1 if not found
+func (br *Bridge) treeIndex(at *gi.AccessTree) int {
+	br.mu.Lock()
+	defer br.mu.Unlock()
+	for i, t := range br.Trees {
+		if t == at {
+			return i
+		}
+	}
+	return -1
+}
+
+// tree returns the tree at given index -- nil if none
+func (br *Bridge) tree(ti int) *gi.AccessTree {
+	br.mu.Lock()
+	defer br.mu.Unlock()
+	if ti < 0 || ti >= len(br.Trees) {
+		return nil
+	}
+	return br.Trees[ti]
+}
+
+// ref returns the reference (bus name, path) for given object path
+func (br *Bridge) ref(path ObjectPath) Struct {
+	if path == NullPath {
+		return Struct{"", path}
+	}
+	return Struct{br.Conn.Name, path}
+}
+
+// nodePath returns the object path for given node of tree at index ti
+func (br *Bridge) nodePath(ti int, an *gi.AccessNode) ObjectPath {
+	if an == nil {
+		return NullPath
+	}
+	return ObjectPath(fmt.Sprintf("%s%d_%d", PathPrefix, ti, an.ID))
+}
+
+// parsePath returns the tree index and node id for given object path
+func parsePath(path ObjectPath) (ti int, id int64, ok bool) {
+	ps := string(path)
+	if !strings.HasPrefix(ps, PathPrefix) {
+		return
+	}
+	ps = ps[len(PathPrefix):]
+	ui := strings.Index(ps, "_")
+	if ui < 0 {
+		return
+	}
+	var err error
+	if ti, err = strconv.Atoi(ps[:ui]); err != nil {
+		return
+	}
+	if id, err = strconv.ParseInt(ps[ui+1:], 10, 64); err != nil {
+		return
+	}
+	ok = true
+	return
+}
+
+////////////////////////////////////////////////////////////////////////////////////////
+// Method calls
+
+// HandleCall handles method calls on the accessible objects -- it is the
+// call handler of the connection
+func (br *Bridge) HandleCall(c *Conn, m *Message) {
+	if err := br.dispatch(m); err != nil {
+		c.ReplyError(m, err.Name, err.Message)
+	}
+}
+
+// objInfo is the information about an object for the Accessible interface
+type objInfo struct {
+	name, desc string
+	role       uint32
+	parent     Struct
+	kids       []interface{}
+	index      int32
+	states     uint64
+	relations  []interface{}
+	ifaces     []string
+}
+
+// dispatch dispatches a method call to the object it is for
+func (br *Bridge) dispatch(m *Message) *Error {
+	if m.Interface == IfaceIntrospect && m.Member == "Introspect" {
+		return br.introspect(m)
+	}
+	if m.Path == RootPath {
+		return br.callApp(m)
+	}
+	ti, id, ok := parsePath(m.Path)
+	at := br.tree(ti)
+	if !ok || at == nil {
+		return &Error{ErrUnknownObject, "unknown object: " + string(m.Path)}
+	}
+	// actions are done without the lock, as they can update the tree
+	if (m.Interface == IfaceAction && m.Member == "DoAction") || (m.Interface == IfaceComponent && m.Member == "GrabFocus") {
+		return br.callAction(m, at, id)
+	}
+	at.Mu.RLock()
+	defer at.Mu.RUnlock()
+	an := at.NodeByID(id)
+	if an == nil {
+		return &Error{ErrUnknownObject, "unknown object: " + string(m.Path)}
+	}
+	return br.callNode(m, ti, at, an)
+}
+
+// args checks that the arguments of a call have given signature
+func args(m *Message, sig string) *Error {
+	if string(m.Signature) != sig {
+		return &Error{ErrInvalidArgs, fmt.Sprintf("%v.%v: arguments must be of type %v, not %v", m.Interface, m.Member, sig, m.Signature)}
+	}
+	return nil
+}
+
+// reply sends the reply to a call, returning an error if the sending failed
+func (br *Bridge) reply(m *Message, sig string, vals ...interface{}) *Error {
+	if err := br.Conn.Reply(m, sig, vals...); err != nil {
+		return &Error{"org.freedesktop.DBus.Error.Failed", err.Error()}
+	}
+	return nil
+}
+
+// appInfo returns the info of the application object
+func (br *Bridge) appInfo() *objInfo {
+	br.mu.Lock()
+	trees := br.Trees
+	oi := &objInfo{name: br.AppName, role: RoleApplication, index: -1}
+	oi.parent = br.Desktop
+	br.mu.Unlock()
+	if oi.parent == nil {
+		oi.parent = br.ref(NullPath)
+	}
+	for ti, at := range trees {
+		at.Mu.RLock()
+		if at.Root != nil {
+			oi.kids = append(oi.kids, br.ref(br.nodePath(ti, at.Root)))
+		}
+		at.Mu.RUnlock()
+	}
+	oi.ifaces = []string{IfaceAccessible, IfaceApplication}
+	return oi
+}
+
+// nodeInfo returns the info of given node
+func (br *Bridge) nodeInfo(ti int, at *gi.AccessTree, an *gi.AccessNode) *objInfo {
+	ai := &an.Info
+	oi := &objInfo{name: ai.Label, desc: ai.Description, role: Roles[ai.Role], states: StateSet(ai)}
+	if an.Parent == nil {
+		oi.parent = br.ref(RootPath)
+		oi.index = int32(ti)
+	} else {
+		oi.parent = br.ref(br.nodePath(ti, an.Parent))
+		oi.index = int32(an.IndexInParent())
+	}
+	for _, k := range an.Kids {
+		oi.kids = append(oi.kids, br.ref(br.nodePath(ti, k)))
+	}
+	for _, rel := range ai.Relations {
+		if rel.Target == nil {
+			continue
+		}
+		tn := at.NodeFor(rel.Target)
+		if tn == nil || tn.Node.This() != rel.Target.This() {
+			continue
+		}
+		oi.relations = append(oi.relations, Struct{Relations[rel.Type], []interface{}{br.ref(br.nodePath(ti, tn))}})
+	}
+	oi.ifaces = nodeIfaces(ai)
+	return oi
+}
+
+// nodeIfaces returns the interfaces implemented by a node with given info
+func nodeIfaces(ai *gi.AccessInfo) []string {
+	ifs := []string{IfaceAccessible, IfaceComponent}
+	if len(ai.Actions) > 0 {
+		ifs = append(ifs, IfaceAction)
+	}
+	if ai.HasRange {
+		ifs = append(ifs, IfaceValue)
+	}
+	if hasText(ai) {
+		ifs = append(ifs, IfaceText)
+	}
+	return ifs
+}
+
+// hasText returns true if the node has the Text interface
+func hasText(ai *gi.AccessInfo) bool {
+	switch ai.Role {
+	case gi.AccessLabel, gi.AccessTextField, gi.AccessPasswordField, gi.AccessTextArea:
+		return true
+	}
+	return false
+}
+
+// nodeText returns the text of the node for the Text interface
+func nodeText(ai *gi.AccessInfo) []rune {
+	if ai.Role == gi.AccessLabel {
+		return []rune(ai.Label)
+	}
+	return []rune(ai.Value)
+}
+
+// callApp handles a method call on the application object
+func (br *Bridge) callApp(m *Message) *Error {
+	oi := br.appInfo()
+	props := func(iface string) []DictEntry {
+		switch iface {
+		case IfaceApplication:
+			br.mu.Lock()
+			id := br.AppID
+			br.mu.Unlock()
+			return []DictEntry{
+				{"ToolkitName", MakeVariant("s", "GoGi")},
+				{"Version", MakeVariant("s", gi.Version)},
+				{"AtspiVersion", MakeVariant("s", "2.1")},
+				{"Id", MakeVariant("i", id)},
+			}
+		case IfaceAccessible:
+			return accessibleProps(oi)
+		}
+		return nil
+	}
+	switch m.Interface {
+	case IfaceProperties:
+		if m.Member == "Set" {
+			if err := args(m, "ssv"); err != nil {
+				return err
+			}
+			if m.Body[0] != IfaceApplication || m.Body[1] != "Id" {
+				return &Error{ErrNotSupported, "property cannot be set"}
+			}
+			id, _ := m.Body[2].(Variant).Value.(int32)
+			br.mu.Lock()
+			br.AppID = id
+			br.mu.Unlock()
+			return br.reply(m, "")
+		}
+		return br.callProps(m, props)
+	case IfaceApplication:
+		if m.Member == "GetLocale" {
+			return br.reply(m, "s", locale())
+		}
+	case IfaceAccessible:
+		return br.callAccessible(m, oi)
+	}
+	return unknownMethod(m)
+}
+
+// callNode handles a method call on a node -- the tree is read-locked
+func (br *Bridge) callNode(m *Message, ti int, at *gi.AccessTree, an *gi.AccessNode) *Error {
+	ai := &an.Info
+	oi := br.nodeInfo(ti, at, an)
+	props := func(iface string) []DictEntry {
+		switch iface {
+		case IfaceAccessible:
+			return accessibleProps(oi)
+		case IfaceAction:
+			if len(ai.Actions) > 0 {
+				return []DictEntry{{"NActions", MakeVariant("i", len(ai.Actions))}}
+			}
+		case IfaceValue:
+			if ai.HasRange {
+				return []DictEntry{
+					{"MinimumValue", MakeVariant("d", ai.Min)},
+					{"MaximumValue", MakeVariant("d", ai.Max)},
+					{"MinimumIncrement", MakeVariant("d", ai.Step)},
+					{"CurrentValue", MakeVariant("d", ai.Cur)},
+					{"Text", MakeVariant("s", ai.Value)},
+				}
+			}
+		case IfaceText:
+			if hasText(ai) {
+				return []DictEntry{
+					{"CharacterCount", MakeVariant("i", len(nodeText(ai)))},
+					{"CaretOffset", MakeVariant("i", 0)},
+				}
+			}
+		}
+		return nil
+	}
+	switch m.Interface {
+	case IfaceProperties:
+		if m.Member == "Set" {
+			return &Error{ErrNotSupported, "property cannot be set"}
+		}
+		return br.callProps(m, props)
+	case IfaceAccessible:
+		return br.callAccessible(m, oi)
+	case IfaceComponent:
+		return br.callComponent(m, ti, at, an)
+	case IfaceAction:
+		if len(ai.Actions) == 0 {
+			break
+		}
+		if m.Member == "GetActions" {
+			var acts []interface{}
+			for i, a := range ai.Actions {
+				acts = append(acts, Struct{a, a, actionKey(ai, i)})
+			}
+			return br.reply(m, "a(sss)", acts)
+		}
+		if err := args(m, "i"); err != nil {
+			return err
+		}
+		i := int(m.Body[0].(int32))
+		if i < 0 || i >= len(ai.Actions) {
+			return &Error{ErrInvalidArgs, "action index out of range"}
+		}
+		switch m.Member {
+		case "GetName", "GetLocalizedName", "GetDescription":
+			return br.reply(m, "s", ai.Actions[i])
+		case "GetKeyBinding":
+			return br.reply(m, "s", actionKey(ai, i))
+		}
+	case IfaceText:
+		if !hasText(ai) {
+			break
+		}
+		if m.Member == "GetText" {
+			if err := args(m, "ii"); err != nil {
+				return err
+			}
+			txt := nodeText(ai)
+			st, ed := int(m.Body[0].(int32)), int(m.Body[1].(int32))
+			if ed < 0 || ed > len(txt) {
+				ed = len(txt)
+			}
+			if st < 0 {
+				st = 0
+			}
+			if st > ed {
+				st = ed
+			}
+			return br.reply(m, "s", string(txt[st:ed]))
+		}
+	}
+	return unknownMethod(m)
+}
+
+// actionKey returns the key binding of action i -- the shortcut is for the
+// first action
+func actionKey(ai *gi.AccessInfo, i int) string {
+	if i == 0 {
+		return ai.Shortcut
+	}
+	return ""
+}
+
+// accessibleProps returns the properties of the Accessible interface
+func accessibleProps(oi *objInfo) []DictEntry {
+	return []DictEntry{
+		{"Name", MakeVariant("s", oi.name)},
+		{"Description", MakeVariant("s", oi.desc)},
+		{"Parent", MakeVariant("(so)", oi.parent)},
+		{"ChildCount", MakeVariant("i", len(oi.kids))},
+		{"Locale", MakeVariant("s", locale())},
+		{"AccessibleId", MakeVariant("s", "")},
+	}
+}
+
+// locale returns the locale of the application, from the environment
+func locale() string {
+	for _, ev := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if l := os.Getenv(ev); l != "" {
+			return l
+		}
+	}
+	return "C"
+}
+
+// unknownMethod returns the error for an unknown method
+func unknownMethod(m *Message) *Error {
+	return &Error{ErrUnknownMethod, fmt.Sprintf("unknown method %v.%v on %v", m.Interface, m.Member, m.Path)}
+}
+
+// callProps handles the methods of the Properties interface, given the
+// function returning the properties for an interface
+func (br *Bridge) callProps(m *Message, props func(iface string) []DictEntry) *Error {
+	switch m.Member {
+	case "Get":
+		if err := args(m, "ss"); err != nil {
+			return err
+		}
+		for _, de := range props(m.Body[0].(string)) {
+			if de.Key == m.Body[1] {
+				return br.reply(m, "v", de.Value)
+			}
+		}
+		return &Error{ErrInvalidArgs, fmt.Sprintf("unknown property %v.%v", m.Body[0], m.Body[1])}
+	case "GetAll":
+		if err := args(m, "s"); err != nil {
+			return err
+		}
+		return br.reply(m, "a{sv}", props(m.Body[0].(string)))
+	}
+	return unknownMethod(m)
+}
+
+// callAccessible handles the methods of the Accessible interface
+func (br *Bridge) callAccessible(m *Message, oi *objInfo) *Error {
+	switch m.Member {
+	case "GetChildAtIndex":
+		if err := args(m, "i"); err != nil {
+			return err
+		}
+		i := int(m.Body[0].(int32))
+		if i < 0 || i >= len(oi.kids) {
+			return br.reply(m, "(so)", br.ref(NullPath))
+		}
+		return br.reply(m, "(so)", oi.kids[i])
+	case "GetChildren":
+		return br.reply(m, "a(so)", oi.kids)
+	case "GetIndexInParent":
+		return br.reply(m, "i", oi.index)
+	case "GetRelationSet":
+		return br.reply(m, "a(ua(so))", oi.relations)
+	case "GetRole":
+		return br.reply(m, "u", oi.role)
+	case "GetRoleName", "GetLocalizedRoleName":
+		return br.reply(m, "s", RoleNames[oi.role])
+	case "GetState":
+		return br.reply(m, "au", []uint32{uint32(oi.states), uint32(oi.states >> 32)})
+	case "GetAttributes":
+		return br.reply(m, "a{ss}", map[string]string{"toolkit": "GoGi"})
+	case "GetApplication":
+		return br.reply(m, "(so)", br.ref(RootPath))
+	case "GetInterfaces":
+		return br.reply(m, "as", oi.ifaces)
+	}
+	return unknownMethod(m)
+}
+
+// coordOffset returns the offset to add to window coordinates for given
+// AT-SPI coordinate type: 0 = screen, 1 = window, 2 = parent
+func coordOffset(at *gi.AccessTree, an *gi.AccessNode, ctype uint32) image.Point {
+	switch ctype {
+	case 0:
+		if at.Win != nil && at.Win.OSWin != nil {
+			return at.Win.OSWin.Position()
+		}
+	case 2:
+		if an != nil && an.Parent != nil {
+			return an.Parent.WinBBox.Min.Mul(-1)
+		}
+	}
+	return image.ZP
+}
+
+// callComponent handles the methods of the Component interface, except
+// GrabFocus
+func (br *Bridge) callComponent(m *Message, ti int, at *gi.AccessTree, an *gi.AccessNode) *Error {
+	bb := an.WinBBox
+	switch m.Member {
+	case "Contains", "GetAccessibleAtPoint":
+		if err := args(m, "iiu"); err != nil {
+			return err
+		}
+		pt := image.Point{int(m.Body[0].(int32)), int(m.Body[1].(int32))}
+		pt = pt.Sub(coordOffset(at, nil, m.Body[2].(uint32)))
+		if m.Member == "Contains" {
+			return br.reply(m, "b", pt.In(bb))
+		}
+		if an.Parent == nil { // the root also has the popups, which can be outside
+			return br.reply(m, "(so)", br.ref(br.nodePath(ti, at.NodeAt(an, pt))))
+		}
+		kn := at.NodeAt(an, pt)
+		if kn == an {
+			kn = nil
+		}
+		return br.reply(m, "(so)", br.ref(br.nodePath(ti, kn)))
+	case "GetExtents", "GetPosition":
+		if err := args(m, "u"); err != nil {
+			return err
+		}
+		bb = bb.Add(coordOffset(at, an, m.Body[0].(uint32)))
+		if m.Member == "GetPosition" {
+			return br.reply(m, "ii", bb.Min.X, bb.Min.Y)
+		}
+		return br.reply(m, "(iiii)", Struct{bb.Min.X, bb.Min.Y, bb.Dx(), bb.Dy()})
+	case "GetSize":
+		return br.reply(m, "ii", bb.Dx(), bb.Dy())
+	case "GetLayer":
+		if an.Parent == nil {
+			return br.reply(m, "u", uint32(7)) // window
+		}
+		return br.reply(m, "u", uint32(3)) // widget
+	case "GetMDIZOrder":
+		return br.reply(m, "n", int16(0))
+	case "GetAlpha":
+		return br.reply(m, "d", 1.0)
+	}
+	return unknownMethod(m)
+}
+
+// callAction handles DoAction and GrabFocus, which are done without the
+// lock on the tree, and on the window goroutine (via RunOnWin) if the tree has
+// a window, as actions can open menus and dialogs -- the reply is then sent
+// from there
+func (br *Bridge) callAction(m *Message, at *gi.AccessTree, id int64) *Error {
+	at.Mu.RLock()
+	an := at.NodeByID(id)
+	var ni gi.Node2D
+	var act string
+	if an != nil {
+		ni = an.Node
+		if m.Member == "DoAction" && args(m, "i") == nil {
+			i := int(m.Body[0].(int32))
+			if i >= 0 && i < len(an.Info.Actions) {
+				act = an.Info.Actions[i]
+			}
+		}
+	}
+	win := at.Win
+	at.Mu.RUnlock()
+	if an == nil {
+		return &Error{ErrUnknownObject, "unknown object: " + string(m.Path)}
+	}
+	if m.Member != "GrabFocus" {
+		if err := args(m, "i"); err != nil {
+			return err
+		}
+		if act == "" {
+			return br.reply(m, "b", false)
+		}
+	}
+	do := func() bool {
+		if m.Member == "GrabFocus" {
+			nb := ni.AsNode2D()
+			if !nb.CanFocus() {
+				return false
+			}
+			nb.GrabFocus()
+			return true
+		}
+		return ni.AccessAction2D(act)
+	}
+	if win == nil {
+		return br.reply(m, "b", do())
+	}
+	if win.IsClosed() {
+		return br.reply(m, "b", false)
+	}
+	win.RunOnWin(func() {
+		if err := br.reply(m, "b", do()); err != nil {
+			br.Conn.ReplyError(m, err.Name, err.Message)
+		}
+	})
+	return nil
+}
+
+// introspect replies to an Introspect call, listing the interfaces of the
+// object
+func (br *Bridge) introspect(m *Message) *Error {
+	var ifs []string
+	if m.Path == RootPath {
+		ifs = br.appInfo().ifaces
+	} else if ti, id, ok := parsePath(m.Path); ok {
+		if at := br.tree(ti); at != nil {
+			at.Mu.RLock()
+			if an := at.NodeByID(id); an != nil {
+				ifs = nodeIfaces(&an.Info)
+			}
+			at.Mu.RUnlock()
+		}
+	}
+	var sb strings.Builder
+	sb.WriteString("<!DOCTYPE node PUBLIC \"-//freedesktop//DTD D-BUS Object Introspection 1.0//EN\"\n\"http://www.freedesktop.org/standards/dbus/1.0/introspect.dtd\">\n<node>\n")
+	for _, i := range append(ifs, IfaceProperties, IfaceIntrospect) {
+		sb.WriteString("  <interface name=\"" + i + "\"/>\n")
+	}
+	sb.WriteString("</node>\n")
+	return br.reply(m, "s", sb.String())
+}
+
+////////////////////////////////////////////////////////////////////////////////////////
+// Events
+
+// emitObject emits an Object event from given object
+func (br *Bridge) emitObject(path ObjectPath, member, detail string, d1, d2 int, data Variant) {
+	br.Conn.Emit(path, IfaceEventObject, member, "siiva{sv}", detail, d1, d2, data, []DictEntry(nil))
+}
+
+// TreeEvent sends the AT-SPI events for a signal from given tree -- it is
+// called for the AccessSig signals of the trees
+func (br *Bridge) TreeEvent(at *gi.AccessTree, sig gi.AccessTreeSignals, an *gi.AccessNode) {
+	ti := br.treeIndex(at)
+	if ti < 0 || br.Conn.IsClosed() {
+		return
+	}
+	at.Mu.RLock()
+	defer at.Mu.RUnlock()
+	parPath := func() ObjectPath {
+		if an.Parent == nil {
+			return RootPath
+		}
+		return br.nodePath(ti, an.Parent)
+	}
+	switch sig {
+	case gi.AccessNodeAdded:
+		br.mu.Lock()
+		br.prev[an] = prevInfo{an.Info, StateSet(&an.Info)}
+		br.mu.Unlock()
+		br.emitObject(parPath(), "ChildrenChanged", "add", an.IndexInParent(), 0, MakeVariant("(so)", br.ref(br.nodePath(ti, an))))
+	case gi.AccessNodeRemoved:
+		br.mu.Lock()
+		delete(br.prev, an)
+		br.mu.Unlock()
+		br.emitObject(parPath(), "ChildrenChanged", "remove", -1, 0, MakeVariant("(so)", br.ref(br.nodePath(ti, an))))
+	case gi.AccessNodeChanged:
+		br.nodeChanged(ti, an)
+	case gi.AccessFocusChanged:
+		if an != nil {
+			br.Conn.Emit(br.nodePath(ti, an), IfaceEventFocus, "Focus", "siiva{sv}", "", 0, 0, MakeVariant("i", 0), []DictEntry(nil))
+		}
+	}
+	// AccessChildrenChanged needs nothing more: the added and removed nodes
+	// have their own events
+}
+
+// nodeChanged sends the events for the changes in the info of a node since
+// the last events sent for it
+func (br *Bridge) nodeChanged(ti int, an *gi.AccessNode) {
+	ai := &an.Info
+	st := StateSet(ai)
+	br.mu.Lock()
+	pi := br.prev[an]
+	br.prev[an] = prevInfo{*ai, st}
+	br.mu.Unlock()
+	path := br.nodePath(ti, an)
+	if pi.info.Label != ai.Label {
+		br.emitObject(path, "PropertyChange", "accessible-name", 0, 0, MakeVariant("s", ai.Label))
+	}
+	if pi.info.Description != ai.Description {
+		br.emitObject(path, "PropertyChange", "accessible-description", 0, 0, MakeVariant("s", ai.Description))
+	}
+	if pi.info.Role != ai.Role {
+		br.emitObject(path, "PropertyChange", "accessible-role", 0, 0, MakeVariant("u", Roles[ai.Role]))
+	}
+	if ai.HasRange && pi.info.Cur != ai.Cur {
+		br.emitObject(path, "PropertyChange", "accessible-value", 0, 0, MakeVariant("d", ai.Cur))
+	} else if !ai.HasRange && pi.info.Value != ai.Value {
+		br.emitObject(path, "PropertyChange", "accessible-value", 0, 0, MakeVariant("s", ai.Value))
+	}
+	if diff := st ^ pi.states; diff != 0 {
+		for b := uint(0); b < 64; b++ {
+			if diff&(1<<b) == 0 {
+				continue
+			}
+			nm, ok := StateNames[b]
+			if !ok {
+				continue
+			}
+			on := 0
+			if st&(1<<b) != 0 {
+				on = 1
+			}
+			br.emitObject(path, "StateChanged", nm, on, 0, MakeVariant("i", 0))
+		}
+	}
+}
@@ -0,0 +1,1023 @@
+// Copyright (c) 2018, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package atspi
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// This file has a minimal D-Bus client: just what is needed to connect to a
+// bus, call methods, receive method calls and send signals, with the
+// standard wire encoding of values given by their signatures.
+
+// ObjectPath is a D-Bus object path
+type ObjectPath string
+
+// Signature is a D-Bus type signature
+type Signature string
+
+// Variant is a D-Bus variant: a value with its signature
+type Variant struct {
+	Sig   Signature
+	Value interface{}
+}
+
+// MakeVariant returns a variant for given signature and value
+func MakeVariant(sig string, val interface{}) Variant {
+	return Variant{Sig: Signature(sig), Value: val}
+}
+
+// Struct is a D-Bus struct -- the values of its fields in order
+type Struct []interface{}
+
+// DictEntry is an entry of a D-Bus dict -- an array of dict entries
+type DictEntry struct {
+	Key   interface{}
+	Value interface{}
+}
+
+// MsgTypes are the types of D-Bus messages
+type MsgTypes byte
+
+const (
+	MsgInvalid MsgTypes = iota
+	MsgMethodCall
+	MsgMethodReturn
+	MsgError
+	MsgSignal
+)
+
+// MsgNoReplyExpected is the message flag for method calls that do not
+// expect a reply
+const MsgNoReplyExpected = 0x1
+
+// header field codes
+const (
+	fieldPath        = 1
+	fieldInterface   = 2
+	fieldMember      = 3
+	fieldErrorName   = 4
+	fieldReplySerial = 5
+	fieldDestination = 6
+	fieldSender      = 7
+	fieldSignature   = 8
+)
+
+// Message is a D-Bus message
+type Message struct {
+	Type        MsgTypes
+	Flags       byte
+	Serial      uint32
+	Path        ObjectPath
+	Interface   string
+	Member      string
+	ErrorName   string
+	ReplySerial uint32
+	Destination string
+	Sender      string
+	Signature   Signature
+	Body        []interface{}
+}
+
+// Error is an error returned by a D-Bus method call
+type Error struct {
+	Name    string
+	Message string
+}
+
+func (e *Error) Error() string {
+	if e.Message == "" {
+		return e.Name
+	}
+	return e.Name + ": " + e.Message
+}
+
+////////////////////////////////////////////////////////////////////////////////////////
+// Encoding
+
+// encoder encodes values in little-endian wire format, with alignment
+// relative to the start of the message
+type encoder struct {
+	buf    bytes.Buffer
+	offset int
+}
+
+func (e *encoder) pad(align int) {
+	for (e.offset+e.buf.Len())%align != 0 {
+		e.buf.WriteByte(0)
+	}
+}
+
+func (e *encoder) u32(v uint32) {
+	e.pad(4)
+	var b [4]byte
+	binary.LittleEndian.PutUint32(b[:], v)
+	e.buf.Write(b[:])
+}
+
+func (e *encoder) u64(v uint64) {
+	e.pad(8)
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], v)
+	e.buf.Write(b[:])
+}
+
+func (e *encoder) str(s string) {
+	e.u32(uint32(len(s)))
+	e.buf.WriteString(s)
+	e.buf.WriteByte(0)
+}
+
+func (e *encoder) sig(s string) {
+	e.buf.WriteByte(byte(len(s)))
+	e.buf.WriteString(s)
+	e.buf.WriteByte(0)
+}
+
+// nextSig splits the first complete type off the signature
+func nextSig(sig string) (string, string, error) {
+	if sig == "" {
+		return "", "", errors.New("dbus: empty signature")
+	}
+	switch sig[0] {
+	case 'a':
+		el, rest, err := nextSig(sig[1:])
+		if err != nil {
+			return "", "", err
+		}
+		return "a" + el, rest, nil
+	case '(', '{':
+		close := byte(')')
+		if sig[0] == '{' {
+			close = '}'
+		}
+		rest := sig[1:]
+		n := 1
+		for rest != "" && rest[0] != close {
+			_, r, err := nextSig(rest)
+			if err != nil {
+				return "", "", err
+			}
+			n += len(rest) - len(r)
+			rest = r
+		}
+		if rest == "" {
+			return "", "", fmt.Errorf("dbus: unterminated signature: %v", sig)
+		}
+		return sig[:n+1], rest[1:], nil
+	case 'y', 'b', 'n', 'q', 'i', 'u', 'x', 't', 'd', 's', 'o', 'g', 'v', 'h':
+		return sig[:1], sig[1:], nil
+	}
+	return "", "", fmt.Errorf("dbus: invalid signature: %v", sig)
+}
+
+// splitSig splits a signature into its complete types
+func splitSig(sig string) ([]string, error) {
+	var sigs []string
+	for sig != "" {
+		s, rest, err := nextSig(sig)
+		if err != nil {
+			return nil, err
+		}
+		sigs = append(sigs, s)
+		sig = rest
+	}
+	return sigs, nil
+}
+
+// alignOf returns the alignment of the type with given signature
+func alignOf(sig string) int {
+	switch sig[0] {
+	case 'n', 'q':
+		return 2
+	case 'b', 'i', 'u', 's', 'o', 'a', 'h':
+		return 4
+	case 'x', 't', 'd', '(', '{':
+		return 8
+	}
+	return 1
+}
+
+// toInt64 converts any integer value to int64
+func toInt64(v interface{}) (int64, error) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return int64(rv.Uint()), nil
+	}
+	return 0, fmt.Errorf("dbus: value %v of type %T is not an integer", v, v)
+}
+
+// encode encodes a value with given (single complete type) signature
+func (e *encoder) encode(sig string, v interface{}) error {
+	switch sig[0] {
+	case 'y':
+		n, err := toInt64(v)
+		if err != nil {
+			return err
+		}
+		e.buf.WriteByte(byte(n))
+	case 'b':
+		b, ok := v.(bool)
+		if !ok {
+			return fmt.Errorf("dbus: value %v of type %T is not a bool", v, v)
+		}
+		if b {
+			e.u32(1)
+		} else {
+			e.u32(0)
+		}
+	case 'n', 'q':
+		n, err := toInt64(v)
+		if err != nil {
+			return err
+		}
+		e.pad(2)
+		var b [2]byte
+		binary.LittleEndian.PutUint16(b[:], uint16(n))
+		e.buf.Write(b[:])
+	case 'i', 'u', 'h':
+		n, err := toInt64(v)
+		if err != nil {
+			return err
+		}
+		e.u32(uint32(n))
+	case 'x', 't':
+		n, err := toInt64(v)
+		if err != nil {
+			return err
+		}
+		e.u64(uint64(n))
+	case 'd':
+		f := reflect.ValueOf(v)
+		if f.Kind() != reflect.Float64 && f.Kind() != reflect.Float32 {
+			return fmt.Errorf("dbus: value %v of type %T is not a float", v, v)
+		}
+		e.u64(math.Float64bits(f.Float()))
+	case 's', 'o':
+		s := reflect.ValueOf(v)
+		if s.Kind() != reflect.String {
+			return fmt.Errorf("dbus: value %v of type %T is not a string", v, v)
+		}
+		e.str(s.String())
+	case 'g':
+		s := reflect.ValueOf(v)
+		if s.Kind() != reflect.String {
+			return fmt.Errorf("dbus: value %v of type %T is not a signature", v, v)
+		}
+		e.sig(s.String())
+	case 'v':
+		vr, ok := v.(Variant)
+		if !ok {
+			return fmt.Errorf("dbus: value %v of type %T is not a Variant", v, v)
+		}
+		e.sig(string(vr.Sig))
+		return e.encode(string(vr.Sig), vr.Value)
+	case '(':
+		st, ok := v.(Struct)
+		if !ok {
+			return fmt.Errorf("dbus: value %v of type %T is not a Struct", v, v)
+		}
+		sigs, err := splitSig(sig[1 : len(sig)-1])
+		if err != nil {
+			return err
+		}
+		if len(sigs) != len(st) {
+			return fmt.Errorf("dbus: struct %v does not match signature %v", st, sig)
+		}
+		e.pad(8)
+		for i, s := range sigs {
+			if err := e.encode(s, st[i]); err != nil {
+				return err
+			}
+		}
+	case 'a':
+		return e.encodeArray(sig, v)
+	default:
+		return fmt.Errorf("dbus: cannot encode signature %v", sig)
+	}
+	return nil
+}
+
+// encodeArray encodes a slice, or a map or slice of DictEntry for dicts
+func (e *encoder) encodeArray(sig string, v interface{}) error {
+	el := sig[1:]
+	e.pad(4)
+	lenPos := e.buf.Len()
+	e.buf.Write([]byte{0, 0, 0, 0})
+	e.pad(alignOf(el))
+	start := e.buf.Len()
+	rv := reflect.ValueOf(v)
+	if el[0] == '{' {
+		sigs, err := splitSig(el[1 : len(el)-1])
+		if err != nil {
+			return err
+		}
+		if len(sigs) != 2 {
+			return fmt.Errorf("dbus: invalid dict signature %v", sig)
+		}
+		entry := func(k, v interface{}) error {
+			e.pad(8)
+			if err := e.encode(sigs[0], k); err != nil {
+				return err
+			}
+			return e.encode(sigs[1], v)
+		}
+		switch {
+		case rv.Kind() == reflect.Map:
+			for _, k := range rv.MapKeys() {
+				if err := entry(k.Interface(), rv.MapIndex(k).Interface()); err != nil {
+					return err
+				}
+			}
+		case rv.Kind() == reflect.Slice:
+			for i := 0; i < rv.Len(); i++ {
+				de, ok := rv.Index(i).Interface().(DictEntry)
+				if !ok {
+					return fmt.Errorf("dbus: dict element is not a DictEntry: %v", rv.Index(i))
+				}
+				if err := entry(de.Key, de.Value); err != nil {
+					return err
+				}
+			}
+		case v == nil:
+		default:
+			return fmt.Errorf("dbus: value of type %T is not a dict", v)
+		}
+	} else {
+		switch {
+		case rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array:
+			for i := 0; i < rv.Len(); i++ {
+				if err := e.encode(el, rv.Index(i).Interface()); err != nil {
+					return err
+				}
+			}
+		case v == nil:
+		default:
+			return fmt.Errorf("dbus: value of type %T is not an array", v)
+		}
+	}
+	binary.LittleEndian.PutUint32(e.buf.Bytes()[lenPos:], uint32(e.buf.Len()-start))
+	return nil
+}
+
+// Marshal returns the wire encoding of the message
+func (m *Message) Marshal() ([]byte, error) {
+	body := &encoder{}
+	if m.Signature != "" {
+		sigs, err := splitSig(string(m.Signature))
+		if err != nil {
+			return nil, err
+		}
+		if len(sigs) != len(m.Body) {
+			return nil, fmt.Errorf("dbus: body %v does not match signature %v", m.Body, m.Signature)
+		}
+		for i, s := range sigs {
+			if err := body.encode(s, m.Body[i]); err != nil {
+				return nil, err
+			}
+		}
+	}
+	var fields []interface{}
+	field := func(code byte, sig string, val interface{}) {
+		fields = append(fields, Struct{code, MakeVariant(sig, val)})
+	}
+	if m.Path != "" {
+		field(fieldPath, "o", m.Path)
+	}
+	if m.Interface != "" {
+		field(fieldInterface, "s", m.Interface)
+	}
+	if m.Member != "" {
+		field(fieldMember, "s", m.Member)
+	}
+	if m.ErrorName != "" {
+		field(fieldErrorName, "s", m.ErrorName)
+	}
+	if m.ReplySerial != 0 {
+		field(fieldReplySerial, "u", m.ReplySerial)
+	}
+	if m.Destination != "" {
+		field(fieldDestination, "s", m.Destination)
+	}
+	if m.Sender != "" {
+		field(fieldSender, "s", m.Sender)
+	}
+	if m.Signature != "" {
+		field(fieldSignature, "g", m.Signature)
+	}
+	hdr := &encoder{}
+	hdr.buf.Write([]byte{'l', byte(m.Type), m.Flags, 1})
+	hdr.u32(uint32(body.buf.Len()))
+	hdr.u32(m.Serial)
+	if err := hdr.encode("a(yv)", fields); err != nil {
+		return nil, err
+	}
+	hdr.pad(8)
+	hdr.buf.Write(body.buf.Bytes())
+	return hdr.buf.Bytes(), nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////
+// Decoding
+
+// decoder decodes values in wire format with given byte order
+type decoder struct {
+	data  []byte
+	pos   int
+	order binary.ByteOrder
+}
+
+var errShort = errors.New("dbus: message too short")
+
+func (d *decoder) align(a int) error {
+	for d.pos%a != 0 {
+		d.pos++
+	}
+	if d.pos > len(d.data) {
+		return errShort
+	}
+	return nil
+}
+
+func (d *decoder) take(n int) ([]byte, error) {
+	if d.pos+n > len(d.data) {
+		return nil, errShort
+	}
+	b := d.data[d.pos : d.pos+n]
+	d.pos += n
+	return b, nil
+}
+
+func (d *decoder) u32() (uint32, error) {
+	if err := d.align(4); err != nil {
+		return 0, err
+	}
+	b, err := d.take(4)
+	if err != nil {
+		return 0, err
+	}
+	return d.order.Uint32(b), nil
+}
+
+func (d *decoder) u64() (uint64, error) {
+	if err := d.align(8); err != nil {
+		return 0, err
+	}
+	b, err := d.take(8)
+	if err != nil {
+		return 0, err
+	}
+	return d.order.Uint64(b), nil
+}
+
+func (d *decoder) str() (string, error) {
+	n, err := d.u32()
+	if err != nil {
+		return "", err
+	}
+	b, err := d.take(int(n) + 1)
+	if err != nil {
+		return "", err
+	}
+	return string(b[:n]), nil
+}
+
+func (d *decoder) sig() (string, error) {
+	b, err := d.take(1)
+	if err != nil {
+		return "", err
+	}
+	s, err := d.take(int(b[0]) + 1)
+	if err != nil {
+		return "", err
+	}
+	return string(s[:b[0]]), nil
+}
+
+// decode decodes a value of given (single complete type) signature --
+// arrays are decoded as []interface{}, dicts as []DictEntry, structs as
+// Struct, and variants as Variant
+func (d *decoder) decode(sig string) (interface{}, error) {
+	switch sig[0] {
+	case 'y':
+		b, err := d.take(1)
+		if err != nil {
+			return nil, err
+		}
+		return b[0], nil
+	case 'b':
+		n, err := d.u32()
+		return n != 0, err
+	case 'n', 'q':
+		if err := d.align(2); err != nil {
+			return nil, err
+		}
+		b, err := d.take(2)
+		if err != nil {
+			return nil, err
+		}
+		if sig[0] == 'n' {
+			return int16(d.order.Uint16(b)), nil
+		}
+		return d.order.Uint16(b), nil
+	case 'i':
+		n, err := d.u32()
+		return int32(n), err
+	case 'u', 'h':
+		return d.u32()
+	case 'x':
+		n, err := d.u64()
+		return int64(n), err
+	case 't':
+		return d.u64()
+	case 'd':
+		n, err := d.u64()
+		return math.Float64frombits(n), err
+	case 's':
+		return d.str()
+	case 'o':
+		s, err := d.str()
+		return ObjectPath(s), err
+	case 'g':
+		s, err := d.sig()
+		return Signature(s), err
+	case 'v':
+		s, err := d.sig()
+		if err != nil {
+			return nil, err
+		}
+		if _, rest, err := nextSig(s); err != nil || rest != "" {
+			return nil, fmt.Errorf("dbus: invalid variant signature: %v", s)
+		}
+		v, err := d.decode(s)
+		return Variant{Sig: Signature(s), Value: v}, err
+	case '(':
+		sigs, err := splitSig(sig[1 : len(sig)-1])
+		if err != nil {
+			return nil, err
+		}
+		if err := d.align(8); err != nil {
+			return nil, err
+		}
+		st := make(Struct, len(sigs))
+		for i, s := range sigs {
+			if st[i], err = d.decode(s); err != nil {
+				return nil, err
+			}
+		}
+		return st, nil
+	case 'a':
+		n, err := d.u32()
+		if err != nil {
+			return nil, err
+		}
+		el := sig[1:]
+		if err := d.align(alignOf(el)); err != nil {
+			return nil, err
+		}
+		end := d.pos + int(n)
+		if end > len(d.data) {
+			return nil, errShort
+		}
+		if el[0] == '{' {
+			sigs, err := splitSig(el[1 : len(el)-1])
+			if err != nil || len(sigs) != 2 {
+				return nil, fmt.Errorf("dbus: invalid dict signature %v", sig)
+			}
+			var des []DictEntry
+			for d.pos < end {
+				if err := d.align(8); err != nil {
+					return nil, err
+				}
+				k, err := d.decode(sigs[0])
+				if err != nil {
+					return nil, err
+				}
+				v, err := d.decode(sigs[1])
+				if err != nil {
+					return nil, err
+				}
+				des = append(des, DictEntry{k, v})
+			}
+			return des, nil
+		}
+		var vals []interface{}
+		for d.pos < end {
+			v, err := d.decode(el)
+			if err != nil {
+				return nil, err
+			}
+			vals = append(vals, v)
+		}
+		return vals, nil
+	}
+	return nil, fmt.Errorf("dbus: cannot decode signature %v", sig)
+}
+
+// ReadMessage reads the next message from given reader
+func ReadMessage(r io.Reader) (*Message, error) {
+	fixed := make([]byte, 16)
+	if _, err := io.ReadFull(r, fixed); err != nil {
+		return nil, err
+	}
+	var order binary.ByteOrder
+	switch fixed[0] {
+	case 'l':
+		order = binary.LittleEndian
+	case 'B':
+		order = binary.BigEndian
+	default:
+		return nil, fmt.Errorf("dbus: invalid byte order %v", fixed[0])
+	}
+	blen := order.Uint32(fixed[4:])
+	flen := order.Uint32(fixed[12:])
+	hlen := 16 + int(flen)
+	hlen += (8 - hlen%8) % 8
+	if blen > 1<<27 || flen > 1<<26 {
+		return nil, errors.New("dbus: message too long")
+	}
+	data := make([]byte, hlen+int(blen))
+	copy(data, fixed)
+	if _, err := io.ReadFull(r, data[16:]); err != nil {
+		return nil, err
+	}
+	m := &Message{Type: MsgTypes(fixed[1]), Flags: fixed[2]}
+	m.Serial = order.Uint32(fixed[8:])
+	d := &decoder{data: data[:hlen], pos: 12, order: order}
+	fv, err := d.decode("a(yv)")
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range fv.([]interface{}) {
+		st := f.(Struct)
+		val := st[1].(Variant).Value
+		switch st[0].(byte) {
+		case fieldPath:
+			m.Path, _ = val.(ObjectPath)
+		case fieldInterface:
+			m.Interface, _ = val.(string)
+		case fieldMember:
+			m.Member, _ = val.(string)
+		case fieldErrorName:
+			m.ErrorName, _ = val.(string)
+		case fieldReplySerial:
+			m.ReplySerial, _ = val.(uint32)
+		case fieldDestination:
+			m.Destination, _ = val.(string)
+		case fieldSender:
+			m.Sender, _ = val.(string)
+		case fieldSignature:
+			m.Signature, _ = val.(Signature)
+		}
+	}
+	if m.Signature != "" {
+		sigs, err := splitSig(string(m.Signature))
+		if err != nil {
+			return nil, err
+		}
+		bd := &decoder{data: data[hlen:], order: order}
+		for _, s := range sigs {
+			v, err := bd.decode(s)
+			if err != nil {
+				return nil, err
+			}
+			m.Body = append(m.Body, v)
+		}
+	}
+	return m, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////
+// Conn
+
+// CallHandler handles method calls received on a connection, as set by
+// SetHandler -- it must send a reply with Reply or ReplyError unless the
+// call has the MsgNoReplyExpected flag.  It is called in a separate
+// goroutine from the one reading messages, one call at a time, so it can
+// make calls itself.
+type CallHandler func(c *Conn, m *Message)
+
+// SignalHandler handles signals received on a connection, for the match
+// rules added with AddMatch, as set by SetSignalHandler.  It is called in the goroutine reading
+// messages, so it must not block or make calls.
+type SignalHandler func(c *Conn, m *Message)
+
+// Conn is a connection to a D-Bus message bus
+type Conn struct {
+	Name    string `desc:"unique name of the connection on the bus"`
+	handler CallHandler
+	signal  SignalHandler
+	conn    net.Conn
+	rd      *bufio.Reader
+	wrMu    sync.Mutex
+	mu      sync.Mutex
+	serial  uint32
+	pending map[uint32]chan *Message
+	calls   chan *Message
+	closed  bool
+}
+
+// CallTimeout is the time to wait for the reply to a method call
+var CallTimeout = 10 * time.Second
+
+// SessionBusAddress returns the address of the session bus, from the
+// environment
+func SessionBusAddress() string {
+	return os.Getenv("DBUS_SESSION_BUS_ADDRESS")
+}
+
+// dialAddr dials one D-Bus server address, such as unix:path=/run/bus
+func dialAddr(addr string) (net.Conn, error) {
+	ci := strings.Index(addr, ":")
+	if ci < 0 {
+		return nil, fmt.Errorf("dbus: invalid address: %v", addr)
+	}
+	trans := addr[:ci]
+	kv := map[string]string{}
+	for _, p := range strings.Split(addr[ci+1:], ",") {
+		if ei := strings.Index(p, "="); ei > 0 {
+			kv[p[:ei]] = unescapeAddr(p[ei+1:])
+		}
+	}
+	switch trans {
+	case "unix":
+		switch {
+		case kv["path"] != "":
+			return net.Dial("unix", kv["path"])
+		case kv["abstract"] != "":
+			return net.Dial("unix", "@"+kv["abstract"])
+		}
+	case "tcp":
+		return net.Dial("tcp", net.JoinHostPort(kv["host"], kv["port"]))
+	}
+	return nil, fmt.Errorf("dbus: unsupported address: %v", addr)
+}
+
+// unescapeAddr unescapes the %xx escapes in address values
+func unescapeAddr(s string) string {
+	if !strings.Contains(s, "%") {
+		return s
+	}
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '%' && i+2 < len(s) {
+			if b, err := strconv.ParseUint(s[i+1:i+3], 16, 8); err == nil {
+				sb.WriteByte(byte(b))
+				i += 2
+				continue
+			}
+		}
+		sb.WriteByte(s[i])
+	}
+	return sb.String()
+}
+
+// Dial connects to the bus at given address (a ; separated list of server
+// addresses, which are tried in turn), authenticates, and registers with
+// the bus, getting a unique name
+func Dial(addr string) (*Conn, error) {
+	var nc net.Conn
+	var err error
+	for _, a := range strings.Split(addr, ";") {
+		if a == "" {
+			continue
+		}
+		if nc, err = dialAddr(a); err == nil {
+			break
+		}
+	}
+	if nc == nil {
+		if err == nil {
+			err = errors.New("dbus: no bus address")
+		}
+		return nil, err
+	}
+	c := &Conn{conn: nc, rd: bufio.NewReader(nc)}
+	c.pending = make(map[uint32]chan *Message)
+	c.calls = make(chan *Message, 64)
+	if err := c.auth(); err != nil {
+		nc.Close()
+		return nil, err
+	}
+	go c.readLoop()
+	go c.callLoop()
+	rep, err := c.Call("org.freedesktop.DBus", "/org/freedesktop/DBus", "org.freedesktop.DBus", "Hello", "")
+	if err != nil {
+		c.Close()
+		return nil, err
+	}
+	if len(rep) > 0 {
+		c.Name, _ = rep[0].(string)
+	}
+	return c, nil
+}
+
+// auth does the EXTERNAL authentication using our uid
+func (c *Conn) auth() error {
+	uid := hex.EncodeToString([]byte(strconv.Itoa(os.Getuid())))
+	if _, err := c.conn.Write([]byte("\x00AUTH EXTERNAL " + uid + "\r\n")); err != nil {
+		return err
+	}
+	ln, err := c.rd.ReadString('\n')
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(ln, "OK") {
+		return fmt.Errorf("dbus: authentication failed: %v", strings.TrimSpace(ln))
+	}
+	_, err = c.conn.Write([]byte("BEGIN\r\n"))
+	return err
+}
+
+// Close closes the connection
+func (c *Conn) Close() error {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return nil
+	}
+	c.closed = true
+	for s, ch := range c.pending {
+		close(ch)
+		delete(c.pending, s)
+	}
+	c.mu.Unlock()
+	return c.conn.Close()
+}
+
+// SetHandler sets the handler for the method calls received
+func (c *Conn) SetHandler(h CallHandler) {
+	c.mu.Lock()
+	c.handler = h
+	c.mu.Unlock()
+}
+
+// SetSignalHandler sets the handler for the signals received
+func (c *Conn) SetSignalHandler(h SignalHandler) {
+	c.mu.Lock()
+	c.signal = h
+	c.mu.Unlock()
+}
+
+// IsClosed returns true if the connection has been closed
+func (c *Conn) IsClosed() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.closed
+}
+
+// readLoop reads messages and dispatches them
+func (c *Conn) readLoop() {
+	for {
+		m, err := ReadMessage(c.rd)
+		if err != nil {
+			c.Close()
+			close(c.calls)
+			return
+		}
+		switch m.Type {
+		case MsgMethodReturn, MsgError:
+			c.mu.Lock()
+			ch, ok := c.pending[m.ReplySerial]
+			delete(c.pending, m.ReplySerial)
+			c.mu.Unlock()
+			if ok {
+				ch <- m
+			}
+		case MsgMethodCall:
+			c.calls <- m
+		case MsgSignal:
+			c.mu.Lock()
+			sh := c.signal
+			c.mu.Unlock()
+			if sh != nil {
+				sh(c, m)
+			}
+		}
+	}
+}
+
+// callLoop calls the Handler for each method call received
+func (c *Conn) callLoop() {
+	for m := range c.calls {
+		c.mu.Lock()
+		h := c.handler
+		c.mu.Unlock()
+		if h != nil {
+			h(c, m)
+		} else if m.Flags&MsgNoReplyExpected == 0 {
+			c.ReplyError(m, "org.freedesktop.DBus.Error.UnknownMethod", "no handler")
+		}
+	}
+}
+
+// Send sends given message, setting its serial number
+func (c *Conn) Send(m *Message) error {
+	return c.send(m, nil)
+}
+
+// send sends given message, registering the channel for its reply if
+// non-nil
+func (c *Conn) send(m *Message, reply chan *Message) error {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return errors.New("dbus: connection closed")
+	}
+	c.serial++
+	m.Serial = c.serial
+	if reply != nil {
+		c.pending[m.Serial] = reply
+	}
+	c.mu.Unlock()
+	b, err := m.Marshal()
+	if err == nil {
+		c.wrMu.Lock()
+		_, err = c.conn.Write(b)
+		c.wrMu.Unlock()
+	}
+	if err != nil && reply != nil {
+		c.mu.Lock()
+		delete(c.pending, m.Serial)
+		c.mu.Unlock()
+	}
+	return err
+}
+
+// Call calls a method, with the arguments encoded according to given
+// signature, and returns the body of the reply
+func (c *Conn) Call(dest string, path ObjectPath, iface, member, sig string, args ...interface{}) ([]interface{}, error) {
+	m := &Message{Type: MsgMethodCall, Destination: dest, Path: path, Interface: iface, Member: member, Signature: Signature(sig), Body: args}
+	ch := make(chan *Message, 1)
+	if err := c.send(m, ch); err != nil {
+		return nil, err
+	}
+	select {
+	case rep, ok := <-ch:
+		if !ok {
+			return nil, errors.New("dbus: connection closed")
+		}
+		if rep.Type == MsgError {
+			e := &Error{Name: rep.ErrorName}
+			if len(rep.Body) > 0 {
+				e.Message, _ = rep.Body[0].(string)
+			}
+			return nil, e
+		}
+		return rep.Body, nil
+	case <-time.After(CallTimeout):
+		c.mu.Lock()
+		delete(c.pending, m.Serial)
+		c.mu.Unlock()
+		return nil, fmt.Errorf("dbus: timeout calling %v.%v", iface, member)
+	}
+}
+
+// AddMatch adds a match rule for the signals to receive, such as
+// type='signal',interface='org.a11y.atspi.Event.Object'
+func (c *Conn) AddMatch(rule string) error {
+	_, err := c.Call("org.freedesktop.DBus", "/org/freedesktop/DBus", "org.freedesktop.DBus", "AddMatch", "s", rule)
+	return err
+}
+
+// Reply sends the reply to given method call, with the values encoded
+// according to given signature
+func (c *Conn) Reply(call *Message, sig string, vals ...interface{}) error {
+	if call.Flags&MsgNoReplyExpected != 0 {
+		return nil
+	}
+	return c.Send(&Message{Type: MsgMethodReturn, Flags: MsgNoReplyExpected, ReplySerial: call.Serial, Destination: call.Sender, Signature: Signature(sig), Body: vals})
+}
+
+// ReplyError sends an error reply to given method call
+func (c *Conn) ReplyError(call *Message, name, msg string) error {
+	if call.Flags&MsgNoReplyExpected != 0 {
+		return nil
+	}
+	return c.Send(&Message{Type: MsgError, Flags: MsgNoReplyExpected, ReplySerial: call.Serial, Destination: call.Sender, ErrorName: name, Signature: "s", Body: []interface{}{msg}})
+}
+
+// Emit sends a signal from given object, with the values encoded
+// according to given signature
+func (c *Conn) Emit(path ObjectPath, iface, member, sig string, vals ...interface{}) error {
+	return c.Send(&Message{Type: MsgSignal, Flags: MsgNoReplyExpected, Path: path, Interface: iface, Member: member, Signature: Signature(sig), Body: vals})
+}
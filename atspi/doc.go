@@ -0,0 +1,28 @@
+// Copyright (c) 2018, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package atspi provides a bridge from the gi.AccessTree of GoGi windows to the
+Linux AT-SPI2 accessibility bus, so that screen readers such as Orca can
+navigate and operate GoGi apps.
+
+Call Start with a window before starting its event loop, to enable its
+accessibility tree and register it on the accessibility bus of the desktop
+session:
+
+	vp.UpdateEndNoSig(updt)
+	if _, err := atspi.Start(win); err != nil {
+		log.Println(err) // no accessibility bus -- app still works
+	}
+	win.StartEventLoop()
+
+Each node of the tree is exported as an object implementing the Accessible
+and Component interfaces, and the Action, Value and Text interfaces as
+appropriate for its role, and changes to the tree are sent as AT-SPI events.
+
+The package includes a minimal D-Bus client (Conn), so it has no external
+dependencies, and a Bridge can be used with any bus, e.g., a private
+dbus-daemon for testing: use NewBridge with a Conn from Dial, and AddTree.
+*/
+package atspi
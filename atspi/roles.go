@@ -0,0 +1,273 @@
+// Copyright (c) 2018, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package atspi
+
+import "github.com/goki/gi/gi"
+
+// AT-SPI role numbers used here, from the AtspiRole enum
+const (
+	RoleInvalid      = 0
+	RoleCalendar     = 5
+	RoleCanvas       = 6
+	RoleCheckBox     = 7
+	RoleColorChooser = 9
+	RoleComboBox     = 11
+	RoleDateEditor   = 12
+	RoleDialog       = 16
+	RoleFileChooser  = 19
+	RoleFrame        = 23
+	RoleImage        = 27
+	RoleLabel        = 29
+	RoleList         = 31
+	RoleListItem     = 32
+	RoleMenu         = 33
+	RoleMenuBar      = 34
+	RoleMenuItem     = 35
+	RolePageTab      = 37
+	RolePageTabList  = 38
+	RolePanel        = 39
+	RolePasswordText = 40
+	RoleProgressBar  = 42
+	RolePushButton   = 43
+	RoleRadioButton  = 44
+	RoleScrollBar    = 48
+	RoleScrollPane   = 49
+	RoleSeparator    = 50
+	RoleSlider       = 51
+	RoleSpinButton   = 52
+	RoleSplitPane    = 53
+	RoleTable        = 55
+	RoleTableCell    = 56
+	RoleText         = 61
+	RoleToggleButton = 62
+	RoleToolBar      = 63
+	RoleToolTip      = 64
+	RoleTree         = 65
+	RoleApplication  = 75
+	RoleEntry        = 79
+	RoleChart        = 80
+	RoleForm         = 87
+	RoleTreeItem     = 91
+)
+
+// Roles maps the gi.AccessRoles to AT-SPI roles
+var Roles = [gi.AccessRolesN]uint32{
+	gi.AccessNone:          RoleInvalid,
+	gi.AccessWindow:        RoleFrame,
+	gi.AccessDialog:        RoleDialog,
+	gi.AccessPanel:         RolePanel,
+	gi.AccessForm:          RoleForm,
+	gi.AccessScrollPane:    RoleScrollPane,
+	gi.AccessSplitPane:     RoleSplitPane,
+	gi.AccessLabel:         RoleLabel,
+	gi.AccessImage:         RoleImage,
+	gi.AccessCanvas:        RoleCanvas,
+	gi.AccessChart:         RoleChart,
+	gi.AccessSeparator:     RoleSeparator,
+	gi.AccessButton:        RolePushButton,
+	gi.AccessToggleButton:  RoleToggleButton,
+	gi.AccessCheckBox:      RoleCheckBox,
+	gi.AccessRadioButton:   RoleRadioButton,
+	gi.AccessComboBox:      RoleComboBox,
+	gi.AccessTextField:     RoleEntry,
+	gi.AccessPasswordField: RolePasswordText,
+	gi.AccessTextArea:      RoleText,
+	gi.AccessSlider:        RoleSlider,
+	gi.AccessScrollBar:     RoleScrollBar,
+	gi.AccessSpinBox:       RoleSpinButton,
+	gi.AccessProgressBar:   RoleProgressBar,
+	gi.AccessMenuBar:       RoleMenuBar,
+	gi.AccessMenu:          RoleMenu,
+	gi.AccessMenuItem:      RoleMenuItem,
+	gi.AccessToolBar:       RoleToolBar,
+	gi.AccessToolTip:       RoleToolTip,
+	gi.AccessTabList:       RolePageTabList,
+	gi.AccessTab:           RolePageTab,
+	gi.AccessTreeView:      RoleTree,
+	gi.AccessTreeItem:      RoleTreeItem,
+	gi.AccessList:          RoleList,
+	gi.AccessListItem:      RoleListItem,
+	gi.AccessTable:         RoleTable,
+	gi.AccessTableCell:     RoleTableCell,
+	gi.AccessCalendar:      RoleCalendar,
+	gi.AccessDateEditor:    RoleDateEditor,
+	gi.AccessColorChooser:  RoleColorChooser,
+	gi.AccessFileChooser:   RoleFileChooser,
+}
+
+// RoleNames are the AT-SPI names of the roles
+var RoleNames = map[uint32]string{
+	RoleInvalid:      "invalid",
+	RoleCalendar:     "calendar",
+	RoleCanvas:       "canvas",
+	RoleCheckBox:     "check box",
+	RoleColorChooser: "color chooser",
+	RoleComboBox:     "combo box",
+	RoleDateEditor:   "date editor",
+	RoleDialog:       "dialog",
+	RoleFileChooser:  "file chooser",
+	RoleFrame:        "frame",
+	RoleImage:        "image",
+	RoleLabel:        "label",
+	RoleList:         "list",
+	RoleListItem:     "list item",
+	RoleMenu:         "menu",
+	RoleMenuBar:      "menu bar",
+	RoleMenuItem:     "menu item",
+	RolePageTab:      "page tab",
+	RolePageTabList:  "page tab list",
+	RolePanel:        "panel",
+	RolePasswordText: "password text",
+	RoleProgressBar:  "progress bar",
+	RolePushButton:   "push button",
+	RoleRadioButton:  "radio button",
+	RoleScrollBar:    "scroll bar",
+	RoleScrollPane:   "scroll pane",
+	RoleSeparator:    "separator",
+	RoleSlider:       "slider",
+	RoleSpinButton:   "spin button",
+	RoleSplitPane:    "split pane",
+	RoleTable:        "table",
+	RoleTableCell:    "table cell",
+	RoleText:         "text",
+	RoleToggleButton: "toggle button",
+	RoleToolBar:      "tool bar",
+	RoleToolTip:      "tool tip",
+	RoleTree:         "tree",
+	RoleApplication:  "application",
+	RoleEntry:        "entry",
+	RoleChart:        "chart",
+	RoleForm:         "form",
+	RoleTreeItem:     "tree item",
+}
+
+// AT-SPI state bits used here, from the AtspiStateType enum
+const (
+	StateActive       = 1
+	StateBusy         = 3
+	StateChecked      = 4
+	StateCollapsed    = 5
+	StateEditable     = 7
+	StateEnabled      = 8
+	StateExpandable   = 9
+	StateExpanded     = 10
+	StateFocusable    = 11
+	StateFocused      = 12
+	StateHasTooltip   = 13
+	StateHorizontal   = 14
+	StateModal        = 16
+	StateMultiLine    = 17
+	StatePressed      = 20
+	StateSelectable   = 22
+	StateSelected     = 23
+	StateSensitive    = 24
+	StateShowing      = 25
+	StateSingleLine   = 26
+	StateVertical     = 29
+	StateVisible      = 30
+	StateInvalidEntry = 36
+	StateCheckable    = 41
+	StateHasPopup     = 42
+)
+
+// StateNames are the AT-SPI names of the states, as used in the detail of
+// StateChanged events
+var StateNames = map[uint]string{
+	StateActive:       "active",
+	StateBusy:         "busy",
+	StateChecked:      "checked",
+	StateCollapsed:    "collapsed",
+	StateEditable:     "editable",
+	StateEnabled:      "enabled",
+	StateExpandable:   "expandable",
+	StateExpanded:     "expanded",
+	StateFocusable:    "focusable",
+	StateFocused:      "focused",
+	StateHasTooltip:   "has-tooltip",
+	StateHorizontal:   "horizontal",
+	StateModal:        "modal",
+	StateMultiLine:    "multi-line",
+	StatePressed:      "pressed",
+	StateSelectable:   "selectable",
+	StateSelected:     "selected",
+	StateSensitive:    "sensitive",
+	StateShowing:      "showing",
+	StateSingleLine:   "single-line",
+	StateVertical:     "vertical",
+	StateVisible:      "visible",
+	StateInvalidEntry: "invalid-entry",
+	StateCheckable:    "checkable",
+	StateHasPopup:     "has-popup",
+}
+
+// stateMap maps the gi.AccessStates that translate directly to AT-SPI states
+var stateMap = map[gi.AccessStates]uint{
+	gi.AccessFocusable:  StateFocusable,
+	gi.AccessFocused:    StateFocused,
+	gi.AccessSelectable: StateSelectable,
+	gi.AccessSelected:   StateSelected,
+	gi.AccessCheckable:  StateCheckable,
+	gi.AccessChecked:    StateChecked,
+	gi.AccessPressed:    StatePressed,
+	gi.AccessExpandable: StateExpandable,
+	gi.AccessEditable:   StateEditable,
+	gi.AccessHorizontal: StateHorizontal,
+	gi.AccessVertical:   StateVertical,
+	gi.AccessModal:      StateModal,
+	gi.AccessHasPopup:   StateHasPopup,
+	gi.AccessHasTooltip: StateHasTooltip,
+	gi.AccessInvalid:    StateInvalidEntry,
+	gi.AccessBusy:       StateBusy,
+}
+
+// StateSet returns the AT-SPI state set for given info, as bits in the
+// order of the AtspiStateType enum
+func StateSet(ai *gi.AccessInfo) uint64 {
+	var ss uint64
+	set := func(st uint) {
+		ss |= 1 << st
+	}
+	if ai.HasState(gi.AccessVisible) {
+		set(StateVisible)
+		set(StateShowing)
+	}
+	if !ai.HasState(gi.AccessInactive) {
+		set(StateEnabled)
+		set(StateSensitive)
+	}
+	for gs, st := range stateMap {
+		if ai.HasState(gs) {
+			set(st)
+		}
+	}
+	if ai.HasState(gi.AccessExpandable) && !ai.HasState(gi.AccessExpanded) {
+		set(StateCollapsed)
+	} else if ai.HasState(gi.AccessExpanded) {
+		set(StateExpanded)
+	}
+	switch ai.Role {
+	case gi.AccessTextField, gi.AccessPasswordField, gi.AccessTextArea, gi.AccessSpinBox, gi.AccessComboBox:
+		if ai.HasState(gi.AccessMultiLine) {
+			set(StateMultiLine)
+		} else {
+			set(StateSingleLine)
+		}
+	case gi.AccessWindow, gi.AccessDialog:
+		if ai.HasState(gi.AccessFocused) {
+			set(StateActive)
+		}
+	}
+	return ss
+}
+
+// Relations maps the gi.AccessRelations to AT-SPI relation types
+var Relations = [gi.AccessRelationsN]uint32{
+	gi.AccessLabelFor:      1,
+	gi.AccessLabelledBy:    2,
+	gi.AccessControllerFor: 3,
+	gi.AccessControlledBy:  4,
+	gi.AccessMemberOf:      5,
+	gi.AccessDescribedBy:   18,
+}
@@ -332,7 +332,7 @@ func (fb *FileBrowse) ConfigSplitView() {
 		ftfr := split.Child(0).(*gi.Frame)
 		ft := giv.AddNewFileTreeView(ftfr, "filetree")
 		fb.FilesView = ft
-		ft.SetRootNode(&fb.Files)
+		ft.SetTree(&fb.Files)
 
 		for i := 0; i < fb.NTextViews; i++ {
 			txly := split.Child(1 + i).(*gi.Layout)
@@ -349,16 +349,18 @@ func (fb *FileBrowse) ConfigSplitView() {
 			if data == nil {
 				return
 			}
-			tvn, _ := data.(ki.Ki).Embed(giv.KiT_FileTreeView).(*giv.FileTreeView)
 			fbb, _ := recv.Embed(KiT_FileBrowse).(*FileBrowse)
-			fn := tvn.SrcNode.Embed(giv.KiT_FileNode).(*giv.FileNode)
+			fn := giv.ItemFileNode(data)
+			if fn == nil {
+				return
+			}
 			switch sig {
 			case int64(giv.TreeViewSelected):
-				fbb.FileNodeSelected(fn, tvn)
+				fbb.FileNodeSelected(fn)
 			case int64(giv.TreeViewOpened):
-				fbb.FileNodeOpened(fn, tvn)
+				fbb.FileNodeOpened(fn)
 			case int64(giv.TreeViewClosed):
-				fbb.FileNodeClosed(fn, tvn)
+				fbb.FileNodeClosed(fn)
 			}
 		})
 		split.SetSplits(.2, .4, .4)
@@ -366,28 +368,19 @@ func (fb *FileBrowse) ConfigSplitView() {
 	}
 }
 
-func (fb *FileBrowse) FileNodeSelected(fn *giv.FileNode, tvn *giv.FileTreeView) {
+func (fb *FileBrowse) FileNodeSelected(fn *giv.FileNode) {
 }
 
-func (fb *FileBrowse) FileNodeOpened(fn *giv.FileNode, tvn *giv.FileTreeView) {
-	if fn.IsDir() {
-		if !fn.IsOpen() {
-			tvn.SetOpen()
-			fn.OpenDir()
-		}
-	} else {
+// FileNodeOpened views opened files -- directories are opened by the view
+func (fb *FileBrowse) FileNodeOpened(fn *giv.FileNode) {
+	if !fn.IsDir() {
 		fb.ViewFileNode(fn)
 		fn.SetOpen()
 		fn.UpdateNode()
 	}
 }
 
-func (fb *FileBrowse) FileNodeClosed(fn *giv.FileNode, tvn *giv.FileTreeView) {
-	if fn.IsDir() {
-		if fn.IsOpen() {
-			fn.CloseDir()
-		}
-	}
+func (fb *FileBrowse) FileNodeClosed(fn *giv.FileNode) {
 }
 
 func (fb *FileBrowse) Render2D() {
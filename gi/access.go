@@ -0,0 +1,427 @@
+// Copyright (c) 2018, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"html"
+	"strings"
+
+	"github.com/goki/ki/bitflag"
+	"github.com/goki/ki/ki"
+	"github.com/goki/ki/kit"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////
+// Accessibility
+
+// Accessibility information is provided by every Node2D via the AccessInfo2D
+// method, which fills in an AccessInfo with the role, label, description,
+// value, states, actions and relations of the node, for assistive
+// technology such as screen readers.  Actions are performed via
+// AccessAction2D.  The AccessTree of a Window mirrors the tree of nodes that
+// have a role, and sends signals when it changes, which are used by bridges
+// to platform accessibility APIs (e.g., the atspi package for Linux).
+
+// AccessRoles are the roles that nodes play for assistive technology --
+// these map onto the roles of the platform accessibility APIs
+type AccessRoles int32
+
+const (
+	// AccessNone means that the node is not exposed itself -- its children
+	// are exposed in its place.  This is the default for generic layouts
+	// and other purely structural elements.
+	AccessNone AccessRoles = iota
+
+	// AccessWindow is a top-level window
+	AccessWindow
+
+	// AccessDialog is a dialog
+	AccessDialog
+
+	// AccessPanel is a generic container that groups other elements
+	AccessPanel
+
+	// AccessForm is a container of labeled fields for editing values
+	AccessForm
+
+	// AccessScrollPane is a container that scrolls its contents
+	AccessScrollPane
+
+	// AccessSplitPane is a container with adjustable splits between elements
+	AccessSplitPane
+
+	// AccessLabel is static text
+	AccessLabel
+
+	// AccessImage is an image
+	AccessImage
+
+	// AccessCanvas is a custom drawing area
+	AccessCanvas
+
+	// AccessChart is a plot or other graphical display of data
+	AccessChart
+
+	// AccessSeparator separates groups of other elements
+	AccessSeparator
+
+	// AccessButton is a push button
+	AccessButton
+
+	// AccessToggleButton is a button with a checked state
+	AccessToggleButton
+
+	// AccessCheckBox is a check box
+	AccessCheckBox
+
+	// AccessRadioButton is one of a set of mutually-exclusive buttons
+	AccessRadioButton
+
+	// AccessComboBox selects one of a list of items
+	AccessComboBox
+
+	// AccessTextField is a single-line text editor
+	AccessTextField
+
+	// AccessPasswordField is a text field whose text is not shown
+	AccessPasswordField
+
+	// AccessTextArea is a multi-line text editor
+	AccessTextArea
+
+	// AccessSlider selects a value in a range
+	AccessSlider
+
+	// AccessScrollBar scrolls another element
+	AccessScrollBar
+
+	// AccessSpinBox edits a number with increment and decrement buttons
+	AccessSpinBox
+
+	// AccessProgressBar shows the progress of an operation
+	AccessProgressBar
+
+	// AccessMenuBar is a bar of menus
+	AccessMenuBar
+
+	// AccessMenu is a popup menu
+	AccessMenu
+
+	// AccessMenuItem is an item in a menu
+	AccessMenuItem
+
+	// AccessToolBar is a bar of actions
+	AccessToolBar
+
+	// AccessToolTip is a popup tooltip
+	AccessToolTip
+
+	// AccessTabList is a set of tabs each selecting a page
+	AccessTabList
+
+	// AccessTab is a tab selecting a page in a tab list
+	AccessTab
+
+	// AccessTreeView is a hierarchical list of items
+	AccessTreeView
+
+	// AccessTreeItem is an item in a tree
+	AccessTreeItem
+
+	// AccessList is a list of items
+	AccessList
+
+	// AccessListItem is an item in a list
+	AccessListItem
+
+	// AccessTable is a table of rows and columns
+	AccessTable
+
+	// AccessTableCell is a cell in a table
+	AccessTableCell
+
+	// AccessCalendar selects a date on a calendar
+	AccessCalendar
+
+	// AccessDateEditor edits a date and / or time
+	AccessDateEditor
+
+	// AccessColorChooser selects a color
+	AccessColorChooser
+
+	// AccessFileChooser selects a file
+	AccessFileChooser
+
+	AccessRolesN
+)
+
+//go:generate stringer -type=AccessRoles
+
+var KiT_AccessRoles = kit.Enums.AddEnumAltLower(AccessRolesN, kit.NotBitFlag, nil, "Access")
+
+func (ev AccessRoles) MarshalJSON() ([]byte, error)  { return kit.EnumMarshalJSON(ev) }
+func (ev *AccessRoles) UnmarshalJSON(b []byte) error { return kit.EnumUnmarshalJSON(ev, b) }
+
+// AccessStates are the states of nodes for assistive technology -- they
+// are bit flags stored in AccessInfo.States
+type AccessStates int32
+
+const (
+	// AccessVisible means the node is currently visible on the screen
+	AccessVisible AccessStates = iota
+
+	// AccessInactive means the node cannot currently be used
+	AccessInactive
+
+	// AccessFocusable means the node can get keyboard focus
+	AccessFocusable
+
+	// AccessFocused means the node has keyboard focus
+	AccessFocused
+
+	// AccessSelectable means the node can be selected
+	AccessSelectable
+
+	// AccessSelected means the node is selected
+	AccessSelected
+
+	// AccessCheckable means the node can be checked
+	AccessCheckable
+
+	// AccessChecked means the node is checked
+	AccessChecked
+
+	// AccessPressed means the node is pressed down
+	AccessPressed
+
+	// AccessExpandable means the node can be expanded to show more
+	// elements, such as a tree item with children
+	AccessExpandable
+
+	// AccessExpanded means the node is expanded
+	AccessExpanded
+
+	// AccessEditable means the value of the node can be edited
+	AccessEditable
+
+	// AccessMultiLine means the text of the node has multiple lines
+	AccessMultiLine
+
+	// AccessHorizontal means the node is oriented horizontally
+	AccessHorizontal
+
+	// AccessVertical means the node is oriented vertically
+	AccessVertical
+
+	// AccessModal means the node blocks input to other nodes
+	AccessModal
+
+	// AccessHasPopup means the node opens a popup menu
+	AccessHasPopup
+
+	// AccessHasTooltip means the node has a tooltip
+	AccessHasTooltip
+
+	// AccessInvalid means the value of the node is not valid
+	AccessInvalid
+
+	// AccessBusy means the node is busy, for example loading
+	AccessBusy
+
+	AccessStatesN
+)
+
+//go:generate stringer -type=AccessStates
+
+var KiT_AccessStates = kit.Enums.AddEnumAltLower(AccessStatesN, kit.BitFlag, nil, "Access")
+
+func (ev AccessStates) MarshalJSON() ([]byte, error)  { return kit.EnumMarshalJSON(ev) }
+func (ev *AccessStates) UnmarshalJSON(b []byte) error { return kit.EnumUnmarshalJSON(ev, b) }
+
+// AccessRelations are the kinds of relations between nodes for assistive
+// technology
+type AccessRelations int32
+
+const (
+	// AccessLabelFor means the node is the label for the target
+	AccessLabelFor AccessRelations = iota
+
+	// AccessLabelledBy means the node is labeled by the target
+	AccessLabelledBy
+
+	// AccessControllerFor means the node controls the target, for example
+	// a tab controls its page
+	AccessControllerFor
+
+	// AccessControlledBy means the node is controlled by the target
+	AccessControlledBy
+
+	// AccessMemberOf means the node is a member of the group of the target
+	AccessMemberOf
+
+	// AccessDescribedBy means the node is described by the target
+	AccessDescribedBy
+
+	AccessRelationsN
+)
+
+//go:generate stringer -type=AccessRelations
+
+var KiT_AccessRelations = kit.Enums.AddEnumAltLower(AccessRelationsN, kit.NotBitFlag, nil, "Access")
+
+func (ev AccessRelations) MarshalJSON() ([]byte, error)  { return kit.EnumMarshalJSON(ev) }
+func (ev *AccessRelations) UnmarshalJSON(b []byte) error { return kit.EnumUnmarshalJSON(ev, b) }
+
+// AccessRelation is a relation of a given type to a target node
+type AccessRelation struct {
+	Type   AccessRelations `desc:"type of relation"`
+	Target ki.Ki           `desc:"target node of the relation"`
+}
+
+// Standard names of accessibility actions, for AccessInfo.Actions and
+// AccessAction2D
+const (
+	// AccessActClick clicks a button or other element
+	AccessActClick = "click"
+
+	// AccessActToggle toggles the checked state
+	AccessActToggle = "toggle"
+
+	// AccessActSelect selects an item
+	AccessActSelect = "select"
+
+	// AccessActExpand expands an item
+	AccessActExpand = "expand"
+
+	// AccessActCollapse collapses an item
+	AccessActCollapse = "collapse"
+
+	// AccessActIncrement increments a value
+	AccessActIncrement = "increment"
+
+	// AccessActDecrement decrements a value
+	AccessActDecrement = "decrement"
+
+	// AccessActMenu shows the menu of an element
+	AccessActMenu = "menu"
+)
+
+// AccessInfo is the accessibility information for a node, filled in by its
+// AccessInfo2D method
+type AccessInfo struct {
+	Role        AccessRoles      `desc:"role of the node -- AccessNone nodes are not exposed themselves, only their children"`
+	Label       string           `desc:"accessible name of the node, such as the text of a button or a label"`
+	Description string           `desc:"longer description of the node, such as its tooltip"`
+	Value       string           `desc:"current value of the node as text, such as the text of a text field"`
+	HasRange    bool             `desc:"the node has a numerical value in a range, in Cur, Min, Max, Step"`
+	Cur         float64          `desc:"current numerical value, if HasRange"`
+	Min         float64          `desc:"minimum numerical value, if HasRange"`
+	Max         float64          `desc:"maximum numerical value, if HasRange"`
+	Step        float64          `desc:"step size of the numerical value, if HasRange"`
+	States      int64            `desc:"bit flags of the AccessStates of the node"`
+	Actions     []string         `desc:"names of actions that can be performed by AccessAction2D -- see the AccessAct* constants for standard names"`
+	Shortcut    string           `desc:"keyboard shortcut that performs the first action, if any"`
+	Relations   []AccessRelation `desc:"relations of the node to other nodes"`
+}
+
+// HasState returns true if given state is set
+func (ai *AccessInfo) HasState(st AccessStates) bool {
+	return bitflag.Has(ai.States, int(st))
+}
+
+// SetState sets given state on or off
+func (ai *AccessInfo) SetState(on bool, st AccessStates) {
+	bitflag.SetState(&ai.States, on, int(st))
+}
+
+// SetRange sets the numerical value and range of the node
+func (ai *AccessInfo) SetRange(cur, min, max, step float32) {
+	ai.HasRange = true
+	ai.Cur, ai.Min, ai.Max, ai.Step = float64(cur), float64(min), float64(max), float64(step)
+}
+
+// AddRelation adds a relation of given type to the target
+func (ai *AccessInfo) AddRelation(typ AccessRelations, target ki.Ki) {
+	ai.Relations = append(ai.Relations, AccessRelation{Type: typ, Target: target})
+}
+
+// Equal returns true if this info is the same as the other -- relation
+// targets are compared by identity
+func (ai *AccessInfo) Equal(oi *AccessInfo) bool {
+	if ai.Role != oi.Role || ai.Label != oi.Label || ai.Description != oi.Description || ai.Value != oi.Value || ai.States != oi.States || ai.Shortcut != oi.Shortcut {
+		return false
+	}
+	if ai.HasRange != oi.HasRange || ai.Cur != oi.Cur || ai.Min != oi.Min || ai.Max != oi.Max || ai.Step != oi.Step {
+		return false
+	}
+	if len(ai.Actions) != len(oi.Actions) || len(ai.Relations) != len(oi.Relations) {
+		return false
+	}
+	for i, a := range ai.Actions {
+		if oi.Actions[i] != a {
+			return false
+		}
+	}
+	for i, r := range ai.Relations {
+		if oi.Relations[i] != r {
+			return false
+		}
+	}
+	return true
+}
+
+// AccessPlainText returns the given text, which can contain html markup as
+// used in labels, as plain text for assistive technology
+func AccessPlainText(txt string) string {
+	if !strings.ContainsAny(txt, "<&") {
+		return txt
+	}
+	var sb strings.Builder
+	intag := false
+	for _, r := range txt {
+		switch {
+		case r == '<':
+			intag = true
+		case r == '>' && intag:
+			intag = false
+		case !intag:
+			sb.WriteRune(r)
+		}
+	}
+	return html.UnescapeString(sb.String())
+}
+
+// AccessClick performs a click on the given button, as from the keyboard
+func AccessClick(bw ButtonWidget) bool {
+	bb := bw.AsButtonBase()
+	if bb.IsInactive() {
+		return false
+	}
+	bb.ButtonPress()
+	bw.ButtonRelease()
+	return true
+}
+
+func (nb *Node2DBase) AccessInfo2D(ai *AccessInfo) {
+}
+
+func (nb *Node2DBase) AccessAction2D(act string) bool {
+	return false
+}
+
+// AccessInfo2D for widgets sets the states common to all widgets, and the
+// tooltip as the description -- the role is left as AccessNone, so that
+// generic widgets are not exposed, and specific widget types set it.
+func (wb *WidgetBase) AccessInfo2D(ai *AccessInfo) {
+	ai.SetState(wb.This().(Node2D).IsVisible(), AccessVisible)
+	ai.SetState(wb.IsInactive(), AccessInactive)
+	ai.SetState(wb.CanFocus(), AccessFocusable)
+	ai.SetState(wb.HasFocus(), AccessFocused)
+	ai.SetState(wb.IsSelected(), AccessSelected)
+	if wb.Tooltip != "" {
+		ai.Description = AccessPlainText(wb.Tooltip)
+		ai.SetState(true, AccessHasTooltip)
+	}
+}
@@ -0,0 +1,276 @@
+// Copyright (c) 2018, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"image"
+	"sync"
+
+	"github.com/goki/ki/ki"
+)
+
+// AccessNode is a node in the AccessTree, mirroring a Node2D that has an
+// accessibility role.  All fields are protected by the Mu of the tree.
+type AccessNode struct {
+	ID      int64           `desc:"unique id of this node within its tree -- stable for the life of the node"`
+	Node    Node2D          `desc:"the node mirrored by this node"`
+	Info    AccessInfo      `desc:"the accessibility info of the node as of the last update"`
+	WinBBox image.Rectangle `desc:"bounding box of the node in window coordinates as of the last update"`
+	Parent  *AccessNode     `desc:"parent of this node in the accessibility tree -- nil for the root"`
+	Kids    []*AccessNode   `desc:"children of this node in the accessibility tree"`
+	gen     int
+}
+
+// IndexInParent returns the index of this node in the Kids of its parent
+// -- -1 for the root
+func (an *AccessNode) IndexInParent() int {
+	if an.Parent == nil {
+		return -1
+	}
+	for i, k := range an.Parent.Kids {
+		if k == an {
+			return i
+		}
+	}
+	return -1
+}
+
+// AccessTreeSignals are the signals sent by an AccessTree when it changes
+// in an Update -- the data is the *AccessNode concerned
+type AccessTreeSignals int64
+
+const (
+	// AccessNodeAdded means a node has been added to the tree
+	AccessNodeAdded AccessTreeSignals = iota
+
+	// AccessNodeRemoved means a node has been removed from the tree -- it
+	// is no longer in the tree when the signal is sent
+	AccessNodeRemoved
+
+	// AccessNodeChanged means the Info of the node has changed
+	AccessNodeChanged
+
+	// AccessChildrenChanged means the children of the node have changed
+	AccessChildrenChanged
+
+	// AccessFocusChanged means the keyboard focus has moved to the node --
+	// the data is nil if no exposed node has the focus
+	AccessFocusChanged
+
+	AccessTreeSignalsN
+)
+
+//go:generate stringer -type=AccessTreeSignals
+
+// AccessTree is the tree of accessibility information for assistive
+// technology, mirroring the nodes under a Top node (and the popups of a
+// Window) that have an accessibility role, as given by their AccessInfo2D
+// methods.  Call Update to bring it up-to-date with the nodes -- this is
+// done automatically for a Window after each publish once EnableAccess has
+// been called -- and connect to AccessSig to be notified of the changes.
+type AccessTree struct {
+	Top       Node2D       `desc:"top node mirrored in the tree -- it is always the Root, even if it has no role"`
+	Win       *Window      `desc:"window whose popups are also included under the Root -- can be nil"`
+	Root      *AccessNode  `desc:"root of the tree, for the Top node"`
+	Focus     *AccessNode  `desc:"node that has the keyboard focus -- nil if none"`
+	AccessSig ki.Signal    `desc:"signal for changes in the tree, sent at the end of Update -- see AccessTreeSignals for the types, data is the *AccessNode"`
+	Mu        sync.RWMutex `desc:"mutex protecting the tree and all of its nodes -- must be read-locked for accessing the nodes from other goroutines"`
+	nodes     map[ki.Ki]*AccessNode
+	ids       map[int64]*AccessNode
+	labels    map[ki.Ki]*AccessNode
+	lastID    int64
+	gen       int
+}
+
+// accessChange records a change during Update, for signaling at the end
+type accessChange struct {
+	sig AccessTreeSignals
+	an  *AccessNode
+}
+
+// NewAccessTree returns a new accessibility tree for the given top node,
+// and window (which can be nil) -- call Update to build it
+func NewAccessTree(top Node2D, win *Window) *AccessTree {
+	at := &AccessTree{Top: top, Win: win}
+	at.nodes = make(map[ki.Ki]*AccessNode)
+	at.ids = make(map[int64]*AccessNode)
+	at.labels = make(map[ki.Ki]*AccessNode)
+	return at
+}
+
+// Update updates the tree from the current state of the nodes, and sends
+// AccessSig signals for all the changes, after the update is complete
+func (at *AccessTree) Update() {
+	if at.Top == nil || at.Top.This() == nil || at.Top.IsDestroyed() {
+		return
+	}
+	at.Mu.Lock()
+	at.gen++
+	for k := range at.labels {
+		delete(at.labels, k)
+	}
+	var chg []accessChange
+	var focus *AccessNode
+
+	var ai AccessInfo
+	at.Top.AccessInfo2D(&ai)
+	if ai.Role == AccessNone {
+		ai.Role = AccessPanel
+	}
+	var extra []ki.Ki
+	if at.Win != nil {
+		at.Win.PopMu.RLock()
+		extra = append(extra, at.Win.PopupStack...)
+		if at.Win.Popup != nil {
+			extra = append(extra, at.Win.Popup)
+		}
+		at.Win.PopMu.RUnlock()
+	}
+	at.Root = at.updateNode(at.Top, nil, &ai, extra, &focus, &chg)
+
+	for k, an := range at.nodes {
+		if an.gen != at.gen {
+			delete(at.nodes, k)
+			delete(at.ids, an.ID)
+			chg = append(chg, accessChange{AccessNodeRemoved, an})
+		}
+	}
+	if focus != at.Focus {
+		at.Focus = focus
+		chg = append(chg, accessChange{AccessFocusChanged, focus})
+	}
+	at.Mu.Unlock()
+
+	for _, c := range chg {
+		at.AccessSig.Emit(at.Top.This(), int64(c.sig), c.an)
+	}
+}
+
+// updateNode updates the node for given Node2D with given info, and its
+// children, followed by any extra nodes as children
+func (at *AccessTree) updateNode(ni Node2D, par *AccessNode, ai *AccessInfo, extra []ki.Ki, focus **AccessNode, chg *[]accessChange) *AccessNode {
+	k := ni.This()
+	if lbl, ok := at.labels[k]; ok {
+		if ai.Label == "" {
+			ai.Label = lbl.Info.Label
+		}
+		ai.AddRelation(AccessLabelledBy, lbl.Node.This())
+	}
+	an, has := at.nodes[k]
+	if !has {
+		at.lastID++
+		an = &AccessNode{ID: at.lastID, Node: ni}
+		at.nodes[k] = an
+		at.ids[an.ID] = an
+		*chg = append(*chg, accessChange{AccessNodeAdded, an})
+	} else if !an.Info.Equal(ai) {
+		*chg = append(*chg, accessChange{AccessNodeChanged, an})
+	}
+	an.Info = *ai
+	an.Parent = par
+	an.gen = at.gen
+	nb := ni.AsNode2D()
+	nb.BBoxMu.RLock()
+	an.WinBBox = nb.WinBBox
+	nb.BBoxMu.RUnlock()
+	if *focus == nil && ai.HasState(AccessFocused) {
+		*focus = an
+	}
+	for _, rel := range ai.Relations {
+		if rel.Type == AccessLabelFor && rel.Target != nil {
+			at.labels[rel.Target.This()] = an
+		}
+	}
+
+	var kids []*AccessNode
+	if ai.Role != AccessImage { // the contents of images (e.g., svg) are not exposed
+		for _, kid := range *k.Children() {
+			at.collect(kid, an, &kids, focus, chg)
+		}
+	}
+	for _, kid := range extra {
+		at.collect(kid, an, &kids, focus, chg)
+	}
+	if has && !accessSameKids(an.Kids, kids) {
+		*chg = append(*chg, accessChange{AccessChildrenChanged, an})
+	}
+	an.Kids = kids
+	return an
+}
+
+// collect adds the nodes for given ki node to kids -- itself if it has a
+// role, otherwise the nodes for its children
+func (at *AccessTree) collect(k ki.Ki, par *AccessNode, kids *[]*AccessNode, focus **AccessNode, chg *[]accessChange) {
+	ni, nb := KiToNode2D(k)
+	if ni == nil || nb.This() == nil || nb.IsInvisible() || nb.IsDeleted() || nb.IsDestroyed() {
+		return
+	}
+	var ai AccessInfo
+	ni.AccessInfo2D(&ai)
+	if ai.Role == AccessNone {
+		for _, kid := range *k.Children() {
+			at.collect(kid, par, kids, focus, chg)
+		}
+		return
+	}
+	*kids = append(*kids, at.updateNode(ni, par, &ai, nil, focus, chg))
+}
+
+// accessSameKids returns true if the two lists of nodes are the same
+func accessSameKids(a, b []*AccessNode) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// NodeByID returns the node with given id -- nil if not found.  The tree
+// must be read-locked.
+func (at *AccessTree) NodeByID(id int64) *AccessNode {
+	return at.ids[id]
+}
+
+// NodeFor returns the node for given ki node, or for its closest parent in
+// the tree if it is not itself in the tree -- nil if none.  The tree must
+// be read-locked.
+func (at *AccessTree) NodeFor(k ki.Ki) *AccessNode {
+	for k != nil && k.This() != nil {
+		if an, ok := at.nodes[k.This()]; ok {
+			return an
+		}
+		k = k.Parent()
+	}
+	return nil
+}
+
+// NodeAt returns the deepest visible node whose bounding box contains the
+// given point in window coordinates, starting from given node -- nil if
+// none.  The tree must be read-locked.
+func (at *AccessTree) NodeAt(an *AccessNode, pt image.Point) *AccessNode {
+	if an == nil || !pt.In(an.WinBBox) {
+		return nil
+	}
+	for i := len(an.Kids) - 1; i >= 0; i-- { // later kids (popups) are on top
+		if kn := at.NodeAt(an.Kids[i], pt); kn != nil {
+			return kn
+		}
+	}
+	return an
+}
+
+// EnableAccess turns on the accessibility tree for this window, which is
+// then updated after each publish of the window -- returns the tree.
+func (w *Window) EnableAccess() *AccessTree {
+	if w.Access == nil {
+		w.Access = NewAccessTree(w.Viewport, w)
+		w.Access.Update()
+	}
+	return w.Access
+}
@@ -234,6 +234,13 @@ func (ac *Action) ButtonRelease() {
 	ac.UpdateEnd(updt)
 }
 
+func (ac *Action) AccessInfo2D(ai *AccessInfo) {
+	ac.ButtonBase.AccessInfo2D(ai)
+	if ac.IsMenu() {
+		ai.Role = AccessMenuItem
+	}
+}
+
 // Init2D calls functions to initialize widget and parts
 func (ac *Action) Init2D() {
 	ac.Init2DWidget()
@@ -98,6 +98,12 @@ func (mb *MenuBar) Render2D() {
 	}
 }
 
+func (mb *MenuBar) AccessInfo2D(ai *AccessInfo) {
+	mb.Layout.AccessInfo2D(ai)
+	ai.Role = AccessMenuBar
+	ai.SetState(true, AccessHorizontal)
+}
+
 // UpdateActions calls UpdateFunc on all actions in menu -- individual menus
 // are automatically updated just prior to menu popup
 func (mb *MenuBar) UpdateActions() {
@@ -191,7 +197,7 @@ func (mb *MenuBar) ConfigMenus(menus []string) {
 		mi := mb.Kids[i]
 		if mi.TypeEmbeds(KiT_Action) {
 			ac := mi.Embed(KiT_Action).(*Action)
-			ac.SetText(m)
+			ac.SetText(TrText(ac, m))
 			ac.SetAsMenu()
 		}
 	}
@@ -346,9 +352,9 @@ func (tb *ToolBar) AddAction(opts ActOpts, sigTo ki.Ki, fun ki.RecvFunc) *Action
 		nm = opts.Icon
 	}
 	ac := AddNewAction(tb, nm)
-	ac.Text = opts.Label
+	ac.Text = TrText(ac, opts.Label)
 	ac.Icon = IconName(opts.Icon)
-	ac.Tooltip = opts.Tooltip
+	ac.Tooltip = TrTooltip(ac, opts.Tooltip)
 	ac.Shortcut = key.Chord(opts.Shortcut).OSShortcut()
 	if opts.ShortcutKey != KeyFunNil {
 		ac.Shortcut = ShortcutForFun(opts.ShortcutKey)
@@ -417,6 +423,13 @@ func (tb *ToolBar) ConnectEvents2D() {
 	tb.SetShortcuts()
 }
 
+func (tb *ToolBar) AccessInfo2D(ai *AccessInfo) {
+	tb.Layout.AccessInfo2D(ai)
+	ai.Role = AccessToolBar
+	ai.SetState(tb.Lay == LayoutHoriz, AccessHorizontal)
+	ai.SetState(tb.Lay == LayoutVert, AccessVertical)
+}
+
 // SetShortcuts sets the shortcuts to window associated with Toolbar
 // Called in ConnectEvents2D()
 func (tb *ToolBar) SetShortcuts() {
@@ -159,6 +159,16 @@ func (bm *Bitmap) Render2D() {
 	}
 }
 
+// AccessInfo2D exposes the bitmap as an image, named by its tooltip or file
+func (bm *Bitmap) AccessInfo2D(ai *AccessInfo) {
+	bm.WidgetBase.AccessInfo2D(ai)
+	ai.Role = AccessImage
+	ai.Label = ai.Description
+	if ai.Label == "" {
+		ai.Label = filepath.Base(string(bm.Filename))
+	}
+}
+
 //////////////////////////////////////////////////////////////////////////////////
 //  Image IO
 
@@ -508,17 +508,16 @@ func (bb *ButtonBase) HoverTooltipEvent() {
 	bb.ConnectEvent(oswin.MouseHoverEvent, RegPri, func(recv, send ki.Ki, sig int64, d interface{}) {
 		me := d.(*mouse.HoverEvent)
 		wbb := recv.Embed(KiT_ButtonBase).(*ButtonBase)
-		tt := wbb.Tooltip
-		if wbb.Shortcut != "" {
-			tt = "[ " + wbb.Shortcut.Shortcut() + " ]: " + tt
+		tt := wbb.TooltipOptions()
+		if tt == nil {
+			tt = NewTooltipOpts("")
 		}
-		if tt != "" {
+		if tt.Shortcut == "" {
+			tt.Shortcut = wbb.Shortcut
+		}
+		if tt.HasContent() {
 			me.SetProcessed()
-			bb.BBoxMu.RLock()
-			pos := wbb.WinBBox.Max
-			bb.BBoxMu.RUnlock()
-			pos.X -= 20
-			PopupTooltip(tt, pos.X, pos.Y, wbb.Viewport, wbb.Nm)
+			wbb.PopupTooltipFor(tt, me.Where)
 		}
 	})
 }
@@ -705,6 +704,41 @@ func (bb *ButtonBase) ConnectEvents2D() {
 	bb.ButtonEvents()
 }
 
+// AccessLabel returns the accessible name of the button: its text, or its
+// tooltip or icon name if it has no text
+func (bb *ButtonBase) AccessLabel() string {
+	switch {
+	case bb.Text != "":
+		return AccessPlainText(bb.Text)
+	case bb.Tooltip != "":
+		return AccessPlainText(bb.Tooltip)
+	}
+	return string(bb.Icon)
+}
+
+func (bb *ButtonBase) AccessInfo2D(ai *AccessInfo) {
+	bb.WidgetBase.AccessInfo2D(ai)
+	ai.Role = AccessButton
+	ai.Label = bb.AccessLabel()
+	ai.Actions = []string{AccessActClick}
+	ai.Shortcut = string(bb.Shortcut)
+	if bb.IsCheckable() {
+		ai.Role = AccessToggleButton
+		ai.SetState(true, AccessCheckable)
+		ai.SetState(bb.IsChecked(), AccessChecked)
+	}
+	ai.SetState(bb.State == ButtonDown, AccessPressed)
+	ai.SetState(bb.HasMenu(), AccessHasPopup)
+}
+
+// AccessAction2D clicks the button, as from the keyboard
+func (bb *ButtonBase) AccessAction2D(act string) bool {
+	if act != AccessActClick && !(act == AccessActToggle && bb.IsCheckable()) {
+		return false
+	}
+	return AccessClick(bb.This().(ButtonWidget))
+}
+
 func (bb *ButtonBase) FocusChanged2D(change FocusChanges) {
 	switch change {
 	case FocusLost:
@@ -1014,3 +1048,9 @@ func (cb *CheckBox) ConfigPartsIfNeeded() {
 		ist.StackTop = 1
 	}
 }
+
+func (cb *CheckBox) AccessInfo2D(ai *AccessInfo) {
+	cb.ButtonBase.AccessInfo2D(ai)
+	ai.Role = AccessCheckBox
+	ai.Actions = []string{AccessActToggle}
+}
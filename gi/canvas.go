@@ -0,0 +1,342 @@
+// Copyright (c) 2018, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"image"
+	"image/draw"
+
+	"github.com/goki/gi/oswin"
+	"github.com/goki/gi/oswin/mouse"
+	"github.com/goki/gi/units"
+	"github.com/goki/ki/ki"
+	"github.com/goki/ki/kit"
+	"github.com/goki/mat32"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////
+// Canvas
+
+// CanvasDrawFunc is a function that draws the contents of a Canvas, using
+// given Paint on given RenderState, in the local coordinates of the canvas:
+// 0,0 is the top-left of its content box, and size is the size of the
+// content box in dots.  Hit regions for mouse events can be registered with
+// AddRegion on the canvas while drawing.
+type CanvasDrawFunc func(cv *Canvas, pc *Paint, rs *RenderState, size mat32.Vec2)
+
+// CanvasRegion is a hit region of a Canvas, registered while drawing, that
+// gets mouse events
+type CanvasRegion struct {
+	Name    string          `desc:"name of the region"`
+	Rect    image.Rectangle `desc:"region in the local coordinates of the canvas"`
+	Tooltip string          `desc:"tooltip shown when hovering over the region"`
+	Data    interface{}     `desc:"arbitrary data associated with the region"`
+}
+
+// CanvasEvent is the data sent with CanvasSig signals
+type CanvasEvent struct {
+	Region *CanvasRegion `desc:"the region the mouse is over -- nil if none"`
+	Pos    image.Point   `desc:"position of the mouse in the local coordinates of the canvas"`
+	Event  oswin.Event   `desc:"the mouse event"`
+}
+
+// Canvas is a widget for custom drawing without subclassing WidgetBase: the
+// DrawFunc is called to draw its contents with a Paint and RenderState in
+// local coordinates.  The drawing is recorded in a retained command list
+// (see PaintRecord), and DrawFunc is only called again when the canvas is
+// invalidated (see Invalidate) -- when the canvas is resized, the recorded
+// drawing is replayed at the new size, unless RedrawOnResize is set.  The
+// result is kept in an image that is composited on each render, so it works
+// efficiently within scrolling Layouts.  Hit regions registered while drawing
+// (AddRegion) receive mouse events, sent as CanvasSig signals with a
+// *CanvasEvent, and show their tooltips when hovered.
+type Canvas struct {
+	WidgetBase
+	DrawFunc       CanvasDrawFunc `copy:"-" json:"-" xml:"-" view:"-" desc:"function that draws the contents of the canvas"`
+	RedrawOnResize bool           `desc:"call DrawFunc again when the canvas is resized, for drawings that depend on the size -- otherwise the recorded drawing is replayed at the new size"`
+	Regions        []CanvasRegion `copy:"-" json:"-" xml:"-" view:"-" desc:"hit regions registered during the last drawing -- later regions are on top of earlier ones"`
+	CanvasSig      ki.Signal      `copy:"-" json:"-" xml:"-" view:"-" desc:"signal for mouse events on the canvas -- see CanvasSignals for the types, and data is a *CanvasEvent"`
+	Record         PaintRecord    `copy:"-" json:"-" xml:"-" view:"-" desc:"the recorded drawing operations of the last call to DrawFunc, which are replayed to redraw the image at another size"`
+	Image          *image.RGBA    `copy:"-" json:"-" xml:"-" view:"-" desc:"the retained image of the drawing, at the current size"`
+	Valid          bool           `copy:"-" json:"-" xml:"-" view:"-" desc:"the recorded drawing is up-to-date -- cleared by Invalidate"`
+	hoverIdx       int
+	drawSize       image.Point
+}
+
+var KiT_Canvas = kit.Types.AddType(&Canvas{}, CanvasProps)
+
+// AddNewCanvas adds a new canvas to given parent node, with given name and
+// draw function.
+func AddNewCanvas(parent ki.Ki, name string, fun CanvasDrawFunc) *Canvas {
+	cv := parent.AddNewChild(KiT_Canvas, name).(*Canvas)
+	cv.DrawFunc = fun
+	return cv
+}
+
+func (cv *Canvas) CopyFieldsFrom(frm interface{}) {
+	fr := frm.(*Canvas)
+	cv.WidgetBase.CopyFieldsFrom(&fr.WidgetBase)
+	cv.DrawFunc = fr.DrawFunc
+	cv.RedrawOnResize = fr.RedrawOnResize
+	cv.Valid = false
+}
+
+func (cv *Canvas) Disconnect() {
+	cv.WidgetBase.Disconnect()
+	cv.CanvasSig.DisconnectAll()
+}
+
+var CanvasProps = ki.Props{
+	"EnumType:Flag":    KiT_NodeFlags,
+	"min-width":        units.NewPx(10),
+	"min-height":       units.NewPx(10),
+	"width":            units.NewEm(20),
+	"height":           units.NewEm(20),
+	"color":            &Prefs.Colors.Font,
+	"background-color": &Prefs.Colors.Background,
+}
+
+// CanvasSignals are signals that a Canvas can send
+type CanvasSignals int64
+
+const (
+	// CanvasPressed means a mouse button was pressed in the canvas
+	CanvasPressed CanvasSignals = iota
+
+	// CanvasReleased means a mouse button was released in the canvas
+	CanvasReleased
+
+	// CanvasDoubleClicked means the canvas was double-clicked
+	CanvasDoubleClicked
+
+	// CanvasDragged means the mouse was dragged with a button pressed --
+	// Event is the *mouse.DragEvent
+	CanvasDragged
+
+	// CanvasRegionEntered means the mouse moved into a region
+	CanvasRegionEntered
+
+	// CanvasRegionExited means the mouse moved out of a region -- Region is
+	// the region that was exited
+	CanvasRegionExited
+
+	CanvasSignalsN
+)
+
+//go:generate stringer -type=CanvasSignals
+
+// Invalidate marks the recorded drawing as out-of-date, so DrawFunc is
+// called again at the next render, and triggers an update
+func (cv *Canvas) Invalidate() {
+	cv.Valid = false
+	cv.UpdateSig()
+}
+
+// SetDrawFunc sets the draw function, and invalidates the drawing
+func (cv *Canvas) SetDrawFunc(fun CanvasDrawFunc) {
+	cv.DrawFunc = fun
+	cv.Invalidate()
+}
+
+// AddRegion registers a hit region -- typically called during DrawFunc,
+// as all regions are reset before each drawing
+func (cv *Canvas) AddRegion(reg CanvasRegion) {
+	cv.Regions = append(cv.Regions, reg)
+}
+
+// RegionIndex returns the index of the topmost region at given local
+// position, -1 if none
+func (cv *Canvas) RegionIndex(pos image.Point) int {
+	for i := len(cv.Regions) - 1; i >= 0; i-- {
+		if pos.In(cv.Regions[i].Rect) {
+			return i
+		}
+	}
+	return -1
+}
+
+// RegionAt returns the topmost region at given local position, nil if none
+func (cv *Canvas) RegionAt(pos image.Point) *CanvasRegion {
+	if i := cv.RegionIndex(pos); i >= 0 {
+		return &cv.Regions[i]
+	}
+	return nil
+}
+
+// ContentPos returns the position of the content box in the viewport
+func (cv *Canvas) ContentPos() mat32.Vec2 {
+	cv.StyMu.RLock()
+	spc := cv.Sty.BoxSpace()
+	cv.StyMu.RUnlock()
+	return cv.LayState.Alloc.Pos.AddScalar(spc)
+}
+
+// LocalPos returns the position in local canvas coordinates of given window
+// position -- this takes into account any scrolling of the canvas within
+// its parent layouts, where the window bounding box is clipped
+func (cv *Canvas) LocalPos(pt image.Point) image.Point {
+	cv.BBoxMu.RLock()
+	vpoff := cv.VpBBox.Min.Sub(cv.WinBBox.Min) // window to viewport
+	cv.BBoxMu.RUnlock()
+	return pt.Add(vpoff).Sub(cv.ContentPos().ToPoint())
+}
+
+// Redraw updates the retained image at given size: DrawFunc is called to
+// record a new drawing if the canvas was invalidated (or resized with
+// RedrawOnResize), resetting the regions -- otherwise the recorded drawing
+// is replayed
+func (cv *Canvas) Redraw(sz image.Point) {
+	if sz.X <= 0 || sz.Y <= 0 {
+		cv.Image = nil
+		return
+	}
+	if cv.Image == nil || cv.Image.Rect.Size() != sz {
+		cv.Image = image.NewRGBA(image.Rectangle{Max: sz})
+	} else {
+		draw.Draw(cv.Image, cv.Image.Rect, image.Transparent, image.ZP, draw.Src)
+	}
+	rs := &RenderState{}
+	rs.Init(sz.X, sz.Y, cv.Image)
+	rs.Bounds = cv.Image.Rect
+	if cv.Valid && (sz == cv.drawSize || !cv.RedrawOnResize) {
+		cv.Record.Replay(rs)
+		return
+	}
+	cv.Valid = true
+	cv.drawSize = sz
+	cv.Regions = cv.Regions[:0]
+	cv.hoverIdx = -1
+	cv.Record.Reset()
+	if cv.DrawFunc == nil {
+		return
+	}
+	pc := &rs.Paint
+	st := &cv.Sty
+	pc.UnContext = st.UnContext
+	pc.FontStyle = st.Font
+	pc.TextStyle = st.Text
+	pc.StrokeStyle.SetColor(&st.Font.Color)
+	pc.FillStyle.SetColor(nil)
+	rs.Record = &cv.Record
+	cv.DrawFunc(cv, pc, rs, mat32.NewVec2FmPoint(sz))
+	rs.Record = nil
+}
+
+// emitEvent emits given canvas signal for given mouse event at given window
+// position
+func (cv *Canvas) emitEvent(sig CanvasSignals, reg *CanvasRegion, pos image.Point, ev oswin.Event) {
+	cv.CanvasSig.Emit(cv.This(), int64(sig), &CanvasEvent{Region: reg, Pos: pos, Event: ev})
+}
+
+// UpdateHover updates the hovered region for given local position, sending
+// region entered and exited signals
+func (cv *Canvas) UpdateHover(pos image.Point, ev oswin.Event) {
+	idx := cv.RegionIndex(pos)
+	if idx == cv.hoverIdx {
+		return
+	}
+	if cv.hoverIdx >= 0 && cv.hoverIdx < len(cv.Regions) {
+		cv.emitEvent(CanvasRegionExited, &cv.Regions[cv.hoverIdx], pos, ev)
+	}
+	cv.hoverIdx = idx
+	if idx >= 0 {
+		cv.emitEvent(CanvasRegionEntered, &cv.Regions[idx], pos, ev)
+	}
+}
+
+// CanvasMouseEvents connects to the mouse events
+func (cv *Canvas) CanvasMouseEvents() {
+	cv.ConnectEvent(oswin.MouseEvent, RegPri, func(recv, send ki.Ki, sig int64, d interface{}) {
+		me := d.(*mouse.Event)
+		cvv := recv.Embed(KiT_Canvas).(*Canvas)
+		pos := cvv.LocalPos(me.Where)
+		reg := cvv.RegionAt(pos)
+		switch me.Action {
+		case mouse.Press:
+			cvv.emitEvent(CanvasPressed, reg, pos, me)
+		case mouse.Release:
+			cvv.emitEvent(CanvasReleased, reg, pos, me)
+		case mouse.DoubleClick:
+			cvv.emitEvent(CanvasDoubleClicked, reg, pos, me)
+		default:
+			return
+		}
+		me.SetProcessed()
+	})
+	cv.ConnectEvent(oswin.MouseDragEvent, RegPri, func(recv, send ki.Ki, sig int64, d interface{}) {
+		me := d.(*mouse.DragEvent)
+		cvv := recv.Embed(KiT_Canvas).(*Canvas)
+		pos := cvv.LocalPos(me.Where)
+		me.SetProcessed()
+		cvv.emitEvent(CanvasDragged, cvv.RegionAt(pos), pos, me)
+	})
+	cv.ConnectEvent(oswin.MouseMoveEvent, RegPri, func(recv, send ki.Ki, sig int64, d interface{}) {
+		me := d.(*mouse.MoveEvent)
+		cvv := recv.Embed(KiT_Canvas).(*Canvas)
+		cvv.UpdateHover(cvv.LocalPos(me.Where), me)
+	})
+	cv.ConnectEvent(oswin.MouseFocusEvent, RegPri, func(recv, send ki.Ki, sig int64, d interface{}) {
+		me := d.(*mouse.FocusEvent)
+		cvv := recv.Embed(KiT_Canvas).(*Canvas)
+		if me.Action == mouse.Exit {
+			cvv.UpdateHover(image.Point{-1, -1}, me)
+		}
+	})
+	cv.ConnectEvent(oswin.MouseHoverEvent, RegPri, func(recv, send ki.Ki, sig int64, d interface{}) {
+		me := d.(*mouse.HoverEvent)
+		cvv := recv.Embed(KiT_Canvas).(*Canvas)
+		tt := cvv.TooltipOptions()
+		if reg := cvv.RegionAt(cvv.LocalPos(me.Where)); reg != nil && reg.Tooltip != "" {
+			tt = NewTooltipOpts(reg.Tooltip)
+		}
+		if tt != nil && tt.HasContent() {
+			me.SetProcessed()
+			cvv.PopupTooltipFor(tt, me.Where)
+		}
+	})
+}
+
+func (cv *Canvas) ConnectEvents2D() {
+	cv.CanvasMouseEvents()
+}
+
+func (cv *Canvas) AccessInfo2D(ai *AccessInfo) {
+	cv.WidgetBase.AccessInfo2D(ai)
+	ai.Role = AccessCanvas
+	ai.Label = ai.Description
+}
+
+// RenderCanvas redraws the retained image if needed, by recording or
+// replaying the drawing, and renders it
+func (cv *Canvas) RenderCanvas() {
+	rs, _, st := cv.RenderLock()
+	defer cv.RenderUnlock(rs)
+	cv.RenderStdBox(st)
+	spc := st.BoxSpace()
+	pos := cv.LayState.Alloc.Pos.AddScalar(spc).ToPoint()
+	sz := cv.LayState.Alloc.Size.AddScalar(-2 * spc).ToPointFloor()
+	if !cv.Valid || cv.Image == nil || cv.Image.Rect.Size() != sz {
+		cv.Redraw(sz)
+	}
+	if cv.Image == nil {
+		return
+	}
+	dst := image.Rectangle{Min: pos, Max: pos.Add(sz)}.Intersect(rs.Bounds)
+	draw.Draw(rs.Image, dst, cv.Image, dst.Min.Sub(pos), draw.Over)
+}
+
+func (cv *Canvas) Render2D() {
+	if cv.FullReRenderIfNeeded() {
+		return
+	}
+	if cv.PushBounds() {
+		cv.This().(Node2D).ConnectEvents2D()
+		cv.RenderCanvas()
+		cv.Render2DChildren()
+		cv.PopBounds()
+	} else {
+		cv.DisconnectAllEvents(RegPri)
+	}
+}
@@ -0,0 +1,489 @@
+// Copyright (c) 2018, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/goki/gi/oswin"
+	"github.com/goki/gi/oswin/key"
+	"github.com/goki/gi/units"
+	"github.com/goki/ki/ki"
+	"github.com/goki/ki/kit"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////
+// Command
+
+// Command is one entry in the command palette -- either an existing Action
+// (from the main menu, a toolbar, or the window Shortcuts), or a registered
+// function that has no menu entry at all.
+type Command struct {
+	Label    string    `desc:"label shown for the command"`
+	Path     string    `desc:"path of menus containing the command, e.g., 'File > Open Recent', shown before the label"`
+	Shortcut key.Chord `desc:"keyboard shortcut bound to the command, if any"`
+	Tooltip  string    `desc:"tooltip with further description of the command"`
+	Icon     IconName  `desc:"icon for the command, if any"`
+	Action   *Action   `json:"-" xml:"-" desc:"action that is triggered to run the command -- nil for a registered Func command"`
+	Func     func()    `json:"-" xml:"-" view:"-" desc:"function that is called to run the command, if there is no Action"`
+}
+
+// FullLabel returns the label with the menu path, if any, as shown in the
+// command palette, and used for matching
+func (cm *Command) FullLabel() string {
+	if cm.Path == "" {
+		return cm.Label
+	}
+	return cm.Path + " > " + cm.Label
+}
+
+// Run runs the command, by triggering its Action or calling its Func
+func (cm *Command) Run() {
+	if cm.Action != nil {
+		cm.Action.Trigger()
+		return
+	}
+	if cm.Func != nil {
+		cm.Func()
+	}
+}
+
+// CommandList is a list of commands, e.g., registered for the entire app or
+// for a given window
+type CommandList []*Command
+
+// Add registers a new command with given label, keyboard shortcut (can be
+// empty) and function, and returns it for further configuration (Path,
+// Tooltip, Icon).  Use this for commands that have no menu entry.
+func (cl *CommandList) Add(label string, shortcut key.Chord, fun func()) *Command {
+	cm := &Command{Label: label, Shortcut: shortcut.OSShortcut(), Func: fun}
+	*cl = append(*cl, cm)
+	return cm
+}
+
+// Remove removes the command with given label, returning true if found
+func (cl *CommandList) Remove(label string) bool {
+	for i, cm := range *cl {
+		if cm.Label == label {
+			*cl = append((*cl)[:i], (*cl)[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// AppCommands are registered commands available in the command palette of
+// every window -- see also Window.Commands for window-specific ones.
+var AppCommands CommandList
+
+// CollectCommands returns all the commands available in the command palette
+// for this window: all the actions in the MainMenu (including sub-menus),
+// all ToolBar actions (including those generated by MethView), and all the
+// window Shortcuts, followed by the window Commands and AppCommands.
+// Inactive actions are skipped.  Menus made on demand by MakeMenuFunc are
+// only expanded in the MainMenu, as toolbar menu functions can have side
+// effects (e.g., marking notifications as read).
+func (w *Window) CollectCommands() []*Command {
+	var cmds []*Command
+	seen := map[*Action]bool{}
+	acts := map[*Action]key.Chord{} // shortcuts not otherwise set on the action
+	for ch, ac := range w.Shortcuts {
+		acts[ac] = ch
+	}
+	var addAct func(ac *Action, path string, mkmenu bool)
+	addAct = func(ac *Action, path string, mkmenu bool) {
+		if seen[ac] {
+			return
+		}
+		seen[ac] = true
+		ac.UpdateActions()
+		if mkmenu && ac.MakeMenuFunc != nil {
+			ac.MakeMenuFunc(ac.This(), &ac.Menu)
+		}
+		if len(ac.Menu) > 0 {
+			sub := ac.Text
+			if sub == "" {
+				sub = ac.Tooltip
+			}
+			if path != "" {
+				sub = path + " > " + sub
+			}
+			for _, mi := range ac.Menu {
+				if mak := mi.Embed(KiT_Action); mak != nil {
+					addAct(mak.(*Action), sub, mkmenu)
+				}
+			}
+			return
+		}
+		if ac.IsInactive() || (ac.Text == "" && ac.Tooltip == "") {
+			return
+		}
+		cm := &Command{Label: ac.Text, Path: path, Shortcut: ac.Shortcut, Tooltip: ac.Tooltip, Icon: ac.Icon, Action: ac}
+		if cm.Label == "" {
+			cm.Label = ac.Tooltip
+			cm.Tooltip = ""
+		}
+		if cm.Shortcut == "" {
+			cm.Shortcut = acts[ac]
+		}
+		cmds = append(cmds, cm)
+	}
+	if w.MainMenu != nil {
+		for _, k := range w.MainMenu.Kids {
+			if ak := k.Embed(KiT_Action); ak != nil {
+				addAct(ak.(*Action), "", true)
+			}
+		}
+	}
+	if w.Viewport != nil {
+		w.Viewport.FuncDownMeFirst(0, nil, func(k ki.Ki, level int, d interface{}) bool {
+			tbk := k.Embed(KiT_ToolBar)
+			if tbk == nil {
+				return ki.Continue
+			}
+			for _, tk := range *tbk.Children() {
+				if ak := tk.Embed(KiT_Action); ak != nil {
+					addAct(ak.(*Action), "", false)
+				}
+			}
+			return ki.Break
+		})
+	}
+	var shacts []*Action
+	for _, ac := range w.Shortcuts {
+		if !seen[ac] {
+			shacts = append(shacts, ac)
+		}
+	}
+	sort.Slice(shacts, func(i, j int) bool {
+		return shacts[i].Text < shacts[j].Text
+	})
+	for _, ac := range shacts {
+		addAct(ac, "", false)
+	}
+	cmds = append(cmds, w.Commands...)
+	cmds = append(cmds, AppCommands...)
+	return cmds
+}
+
+// CommandPalette opens the command palette for this window, activated by
+// the KeyFunCommandPalette key function
+func (w *Window) CommandPalette() {
+	if w.Viewport == nil {
+		return
+	}
+	CommandPaletteDialog(w.Viewport, w.CollectCommands())
+}
+
+////////////////////////////////////////////////////////////////////////////////////////
+// Fuzzy matching
+
+// FuzzyMatch returns a score for how well the given pattern matches the given
+// string, as a case-insensitive subsequence of it, and false if it does not
+// match at all.  Matches at the start of words, and runs of consecutive
+// matching characters score higher, so e.g., "sa" matches "Save As" better
+// than "Paste".  An empty pattern matches everything with score 0.
+func FuzzyMatch(pattern, str string) (int, bool) {
+	pr := []rune(strings.ToLower(pattern))
+	if len(pr) == 0 {
+		return 0, true
+	}
+	sr := []rune(str)
+	score := 0
+	pi := 0
+	last := -2
+	for si := 0; si < len(sr) && pi < len(pr); si++ {
+		if unicode.ToLower(sr[si]) != pr[pi] {
+			continue
+		}
+		score++
+		if si == last+1 {
+			score += 3
+		}
+		if si == 0 || !unicode.IsLetter(sr[si-1]) && !unicode.IsDigit(sr[si-1]) ||
+			unicode.IsUpper(sr[si]) && unicode.IsLower(sr[si-1]) {
+			score += 5
+		}
+		last = si
+		pi++
+	}
+	if pi < len(pr) {
+		return 0, false
+	}
+	return score, true
+}
+
+// FuzzyRank returns the indexes of the strings that FuzzyMatch the given
+// pattern, best match first -- strings with the same score stay in their
+// original order
+func FuzzyRank(pattern string, strs []string) []int {
+	type scored struct {
+		idx   int
+		score int
+	}
+	var sc []scored
+	for i, str := range strs {
+		if s, ok := FuzzyMatch(pattern, str); ok {
+			sc = append(sc, scored{i, s})
+		}
+	}
+	sort.SliceStable(sc, func(i, j int) bool {
+		return sc[i].score > sc[j].score
+	})
+	idxs := make([]int, len(sc))
+	for i := range sc {
+		idxs[i] = sc[i].idx
+	}
+	return idxs
+}
+
+////////////////////////////////////////////////////////////////////////////////////////
+// CommandPalette
+
+// CommandPaletteMaxItems is the maximum number of matching commands shown in
+// the command palette
+var CommandPaletteMaxItems = 50
+
+// CommandPalette is a search field with a list of the commands that match
+// the search text, best matches first, showing their keyboard shortcuts.
+// Up / Down select a command, and Enter (or clicking) runs it.  It is
+// normally shown in a dialog by Window.CommandPalette (see
+// CommandPaletteDialog).
+type CommandPalette struct {
+	Frame
+	Commands []*Command `json:"-" xml:"-" desc:"all the commands that can be searched"`
+	Matches  []*Command `json:"-" xml:"-" desc:"the commands matching the current search text, best first"`
+	SelIdx   int        `json:"-" xml:"-" desc:"index of the selected command in Matches"`
+	Win      *Window    `json:"-" xml:"-" desc:"window the commands are run in"`
+}
+
+var KiT_CommandPalette = kit.Types.AddType(&CommandPalette{}, CommandPaletteProps)
+
+// AddNewCommandPalette adds a new command palette to given parent node, with given name.
+func AddNewCommandPalette(parent ki.Ki, name string) *CommandPalette {
+	return parent.AddNewChild(KiT_CommandPalette, name).(*CommandPalette)
+}
+
+var CommandPaletteProps = ki.Props{
+	"EnumType:Flag":    KiT_NodeFlags,
+	"padding":          units.NewPx(2),
+	"margin":           units.NewPx(2),
+	"color":            &Prefs.Colors.Font,
+	"background-color": &Prefs.Colors.Background,
+	"#results": ki.Props{
+		"spacing": units.NewPx(0),
+		"padding": units.NewPx(0),
+	},
+}
+
+// Search returns the search text field
+func (cp *CommandPalette) Search() *TextField {
+	return cp.ChildByName("search", 0).(*TextField)
+}
+
+// Results returns the frame with the matching commands
+func (cp *CommandPalette) Results() *Frame {
+	return cp.ChildByName("results", 1).(*Frame)
+}
+
+// Config configures the search field and results
+func (cp *CommandPalette) Config() {
+	cp.Lay = LayoutVert
+	config := kit.TypeAndNameList{}
+	config.Add(KiT_TextField, "search")
+	config.Add(KiT_Frame, "results")
+	mods, updt := cp.ConfigChildren(config, ki.UniqueNames)
+	if !mods {
+		return
+	}
+	sf := cp.Search()
+	sf.Placeholder = "Search commands"
+	sf.SetStretchMaxWidth()
+	sf.SetMinPrefWidth(units.NewCh(60))
+	sf.TextFieldSig.ConnectOnly(cp.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+		cpp := recv.Embed(KiT_CommandPalette).(*CommandPalette)
+		switch TextFieldSignals(sig) {
+		case TextFieldInsert, TextFieldBackspace, TextFieldDelete, TextFieldCleared:
+			cpp.UpdateMatches()
+		}
+	})
+	res := cp.Results()
+	res.Lay = LayoutVert
+	res.SetStretchMax()
+	res.SetMinPrefHeight(units.NewEm(20))
+	cp.UpdateMatches()
+	cp.UpdateEnd(updt)
+}
+
+// UpdateMatches updates the Matches for the current search text, and the
+// results showing them
+func (cp *CommandPalette) UpdateMatches() {
+	pat := strings.TrimSpace(string(cp.Search().EditTxt))
+	labels := make([]string, len(cp.Commands))
+	for i, cm := range cp.Commands {
+		labels[i] = cm.FullLabel()
+	}
+	mi := FuzzyRank(pat, labels)
+	cp.Matches = make([]*Command, len(mi))
+	for i, ci := range mi {
+		cp.Matches[i] = cp.Commands[ci]
+	}
+	cp.SelIdx = 0
+	cp.ConfigResults()
+}
+
+// ConfigResults configures the results to show the current Matches
+func (cp *CommandPalette) ConfigResults() {
+	res := cp.Results()
+	updt := res.UpdateStart()
+	res.SetFullReRender()
+	res.DeleteChildren(ki.DestroyKids)
+	n := len(cp.Matches)
+	if n > CommandPaletteMaxItems {
+		n = CommandPaletteMaxItems
+	}
+	var m Menu
+	for i := 0; i < n; i++ {
+		cm := cp.Matches[i]
+		ac := m.AddAction(ActOpts{Label: cm.FullLabel(), Icon: string(cm.Icon), Tooltip: cm.Tooltip, Data: i},
+			cp.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+				cpp := recv.Embed(KiT_CommandPalette).(*CommandPalette)
+				cpp.RunCommand(data.(int))
+			})
+		ac.Shortcut = cm.Shortcut
+		ac.SetProp("no-focus", true)
+	}
+	for _, mi := range m {
+		res.AddChild(mi)
+	}
+	res.UpdateEnd(updt)
+	cp.SelectCommand(cp.SelIdx)
+}
+
+// SelectCommand selects the command at given index in the results
+func (cp *CommandPalette) SelectCommand(idx int) {
+	res := cp.Results()
+	if len(res.Kids) == 0 {
+		return
+	}
+	if idx < 0 {
+		idx = 0
+	}
+	if idx >= len(res.Kids) {
+		idx = len(res.Kids) - 1
+	}
+	updt := res.UpdateStart()
+	for i, k := range res.Kids {
+		ac := k.(*Action)
+		if ac.IsSelected() != (i == idx) {
+			ac.SetSelectedState(i == idx)
+			ac.UpdateButtonStyle()
+		}
+	}
+	cp.SelIdx = idx
+	res.UpdateEnd(updt)
+	res.Kids[idx].(*Action).ScrollToMe()
+}
+
+// ParentDialog returns the dialog containing the palette, or nil if none
+func (cp *CommandPalette) ParentDialog() *Dialog {
+	dlg := cp.ParentByType(KiT_Dialog, ki.Embeds)
+	if dlg == nil {
+		return nil
+	}
+	return dlg.Embed(KiT_Dialog).(*Dialog)
+}
+
+// RunCommand closes the dialog containing the palette, if any, and then
+// runs the command at given index in Matches, on the window event loop
+func (cp *CommandPalette) RunCommand(idx int) {
+	if idx < 0 || idx >= len(cp.Matches) {
+		return
+	}
+	cm := cp.Matches[idx]
+	if dlg := cp.ParentDialog(); dlg != nil {
+		dlg.Accept()
+	}
+	if cp.Win != nil {
+		cp.Win.RunOnWin(cm.Run)
+	} else {
+		cm.Run()
+	}
+}
+
+// KeyInput handles keyboard navigation and selection of the results
+func (cp *CommandPalette) KeyInput(kt *key.ChordEvent) {
+	kf := KeyFun(kt.Chord())
+	switch kf {
+	case KeyFunMoveUp:
+		kt.SetProcessed()
+		cp.SelectCommand(cp.SelIdx - 1)
+	case KeyFunMoveDown:
+		kt.SetProcessed()
+		cp.SelectCommand(cp.SelIdx + 1)
+	case KeyFunPageUp:
+		kt.SetProcessed()
+		cp.SelectCommand(cp.SelIdx - 10)
+	case KeyFunPageDown:
+		kt.SetProcessed()
+		cp.SelectCommand(cp.SelIdx + 10)
+	case KeyFunEnter, KeyFunAccept:
+		kt.SetProcessed()
+		cp.RunCommand(cp.SelIdx)
+	case KeyFunAbort:
+		kt.SetProcessed()
+		if dlg := cp.ParentDialog(); dlg != nil {
+			dlg.Cancel()
+		}
+	}
+}
+
+func (cp *CommandPalette) KeyChordEvent() {
+	// HiPri to take precedence over the search text field
+	cp.ConnectEvent(oswin.KeyChordEvent, HiPri, func(recv, send ki.Ki, sig int64, d interface{}) {
+		cpp := recv.Embed(KiT_CommandPalette).(*CommandPalette)
+		if !cpp.HasFocus2D() {
+			return
+		}
+		kt := d.(*key.ChordEvent)
+		cpp.KeyInput(kt)
+	})
+}
+
+func (cp *CommandPalette) Init2D() {
+	cp.Frame.Init2D()
+	cp.Config()
+}
+
+func (cp *CommandPalette) ConnectEvents2D() {
+	cp.Frame.ConnectEvents2D()
+	cp.KeyChordEvent()
+}
+
+// CommandPaletteDialog opens a dialog with a CommandPalette for searching
+// and running the given commands (typically from Window.CollectCommands) in
+// the window of given viewport.
+func CommandPaletteDialog(avp *Viewport2D, cmds []*Command) *Dialog {
+	avp = ValidViewport(avp)
+	if avp == nil {
+		return nil
+	}
+	dlg := NewStdDialog(DlgOpts{Title: "Commands"}, NoOk, NoCancel)
+	dlg.Modal = true
+
+	frame := dlg.Frame()
+	_, prIdx := dlg.PromptWidget(frame)
+	cp := frame.InsertNewChild(KiT_CommandPalette, prIdx+1, "cmd-palette").(*CommandPalette)
+	cp.Commands = cmds
+	cp.Win = avp.Win
+	cp.Config()
+
+	dlg.UpdateEndNoSig(true)
+	sz := avp.Win.Viewport.Geom.Size
+	dlg.Open(sz.X/4, sz.Y/10, avp, nil)
+	return dlg
+}
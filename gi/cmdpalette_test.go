@@ -0,0 +1,62 @@
+// Copyright (c) 2018, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFuzzyMatch(t *testing.T) {
+	tests := []struct {
+		pat, str string
+		score    int
+		ok       bool
+	}{
+		{"", "anything", 0, true},
+		{"", "", 0, true},
+		{"x", "", 0, false},
+		{"sa", "Save As", 10, true},  // word start + consecutive
+		{"SA", "save as", 10, true},  // case insensitive
+		{"sa", "Close All", 7, true}, // mid-word s, word-start a
+		{"fi", "OpenFile", 10, true}, // camel case word start
+		{"xyz", "Save", 0, false},    // no match
+		{"as", "Save", 0, false},     // out of order
+		{"ss", "Save", 0, false},     // each rune used once
+		{"2", "h2o", 1, true},        // digit inside a word
+		{"éc", "Éclair", 10, true},   // non-ascii case folding
+		{"ü", "Grün", 1, true},       // non-ascii mid-word
+		{"日本", "日本語", 10, true},      // non-latin letters
+		{"本", "日 本", 6, true},        // word start after space
+		{"ab", "a-b", 12, true},      // punctuation starts a word
+	}
+	for _, tt := range tests {
+		score, ok := FuzzyMatch(tt.pat, tt.str)
+		if score != tt.score || ok != tt.ok {
+			t.Errorf("FuzzyMatch(%q, %q) = %v, %v; want %v, %v", tt.pat, tt.str, score, ok, tt.score, tt.ok)
+		}
+	}
+}
+
+func TestFuzzyRank(t *testing.T) {
+	cmds := []string{"Paste", "Close All", "Save As", "Save All", "Settings"}
+	tests := []struct {
+		pat  string
+		strs []string
+		want []int
+	}{
+		{"sa", cmds, []int{2, 3, 1}},                         // ties keep original order
+		{"sa", []string{"Save All", "Save As"}, []int{0, 1}}, // ties in the other order
+		{"", cmds, []int{0, 1, 2, 3, 4}},                     // empty pattern keeps all
+		{"set", cmds, []int{4}},
+		{"qq", cmds, []int{}},
+		{"éd", []string{"Modéd", "Édit"}, []int{1, 0}},
+	}
+	for _, tt := range tests {
+		if got := FuzzyRank(tt.pat, tt.strs); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("FuzzyRank(%q, %q) = %v; want %v", tt.pat, tt.strs, got, tt.want)
+		}
+	}
+}
@@ -428,6 +428,25 @@ func (cb *ComboBox) SelectItem(idx int) {
 	cb.UpdateEnd(updt)
 }
 
+// AccessInfo2D exposes the current item as the value of the combo box
+func (cb *ComboBox) AccessInfo2D(ai *AccessInfo) {
+	cb.ButtonBase.AccessInfo2D(ai)
+	ai.Role = AccessComboBox
+	ai.Label = AccessPlainText(cb.Tooltip)
+	ai.Value = AccessPlainText(cb.Text)
+	ai.Actions = []string{AccessActMenu}
+	ai.SetState(cb.Editable, AccessEditable)
+	ai.SetState(true, AccessHasPopup)
+}
+
+// AccessAction2D opens the menu of items
+func (cb *ComboBox) AccessAction2D(act string) bool {
+	if act != AccessActMenu && act != AccessActClick {
+		return false
+	}
+	return AccessClick(cb.This().(ButtonWidget))
+}
+
 // MakeItemsMenu makes menu of all the items
 func (cb *ComboBox) MakeItemsMenu() {
 	nitm := len(cb.Items)
@@ -0,0 +1,629 @@
+// Copyright (c) 2018, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/goki/gi/oswin"
+	"github.com/goki/gi/oswin/key"
+	"github.com/goki/gi/units"
+	"github.com/goki/ki/ki"
+	"github.com/goki/ki/kit"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////
+// DatePicker
+
+// DatePickerDays is the number of day buttons shown in the month grid of a
+// DatePicker -- 6 weeks covers every possible month layout
+const DatePickerDays = 42
+
+// DatePicker is a month-grid calendar for choosing a date, or a range of
+// dates in RangeMode.  The grid is navigable with the keyboard: arrows move
+// the cursor by a day or a week, PageUp / PageDown by a month, Home / End go
+// to the start / end of the month, and Enter selects the cursor date.
+// Dates outside of MinDate / MaxDate are shown inactive and cannot be selected.
+type DatePicker struct {
+	Frame
+	Date          time.Time    `desc:"currently selected date -- only the date part is used"`
+	Cursor        time.Time    `copy:"-" json:"-" xml:"-" desc:"keyboard cursor date -- the month containing the cursor is the one displayed"`
+	MinDate       time.Time    `desc:"earliest date that can be selected -- zero means no limit"`
+	MaxDate       time.Time    `desc:"latest date that can be selected -- zero means no limit"`
+	WeekNums      bool         `desc:"show ISO week numbers in an extra column at the start of each row"`
+	FirstWeekday  time.Weekday `desc:"day of the week shown in the first column -- initialized from the ActiveLocaleFormat"`
+	RangeMode     bool         `desc:"select a range of dates instead of a single date: the first selection sets RangeStart, the second sets RangeEnd"`
+	RangeStart    time.Time    `desc:"start of the selected range, in RangeMode"`
+	RangeEnd      time.Time    `desc:"end of the selected range, in RangeMode -- zero while the range is being selected"`
+	DatePickerSig ki.Signal    `copy:"-" json:"-" xml:"-" view:"-" desc:"signal for date picker -- see DatePickerSignals for the types"`
+}
+
+var KiT_DatePicker = kit.Types.AddType(&DatePicker{}, DatePickerProps)
+
+// AddNewDatePicker adds a new date picker to given parent node, with given name.
+func AddNewDatePicker(parent ki.Ki, name string) *DatePicker {
+	dp := parent.AddNewChild(KiT_DatePicker, name).(*DatePicker)
+	dp.FirstWeekday = ActiveLocaleFormat.FirstWeekday
+	return dp
+}
+
+func (dp *DatePicker) CopyFieldsFrom(frm interface{}) {
+	fr := frm.(*DatePicker)
+	dp.Frame.CopyFieldsFrom(&fr.Frame)
+	dp.Date = fr.Date
+	dp.MinDate = fr.MinDate
+	dp.MaxDate = fr.MaxDate
+	dp.WeekNums = fr.WeekNums
+	dp.FirstWeekday = fr.FirstWeekday
+	dp.RangeMode = fr.RangeMode
+	dp.RangeStart = fr.RangeStart
+	dp.RangeEnd = fr.RangeEnd
+}
+
+func (dp *DatePicker) Disconnect() {
+	dp.Frame.Disconnect()
+	dp.DatePickerSig.DisconnectAll()
+}
+
+var DatePickerProps = ki.Props{
+	"EnumType:Flag":    KiT_NodeFlags,
+	"border-width":     units.NewPx(1),
+	"border-radius":    units.NewPx(2),
+	"border-color":     &Prefs.Colors.Border,
+	"padding":          units.NewPx(2),
+	"margin":           units.NewPx(2),
+	"color":            &Prefs.Colors.Font,
+	"background-color": &Prefs.Colors.Background,
+	"#month": ki.Props{
+		"text-align":  AlignCenter,
+		"font-weight": WeightBold,
+	},
+	"#grid": ki.Props{
+		"spacing": units.NewPx(1),
+		"padding": units.NewPx(0),
+	},
+}
+
+// DatePickerSignals are signals that a date picker can send
+type DatePickerSignals int64
+
+const (
+	// DatePickerSelected means a date was selected, by mouse or Enter --
+	// data is the time.Time date.
+	DatePickerSelected DatePickerSignals = iota
+
+	// DatePickerRangeSelected means a complete range was selected in
+	// RangeMode -- data is a [2]time.Time with the start and end dates.
+	DatePickerRangeSelected
+
+	// DatePickerCanceled means the user pressed Abort (Escape) in the picker,
+	// e.g., to close a popup without selecting.
+	DatePickerCanceled
+
+	DatePickerSignalsN
+)
+
+//go:generate stringer -type=DatePickerSignals
+
+// DateOnly returns the given time truncated to midnight in its own location
+func DateOnly(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
+// SameDate returns true if the two times fall on the same calendar date
+func SameDate(a, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	return ay == by && am == bm && ad == bd
+}
+
+// InRange returns true if the given date is within MinDate and MaxDate
+func (dp *DatePicker) InRange(d time.Time) bool {
+	d = DateOnly(d)
+	if !dp.MinDate.IsZero() && d.Before(DateOnly(dp.MinDate)) {
+		return false
+	}
+	if !dp.MaxDate.IsZero() && d.After(DateOnly(dp.MaxDate)) {
+		return false
+	}
+	return true
+}
+
+// ClampDate returns the given date limited to MinDate and MaxDate
+func (dp *DatePicker) ClampDate(d time.Time) time.Time {
+	if !dp.MinDate.IsZero() && DateOnly(d).Before(DateOnly(dp.MinDate)) {
+		return DateOnly(dp.MinDate)
+	}
+	if !dp.MaxDate.IsZero() && DateOnly(d).After(DateOnly(dp.MaxDate)) {
+		return DateOnly(dp.MaxDate)
+	}
+	return d
+}
+
+// InSelection returns true if the given date is the selected date, or
+// within the selected range in RangeMode
+func (dp *DatePicker) InSelection(d time.Time) bool {
+	if !dp.RangeMode {
+		return !dp.Date.IsZero() && SameDate(d, dp.Date)
+	}
+	if dp.RangeStart.IsZero() {
+		return false
+	}
+	if dp.RangeEnd.IsZero() {
+		return SameDate(d, dp.RangeStart)
+	}
+	d = DateOnly(d)
+	return !d.Before(DateOnly(dp.RangeStart)) && !d.After(DateOnly(dp.RangeEnd))
+}
+
+// SetDate sets the selected date and moves the cursor to it, updating the display
+func (dp *DatePicker) SetDate(d time.Time) {
+	updt := dp.UpdateStart()
+	dp.Date = DateOnly(d)
+	dp.Cursor = dp.Date
+	dp.UpdateDays()
+	dp.UpdateEnd(updt)
+}
+
+// SetRange sets the selected range in RangeMode, updating the display
+func (dp *DatePicker) SetRange(st, ed time.Time) {
+	updt := dp.UpdateStart()
+	if ed.Before(st) {
+		st, ed = ed, st
+	}
+	dp.RangeStart = DateOnly(st)
+	dp.RangeEnd = DateOnly(ed)
+	dp.Cursor = dp.RangeStart
+	dp.UpdateDays()
+	dp.UpdateEnd(updt)
+}
+
+// SelectDateAction selects given date (if within range), emitting
+// DatePickerSelected -- in RangeMode it alternately sets the start and the
+// end of the range, emitting DatePickerRangeSelected when complete.
+func (dp *DatePicker) SelectDateAction(d time.Time) {
+	if !dp.InRange(d) {
+		return
+	}
+	d = DateOnly(d)
+	updt := dp.UpdateStart()
+	dp.Cursor = d
+	if dp.RangeMode {
+		if dp.RangeStart.IsZero() || !dp.RangeEnd.IsZero() {
+			dp.RangeStart = d
+			dp.RangeEnd = time.Time{}
+		} else {
+			if d.Before(dp.RangeStart) {
+				dp.RangeEnd = dp.RangeStart
+				dp.RangeStart = d
+			} else {
+				dp.RangeEnd = d
+			}
+		}
+	}
+	dp.Date = d
+	dp.UpdateDays()
+	dp.UpdateEnd(updt)
+	dp.DatePickerSig.Emit(dp.This(), int64(DatePickerSelected), d)
+	if dp.RangeMode && !dp.RangeEnd.IsZero() {
+		dp.DatePickerSig.Emit(dp.This(), int64(DatePickerRangeSelected), [2]time.Time{dp.RangeStart, dp.RangeEnd})
+	}
+}
+
+// MoveCursor moves the keyboard cursor by given number of months and days,
+// staying within MinDate / MaxDate.  If extend is true in RangeMode, the
+// range end follows the cursor.
+func (dp *DatePicker) MoveCursor(months, days int, extend bool) {
+	cur := dp.Cursor
+	if cur.IsZero() {
+		cur = DateOnly(time.Now())
+	}
+	if months != 0 { // stay within the target month, e.g., Jan 31 -> Feb 28
+		y, m, d := cur.Date()
+		first := time.Date(y, m+time.Month(months), 1, 0, 0, 0, 0, cur.Location())
+		last := first.AddDate(0, 1, -1).Day()
+		if d > last {
+			d = last
+		}
+		cur = time.Date(first.Year(), first.Month(), d, 0, 0, 0, 0, cur.Location())
+	}
+	cur = dp.ClampDate(cur.AddDate(0, 0, days))
+	updt := dp.UpdateStart()
+	dp.Cursor = cur
+	if extend && dp.RangeMode && !dp.RangeStart.IsZero() {
+		dp.RangeEnd = time.Time{}
+		dp.SelectDateAction(cur)
+	}
+	dp.UpdateDays()
+	dp.UpdateEnd(updt)
+}
+
+// MoveCursorMonthEdge moves the cursor to the first (end = false) or last
+// day of the displayed month
+func (dp *DatePicker) MoveCursorMonthEdge(end bool) {
+	st := dp.MonthStart()
+	if end {
+		st = st.AddDate(0, 1, -1)
+	}
+	updt := dp.UpdateStart()
+	dp.Cursor = dp.ClampDate(st)
+	dp.UpdateDays()
+	dp.UpdateEnd(updt)
+}
+
+// MonthStart returns the first day of the displayed month
+func (dp *DatePicker) MonthStart() time.Time {
+	cur := dp.Cursor
+	if cur.IsZero() {
+		cur = dp.Date
+		if cur.IsZero() {
+			cur = time.Now()
+		}
+	}
+	return time.Date(cur.Year(), cur.Month(), 1, 0, 0, 0, 0, cur.Location())
+}
+
+// GridStart returns the date shown in the first day button of the grid
+func (dp *DatePicker) GridStart() time.Time {
+	st := dp.MonthStart()
+	off := (int(st.Weekday()) - int(dp.FirstWeekday) + 7) % 7
+	return st.AddDate(0, 0, -off)
+}
+
+// Header returns the header layout with the month navigation
+func (dp *DatePicker) Header() *Layout {
+	return dp.ChildByName("header", 0).(*Layout)
+}
+
+// Grid returns the grid frame with the day buttons
+func (dp *DatePicker) Grid() *Frame {
+	return dp.ChildByName("grid", 1).(*Frame)
+}
+
+// Config configures the header and month grid as needed
+func (dp *DatePicker) Config() {
+	dp.Lay = LayoutVert
+	config := kit.TypeAndNameList{}
+	config.Add(KiT_Layout, "header")
+	config.Add(KiT_Frame, "grid")
+	mods, updt := dp.ConfigChildren(config, ki.UniqueNames)
+	hdr := dp.Header()
+	if !hdr.HasChildren() {
+		hdr.Lay = LayoutHoriz
+		prev := AddNewAction(hdr, "prev")
+		prev.Icon = "wedge-left"
+		prev.Tooltip = "previous month (PageUp)"
+		prev.SetProp("no-focus", true)
+		prev.ActionSig.ConnectOnly(dp.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+			dpp := recv.Embed(KiT_DatePicker).(*DatePicker)
+			dpp.MoveCursor(-1, 0, false)
+		})
+		AddNewStretch(hdr, "str1")
+		AddNewLabel(hdr, "month", "")
+		AddNewStretch(hdr, "str2")
+		next := AddNewAction(hdr, "next")
+		next.Icon = "wedge-right"
+		next.Tooltip = "next month (PageDown)"
+		next.SetProp("no-focus", true)
+		next.ActionSig.ConnectOnly(dp.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+			dpp := recv.Embed(KiT_DatePicker).(*DatePicker)
+			dpp.MoveCursor(1, 0, false)
+		})
+	}
+	grid := dp.Grid()
+	grid.Lay = LayoutGrid
+	ncol := 7
+	if dp.WeekNums {
+		ncol = 8
+	}
+	grid.SetProp("columns", ncol)
+	gconfig := kit.TypeAndNameList{}
+	if dp.WeekNums {
+		gconfig.Add(KiT_Label, "wk-hdr")
+	}
+	for c := 0; c < 7; c++ {
+		gconfig.Add(KiT_Label, fmt.Sprintf("day-hdr-%d", c))
+	}
+	for r := 0; r < DatePickerDays/7; r++ {
+		if dp.WeekNums {
+			gconfig.Add(KiT_Label, fmt.Sprintf("wk-%d", r))
+		}
+		for c := 0; c < 7; c++ {
+			gconfig.Add(KiT_Button, fmt.Sprintf("day-%d", r*7+c))
+		}
+	}
+	gmods, gupdt := grid.ConfigChildren(gconfig, ki.UniqueNames)
+	if gmods {
+		for idx := 0; idx < DatePickerDays; idx++ {
+			db := grid.ChildByName(fmt.Sprintf("day-%d", idx), idx).(*Button)
+			db.SetProp("no-focus", true)
+			db.SetProp("index", idx)
+			db.SetProp("min-width", units.NewCh(3))
+			db.SetProp("padding", units.NewPx(2))
+			db.SetProp("margin", units.NewPx(0))
+			db.SetProp("border-width", units.NewPx(0))
+			db.ButtonSig.ConnectOnly(dp.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+				if sig != int64(ButtonClicked) {
+					return
+				}
+				dpp := recv.Embed(KiT_DatePicker).(*DatePicker)
+				di := send.Prop("index").(int)
+				dpp.GrabFocus()
+				dpp.SelectDateAction(dpp.GridStart().AddDate(0, 0, di))
+			})
+		}
+		grid.UpdateEnd(gupdt)
+	}
+	dp.UpdateDays()
+	if mods {
+		dp.UpdateEnd(updt)
+	}
+}
+
+// UpdateDays updates the month label, week numbers and day buttons to
+// reflect the current cursor month and selection
+func (dp *DatePicker) UpdateDays() {
+	if !dp.HasChildren() {
+		return
+	}
+	st := dp.MonthStart()
+	if lbl, ok := dp.Header().ChildByName("month", 2).(*Label); ok {
+		lbl.SetText(st.Format("January 2006"))
+	}
+	grid := dp.Grid()
+	if dp.WeekNums {
+		if lbl, ok := grid.ChildByName("wk-hdr", 0).(*Label); ok {
+			lbl.SetText("Wk")
+		}
+	}
+	for c := 0; c < 7; c++ {
+		if lbl, ok := grid.ChildByName(fmt.Sprintf("day-hdr-%d", c), c).(*Label); ok {
+			wd := time.Weekday((int(dp.FirstWeekday) + c) % 7)
+			lbl.SetText(wd.String()[:2])
+			lbl.SetProp("text-align", AlignCenter)
+		}
+	}
+	gst := dp.GridStart()
+	for idx := 0; idx < DatePickerDays; idx++ {
+		d := gst.AddDate(0, 0, idx)
+		if dp.WeekNums && idx%7 == 0 {
+			thu := d.AddDate(0, 0, (int(time.Thursday)-int(d.Weekday())+7)%7)
+			_, wk := thu.ISOWeek()
+			if lbl, ok := grid.ChildByName(fmt.Sprintf("wk-%d", idx/7), idx).(*Label); ok {
+				lbl.SetText(strconv.Itoa(wk))
+				lbl.SetProp("color", "lighter-40")
+			}
+		}
+		db, ok := grid.ChildByName(fmt.Sprintf("day-%d", idx), idx).(*Button)
+		if !ok {
+			continue
+		}
+		db.SetText(strconv.Itoa(d.Day()))
+		db.SetInactiveState(!dp.InRange(d))
+		db.SetSelectedState(dp.InSelection(d))
+		if d.Month() != st.Month() {
+			db.SetProp("color", "lighter-40")
+		} else {
+			db.DeleteProp("color")
+		}
+		if !dp.Cursor.IsZero() && SameDate(d, dp.Cursor) {
+			db.SetProp("border-width", units.NewPx(1))
+		} else {
+			db.SetProp("border-width", units.NewPx(0))
+		}
+	}
+	dp.SetFullReRender()
+}
+
+// KeyInput handles keyboard navigation and selection within the month grid
+func (dp *DatePicker) KeyInput(kt *key.ChordEvent) {
+	if KeyEventTrace {
+		fmt.Printf("DatePicker KeyInput: %v\n", dp.PathUnique())
+	}
+	extend := kt.HasAnyModifier(key.Shift)
+	kf := KeyFun(kt.Chord())
+	switch kf {
+	case KeyFunMoveLeft:
+		kt.SetProcessed()
+		dp.MoveCursor(0, -1, extend)
+	case KeyFunMoveRight:
+		kt.SetProcessed()
+		dp.MoveCursor(0, 1, extend)
+	case KeyFunMoveUp:
+		kt.SetProcessed()
+		dp.MoveCursor(0, -7, extend)
+	case KeyFunMoveDown:
+		kt.SetProcessed()
+		dp.MoveCursor(0, 7, extend)
+	case KeyFunPageUp:
+		kt.SetProcessed()
+		dp.MoveCursor(-1, 0, extend)
+	case KeyFunPageDown:
+		kt.SetProcessed()
+		dp.MoveCursor(1, 0, extend)
+	case KeyFunHome:
+		kt.SetProcessed()
+		dp.MoveCursorMonthEdge(false)
+	case KeyFunEnd:
+		kt.SetProcessed()
+		dp.MoveCursorMonthEdge(true)
+	case KeyFunEnter, KeyFunAccept:
+		kt.SetProcessed()
+		if !dp.Cursor.IsZero() {
+			dp.SelectDateAction(dp.Cursor)
+		}
+	case KeyFunAbort:
+		kt.SetProcessed()
+		dp.DatePickerSig.Emit(dp.This(), int64(DatePickerCanceled), nil)
+	}
+}
+
+func (dp *DatePicker) KeyChordEvent() {
+	// HiPri to take precedence over the focus navigation of the grid layout
+	dp.ConnectEvent(oswin.KeyChordEvent, HiPri, func(recv, send ki.Ki, sig int64, d interface{}) {
+		dpp := recv.Embed(KiT_DatePicker).(*DatePicker)
+		if !dpp.HasFocus2D() {
+			return
+		}
+		kt := d.(*key.ChordEvent)
+		dpp.KeyInput(kt)
+	})
+}
+
+func (dp *DatePicker) Init2D() {
+	dp.Frame.Init2D()
+	dp.SetCanFocusIfActive()
+	if dp.Cursor.IsZero() {
+		cur := dp.Date
+		if cur.IsZero() {
+			cur = time.Now()
+		}
+		dp.Cursor = dp.ClampDate(DateOnly(cur))
+	}
+	dp.Config()
+}
+
+func (dp *DatePicker) ConnectEvents2D() {
+	dp.Frame.ConnectEvents2D()
+	dp.KeyChordEvent()
+}
+
+func (dp *DatePicker) AccessInfo2D(ai *AccessInfo) {
+	dp.Frame.AccessInfo2D(ai)
+	ai.Role = AccessCalendar
+	if !dp.Date.IsZero() {
+		ai.Value = dp.Date.Format("2006-01-02")
+	}
+}
+
+func (dp *DatePicker) FocusChanged2D(change FocusChanges) {
+	if change == FocusGot {
+		dp.ScrollToMe()
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////
+// TimePicker
+
+// TimePicker has SpinBox fields for choosing the hour, minute and
+// optionally second of a time -- the date part of Time is preserved.
+type TimePicker struct {
+	Frame
+	Time          time.Time `desc:"current time value -- the date and location are preserved when the time of day is edited"`
+	ShowSecs      bool      `desc:"show a field for seconds"`
+	TimePickerSig ki.Signal `copy:"-" json:"-" xml:"-" view:"-" desc:"signal for time picker -- has no signal types, just emitted when the time changes, with the time.Time as data"`
+}
+
+var KiT_TimePicker = kit.Types.AddType(&TimePicker{}, TimePickerProps)
+
+// AddNewTimePicker adds a new time picker to given parent node, with given name.
+func AddNewTimePicker(parent ki.Ki, name string) *TimePicker {
+	return parent.AddNewChild(KiT_TimePicker, name).(*TimePicker)
+}
+
+func (tp *TimePicker) CopyFieldsFrom(frm interface{}) {
+	fr := frm.(*TimePicker)
+	tp.Frame.CopyFieldsFrom(&fr.Frame)
+	tp.Time = fr.Time
+	tp.ShowSecs = fr.ShowSecs
+}
+
+func (tp *TimePicker) Disconnect() {
+	tp.Frame.Disconnect()
+	tp.TimePickerSig.DisconnectAll()
+}
+
+var TimePickerProps = ki.Props{
+	"EnumType:Flag":  KiT_NodeFlags,
+	"border-width":   units.NewPx(0),
+	"padding":        units.NewPx(2),
+	"margin":         units.NewPx(2),
+	"vertical-align": AlignMiddle,
+	"color":          &Prefs.Colors.Font,
+}
+
+// SetTime sets the time value, updating the display
+func (tp *TimePicker) SetTime(t time.Time) {
+	updt := tp.UpdateStart()
+	tp.Time = t
+	tp.UpdateFields()
+	tp.UpdateEnd(updt)
+}
+
+// Config configures the hour, minute and second fields as needed
+func (tp *TimePicker) Config() {
+	tp.Lay = LayoutHoriz
+	config := kit.TypeAndNameList{}
+	config.Add(KiT_SpinBox, "hour")
+	config.Add(KiT_Label, "sep-min")
+	config.Add(KiT_SpinBox, "minute")
+	if tp.ShowSecs {
+		config.Add(KiT_Label, "sep-sec")
+		config.Add(KiT_SpinBox, "second")
+	}
+	mods, updt := tp.ConfigChildren(config, ki.UniqueNames)
+	if mods {
+		for _, fld := range []string{"hour", "minute", "second"} {
+			sb, ok := tp.ChildByName(fld, 0).(*SpinBox)
+			if !ok {
+				continue
+			}
+			mx := float32(59)
+			if fld == "hour" {
+				mx = 23
+			}
+			sb.Defaults()
+			sb.SetMinMax(true, 0, true, mx)
+			sb.Step = 1
+			sb.PageStep = 10
+			sb.Format = "%02d"
+			sb.SpinBoxSig.ConnectOnly(tp.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+				tpp := recv.Embed(KiT_TimePicker).(*TimePicker)
+				tpp.FieldsToTime()
+			})
+		}
+		for _, sep := range []string{"sep-min", "sep-sec"} {
+			if lbl, ok := tp.ChildByName(sep, 0).(*Label); ok {
+				lbl.SetText(":")
+				lbl.SetProp("vertical-align", AlignMiddle)
+			}
+		}
+		tp.UpdateEnd(updt)
+	}
+	tp.UpdateFields()
+}
+
+// UpdateFields updates the field values from the Time
+func (tp *TimePicker) UpdateFields() {
+	vals := map[string]int{"hour": tp.Time.Hour(), "minute": tp.Time.Minute(), "second": tp.Time.Second()}
+	for fld, v := range vals {
+		if sb, ok := tp.ChildByName(fld, 0).(*SpinBox); ok {
+			sb.SetValue(float32(v))
+		}
+	}
+}
+
+// FieldsToTime sets the Time from the current field values, and emits the
+// TimePickerSig signal
+func (tp *TimePicker) FieldsToTime() {
+	fv := func(fld string, def int) int {
+		if sb, ok := tp.ChildByName(fld, 0).(*SpinBox); ok {
+			return int(sb.Value)
+		}
+		return def
+	}
+	t := tp.Time
+	sec := fv("second", t.Second())
+	tp.Time = time.Date(t.Year(), t.Month(), t.Day(), fv("hour", t.Hour()), fv("minute", t.Minute()), sec, 0, t.Location())
+	tp.TimePickerSig.Emit(tp.This(), 0, tp.Time)
+}
+
+func (tp *TimePicker) Init2D() {
+	tp.Frame.Init2D()
+	tp.Config()
+}
@@ -0,0 +1,482 @@
+// Copyright (c) 2018, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"fmt"
+	"image"
+	"strings"
+	"time"
+
+	"github.com/goki/gi/units"
+	"github.com/goki/ki/ints"
+	"github.com/goki/ki/ki"
+	"github.com/goki/ki/kit"
+	"github.com/goki/mat32"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////
+// DateTimeField
+
+// DateTimeField combines a TextField for typing a date and / or time with
+// an action that pops up a DatePicker and / or TimePicker -- all configured
+// within the Parts of the widget.  The text is parsed and validated using
+// the Format, and a matching input mask is used for the default formats.
+type DateTimeField struct {
+	PartsWidgetBase
+	Time         time.Time          `xml:"time" desc:"current time value"`
+	Mode         DateTimeFieldModes `xml:"mode" desc:"whether to edit the date, the time of day, or both"`
+	Format       string             `xml:"format" desc:"prop = format -- time.Format layout for the text -- blank uses the date and time formats of the ActiveLocaleFormat for the Mode, which also enable an input mask if they are numeric"`
+	MinDate      time.Time          `desc:"earliest date allowed -- zero means no limit"`
+	MaxDate      time.Time          `desc:"latest date allowed -- zero means no limit"`
+	WeekNums     bool               `xml:"week-nums" desc:"show week numbers in the date picker"`
+	FirstWeekday time.Weekday       `desc:"first day of the week in the date picker -- initialized from the ActiveLocaleFormat"`
+	Icon         IconName           `view:"show-name" desc:"icon to use for the picker action -- defaults to calendar, or clock in time mode"`
+	DateTimeSig  ki.Signal          `copy:"-" json:"-" xml:"-" view:"-" desc:"signal for date time field -- has no signal types, just emitted when the value changes, with the time.Time as data"`
+}
+
+var KiT_DateTimeField = kit.Types.AddType(&DateTimeField{}, DateTimeFieldProps)
+
+// AddNewDateTimeField adds a new date time field to given parent node, with given name.
+func AddNewDateTimeField(parent ki.Ki, name string) *DateTimeField {
+	dt := parent.AddNewChild(KiT_DateTimeField, name).(*DateTimeField)
+	dt.FirstWeekday = ActiveLocaleFormat.FirstWeekday
+	return dt
+}
+
+func (dt *DateTimeField) CopyFieldsFrom(frm interface{}) {
+	fr := frm.(*DateTimeField)
+	dt.PartsWidgetBase.CopyFieldsFrom(&fr.PartsWidgetBase)
+	dt.Time = fr.Time
+	dt.Mode = fr.Mode
+	dt.Format = fr.Format
+	dt.MinDate = fr.MinDate
+	dt.MaxDate = fr.MaxDate
+	dt.WeekNums = fr.WeekNums
+	dt.FirstWeekday = fr.FirstWeekday
+	dt.Icon = fr.Icon
+}
+
+func (dt *DateTimeField) Disconnect() {
+	dt.PartsWidgetBase.Disconnect()
+	dt.DateTimeSig.DisconnectAll()
+}
+
+var DateTimeFieldProps = ki.Props{
+	"EnumType:Flag": KiT_NodeFlags,
+	"#picker": ki.Props{
+		"max-width":  units.NewEx(2),
+		"max-height": units.NewEx(2),
+		"margin":     units.NewPx(1),
+		"padding":    units.NewPx(0),
+		"fill":       &Prefs.Colors.Icon,
+		"stroke":     &Prefs.Colors.Font,
+	},
+	"#text-field": ki.Props{
+		"min-width": units.NewCh(6),
+		"margin":    units.NewPx(2),
+		"padding":   units.NewPx(2),
+		"clear-act": false,
+	},
+}
+
+// DateTimeFieldModes determine which parts of a time a DateTimeField edits
+type DateTimeFieldModes int32
+
+const (
+	// DateTimeFieldDate edits just the date
+	DateTimeFieldDate DateTimeFieldModes = iota
+
+	// DateTimeFieldTime edits just the time of day
+	DateTimeFieldTime
+
+	// DateTimeFieldDateTime edits both the date and the time of day
+	DateTimeFieldDateTime
+
+	DateTimeFieldModesN
+)
+
+//go:generate stringer -type=DateTimeFieldModes
+
+var KiT_DateTimeFieldModes = kit.Enums.AddEnumAltLower(DateTimeFieldModesN, kit.NotBitFlag, StylePropProps, "DateTimeField")
+
+func (ev DateTimeFieldModes) MarshalJSON() ([]byte, error)  { return kit.EnumMarshalJSON(ev) }
+func (ev *DateTimeFieldModes) UnmarshalJSON(b []byte) error { return kit.EnumUnmarshalJSON(ev, b) }
+
+// DateTimeFieldFormats are the ISO 8601 time.Format layouts for each mode,
+// used where the ActiveLocaleFormat has no date or time format -- they
+// correspond to the date, time and datetime TextFieldMasks
+var DateTimeFieldFormats = map[DateTimeFieldModes]string{
+	DateTimeFieldDate:     "2006-01-02",
+	DateTimeFieldTime:     "15:04",
+	DateTimeFieldDateTime: "2006-01-02 15:04",
+}
+
+// TimeFormat returns the time.Format layout in use
+func (dt *DateTimeField) TimeFormat() string {
+	if dt.Format != "" {
+		return dt.Format
+	}
+	return ActiveLocaleFormat.DateTimeFormat(dt.Mode)
+}
+
+// HasDate returns true if the date is edited in the current Mode
+func (dt *DateTimeField) HasDate() bool {
+	return dt.Mode != DateTimeFieldTime
+}
+
+// HasTime returns true if the time of day is edited in the current Mode
+func (dt *DateTimeField) HasTime() bool {
+	return dt.Mode != DateTimeFieldDate
+}
+
+// ValToString converts the time to its text representation -- a zero time
+// is shown as blank
+func (dt *DateTimeField) ValToString(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(dt.TimeFormat())
+}
+
+// StringToVal parses the text into a time, in the location of the current
+// Time -- in time mode the date of the current Time is preserved.
+// A blank string is the zero time.
+func (dt *DateTimeField) StringToVal(str string) (time.Time, error) {
+	if str == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.ParseInLocation(dt.TimeFormat(), str, dt.Time.Location())
+	if err != nil {
+		return t, fmt.Errorf("must be in the format %v", dt.TimeFormat())
+	}
+	if !dt.HasDate() {
+		y, m, d := dt.Time.Date()
+		t = time.Date(y, m, d, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	}
+	return t, nil
+}
+
+// ValidateText checks that the text parses in the Format and is within
+// MinDate / MaxDate, satisfying the TextValidator interface for the text-field part
+func (dt *DateTimeField) ValidateText(txt string) error {
+	t, err := dt.StringToVal(txt)
+	if err != nil || t.IsZero() || !dt.HasDate() {
+		return err
+	}
+	if !dt.MinDate.IsZero() && DateOnly(t).Before(DateOnly(dt.MinDate)) {
+		return fmt.Errorf("must be on or after %v", dt.MinDate.Format(ActiveLocaleFormat.DateTimeFormat(DateTimeFieldDate)))
+	}
+	if !dt.MaxDate.IsZero() && DateOnly(t).After(DateOnly(dt.MaxDate)) {
+		return fmt.Errorf("must be on or before %v", dt.MaxDate.Format(ActiveLocaleFormat.DateTimeFormat(DateTimeFieldDate)))
+	}
+	return nil
+}
+
+// SetTime sets the time value and updates the display
+func (dt *DateTimeField) SetTime(t time.Time) {
+	updt := dt.UpdateStart()
+	dt.Time = t
+	dt.UpdateEnd(updt)
+}
+
+// SetTimeAction calls SetTime and also emits the signal
+func (dt *DateTimeField) SetTimeAction(t time.Time) {
+	dt.SetTime(t)
+	dt.DateTimeSig.Emit(dt.This(), 0, dt.Time)
+}
+
+func (dt *DateTimeField) ConfigParts() {
+	if dt.Icon.IsNil() {
+		dt.Icon = IconName("calendar")
+		if !dt.HasDate() {
+			dt.Icon = IconName("clock")
+		}
+	}
+	dt.Parts.Lay = LayoutHoriz
+	dt.Parts.SetProp("vertical-align", AlignMiddle)
+	config := kit.TypeAndNameList{}
+	config.Add(KiT_TextField, "text-field")
+	if !dt.IsInactive() {
+		config.Add(KiT_Action, "picker")
+	}
+	mods, updt := dt.Parts.ConfigChildren(config, ki.UniqueNames)
+	if mods || RebuildDefaultStyles {
+		if !dt.IsInactive() {
+			pk := dt.Parts.ChildByName("picker", 1).(*Action)
+			pk.SetProp("no-focus", true)
+			pk.Icon = dt.Icon
+			pk.Tooltip = "choose from a calendar"
+			if !dt.HasDate() {
+				pk.Tooltip = "choose the time of day"
+			}
+			dt.StylePart(Node2D(pk))
+			pk.ActionSig.ConnectOnly(dt.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+				dtt := recv.Embed(KiT_DateTimeField).(*DateTimeField)
+				dtt.OpenPicker()
+			})
+		}
+		tf := dt.Parts.ChildByName("text-field", 0).(*TextField)
+		tf.SetFlagState(dt.IsInactive(), int(Inactive))
+		tf.SetProp("clear-act", false)
+		tf.SetProp("width", units.NewCh(float32(len(dt.TimeFormat())+2)))
+		dt.StylePart(Node2D(tf))
+		if dt.Format == "" {
+			tf.Mask = LayoutMask(dt.TimeFormat())
+		}
+		tf.Placeholder = dt.TimeFormat()
+		tf.Txt = dt.ValToString(dt.Time)
+		tf.Validator = dt
+		if !dt.IsInactive() {
+			tf.TextFieldSig.ConnectOnly(dt.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+				if sig == int64(TextFieldDone) || sig == int64(TextFieldDeFocused) {
+					dtt := recv.Embed(KiT_DateTimeField).(*DateTimeField)
+					tf := send.(*TextField)
+					t, err := dtt.StringToVal(tf.Text())
+					if err == nil && !t.Equal(dtt.Time) {
+						dtt.SetTimeAction(t)
+					}
+				}
+			})
+		}
+		dt.UpdateEnd(updt)
+	}
+}
+
+func (dt *DateTimeField) ConfigPartsIfNeeded() {
+	if !dt.Parts.HasChildren() {
+		dt.ConfigParts()
+	}
+	tf := dt.Parts.ChildByName("text-field", 0).(*TextField)
+	txt := dt.ValToString(dt.Time)
+	if tf.Txt != txt {
+		tf.SetText(txt)
+	}
+}
+
+// OpenPicker pops up the date and / or time pickers below the field -- in
+// date mode selecting a day applies it directly, otherwise the Ok button
+// applies the chosen date and time.  Escape or Cancel closes without change.
+func (dt *DateTimeField) OpenPicker() {
+	win := dt.ParentWindow()
+	if win == nil {
+		return
+	}
+	cur := dt.Time
+	if cur.IsZero() {
+		cur = time.Now()
+		if !dt.HasTime() {
+			cur = DateOnly(cur)
+		}
+	}
+	PickerPopup(&dt.WidgetBase, func(pvp *Viewport2D, frame *Frame) ki.Ki {
+		apply := func(t time.Time) {
+			win.ClosePopup(pvp.This())
+			if !t.Equal(dt.Time) {
+				dt.SetTimeAction(t)
+			}
+		}
+		var focus ki.Ki
+		var tp *TimePicker
+		if dt.HasDate() {
+			dp := AddNewDatePicker(frame, "date")
+			dp.MinDate = dt.MinDate
+			dp.MaxDate = dt.MaxDate
+			dp.WeekNums = dt.WeekNums
+			dp.FirstWeekday = dt.FirstWeekday
+			if !dt.Time.IsZero() {
+				dp.Date = DateOnly(dt.Time)
+			}
+			dp.DatePickerSig.Connect(dt.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+				switch DatePickerSignals(sig) {
+				case DatePickerSelected:
+					d := data.(time.Time)
+					if tp == nil {
+						apply(d)
+						return
+					}
+					t := tp.Time
+					tp.Time = time.Date(d.Year(), d.Month(), d.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+				case DatePickerCanceled:
+					win.ClosePopup(pvp.This())
+				}
+			})
+			focus = dp.This()
+		}
+		if dt.HasTime() {
+			tp = AddNewTimePicker(frame, "time")
+			tp.Time = cur
+			tp.ShowSecs = strings.Contains(dt.TimeFormat(), "05")
+			AddPickerButtons(frame, dt.This(), func() { apply(tp.Time) }, func() { win.ClosePopup(pvp.This()) })
+		}
+		return focus
+	})
+}
+
+// PickerPopup pops up a picker below given widget, in a popup viewport
+// with a frame that is filled in by given config function, which returns
+// the node to focus (can be nil) -- the picker closes the popup with
+// Window.ClosePopup on the viewport passed to config.
+func PickerPopup(wb *WidgetBase, config func(pvp *Viewport2D, frame *Frame) ki.Ki) *Viewport2D {
+	win := wb.ParentWindow()
+	if win == nil {
+		return nil
+	}
+	mainVp := win.Viewport
+	pvp := &Viewport2D{}
+	pvp.InitName(pvp, wb.Nm+"-picker")
+	pvp.Win = win
+	updt := pvp.UpdateStart()
+	pvp.SetProp("color", &Prefs.Colors.Font)
+	pvp.Fill = true
+	pvp.SetFlag(int(VpFlagPopup))
+	pvp.SetFlag(int(VpFlagPopupDestroyAll))
+	frame := AddNewFrame(pvp, "Frame", LayoutVert)
+	frame.SetProps(MenuFrameProps, ki.NoUpdate)
+
+	focus := config(pvp, frame)
+
+	frame.Init2DTree()
+	frame.Style2DTree()                                    // sufficient to get sizes
+	frame.LayState.Alloc.Size = mainVp.LayState.Alloc.Size // give it the whole vp initially
+	frame.Size2DTree(0)                                    // collect sizes
+	pvp.Win = nil
+	vpsz := frame.LayState.Size.Pref.Min(mainVp.LayState.Alloc.Size.MulScalar(.9)).ToPoint()
+	wb.BBoxMu.RLock()
+	x := wb.WinBBox.Min.X
+	y := wb.WinBBox.Max.Y
+	wb.BBoxMu.RUnlock()
+	x = ints.MaxInt(0, ints.MinInt(x, mainVp.Geom.Size.X-vpsz.X)) // fit
+	y = ints.MaxInt(0, ints.MinInt(y, mainVp.Geom.Size.Y-vpsz.Y))
+	pvp.Resize(vpsz)
+	pvp.Geom.Pos = image.Point{x, y}
+	pvp.UpdateEndNoSig(updt)
+	win.SetNextPopup(pvp.This(), focus)
+	return pvp
+}
+
+// AddPickerButtons adds a row of Ok and Cancel buttons to a picker popup
+// frame, calling given functions when clicked, with recv as the receiver
+// for the button signals
+func AddPickerButtons(frame *Frame, recv ki.Ki, ok, cancel func()) {
+	bb := AddNewLayout(frame, "buttons", LayoutHoriz)
+	AddNewStretch(bb, "str")
+	okb := AddNewButton(bb, "ok")
+	okb.SetText(TrText(okb, "Ok"))
+	okb.ButtonSig.Connect(recv, func(recv, send ki.Ki, sig int64, data interface{}) {
+		if sig == int64(ButtonClicked) {
+			ok()
+		}
+	})
+	cancelb := AddNewButton(bb, "cancel")
+	cancelb.SetText(TrText(cancelb, "Cancel"))
+	cancelb.ButtonSig.Connect(recv, func(recv, send ki.Ki, sig int64, data interface{}) {
+		if sig == int64(ButtonClicked) {
+			cancel()
+		}
+	})
+}
+
+func (dt *DateTimeField) Init2D() {
+	dt.Init2DWidget()
+	dt.ConfigParts()
+}
+
+// StyleFromProps styles DateTimeField-specific fields from ki.Prop properties
+// doesn't support inherit or default
+func (dt *DateTimeField) StyleFromProps(props ki.Props, vp *Viewport2D) {
+	for key, val := range props {
+		if len(key) == 0 {
+			continue
+		}
+		if key[0] == '#' || key[0] == '.' || key[0] == ':' || key[0] == '_' {
+			continue
+		}
+		switch key {
+		case "format":
+			dt.Format = kit.ToString(val)
+		case "mode":
+			switch vt := val.(type) {
+			case DateTimeFieldModes:
+				dt.Mode = vt
+			case string:
+				kit.Enums.SetEnumIfaceFromStringAltFirst(&dt.Mode, vt)
+			}
+		case "week-nums":
+			if bv, ok := kit.ToBool(val); ok {
+				dt.WeekNums = bv
+			}
+		}
+	}
+}
+
+// StyleDateTimeField does date time field styling -- sets StyMu Lock
+func (dt *DateTimeField) StyleDateTimeField() {
+	dt.StyMu.Lock()
+	defer dt.StyMu.Unlock()
+
+	hasTempl, saveTempl := dt.Sty.FromTemplate()
+	if !hasTempl || saveTempl {
+		dt.Style2DWidget()
+	} else {
+		dt.Sty.SetUnitContext(dt.Viewport, mat32.Vec2Zero)
+	}
+	if hasTempl && saveTempl {
+		dt.Sty.SaveTemplate()
+	}
+	dt.StyleFromProps(dt.Props, dt.Viewport)
+}
+
+func (dt *DateTimeField) Style2D() {
+	dt.StyleDateTimeField()
+	dt.StyMu.Lock()
+	dt.LayState.SetFromStyle(&dt.Sty.Layout) // also does reset
+	dt.StyMu.Unlock()
+	dt.ConfigParts()
+}
+
+func (dt *DateTimeField) Size2D(iter int) {
+	dt.Size2DParts(iter)
+}
+
+func (dt *DateTimeField) Layout2D(parBBox image.Rectangle, iter int) bool {
+	dt.ConfigPartsIfNeeded()
+	dt.Layout2DBase(parBBox, true, iter) // init style
+	dt.Layout2DParts(parBBox, iter)
+	return dt.Layout2DChildren(iter)
+}
+
+func (dt *DateTimeField) Render2D() {
+	if dt.FullReRenderIfNeeded() {
+		return
+	}
+	if dt.PushBounds() {
+		dt.This().(Node2D).ConnectEvents2D()
+		dt.ConfigPartsIfNeeded()
+		dt.Render2DChildren()
+		dt.Render2DParts()
+		dt.PopBounds()
+	} else {
+		dt.DisconnectAllEvents(RegPri)
+	}
+}
+
+func (dt *DateTimeField) ConnectEvents2D() {
+	dt.HoverTooltipEvent()
+}
+
+func (dt *DateTimeField) AccessInfo2D(ai *AccessInfo) {
+	dt.PartsWidgetBase.AccessInfo2D(ai)
+	ai.Role = AccessDateEditor
+	ai.Value = dt.ValToString(dt.Time)
+	ai.SetState(!dt.IsInactive(), AccessEditable)
+}
+
+func (dt *DateTimeField) HasFocus2D() bool {
+	if dt.IsInactive() {
+		return false
+	}
+	return dt.ContainsFocus() // needed for getting key events
+}
@@ -293,7 +293,8 @@ func (dlg *Dialog) Frame() *Frame {
 func (dlg *Dialog) SetTitle(title string, frame *Frame) *Label {
 	dlg.Title = title
 	if frame != nil {
-		lab := AddNewLabel(frame, "title", title)
+		lab := AddNewLabel(frame, "title", "")
+		lab.Text = TrText(lab, title)
 		dlg.StylePart(Node2D(lab))
 		return lab
 	}
@@ -314,7 +315,8 @@ func (dlg *Dialog) TitleWidget(frame *Frame) (*Label, int) {
 func (dlg *Dialog) SetPrompt(prompt string, frame *Frame) *Label {
 	dlg.Prompt = prompt
 	if frame != nil {
-		lab := AddNewLabel(frame, "prompt", prompt)
+		lab := AddNewLabel(frame, "prompt", "")
+		lab.Text = TrText(lab, prompt)
 		dlg.StylePart(Node2D(lab))
 		return lab
 	}
@@ -387,7 +389,7 @@ func (dlg *Dialog) StdButtonConfig(stretch, ok, cancel bool) kit.TypeAndNameList
 func (dlg *Dialog) StdButtonConnect(ok, cancel bool, bb *Layout) {
 	if ok {
 		okb := bb.ChildByName("ok", 0).Embed(KiT_Button).(*Button)
-		okb.SetText("Ok")
+		okb.SetText(TrText(okb, "Ok"))
 		okb.ButtonSig.Connect(dlg.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
 			if sig == int64(ButtonClicked) {
 				dlg := recv.Embed(KiT_Dialog).(*Dialog)
@@ -397,7 +399,7 @@ func (dlg *Dialog) StdButtonConnect(ok, cancel bool, bb *Layout) {
 	}
 	if cancel {
 		canb := bb.ChildByName("cancel", 0).Embed(KiT_Button).(*Button)
-		canb.SetText("Cancel")
+		canb.SetText(TrText(canb, "Cancel"))
 		canb.ButtonSig.Connect(dlg.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
 			if sig == int64(ButtonClicked) {
 				dlg := recv.Embed(KiT_Dialog).(*Dialog)
@@ -486,6 +488,14 @@ func (dlg *Dialog) HasFocus2D() bool {
 	return true // dialog ALWAYS gets all the events!
 }
 
+func (dlg *Dialog) AccessInfo2D(ai *AccessInfo) {
+	dlg.Viewport2D.AccessInfo2D(ai)
+	ai.Role = AccessDialog
+	ai.Label = AccessPlainText(dlg.Title)
+	ai.Description = AccessPlainText(dlg.Prompt)
+	ai.SetState(dlg.Modal, AccessModal)
+}
+
 //////////////////////////////////////////////////////////////////////////
 //     Specific Dialogs
 
@@ -521,7 +531,7 @@ func ChoiceDialog(avp *Viewport2D, opts DlgOpts, choices []string, recv ki.Ki, f
 		chnm := strcase.ToKebab(ch)
 		b := AddNewButton(bb, chnm)
 		b.SetProp("__cdSigVal", int64(i))
-		b.SetText(ch)
+		b.SetText(TrText(b, ch))
 		if chnm == "cancel" {
 			b.ButtonSig.Connect(dlg.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
 				if sig == int64(ButtonClicked) {
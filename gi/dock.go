@@ -0,0 +1,1120 @@
+// Copyright (c) 2018, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"encoding/json"
+	"fmt"
+	"image"
+	"io/ioutil"
+	"log"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/goki/gi/oswin"
+	"github.com/goki/gi/oswin/dnd"
+	"github.com/goki/gi/oswin/mimedata"
+	"github.com/goki/gi/oswin/mouse"
+	"github.com/goki/gi/units"
+	"github.com/goki/ki/ki"
+	"github.com/goki/ki/kit"
+	"github.com/goki/mat32"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////
+//    DockLayout
+
+// DockPanelMimeType is the mime type used for drag-n-drop of dock panels --
+// the data is the name of the panel
+const DockPanelMimeType = "application/x-gogi-dock-panel"
+
+// DockZones are the regions of a tab group where a dragged panel can be
+// dropped, and the edges of a DockArea where panels can be collapsed
+type DockZones int32
+
+const (
+	// DockCenter adds the panel as a new tab in the tab group
+	DockCenter DockZones = iota
+
+	// DockLeft splits the tab group horizontally, with the panel on the left
+	DockLeft
+
+	// DockRight splits the tab group horizontally, with the panel on the right
+	DockRight
+
+	// DockTop splits the tab group vertically, with the panel on top
+	DockTop
+
+	// DockBottom splits the tab group vertically, with the panel on the bottom
+	DockBottom
+
+	DockZonesN
+)
+
+//go:generate stringer -type=DockZones
+
+var KiT_DockZones = kit.Enums.AddEnumAltLower(DockZonesN, kit.NotBitFlag, StylePropProps, "Dock")
+
+func (ev DockZones) MarshalJSON() ([]byte, error)  { return kit.EnumMarshalJSON(ev) }
+func (ev *DockZones) UnmarshalJSON(b []byte) error { return kit.EnumUnmarshalJSON(ev, b) }
+
+// Dim returns the dimension along which a drop in this zone splits the
+// space -- X for left / right, Y for top / bottom (and center)
+func (ev DockZones) Dim() mat32.Dims {
+	if ev == DockLeft || ev == DockRight {
+		return mat32.X
+	}
+	return mat32.Y
+}
+
+// IsBefore returns true if the new panel goes before the existing ones
+// along the split dimension (left or top)
+func (ev DockZones) IsBefore() bool {
+	return ev == DockLeft || ev == DockTop
+}
+
+// DockEdgeNames are the names of the edges of a DockArea, used for the
+// bars holding collapsed panels and in menus
+var DockEdgeNames = map[DockZones]string{
+	DockLeft:   "left",
+	DockRight:  "right",
+	DockTop:    "top",
+	DockBottom: "bottom",
+}
+
+// DockZoneMargin is the proportion of a tab group's size, from each edge,
+// that maps to the split drop zone for that edge -- drops further inside
+// add the panel as a tab
+var DockZoneMargin = float32(0.25)
+
+// DockLayout is the serializable arrangement of panels within a DockArea.
+// It is either a split, with Kids arranged along Dim, or (if there are no
+// Kids) a tab group holding the named Panels.
+type DockLayout struct {
+	Dim    mat32.Dims    `desc:"for a split, dimension along which the Kids are arranged"`
+	Splits []float32     `desc:"for a split, proportion of space allocated to each of the Kids"`
+	Kids   []*DockLayout `desc:"sub-layouts of a split -- if empty, this is a tab group"`
+	Panels []string      `desc:"for a tab group, names of the panels, in tab order"`
+	Cur    int           `desc:"for a tab group, index of the selected tab"`
+}
+
+// IsTabs returns true if this is a tab group, not a split
+func (dl *DockLayout) IsTabs() bool {
+	return len(dl.Kids) == 0
+}
+
+// FindPanel returns the tab group containing given panel, and the panel's
+// index within it -- nil if not found
+func (dl *DockLayout) FindPanel(name string) (*DockLayout, int) {
+	if dl.IsTabs() {
+		for i, pn := range dl.Panels {
+			if pn == name {
+				return dl, i
+			}
+		}
+		return nil, -1
+	}
+	for _, k := range dl.Kids {
+		if grp, idx := k.FindPanel(name); grp != nil {
+			return grp, idx
+		}
+	}
+	return nil, -1
+}
+
+// ParentOf returns the split containing given sub-layout, and its index
+// within that split -- nil if it is not found or is this layout itself
+func (dl *DockLayout) ParentOf(sub *DockLayout) (*DockLayout, int) {
+	for i, k := range dl.Kids {
+		if k == sub {
+			return dl, i
+		}
+		if par, idx := k.ParentOf(sub); par != nil {
+			return par, idx
+		}
+	}
+	return nil, -1
+}
+
+// TabGroups returns all the tab groups in this layout, in depth-first order
+func (dl *DockLayout) TabGroups() []*DockLayout {
+	if dl.IsTabs() {
+		return []*DockLayout{dl}
+	}
+	var grps []*DockLayout
+	for _, k := range dl.Kids {
+		grps = append(grps, k.TabGroups()...)
+	}
+	return grps
+}
+
+// AllPanels returns the names of all the panels in this layout
+func (dl *DockLayout) AllPanels() []string {
+	var pns []string
+	for _, grp := range dl.TabGroups() {
+		pns = append(pns, grp.Panels...)
+	}
+	return pns
+}
+
+// RemovePanel removes given panel from its tab group, returning false if
+// not found -- empty groups remain until Prune is called
+func (dl *DockLayout) RemovePanel(name string) bool {
+	grp, idx := dl.FindPanel(name)
+	if grp == nil {
+		return false
+	}
+	grp.Panels = append(grp.Panels[:idx], grp.Panels[idx+1:]...)
+	if idx < grp.Cur || grp.Cur >= len(grp.Panels) {
+		grp.Cur--
+	}
+	if grp.Cur < 0 {
+		grp.Cur = 0
+	}
+	return true
+}
+
+// InsertPanel inserts given panel relative to given tab group within this
+// layout -- as a new tab for DockCenter, otherwise by splitting the group
+// (or adding to its parent split if that is already along the same dim)
+func (dl *DockLayout) InsertPanel(grp *DockLayout, name string, zone DockZones) {
+	if zone == DockCenter {
+		grp.Panels = append(grp.Panels, name)
+		grp.Cur = len(grp.Panels) - 1
+		return
+	}
+	nw := &DockLayout{Panels: []string{name}}
+	dim := zone.Dim()
+	if par, gidx := dl.ParentOf(grp); par != nil && par.Dim == dim {
+		par.fixSplits()
+		half := par.Splits[gidx] / 2
+		par.Splits[gidx] = half
+		idx := gidx
+		if !zone.IsBefore() {
+			idx++
+		}
+		par.Kids = append(par.Kids, nil)
+		copy(par.Kids[idx+1:], par.Kids[idx:])
+		par.Kids[idx] = nw
+		par.Splits = append(par.Splits, 0)
+		copy(par.Splits[idx+1:], par.Splits[idx:])
+		par.Splits[idx] = half
+		return
+	}
+	old := &DockLayout{}
+	*old = *grp
+	*grp = DockLayout{Dim: dim, Splits: []float32{0.5, 0.5}}
+	if zone.IsBefore() {
+		grp.Kids = []*DockLayout{nw, old}
+	} else {
+		grp.Kids = []*DockLayout{old, nw}
+	}
+}
+
+// fixSplits ensures there is one split value per sub-layout
+func (dl *DockLayout) fixSplits() {
+	for len(dl.Splits) < len(dl.Kids) {
+		dl.Splits = append(dl.Splits, 1/float32(len(dl.Kids)))
+	}
+	dl.Splits = dl.Splits[:len(dl.Kids)]
+}
+
+// Prune removes empty tab groups, and replaces splits having only one
+// sub-layout with that sub-layout -- returns false if nothing is left
+func (dl *DockLayout) Prune() bool {
+	if dl.IsTabs() {
+		if dl.Cur >= len(dl.Panels) {
+			dl.Cur = len(dl.Panels) - 1
+		}
+		if dl.Cur < 0 {
+			dl.Cur = 0
+		}
+		return len(dl.Panels) > 0
+	}
+	dl.fixSplits()
+	var kids []*DockLayout
+	var splits []float32
+	for i, k := range dl.Kids {
+		if k.Prune() {
+			kids = append(kids, k)
+			splits = append(splits, dl.Splits[i])
+		}
+	}
+	switch len(kids) {
+	case 0:
+		*dl = DockLayout{}
+		return false
+	case 1:
+		*dl = *kids[0]
+		return true
+	}
+	dl.Kids = kids
+	dl.Splits = splits
+	return true
+}
+
+// DockState is the full arrangement of the panels in a DockArea, which is
+// saved as JSON in the DockPrefs and restored on startup.  The geometry of
+// floating panel windows is saved in the WinGeomPrefs, under the window
+// name given by DockArea.FloatWinName.
+type DockState struct {
+	Root      *DockLayout          `desc:"layout of the docked panels -- nil if there are none"`
+	Floating  []string             `desc:"names of panels floating in their own window"`
+	Collapsed map[string]DockZones `desc:"panels collapsed to an edge of the dock area, with the edge they are on"`
+}
+
+// IsFloating returns true if given panel is floating in its own window
+func (ds *DockState) IsFloating(name string) bool {
+	for _, fn := range ds.Floating {
+		if fn == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Remove removes given panel from wherever it is in the state -- the
+// layout is not pruned
+func (ds *DockState) Remove(name string) {
+	if ds.Root != nil {
+		ds.Root.RemovePanel(name)
+	}
+	for i, fn := range ds.Floating {
+		if fn == name {
+			ds.Floating = append(ds.Floating[:i], ds.Floating[i+1:]...)
+			break
+		}
+	}
+	delete(ds.Collapsed, name)
+}
+
+// Prune prunes the layout, setting Root to nil if it is empty
+func (ds *DockState) Prune() {
+	if ds.Root != nil && !ds.Root.Prune() {
+		ds.Root = nil
+	}
+}
+
+// AddPanel adds given panel as a tab in the first tab group, creating the
+// layout if there is none
+func (ds *DockState) AddPanel(name string) {
+	if ds.Root == nil {
+		ds.Root = &DockLayout{Panels: []string{name}}
+		return
+	}
+	ds.Root.InsertPanel(ds.Root.TabGroups()[0], name, DockCenter)
+}
+
+// AddPanelAtEdge adds given panel along given edge of the whole layout
+func (ds *DockState) AddPanelAtEdge(name string, edge DockZones) {
+	if ds.Root == nil || edge == DockCenter {
+		ds.AddPanel(name)
+		return
+	}
+	if !ds.Root.IsTabs() && ds.Root.Dim == edge.Dim() {
+		ds.Root.fixSplits()
+		nw := &DockLayout{Panels: []string{name}}
+		sp := 1 / float32(len(ds.Root.Kids))
+		if edge.IsBefore() {
+			ds.Root.Kids = append([]*DockLayout{nw}, ds.Root.Kids...)
+			ds.Root.Splits = append([]float32{sp}, ds.Root.Splits...)
+		} else {
+			ds.Root.Kids = append(ds.Root.Kids, nw)
+			ds.Root.Splits = append(ds.Root.Splits, sp)
+		}
+		return
+	}
+	ds.Root.InsertPanel(ds.Root, name, edge)
+}
+
+// OpenJSON opens the dock state from a JSON-formatted file
+func (ds *DockState) OpenJSON(filename FileName) error {
+	b, err := ioutil.ReadFile(string(filename))
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	return json.Unmarshal(b, ds)
+}
+
+// SaveJSON saves the dock state to a JSON-formatted file
+func (ds *DockState) SaveJSON(filename FileName) error {
+	b, err := json.MarshalIndent(ds, "", "  ")
+	if err != nil {
+		log.Println(err) // unlikely
+		return err
+	}
+	err = ioutil.WriteFile(string(filename), b, 0644)
+	if err != nil {
+		log.Println(err)
+	}
+	return err
+}
+
+// DockLayoutPrefs records the saved DockState of each DockArea, by name of
+// the DockArea
+type DockLayoutPrefs map[string]*DockState
+
+// DockPrefs are the saved dock layouts, stored in the GoGi prefs directory
+var DockPrefs = DockLayoutPrefs{}
+
+// DockPrefsFileName is the name of the dock layout prefs file in GoGi prefs directory
+var DockPrefsFileName = "dock_prefs.json"
+
+// DockPrefsMu protects updating of DockPrefs
+var DockPrefsMu sync.Mutex
+
+// Open dock layout preferences from GoGi standard prefs directory --
+// called under mutex
+func (dp *DockLayoutPrefs) Open() error {
+	pdir := oswin.TheApp.GoGiPrefsDir()
+	pnm := filepath.Join(pdir, DockPrefsFileName)
+	b, err := ioutil.ReadFile(pnm)
+	if err != nil {
+		// log.Println(err) // ok to be non-existent
+		return err
+	}
+	*dp = make(DockLayoutPrefs)
+	err = json.Unmarshal(b, dp)
+	if err != nil {
+		log.Println(err)
+	}
+	return err
+}
+
+// Save dock layout preferences to GoGi standard prefs directory --
+// called under mutex
+func (dp *DockLayoutPrefs) Save() error {
+	pdir := oswin.TheApp.GoGiPrefsDir()
+	pnm := filepath.Join(pdir, DockPrefsFileName)
+	b, err := json.MarshalIndent(dp, "", "  ")
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	err = ioutil.WriteFile(pnm, b, 0644)
+	if err != nil {
+		log.Println(err)
+	}
+	return err
+}
+
+////////////////////////////////////////////////////////////////////////////////////////
+//    DockArea
+
+// DockPanel is a widget registered with a DockArea, which can be moved
+// among tab groups, collapsed to an edge, or floated in its own window
+type DockPanel struct {
+	Name   string  `desc:"unique name of the panel -- used to record its place in the layout"`
+	Title  string  `desc:"title shown on its tab, edge button and floating window"`
+	Widget Node2D  `json:"-" xml:"-" desc:"the widget shown in the panel"`
+	Win    *Window `json:"-" xml:"-" desc:"window the panel is floating in, if any"`
+}
+
+// DockArea arranges a set of panels into tab groups that are split
+// horizontally or vertically, as recorded in its DockState.  Panels can be
+// dragged by their tab onto another tab group (the edges of the group split
+// it, the center adds a tab), collapsed to an edge of the area, or floated
+// in their own window, via the context menu of their tab.  The arrangement
+// is saved by SaveLayout and restored by RestoreLayout, typically called
+// once all the panels have been added.
+type DockArea struct {
+	Layout
+	Panels     []*DockPanel               `json:"-" xml:"-" desc:"registered panels, in the order added"`
+	State      DockState                  `desc:"current arrangement of the panels"`
+	FloatSize  image.Point                `desc:"default size of a floating panel window, in standardized pixels, if there are no saved window geometry prefs for it"`
+	DockSig    ki.Signal                  `copy:"-" json:"-" xml:"-" view:"-" desc:"signal for dock area -- see DockSignals for the types"`
+	splitLays  map[*SplitView]*DockLayout `copy:"-" json:"-" xml:"-" view:"-"`
+	tabsGroups []*DockTabs                `copy:"-" json:"-" xml:"-" view:"-"`
+}
+
+var KiT_DockArea = kit.Types.AddType(&DockArea{}, DockAreaProps)
+
+// AddNewDockArea adds a new dock area to given parent node, with given name.
+func AddNewDockArea(parent ki.Ki, name string) *DockArea {
+	return parent.AddNewChild(KiT_DockArea, name).(*DockArea)
+}
+
+func (da *DockArea) CopyFieldsFrom(frm interface{}) {
+	fr := frm.(*DockArea)
+	da.Layout.CopyFieldsFrom(&fr.Layout)
+	da.FloatSize = fr.FloatSize
+}
+
+func (da *DockArea) Disconnect() {
+	da.Layout.Disconnect()
+	da.DockSig.DisconnectAll()
+}
+
+var DockAreaProps = ki.Props{
+	"EnumType:Flag":    KiT_NodeFlags,
+	"background-color": &Prefs.Colors.Background,
+	"color":            &Prefs.Colors.Font,
+	"max-width":        -1,
+	"max-height":       -1,
+}
+
+// DockSignals are signals that the DockArea can send
+type DockSignals int64
+
+const (
+	// DockLayoutChanged indicates that panels were moved, collapsed,
+	// restored, floated or docked -- data is the name of the panel
+	DockLayoutChanged DockSignals = iota
+
+	DockSignalsN
+)
+
+//go:generate stringer -type=DockSignals
+
+// PanelByName returns the registered panel with given name, nil if not found
+func (da *DockArea) PanelByName(name string) *DockPanel {
+	for _, pn := range da.Panels {
+		if pn.Name == name {
+			return pn
+		}
+	}
+	return nil
+}
+
+// AddPanel registers given widget as a panel, using its name as the panel
+// name, and adds it as a tab in the first tab group
+func (da *DockArea) AddPanel(widg Node2D, title string) *DockPanel {
+	name := widg.Name()
+	if pn := da.PanelByName(name); pn != nil {
+		log.Printf("gi.DockArea AddPanel: panel named: %v already exists\n", name)
+		return pn
+	}
+	pn := &DockPanel{Name: name, Title: title, Widget: widg}
+	da.Panels = append(da.Panels, pn)
+	da.SyncState()
+	da.State.AddPanel(name)
+	da.ConfigDock()
+	return pn
+}
+
+// AddNewPanel adds a new widget of given type as a panel with given name
+// and title, and returns the new widget
+func (da *DockArea) AddNewPanel(typ reflect.Type, name, title string) Node2D {
+	widg := ki.NewOfType(typ).(Node2D)
+	widg.InitName(widg, name)
+	da.AddPanel(widg, title)
+	return widg
+}
+
+// Center returns the layout holding the docked panels
+func (da *DockArea) Center() *Layout {
+	mid, err := da.ChildByNameTry("middle", 0)
+	if err != nil {
+		return nil
+	}
+	cen, err := mid.ChildByNameTry("center", 0)
+	if err != nil {
+		return nil
+	}
+	return cen.(*Layout)
+}
+
+// SyncState updates the State from the current splits and selected tabs of
+// the dock widgets -- called prior to any change in the layout
+func (da *DockArea) SyncState() {
+	for sv, dl := range da.splitLays {
+		if len(sv.Splits) == len(dl.Kids) {
+			dl.Splits = append([]float32{}, sv.Splits...)
+		}
+	}
+	for _, dt := range da.tabsGroups {
+		if _, idx, ok := dt.CurTab(); ok {
+			dt.Group.Cur = idx
+		}
+	}
+}
+
+// dockDetach removes given panel widget from its parent, without destroying
+// it, so that it can be added elsewhere, possibly in another window
+func dockDetach(widg Node2D) {
+	par := widg.Parent()
+	if par == nil {
+		return
+	}
+	widg.AsNode2D().DisconnectAllEvents(AllPris)
+	par.DeleteChild(widg, false)
+	widg.ClearFlag(int(ki.NodeDeleted))
+}
+
+// ConfigDock rebuilds the dock widgets according to the current State --
+// panel widgets are moved into their new places.  Call SyncState before
+// changing the State, to capture the current splits and tabs.
+func (da *DockArea) ConfigDock() {
+	da.State.Prune()
+	updt := da.UpdateStart()
+	da.SetFullReRender()
+	da.Lay = LayoutVert
+	da.ConfigEdges()
+
+	for _, pn := range da.Panels {
+		if pn.Win == nil {
+			dockDetach(pn.Widget)
+		}
+	}
+	cen := da.Center()
+	cen.DeleteChildren(ki.DestroyKids)
+	da.splitLays = make(map[*SplitView]*DockLayout)
+	da.tabsGroups = nil
+	if da.State.Root != nil {
+		da.ConfigLayout(cen, da.State.Root)
+	}
+	da.ConfigEdgeActions()
+	da.UpdateEnd(updt)
+}
+
+// ConfigEdges configures the edge bars holding collapsed panels, and the
+// center layout holding the docked panels
+func (da *DockArea) ConfigEdges() {
+	has := make(map[DockZones]bool)
+	for _, edge := range da.State.Collapsed {
+		has[edge] = true
+	}
+	config := kit.TypeAndNameList{}
+	if has[DockTop] {
+		config.Add(KiT_Frame, DockEdgeNames[DockTop])
+	}
+	config.Add(KiT_Layout, "middle")
+	if has[DockBottom] {
+		config.Add(KiT_Frame, DockEdgeNames[DockBottom])
+	}
+	da.ConfigChildren(config, ki.UniqueNames)
+
+	mid := da.ChildByName("middle", 0).(*Layout)
+	mid.Lay = LayoutHoriz
+	mid.SetStretchMax()
+	config = kit.TypeAndNameList{}
+	if has[DockLeft] {
+		config.Add(KiT_Frame, DockEdgeNames[DockLeft])
+	}
+	config.Add(KiT_Layout, "center")
+	if has[DockRight] {
+		config.Add(KiT_Frame, DockEdgeNames[DockRight])
+	}
+	mid.ConfigChildren(config, ki.UniqueNames)
+	cen := mid.ChildByName("center", 0).(*Layout)
+	cen.Lay = LayoutVert
+	cen.SetStretchMax()
+}
+
+// EdgeBar returns the bar for panels collapsed to given edge, nil if there
+// are none
+func (da *DockArea) EdgeBar(edge DockZones) *Frame {
+	var par ki.Ki = da.This()
+	if edge.Dim() == mat32.X {
+		par = da.ChildByName("middle", 0)
+	}
+	if par == nil {
+		return nil
+	}
+	bar := par.ChildByName(DockEdgeNames[edge], 0)
+	if bar == nil {
+		return nil
+	}
+	return bar.(*Frame)
+}
+
+// ConfigEdgeActions configures the buttons for restoring collapsed panels
+func (da *DockArea) ConfigEdgeActions() {
+	for edge := DockLeft; edge < DockZonesN; edge++ {
+		bar := da.EdgeBar(edge)
+		if bar == nil {
+			continue
+		}
+		bar.SetProp("background-color", &Prefs.Colors.Control)
+		bar.SetProp("padding", units.NewPx(2))
+		bar.SetProp("spacing", units.NewPx(2))
+		if edge.Dim() == mat32.X {
+			bar.Lay = LayoutVert
+			bar.SetStretchMaxHeight()
+		} else {
+			bar.Lay = LayoutHoriz
+			bar.SetStretchMaxWidth()
+		}
+		bar.DeleteChildren(ki.DestroyKids)
+		for _, pn := range da.Panels {
+			if ce, ok := da.State.Collapsed[pn.Name]; !ok || ce != edge {
+				continue
+			}
+			ac := AddNewAction(bar, pn.Name)
+			ac.SetText(pn.Title)
+			ac.Tooltip = "restore the " + pn.Title + " panel"
+			ac.Data = pn.Name
+			ac.ActionSig.Connect(da.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+				dda := recv.Embed(KiT_DockArea).(*DockArea)
+				nm := send.(*Action).Data.(string)
+				dda.RunLater(func() { dda.RestorePanel(nm) })
+			})
+		}
+	}
+}
+
+// ConfigLayout builds the widgets for given layout within given parent
+func (da *DockArea) ConfigLayout(par ki.Ki, dl *DockLayout) {
+	nm := fmt.Sprintf("dock-%d", len(*par.Children()))
+	if dl.IsTabs() {
+		dt := AddNewDockTabs(par, nm)
+		dt.Area = da
+		dt.Group = dl
+		dt.NoDeleteTabs = true
+		dt.NoDragTabs = true
+		dt.SetStretchMax()
+		for _, pnm := range dl.Panels {
+			if pn := da.PanelByName(pnm); pn != nil {
+				dt.AddTab(pn.Widget, pn.Title)
+			}
+		}
+		if dl.Cur > 0 {
+			dt.SelectTabIndex(dl.Cur)
+		}
+		da.tabsGroups = append(da.tabsGroups, dt)
+		return
+	}
+	sv := AddNewSplitView(par, nm)
+	sv.Dim = dl.Dim
+	for _, k := range dl.Kids {
+		da.ConfigLayout(sv, k)
+	}
+	dl.fixSplits()
+	sv.SetSplits(dl.Splits...)
+	da.splitLays[sv] = dl
+}
+
+// RunLater runs given function after the current event has been processed
+// -- needed for changes triggered by the dock widgets themselves, which
+// are destroyed when the layout is rebuilt
+func (da *DockArea) RunLater(fun func()) {
+	win := da.ParentWindow()
+	if win == nil {
+		fun()
+		return
+	}
+	win.RunOnWin(fun)
+}
+
+// EmitChanged emits the DockLayoutChanged signal for given panel
+func (da *DockArea) EmitChanged(name string) {
+	da.DockSig.Emit(da.This(), int64(DockLayoutChanged), name)
+}
+
+// MovePanel moves given panel relative to the tab group holding the target
+// panel: as a new tab for DockCenter, otherwise splitting that group
+func (da *DockArea) MovePanel(name, target string, zone DockZones) {
+	if da.PanelByName(name) == nil || da.State.Root == nil {
+		return
+	}
+	da.SyncState()
+	grp, _ := da.State.Root.FindPanel(target)
+	if grp == nil {
+		return
+	}
+	if sgrp, _ := da.State.Root.FindPanel(name); sgrp == grp && (zone == DockCenter || len(grp.Panels) == 1) {
+		return // nothing to do
+	}
+	da.State.Remove(name)
+	da.State.Root.InsertPanel(grp, name, zone)
+	da.ConfigDock()
+	da.EmitChanged(name)
+}
+
+// CollapsePanel collapses given docked panel to given edge of the dock area,
+// where it is shown as a button that restores it
+func (da *DockArea) CollapsePanel(name string, edge DockZones) {
+	if edge == DockCenter || da.State.Root == nil {
+		return
+	}
+	if grp, _ := da.State.Root.FindPanel(name); grp == nil {
+		return
+	}
+	da.SyncState()
+	da.State.Remove(name)
+	if da.State.Collapsed == nil {
+		da.State.Collapsed = make(map[string]DockZones)
+	}
+	da.State.Collapsed[name] = edge
+	da.ConfigDock()
+	da.EmitChanged(name)
+}
+
+// RestorePanel restores given collapsed panel, docking it along the edge
+// it was collapsed to
+func (da *DockArea) RestorePanel(name string) {
+	edge, ok := da.State.Collapsed[name]
+	if !ok {
+		return
+	}
+	da.SyncState()
+	da.State.Remove(name)
+	da.State.AddPanelAtEdge(name, edge)
+	da.ConfigDock()
+	da.EmitChanged(name)
+}
+
+// FloatWinName returns the name of the window for given floating panel --
+// its geometry is saved in WinGeomPrefs under this name
+func (da *DockArea) FloatWinName(name string) string {
+	return "dock-" + da.Nm + "-" + name
+}
+
+// FloatPanel moves given panel into its own window -- closing the window
+// docks it again
+func (da *DockArea) FloatPanel(name string) *Window {
+	pn := da.PanelByName(name)
+	if pn == nil {
+		return nil
+	}
+	if pn.Win != nil {
+		pn.Win.OSWin.Raise()
+		return pn.Win
+	}
+	da.SyncState()
+	da.State.Remove(name)
+	da.State.Floating = append(da.State.Floating, name)
+	da.ConfigDock()
+	win := da.OpenFloatWin(pn)
+	da.EmitChanged(name)
+	return win
+}
+
+// OpenFloatWin opens the window for given floating panel
+func (da *DockArea) OpenFloatWin(pn *DockPanel) *Window {
+	dockDetach(pn.Widget)
+	sz := da.FloatSize
+	if sz == image.ZP {
+		sz = image.Point{640, 480}
+	}
+	win := NewMainWindow(da.FloatWinName(pn.Name), pn.Title, sz.X, sz.Y)
+	vp := win.WinViewport2D()
+	updt := vp.UpdateStart()
+	mfr := win.SetMainFrame()
+	mfr.Lay = LayoutVert
+
+	tb := AddNewToolBar(mfr, "toolbar")
+	tb.SetStretchMaxWidth()
+	tb.AddAction(ActOpts{Label: "Dock", Icon: "wedge-down", Tooltip: "return this panel to the window it came from"},
+		win.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+			recv.Embed(KiT_Window).(*Window).CloseReq()
+		})
+	mfr.AddChild(pn.Widget)
+	pn.Widget.AsNode2D().SetStretchMax()
+	pn.Win = win
+
+	win.SetCloseReqFunc(func(w *Window) {
+		da.CloseFloatWin(pn)
+		da.RunLater(func() { da.DockPanel(pn.Name) })
+	})
+	vp.UpdateEndNoSig(updt)
+	win.GoStartEventLoop()
+	return win
+}
+
+// CloseFloatWin closes the window of given floating panel, detaching the
+// panel widget from it, without docking it
+func (da *DockArea) CloseFloatWin(pn *DockPanel) {
+	win := pn.Win
+	if win == nil {
+		return
+	}
+	dockDetach(pn.Widget)
+	pn.Win = nil
+	win.Close()
+}
+
+// DockPanel docks given floating panel as a tab in the first tab group
+func (da *DockArea) DockPanel(name string) {
+	pn := da.PanelByName(name)
+	if pn == nil || !da.State.IsFloating(name) {
+		return
+	}
+	da.CloseFloatWin(pn)
+	da.SyncState()
+	da.State.Remove(name)
+	da.State.AddPanel(name)
+	da.ConfigDock()
+	da.EmitChanged(name)
+}
+
+// SetState sets the arrangement of the panels to given state -- unknown
+// panels are ignored, and any panels not in the state are added to the
+// first tab group
+func (da *DockArea) SetState(ds *DockState) {
+	nst := DockState{Root: ds.Root, Collapsed: make(map[string]DockZones)}
+	if nst.Root != nil {
+		for _, pnm := range nst.Root.AllPanels() {
+			if da.PanelByName(pnm) == nil {
+				nst.Root.RemovePanel(pnm)
+			}
+		}
+	}
+	for pnm, edge := range ds.Collapsed {
+		if da.PanelByName(pnm) != nil && edge != DockCenter {
+			nst.Remove(pnm)
+			nst.Collapsed[pnm] = edge
+		}
+	}
+	for _, pnm := range ds.Floating {
+		if da.PanelByName(pnm) != nil && !nst.IsFloating(pnm) {
+			nst.Remove(pnm)
+			nst.Floating = append(nst.Floating, pnm)
+		}
+	}
+	nst.Prune()
+	for _, pn := range da.Panels {
+		_, col := nst.Collapsed[pn.Name]
+		if col || nst.IsFloating(pn.Name) {
+			continue
+		}
+		if nst.Root != nil {
+			if grp, _ := nst.Root.FindPanel(pn.Name); grp != nil {
+				continue
+			}
+		}
+		nst.AddPanel(pn.Name)
+	}
+	for _, pn := range da.Panels {
+		if pn.Win != nil && !nst.IsFloating(pn.Name) {
+			da.CloseFloatWin(pn)
+		}
+	}
+	da.State = nst
+	da.ConfigDock()
+	for _, pnm := range nst.Floating {
+		if pn := da.PanelByName(pnm); pn.Win == nil {
+			da.OpenFloatWin(pn)
+		}
+	}
+	da.EmitChanged("")
+}
+
+// SaveLayout saves the current arrangement of the panels in DockPrefs,
+// under the name of this dock area
+func (da *DockArea) SaveLayout() error {
+	da.SyncState()
+	DockPrefsMu.Lock()
+	defer DockPrefsMu.Unlock()
+	DockPrefs.Open() // get any changes from other apps
+	if DockPrefs == nil {
+		DockPrefs = make(DockLayoutPrefs)
+	}
+	st := da.State
+	DockPrefs[da.Nm] = &st
+	return DockPrefs.Save()
+}
+
+// RestoreLayout restores the arrangement of the panels saved in DockPrefs
+// under the name of this dock area -- returns false if none was saved
+func (da *DockArea) RestoreLayout() bool {
+	DockPrefsMu.Lock()
+	DockPrefs.Open()
+	ds, ok := DockPrefs[da.Nm]
+	DockPrefsMu.Unlock()
+	if !ok || ds == nil {
+		return false
+	}
+	da.SetState(ds)
+	return true
+}
+
+func (da *DockArea) Init2D() {
+	if len(da.Kids) == 0 {
+		da.ConfigDock()
+	}
+	da.Layout.Init2D()
+}
+
+////////////////////////////////////////////////////////////////////////////////////////
+//    DockTabs
+
+// DockTabs is a TabView showing one tab group of a DockArea -- its tabs can
+// be dragged onto other tab groups, and have a context menu for collapsing
+// and floating the panel
+type DockTabs struct {
+	TabView
+	Area  *DockArea   `copy:"-" json:"-" xml:"-" view:"-" desc:"dock area that we belong to"`
+	Group *DockLayout `copy:"-" json:"-" xml:"-" view:"-" desc:"tab group in the dock area layout that we show"`
+}
+
+var KiT_DockTabs = kit.Types.AddType(&DockTabs{}, DockTabsProps)
+
+// AddNewDockTabs adds a new dock tab group to given parent node, with given name.
+func AddNewDockTabs(parent ki.Ki, name string) *DockTabs {
+	return parent.AddNewChild(KiT_DockTabs, name).(*DockTabs)
+}
+
+var DockTabsProps = ki.Props{
+	"EnumType:Flag":    KiT_NodeFlags,
+	"border-color":     &Prefs.Colors.Border,
+	"border-width":     units.NewPx(1),
+	"background-color": &Prefs.Colors.Background,
+	"color":            &Prefs.Colors.Font,
+	"max-width":        -1,
+	"max-height":       -1,
+}
+
+// PanelName returns the name of the panel at given tab index
+func (dt *DockTabs) PanelName(idx int) string {
+	if dt.Group == nil || idx < 0 || idx >= len(dt.Group.Panels) {
+		return ""
+	}
+	return dt.Group.Panels[idx]
+}
+
+// DropZone returns the zone for a drop at given window position, along with
+// the region of the window it covers
+func (dt *DockTabs) DropZone(pos image.Point) (DockZones, image.Rectangle) {
+	dt.BBoxMu.RLock()
+	bb := dt.WinBBox
+	dt.BBoxMu.RUnlock()
+	sz := bb.Size()
+	if sz.X <= 0 || sz.Y <= 0 {
+		return DockCenter, bb
+	}
+	fx := float32(pos.X-bb.Min.X) / float32(sz.X)
+	fy := float32(pos.Y-bb.Min.Y) / float32(sz.Y)
+	zone := DockCenter
+	mind := DockZoneMargin
+	for z, d := range [DockZonesN]float32{1, fx, 1 - fx, fy, 1 - fy} {
+		if d < mind {
+			mind = d
+			zone = DockZones(z)
+		}
+	}
+	r := bb
+	switch zone {
+	case DockLeft:
+		r.Max.X = bb.Min.X + sz.X/2
+	case DockRight:
+		r.Min.X = bb.Max.X - sz.X/2
+	case DockTop:
+		r.Max.Y = bb.Min.Y + sz.Y/2
+	case DockBottom:
+		r.Min.Y = bb.Max.Y - sz.Y/2
+	}
+	return zone, r
+}
+
+// DragNDropStart starts a drag-n-drop of the panel at given tab index
+func (dt *DockTabs) DragNDropStart(idx int) {
+	_, tab, ok := dt.TabAtIndex(idx)
+	if !ok {
+		return
+	}
+	md := mimedata.NewMime(DockPanelMimeType, []byte(dt.PanelName(idx)))
+	sp := &Sprite{}
+	sp.GrabRenderFrom(tab)
+	ImageClearer(sp.Pixels, 50.0)
+	dt.ParentWindow().StartDragNDrop(dt.This(), md, sp)
+}
+
+// DragNDropTarget handles a drop of a dock panel onto this tab group
+func (dt *DockTabs) DragNDropTarget(de *dnd.Event) {
+	de.Target = dt.This()
+	de.Mod = dnd.DropMove
+	de.SetProcessed()
+	dt.HideDropHint()
+	da := dt.Area
+	name := de.Data.Text(DockPanelMimeType)
+	target := dt.PanelName(0)
+	zone, _ := dt.DropZone(de.Pos())
+	dt.ParentWindow().FinalizeDragNDrop(dnd.DropMove)
+	da.RunLater(func() { da.MovePanel(name, target, zone) })
+}
+
+// MakeDockMenu makes the menu of dock actions for the panel at given tab index
+func (dt *DockTabs) MakeDockMenu(idx int, m *Menu) {
+	name := dt.PanelName(idx)
+	if name == "" {
+		return
+	}
+	da := dt.Area
+	m.AddAction(ActOpts{Label: "Float", Tooltip: "move the panel into its own window"},
+		da.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+			dda := recv.Embed(KiT_DockArea).(*DockArea)
+			dda.RunLater(func() { dda.FloatPanel(name) })
+		})
+	m.AddSeparator("sep-collapse")
+	for edge := DockLeft; edge < DockZonesN; edge++ {
+		ed := edge
+		m.AddAction(ActOpts{Label: "Collapse " + strings.Title(DockEdgeNames[edge]), Tooltip: "collapse the panel to this edge of the dock area"},
+			da.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+				dda := recv.Embed(KiT_DockArea).(*DockArea)
+				dda.RunLater(func() { dda.CollapsePanel(name, ed) })
+			})
+	}
+}
+
+// DockTabsEvents connects the drag-n-drop and context menu events -- HiPri
+// so that drops of dock panels are not taken by widgets within the panels
+func (dt *DockTabs) DockTabsEvents() {
+	dt.ConnectEvent(oswin.MouseEvent, HiPri, func(recv, send ki.Ki, sig int64, d interface{}) {
+		me := d.(*mouse.Event)
+		if me.Action != mouse.Release || me.Button != mouse.Right {
+			return
+		}
+		dtt := recv.Embed(KiT_DockTabs).(*DockTabs)
+		idx := dtt.TabIndexAt(me.Pos())
+		if idx < 0 {
+			return
+		}
+		me.SetProcessed()
+		var men Menu
+		dtt.MakeDockMenu(idx, &men)
+		if len(men) == 0 {
+			return
+		}
+		pos := me.Pos()
+		PopupMenu(men, pos.X, pos.Y, dtt.ViewportSafe(), dtt.Nm+"-menu")
+	})
+	dt.ConnectEvent(oswin.DNDEvent, HiPri, func(recv, send ki.Ki, sig int64, d interface{}) {
+		de := d.(*dnd.Event)
+		dtt := recv.Embed(KiT_DockTabs).(*DockTabs)
+		switch de.Action {
+		case dnd.Start:
+			if idx := dtt.TabIndexAt(de.Pos()); idx >= 0 {
+				de.SetProcessed()
+				dtt.DragNDropStart(idx)
+			}
+		case dnd.DropOnTarget:
+			if de.Data.HasType(DockPanelMimeType) {
+				dtt.DragNDropTarget(de)
+			}
+		}
+	})
+	dt.ConnectEvent(oswin.DNDMoveEvent, HiPri, func(recv, send ki.Ki, sig int64, d interface{}) {
+		dtt := recv.Embed(KiT_DockTabs).(*DockTabs)
+		if !dtt.IsDragOf(DockPanelMimeType) {
+			return
+		}
+		de := d.(*dnd.MoveEvent)
+		_, r := dtt.DropZone(de.Pos())
+		dtt.ShowDropHint(r)
+	})
+	dt.ConnectEvent(oswin.DNDFocusEvent, HiPri, func(recv, send ki.Ki, sig int64, d interface{}) {
+		dtt := recv.Embed(KiT_DockTabs).(*DockTabs)
+		if !dtt.IsDragOf(DockPanelMimeType) {
+			return
+		}
+		de := d.(*dnd.FocusEvent)
+		switch de.Action {
+		case dnd.Enter:
+			dtt.ParentWindow().DNDSetCursor(dnd.DropMove)
+		case dnd.Exit:
+			dtt.ParentWindow().DNDNotCursor()
+			dtt.HideDropHint()
+		}
+	})
+}
+
+func (dt *DockTabs) ConnectEvents2D() {
+	dt.TabView.ConnectEvents2D()
+	dt.DockTabsEvents()
+}
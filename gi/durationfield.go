@@ -0,0 +1,386 @@
+// Copyright (c) 2018, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"fmt"
+	"image"
+	"time"
+
+	"github.com/goki/gi/units"
+	"github.com/goki/ki/ki"
+	"github.com/goki/ki/kit"
+	"github.com/goki/mat32"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////
+// DurationPicker
+
+// DurationPicker has SpinBox fields for choosing the hours, minutes,
+// seconds and optionally milliseconds of a time.Duration -- the sign of the
+// Duration is preserved, and any smaller units are dropped when it is edited.
+type DurationPicker struct {
+	Frame
+	Dur               time.Duration `desc:"current duration value"`
+	ShowMSecs         bool          `desc:"show a field for milliseconds"`
+	DurationPickerSig ki.Signal     `copy:"-" json:"-" xml:"-" view:"-" desc:"signal for duration picker -- has no signal types, just emitted when the duration changes, with the time.Duration as data"`
+}
+
+var KiT_DurationPicker = kit.Types.AddType(&DurationPicker{}, DurationPickerProps)
+
+// AddNewDurationPicker adds a new duration picker to given parent node, with given name.
+func AddNewDurationPicker(parent ki.Ki, name string) *DurationPicker {
+	return parent.AddNewChild(KiT_DurationPicker, name).(*DurationPicker)
+}
+
+func (dp *DurationPicker) CopyFieldsFrom(frm interface{}) {
+	fr := frm.(*DurationPicker)
+	dp.Frame.CopyFieldsFrom(&fr.Frame)
+	dp.Dur = fr.Dur
+	dp.ShowMSecs = fr.ShowMSecs
+}
+
+func (dp *DurationPicker) Disconnect() {
+	dp.Frame.Disconnect()
+	dp.DurationPickerSig.DisconnectAll()
+}
+
+var DurationPickerProps = ki.Props{
+	"EnumType:Flag":  KiT_NodeFlags,
+	"border-width":   units.NewPx(0),
+	"padding":        units.NewPx(2),
+	"margin":         units.NewPx(2),
+	"vertical-align": AlignMiddle,
+	"color":          &Prefs.Colors.Font,
+}
+
+// durationPickerFields are the names, units and maximum values of the
+// fields of a DurationPicker -- 0 = no maximum
+var durationPickerFields = []struct {
+	name string
+	unit time.Duration
+	max  float32
+}{
+	{"hours", time.Hour, 0},
+	{"minutes", time.Minute, 59},
+	{"seconds", time.Second, 59},
+	{"msecs", time.Millisecond, 999},
+}
+
+// DurationParts returns the whole hours, minutes, seconds and milliseconds
+// of the magnitude of given duration
+func DurationParts(d time.Duration) (h, m, s, ms int64) {
+	if d < 0 {
+		d = -d
+	}
+	h = int64(d / time.Hour)
+	m = int64(d % time.Hour / time.Minute)
+	s = int64(d % time.Minute / time.Second)
+	ms = int64(d % time.Second / time.Millisecond)
+	return
+}
+
+// SetDuration sets the duration value, updating the display
+func (dp *DurationPicker) SetDuration(d time.Duration) {
+	updt := dp.UpdateStart()
+	dp.Dur = d
+	dp.UpdateFields()
+	dp.UpdateEnd(updt)
+}
+
+// Config configures the fields as needed
+func (dp *DurationPicker) Config() {
+	dp.Lay = LayoutHoriz
+	config := kit.TypeAndNameList{}
+	for _, fld := range durationPickerFields {
+		if fld.name == "msecs" && !dp.ShowMSecs {
+			continue
+		}
+		config.Add(KiT_SpinBox, fld.name)
+		config.Add(KiT_Label, "lbl-"+fld.name)
+	}
+	mods, updt := dp.ConfigChildren(config, ki.UniqueNames)
+	if mods {
+		for _, fld := range durationPickerFields {
+			sb, ok := dp.ChildByName(fld.name, 0).(*SpinBox)
+			if !ok {
+				continue
+			}
+			sb.Defaults()
+			sb.SetMinMax(true, 0, fld.max > 0, fld.max)
+			sb.Step = 1
+			sb.PageStep = 10
+			sb.Format = "%d"
+			sb.SpinBoxSig.ConnectOnly(dp.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+				dpp := recv.Embed(KiT_DurationPicker).(*DurationPicker)
+				dpp.FieldsToDuration()
+			})
+			if lbl, ok := dp.ChildByName("lbl-"+fld.name, 0).(*Label); ok {
+				lbl.SetText(TrText(dp, fld.name))
+				lbl.SetProp("vertical-align", AlignMiddle)
+			}
+		}
+		dp.UpdateEnd(updt)
+	}
+	dp.UpdateFields()
+}
+
+// UpdateFields updates the field values from the Dur
+func (dp *DurationPicker) UpdateFields() {
+	h, m, s, ms := DurationParts(dp.Dur)
+	vals := map[string]int64{"hours": h, "minutes": m, "seconds": s, "msecs": ms}
+	for fld, v := range vals {
+		if sb, ok := dp.ChildByName(fld, 0).(*SpinBox); ok {
+			sb.SetValue(float32(v))
+		}
+	}
+}
+
+// FieldsToDuration sets the Dur from the current field values, and emits
+// the DurationPickerSig signal
+func (dp *DurationPicker) FieldsToDuration() {
+	var d time.Duration
+	for _, fld := range durationPickerFields {
+		if sb, ok := dp.ChildByName(fld.name, 0).(*SpinBox); ok {
+			d += time.Duration(sb.Value) * fld.unit
+		}
+	}
+	if dp.Dur < 0 {
+		d = -d
+	}
+	dp.Dur = d
+	dp.DurationPickerSig.Emit(dp.This(), 0, dp.Dur)
+}
+
+func (dp *DurationPicker) Init2D() {
+	dp.Frame.Init2D()
+	dp.Config()
+}
+
+////////////////////////////////////////////////////////////////////////////////////////
+// DurationField
+
+// DurationField combines a TextField for typing a time.Duration, in the
+// time.ParseDuration format (e.g., 1h30m or 250ms), with an action that pops
+// up a DurationPicker to choose it with spin boxes -- all configured within
+// the Parts of the widget.
+type DurationField struct {
+	PartsWidgetBase
+	Dur         time.Duration `xml:"dur" desc:"current duration value"`
+	Icon        IconName      `view:"show-name" desc:"icon to use for the picker action -- defaults to clock"`
+	DurationSig ki.Signal     `copy:"-" json:"-" xml:"-" view:"-" desc:"signal for duration field -- has no signal types, just emitted when the value changes, with the time.Duration as data"`
+}
+
+var KiT_DurationField = kit.Types.AddType(&DurationField{}, DurationFieldProps)
+
+// AddNewDurationField adds a new duration field to given parent node, with given name.
+func AddNewDurationField(parent ki.Ki, name string) *DurationField {
+	return parent.AddNewChild(KiT_DurationField, name).(*DurationField)
+}
+
+func (df *DurationField) CopyFieldsFrom(frm interface{}) {
+	fr := frm.(*DurationField)
+	df.PartsWidgetBase.CopyFieldsFrom(&fr.PartsWidgetBase)
+	df.Dur = fr.Dur
+	df.Icon = fr.Icon
+}
+
+func (df *DurationField) Disconnect() {
+	df.PartsWidgetBase.Disconnect()
+	df.DurationSig.DisconnectAll()
+}
+
+var DurationFieldProps = ki.Props{
+	"EnumType:Flag": KiT_NodeFlags,
+	"#picker": ki.Props{
+		"max-width":  units.NewEx(2),
+		"max-height": units.NewEx(2),
+		"margin":     units.NewPx(1),
+		"padding":    units.NewPx(0),
+		"fill":       &Prefs.Colors.Icon,
+		"stroke":     &Prefs.Colors.Font,
+	},
+	"#text-field": ki.Props{
+		"min-width": units.NewCh(8),
+		"margin":    units.NewPx(2),
+		"padding":   units.NewPx(2),
+		"clear-act": false,
+	},
+}
+
+// ValidateText checks that the text is a valid duration, satisfying the
+// TextValidator interface for the text-field part
+func (df *DurationField) ValidateText(txt string) error {
+	if _, err := time.ParseDuration(txt); err != nil {
+		return fmt.Errorf("must be a duration such as 300ms, 1.5h or 2h45m")
+	}
+	return nil
+}
+
+// SetDuration sets the duration value and updates the display
+func (df *DurationField) SetDuration(d time.Duration) {
+	updt := df.UpdateStart()
+	df.Dur = d
+	df.UpdateEnd(updt)
+}
+
+// SetDurationAction calls SetDuration and also emits the signal
+func (df *DurationField) SetDurationAction(d time.Duration) {
+	df.SetDuration(d)
+	df.DurationSig.Emit(df.This(), 0, df.Dur)
+}
+
+func (df *DurationField) ConfigParts() {
+	if df.Icon.IsNil() {
+		df.Icon = IconName("clock")
+	}
+	df.Parts.Lay = LayoutHoriz
+	df.Parts.SetProp("vertical-align", AlignMiddle)
+	config := kit.TypeAndNameList{}
+	config.Add(KiT_TextField, "text-field")
+	if !df.IsInactive() {
+		config.Add(KiT_Action, "picker")
+	}
+	mods, updt := df.Parts.ConfigChildren(config, ki.UniqueNames)
+	if mods || RebuildDefaultStyles {
+		if !df.IsInactive() {
+			pk := df.Parts.ChildByName("picker", 1).(*Action)
+			pk.SetProp("no-focus", true)
+			pk.Icon = df.Icon
+			pk.Tooltip = "choose the hours, minutes and seconds"
+			df.StylePart(Node2D(pk))
+			pk.ActionSig.ConnectOnly(df.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+				dff := recv.Embed(KiT_DurationField).(*DurationField)
+				dff.OpenPicker()
+			})
+		}
+		tf := df.Parts.ChildByName("text-field", 0).(*TextField)
+		tf.SetFlagState(df.IsInactive(), int(Inactive))
+		tf.SetProp("clear-act", false)
+		df.StylePart(Node2D(tf))
+		tf.Placeholder = "e.g., 1h30m"
+		tf.Txt = df.Dur.String()
+		tf.Validator = df
+		if !df.IsInactive() {
+			tf.TextFieldSig.ConnectOnly(df.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+				if sig == int64(TextFieldDone) || sig == int64(TextFieldDeFocused) {
+					dff := recv.Embed(KiT_DurationField).(*DurationField)
+					tf := send.(*TextField)
+					d, err := time.ParseDuration(tf.Text())
+					if err == nil && d != dff.Dur {
+						dff.SetDurationAction(d)
+					}
+				}
+			})
+		}
+		df.UpdateEnd(updt)
+	}
+}
+
+func (df *DurationField) ConfigPartsIfNeeded() {
+	if !df.Parts.HasChildren() {
+		df.ConfigParts()
+	}
+	tf := df.Parts.ChildByName("text-field", 0).(*TextField)
+	txt := df.Dur.String()
+	if tf.Txt != txt {
+		tf.SetText(txt)
+	}
+}
+
+// OpenPicker pops up the duration picker below the field -- the Ok button
+// applies the chosen duration, and Escape or Cancel closes without change.
+// Milliseconds are shown if the duration has any.
+func (df *DurationField) OpenPicker() {
+	win := df.ParentWindow()
+	if win == nil {
+		return
+	}
+	PickerPopup(&df.WidgetBase, func(pvp *Viewport2D, frame *Frame) ki.Ki {
+		dp := AddNewDurationPicker(frame, "duration")
+		dp.Dur = df.Dur
+		dp.ShowMSecs = df.Dur%time.Second != 0
+		AddPickerButtons(frame, df.This(), func() {
+			win.ClosePopup(pvp.This())
+			if dp.Dur != df.Dur {
+				df.SetDurationAction(dp.Dur)
+			}
+		}, func() { win.ClosePopup(pvp.This()) })
+		return dp.This()
+	})
+}
+
+func (df *DurationField) Init2D() {
+	df.Init2DWidget()
+	df.ConfigParts()
+}
+
+// StyleDurationField does duration field styling -- sets StyMu Lock
+func (df *DurationField) StyleDurationField() {
+	df.StyMu.Lock()
+	defer df.StyMu.Unlock()
+
+	hasTempl, saveTempl := df.Sty.FromTemplate()
+	if !hasTempl || saveTempl {
+		df.Style2DWidget()
+	} else {
+		df.Sty.SetUnitContext(df.Viewport, mat32.Vec2Zero)
+	}
+	if hasTempl && saveTempl {
+		df.Sty.SaveTemplate()
+	}
+}
+
+func (df *DurationField) Style2D() {
+	df.StyleDurationField()
+	df.StyMu.Lock()
+	df.LayState.SetFromStyle(&df.Sty.Layout) // also does reset
+	df.StyMu.Unlock()
+	df.ConfigParts()
+}
+
+func (df *DurationField) Size2D(iter int) {
+	df.Size2DParts(iter)
+}
+
+func (df *DurationField) Layout2D(parBBox image.Rectangle, iter int) bool {
+	df.ConfigPartsIfNeeded()
+	df.Layout2DBase(parBBox, true, iter) // init style
+	df.Layout2DParts(parBBox, iter)
+	return df.Layout2DChildren(iter)
+}
+
+func (df *DurationField) Render2D() {
+	if df.FullReRenderIfNeeded() {
+		return
+	}
+	if df.PushBounds() {
+		df.This().(Node2D).ConnectEvents2D()
+		df.ConfigPartsIfNeeded()
+		df.Render2DChildren()
+		df.Render2DParts()
+		df.PopBounds()
+	} else {
+		df.DisconnectAllEvents(RegPri)
+	}
+}
+
+func (df *DurationField) ConnectEvents2D() {
+	df.HoverTooltipEvent()
+}
+
+func (df *DurationField) AccessInfo2D(ai *AccessInfo) {
+	df.PartsWidgetBase.AccessInfo2D(ai)
+	ai.Role = AccessTextField
+	ai.Value = df.Dur.String()
+	ai.SetState(!df.IsInactive(), AccessEditable)
+}
+
+func (df *DurationField) HasFocus2D() bool {
+	if df.IsInactive() {
+		return false
+	}
+	return df.ContainsFocus() // needed for getting key events
+}
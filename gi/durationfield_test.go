@@ -0,0 +1,29 @@
+// Copyright (c) 2018, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDurationParts(t *testing.T) {
+	tests := []struct {
+		d             time.Duration
+		h, m, s, msec int64
+	}{
+		{0, 0, 0, 0, 0},
+		{90 * time.Minute, 1, 30, 0, 0},
+		{26*time.Hour + 5*time.Second, 26, 0, 5, 0},
+		{1500 * time.Millisecond, 0, 0, 1, 500},
+		{-(2*time.Hour + 45*time.Minute), 2, 45, 0, 0},
+	}
+	for _, tt := range tests {
+		h, m, s, ms := DurationParts(tt.d)
+		if h != tt.h || m != tt.m || s != tt.s || ms != tt.msec {
+			t.Errorf("DurationParts(%v) = %v, %v, %v, %v; want %v, %v, %v, %v", tt.d, h, m, s, ms, tt.h, tt.m, tt.s, tt.msec)
+		}
+	}
+}
@@ -45,7 +45,7 @@ type FontStyle struct {
 func (fs *FontStyle) Defaults() {
 	fs.Color.SetColor(color.Black)
 	fs.Opacity = 1.0
-	fs.Size = units.NewPt(12)
+	fs.Size = units.NewPt(FontSizePoints["medium"])
 }
 
 // SetStylePost does any updates after generic xml-tag property setting -- use
@@ -155,6 +155,16 @@ func (ic *Icon) Render2D() {
 	}
 }
 
+// AccessInfo2D exposes icons as images, named by their tooltip or icon name
+func (ic *Icon) AccessInfo2D(ai *AccessInfo) {
+	ic.WidgetBase.AccessInfo2D(ai)
+	ai.Role = AccessImage
+	ai.Label = ai.Description
+	if ai.Label == "" {
+		ai.Label = ic.UniqueNm
+	}
+}
+
 ////////////////////////////////////////////////////////////////////////////////////////
 //  IconMgr
 
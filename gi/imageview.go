@@ -0,0 +1,812 @@
+// Copyright (c) 2018, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/gif"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/anthonynsimon/bild/adjust"
+	"github.com/anthonynsimon/bild/transform"
+	"github.com/goki/gi/oswin"
+	"github.com/goki/gi/oswin/key"
+	"github.com/goki/gi/oswin/mouse"
+	"github.com/goki/gi/units"
+	"github.com/goki/ki/ki"
+	"github.com/goki/ki/kit"
+	"github.com/goki/mat32"
+	"golang.org/x/image/draw"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////
+// ImageView
+
+// ImageFitModes are the ways that an ImageView fits the image into its view
+type ImageFitModes int32
+
+const (
+	// ImageFit shows the whole image, as large as fits in the view
+	ImageFit ImageFitModes = iota
+
+	// ImageFill fills the whole view with the image, cropping the parts
+	// that do not fit
+	ImageFill
+
+	// ImageActual shows the image at its actual size: 1 image pixel per dot
+	ImageActual
+
+	// ImageZoomed shows the image at the Zoom and Center set by the user
+	ImageZoomed
+
+	ImageFitModesN
+)
+
+//go:generate stringer -type=ImageFitModes
+
+var KiT_ImageFitModes = kit.Enums.AddEnumAltLower(ImageFitModesN, kit.NotBitFlag, nil, "Image")
+
+func (ev ImageFitModes) MarshalJSON() ([]byte, error)  { return kit.EnumMarshalJSON(ev) }
+func (ev *ImageFitModes) UnmarshalJSON(b []byte) error { return kit.EnumUnmarshalJSON(ev, b) }
+
+// ImageViewPixelGridZoom is the default zoom factor (dots per image pixel)
+// at and above which ImageView shows a grid around pixels and a read-out of
+// the color of the pixel under the mouse
+var ImageViewPixelGridZoom = float32(8)
+
+// ImageView is a widget for viewing images, with fitting and zooming
+// (mouse wheel, around the mouse), panning by dragging, a pixel grid and
+// color read-out at high zoom, rotation and flipping, brightness, contrast
+// and gamma adjustments, and playback of multi-frame (animated GIF)
+// images.  Unlike Bitmap, the size of the view does not depend on the
+// image.  Double-click toggles between fitting the image and the actual
+// size, the zoom keys zoom once it has been clicked to give it the focus,
+// and the context menu has the other view settings.
+type ImageView struct {
+	WidgetBase
+	Filename   FileName      `desc:"file name of image loaded -- set by OpenImage"`
+	Fit        ImageFitModes `desc:"how the image is fit into the view -- set to ImageZoomed by zooming and panning with the mouse"`
+	Zoom       float32       `desc:"scale of the image in dots per image pixel, for ImageZoomed -- set from the view size for the other Fit modes"`
+	Center     mat32.Vec2    `desc:"position in the (rotated) image at the center of the view, for ImageZoomed"`
+	Rotation   int           `desc:"clockwise rotation of the image, in multiples of 90 degrees"`
+	FlipH      bool          `desc:"flip the image horizontally (after rotation)"`
+	FlipV      bool          `desc:"flip the image vertically (after rotation)"`
+	Brightness float64       `min:"-1" max:"1" step:"0.05" desc:"brightness adjustment, from -1 to 1 -- 0 = none"`
+	Contrast   float64       `min:"-1" max:"1" step:"0.05" desc:"contrast adjustment, from -1 to 1 -- 0 = none"`
+	Gamma      float64       `min:"0" step:"0.1" desc:"gamma correction -- 0 or 1 = none"`
+	GridZoom   float32       `desc:"zoom at and above which a grid around pixels and a read-out of the pixel under the mouse are shown -- 0 = ImageViewPixelGridZoom"`
+	Frames     []*image.RGBA `copy:"-" json:"-" xml:"-" view:"-" desc:"the frames of the image -- one for still images, more for animations"`
+	Delays     []int         `copy:"-" json:"-" xml:"-" view:"-" desc:"delay after each frame of an animation, in 100ths of a second"`
+	Frame      int           `copy:"-" json:"-" xml:"-" view:"-" desc:"current frame shown"`
+	Playing    bool          `copy:"-" json:"-" xml:"-" view:"-" desc:"animation is playing -- frames advance while the view is in a window, starting at its first render"`
+	Disp       *image.RGBA   `copy:"-" json:"-" xml:"-" view:"-" desc:"the current frame, rotated, flipped and adjusted, as displayed"`
+	HoverPix   image.Point   `copy:"-" json:"-" xml:"-" view:"-" desc:"pixel of the displayed image under the mouse"`
+	HoverOn    bool          `copy:"-" json:"-" xml:"-" view:"-" desc:"mouse is over a pixel of the image"`
+	dispFrame  int
+	dispValid  bool
+	viewPos    mat32.Vec2
+	viewSize   mat32.Vec2
+	playGen    int
+	playTimer  bool
+	tr         TextRender
+}
+
+var KiT_ImageView = kit.Types.AddType(&ImageView{}, ImageViewProps)
+
+// AddNewImageView adds a new image view to given parent node, with given name.
+func AddNewImageView(parent ki.Ki, name string) *ImageView {
+	return parent.AddNewChild(KiT_ImageView, name).(*ImageView)
+}
+
+func (iv *ImageView) CopyFieldsFrom(frm interface{}) {
+	fr := frm.(*ImageView)
+	iv.WidgetBase.CopyFieldsFrom(&fr.WidgetBase)
+	iv.Filename = fr.Filename
+	iv.Fit = fr.Fit
+	iv.Zoom = fr.Zoom
+	iv.Center = fr.Center
+	iv.Rotation = fr.Rotation
+	iv.FlipH = fr.FlipH
+	iv.FlipV = fr.FlipV
+	iv.Brightness = fr.Brightness
+	iv.Contrast = fr.Contrast
+	iv.Gamma = fr.Gamma
+	iv.GridZoom = fr.GridZoom
+	iv.Frames = fr.Frames
+	iv.Delays = fr.Delays
+	iv.dispValid = false
+}
+
+func (iv *ImageView) Disconnect() {
+	iv.WidgetBase.Disconnect()
+	iv.Stop()
+}
+
+// OpenImage opens an image file for viewing -- all the frames of animated
+// GIF files are loaded, and played
+func (iv *ImageView) OpenImage(filename FileName) error {
+	path := string(filename)
+	var err error
+	if strings.ToLower(filepath.Ext(path)) == ".gif" {
+		err = iv.openGIF(path)
+	} else {
+		var img image.Image
+		img, err = OpenImage(path)
+		if err == nil {
+			iv.SetImage(img)
+		}
+	}
+	if err != nil {
+		log.Printf("gi.ImageView.OpenImage -- could not open file: %v, err: %v\n", filename, err)
+		return err
+	}
+	iv.Filename = filename
+	return nil
+}
+
+// openGIF opens all the frames of a gif file
+func (iv *ImageView) openGIF(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	g, err := gif.DecodeAll(file)
+	if err != nil {
+		return err
+	}
+	iv.SetFrames(GIFFrames(g), g.Delay)
+	return nil
+}
+
+// GIFFrames returns the full frames of given gif, composing each frame over
+// the previous ones according to their disposal methods
+func GIFFrames(g *gif.GIF) []*image.RGBA {
+	bounds := image.Rect(0, 0, g.Config.Width, g.Config.Height)
+	if bounds.Empty() && len(g.Image) > 0 {
+		bounds = g.Image[0].Bounds()
+	}
+	cur := image.NewRGBA(bounds)
+	frames := make([]*image.RGBA, len(g.Image))
+	for i, fr := range g.Image {
+		var prev *image.RGBA
+		disp := byte(0)
+		if i < len(g.Disposal) {
+			disp = g.Disposal[i]
+		}
+		if disp == gif.DisposalPrevious {
+			prev = image.NewRGBA(bounds)
+			draw.Draw(prev, bounds, cur, bounds.Min, draw.Src)
+		}
+		draw.Draw(cur, fr.Bounds(), fr, fr.Bounds().Min, draw.Over)
+		frames[i] = image.NewRGBA(bounds)
+		draw.Draw(frames[i], bounds, cur, bounds.Min, draw.Src)
+		switch disp {
+		case gif.DisposalBackground:
+			draw.Draw(cur, fr.Bounds(), image.Transparent, image.ZP, draw.Src)
+		case gif.DisposalPrevious:
+			cur = prev
+		}
+	}
+	return frames
+}
+
+// SetImage sets a single (still) image to view -- the image is copied
+func (iv *ImageView) SetImage(img image.Image) {
+	rgba := image.NewRGBA(image.Rectangle{Max: img.Bounds().Size()})
+	draw.Draw(rgba, rgba.Bounds(), img, img.Bounds().Min, draw.Src)
+	iv.SetFrames([]*image.RGBA{rgba}, nil)
+}
+
+// SetFrames sets the frames of an animation to view, with given delays
+// after each frame in 100ths of a second, and starts playing it if there is
+// more than one frame
+func (iv *ImageView) SetFrames(frames []*image.RGBA, delays []int) {
+	iv.Stop()
+	iv.Frames = frames
+	iv.Delays = delays
+	iv.Frame = 0
+	iv.dispValid = false
+	iv.UpdateSig()
+	if len(frames) > 1 {
+		iv.Play()
+	}
+}
+
+// NFrames returns the number of frames of the image
+func (iv *ImageView) NFrames() int {
+	return len(iv.Frames)
+}
+
+// Play starts playing the frames of an animation -- if the view is not in a
+// window yet, the frames start advancing at its first render
+func (iv *ImageView) Play() {
+	if iv.Playing || len(iv.Frames) < 2 {
+		return
+	}
+	iv.Playing = true
+	iv.playGen++
+	iv.nextFrameAfter(iv.playGen)
+}
+
+// Stop stops playing an animation
+func (iv *ImageView) Stop() {
+	iv.Playing = false
+	iv.playGen++
+}
+
+// TogglePlay toggles playing of an animation
+func (iv *ImageView) TogglePlay() {
+	if iv.Playing {
+		iv.Stop()
+	} else {
+		iv.Play()
+	}
+}
+
+// SetFrame sets the frame to show
+func (iv *ImageView) SetFrame(frame int) {
+	if frame < 0 || frame >= len(iv.Frames) {
+		return
+	}
+	iv.Frame = frame
+	iv.UpdateSig()
+}
+
+// nextFrameAfter advances to the next frame after the delay of the current
+// frame, if still playing with given play generation -- the frame is
+// advanced on the window goroutine, via RunOnWin.  Without a window, the
+// timer is not started, and Render2D starts it when the view is shown.
+func (iv *ImageView) nextFrameAfter(gen int) {
+	win := iv.ParentWindow()
+	if win == nil || win.IsClosed() {
+		iv.playTimer = false
+		return
+	}
+	delay := 10
+	if iv.Frame < len(iv.Delays) && iv.Delays[iv.Frame] > 0 {
+		delay = iv.Delays[iv.Frame]
+	}
+	iv.playTimer = true
+	time.AfterFunc(time.Duration(delay)*10*time.Millisecond, func() {
+		if win.IsClosed() {
+			if iv.playGen == gen {
+				iv.playTimer = false
+			}
+			return
+		}
+		win.RunOnWin(func() {
+			if iv.playGen != gen {
+				return
+			}
+			iv.playTimer = false
+			if !iv.Playing || iv.This() == nil || iv.IsDestroyed() || iv.IsDeleted() {
+				return
+			}
+			if iv.This().(Node2D).IsVisible() {
+				iv.Frame = (iv.Frame + 1) % len(iv.Frames)
+				iv.UpdateSig()
+			}
+			iv.nextFrameAfter(gen)
+		})
+	})
+}
+
+// SetFit sets the fit mode and updates the view
+func (iv *ImageView) SetFit(fit ImageFitModes) {
+	iv.Fit = fit
+	iv.UpdateSig()
+}
+
+// Rotate rotates the image by given number of 90 degree steps clockwise
+// (negative for counter-clockwise)
+func (iv *ImageView) Rotate(steps int) {
+	iv.Rotation = ((iv.Rotation+steps)%4 + 4) % 4
+	iv.dispValid = false
+	iv.UpdateSig()
+}
+
+// ToggleFlipH toggles horizontal flipping of the image
+func (iv *ImageView) ToggleFlipH() {
+	iv.FlipH = !iv.FlipH
+	iv.dispValid = false
+	iv.UpdateSig()
+}
+
+// ToggleFlipV toggles vertical flipping of the image
+func (iv *ImageView) ToggleFlipV() {
+	iv.FlipV = !iv.FlipV
+	iv.dispValid = false
+	iv.UpdateSig()
+}
+
+// SetAdjust sets the brightness, contrast and gamma adjustments -- see the
+// fields for the ranges
+func (iv *ImageView) SetAdjust(brightness, contrast, gamma float64) {
+	iv.Brightness = brightness
+	iv.Contrast = contrast
+	iv.Gamma = gamma
+	iv.dispValid = false
+	iv.UpdateSig()
+}
+
+// ResetAdjust resets rotation, flipping and all adjustments
+func (iv *ImageView) ResetAdjust() {
+	iv.Rotation = 0
+	iv.FlipH, iv.FlipV = false, false
+	iv.SetAdjust(0, 0, 0)
+}
+
+// UpdateImage updates the displayed image after the fields for rotation,
+// flipping or adjustments have been set directly
+func (iv *ImageView) UpdateImage() {
+	iv.dispValid = false
+	iv.UpdateSig()
+}
+
+// ImageRotate90 returns a copy of given image rotated by given number of
+// 90 degree steps clockwise -- exact, without any interpolation
+func ImageRotate90(img *image.RGBA, steps int) *image.RGBA {
+	steps = (steps%4 + 4) % 4
+	if steps == 0 {
+		return img
+	}
+	sz := img.Bounds().Size()
+	osz := sz
+	if steps != 2 {
+		osz = image.Point{sz.Y, sz.X}
+	}
+	out := image.NewRGBA(image.Rectangle{Max: osz})
+	min := img.Bounds().Min
+	for y := 0; y < sz.Y; y++ {
+		for x := 0; x < sz.X; x++ {
+			var ox, oy int
+			switch steps {
+			case 1:
+				ox, oy = sz.Y-1-y, x
+			case 2:
+				ox, oy = sz.X-1-x, sz.Y-1-y
+			case 3:
+				ox, oy = y, sz.X-1-x
+			}
+			si := img.PixOffset(min.X+x, min.Y+y)
+			di := out.PixOffset(ox, oy)
+			copy(out.Pix[di:di+4], img.Pix[si:si+4])
+		}
+	}
+	return out
+}
+
+// UpdateDisp updates the displayed image from the current frame, if needed
+func (iv *ImageView) UpdateDisp() {
+	if len(iv.Frames) == 0 {
+		iv.Disp = nil
+		return
+	}
+	if iv.Frame >= len(iv.Frames) {
+		iv.Frame = 0
+	}
+	if iv.dispValid && iv.dispFrame == iv.Frame && iv.Disp != nil {
+		return
+	}
+	iv.dispValid = true
+	iv.dispFrame = iv.Frame
+	img := ImageRotate90(iv.Frames[iv.Frame], iv.Rotation)
+	if iv.FlipH {
+		img = transform.FlipH(img)
+	}
+	if iv.FlipV {
+		img = transform.FlipV(img)
+	}
+	if iv.Brightness != 0 {
+		img = adjust.Brightness(img, iv.Brightness)
+	}
+	if iv.Contrast != 0 {
+		img = adjust.Contrast(img, iv.Contrast)
+	}
+	if iv.Gamma > 0 && iv.Gamma != 1 {
+		img = adjust.Gamma(img, iv.Gamma)
+	}
+	iv.Disp = img
+}
+
+// PixelGridZoom returns the zoom at and above which the pixel grid and
+// read-out are shown
+func (iv *ImageView) PixelGridZoom() float32 {
+	if iv.GridZoom > 0 {
+		return iv.GridZoom
+	}
+	return ImageViewPixelGridZoom
+}
+
+// UpdateZoom sets the Zoom and Center according to the Fit mode, for given
+// view size and image size
+func (iv *ImageView) UpdateZoom(vsz mat32.Vec2, isz image.Point) {
+	if iv.Fit == ImageZoomed && iv.Zoom > 0 {
+		return
+	}
+	sz := mat32.NewVec2FmPoint(isz)
+	iv.Center = sz.MulScalar(0.5)
+	switch iv.Fit {
+	case ImageFill:
+		iv.Zoom = mat32.Max(vsz.X/sz.X, vsz.Y/sz.Y)
+	case ImageActual:
+		iv.Zoom = 1
+	default:
+		iv.Zoom = mat32.Min(vsz.X/sz.X, vsz.Y/sz.Y)
+	}
+}
+
+// ViewToImage returns the position in the displayed image of given
+// position in the viewport
+func (iv *ImageView) ViewToImage(p mat32.Vec2) mat32.Vec2 {
+	vc := iv.viewPos.Add(iv.viewSize.MulScalar(0.5))
+	return iv.Center.Add(p.Sub(vc).DivScalar(iv.Zoom))
+}
+
+// ImageToView returns the position in the viewport of given position in
+// the displayed image
+func (iv *ImageView) ImageToView(p mat32.Vec2) mat32.Vec2 {
+	vc := iv.viewPos.Add(iv.viewSize.MulScalar(0.5))
+	return vc.Add(p.Sub(iv.Center).MulScalar(iv.Zoom))
+}
+
+// WinToView returns the viewport position of given window position
+func (iv *ImageView) WinToView(pt image.Point) mat32.Vec2 {
+	iv.BBoxMu.RLock()
+	vpoff := iv.VpBBox.Min.Sub(iv.WinBBox.Min)
+	iv.BBoxMu.RUnlock()
+	return mat32.NewVec2FmPoint(pt.Add(vpoff))
+}
+
+// PixelAt returns the pixel of the displayed image at given window
+// position, and its color -- false if not over the image
+func (iv *ImageView) PixelAt(pt image.Point) (image.Point, color.RGBA, bool) {
+	if iv.Disp == nil || iv.Zoom <= 0 {
+		return image.ZP, color.RGBA{}, false
+	}
+	vp := iv.WinToView(pt)
+	if !vp.ToPoint().In(image.Rectangle{Min: iv.viewPos.ToPoint(), Max: iv.viewPos.Add(iv.viewSize).ToPoint()}) {
+		return image.ZP, color.RGBA{}, false
+	}
+	ip := iv.ViewToImage(vp).ToPointFloor()
+	if !ip.In(iv.Disp.Bounds()) {
+		return image.ZP, color.RGBA{}, false
+	}
+	return ip, iv.Disp.RGBAAt(ip.X, ip.Y), true
+}
+
+// ZoomAt zooms by given factor around given window position
+func (iv *ImageView) ZoomAt(factor float32, pt image.Point) {
+	if iv.Zoom <= 0 {
+		return
+	}
+	vp := iv.WinToView(pt)
+	ip := iv.ViewToImage(vp)
+	iv.Zoom = mat32.Clamp(iv.Zoom*factor, 0.01, 256)
+	iv.Fit = ImageZoomed
+	vc := iv.viewPos.Add(iv.viewSize.MulScalar(0.5))
+	iv.Center = ip.Sub(vp.Sub(vc).DivScalar(iv.Zoom))
+	iv.UpdateSig()
+}
+
+// PanBy pans the view by given number of dots
+func (iv *ImageView) PanBy(del image.Point) {
+	if iv.Zoom <= 0 {
+		return
+	}
+	iv.Fit = ImageZoomed
+	iv.Center = iv.Center.Sub(mat32.NewVec2FmPoint(del).DivScalar(iv.Zoom))
+	iv.UpdateSig()
+}
+
+// ImageViewEvents connects to the mouse events, and the zoom keys when the
+// view has the focus (by clicking on it)
+func (iv *ImageView) ImageViewEvents() {
+	iv.ConnectEvent(oswin.MouseScrollEvent, RegPri, func(recv, send ki.Ki, sig int64, d interface{}) {
+		me := d.(*mouse.ScrollEvent)
+		ivv := recv.Embed(KiT_ImageView).(*ImageView)
+		del := float32(me.NonZeroDelta(false))
+		if del == 0 {
+			return
+		}
+		me.SetProcessed()
+		// smooth zoom: proportional to the scroll amount
+		ivv.ZoomAt(mat32.Pow(1.01, -mat32.Clamp(del, -50, 50)), me.Where)
+	})
+	iv.ConnectEvent(oswin.MouseDragEvent, RegPri, func(recv, send ki.Ki, sig int64, d interface{}) {
+		me := d.(*mouse.DragEvent)
+		ivv := recv.Embed(KiT_ImageView).(*ImageView)
+		me.SetProcessed()
+		ivv.PanBy(me.Delta())
+	})
+	iv.ConnectEvent(oswin.MouseEvent, RegPri, func(recv, send ki.Ki, sig int64, d interface{}) {
+		me := d.(*mouse.Event)
+		ivv := recv.Embed(KiT_ImageView).(*ImageView)
+		switch {
+		case me.Button == mouse.Left && me.Action == mouse.Press:
+			ivv.GrabFocus() // for the zoom keys
+		case me.Button == mouse.Left && me.Action == mouse.DoubleClick:
+			me.SetProcessed()
+			if ivv.Fit == ImageFit {
+				ivv.SetFit(ImageActual)
+			} else {
+				ivv.SetFit(ImageFit)
+			}
+		case me.Button == mouse.Right && me.Action == mouse.Release:
+			me.SetProcessed()
+			ivv.EmitContextMenuSignal()
+			ivv.This().(Node2D).ContextMenu()
+		}
+	})
+	iv.ConnectEvent(oswin.MouseMoveEvent, RegPri, func(recv, send ki.Ki, sig int64, d interface{}) {
+		me := d.(*mouse.MoveEvent)
+		ivv := recv.Embed(KiT_ImageView).(*ImageView)
+		if ivv.Zoom < ivv.PixelGridZoom() {
+			if ivv.HoverOn {
+				ivv.HoverOn = false
+				ivv.UpdateSig()
+			}
+			return
+		}
+		pix, _, ok := ivv.PixelAt(me.Where)
+		if ok != ivv.HoverOn || pix != ivv.HoverPix {
+			ivv.HoverOn, ivv.HoverPix = ok, pix
+			ivv.UpdateSig()
+		}
+	})
+	iv.ConnectEvent(oswin.KeyChordEvent, RegPri, func(recv, send ki.Ki, sig int64, d interface{}) {
+		kt := d.(*key.ChordEvent)
+		ivv := recv.Embed(KiT_ImageView).(*ImageView)
+		switch KeyFun(kt.Chord()) {
+		case KeyFunZoomIn:
+			kt.SetProcessed()
+			ivv.ZoomAt(1.25, ivv.WinBBox.Min.Add(ivv.WinBBox.Size().Div(2)))
+		case KeyFunZoomOut:
+			kt.SetProcessed()
+			ivv.ZoomAt(0.8, ivv.WinBBox.Min.Add(ivv.WinBBox.Size().Div(2)))
+		}
+	})
+}
+
+func (iv *ImageView) Style2D() {
+	iv.SetCanFocusIfActive()
+	iv.WidgetBase.Style2D()
+}
+
+func (iv *ImageView) ConnectEvents2D() {
+	iv.ImageViewEvents()
+	iv.HoverTooltipEvent()
+}
+
+func (iv *ImageView) AccessInfo2D(ai *AccessInfo) {
+	iv.WidgetBase.AccessInfo2D(ai)
+	ai.Role = AccessImage
+	ai.Label = ai.Description
+	if ai.Label == "" && iv.Filename != "" {
+		ai.Label = filepath.Base(string(iv.Filename))
+	}
+	ai.SetState(iv.Playing, AccessBusy)
+}
+
+func (iv *ImageView) MakeContextMenu(m *Menu) {
+	fits := []struct {
+		label string
+		fit   ImageFitModes
+	}{{"Fit", ImageFit}, {"Fill", ImageFill}, {"Actual Size", ImageActual}}
+	for _, f := range fits {
+		fit := f.fit
+		m.AddAction(ActOpts{Label: f.label}, iv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+			recv.Embed(KiT_ImageView).(*ImageView).SetFit(fit)
+		})
+	}
+	m.AddSeparator("sep-xform")
+	m.AddAction(ActOpts{Label: "Rotate Right"}, iv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+		recv.Embed(KiT_ImageView).(*ImageView).Rotate(1)
+	})
+	m.AddAction(ActOpts{Label: "Rotate Left"}, iv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+		recv.Embed(KiT_ImageView).(*ImageView).Rotate(-1)
+	})
+	m.AddAction(ActOpts{Label: "Flip Horizontal"}, iv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+		recv.Embed(KiT_ImageView).(*ImageView).ToggleFlipH()
+	})
+	m.AddAction(ActOpts{Label: "Flip Vertical"}, iv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+		recv.Embed(KiT_ImageView).(*ImageView).ToggleFlipV()
+	})
+	m.AddAction(ActOpts{Label: "Reset Adjustments"}, iv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+		recv.Embed(KiT_ImageView).(*ImageView).ResetAdjust()
+	})
+	if len(iv.Frames) > 1 {
+		m.AddSeparator("sep-play")
+		lbl := "Play"
+		if iv.Playing {
+			lbl = "Pause"
+		}
+		m.AddAction(ActOpts{Label: lbl}, iv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+			recv.Embed(KiT_ImageView).(*ImageView).TogglePlay()
+		})
+	}
+	iv.WidgetBase.MakeContextMenu(m)
+}
+
+// RenderPixelGrid renders lines around the visible pixels
+func (iv *ImageView) RenderPixelGrid(rs *RenderState, pc *Paint, st *Style) {
+	imin := iv.ViewToImage(iv.viewPos).ToPointFloor()
+	imax := iv.ViewToImage(iv.viewPos.Add(iv.viewSize)).ToPointCeil()
+	ib := image.Rectangle{Min: imin, Max: imax}.Intersect(iv.Disp.Bounds())
+	if ib.Empty() {
+		return
+	}
+	vmin := iv.ImageToView(mat32.NewVec2FmPoint(ib.Min))
+	vmax := iv.ImageToView(mat32.NewVec2FmPoint(ib.Max))
+	pc.StrokeStyle.SetColor(color.RGBA{128, 128, 128, 128})
+	pc.StrokeStyle.Width.Dots = 1
+	pc.FillStyle.SetColor(nil)
+	for x := ib.Min.X; x <= ib.Max.X; x++ {
+		vx := iv.ImageToView(mat32.Vec2{float32(x), 0}).X
+		pc.DrawLine(rs, vx, vmin.Y, vx, vmax.Y)
+	}
+	for y := ib.Min.Y; y <= ib.Max.Y; y++ {
+		vy := iv.ImageToView(mat32.Vec2{0, float32(y)}).Y
+		pc.DrawLine(rs, vmin.X, vy, vmax.X, vy)
+	}
+	pc.Stroke(rs)
+}
+
+// RenderReadout renders the position and color of the pixel under the mouse
+func (iv *ImageView) RenderReadout(rs *RenderState, pc *Paint, st *Style) {
+	if !iv.HoverOn || !iv.HoverPix.In(iv.Disp.Bounds()) {
+		return
+	}
+	c := iv.Disp.RGBAAt(iv.HoverPix.X, iv.HoverPix.Y)
+	txt := fmt.Sprintf("%d, %d: %d %d %d %d  #%02x%02x%02x", iv.HoverPix.X, iv.HoverPix.Y, c.R, c.G, c.B, c.A, c.R, c.G, c.B)
+	iv.tr.SetString(txt, &st.Font, &st.UnContext, &st.Text, true, 0, 0)
+	pad := float32(4)
+	bsz := iv.tr.Size.AddScalar(2 * pad)
+	bpos := mat32.Vec2{iv.viewPos.X, iv.viewPos.Y + iv.viewSize.Y - bsz.Y}
+	pc.FillBoxColor(rs, bpos, bsz, &st.Font.BgColor.Color)
+	iv.tr.RenderTopPos(rs, bpos.AddScalar(pad))
+	// mark the pixel
+	p0 := iv.ImageToView(mat32.NewVec2FmPoint(iv.HoverPix))
+	pc.StrokeStyle.SetColor(&st.Font.Color)
+	pc.StrokeStyle.Width.Dots = 2
+	pc.FillStyle.SetColor(nil)
+	pc.DrawRectangle(rs, p0.X, p0.Y, iv.Zoom, iv.Zoom)
+	pc.Stroke(rs)
+}
+
+// RenderImageView renders the image
+func (iv *ImageView) RenderImageView() {
+	rs, pc, st := iv.RenderLock()
+	defer iv.RenderUnlock(rs)
+	iv.RenderStdBox(st)
+	iv.UpdateDisp()
+	if iv.Disp == nil {
+		return
+	}
+	if st.Font.Face == nil {
+		st.Font.OpenFont(&st.UnContext)
+	}
+	spc := st.BoxSpace()
+	iv.viewPos = iv.LayState.Alloc.Pos.AddScalar(spc)
+	iv.viewSize = iv.LayState.Alloc.Size.AddScalar(-2 * spc)
+	if iv.viewSize.X <= 0 || iv.viewSize.Y <= 0 {
+		return
+	}
+	isz := iv.Disp.Bounds().Size()
+	iv.UpdateZoom(iv.viewSize, isz)
+
+	vb := image.Rectangle{Min: iv.viewPos.ToPoint(), Max: iv.viewPos.Add(iv.viewSize).ToPoint()}.Intersect(rs.Bounds)
+	dst, ok := rs.Image.SubImage(vb).(*image.RGBA)
+	if !ok {
+		return
+	}
+	dmin := iv.ImageToView(mat32.Vec2{})
+	dmax := iv.ImageToView(mat32.NewVec2FmPoint(isz))
+	dr := image.Rectangle{Min: dmin.ToPoint(), Max: dmax.ToPoint()}
+	if iv.Zoom >= 1 {
+		draw.NearestNeighbor.Scale(dst, dr, iv.Disp, iv.Disp.Bounds(), draw.Over, nil)
+	} else {
+		draw.ApproxBiLinear.Scale(dst, dr, iv.Disp, iv.Disp.Bounds(), draw.Over, nil)
+	}
+	if iv.Zoom >= iv.PixelGridZoom() {
+		// note: can't PushBounds while holding the render lock
+		obounds := rs.Bounds
+		rs.Bounds = vb
+		iv.RenderPixelGrid(rs, pc, st)
+		iv.RenderReadout(rs, pc, st)
+		rs.Bounds = obounds
+	}
+}
+
+func (iv *ImageView) Render2D() {
+	if iv.FullReRenderIfNeeded() {
+		return
+	}
+	if iv.Playing && !iv.playTimer { // started or moved without a window
+		iv.nextFrameAfter(iv.playGen)
+	}
+	if iv.PushBounds() {
+		iv.This().(Node2D).ConnectEvents2D()
+		iv.RenderImageView()
+		iv.Render2DChildren()
+		iv.PopBounds()
+	} else {
+		iv.DisconnectAllEvents(RegPri)
+	}
+}
+
+var ImageViewProps = ki.Props{
+	"EnumType:Flag":    KiT_NodeFlags,
+	"border-width":     units.NewPx(1),
+	"border-color":     &Prefs.Colors.Border,
+	"padding":          units.NewPx(0),
+	"margin":           units.NewPx(2),
+	"color":            &Prefs.Colors.Font,
+	"background-color": &Prefs.Colors.Background,
+	"min-width":        units.NewEm(10),
+	"min-height":       units.NewEm(10),
+	"width":            units.NewEm(30),
+	"height":           units.NewEm(20),
+	"max-width":        -1,
+	"max-height":       -1,
+	"ToolBar": ki.PropSlice{
+		{"OpenImage", ki.Props{
+			"desc": "Open an image file for viewing -- animated GIF files are played",
+			"icon": "file-open",
+			"Args": ki.PropSlice{
+				{"File Name", ki.Props{
+					"default-field": "Filename",
+					"ext":           ".png,.jpg,.gif",
+				}},
+			},
+		}},
+		{"SetFit", ki.Props{
+			"desc": "set how the image is fit into the view",
+			"icon": "zoom-in",
+			"Args": ki.PropSlice{
+				{"Fit", ki.Props{
+					"default-field": "Fit",
+				}},
+			},
+		}},
+		{"Rotate", ki.Props{
+			"desc": "rotate the image clockwise by given number of 90 degree steps -- negative for counter-clockwise",
+			"icon": "update",
+			"Args": ki.PropSlice{
+				{"Steps", ki.Props{
+					"default": 1,
+				}},
+			},
+		}},
+		{"SetAdjust", ki.Props{
+			"desc":  "set brightness and contrast (-1 to 1, 0 = none) and gamma (0 or 1 = none)",
+			"icon":  "color",
+			"label": "Adjust",
+			"Args": ki.PropSlice{
+				{"Brightness", ki.Props{
+					"default-field": "Brightness",
+				}},
+				{"Contrast", ki.Props{
+					"default-field": "Contrast",
+				}},
+				{"Gamma", ki.Props{
+					"default-field": "Gamma",
+				}},
+			},
+		}},
+		{"TogglePlay", ki.Props{
+			"desc":  "play or pause animations",
+			"icon":  "play",
+			"label": "Play / Pause",
+		}},
+	},
+}
@@ -0,0 +1,424 @@
+// Copyright (c) 2018, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/goki/gi/oswin"
+	"github.com/goki/gi/oswin/key"
+	"github.com/goki/gi/oswin/mouse"
+	"github.com/goki/gi/units"
+	"github.com/goki/ki/ki"
+	"github.com/goki/ki/kit"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////
+// Inspector picking mode
+
+// Inspector receives the widgets under the mouse in all windows while
+// inspecting is on (see InspectStart) -- the GiEditor uses this to pick
+// widgets and show their styles
+type Inspector interface {
+	// InspectHover is called with the widget under the mouse in given window
+	// (nil if none), which is highlighted -- called in the event loop of
+	// that window
+	InspectHover(win *Window, nii Node2D)
+
+	// InspectPick is called with the widget clicked in given window, which
+	// stops the inspecting -- called in the event loop of that window
+	InspectPick(win *Window, nii Node2D)
+
+	// InspectWin returns the window of the inspector itself, whose events
+	// are processed normally
+	InspectWin() *Window
+}
+
+// InspectSpriteName is the name of the sprite that highlights the inspected
+// widget with its box model
+const InspectSpriteName = "gi.Window:Inspect"
+
+// InspectColors are the colors of the margin, border, padding and content
+// boxes in the highlight of the inspected widget
+var InspectColors = [4]color.RGBA{
+	{246, 178, 107, 150},
+	{255, 229, 153, 170},
+	{147, 196, 125, 150},
+	{111, 168, 220, 150},
+}
+
+var (
+	// inspectMu protects the inspector state, which is used from the event
+	// loops of all windows
+	inspectMu sync.Mutex
+
+	// curInspector is the active inspector, if inspecting
+	curInspector Inspector
+
+	// inspectHiWin is the window with the highlight, if any
+	inspectHiWin *Window
+)
+
+// InspectStart starts inspecting, i.e., picking widgets with the mouse in
+// all windows except that of the inspector: moving the mouse highlights the
+// widget under it, clicking picks it, and Escape cancels
+func InspectStart(ins Inspector) {
+	inspectMu.Lock()
+	curInspector = ins
+	inspectMu.Unlock()
+}
+
+// InspectStop stops inspecting -- the highlight remains until
+// InspectHighlight(nil)
+func InspectStop() {
+	inspectMu.Lock()
+	curInspector = nil
+	inspectMu.Unlock()
+}
+
+// IsInspecting returns true if inspecting is on
+func IsInspecting() bool {
+	inspectMu.Lock()
+	defer inspectMu.Unlock()
+	return curInspector != nil
+}
+
+// InspectEvent processes mouse and key events of the window while
+// inspecting -- returns true if the event was used for inspecting, so that
+// it is not processed further
+func (w *Window) InspectEvent(evi oswin.Event) bool {
+	inspectMu.Lock()
+	ins := curInspector
+	inspectMu.Unlock()
+	if ins == nil || ins.InspectWin() == w {
+		return false
+	}
+	switch e := evi.(type) {
+	case *mouse.MoveEvent:
+		nii := w.NodeAt(e.Where)
+		InspectHighlight(nii)
+		ins.InspectHover(w, nii)
+	case *mouse.DragEvent:
+	case *mouse.Event:
+		if e.Action != mouse.Press {
+			break
+		}
+		InspectStop()
+		nii := w.NodeAt(e.Where)
+		InspectHighlight(nii)
+		ins.InspectPick(w, nii)
+	case *key.ChordEvent:
+		if KeyFun(e.Chord()) != KeyFunAbort {
+			return false
+		}
+		InspectStop()
+		InspectHighlight(nil)
+	default:
+		return false
+	}
+	evi.SetProcessed()
+	return true
+}
+
+// NodeAt returns the innermost visible widget (or other 2D node) at given
+// position in the window, including in the current popup -- nil if none
+func (w *Window) NodeAt(pos image.Point) Node2D {
+	var found Node2D
+	find := func(k ki.Ki, level int, d interface{}) bool {
+		nii, nb := KiToNode2D(k)
+		if nb == nil {
+			return ki.Continue
+		}
+		if nb.IsInvisible() || !pos.In(nb.WinBBox) {
+			return ki.Break
+		}
+		found = nii
+		return ki.Continue
+	}
+	w.PopMu.RLock()
+	pop := w.Popup
+	w.PopMu.RUnlock()
+	if pop != nil && pop.This() != nil {
+		pop.FuncDownMeFirst(0, nil, find)
+		if found != nil {
+			return found
+		}
+	}
+	w.Viewport.FuncDownMeFirst(0, nil, find)
+	return found
+}
+
+// InspectHighlight highlights given widget in its window with the box model
+// of its margin, border, padding and content, removing any previous
+// highlight -- nil just removes the highlight
+func InspectHighlight(nii Node2D) {
+	var win *Window
+	if nii != nil && nii.This() != nil {
+		win = nii.AsNode2D().ParentWindow()
+	}
+	inspectMu.Lock()
+	owin := inspectHiWin
+	inspectHiWin = win
+	inspectMu.Unlock()
+	if owin != nil && owin != win && !owin.IsClosed() {
+		if owin.DeleteSprite(InspectSpriteName) {
+			owin.RenderOverlays()
+		}
+	}
+	if win == nil {
+		return
+	}
+	boxes := BoxModel(nii)
+	outer := boxes[0]
+	if outer.Empty() {
+		if win.DeleteSprite(InspectSpriteName) {
+			win.RenderOverlays()
+		}
+		return
+	}
+	win.DeleteSprite(InspectSpriteName)
+	sp := &Sprite{Name: InspectSpriteName, On: true}
+	sp.Resize(outer.Size())
+	sp.Geom.Pos = outer.Min
+	for i, bx := range boxes {
+		in := image.ZR
+		if i < len(boxes)-1 {
+			in = boxes[i+1]
+		}
+		clr := image.NewUniform(InspectColors[i])
+		for _, r := range rectMinus(bx, in) {
+			draw.Draw(sp.Pixels, r.Sub(outer.Min), clr, image.ZP, draw.Src)
+		}
+	}
+	win.AddSprite(sp)
+	win.RenderOverlays()
+}
+
+// rectMinus returns the parts of rectangle r outside of rectangle in, which
+// must be inside r, or empty
+func rectMinus(r, in image.Rectangle) []image.Rectangle {
+	if in.Empty() {
+		return []image.Rectangle{r}
+	}
+	return []image.Rectangle{
+		image.Rect(r.Min.X, r.Min.Y, r.Max.X, in.Min.Y),
+		image.Rect(r.Min.X, in.Max.Y, r.Max.X, r.Max.Y),
+		image.Rect(r.Min.X, in.Min.Y, in.Min.X, in.Max.Y),
+		image.Rect(in.Max.X, in.Min.Y, r.Max.X, in.Max.Y),
+	}
+}
+
+// BoxModel returns the margin, border, padding and content boxes of given
+// node, in window coordinates -- for nodes that are not widgets, these are
+// all the bounding box of the node
+func BoxModel(nii Node2D) [4]image.Rectangle {
+	var boxes [4]image.Rectangle
+	nb := nii.AsNode2D()
+	outer := nb.ObjBBox
+	if nb.Viewport != nil {
+		outer = outer.Add(nb.Viewport.WinBBox.Min)
+	}
+	boxes[0] = outer
+	wb := nii.AsWidget()
+	if wb == nil {
+		boxes[1], boxes[2], boxes[3] = outer, outer, outer
+		return boxes
+	}
+	wb.StyMu.RLock()
+	st := &wb.Sty
+	insets := [3]float32{st.Layout.Margin.Dots, st.Border.Width.Dots, st.Layout.Padding.Dots}
+	wb.StyMu.RUnlock()
+	for i, in := range insets {
+		d := int(in + 0.5)
+		r := boxes[i].Inset(d)
+		if r.Empty() {
+			r = image.Rectangle{Min: r.Min, Max: r.Min}
+		}
+		boxes[i+1] = r
+	}
+	return boxes
+}
+
+////////////////////////////////////////////////////////////////////////////////////////
+// Style sources
+
+// StyleProp is a style property of a widget, with its computed value and
+// the source of the value -- see StyleSources
+type StyleProp struct {
+	Name   string `width:"20" desc:"name of the style property, as in CSS"`
+	Value  string `width:"20" desc:"computed value of the property, as used for rendering"`
+	Source string `width:"30" desc:"where the value was set: default (Style defaults or inherited from the parent), type (type properties), part (#name properties of the parent widget type), inline (properties of the node itself), class (.class type properties), state (e.g., :hover properties of buttons) or css (style sheets of the node and its parents) -- the last one to set the property determines the value"`
+	Set    string `width:"20" desc:"value as set in the source"`
+}
+
+// StyleSources returns all the style properties of given widget, with their
+// computed values and where they were set, in the order in which the
+// sources are applied in styling: type and part defaults, inline
+// properties, classes, button states and CSS style sheets
+func StyleSources(nii Node2D) []StyleProp {
+	wb := nii.AsWidget()
+	if wb == nil {
+		return nil
+	}
+	srcs := map[string]StyleProp{}
+	add := func(src string, props ki.Props) {
+		for k, v := range props {
+			if k == "" || strings.IndexAny(k[:1], "#.:_ABCDEFGHIJKLMNOPQRSTUVWXYZ") >= 0 {
+				continue // selectors and non-style type properties
+			}
+			switch v.(type) {
+			case ki.Props, ki.PropSlice:
+				continue
+			}
+			srcs[k] = StyleProp{Name: k, Source: src, Set: styleValString(v)}
+		}
+	}
+	tprops := ki.Props(*kit.Types.Properties(wb.Type(), true))
+	classes := strings.Fields(strings.ToLower(wb.Class))
+	var selector string
+	var sty *Style
+	wb.StyMu.RLock()
+	sty = &wb.Sty
+	if bw, ok := nii.(ButtonWidget); ok {
+		bb := bw.AsButtonBase()
+		if int(bb.State) < len(ButtonSelectors) {
+			selector = ButtonSelectors[bb.State]
+			sty = &bb.StateStyles[bb.State]
+		}
+	}
+	csty := *sty
+	cssAgg := wb.CSSAgg
+	wb.StyMu.RUnlock()
+
+	kit.TypesMu.RLock()
+	add("type", tprops)
+	if pw := partParent(wb); pw != nil {
+		ptprops := ki.Props(*kit.Types.Properties(pw.Type(), true))
+		if sp, ok := ki.SubProps(ptprops, "#"+strings.ToLower(wb.Name())); ok {
+			add("part of "+strings.ToLower(pw.Type().Name()), sp)
+		}
+	}
+	kit.TypesMu.RUnlock()
+	add("inline", *wb.Properties())
+	kit.TypesMu.RLock()
+	for _, cl := range classes {
+		if sp, ok := ki.SubProps(tprops, "."+cl); ok {
+			add("class ."+cl, sp)
+		}
+	}
+	kit.TypesMu.RUnlock()
+	if selector != "" {
+		if sp := wb.StyleProps(selector); sp != nil {
+			add("state "+selector, sp)
+		}
+	}
+	if cssAgg != nil {
+		sels := []string{strings.ToLower(wb.Type().Name())}
+		for _, cl := range classes {
+			sels = append(sels, "."+cl)
+		}
+		sels = append(sels, "#"+strings.ToLower(wb.Name()))
+		for _, sel := range sels {
+			sp, ok := cssAgg[sel].(ki.Props)
+			if !ok {
+				continue
+			}
+			add("css "+sel, sp)
+			if selector != "" {
+				if ssp, ok := sp[selector].(ki.Props); ok {
+					add("css "+sel+selector, ssp)
+				}
+			}
+		}
+	}
+
+	var sps []StyleProp
+	styleFieldsFunc(reflect.ValueOf(&csty).Elem(), "", func(name string, val reflect.Value) {
+		sp, ok := srcs[name]
+		if !ok {
+			sp = StyleProp{Name: name, Source: "default"}
+		}
+		delete(srcs, name)
+		sp.Value = styleValString(val.Interface())
+		sps = append(sps, sp)
+	})
+	for _, sp := range srcs { // not part of the Style, e.g., fill of icons
+		sp.Value = sp.Set
+		sps = append(sps, sp)
+	}
+	sort.SliceStable(sps, func(i, j int) bool {
+		return sps[i].Name < sps[j].Name
+	})
+	return sps
+}
+
+// partParent returns the widget that the given widget is a part of, if it
+// is in the Parts of a widget, else nil
+func partParent(wb *WidgetBase) Node2D {
+	par := wb.Parent()
+	if par == nil || par.Parent() == nil {
+		return nil
+	}
+	ppi := par.Parent().Embed(KiT_PartsWidgetBase)
+	if ppi == nil {
+		return nil
+	}
+	pp := ppi.(*PartsWidgetBase)
+	if pp.Parts.This() != par.This() {
+		return nil
+	}
+	return par.Parent().(Node2D)
+}
+
+// styleFieldsFunc calls given function on each of the style fields of given
+// style struct value, with the name of its property as given by the xml tags
+func styleFieldsFunc(v reflect.Value, prefix string, fun func(name string, val reflect.Value)) {
+	typ := v.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		tag := f.Tag.Get("xml")
+		if f.PkgPath != "" || tag == "-" {
+			continue
+		}
+		name := prefix + tag
+		if prefix != "" && tag != "" && !strings.HasPrefix(tag, ".") {
+			name = prefix + "-" + tag
+		}
+		fv := v.Field(i)
+		if f.Type.Kind() == reflect.Struct && strings.HasSuffix(f.Type.Name(), "Style") {
+			styleFieldsFunc(fv, name, fun)
+			continue
+		}
+		if tag == "" {
+			continue
+		}
+		fun(name, fv)
+	}
+}
+
+// styleValString returns a string representation of a style value
+func styleValString(v interface{}) string {
+	switch vv := v.(type) {
+	case Color:
+		return vv.String()
+	case units.Value:
+		return vv.String()
+	case ColorSpec:
+		if vv.Source == SolidColor {
+			return vv.Color.String()
+		}
+		return fmt.Sprintf("%v gradient", vv.Source)
+	case fmt.Stringer:
+		return vv.String()
+	}
+	return kit.ToString(v)
+}
@@ -88,6 +88,7 @@ const (
 	KeyFunWinClose
 	KeyFunWinSnapshot
 	KeyFunGoGiEditor
+	KeyFunCommandPalette // search and run any command in the window
 	// Below are menu specific functions -- use these as shortcuts for menu actions
 	// allows uniqueness of mapping and easy customization of all key actions
 	KeyFunMenuNew
@@ -424,7 +425,7 @@ var KeyMapsProps = ki.Props{
 				"confirm": true,
 			}},
 		}},
-		{"Edit", "Copy Cut Paste Dupe"},
+		{"Edit", "Undo Redo Copy Cut Paste Dupe"},
 		{"Window", "Windows"},
 	},
 	"ToolBar": ki.PropSlice{
@@ -599,6 +600,8 @@ var StdKeyMaps = KeyMaps{
 		"Shift+Control+G":         KeyFunWinSnapshot,
 		"Control+Alt+I":           KeyFunGoGiEditor,
 		"Shift+Control+I":         KeyFunGoGiEditor,
+		"F1":                      KeyFunCommandPalette,
+		"Shift+Meta+P":            KeyFunCommandPalette,
 		"Meta+N":                  KeyFunMenuNew,
 		"Shift+Meta+N":            KeyFunMenuNewAlt1,
 		"Alt+Meta+N":              KeyFunMenuNewAlt2,
@@ -733,6 +736,8 @@ var StdKeyMaps = KeyMaps{
 		"Shift+Control+G":         KeyFunWinSnapshot,
 		"Control+Alt+I":           KeyFunGoGiEditor,
 		"Shift+Control+I":         KeyFunGoGiEditor,
+		"F1":                      KeyFunCommandPalette,
+		"Shift+Meta+P":            KeyFunCommandPalette,
 		"Meta+N":                  KeyFunMenuNew,
 		"Shift+Meta+N":            KeyFunMenuNewAlt1,
 		"Alt+Meta+N":              KeyFunMenuNewAlt2,
@@ -852,6 +857,7 @@ var StdKeyMaps = KeyMaps{
 		"Shift+Control+G":         KeyFunWinSnapshot,
 		"Control+Alt+I":           KeyFunGoGiEditor,
 		"Shift+Control+I":         KeyFunGoGiEditor,
+		"F1":                      KeyFunCommandPalette,
 		"Alt+N":                   KeyFunMenuNew, // ctrl keys conflict..
 		"Shift+Alt+N":             KeyFunMenuNewAlt1,
 		"Control+Alt+N":           KeyFunMenuNewAlt2,
@@ -941,6 +947,7 @@ var StdKeyMaps = KeyMaps{
 		"Control+Alt+G":           KeyFunWinSnapshot,
 		"Shift+Control+G":         KeyFunWinSnapshot,
 		"Shift+Control+I":         KeyFunGoGiEditor,
+		"F1":                      KeyFunCommandPalette,
 		"Shift+Control+N":         KeyFunMenuNewAlt1,
 		"Control+Alt+N":           KeyFunMenuNewAlt2,
 		"Control+O":               KeyFunMenuOpen,
@@ -1028,6 +1035,7 @@ var StdKeyMaps = KeyMaps{
 		"Control+Alt+G":           KeyFunWinSnapshot,
 		"Shift+Control+G":         KeyFunWinSnapshot,
 		"Shift+Control+I":         KeyFunGoGiEditor,
+		"F1":                      KeyFunCommandPalette,
 		"Control+N":               KeyFunMenuNew,
 		"Shift+Control+N":         KeyFunMenuNewAlt1,
 		"Control+Alt+N":           KeyFunMenuNewAlt2,
@@ -1116,6 +1124,7 @@ var StdKeyMaps = KeyMaps{
 		"Control+Alt+G":           KeyFunWinSnapshot,
 		"Shift+Control+G":         KeyFunWinSnapshot,
 		"Shift+Control+I":         KeyFunGoGiEditor,
+		"F1":                      KeyFunCommandPalette,
 		"Control+N":               KeyFunMenuNew,
 		"Shift+Control+N":         KeyFunMenuNewAlt1,
 		"Control+Alt+N":           KeyFunMenuNewAlt2,
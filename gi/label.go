@@ -35,6 +35,7 @@ type Label struct {
 	Render      TextRender          `copy:"-" xml:"-" json:"-" desc:"render data for text label"`
 	RenderPos   mat32.Vec2          `copy:"-" xml:"-" json:"-" desc:"position offset of start of text rendering, from last render -- AllocPos plus alignment factors for center, right etc."`
 	CurBgColor  Color               `copy:"-" xml:"-" json:"-" desc:"current background color -- grabbed when rendering for first time, and used when toggling off of selected mode, or for redrawable, to wipe out bg"`
+	AccessFor   ki.Ki               `copy:"-" json:"-" xml:"-" view:"-" desc:"widget that this label is the label for, for assistive technology -- the widget is named by this label if it has no label of its own -- the label must come before the widget in the tree"`
 }
 
 var KiT_Label = kit.Types.AddType(&Label{}, LabelProps)
@@ -201,13 +202,9 @@ func (lb *Label) HoverEvent() {
 				}
 			}
 		}
-		if llb.Tooltip != "" {
+		if tt := llb.TooltipOptions(); tt != nil && tt.HasContent() {
 			me.SetProcessed()
-			llb.BBoxMu.RLock()
-			pos := llb.WinBBox.Max
-			llb.BBoxMu.RUnlock()
-			pos.X -= 20
-			PopupTooltip(llb.Tooltip, pos.X, pos.Y, llb.Viewport, llb.Nm)
+			llb.PopupTooltipFor(tt, me.Where)
 		}
 	})
 }
@@ -435,3 +432,13 @@ func (lb *Label) Render2D() {
 func (lb *Label) ConnectEvents2D() {
 	lb.LabelEvents()
 }
+
+func (lb *Label) AccessInfo2D(ai *AccessInfo) {
+	lb.WidgetBase.AccessInfo2D(ai)
+	ai.Role = AccessLabel
+	ai.Label = AccessPlainText(lb.Text)
+	ai.SetState(lb.Selectable, AccessSelectable)
+	if lb.AccessFor != nil {
+		ai.AddRelation(AccessLabelFor, lb.AccessFor)
+	}
+}
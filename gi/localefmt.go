@@ -0,0 +1,321 @@
+// Copyright (c) 2018, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/goki/gi/i18n"
+	"github.com/goki/ki/kit"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////
+// LocaleFormat
+
+// LocaleFormat has the conventions of a locale for formatting and parsing
+// numbers, dates and times, and currency amounts -- the ActiveLocaleFormat
+// is used by SpinBox, DateTimeField, DatePicker and the value views in giv,
+// for both display and parsing of what the user enters.
+type LocaleFormat struct {
+	DecimalSep     string       `desc:"decimal separator, e.g., . or ,"`
+	GroupSep       string       `desc:"separator between groups of digits in the integer part of numbers, e.g., , or . -- blank for no grouping"`
+	GroupDigits    int          `min:"0" desc:"number of digits in each group (3 in most locales) -- 0 for no grouping"`
+	DateFormat     string       `desc:"time.Format layout for dates, e.g., 01/02/2006 or 02.01.2006 -- numeric layouts also enable input masks"`
+	TimeFormat     string       `desc:"time.Format layout for the time of day, e.g., 15:04 or 3:04 PM"`
+	FirstWeekday   time.Weekday `desc:"first day of the week, shown in the first column of calendars"`
+	CurrencySymbol string       `desc:"currency symbol, e.g., $ or €"`
+	CurrencyAfter  bool         `desc:"the currency symbol goes after the amount, e.g., 1.234,50 € instead of $1,234.50"`
+	CurrencyDigits int          `min:"0" desc:"number of decimal digits in currency amounts"`
+}
+
+var KiT_LocaleFormat = kit.Types.AddType(&LocaleFormat{}, nil)
+
+// LocaleFormats are the standard formats of locales, by language or
+// language_TERRITORY (as in i18n.NormLocale) -- the C entry is used for
+// locales not listed, including the C / POSIX locale and English without a
+// territory, and has the ISO 8601 date and time formats with no digit
+// grouping
+var LocaleFormats = map[string]*LocaleFormat{
+	"C":     {".", "", 0, "2006-01-02", "15:04", time.Sunday, "", false, 2},
+	"en_US": {".", ",", 3, "01/02/2006", "3:04 PM", time.Sunday, "$", false, 2},
+	"en_GB": {".", ",", 3, "02/01/2006", "15:04", time.Monday, "£", false, 2},
+	"en_IE": {".", ",", 3, "02/01/2006", "15:04", time.Monday, "€", false, 2},
+	"en_AU": {".", ",", 3, "02/01/2006", "3:04 PM", time.Monday, "$", false, 2},
+	"en_CA": {".", ",", 3, "2006-01-02", "3:04 PM", time.Sunday, "$", false, 2},
+	"de":    {",", ".", 3, "02.01.2006", "15:04", time.Monday, "€", true, 2},
+	"de_CH": {".", "’", 3, "02.01.2006", "15:04", time.Monday, "CHF", false, 2},
+	"fr":    {",", "\u00a0", 3, "02/01/2006", "15:04", time.Monday, "€", true, 2},
+	"fr_CA": {",", "\u00a0", 3, "2006-01-02", "15:04", time.Sunday, "$", true, 2},
+	"fr_CH": {",", "\u00a0", 3, "02.01.2006", "15:04", time.Monday, "CHF", true, 2},
+	"es":    {",", ".", 3, "02/01/2006", "15:04", time.Monday, "€", true, 2},
+	"es_MX": {".", ",", 3, "02/01/2006", "15:04", time.Sunday, "$", false, 2},
+	"it":    {",", ".", 3, "02/01/2006", "15:04", time.Monday, "€", true, 2},
+	"nl":    {",", ".", 3, "02-01-2006", "15:04", time.Monday, "€", false, 2},
+	"pt":    {",", "\u00a0", 3, "02/01/2006", "15:04", time.Monday, "€", true, 2},
+	"pt_BR": {",", ".", 3, "02/01/2006", "15:04", time.Sunday, "R$", false, 2},
+	"da":    {",", ".", 3, "02.01.2006", "15:04", time.Monday, "kr.", true, 2},
+	"sv":    {",", "\u00a0", 3, "2006-01-02", "15:04", time.Monday, "kr", true, 2},
+	"nb":    {",", "\u00a0", 3, "02.01.2006", "15:04", time.Monday, "kr", true, 2},
+	"fi":    {",", "\u00a0", 3, "2.1.2006", "15.04", time.Monday, "€", true, 2},
+	"pl":    {",", "\u00a0", 3, "02.01.2006", "15:04", time.Monday, "zł", true, 2},
+	"cs":    {",", "\u00a0", 3, "02.01.2006", "15:04", time.Monday, "Kč", true, 2},
+	"ru":    {",", "\u00a0", 3, "02.01.2006", "15:04", time.Monday, "₽", true, 2},
+	"uk":    {",", "\u00a0", 3, "02.01.2006", "15:04", time.Monday, "₴", true, 2},
+	"tr":    {",", ".", 3, "02.01.2006", "15:04", time.Monday, "₺", false, 2},
+	"ja":    {".", ",", 3, "2006/01/02", "15:04", time.Sunday, "¥", false, 0},
+	"zh":    {".", ",", 3, "2006/01/02", "15:04", time.Monday, "¥", false, 2},
+	"ko":    {".", ",", 3, "2006-01-02", "15:04", time.Sunday, "₩", false, 0},
+}
+
+// StdLocaleFormat returns a copy of the standard format for given locale,
+// from LocaleFormats, using the language if the locale is not listed
+func StdLocaleFormat(loc string) LocaleFormat {
+	for _, l := range i18n.LocaleFallbacks(loc) {
+		if lf, ok := LocaleFormats[l]; ok {
+			return *lf
+		}
+	}
+	return *LocaleFormats["C"]
+}
+
+// ActiveLocaleFormat is the LocaleFormat in use -- set from Prefs.Format in
+// Preferences.Apply
+var ActiveLocaleFormat = *LocaleFormats["C"]
+
+// isSpaceSep returns true if the separator is a space, which the user may
+// enter as any kind of space
+func isSpaceSep(sep string) bool {
+	return sep != "" && strings.TrimSpace(sep) == ""
+}
+
+// LocalizeNumber converts a number formatted in the Go syntax, as by fmt or
+// strconv, e.g., -1234.5, to the locale format, e.g., -1.234,5 -- any
+// leading padding and trailing text is kept, and numbers in hex or other
+// bases are returned as-is.  Digits are not grouped if there is an exponent.
+func (lf *LocaleFormat) LocalizeNumber(s string) string {
+	i := 0
+	for i < len(s) && s[i] == ' ' {
+		i++
+	}
+	if i < len(s) && (s[i] == '-' || s[i] == '+') {
+		i++
+	}
+	st := i
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+	ip := s[st:i]
+	rest := s[i:]
+	if ip == "" || strings.HasPrefix(rest, "x") || strings.HasPrefix(rest, "X") || strings.HasPrefix(rest, "b") || strings.HasPrefix(rest, "o") {
+		return s
+	}
+	frac := ""
+	if strings.HasPrefix(rest, ".") {
+		j := 1
+		for j < len(rest) && rest[j] >= '0' && rest[j] <= '9' {
+			j++
+		}
+		frac, rest = lf.DecimalSep+rest[1:j], rest[j:]
+	}
+	exp := strings.HasPrefix(rest, "e") || strings.HasPrefix(rest, "E")
+	if lf.GroupSep != "" && lf.GroupDigits > 0 && !exp && ip[0] != '0' && len(ip) > lf.GroupDigits {
+		var sb strings.Builder
+		fg := len(ip) % lf.GroupDigits
+		if fg == 0 {
+			fg = lf.GroupDigits
+		}
+		sb.WriteString(ip[:fg])
+		for g := fg; g < len(ip); g += lf.GroupDigits {
+			sb.WriteString(lf.GroupSep)
+			sb.WriteString(ip[g : g+lf.GroupDigits])
+		}
+		ip = sb.String()
+	}
+	return s[:st] + ip + frac + rest
+}
+
+// DelocalizeNumber converts a number entered in the locale format, e.g.,
+// 1.234,5, to the Go syntax for strconv, e.g., 1234.5 -- digit group
+// separators are removed, and any kind of space is accepted where the
+// group separator is a space
+func (lf *LocaleFormat) DelocalizeNumber(s string) string {
+	s = strings.TrimSpace(s)
+	switch {
+	case isSpaceSep(lf.GroupSep):
+		s = strings.Map(func(r rune) rune {
+			if unicode.IsSpace(r) || r == ' ' {
+				return -1
+			}
+			return r
+		}, s)
+	case lf.GroupSep != "":
+		s = strings.Replace(s, lf.GroupSep, "", -1)
+	}
+	if lf.DecimalSep != "" && lf.DecimalSep != "." {
+		s = strings.Replace(s, lf.DecimalSep, ".", -1)
+	}
+	return s
+}
+
+// splitFormat splits a fmt format string for one value into the text before
+// the verb, the verb with its flags, width and precision, and the text after
+// -- the verb is empty if there is none
+func splitFormat(format string) (pre, verb, post string) {
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			continue
+		}
+		if i+1 < len(format) && format[i+1] == '%' {
+			i++
+			continue
+		}
+		j := i + 1
+		for j < len(format) && strings.IndexByte("+-# 0123456789.", format[j]) >= 0 {
+			j++
+		}
+		if j < len(format) {
+			j++
+		}
+		return format[:i], format[i:j], format[j:]
+	}
+	return format, "", ""
+}
+
+// FormatValue formats given number using a fmt format string, e.g., %g or
+// %.2f Hz, with the number localized for decimal verbs (d, e, f, g, v)
+func (lf *LocaleFormat) FormatValue(format string, val interface{}) string {
+	pre, verb, post := splitFormat(format)
+	if verb == "" || !strings.ContainsAny(verb[len(verb)-1:], "deEfFgGv") {
+		return fmt.Sprintf(format, val)
+	}
+	return strings.Replace(pre, "%%", "%", -1) + lf.LocalizeNumber(fmt.Sprintf(verb, val)) + strings.Replace(post, "%%", "%", -1)
+}
+
+// FormatFloat formats given number in the locale format, using the %g
+// format if format is blank
+func (lf *LocaleFormat) FormatFloat(val float64, format string) string {
+	if format == "" {
+		format = "%g"
+	}
+	return lf.FormatValue(format, val)
+}
+
+// ParseFloat parses a number entered in the locale format
+func (lf *LocaleFormat) ParseFloat(s string) (float64, error) {
+	return strconv.ParseFloat(lf.DelocalizeNumber(s), 64)
+}
+
+// ParseInt parses an integer entered in the locale format -- 0x etc
+// prefixes are accepted for other bases, as in strconv.ParseInt
+func (lf *LocaleFormat) ParseInt(s string) (int64, error) {
+	return strconv.ParseInt(lf.DelocalizeNumber(s), 0, 64)
+}
+
+// FormatCurrency formats given amount with the currency symbol, rounded to
+// CurrencyDigits
+func (lf *LocaleFormat) FormatCurrency(val float64) string {
+	s := lf.LocalizeNumber(strconv.FormatFloat(math.Abs(val), 'f', lf.CurrencyDigits, 64))
+	switch {
+	case lf.CurrencySymbol == "":
+	case lf.CurrencyAfter:
+		s += "\u00a0" + lf.CurrencySymbol
+	case len([]rune(lf.CurrencySymbol)) > 1 && unicode.IsLetter([]rune(lf.CurrencySymbol)[0]):
+		s = lf.CurrencySymbol + "\u00a0" + s // e.g., CHF
+	default:
+		s = lf.CurrencySymbol + s
+	}
+	if val < 0 {
+		s = "-" + s
+	}
+	return s
+}
+
+// ParseCurrency parses an amount entered in the locale format, with or
+// without the currency symbol
+func (lf *LocaleFormat) ParseCurrency(s string) (float64, error) {
+	if lf.CurrencySymbol != "" {
+		s = strings.Replace(s, lf.CurrencySymbol, "", 1)
+	}
+	return lf.ParseFloat(s)
+}
+
+// DateTimeFormat returns the time.Format layout for given DateTimeField mode
+// -- the DateTimeFieldFormats are used for any that are blank.  The full
+// date and time layout also has the seconds and the zone offset, e.g.,
+// 02.01.2006 15:04:05 -07:00, so that no part of a time is lost when the
+// text is parsed back.
+func (lf *LocaleFormat) DateTimeFormat(mode DateTimeFieldModes) string {
+	df, tf := lf.DateFormat, lf.TimeFormat
+	if df == "" {
+		df = DateTimeFieldFormats[DateTimeFieldDate]
+	}
+	if tf == "" {
+		tf = DateTimeFieldFormats[DateTimeFieldTime]
+	}
+	switch mode {
+	case DateTimeFieldDate:
+		return df
+	case DateTimeFieldTime:
+		return tf
+	}
+	return df + " " + LayoutWithSeconds(tf) + " -07:00"
+}
+
+// LayoutWithSeconds returns given time of day layout with the seconds added
+// after the minutes, using the same separator as between the hours and
+// minutes, e.g., 3:04:05 PM for 3:04 PM, or 15.04.05 for 15.04
+func LayoutWithSeconds(layout string) string {
+	if strings.Contains(layout, "05") {
+		return layout
+	}
+	mi := strings.Index(layout, "04")
+	if mi < 0 {
+		return layout
+	}
+	sep := ":"
+	if mi > 0 && layout[mi-1] < 0x80 {
+		sep = layout[mi-1 : mi]
+	}
+	return layout[:mi+2] + sep + "05" + layout[mi+2:]
+}
+
+// layoutMaskElems are the numeric elements of time.Format layouts and their
+// TextFieldMask equivalents, in the order they must be matched
+var layoutMaskElems = []struct{ Elem, Mask string }{
+	{"2006", "9999"}, {"01", "99"}, {"02", "99"}, {"15", "99"}, {"04", "99"}, {"05", "99"},
+}
+
+// LayoutMask returns the TextFieldMask for entering times in given
+// time.Format layout, if it only has fixed-width numeric elements, e.g.,
+// 99.99.9999 for 02.01.2006 -- otherwise blank, e.g., for Jan 2, 2006 or
+// 3:04 PM
+func LayoutMask(layout string) string {
+	var sb strings.Builder
+outer:
+	for i := 0; i < len(layout); {
+		for _, le := range layoutMaskElems {
+			if strings.HasPrefix(layout[i:], le.Elem) {
+				sb.WriteString(le.Mask)
+				i += len(le.Elem)
+				continue outer
+			}
+		}
+		c := layout[i]
+		switch {
+		case c >= '0' && c <= '9' || c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z' || c >= 0x80:
+			return ""
+		case c == '#' || c == '*' || c == '\\':
+			sb.WriteByte('\\')
+		}
+		sb.WriteByte(c)
+		i++
+	}
+	return sb.String()
+}
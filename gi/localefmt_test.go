@@ -0,0 +1,102 @@
+// Copyright (c) 2018, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLocalizeNumber(t *testing.T) {
+	tests := []struct {
+		loc, in, out string
+	}{
+		{"C", "-1234.5", "-1234.5"},
+		{"en_US", "1234567.25", "1,234,567.25"},
+		{"de", "-1234.5", "-1.234,5"},
+		{"de", "1234567", "1.234.567"},
+		{"de", "123", "123"},
+		{"de", "0.5", "0,5"},
+		{"de", "1.5e+06", "1,5e+06"},
+		{"de", "12345e3", "12345e3"},
+		{"de", "0x1F", "0x1F"},
+		{"de", "  1234", "  1.234"},
+		{"de", "1234.5 Hz", "1.234,5 Hz"},
+		{"de", "abc", "abc"},
+		{"fr", "1234.5", "1 234,5"},
+		{"de_CH", "1234.5", "1’234.5"},
+	}
+	for _, ts := range tests {
+		lf := StdLocaleFormat(ts.loc)
+		if out := lf.LocalizeNumber(ts.in); out != ts.out {
+			t.Errorf("%v LocalizeNumber(%q) = %q, want %q", ts.loc, ts.in, out, ts.out)
+		}
+	}
+}
+
+func TestDelocalizeNumber(t *testing.T) {
+	tests := []struct {
+		loc, in, out string
+	}{
+		{"C", "1234.5", "1234.5"},
+		{"en_US", "1,234.5", "1234.5"},
+		{"de", "1.234,5", "1234.5"},
+		{"de", " -1.234.567 ", "-1234567"},
+		{"de", "1.234", "1234"},
+		{"fr", "1 234,5", "1234.5"},
+		{"fr", "1 234,5", "1234.5"},
+		{"de_CH", "1’234.5", "1234.5"},
+	}
+	for _, ts := range tests {
+		lf := StdLocaleFormat(ts.loc)
+		if out := lf.DelocalizeNumber(ts.in); out != ts.out {
+			t.Errorf("%v DelocalizeNumber(%q) = %q, want %q", ts.loc, ts.in, out, ts.out)
+		}
+	}
+}
+
+func TestLayoutMask(t *testing.T) {
+	tests := []struct {
+		layout, mask string
+	}{
+		{"02.01.2006", "99.99.9999"},
+		{"01/02/2006", "99/99/9999"},
+		{"2006-01-02 15:04", "9999-99-99 99:99"},
+		{"15:04:05", "99:99:99"},
+		{"15:04#", "99:99\\#"},
+		{"Jan 2, 2006", ""},
+		{"3:04 PM", ""},
+		{"2.1.2006", ""},
+	}
+	for _, ts := range tests {
+		if mask := LayoutMask(ts.layout); mask != ts.mask {
+			t.Errorf("LayoutMask(%q) = %q, want %q", ts.layout, mask, ts.mask)
+		}
+	}
+}
+
+func TestDateTimeFormat(t *testing.T) {
+	tm := time.Date(2020, 3, 4, 17, 6, 7, 0, time.FixedZone("X", 5*3600+1800))
+	tests := []struct {
+		loc, out string
+	}{
+		{"C", "2020-03-04 17:06:07 +05:30"},
+		{"en_US", "03/04/2020 5:06:07 PM +05:30"},
+		{"de", "04.03.2020 17:06:07 +05:30"},
+		{"fi", "4.3.2020 17.06.07 +05:30"},
+	}
+	for _, ts := range tests {
+		lf := StdLocaleFormat(ts.loc)
+		layout := lf.DateTimeFormat(DateTimeFieldDateTime)
+		str := tm.Format(layout)
+		if str != ts.out {
+			t.Errorf("%v: formatted %q, want %q", ts.loc, str, ts.out)
+		}
+		pt, err := time.Parse(layout, str)
+		if err != nil || !pt.Equal(tm) {
+			t.Errorf("%v: parsed %q back as %v, %v", ts.loc, str, pt, err)
+		}
+	}
+}
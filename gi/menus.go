@@ -5,9 +5,11 @@
 package gi
 
 import (
+	"fmt"
 	"image"
 	"log"
 
+	"github.com/goki/gi/i18n"
 	"github.com/goki/gi/oswin"
 	"github.com/goki/gi/oswin/key"
 	"github.com/goki/gi/units"
@@ -63,8 +65,8 @@ func (m *Menu) SetAction(ac *Action, opts ActOpts, sigTo ki.Ki, fun ki.RecvFunc)
 		nm = opts.Icon
 	}
 	ac.InitName(ac, nm)
-	ac.Text = opts.Label
-	ac.Tooltip = opts.Tooltip
+	ac.Text = TrText(ac, opts.Label)
+	ac.Tooltip = TrTooltip(ac, opts.Tooltip)
 	ac.Icon = IconName(opts.Icon)
 	ac.Shortcut = key.Chord(opts.Shortcut).OSShortcut()
 	if opts.ShortcutKey != KeyFunNil {
@@ -273,12 +275,17 @@ func (m *Menu) AddAppMenu(win *Window) {
 
 // AddStdAppMenu adds a standard set of menu items for application-level control.
 func (m *Menu) AddStdAppMenu(win *Window) {
-	aboutitle := "About " + oswin.TheApp.Name()
-	m.AddAction(ActOpts{Label: aboutitle},
-		win, func(recv, send ki.Ki, sig int64, data interface{}) {
-			ww := recv.Embed(KiT_Window).(*Window)
-			PromptDialog(ww.Viewport, DlgOpts{Title: aboutitle, Prompt: oswin.TheApp.About()}, AddOk, NoCancel, nil, nil)
-		})
+	aboutTitle := func() string {
+		return fmt.Sprintf(i18n.Tr("About %v"), oswin.TheApp.Name())
+	}
+	ac := m.AddAction(ActOpts{Name: "About " + oswin.TheApp.Name(),
+		UpdateFunc: func(ac *Action) {
+			ac.SetText(aboutTitle()) // follows locale changes
+		}}, win, func(recv, send ki.Ki, sig int64, data interface{}) {
+		ww := recv.Embed(KiT_Window).(*Window)
+		PromptDialog(ww.Viewport, DlgOpts{Title: aboutTitle(), Prompt: oswin.TheApp.About()}, AddOk, NoCancel, nil, nil)
+	})
+	ac.Text = aboutTitle() // already translated -- no TrTextProp
 	m.AddAction(ActOpts{Label: "GoGi Preferences...", Shortcut: "Command+P"},
 		win, func(recv, send ki.Ki, sig int64, data interface{}) {
 			TheViewIFace.PrefsView(&Prefs)
@@ -691,3 +698,10 @@ func (sp *Separator) Render2D() {
 		sp.PopBounds()
 	}
 }
+
+func (sp *Separator) AccessInfo2D(ai *AccessInfo) {
+	sp.WidgetBase.AccessInfo2D(ai)
+	ai.Role = AccessSeparator
+	ai.SetState(sp.Horiz, AccessHorizontal)
+	ai.SetState(!sp.Horiz, AccessVertical)
+}
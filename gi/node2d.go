@@ -241,6 +241,17 @@ type Node2D interface {
 	// For e.g., gi3d.Scene which renders directly to the window texture for maximum efficiency
 	// Returns true if this is a type of node that does this (even if it didn't do it)
 	DirectWinUpload() bool
+
+	// AccessInfo2D fills in the accessibility information for this node --
+	// its role, label, description, value, states, actions and relations --
+	// for assistive technology such as screen readers.  Nodes that leave the
+	// role as AccessNone are not exposed themselves, only their children.
+	AccessInfo2D(ai *AccessInfo)
+
+	// AccessAction2D performs the given accessibility action, one of those
+	// listed in AccessInfo.Actions -- returns false if the action is not
+	// supported or could not be performed.
+	AccessAction2D(act string) bool
 }
 
 // FocusChanges are the kinds of changes that can be reported via
@@ -237,6 +237,7 @@ type RenderState struct {
 	PaintBack      Paint             `desc:"backup of paint -- don't need a full stack but sometimes safer to backup and restore"`
 	RenderMu       sync.Mutex        `desc:"mutex for overall rendering"`
 	RasterMu       sync.Mutex        `desc:"mutex for final rasterx rendering -- only one at a time"`
+	Record         *PaintRecord      `desc:"if set, drawing operations are recorded in it, so they can be replayed -- see Canvas"`
 }
 
 // Init initializes RenderState -- must be called whenever image size changes
@@ -554,6 +555,9 @@ func (pc *Paint) stroke(rs *RenderState) {
 	if rs.Raster == nil {
 		return
 	}
+	if rs.Record != nil {
+		rs.Record.recordPath(pc, rs, (*Paint).stroke)
+	}
 	// pr := prof.Start("Paint.stroke")
 	// defer pr.End()
 
@@ -603,6 +607,9 @@ func (pc *Paint) fill(rs *RenderState) {
 	if rs.Raster == nil {
 		return
 	}
+	if rs.Record != nil {
+		rs.Record.recordPath(pc, rs, (*Paint).fill)
+	}
 	// pr := prof.Start("Paint.fill")
 	// pr.End()
 
@@ -665,6 +672,9 @@ func (pc *Paint) Fill(rs *RenderState) {
 func (pc *Paint) FillBox(rs *RenderState, pos, size mat32.Vec2, clr *ColorSpec) {
 	if clr.Source == SolidColor {
 		b := rs.Bounds.Intersect(mat32.RectFromPosSizeMax(pos, size))
+		if rs.Record != nil {
+			rs.Record.recordDraw(b, &image.Uniform{clr.Color}, draw.Src)
+		}
 		draw.Draw(rs.Image, b, &image.Uniform{clr.Color}, image.ZP, draw.Src)
 	} else {
 		pc.FillStyle.SetColorSpec(clr)
@@ -676,6 +686,9 @@ func (pc *Paint) FillBox(rs *RenderState, pos, size mat32.Vec2, clr *ColorSpec)
 // FillBoxColor is an optimized fill of a square region with given uniform color
 func (pc *Paint) FillBoxColor(rs *RenderState, pos, size mat32.Vec2, clr color.Color) {
 	b := rs.Bounds.Intersect(mat32.RectFromPosSizeMax(pos, size))
+	if rs.Record != nil {
+		rs.Record.recordDraw(b, &image.Uniform{clr}, draw.Src)
+	}
 	draw.Draw(rs.Image, b, &image.Uniform{clr}, image.ZP, draw.Src)
 }
 
@@ -693,6 +706,9 @@ func (pc *Paint) ClipPreserve(rs *RenderState) {
 		draw.DrawMask(mask, mask.Bounds(), clip, image.ZP, rs.Mask, image.ZP, draw.Over)
 		rs.Mask = mask
 	}
+	if rs.Record != nil {
+		rs.Record.recordMask(rs.Mask)
+	}
 }
 
 // SetMask allows you to directly set the *image.Alpha to be used as a clipping
@@ -703,6 +719,9 @@ func (pc *Paint) SetMask(rs *RenderState, mask *image.Alpha) error {
 		return errors.New("mask size must match context size")
 	}
 	rs.Mask = mask
+	if rs.Record != nil {
+		rs.Record.recordMask(mask)
+	}
 	return nil
 }
 
@@ -727,6 +746,9 @@ func (pc *Paint) Clip(rs *RenderState) {
 // ResetClip clears the clipping region.
 func (pc *Paint) ResetClip(rs *RenderState) {
 	rs.Mask = nil
+	if rs.Record != nil {
+		rs.Record.recordMask(nil)
+	}
 }
 
 //////////////////////////////////////////////////////////////////////////////////
@@ -735,11 +757,18 @@ func (pc *Paint) ResetClip(rs *RenderState) {
 // Clear fills the entire image with the current fill color.
 func (pc *Paint) Clear(rs *RenderState) {
 	src := image.NewUniform(&pc.FillStyle.Color.Color)
+	if rs.Record != nil {
+		rs.Record.recordClear(pc.FillStyle.Color.Color)
+	}
 	draw.Draw(rs.Image, rs.Image.Bounds(), src, image.ZP, draw.Src)
 }
 
 // SetPixel sets the color of the specified pixel using the current stroke color.
 func (pc *Paint) SetPixel(rs *RenderState, x, y int) {
+	if rs.Record != nil {
+		clr := pc.StrokeStyle.Color.Color
+		rs.Record.recordPixel(x, y, &clr)
+	}
 	rs.Image.Set(x, y, &pc.StrokeStyle.Color.Color)
 }
 
@@ -1009,15 +1038,24 @@ func (pc *Paint) DrawImageAnchored(rs *RenderState, fmIm image.Image, x, y int,
 	s := rs.Image.Bounds().Size()
 	x -= int(ax * float32(s.X))
 	y -= int(ay * float32(s.Y))
-	transformer := draw.BiLinear
 	fx, fy := float32(x), float32(y)
 	m := rs.XForm.Translate(fx, fy)
+	if rs.Record != nil {
+		rs.Record.recordImage(fmIm, m, rs.Mask)
+	}
+	drawImageXForm(rs.Image, fmIm, m, rs.Mask)
+}
+
+// drawImageXForm draws given image into given destination with given
+// transform, through given mask if non-nil
+func drawImageXForm(dst *image.RGBA, fmIm image.Image, m mat32.Mat2, mask *image.Alpha) {
+	transformer := draw.BiLinear
 	s2d := f64.Aff3{float64(m.XX), float64(m.XY), float64(m.X0), float64(m.YX), float64(m.YY), float64(m.Y0)}
-	if rs.Mask == nil {
-		transformer.Transform(rs.Image, s2d, fmIm, fmIm.Bounds(), draw.Over, nil)
+	if mask == nil {
+		transformer.Transform(dst, s2d, fmIm, fmIm.Bounds(), draw.Over, nil)
 	} else {
-		transformer.Transform(rs.Image, s2d, fmIm, fmIm.Bounds(), draw.Over, &draw.Options{
-			DstMask:  rs.Mask,
+		transformer.Transform(dst, s2d, fmIm, fmIm.Bounds(), draw.Over, &draw.Options{
+			DstMask:  mask,
 			DstMaskP: image.ZP,
 		})
 	}
@@ -0,0 +1,129 @@
+// Copyright (c) 2018, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/goki/mat32"
+	"github.com/srwiley/rasterx"
+	"golang.org/x/image/draw"
+)
+
+// PaintOp is one recorded drawing operation, which redoes it on given
+// RenderState
+type PaintOp func(rs *RenderState)
+
+// PaintRecord is a retained list of the drawing operations done with a
+// Paint on a RenderState while its Record is set -- each path fill and
+// stroke, box fill, image and text is recorded in the coordinates of the
+// render image (after the transform), with a copy of the paint styles in
+// effect, so the drawing can be replayed onto another RenderState, e.g., of
+// a different size.  Drawing directly into the Image of the RenderState is
+// not recorded.  Used by Canvas.
+type PaintRecord struct {
+	Ops []PaintOp `desc:"the recorded operations, in drawing order"`
+}
+
+// Reset clears the recorded operations
+func (pr *PaintRecord) Reset() {
+	pr.Ops = pr.Ops[:0]
+}
+
+// Len returns the number of recorded operations
+func (pr *PaintRecord) Len() int {
+	return len(pr.Ops)
+}
+
+// Add adds given operation to the record
+func (pr *PaintRecord) Add(op PaintOp) {
+	pr.Ops = append(pr.Ops, op)
+}
+
+// Replay redoes the recorded operations on given render state, which is
+// restored afterward -- drawing is restricted to the bounds in effect when
+// each operation was recorded, within the image of the render state
+func (pr *PaintRecord) Replay(rs *RenderState) {
+	rec, xf, bounds, mask := rs.Record, rs.XForm, rs.Bounds, rs.Mask
+	path, hasCur := rs.Path, rs.HasCurrent
+	rs.Record = nil
+	rs.Path = nil
+	for _, op := range pr.Ops {
+		op(rs)
+	}
+	rs.Record, rs.XForm, rs.Bounds, rs.Mask = rec, xf, bounds, mask
+	rs.Path, rs.HasCurrent = path, hasCur
+}
+
+// replayBounds sets the bounds of given render state to given recorded
+// bounds, within its image
+func replayBounds(rs *RenderState, bounds image.Rectangle) {
+	rs.Bounds = bounds.Intersect(rs.Image.Bounds())
+}
+
+// recordPath records drawing the current path of the render state with
+// given paint function (fill or stroke)
+func (pr *PaintRecord) recordPath(pc *Paint, rs *RenderState, fun func(pc *Paint, rs *RenderState)) {
+	rpc := *pc
+	rpc.StrokeStyle.Dashes = append([]float64(nil), pc.StrokeStyle.Dashes...)
+	path := append(rasterx.Path(nil), rs.Path...)
+	xf, bounds := rs.XForm, rs.Bounds
+	pr.Add(func(rs *RenderState) {
+		pc := rpc
+		pc.StrokeStyle.Dashes = append([]float64(nil), rpc.StrokeStyle.Dashes...) // stroke scales them
+		rs.Path = append(rs.Path[:0], path...)
+		rs.XForm = xf
+		replayBounds(rs, bounds)
+		fun(&pc, rs)
+	})
+}
+
+// recordDraw records drawing given source over given rectangle, with given
+// draw op
+func (pr *PaintRecord) recordDraw(r image.Rectangle, src image.Image, op draw.Op) {
+	pr.Add(func(rs *RenderState) {
+		draw.Draw(rs.Image, r.Intersect(rs.Image.Bounds()), src, image.ZP, op)
+	})
+}
+
+// recordClear records filling the entire image with given color
+func (pr *PaintRecord) recordClear(clr Color) {
+	pr.Add(func(rs *RenderState) {
+		draw.Draw(rs.Image, rs.Image.Bounds(), image.NewUniform(&clr), image.ZP, draw.Src)
+	})
+}
+
+// recordPixel records setting the pixel at given position to given color
+func (pr *PaintRecord) recordPixel(x, y int, clr color.Color) {
+	pr.Add(func(rs *RenderState) {
+		rs.Image.Set(x, y, clr)
+	})
+}
+
+// recordMask records setting the clipping mask of the render state to
+// given mask
+func (pr *PaintRecord) recordMask(mask *image.Alpha) {
+	pr.Add(func(rs *RenderState) {
+		rs.Mask = mask
+	})
+}
+
+// recordImage records drawing given image with given transform
+func (pr *PaintRecord) recordImage(img image.Image, m mat32.Mat2, mask *image.Alpha) {
+	pr.Add(func(rs *RenderState) {
+		drawImageXForm(rs.Image, img, m, mask)
+	})
+}
+
+// recordText records rendering a copy of given text at given position
+func (pr *PaintRecord) recordText(tr *TextRender, rs *RenderState, pos mat32.Vec2) {
+	rtr := tr.Clone()
+	bounds := rs.Bounds
+	pr.Add(func(rs *RenderState) {
+		replayBounds(rs, bounds)
+		rtr.Render(rs, pos)
+	})
+}
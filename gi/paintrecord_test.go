@@ -0,0 +1,52 @@
+// Copyright (c) 2018, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/goki/mat32"
+)
+
+func TestPaintRecordReplay(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 20, 20))
+	rs := &RenderState{}
+	rs.Init(20, 20, img)
+	rs.Bounds = img.Rect
+	rec := &PaintRecord{}
+	rs.Record = rec
+	pc := &rs.Paint
+	pc.FillStyle.SetColor(color.RGBA{255, 0, 0, 255})
+	pc.StrokeStyle.SetColor(nil)
+	pc.DrawRectangle(rs, 2, 2, 6, 6)
+	pc.Fill(rs)
+	pc.FillBoxColor(rs, mat32.Vec2{12, 12}, mat32.Vec2{4, 4}, color.RGBA{0, 0, 255, 255})
+	pc.FillStyle.SetColor(color.RGBA{0, 255, 0, 255}) // after drawing: must not affect the record
+	rs.Record = nil
+
+	if rec.Len() != 2 {
+		t.Fatalf("recorded %v ops, want 2", rec.Len())
+	}
+	for _, sz := range []int{20, 30, 10} {
+		rimg := image.NewRGBA(image.Rect(0, 0, sz, sz))
+		rrs := &RenderState{}
+		rrs.Init(sz, sz, rimg)
+		rrs.Bounds = rimg.Rect
+		rec.Replay(rrs)
+		for _, pt := range []image.Point{{4, 4}, {1, 1}, {9, 9}, {13, 13}} {
+			if !pt.In(rimg.Rect) {
+				continue
+			}
+			if got, want := rimg.RGBAAt(pt.X, pt.Y), img.RGBAAt(pt.X, pt.Y); got != want {
+				t.Errorf("size %v: replayed pixel at %v = %v, want %v", sz, pt, got, want)
+			}
+		}
+	}
+	if c := img.RGBAAt(4, 4); c.R != 255 || c.G != 0 {
+		t.Errorf("drawn pixel = %v, want red", c)
+	}
+}
@@ -10,10 +10,12 @@ import (
 	"image/color"
 	"io/ioutil"
 	"log"
+	"os"
 	"os/user"
 	"path/filepath"
 	"strings"
 
+	"github.com/goki/gi/i18n"
 	"github.com/goki/gi/oswin"
 	"github.com/goki/gi/oswin/mouse"
 	"github.com/goki/ki/ki"
@@ -38,25 +40,31 @@ var ZoomFactor = float32(1.0)
 // CSS-style sheets under CustomStyle.  These prefs are saved and loaded from
 // the GoGi user preferences directory -- see oswin/App for further info.
 type Preferences struct {
-	LogicalDPIScale      float32                `min:"0.1" step:"0.1" desc:"overall scaling factor for Logical DPI as a multiplier on Physical DPI -- smaller numbers produce smaller font sizes etc"`
-	ScreenPrefs          map[string]ScreenPrefs `desc:"screen-specific preferences -- will override overall defaults if set"`
-	Colors               ColorPrefs             `desc:"active color preferences"`
-	ColorSchemes         map[string]*ColorPrefs `desc:"named color schemes -- has Light and Dark schemes by default"`
-	Params               ParamPrefs             `view:"inline" desc:"parameters controlling GUI behavior"`
-	Editor               EditorPrefs            `view:"inline" desc:"editor preferences -- for TextView etc"`
-	KeyMap               KeyMapName             `desc:"select the active keymap from list of available keymaps -- see Edit KeyMaps for editing / saving / loading that list"`
-	SaveKeyMaps          bool                   `desc:"if set, the current available set of key maps is saved to your preferences directory, and automatically loaded at startup -- this should be set if you are using custom key maps, but it may be safer to keep it <i>OFF</i> if you are <i>not</i> using custom key maps, so that you'll always have the latest compiled-in standard key maps with all the current key functions bound to standard key chords"`
-	SaveDetailed         bool                   `desc:"if set, the detailed preferences are saved and loaded at startup -- only "`
-	CustomStyles         ki.Props               `desc:"a custom style sheet -- add a separate Props entry for each type of object, e.g., button, or class using .classname, or specific named element using #name -- all are case insensitive"`
-	CustomStylesOverride bool                   `desc:"if true my custom styles override other styling (i.e., they come <i>last</i> in styling process -- otherwise they provide defaults that can be overridden by app-specific styling (i.e, they come first)."`
-	FontFamily           FontName               `desc:"default font family when otherwise not specified"`
-	MonoFont             FontName               `desc:"default mono-spaced font family"`
-	FontPaths            []string               `desc:"extra font paths, beyond system defaults -- searched first"`
-	User                 User                   `desc:"user info -- partially filled-out automatically if empty / when prefs first created"`
-	FavPaths             FavPaths               `desc:"favorite paths, shown in FileViewer and also editable there"`
-	FileViewSort         string                 `view:"-" desc:"column to sort by in FileView, and :up or :down for direction -- updated automatically via FileView"`
-	ColorFilename        FileName               `view:"-" ext:".json" desc:"filename for saving / loading colors"`
-	Changed              bool                   `view:"-" changeflag:"+" json:"-" xml:"-" desc:"flag that is set by StructView by virtue of changeflag tag, whenever an edit is made.  Used to drive save menus etc."`
+	LogicalDPIScale      float32                    `min:"0.1" step:"0.1" desc:"overall scaling factor for Logical DPI as a multiplier on Physical DPI -- smaller numbers produce smaller font sizes etc"`
+	ScreenPrefs          map[string]ScreenPrefs     `desc:"screen-specific preferences -- will override overall defaults if set"`
+	Colors               ColorPrefs                 `desc:"active color preferences"`
+	ColorSchemes         map[string]*ColorPrefs     `desc:"named color schemes -- has Light and Dark schemes by default"`
+	Theme                ThemeName                  `desc:"theme for the colors, fonts, spacing and widget styles -- choose from the available themes, including any .json or .css theme files in the themes directory of the GoGi prefs directory -- choosing a theme replaces the Colors -- leave empty for the standard styles"`
+	Params               ParamPrefs                 `view:"inline" desc:"parameters controlling GUI behavior"`
+	Editor               EditorPrefs                `view:"inline" desc:"editor preferences -- for TextView etc"`
+	KeyMap               KeyMapName                 `desc:"select the active keymap from list of available keymaps -- see Edit KeyMaps for editing / saving / loading that list"`
+	Locale               string                     `desc:"language and region for the user interface, e.g., de or de_DE -- leave empty to use the system locale (from LANG) -- requires translations loaded by the app, see the i18n package"`
+	Format               LocaleFormat               `desc:"formats for numbers, dates and times, and currency -- set to the standard formats of the Locale (see LocaleFormats) unless CustomFormat is set"`
+	CustomFormat         bool                       `desc:"use the Format as edited here, instead of the standard formats of the Locale"`
+	SaveKeyMaps          bool                       `desc:"if set, the current available set of key maps is saved to your preferences directory, and automatically loaded at startup -- this should be set if you are using custom key maps, but it may be safer to keep it <i>OFF</i> if you are <i>not</i> using custom key maps, so that you'll always have the latest compiled-in standard key maps with all the current key functions bound to standard key chords"`
+	SaveDetailed         bool                       `desc:"if set, the detailed preferences are saved and loaded at startup -- only "`
+	CustomStyles         ki.Props                   `desc:"a custom style sheet -- add a separate Props entry for each type of object, e.g., button, or class using .classname, or specific named element using #name -- all are case insensitive"`
+	CustomStylesOverride bool                       `desc:"if true my custom styles override other styling (i.e., they come <i>last</i> in styling process -- otherwise they provide defaults that can be overridden by app-specific styling (i.e, they come first)."`
+	FontFamily           FontName                   `desc:"default font family when otherwise not specified"`
+	MonoFont             FontName                   `desc:"default mono-spaced font family"`
+	FontPaths            []string                   `desc:"extra font paths, beyond system defaults -- searched first"`
+	User                 User                       `desc:"user info -- partially filled-out automatically if empty / when prefs first created"`
+	FavPaths             FavPaths                   `desc:"favorite paths, shown in FileViewer and also editable there"`
+	FileViewSort         string                     `view:"-" desc:"column to sort by in FileView, and :up or :down for direction -- updated automatically via FileView"`
+	TableViews           map[string]*TableViewPrefs `view:"-" json:"-" xml:"-" desc:"column layout, sorting and filters for each type of struct viewed in a TableView, by full type name -- updated automatically via TableView, and saved in its own file (TableViewsFileName) when a window is closed"`
+	ColorFilename        FileName                   `view:"-" ext:".json" desc:"filename for saving / loading colors"`
+	Changed              bool                       `view:"-" changeflag:"+" json:"-" xml:"-" desc:"flag that is set by StructView by virtue of changeflag tag, whenever an edit is made.  Used to drive save menus etc."`
+	TableViewsChanged    bool                       `view:"-" json:"-" xml:"-" desc:"flag that is set when TableViews has been updated by a TableView -- they are then saved by SaveTableViews when a window is closed, instead of on every change"`
 }
 
 var KiT_Preferences = kit.Types.AddType(&Preferences{}, PreferencesProps)
@@ -74,6 +82,7 @@ func (pf *Preferences) Defaults() {
 	pf.FontFamily = "Go"
 	pf.MonoFont = "Go Mono"
 	pf.KeyMap = DefaultKeyMap
+	pf.Format = StdLocaleFormat(i18n.SystemLocale())
 	pf.UpdateUser()
 }
 
@@ -90,6 +99,9 @@ func (pf *Preferences) Open() error {
 		return err
 	}
 	err = json.Unmarshal(b, pf)
+	if terr := LoadThemesDir(filepath.Join(pdir, ThemesDirName)); terr != nil && !os.IsNotExist(terr) {
+		log.Println(terr)
+	}
 	if pf.SaveKeyMaps {
 		err = AvailKeyMaps.OpenPrefs()
 		if err != nil {
@@ -99,6 +111,7 @@ func (pf *Preferences) Open() error {
 	if pf.SaveDetailed {
 		PrefsDet.Open()
 	}
+	pf.OpenTableViews()
 	if pf.User.Username == "" {
 		pf.UpdateUser()
 	}
@@ -125,10 +138,56 @@ func (pf *Preferences) Save() error {
 	if pf.SaveDetailed {
 		PrefsDet.Save()
 	}
+	pf.SaveTableViews()
 	pf.Changed = false
 	return err
 }
 
+// TableViewsFileName is the name of the file in GoGi prefs directory where
+// the TableViews preferences are saved
+var TableViewsFileName = "table_views.json"
+
+// OpenTableViews opens the TableViews preferences from GoGi standard prefs
+// directory
+func (pf *Preferences) OpenTableViews() error {
+	pdir := oswin.TheApp.GoGiPrefsDir()
+	pnm := filepath.Join(pdir, TableViewsFileName)
+	b, err := ioutil.ReadFile(pnm)
+	if err != nil {
+		return err // ok to be non-existent
+	}
+	err = json.Unmarshal(b, &pf.TableViews)
+	if err != nil {
+		log.Println(err)
+	}
+	pf.TableViewsChanged = false
+	return err
+}
+
+// SaveTableViews saves the TableViews preferences to their own file in GoGi
+// standard prefs directory, if they have changed since the last save --
+// called when a window is closed, so the other preferences are only saved
+// explicitly
+func (pf *Preferences) SaveTableViews() error {
+	if !pf.TableViewsChanged {
+		return nil
+	}
+	pdir := oswin.TheApp.GoGiPrefsDir()
+	pnm := filepath.Join(pdir, TableViewsFileName)
+	b, err := json.MarshalIndent(pf.TableViews, "", "  ")
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	err = ioutil.WriteFile(pnm, b, 0644)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	pf.TableViewsChanged = false
+	return nil
+}
+
 // IsDarkMode returns true if the current background color preference is dark
 func (pf *Preferences) IsDarkMode() bool {
 	return pf.Colors.Background.IsDark()
@@ -195,6 +254,7 @@ func (pf *Preferences) Apply() {
 	if pf.ColorSchemes["Dark"].HiStyle == "" {
 		pf.ColorSchemes["Dark"].HiStyle = "monokai"
 	}
+	pf.ApplyTheme()
 
 	TheViewIFace.SetHiStyleDefault(pf.Colors.HiStyle)
 	mouse.DoubleClickMSec = pf.Params.DoubleClickMSec
@@ -204,6 +264,17 @@ func (pf *Preferences) Apply() {
 	if pf.KeyMap != "" {
 		SetActiveKeyMapName(pf.KeyMap) // fills in missing pieces
 	}
+	loc := pf.Locale
+	if loc == "" {
+		loc = i18n.SystemLocale()
+	}
+	if i18n.NormLocale(loc) != i18n.Locale() {
+		i18n.SetLocale(loc) // re-labels all windows
+	}
+	if !pf.CustomFormat {
+		pf.Format = StdLocaleFormat(loc)
+	}
+	ActiveLocaleFormat = pf.Format
 	if pf.SaveDetailed {
 		PrefsDet.Apply()
 	}
@@ -359,6 +430,14 @@ var PreferencesProps = ki.Props{
 			{"sep-color", ki.BlankProp{}},
 			{"LightMode", ki.Props{}},
 			{"DarkMode", ki.Props{}},
+			{"SetTheme", ki.Props{
+				"label": "Theme...",
+				"Args": ki.PropSlice{
+					{"Theme", ki.Props{
+						"default-field": "Theme",
+					}},
+				},
+			}},
 			{"sep-misc", ki.BlankProp{}},
 			{"SaveZoom", ki.Props{
 				"desc": "Save current zoom magnification factor, either for all screens or for the current screen only",
@@ -375,7 +454,7 @@ var PreferencesProps = ki.Props{
 			{"sep-close", ki.BlankProp{}},
 			{"Close Window", ki.BlankProp{}},
 		}},
-		{"Edit", "Copy Cut Paste"},
+		{"Edit", "Undo Redo Copy Cut Paste"},
 		{"Window", "Windows"},
 	},
 	"ToolBar": ki.PropSlice{
@@ -401,6 +480,16 @@ var PreferencesProps = ki.Props{
 			"desc": "Set color mode to Dark mode as defined in ColorSchemes -- automatically does Save and UpdateAll",
 			"icon": "color",
 		}},
+		{"SetTheme", ki.Props{
+			"label": "Theme",
+			"desc":  "Choose the theme for the colors, fonts, spacing and widget styles -- automatically does Save and UpdateAll",
+			"icon":  "color",
+			"Args": ki.PropSlice{
+				{"Theme", ki.Props{
+					"default-field": "Theme",
+				}},
+			},
+		}},
 		{"sep-scrn", ki.BlankProp{}},
 		{"SaveZoom", ki.Props{
 			"icon": "zoom-in",
@@ -822,6 +911,76 @@ func OpenPaths() {
 	StringsAddExtras((*[]string)(&SavedPaths), SavedPathsExtras)
 }
 
+//////////////////////////////////////////////////////////////////
+//  TableViewPrefs
+
+// TableViewPrefs records the column layout, sorting and filtering state of a
+// giv.TableView, which is saved in Prefs.TableViews for each type of struct
+// viewed, so that it persists across views and sessions -- the layout
+// (Order, Hidden, Widths) is always restored, while the sorting and filters
+// are only restored by views that opt in, as they sort the viewed slice and
+// hide rows.  Columns are identified by field name.
+type TableViewPrefs struct {
+	Order   []string           `desc:"field names in display order -- fields not listed are shown after those listed, in struct order"`
+	Hidden  []string           `desc:"field names of columns that are hidden"`
+	Widths  map[string]float32 `desc:"column widths in raw display units (dots), for columns that have been resized"`
+	Sort    []string           `desc:"sort keys in priority order, as the field name followed by :up or :down for the direction"`
+	Filters map[string]string  `desc:"filter expressions, by field name -- empty = no filter"`
+}
+
+// Copy returns a copy of the full state, including the sorting and filters
+func (tp *TableViewPrefs) Copy() *TableViewPrefs {
+	cp := tp.Layout()
+	cp.Sort = append(cp.Sort, tp.Sort...)
+	if tp.Filters != nil {
+		cp.Filters = make(map[string]string, len(tp.Filters))
+		for k, v := range tp.Filters {
+			cp.Filters[k] = v
+		}
+	}
+	return cp
+}
+
+// Layout returns a copy of the column layout (Order, Hidden, Widths), without
+// the sorting and filters
+func (tp *TableViewPrefs) Layout() *TableViewPrefs {
+	ly := &TableViewPrefs{}
+	ly.Order = append(ly.Order, tp.Order...)
+	ly.Hidden = append(ly.Hidden, tp.Hidden...)
+	if tp.Widths != nil {
+		ly.Widths = make(map[string]float32, len(tp.Widths))
+		for k, v := range tp.Widths {
+			ly.Widths[k] = v
+		}
+	}
+	return ly
+}
+
+// IsHidden returns true if the column for given field is hidden
+func (tp *TableViewPrefs) IsHidden(fld string) bool {
+	for _, nm := range tp.Hidden {
+		if nm == fld {
+			return true
+		}
+	}
+	return false
+}
+
+// SetHidden sets whether the column for given field is hidden
+func (tp *TableViewPrefs) SetHidden(fld string, hide bool) {
+	for i, nm := range tp.Hidden {
+		if nm == fld {
+			if !hide {
+				tp.Hidden = append(tp.Hidden[:i], tp.Hidden[i+1:]...)
+			}
+			return
+		}
+	}
+	if hide {
+		tp.Hidden = append(tp.Hidden, fld)
+	}
+}
+
 //////////////////////////////////////////////////////////////////
 //  PrefsDetailed
 
@@ -837,6 +996,8 @@ type PrefsDetailed struct {
 	DNDStartPix                int  `def:"20" min:"0" max:"100" step:"1" desc:"the number of pixels that must be moved before initiating a drag-n-drop event -- gotta drag it like you mean it"`
 	HoverStartMSec             int  `def:"1000" min:"10" max:"10000" step:"10" desc:"the number of milliseconds to wait before initiating a hover event (e.g., for opening a tooltip)"`
 	HoverMaxPix                int  `def:"5" min:"0" max:"1000" step:"1" desc:"the maximum number of pixels that mouse can move and still register a Hover event"`
+	TooltipShowMSec            int  `def:"0" min:"0" max:"10000" step:"10" desc:"the number of milliseconds to wait before showing a tooltip, in addition to the HoverStartMSec for the hover event that triggers it"`
+	TooltipHideMSec            int  `def:"0" min:"0" max:"60000" step:"100" desc:"the number of milliseconds after which a tooltip is hidden automatically -- 0 = only when the mouse moves away"`
 	CompleteWaitMSec           int  `def:"500" min:"10" max:"10000" step:"10" desc:"the number of milliseconds to wait before offering completions"`
 	CompleteMaxItems           int  `def:"25" min:"5" step:"1" desc:"the maximum number of completions offered in popup"`
 	CursorBlinkMSec            int  `def:"500" min:"0" max:"1000" step:"5" desc:"number of milliseconds that cursor blinks on and off -- set to 0 to disable blinking"`
@@ -906,6 +1067,8 @@ func (pf *PrefsDetailed) Defaults() {
 	pf.DNDStartPix = DNDStartPix
 	pf.HoverStartMSec = HoverStartMSec
 	pf.HoverMaxPix = HoverMaxPix
+	pf.TooltipShowMSec = TooltipShowMSec
+	pf.TooltipHideMSec = TooltipHideMSec
 	pf.CompleteWaitMSec = CompleteWaitMSec
 	pf.CompleteMaxItems = CompleteMaxItems
 	pf.CursorBlinkMSec = CursorBlinkMSec
@@ -934,6 +1097,8 @@ func (pf *PrefsDetailed) Apply() {
 	DNDStartPix = pf.DNDStartPix
 	HoverStartMSec = pf.HoverStartMSec
 	HoverMaxPix = pf.HoverMaxPix
+	TooltipShowMSec = pf.TooltipShowMSec
+	TooltipHideMSec = pf.TooltipHideMSec
 	CompleteWaitMSec = pf.CompleteWaitMSec
 	CompleteMaxItems = pf.CompleteMaxItems
 	CursorBlinkMSec = pf.CursorBlinkMSec
@@ -968,7 +1133,7 @@ var PrefsDetailedProps = ki.Props{
 			}},
 			{"Close Window", ki.BlankProp{}},
 		}},
-		{"Edit", "Copy Cut Paste"},
+		{"Edit", "Undo Redo Copy Cut Paste"},
 		{"Window", "Windows"},
 	},
 	"ToolBar": ki.PropSlice{
@@ -0,0 +1,476 @@
+// Copyright (c) 2018, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/chewxy/math32"
+	"github.com/goki/gi/units"
+	"github.com/goki/ki/ki"
+	"github.com/goki/ki/kit"
+	"github.com/goki/mat32"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////
+// ProgressBar
+
+// ProgressBar shows the progress of an operation as a bar that fills from
+// left to right in proportion to Value / Max.  In Indeterminate mode (for
+// operations of unknown length), a block moves back and forth instead.
+// Text can optionally be shown on top of the bar -- the percent complete
+// by default, or the Text string if set.
+type ProgressBar struct {
+	WidgetBase
+	Value         float32    `xml:"value" desc:"current progress value, from 0 to Max"`
+	Max           float32    `xml:"max" desc:"value corresponding to completion -- defaults to 1 so Value can be given as a proportion"`
+	Indeterminate bool       `xml:"indeterminate" desc:"show an animated moving block instead of the Value, for operations of unknown length"`
+	ShowText      bool       `xml:"show-text" desc:"show text on top of the bar: the Text if set, otherwise the percent complete"`
+	Text          string     `xml:"text" desc:"text to show on the bar when ShowText is on -- if empty, percent complete is shown"`
+	BarColor      Color      `xml:"bar-color" desc:"color of the filled portion of the bar -- set from bar-color property"`
+	Phase         float32    `copy:"-" json:"-" xml:"-" view:"-" desc:"animation phase for Indeterminate mode, from 0 to 1"`
+	Render        TextRender `copy:"-" json:"-" xml:"-" view:"-" desc:"render data for the text"`
+}
+
+var KiT_ProgressBar = kit.Types.AddType(&ProgressBar{}, ProgressBarProps)
+
+// AddNewProgressBar adds a new progress bar to given parent node, with given name.
+func AddNewProgressBar(parent ki.Ki, name string) *ProgressBar {
+	pb := parent.AddNewChild(KiT_ProgressBar, name).(*ProgressBar)
+	pb.Max = 1
+	return pb
+}
+
+func (pb *ProgressBar) CopyFieldsFrom(frm interface{}) {
+	fr := frm.(*ProgressBar)
+	pb.WidgetBase.CopyFieldsFrom(&fr.WidgetBase)
+	pb.Value = fr.Value
+	pb.Max = fr.Max
+	pb.Indeterminate = fr.Indeterminate
+	pb.ShowText = fr.ShowText
+	pb.Text = fr.Text
+	pb.BarColor = fr.BarColor
+}
+
+var ProgressBarProps = ki.Props{
+	"EnumType:Flag":    KiT_NodeFlags,
+	"border-width":     units.NewPx(1),
+	"border-radius":    units.NewPx(4),
+	"border-color":     &Prefs.Colors.Border,
+	"padding":          units.NewPx(0),
+	"margin":           units.NewPx(2),
+	"max-width":        -1,
+	"vertical-align":   AlignMiddle,
+	"font-size":        units.NewPt(10),
+	"color":            &Prefs.Colors.Font,
+	"background-color": &Prefs.Colors.Control,
+	"bar-color":        &Prefs.Colors.Select,
+}
+
+// Frac returns the proportion complete, from 0 to 1
+func (pb *ProgressBar) Frac() float32 {
+	if pb.Max <= 0 {
+		return mat32.Clamp(pb.Value, 0, 1)
+	}
+	return mat32.Clamp(pb.Value/pb.Max, 0, 1)
+}
+
+// Label returns the text shown on the bar when ShowText is on
+func (pb *ProgressBar) Label() string {
+	if pb.Text != "" {
+		return pb.Text
+	}
+	if pb.Indeterminate {
+		return ""
+	}
+	return fmt.Sprintf("%d%%", int(100*pb.Frac()+0.5))
+}
+
+// SetValue sets the progress value (clamped to 0..Max) and updates the display
+func (pb *ProgressBar) SetValue(val float32) {
+	mx := pb.Max
+	if mx <= 0 {
+		mx = 1
+	}
+	val = mat32.Clamp(val, 0, mx)
+	if val == pb.Value && !pb.Indeterminate {
+		return
+	}
+	updt := pb.UpdateStart()
+	pb.Value = val
+	pb.Indeterminate = false
+	pb.UpdateEnd(updt)
+}
+
+// SetIndeterminate turns Indeterminate mode on or off, starting or stopping
+// the animation as needed
+func (pb *ProgressBar) SetIndeterminate(on bool) {
+	if pb.Indeterminate == on {
+		return
+	}
+	updt := pb.UpdateStart()
+	pb.Indeterminate = on
+	pb.Phase = 0
+	pb.UpdateEnd(updt)
+}
+
+// SetText sets the text to show on the bar, and turns on ShowText if non-empty
+func (pb *ProgressBar) SetText(txt string) {
+	updt := pb.UpdateStart()
+	pb.Text = txt
+	if txt != "" {
+		pb.ShowText = true
+	}
+	pb.UpdateEnd(updt)
+}
+
+func (pb *ProgressBar) AccessInfo2D(ai *AccessInfo) {
+	pb.WidgetBase.AccessInfo2D(ai)
+	ai.Role = AccessProgressBar
+	ai.Value = pb.Label()
+	ai.SetRange(pb.Value, 0, pb.Max, 0)
+	ai.SetState(pb.Indeterminate, AccessBusy)
+}
+
+// IsAnimating returns true if the bar is currently animating
+func (pb *ProgressBar) IsAnimating() bool {
+	return pb.Indeterminate
+}
+
+// AnimStep advances the animation phase by one step and updates the display
+func (pb *ProgressBar) AnimStep() {
+	pb.Phase += 0.02
+	if pb.Phase >= 1 {
+		pb.Phase -= 1
+	}
+	pb.UpdateSig()
+}
+
+// StyleProgressBar does the style for the bar, including the bar-color property
+func (pb *ProgressBar) StyleProgressBar() {
+	pb.Style2DWidget()
+	pb.StyMu.Lock()
+	if pv, ok := pb.PropInherit("bar-color", ki.NoInherit, ki.TypeProps); ok {
+		pb.BarColor.SetIFace(pv, pb.Viewport, "bar-color")
+	}
+	pb.StyMu.Unlock()
+}
+
+func (pb *ProgressBar) Style2D() {
+	pb.StyleProgressBar()
+	pb.StyMu.Lock()
+	pb.LayState.SetFromStyle(&pb.Sty.Layout) // also does reset
+	pb.StyMu.Unlock()
+}
+
+func (pb *ProgressBar) Size2D(iter int) {
+	pb.InitLayout2D()
+	st := &pb.Sty
+	fht := st.Font.Face.Metrics.Height
+	pb.Size2DFromWH(8*fht, 1.2*fht)
+}
+
+func (pb *ProgressBar) Render2D() {
+	if pb.FullReRenderIfNeeded() {
+		return
+	}
+	if pb.PushBounds() {
+		pb.RenderProgressBar()
+		pb.Render2DChildren()
+		pb.PopBounds()
+		if pb.IsAnimating() {
+			ProgressAnimAdd(pb)
+		}
+	}
+}
+
+// RenderProgressBar renders the box, the filled portion of the bar, and the text
+func (pb *ProgressBar) RenderProgressBar() {
+	rs, pc, st := pb.RenderLock()
+	defer pb.RenderUnlock(rs)
+
+	pb.RenderStdBox(st)
+
+	spc := st.BoxSpace()
+	pos := pb.LayState.Alloc.Pos.AddScalar(spc)
+	sz := pb.LayState.Alloc.Size.AddScalar(-2.0 * spc)
+	if sz.X <= 0 || sz.Y <= 0 {
+		return
+	}
+
+	bpos := pos
+	bsz := sz
+	if pb.Indeterminate {
+		bw := 0.25 * sz.X
+		ph := 2 * pb.Phase // goes out and back
+		if ph > 1 {
+			ph = 2 - ph
+		}
+		bpos.X += ph * (sz.X - bw)
+		bsz.X = bw
+	} else {
+		bsz.X = pb.Frac() * sz.X
+	}
+	if bsz.X > 0 {
+		pc.StrokeStyle.SetColor(nil)
+		pc.FillStyle.SetColor(&pb.BarColor)
+		pc.DrawRoundedRectangle(rs, bpos.X, bpos.Y, bsz.X, bsz.Y, mat32.Max(0, st.Border.Radius.Dots-st.Border.Width.Dots))
+		pc.Fill(rs)
+	}
+
+	if !pb.ShowText {
+		return
+	}
+	txt := pb.Label()
+	if txt == "" {
+		return
+	}
+	pb.Render.SetString(txt, &st.Font, &st.UnContext, &st.Text, true, 0, 0)
+	tpos := mat32.Vec2{pos.X + 0.5*(sz.X-pb.Render.Size.X), pos.Y + 0.5*(sz.Y-pb.Render.Size.Y)}
+	pb.Render.RenderTopPos(rs, tpos)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////
+// Spinner
+
+// Spinner is a busy indicator, showing a rotating arc while Spinning -- use
+// for operations of unknown length where a ProgressBar would take up too
+// much space.  When not spinning it still takes up its space, but draws nothing.
+type Spinner struct {
+	WidgetBase
+	Spinning bool    `xml:"spinning" desc:"whether the spinner is currently spinning"`
+	Phase    float32 `copy:"-" json:"-" xml:"-" view:"-" desc:"rotation phase, from 0 to 1"`
+}
+
+var KiT_Spinner = kit.Types.AddType(&Spinner{}, SpinnerProps)
+
+// AddNewSpinner adds a new spinner to given parent node, with given name.
+func AddNewSpinner(parent ki.Ki, name string) *Spinner {
+	return parent.AddNewChild(KiT_Spinner, name).(*Spinner)
+}
+
+func (sp *Spinner) CopyFieldsFrom(frm interface{}) {
+	fr := frm.(*Spinner)
+	sp.WidgetBase.CopyFieldsFrom(&fr.WidgetBase)
+	sp.Spinning = fr.Spinning
+}
+
+var SpinnerProps = ki.Props{
+	"EnumType:Flag":  KiT_NodeFlags,
+	"padding":        units.NewPx(0),
+	"margin":         units.NewPx(2),
+	"width":          units.NewEm(1.2),
+	"height":         units.NewEm(1.2),
+	"vertical-align": AlignMiddle,
+	"color":          &Prefs.Colors.Select,
+}
+
+// Start starts the spinner spinning
+func (sp *Spinner) Start() {
+	sp.SetSpinning(true)
+}
+
+// Stop stops the spinner, which then draws nothing
+func (sp *Spinner) Stop() {
+	sp.SetSpinning(false)
+}
+
+// SetSpinning sets whether the spinner is spinning, updating as needed
+func (sp *Spinner) SetSpinning(spin bool) {
+	if sp.Spinning == spin {
+		return
+	}
+	updt := sp.UpdateStart()
+	sp.Spinning = spin
+	sp.UpdateEnd(updt)
+}
+
+// IsAnimating returns true if the spinner is currently animating
+func (sp *Spinner) IsAnimating() bool {
+	return sp.Spinning
+}
+
+// AnimStep advances the rotation by one step and updates the display
+func (sp *Spinner) AnimStep() {
+	sp.Phase += 0.05
+	if sp.Phase >= 1 {
+		sp.Phase -= 1
+	}
+	sp.UpdateSig()
+}
+
+func (sp *Spinner) Size2D(iter int) {
+	sp.InitLayout2D()
+	st := &sp.Sty
+	fht := st.Font.Face.Metrics.Height
+	sp.Size2DFromWH(fht, fht)
+}
+
+func (sp *Spinner) Render2D() {
+	if sp.FullReRenderIfNeeded() {
+		return
+	}
+	if sp.PushBounds() {
+		sp.RenderSpinner()
+		sp.Render2DChildren()
+		sp.PopBounds()
+		if sp.IsAnimating() {
+			ProgressAnimAdd(sp)
+		}
+	}
+}
+
+// RenderSpinner renders the background and the rotating arc
+func (sp *Spinner) RenderSpinner() {
+	rs, pc, st := sp.RenderLock()
+	defer sp.RenderUnlock(rs)
+
+	pos := sp.LayState.Alloc.Pos.AddScalar(st.Layout.Margin.Dots)
+	sz := sp.LayState.Alloc.Size.AddScalar(-2.0 * st.Layout.Margin.Dots)
+	if !st.Font.BgColor.IsNil() {
+		pc.FillBox(rs, pos, sz, &st.Font.BgColor)
+	}
+	if !sp.Spinning {
+		return
+	}
+	rad := 0.5 * mat32.Min(sz.X, sz.Y)
+	wd := mat32.Max(1, 0.2*rad)
+	rad -= 0.5 * wd
+	if rad <= 0 {
+		return
+	}
+	ctr := pos.Add(sz.MulScalar(0.5))
+	st1 := 2 * math32.Pi * sp.Phase
+	pc.FillStyle.SetColor(nil)
+	pc.StrokeStyle.SetColor(&st.Font.Color)
+	pc.StrokeStyle.Width.Dots = wd
+	pc.DrawArc(rs, ctr.X, ctr.Y, rad, st1, st1+1.5*math32.Pi)
+	pc.Stroke(rs)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////
+// Animation
+
+// ProgressAnimator is implemented by widgets that are animated by the shared
+// progress animation ticker (ProgressBar in Indeterminate mode, Spinner)
+type ProgressAnimator interface {
+	Node2D
+
+	// IsAnimating returns true if the widget still needs to be animated
+	IsAnimating() bool
+
+	// AnimStep advances the animation one step and updates the display --
+	// always called on the window event-processing goroutine
+	AnimStep()
+}
+
+// ProgressAnimMSec is the number of milliseconds between animation steps
+// for ProgressAnimator widgets
+var ProgressAnimMSec = 50
+
+// ProgressAnimMu is mutex protecting the progress animation state
+var ProgressAnimMu sync.Mutex
+
+// ProgressAnimTicker is the time.Ticker driving ProgressAnimator widgets --
+// it only runs while there are widgets to animate
+var ProgressAnimTicker *time.Ticker
+
+// progressAnimNodes are the currently-animating nodes
+var progressAnimNodes = map[ProgressAnimator]bool{}
+
+// progressAnimPending records windows with an animation step not yet processed
+var progressAnimPending = map[*Window]bool{}
+
+// ProgressAnimAdd adds given widget to those being animated, starting the
+// ticker if needed -- it is removed automatically once it stops animating,
+// becomes invisible, or is destroyed.
+func ProgressAnimAdd(pa ProgressAnimator) {
+	if ProgressAnimMSec == 0 {
+		return
+	}
+	ProgressAnimMu.Lock()
+	progressAnimNodes[pa] = true
+	if ProgressAnimTicker == nil {
+		ProgressAnimTicker = time.NewTicker(time.Duration(ProgressAnimMSec) * time.Millisecond)
+		go ProgressAnim()
+	}
+	ProgressAnimMu.Unlock()
+}
+
+// ProgressAnim is the goroutine that sends animation steps to each window
+// with animating widgets -- the steps themselves run on the window goroutine
+func ProgressAnim() {
+	for {
+		ProgressAnimMu.Lock()
+		tick := ProgressAnimTicker
+		ProgressAnimMu.Unlock()
+		if tick == nil {
+			return
+		}
+		<-tick.C
+		ProgressAnimMu.Lock()
+		for win := range progressAnimPending {
+			if win.IsClosed() {
+				delete(progressAnimPending, win)
+			}
+		}
+		wins := map[*Window][]ProgressAnimator{}
+		for pa := range progressAnimNodes {
+			win := progressAnimWin(pa)
+			if win == nil {
+				delete(progressAnimNodes, pa)
+				continue
+			}
+			if win.IsResizing() || win.IsUpdating() || progressAnimPending[win] {
+				continue
+			}
+			wins[win] = append(wins[win], pa)
+		}
+		if len(progressAnimNodes) == 0 {
+			ProgressAnimTicker.Stop()
+			ProgressAnimTicker = nil
+			ProgressAnimMu.Unlock()
+			return
+		}
+		for win, pas := range wins {
+			progressAnimPending[win] = true
+			win, pas := win, pas
+			win.RunOnWin(func() {
+				ProgressAnimMu.Lock()
+				delete(progressAnimPending, win)
+				ProgressAnimMu.Unlock()
+				for _, pa := range pas {
+					if pa.This() == nil || pa.IsDestroyed() || pa.IsDeleted() || !pa.IsAnimating() {
+						continue
+					}
+					pa.AnimStep()
+				}
+			})
+		}
+		ProgressAnimMu.Unlock()
+	}
+}
+
+// progressAnimWin returns the window for given animated widget, or nil if
+// it should no longer be animated
+func progressAnimWin(pa ProgressAnimator) *Window {
+	if pa.This() == nil || pa.IsDestroyed() || pa.IsDeleted() {
+		return nil
+	}
+	if !pa.IsAnimating() || !pa.IsVisible() {
+		return nil
+	}
+	wb := pa.AsWidget()
+	if wb == nil || wb.Viewport == nil {
+		return nil
+	}
+	win := wb.ParentWindow()
+	if win == nil || win.IsClosed() {
+		return nil
+	}
+	return win
+}
@@ -0,0 +1,567 @@
+// Copyright (c) 2018, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"fmt"
+	"image"
+
+	"github.com/chewxy/math32"
+	"github.com/goki/gi/oswin"
+	"github.com/goki/gi/oswin/key"
+	"github.com/goki/gi/oswin/mouse"
+	"github.com/goki/gi/units"
+	"github.com/goki/ki/ki"
+	"github.com/goki/ki/kit"
+	"github.com/goki/mat32"
+)
+
+// Range32 is a numeric interval from Min to Max, as edited by a RangeSlider
+type Range32 struct {
+	Min float32 `desc:"low end of the range"`
+	Max float32 `desc:"high end of the range"`
+}
+
+var KiT_Range32 = kit.Types.AddType(&Range32{}, nil)
+
+// Set sets the min and max values
+func (r *Range32) Set(min, max float32) {
+	r.Min = min
+	r.Max = max
+}
+
+// Contains returns true if given value is within the range, inclusive
+func (r *Range32) Contains(val float32) bool {
+	return val >= r.Min && val <= r.Max
+}
+
+// String satisfies the Stringer interface
+func (r Range32) String() string {
+	return fmt.Sprintf("%g..%g", r.Min, r.Max)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////
+//  RangeSlider
+
+// RangeSlider is a slider with two thumbs, for selecting an interval of
+// values: Value is the low end and HighValue the high end, and the thumbs
+// cannot cross (see MinGap).  The thumb nearest to a mouse click is moved,
+// and it becomes the active thumb that is moved by the arrow and page keys
+// -- Enter or SelectMode (Control+Space) switches the active thumb, Home moves
+// the low thumb to Min and End the high thumb to Max.  SliderValueChanged
+// signals have a Range32 as data.
+type RangeSlider struct {
+	SliderBase
+	HighValue   float32 `xml:"high-value" desc:"current value of the high thumb -- Value is the low thumb"`
+	MinGap      float32 `xml:"min-gap" desc:"minimum distance between the low and high values"`
+	HighActive  bool    `desc:"if true, the high thumb is the active one, which is moved by the keyboard and mouse scroll"`
+	HighPos     float32 `xml:"-" desc:"logical position of the high thumb relative to Size"`
+	HighDragPos float32 `xml:"-" desc:"underlying drag position of the high thumb -- not subject to snapping"`
+	EmitHigh    float32 `copy:"-" xml:"-" json:"-" desc:"previous emitted high value - don't re-emit if it is the same"`
+}
+
+var KiT_RangeSlider = kit.Types.AddType(&RangeSlider{}, RangeSliderProps)
+
+// AddNewRangeSlider adds a new range slider to given parent node, with given name.
+func AddNewRangeSlider(parent ki.Ki, name string) *RangeSlider {
+	return parent.AddNewChild(KiT_RangeSlider, name).(*RangeSlider)
+}
+
+func (rs *RangeSlider) CopyFieldsFrom(frm interface{}) {
+	fr := frm.(*RangeSlider)
+	rs.SliderBase.CopyFieldsFrom(&fr.SliderBase)
+	rs.HighValue = fr.HighValue
+	rs.MinGap = fr.MinGap
+	rs.HighActive = fr.HighActive
+	rs.HighPos = fr.HighPos
+	rs.HighDragPos = fr.HighDragPos
+}
+
+var RangeSliderProps = ki.Props{
+	"EnumType:Flag":    KiT_NodeFlags,
+	"border-width":     units.NewPx(1),
+	"border-radius":    units.NewPx(4),
+	"border-color":     &Prefs.Colors.Border,
+	"padding":          units.NewPx(6),
+	"margin":           units.NewPx(4),
+	"background-color": &Prefs.Colors.Control,
+	"color":            &Prefs.Colors.Font,
+	SliderSelectors[SliderActive]: ki.Props{
+		"background-color": "lighter-0",
+	},
+	SliderSelectors[SliderInactive]: ki.Props{
+		"border-color": "highlight-50",
+		"color":        "highlight-50",
+	},
+	SliderSelectors[SliderHover]: ki.Props{
+		"background-color": "highlight-10",
+	},
+	SliderSelectors[SliderFocus]: ki.Props{
+		"border-width":     units.NewPx(2),
+		"background-color": "samelight-50",
+	},
+	SliderSelectors[SliderDown]: ki.Props{
+		"background-color": "highlight-20",
+	},
+	SliderSelectors[SliderValue]: ki.Props{
+		"border-color":     &Prefs.Colors.Icon,
+		"background-color": &Prefs.Colors.Icon,
+	},
+	SliderSelectors[SliderBox]: ki.Props{
+		"border-color":     &Prefs.Colors.Background,
+		"background-color": &Prefs.Colors.Background,
+	},
+}
+
+func (rs *RangeSlider) Defaults() {
+	rs.ThumbSize = units.NewEm(1.5)
+	rs.ThSize = 25.0
+	rs.ThSizeReal = rs.ThSize
+	rs.Step = 0.1
+	rs.PageStep = 0.2
+	rs.Max = 1.0
+	if rs.HighValue == 0 {
+		rs.HighValue = rs.Max
+	}
+	rs.Prec = 9
+}
+
+// Range returns the current low and high values as a Range32
+func (rs *RangeSlider) Range() Range32 {
+	return Range32{rs.Value, rs.HighValue}
+}
+
+// ClampRange returns the given low and high values constrained to Min..Max,
+// with low <= high - MinGap
+func (rs *RangeSlider) ClampRange(low, high float32) (float32, float32) {
+	if high < low {
+		low, high = high, low
+	}
+	low = mat32.Clamp(low, rs.Min, rs.Max)
+	high = mat32.Clamp(high, rs.Min, rs.Max)
+	if high-low < rs.MinGap {
+		if low+rs.MinGap <= rs.Max {
+			high = low + rs.MinGap
+		} else {
+			high = rs.Max
+			low = mat32.Max(rs.Min, high-rs.MinGap)
+		}
+	}
+	return low, high
+}
+
+// SetRange sets the low and high values, constrained to Min..Max, and
+// updates the thumb positions, but does not emit a signal (see SetRangeAction)
+func (rs *RangeSlider) SetRange(low, high float32) {
+	updt := rs.UpdateStart()
+	rs.Value, rs.HighValue = rs.ClampRange(low, high)
+	rs.UpdateRangePos()
+	rs.UpdateEnd(updt)
+}
+
+// SetRangeAction sets the low and high values and emits a changed signal
+func (rs *RangeSlider) SetRangeAction(low, high float32) {
+	rs.SetRange(low, high)
+	rs.EmitNewRange()
+}
+
+// EmitNewRange emits SliderValueChanged with the Range32 if it has changed
+// since the last emit -- returns true if emitted
+func (rs *RangeSlider) EmitNewRange() bool {
+	if rs.Value == rs.EmitValue && rs.HighValue == rs.EmitHigh {
+		return false
+	}
+	rs.EmitValue = rs.Value
+	rs.EmitHigh = rs.HighValue
+	rs.SliderSig.Emit(rs.This(), int64(SliderValueChanged), rs.Range())
+	return true
+}
+
+// UpdateRangePos updates the thumb positions from the current values
+func (rs *RangeSlider) UpdateRangePos() {
+	if rs.Size == 0.0 {
+		return
+	}
+	rs.Pos = rs.Size * rs.ValueToFrac(rs.Value)
+	rs.HighPos = rs.Size * rs.ValueToFrac(rs.HighValue)
+	rs.DragPos = rs.Pos
+	rs.HighDragPos = rs.HighPos
+}
+
+// SizeFromAlloc gets size from allocation
+func (rs *RangeSlider) SizeFromAlloc() {
+	if rs.LayState.Alloc.Size.IsNil() {
+		return
+	}
+	if rs.Min == 0 && rs.Max == 0 { // uninit
+		rs.Defaults()
+	}
+	spc := rs.BoxSpace()
+	rs.Size = rs.LayState.Alloc.Size.Dim(rs.Dim) - 2.0*spc - rs.ThSize
+	if rs.Size <= 0 {
+		return
+	}
+	rs.UpdateRangePos()
+}
+
+// SetThumbPos sets the position of the high or low thumb at given position
+// in pixels, and updates the corresponding value, keeping it from crossing
+// the other thumb
+func (rs *RangeSlider) SetThumbPos(high bool, pos float32) {
+	if rs.Size <= 0 {
+		return
+	}
+	updt := rs.UpdateStart()
+	pos = mat32.Clamp(pos, 0, rs.Size)
+	val := mat32.Truncate(rs.FracToValue(pos/rs.Size), rs.Prec)
+	if rs.Snap {
+		val = mat32.Truncate(mat32.IntMultiple(val, rs.Step), rs.Prec)
+	}
+	if high {
+		rs.HighValue = mat32.Clamp(val, mat32.Min(rs.Value+rs.MinGap, rs.Max), rs.Max)
+		rs.HighDragPos = pos
+	} else {
+		rs.Value = mat32.Clamp(val, rs.Min, mat32.Max(rs.HighValue-rs.MinGap, rs.Min))
+		rs.DragPos = pos
+	}
+	rs.Pos = rs.Size * rs.ValueToFrac(rs.Value)
+	rs.HighPos = rs.Size * rs.ValueToFrac(rs.HighValue)
+	if rs.Tracking && (math32.Abs(rs.Value-rs.EmitValue) > rs.TrackThr || math32.Abs(rs.HighValue-rs.EmitHigh) > rs.TrackThr) {
+		rs.EmitNewRange()
+	}
+	rs.UpdateEnd(updt)
+}
+
+// StepThumb moves the active thumb by given number of steps (e.g., Step or
+// PageStep, can be negative) and emits a changed signal
+func (rs *RangeSlider) StepThumb(steps float32) {
+	if rs.HighActive {
+		rs.SetRangeAction(rs.Value, mat32.Max(rs.StepValue(rs.HighValue, steps), rs.Value+rs.MinGap))
+	} else {
+		rs.SetRangeAction(mat32.Min(rs.StepValue(rs.Value, steps), rs.HighValue-rs.MinGap), rs.HighValue)
+	}
+}
+
+// SetHighActive sets which thumb is active, updating the display
+func (rs *RangeSlider) SetHighActive(high bool) {
+	if rs.HighActive == high {
+		return
+	}
+	updt := rs.UpdateStart()
+	rs.HighActive = high
+	rs.UpdateEnd(updt)
+}
+
+// ThumbPress starts moving the thumb nearest to given position -- emits
+// SliderPressed
+func (rs *RangeSlider) ThumbPress(pos float32) {
+	rs.EmitValue = rs.Min - 1.0 // invalid value
+	updt := rs.UpdateStart()
+	high := math32.Abs(pos-rs.HighPos) < math32.Abs(pos-rs.Pos)
+	if rs.Pos == rs.HighPos { // stacked: go by side of click
+		high = pos > rs.HighPos || (pos == rs.HighPos && rs.HighValue < rs.Max)
+	}
+	rs.HighActive = high
+	rs.SetSliderState(SliderDown)
+	rs.SetThumbPos(high, pos)
+	rs.SliderSig.Emit(rs.This(), int64(SliderPressed), rs.Range())
+	rs.UpdateEnd(updt)
+}
+
+// ThumbRelease is called when the mouse is released -- emits SliderReleased,
+// and the changed value if it was pressed
+func (rs *RangeSlider) ThumbRelease() {
+	wasPressed := (rs.State == SliderDown)
+	updt := rs.UpdateStart()
+	rs.SetSliderState(SliderActive)
+	rs.SliderSig.Emit(rs.This(), int64(SliderReleased), rs.Range())
+	if wasPressed {
+		rs.EmitNewRange()
+	}
+	rs.UpdateEnd(updt)
+}
+
+// ThumbMove is called when the active thumb is dragged along relevant axis
+func (rs *RangeSlider) ThumbMove(start, end float32) {
+	del := end - start
+	if rs.HighActive {
+		rs.SetThumbPos(true, rs.HighDragPos+del)
+	} else {
+		rs.SetThumbPos(false, rs.DragPos+del)
+	}
+	rs.SliderSig.Emit(rs.This(), int64(SliderMoved), rs.Range())
+}
+
+func (rs *RangeSlider) KeyInput(kt *key.ChordEvent) {
+	if KeyEventTrace {
+		fmt.Printf("RangeSlider KeyInput: %v\n", rs.PathUnique())
+	}
+	kf := KeyFun(kt.Chord())
+	switch kf {
+	case KeyFunMoveUp, KeyFunMoveLeft:
+		rs.StepThumb(-rs.Step)
+		kt.SetProcessed()
+	case KeyFunMoveDown, KeyFunMoveRight:
+		rs.StepThumb(rs.Step)
+		kt.SetProcessed()
+	case KeyFunPageUp:
+		rs.StepThumb(-rs.PageStep)
+		kt.SetProcessed()
+	case KeyFunPageDown:
+		rs.StepThumb(rs.PageStep)
+		kt.SetProcessed()
+	case KeyFunHome:
+		rs.SetHighActive(false)
+		rs.SetRangeAction(rs.Min, rs.HighValue)
+		kt.SetProcessed()
+	case KeyFunEnd:
+		rs.SetHighActive(true)
+		rs.SetRangeAction(rs.Value, rs.Max)
+		kt.SetProcessed()
+	case KeyFunEnter, KeyFunSelectMode:
+		rs.SetHighActive(!rs.HighActive)
+		kt.SetProcessed()
+	}
+}
+
+// PointToThumbPos translates a point in global pixel coords into a thumb
+// position along the slider
+func (rs *RangeSlider) PointToThumbPos(pt image.Point) float32 {
+	rp := rs.PointToRelPos(pt)
+	spc := rs.Sty.Layout.Margin.Dots + 0.5*rs.ThSize
+	if rs.Dim == mat32.X {
+		return float32(rp.X) - spc
+	}
+	return float32(rp.Y) - spc
+}
+
+func (rs *RangeSlider) MouseDragEvent() {
+	rs.ConnectEvent(oswin.MouseDragEvent, RegPri, func(recv, send ki.Ki, sig int64, d interface{}) {
+		me := d.(*mouse.DragEvent)
+		rsl := recv.Embed(KiT_RangeSlider).(*RangeSlider)
+		if rsl.IsInactive() {
+			return
+		}
+		me.SetProcessed()
+		rsl.ThumbMove(rsl.PointToThumbPos(me.From), rsl.PointToThumbPos(me.Where))
+	})
+}
+
+func (rs *RangeSlider) MouseEvent() {
+	rs.ConnectEvent(oswin.MouseEvent, RegPri, func(recv, send ki.Ki, sig int64, d interface{}) {
+		me := d.(*mouse.Event)
+		rsl := recv.Embed(KiT_RangeSlider).(*RangeSlider)
+		if rsl.IsInactive() {
+			me.SetProcessed()
+			rsl.SetSelectedState(!rsl.IsSelected())
+			rsl.EmitSelectedSignal()
+			rsl.UpdateSig()
+			return
+		}
+		if me.Button != mouse.Left {
+			return
+		}
+		me.SetProcessed()
+		if me.Action == mouse.Press {
+			rsl.GrabFocus()
+			rsl.ThumbPress(rsl.PointToThumbPos(me.Where))
+		} else {
+			rsl.ThumbRelease()
+		}
+	})
+}
+
+func (rs *RangeSlider) MouseScrollEvent() {
+	rs.ConnectEvent(oswin.MouseScrollEvent, RegPri, func(recv, send ki.Ki, sig int64, d interface{}) {
+		rsl := recv.Embed(KiT_RangeSlider).(*RangeSlider)
+		if rsl.IsInactive() {
+			return
+		}
+		me := d.(*mouse.ScrollEvent)
+		me.SetProcessed()
+		cur := rsl.Pos
+		if rsl.HighActive {
+			cur = rsl.HighPos
+		}
+		if rsl.Dim == mat32.X {
+			rsl.ThumbMove(cur, cur+float32(me.NonZeroDelta(true))) // preferX
+		} else {
+			rsl.ThumbMove(cur, cur-float32(me.NonZeroDelta(false))) // preferY
+		}
+		rsl.EmitNewRange()
+	})
+}
+
+func (rs *RangeSlider) KeyChordEvent() {
+	rs.ConnectEvent(oswin.KeyChordEvent, RegPri, func(recv, send ki.Ki, sig int64, d interface{}) {
+		rsl := recv.Embed(KiT_RangeSlider).(*RangeSlider)
+		if rsl.IsInactive() {
+			return
+		}
+		rsl.KeyInput(d.(*key.ChordEvent))
+	})
+}
+
+func (rs *RangeSlider) RangeSliderEvents() {
+	rs.MouseDragEvent()
+	rs.MouseEvent()
+	rs.MouseFocusEvent()
+	rs.MouseScrollEvent()
+	rs.KeyChordEvent()
+}
+
+// StyleFromProps styles RangeSlider-specific fields from ki.Prop properties
+// doesn't support inherit or default
+func (rs *RangeSlider) StyleFromProps(props ki.Props, vp *Viewport2D) {
+	for key, val := range props {
+		if len(key) == 0 {
+			continue
+		}
+		if key[0] == '#' || key[0] == '.' || key[0] == ':' || key[0] == '_' {
+			continue
+		}
+		switch key {
+		case "high-value":
+			if iv, ok := kit.ToFloat32(val); ok {
+				rs.HighValue = iv
+			}
+		case "min-gap":
+			if iv, ok := kit.ToFloat32(val); ok {
+				rs.MinGap = iv
+			}
+		}
+	}
+}
+
+func (rs *RangeSlider) Init2D() {
+	rs.Init2DSlider()
+}
+
+func (rs *RangeSlider) Style2D() {
+	rs.SetCanFocusIfActive()
+	rs.StyleSlider()
+	rs.StyMu.Lock()
+	rs.StyleFromProps(rs.Props, rs.Viewport)
+	rs.LayState.SetFromStyle(&rs.Sty.Layout) // also does reset
+	rs.StyMu.Unlock()
+}
+
+func (rs *RangeSlider) Size2D(iter int) {
+	rs.InitLayout2D()
+	if rs.ThSize == 0.0 {
+		rs.Defaults()
+	}
+	st := &rs.Sty
+	// get at least thumbsize + margin + border.size, plus any ticks
+	sz := rs.ThSize + 2.0*(st.Layout.Margin.Dots+st.Border.Width.Dots)
+	rs.LayState.Alloc.Size.SetDim(mat32.OtherDim(rs.Dim), sz+rs.TicksSize())
+}
+
+func (rs *RangeSlider) Layout2D(parBBox image.Rectangle, iter int) bool {
+	rs.Layout2DBase(parBBox, true, iter) // init style
+	for i := 0; i < int(SliderStatesN); i++ {
+		rs.StateStyles[i].CopyUnitContext(&rs.Sty.UnContext)
+	}
+	rs.SizeFromAlloc()
+	return rs.Layout2DChildren(iter)
+}
+
+func (rs *RangeSlider) Render2D() {
+	if rs.FullReRenderIfNeeded() {
+		return
+	}
+	if !rs.Off && rs.PushBounds() {
+		rs.This().(Node2D).ConnectEvents2D()
+		rs.Render2DDefaultStyle()
+		rs.Render2DChildren()
+		rs.PopBounds()
+	} else {
+		rs.DisconnectAllEvents(RegPri)
+	}
+}
+
+// Render2DDefaultStyle renders the track, the selected range between the
+// thumbs, the ticks and the two thumbs -- see Slider for the layout
+func (rs *RangeSlider) Render2DDefaultStyle() {
+	rst, pc, st := rs.RenderLock()
+	defer rs.RenderUnlock(rst)
+
+	rs.RenderStdBox(&rs.StateStyles[SliderBox])
+
+	pc.StrokeStyle.SetColor(&st.Border.Color)
+	pc.StrokeStyle.Width = st.Border.Width
+	pc.FillStyle.SetColorSpec(&st.Font.BgColor)
+
+	spc := st.BoxSpace()
+	pos := rs.LayState.Alloc.Pos
+	sz := rs.LayState.Alloc.Size
+	ht := 0.5 * rs.ThSize
+	odim := mat32.OtherDim(rs.Dim)
+	tksz := rs.TicksSize()
+	sz.SetSubDim(odim, tksz)
+
+	bpos := pos
+	bsz := sz
+	bpos.SetAddDim(odim, spc)
+	bsz.SetSubDim(odim, 2.0*spc)
+	bpos.SetAddDim(rs.Dim, spc+ht)
+	bsz.SetSubDim(rs.Dim, 2.0*(spc+ht))
+	rs.RenderBoxImpl(bpos, bsz, st.Border.Radius.Dots)
+	if tksz > 0 {
+		rs.RenderTicks(rst, pc, bpos.Dim(rs.Dim), rs.Size, pos.Dim(odim)+sz.Dim(odim))
+	}
+
+	vpos := bpos
+	vpos.SetAddDim(rs.Dim, rs.Pos)
+	vsz := bsz
+	vsz.SetDim(rs.Dim, rs.HighPos-rs.Pos)
+	pc.StrokeStyle.SetColor(&st.Border.Color)
+	pc.StrokeStyle.Width = st.Border.Width
+	pc.FillStyle.SetColorSpec(&rs.StateStyles[SliderValue].Font.BgColor)
+	rs.RenderBoxImpl(vpos, vsz, st.Border.Radius.Dots)
+
+	ctr := pos.Dim(odim) + 0.5*sz.Dim(odim)
+	for i, tp := range []float32{rs.Pos, rs.HighPos} {
+		tpos := pos
+		tpos.SetDim(rs.Dim, bpos.Dim(rs.Dim)+tp)
+		tpos.SetDim(odim, ctr)
+		pc.FillStyle.SetColorSpec(&st.Font.BgColor)
+		pc.StrokeStyle.Width = st.Border.Width
+		if (i == 1) == rs.HighActive && rs.HasFocus() { // mark the active thumb
+			pc.StrokeStyle.Width.Dots *= 2
+		}
+		pc.DrawCircle(rst, tpos.X, tpos.Y, ht)
+		pc.FillStrokeClear(rst)
+	}
+}
+
+func (rs *RangeSlider) ConnectEvents2D() {
+	rs.RangeSliderEvents()
+}
+
+// AccessInfo2D exposes the low and high values as the value -- the range
+// is that of the active thumb
+func (rs *RangeSlider) AccessInfo2D(ai *AccessInfo) {
+	rs.SliderBase.AccessInfo2D(ai)
+	ai.Value = fmt.Sprintf("%g - %g", rs.Value, rs.HighValue)
+	if rs.HighActive {
+		ai.SetRange(rs.HighValue, rs.Min, rs.Max, rs.Step)
+	}
+}
+
+func (rs *RangeSlider) FocusChanged2D(change FocusChanges) {
+	switch change {
+	case FocusLost:
+		rs.SetSliderState(SliderActive) // lose any hover state but whatever..
+		rs.UpdateSig()
+	case FocusGot:
+		rs.ScrollToMe()
+		rs.SetSliderState(SliderFocus)
+		rs.EmitFocusedSignal()
+		rs.UpdateSig()
+	case FocusInactive: // don't care..
+	case FocusActive:
+	}
+}
@@ -7,6 +7,7 @@ package gi
 import (
 	"fmt"
 	"image"
+	"math"
 
 	"github.com/chewxy/math32"
 	"github.com/goki/gi/oswin"
@@ -56,6 +57,10 @@ type SliderBase struct {
 	Tracking    bool                 `xml:"tracking" desc:"if true, will send continuous updates of value changes as user moves the slider -- otherwise only at the end -- see TrackThr for a threshold on amount of change"`
 	TrackThr    float32              `xml:"track-thr" desc:"threshold for amount of change in scroll value before emitting a signal in Tracking mode"`
 	Snap        bool                 `xml:"snap" desc:"snap the values to Step size increments"`
+	Log         bool                 `xml:"log" desc:"use a logarithmic scale from Min to Max, which must both be > 0 -- Step and PageStep are then in log10 units (decades), and TickStep in decades between ticks -- not used with ValThumb"`
+	TickStep    float32              `xml:"tick-step" desc:"if > 0, tick marks are drawn along the slider at each multiple of this step within Min..Max (in decades for Log scale)"`
+	TickLabels  bool                 `xml:"tick-labels" desc:"draw the values as labels next to the tick marks"`
+	TickFormat  string               `xml:"tick-format" desc:"format string for tick labels -- blank defaults to %g"`
 	Off         bool                 `desc:"can turn off e.g., scrollbar rendering with this flag -- just prevents rendering"`
 	State       SliderStates         `json:"-" xml:"-" desc:"state of slider"`
 	StateStyles [SliderStatesN]Style `copy:"-" json:"-" xml:"-" desc:"styles for different states of the slider, one for each state -- everything inherits from the base Style which is styled first according to the user-set styles, and then subsequent style settings can override that"`
@@ -89,6 +94,10 @@ func (sb *SliderBase) CopyFieldsFrom(frm interface{}) {
 	sb.Tracking = fr.Tracking
 	sb.TrackThr = fr.TrackThr
 	sb.Snap = fr.Snap
+	sb.Log = fr.Log
+	sb.TickStep = fr.TickStep
+	sb.TickLabels = fr.TickLabels
+	sb.TickFormat = fr.TickFormat
 	sb.Off = fr.Off
 }
 
@@ -271,7 +280,7 @@ func (sb *SliderBase) SetSliderPos(pos float32) {
 		}
 	}
 	sb.Pos = mat32.Max(0, sb.Pos)
-	sb.Value = mat32.Truncate(sb.Min+(sb.Max-sb.Min)*(sb.Pos/effSz), sb.Prec)
+	sb.Value = mat32.Truncate(sb.FracToValue(sb.Pos/effSz), sb.Prec)
 	sb.Value = mat32.Clamp(sb.Value, sb.Min, sb.Max)
 	if sb.ValThumb {
 		sb.Value = mat32.Min(sb.Value, sb.Max-sb.ThumbVal)
@@ -307,7 +316,46 @@ func (sb *SliderBase) UpdatePosFromValue() {
 			effSz -= 0.5 // rounding errors
 		}
 	}
-	sb.Pos = effSz * (sb.Value - sb.Min) / (sb.Max - sb.Min)
+	sb.Pos = effSz * sb.ValueToFrac(sb.Value)
+}
+
+// IsLog returns true if the Log scale is in effect -- it requires a positive
+// Min < Max and does not apply to ValThumb sliders
+func (sb *SliderBase) IsLog() bool {
+	return sb.Log && !sb.ValThumb && sb.Min > 0 && sb.Max > sb.Min
+}
+
+// ValueToFrac returns the proportion (0..1) along the slider for given value,
+// using the Log scale if in effect
+func (sb *SliderBase) ValueToFrac(val float32) float32 {
+	if sb.Max == sb.Min {
+		return 0
+	}
+	if sb.IsLog() {
+		if val <= sb.Min {
+			return 0
+		}
+		return math32.Log(val/sb.Min) / math32.Log(sb.Max/sb.Min)
+	}
+	return (val - sb.Min) / (sb.Max - sb.Min)
+}
+
+// FracToValue returns the value at given proportion (0..1) along the slider,
+// using the Log scale if in effect
+func (sb *SliderBase) FracToValue(frac float32) float32 {
+	if sb.IsLog() {
+		return sb.Min * mat32.Pow(sb.Max/sb.Min, frac)
+	}
+	return sb.Min + (sb.Max-sb.Min)*frac
+}
+
+// StepValue returns given value moved by given number of steps (e.g., Step
+// or PageStep, can be negative) -- steps are multiplicative decades for Log scale
+func (sb *SliderBase) StepValue(val, steps float32) float32 {
+	if sb.IsLog() {
+		return mat32.Truncate(val*mat32.Pow(10, steps), sb.Prec)
+	}
+	return val + steps
 }
 
 // SetValue sets the value and updates the slider position, but does not
@@ -337,6 +385,32 @@ func (sb *SliderBase) SetValueAction(val float32) {
 	sb.EmitNewValue()
 }
 
+func (sb *SliderBase) AccessInfo2D(ai *AccessInfo) {
+	sb.WidgetBase.AccessInfo2D(ai)
+	ai.Role = AccessSlider
+	ai.Value = fmt.Sprintf("%g", sb.Value)
+	ai.SetRange(sb.Value, sb.Min, sb.Max, sb.Step)
+	ai.SetState(sb.Dim == mat32.X, AccessHorizontal)
+	ai.SetState(sb.Dim == mat32.Y, AccessVertical)
+	ai.Actions = []string{AccessActIncrement, AccessActDecrement}
+}
+
+// AccessAction2D increments or decrements the value by the Step
+func (sb *SliderBase) AccessAction2D(act string) bool {
+	if sb.IsInactive() {
+		return false
+	}
+	switch act {
+	case AccessActIncrement:
+		sb.SetValueAction(sb.Value + sb.Step)
+	case AccessActDecrement:
+		sb.SetValueAction(sb.Value - sb.Step)
+	default:
+		return false
+	}
+	return true
+}
+
 // SetThumbValue sets the thumb value to given value and updates the thumb size
 // -- for scrollbar-style sliders where the thumb size represents visible range
 func (sb *SliderBase) SetThumbValue(val float32) {
@@ -364,25 +438,25 @@ func (sb *SliderBase) KeyInput(kt *key.ChordEvent) {
 	kf := KeyFun(kt.Chord())
 	switch kf {
 	case KeyFunMoveUp:
-		sb.SetValueAction(sb.Value - sb.Step)
+		sb.SetValueAction(sb.StepValue(sb.Value, -sb.Step))
 		kt.SetProcessed()
 	case KeyFunMoveLeft:
-		sb.SetValueAction(sb.Value - sb.Step)
+		sb.SetValueAction(sb.StepValue(sb.Value, -sb.Step))
 		kt.SetProcessed()
 	case KeyFunMoveDown:
-		sb.SetValueAction(sb.Value + sb.Step)
+		sb.SetValueAction(sb.StepValue(sb.Value, sb.Step))
 		kt.SetProcessed()
 	case KeyFunMoveRight:
-		sb.SetValueAction(sb.Value + sb.Step)
+		sb.SetValueAction(sb.StepValue(sb.Value, sb.Step))
 		kt.SetProcessed()
 	case KeyFunPageUp:
-		sb.SetValueAction(sb.Value - sb.PageStep)
+		sb.SetValueAction(sb.StepValue(sb.Value, -sb.PageStep))
 		kt.SetProcessed()
 	// case KeyFunPageLeft:
 	// 	sb.SetValueAction(sb.Value - sb.PageStep)
 	// 	kt.SetProcessed()
 	case KeyFunPageDown:
-		sb.SetValueAction(sb.Value + sb.PageStep)
+		sb.SetValueAction(sb.StepValue(sb.Value, sb.PageStep))
 		kt.SetProcessed()
 	// case KeyFunPageRight:
 	// 	sb.SetValueAction(sb.Value + sb.PageStep)
@@ -601,6 +675,20 @@ func (sr *SliderBase) StyleFromProps(props ki.Props, vp *Viewport2D) {
 			if bv, ok := kit.ToBool(val); ok {
 				sr.Snap = bv
 			}
+		case "log":
+			if bv, ok := kit.ToBool(val); ok {
+				sr.Log = bv
+			}
+		case "tick-step":
+			if iv, ok := kit.ToFloat32(val); ok {
+				sr.TickStep = iv
+			}
+		case "tick-labels":
+			if bv, ok := kit.ToBool(val); ok {
+				sr.TickLabels = bv
+			}
+		case "tick-format":
+			sr.TickFormat = kit.ToString(val)
 		}
 	}
 }
@@ -632,6 +720,117 @@ func (sr *SliderBase) StyleSlider() {
 	sr.ThSize = sr.ThumbSize.Dots
 }
 
+// TickValues returns the values at which tick marks are drawn, according
+// to TickStep -- nil if no ticks
+func (sb *SliderBase) TickValues() []float32 {
+	if sb.TickStep <= 0 || sb.Max <= sb.Min {
+		return nil
+	}
+	if sb.IsLog() {
+		lmin := math32.Log10(sb.Min)
+		lmax := math32.Log10(sb.Max)
+		return sb.tickValues(lmin, lmax+1.0e-4, func(lv float32) float32 { return mat32.Pow(10, lv) })
+	}
+	return sb.tickValues(sb.Min, sb.Max+1.0e-4*sb.TickStep, func(v float32) float32 { return v })
+}
+
+// SliderMaxTicks is the maximum number of ticks on a slider -- above it,
+// no ticks are shown as they are not useful
+var SliderMaxTicks = 1000
+
+// tickValues returns the values of the ticks at multiples of TickStep from
+// min to max, mapped through given function, or nil if more than
+// SliderMaxTicks -- each tick is computed from its index, as float32 steps
+// can vanish against large values
+func (sb *SliderBase) tickValues(min, max float32, fun func(v float32) float32) []float32 {
+	first := math.Ceil(float64(min) / float64(sb.TickStep))
+	last := math.Floor(float64(max) / float64(sb.TickStep))
+	if math.IsNaN(first) || math.IsNaN(last) || last < first {
+		return nil
+	}
+	if last-first >= float64(SliderMaxTicks) {
+		return nil
+	}
+	n := int(last-first) + 1
+	tks := make([]float32, n)
+	for i := 0; i < n; i++ {
+		v := float32((first + float64(i)) * float64(sb.TickStep))
+		tks[i] = mat32.Truncate(fun(v), sb.Prec)
+	}
+	return tks
+}
+
+// TickLabel returns the label string for given tick value
+func (sb *SliderBase) TickLabel(val float32) string {
+	if sb.TickFormat == "" {
+		return fmt.Sprintf("%g", val)
+	}
+	return fmt.Sprintf(sb.TickFormat, val)
+}
+
+// TicksSize returns the extra size needed in the dimension orthogonal to
+// the slider for tick marks and their labels
+func (sb *SliderBase) TicksSize() float32 {
+	if sb.TickStep <= 0 {
+		return 0
+	}
+	st := &sb.Sty
+	if st.Font.Face == nil {
+		st.Font.OpenFont(&st.UnContext)
+	}
+	fht := st.Font.Face.Metrics.Height
+	sz := 0.5 * fht // tick length
+	if !sb.TickLabels {
+		return sz
+	}
+	if sb.Dim == mat32.X {
+		return sz + fht
+	}
+	mxw := float32(0)
+	var tr TextRender
+	for _, tv := range sb.TickValues() {
+		tr.SetString(sb.TickLabel(tv), &st.Font, &st.UnContext, &st.Text, true, 0, 0)
+		mxw = mat32.Max(mxw, tr.Size.X)
+	}
+	return sz + mxw + 2
+}
+
+// RenderTicks draws the tick marks and labels, for a track starting at given
+// position along the slider dimension, of given length, with the ticks
+// starting at base in the other dimension -- must be called within RenderLock
+func (sb *SliderBase) RenderTicks(rs *RenderState, pc *Paint, start, length, base float32) {
+	tks := sb.TickValues()
+	if len(tks) == 0 {
+		return
+	}
+	st := &sb.Sty
+	fht := st.Font.Face.Metrics.Height
+	tlen := 0.5 * fht
+	pc.StrokeStyle.SetColor(&st.Font.Color)
+	pc.StrokeStyle.Width.Dots = 1
+	var tr TextRender
+	for _, tv := range tks {
+		p := start + length*sb.ValueToFrac(tv)
+		var lpos mat32.Vec2
+		if sb.Dim == mat32.X {
+			pc.DrawLine(rs, p, base, p, base+tlen)
+		} else {
+			pc.DrawLine(rs, base, p, base+tlen, p)
+		}
+		pc.Stroke(rs)
+		if !sb.TickLabels {
+			continue
+		}
+		tr.SetString(sb.TickLabel(tv), &st.Font, &st.UnContext, &st.Text, true, 0, 0)
+		if sb.Dim == mat32.X {
+			lpos = mat32.Vec2{p - 0.5*tr.Size.X, base + tlen}
+		} else {
+			lpos = mat32.Vec2{base + tlen + 2, p - 0.5*tr.Size.Y}
+		}
+		tr.RenderTopPos(rs, lpos)
+	}
+}
+
 ////////////////////////////////////////////////////////////////////////////////////////
 //  Slider
 
@@ -727,9 +926,9 @@ func (sr *Slider) Size2D(iter int) {
 		sr.Defaults()
 	}
 	st := &sr.Sty
-	// get at least thumbsize + margin + border.size
+	// get at least thumbsize + margin + border.size, plus any ticks
 	sz := sr.ThSize + 2.0*(st.Layout.Margin.Dots+st.Border.Width.Dots)
-	sr.LayState.Alloc.Size.SetDim(mat32.OtherDim(sr.Dim), sz)
+	sr.LayState.Alloc.Size.SetDim(mat32.OtherDim(sr.Dim), sz+sr.TicksSize())
 }
 
 func (sr *Slider) Layout2D(parBBox image.Rectangle, iter int) bool {
@@ -792,11 +991,16 @@ func (sr *Slider) Render2DDefaultStyle() {
 	ht := 0.5 * sr.ThSize
 
 	odim := mat32.OtherDim(sr.Dim)
+	tksz := sr.TicksSize()
+	sz.SetSubDim(odim, tksz) // ticks go after the track
 	bpos.SetAddDim(odim, spc)
-	bsz.SetSubDim(odim, 2.0*spc)
+	bsz.SetSubDim(odim, 2.0*spc+tksz)
 	bpos.SetAddDim(sr.Dim, spc+ht)
 	bsz.SetSubDim(sr.Dim, 2.0*(spc+ht))
 	sr.RenderBoxImpl(bpos, bsz, st.Border.Radius.Dots)
+	if tksz > 0 {
+		sr.RenderTicks(rs, pc, bpos.Dim(sr.Dim), sr.Size, pos.Dim(odim)+sz.Dim(odim))
+	}
 
 	bsz.SetDim(sr.Dim, sr.Pos)
 	pc.FillStyle.SetColorSpec(&sr.StateStyles[SliderValue].Font.BgColor)
@@ -973,6 +1177,11 @@ func (sb *ScrollBar) ConnectEvents2D() {
 	sb.SliderEvents()
 }
 
+func (sb *ScrollBar) AccessInfo2D(ai *AccessInfo) {
+	sb.SliderBase.AccessInfo2D(ai)
+	ai.Role = AccessScrollBar
+}
+
 func (sb *ScrollBar) FocusChanged2D(change FocusChanges) {
 	switch change {
 	case FocusLost:
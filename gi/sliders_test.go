@@ -0,0 +1,42 @@
+// Copyright (c) 2018, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import "testing"
+
+func TestSliderTickValues(t *testing.T) {
+	tests := []struct {
+		name          string
+		min, max, stp float32
+		log           bool
+		want          []float32
+	}{
+		{"linear", 0, 1, 0.25, false, []float32{0, 0.25, 0.5, 0.75, 1}},
+		{"offset", 0.1, 1, 0.25, false, []float32{0.25, 0.5, 0.75, 1}},
+		{"log", 1, 1000, 1, true, []float32{1, 10, 100, 1000}},
+		{"no-step", 0, 1, 0, false, nil},
+		{"too-many", 0, 1e6, 1, false, nil},
+		{"log-too-many", 1, 1e30, 0.01, true, nil},
+	}
+	for _, tt := range tests {
+		sb := &SliderBase{Min: tt.min, Max: tt.max, TickStep: tt.stp, Log: tt.log, Prec: 9}
+		tks := sb.TickValues()
+		if len(tks) != len(tt.want) {
+			t.Errorf("%v: got %v ticks, want %v: %v", tt.name, len(tks), len(tt.want), tks)
+			continue
+		}
+		for i, v := range tks {
+			if v != tt.want[i] {
+				t.Errorf("%v: tick %v = %v, want %v", tt.name, i, v, tt.want[i])
+			}
+		}
+	}
+
+	// adding a step of 1 to 1e8 in float32 is a no-op: ticks must still end
+	sb := &SliderBase{Min: 1e8, Max: 1e8 + 16, TickStep: 1, Prec: 9}
+	if tks := sb.TickValues(); len(tks) != 17 {
+		t.Errorf("large: got %v ticks, want 17", len(tks))
+	}
+}
@@ -5,10 +5,11 @@
 package gi
 
 import (
-	"fmt"
 	"image"
 	"log"
+	"math"
 	"strconv"
+	"strings"
 
 	"github.com/goki/gi/oswin"
 	"github.com/goki/gi/oswin/mouse"
@@ -33,7 +34,7 @@ type SpinBox struct {
 	Step       float32   `xml:"step" desc:"smallest step size to increment"`
 	PageStep   float32   `xml:"pagestep" desc:"larger PageUp / Dn step size"`
 	Prec       int       `desc:"specifies the precision of decimal places (total, not after the decimal point) to use in representing the number -- this helps to truncate small weird floating point values in the nether regions"`
-	Format     string    `xml:"format" desc:"prop = format -- format string for printing the value -- blank defaults to %g.  If decimal based (ends in d, b, c, o, O, q, x, X, or U) then value is converted to decimal prior to printing"`
+	Format     string    `xml:"format" desc:"prop = format -- format string for printing the value -- blank defaults to %g.  If decimal based (ends in d, b, c, o, O, q, x, X, or U) then value is converted to decimal prior to printing.  Numbers are shown and entered in the ActiveLocaleFormat, and a format of currency formats the value as a currency amount"`
 	UpIcon     IconName  `view:"show-name" desc:"icon to use for up button -- defaults to wedge-up"`
 	DownIcon   IconName  `view:"show-name" desc:"icon to use for down button -- defaults to wedge-down"`
 	SpinBoxSig ki.Signal `copy:"-" json:"-" xml:"-" view:"-" desc:"signal for spin box -- has no signal types, just emitted when the value changes"`
@@ -233,6 +234,7 @@ func (sb *SpinBox) ConfigParts() {
 		}
 		sb.StylePart(Node2D(tf))
 		tf.Txt = sb.ValToString(sb.Value)
+		tf.Validator = sb
 		if !sb.IsInactive() {
 			tf.TextFieldSig.ConnectOnly(sb.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
 				if sig == int64(TextFieldDone) || sig == int64(TextFieldDeFocused) {
@@ -262,28 +264,37 @@ func (sb *SpinBox) FormatIsInt() bool {
 	return false
 }
 
-// ValToString converts the value to the string representation thereof
+// ValToString converts the value to the string representation thereof,
+// in the ActiveLocaleFormat
 func (sb *SpinBox) ValToString(val float32) string {
-	if sb.Format == "" {
-		return fmt.Sprintf("%g", val)
-	}
-	if sb.FormatIsInt() {
-		return fmt.Sprintf(sb.Format, int64(val))
+	lf := &ActiveLocaleFormat
+	switch {
+	case sb.Format == "currency":
+		return lf.FormatCurrency(float64(val))
+	case sb.FormatIsInt():
+		return lf.FormatValue(sb.Format, int64(val))
 	}
-	return fmt.Sprintf(sb.Format, val)
+	return lf.FormatFloat(float64(val), sb.Format)
 }
 
-// StringToVal converts the string field back to float value
+// StringToVal converts the string field back to float value, parsing it in
+// the ActiveLocaleFormat
 func (sb *SpinBox) StringToVal(str string) (float32, error) {
 	var fval float32
 	var err error
-	if sb.FormatIsInt() {
+	lf := &ActiveLocaleFormat
+	switch {
+	case sb.Format == "currency":
+		var fv float64
+		fv, err = lf.ParseCurrency(str)
+		fval = float32(fv)
+	case sb.FormatIsInt():
 		var iv int64
-		iv, err = strconv.ParseInt(str, 0, 64)
+		iv, err = lf.ParseInt(str)
 		fval = float32(iv)
-	} else {
+	default:
 		var fv float64
-		fv, err = strconv.ParseFloat(str, 32)
+		fv, err = lf.ParseFloat(str)
 		fval = float32(fv)
 	}
 	if err != nil {
@@ -292,6 +303,20 @@ func (sb *SpinBox) StringToVal(str string) (float32, error) {
 	return fval, err
 }
 
+// ValidateText checks that the text is a number within the Min / Max range,
+// satisfying the TextValidator interface for the text-field part
+func (sb *SpinBox) ValidateText(txt string) error {
+	f64 := func(v float32) float64 { // keep float32 precision for messages
+		fv, _ := strconv.ParseFloat(strconv.FormatFloat(float64(v), 'g', -1, 32), 64)
+		return fv
+	}
+	if sb.Format == "currency" {
+		txt = strings.Replace(txt, ActiveLocaleFormat.CurrencySymbol, "", 1)
+	}
+	rv := RangeValidator{Min: f64(sb.Min), Max: f64(sb.Max), HasMin: sb.HasMin, HasMax: sb.HasMax, Int: sb.FormatIsInt(), Required: true}
+	return rv.ValidateText(txt)
+}
+
 func (sb *SpinBox) ConfigPartsIfNeeded() {
 	if !sb.Parts.HasChildren() {
 		sb.ConfigParts()
@@ -446,6 +471,38 @@ func (sb *SpinBox) ConnectEvents2D() {
 	sb.SpinBoxEvents()
 }
 
+func (sb *SpinBox) AccessInfo2D(ai *AccessInfo) {
+	sb.WidgetBase.AccessInfo2D(ai)
+	ai.Role = AccessSpinBox
+	ai.Value = sb.ValToString(sb.Value)
+	min, max := float32(-math.MaxFloat32), float32(math.MaxFloat32)
+	if sb.HasMin {
+		min = sb.Min
+	}
+	if sb.HasMax {
+		max = sb.Max
+	}
+	ai.SetRange(sb.Value, min, max, sb.Step)
+	ai.SetState(!sb.IsInactive(), AccessEditable)
+	ai.Actions = []string{AccessActIncrement, AccessActDecrement}
+}
+
+// AccessAction2D increments or decrements the value by one step
+func (sb *SpinBox) AccessAction2D(act string) bool {
+	if sb.IsInactive() {
+		return false
+	}
+	switch act {
+	case AccessActIncrement:
+		sb.IncrValue(1)
+	case AccessActDecrement:
+		sb.IncrValue(-1)
+	default:
+		return false
+	}
+	return true
+}
+
 func (sb *SpinBox) HasFocus2D() bool {
 	if sb.IsInactive() {
 		return false
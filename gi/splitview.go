@@ -427,6 +427,13 @@ func (sv *SplitView) ConnectEvents2D() {
 	sv.SplitViewEvents()
 }
 
+func (sv *SplitView) AccessInfo2D(ai *AccessInfo) {
+	sv.PartsWidgetBase.AccessInfo2D(ai)
+	ai.Role = AccessSplitPane
+	ai.SetState(sv.Dim == mat32.X, AccessHorizontal)
+	ai.SetState(sv.Dim == mat32.Y, AccessVertical)
+}
+
 func (sv *SplitView) HasFocus2D() bool {
 	return sv.ContainsFocus() // anyone within us gives us focus..
 }
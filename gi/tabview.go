@@ -6,11 +6,18 @@ package gi
 
 import (
 	"fmt"
+	"image"
+	"image/draw"
 	"log"
 	"reflect"
+	"strconv"
 	"sync"
 
+	"github.com/goki/gi/oswin"
+	"github.com/goki/gi/oswin/dnd"
+	"github.com/goki/gi/oswin/mimedata"
 	"github.com/goki/gi/units"
+	"github.com/goki/ki/ints"
 	"github.com/goki/ki/ki"
 	"github.com/goki/ki/kit"
 )
@@ -21,13 +28,18 @@ import (
 // HorizFlow Layout for the tabs (which can flow across multiple rows as
 // needed) and a Stacked Frame that actually contains all the children, and
 // provides scrollbars as needed to any content within.  Typically should have
-// max stretch and a set preferred size, so it expands.
+// max stretch and a set preferred size, so it expands.  Tabs can be dragged
+// to reorder them, or onto the tabs of another TabView in the same window
+// to move them there, and when they do not all fit, a menu listing all of
+// them is shown at the end.
 type TabView struct {
 	Layout
 	MaxChars     int          `desc:"maximum number of characters to include in tab label -- elides labels that are longer than that"`
 	TabViewSig   ki.Signal    `copy:"-" json:"-" xml:"-" desc:"signal for tab widget -- see TabViewSignals for the types"`
 	NewTabButton bool         `desc:"show a new tab button at right of list of tabs"`
 	NoDeleteTabs bool         `desc:"if true, tabs are not user-deleteable"`
+	NoDragTabs   bool         `desc:"if true, tabs cannot be dragged to reorder them, or to move them to or from other tab views"`
+	TabsOverflow bool         `json:"-" xml:"-" inactive:"+" desc:"true if the tabs do not all fit, in which case a menu listing all the tabs is shown at the end"`
 	NewTabType   reflect.Type `desc:"type of widget to create in a new tab via new tab button -- Frame by default"`
 	Mu           sync.Mutex   `copy:"-" json:"-" xml:"-" view:"-" desc:"mutex protecting updates to tabs -- tabs can be driven programmatically and via user input so need extra protection"`
 }
@@ -44,6 +56,8 @@ func (tv *TabView) CopyFieldsFrom(frm interface{}) {
 	tv.Layout.CopyFieldsFrom(&fr.Layout)
 	tv.MaxChars = fr.MaxChars
 	tv.NewTabButton = fr.NewTabButton
+	tv.NoDeleteTabs = fr.NoDeleteTabs
+	tv.NoDragTabs = fr.NoDragTabs
 	tv.NewTabType = fr.NewTabType
 }
 
@@ -128,6 +142,7 @@ func (tv *TabView) InsertTab(widg Node2D, label string, idx int) {
 	tv.SetFullReRender()
 	fr.InsertChild(widg, idx)
 	tv.InsertTabOnlyAt(widg, label, idx)
+	tv.RenumberTabs()
 	tv.Mu.Unlock()
 	tv.UpdateEnd(updt)
 }
@@ -351,34 +366,263 @@ func (tv *TabView) DeleteTabIndexAction(idx int) {
 	}
 }
 
-// ConfigNewTabButton configures the new tab + button at end of list of tabs
+// ConfigNewTabButton configures the new tab + button, and the menu of all
+// tabs shown when they overflow, at the end of the list of tabs
 func (tv *TabView) ConfigNewTabButton() bool {
 	sz := tv.NTabs()
 	tb := tv.Tabs()
 	ntb := len(tb.Kids)
+	var extras []string
 	if tv.NewTabButton {
-		if ntb == sz+1 {
-			return false
+		extras = append(extras, "new-tab")
+	}
+	if tv.TabsOverflow {
+		extras = append(extras, "tabs-menu")
+	}
+	if ntb == sz+len(extras) {
+		same := true
+		for i, nm := range extras {
+			if tb.Kids[sz+i].Name() != nm {
+				same = false
+				break
+			}
 		}
-		if tv.NewTabType == nil {
-			tv.NewTabType = KiT_Frame
+		if same {
+			return false
 		}
-		tab := tb.InsertNewChild(KiT_Action, ntb, "new-tab").(*Action)
+	}
+	for i := ntb - 1; i >= sz; i-- {
+		tb.DeleteChildAtIndex(i, ki.DestroyKids) // always destroy -- we manage
+	}
+	for _, nm := range extras {
+		tab := AddNewAction(tb, nm)
 		tab.Data = -1
-		tab.SetIcon("plus")
-		tab.ActionSig.ConnectOnly(tv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+		switch nm {
+		case "new-tab":
+			if tv.NewTabType == nil {
+				tv.NewTabType = KiT_Frame
+			}
+			tab.SetIcon("plus")
+			tab.ActionSig.ConnectOnly(tv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+				tvv := recv.Embed(KiT_TabView).(*TabView)
+				tvv.SetFullReRender()
+				tvv.AddNewTabAction(tvv.NewTabType, "New Tab")
+			})
+		case "tabs-menu":
+			tab.SetIcon("wedge-down")
+			tab.Tooltip = "show all tabs"
+			tab.MakeMenuFunc = func(obj ki.Ki, m *Menu) {
+				tv.MakeTabsMenu(m)
+			}
+		}
+	}
+	return true
+}
+
+// MakeTabsMenu makes a menu listing all the tabs, for selecting among them
+// when they do not all fit
+func (tv *TabView) MakeTabsMenu(m *Menu) {
+	sz := tv.NTabs()
+	*m = make(Menu, 0, sz)
+	_, cur, _ := tv.CurTab()
+	for i := 0; i < sz; i++ {
+		_, tab, ok := tv.TabAtIndex(i)
+		if !ok {
+			continue
+		}
+		opts := ActOpts{Label: tab.Text, Data: i}
+		if i == cur {
+			opts.Icon = "checkmark"
+		}
+		m.AddAction(opts, tv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
 			tvv := recv.Embed(KiT_TabView).(*TabView)
-			tvv.SetFullReRender()
-			tvv.AddNewTabAction(tvv.NewTabType, "New Tab")
+			tvv.SelectTabIndexAction(data.(int))
 		})
-		return true
-	} else {
-		if ntb == sz {
-			return false
+	}
+}
+
+// CheckTabsOverflow checks whether the tabs all fit within the space
+// allocated to them, and if that has changed, updates TabsOverflow and the
+// menu of all tabs -- called after layout
+func (tv *TabView) CheckTabsOverflow() {
+	tbs := tv.Tabs()
+	sz := tv.NTabs()
+	avail := tbs.LayState.Alloc.Size.X
+	if avail <= 0 || (sz == 0 && !tv.TabsOverflow) {
+		return
+	}
+	need := float32(0)
+	for i, k := range tbs.Kids {
+		if i >= sz && k.Name() == "tabs-menu" {
+			continue
 		}
-		tb.DeleteChildAtIndex(ntb-1, ki.DestroyKids) // always destroy -- we manage
+		if wb := k.(Node2D).AsWidget(); wb != nil {
+			need += wb.LayState.Alloc.Size.X
+			if i > 0 {
+				need += tbs.Spacing.Dots
+			}
+		}
+	}
+	over := need > avail
+	if over == tv.TabsOverflow {
+		return
+	}
+	tv.TabsOverflow = over
+	win := tv.ParentWindow()
+	if win == nil {
+		return
+	}
+	win.RunOnWin(func() { // not during layout
+		updt := tv.UpdateStart()
+		tv.SetFullReRender()
+		tv.ConfigNewTabButton()
+		tv.UpdateEnd(updt)
+	})
+}
+
+// NPinned returns the number of pinned tabs, which are always first
+func (tv *TabView) NPinned() int {
+	sz := tv.NTabs()
+	tbs := tv.Tabs()
+	np := 0
+	for i := 0; i < sz; i++ {
+		if tbs.Child(i).Embed(KiT_TabButton).(*TabButton).Pinned {
+			np++
+		}
+	}
+	return np
+}
+
+// PinTab pins or unpins the tab at given index -- pinned tabs are kept
+// before all the others, show a pin icon, and cannot be closed by the user
+func (tv *TabView) PinTab(idx int, pin bool) {
+	_, tab, ok := tv.TabAtIndex(idx)
+	if !ok || tab.Pinned == pin {
+		return
+	}
+	np := tv.NPinned()
+	updt := tv.UpdateStart()
+	tv.SetFullReRender()
+	tab.Pinned = pin
+	tab.NoDelete = pin || tv.NoDeleteTabs
+	if pin {
+		tab.SetIcon("pin")
+		tv.MoveTab(idx, np)
+	} else {
+		tab.SetIcon("")
+		tv.MoveTab(idx, np-1)
+	}
+	tv.UpdateEnd(updt)
+}
+
+// MoveTab moves the tab at index from to index to, keeping the same tab
+// selected -- the new index is limited so that pinned tabs remain before
+// all the others.  Emits TabMoved signal.  Returns false if from is invalid.
+func (tv *TabView) MoveTab(from, to int) bool {
+	_, tab, ok := tv.TabAtIndex(from)
+	if !ok {
+		return false
+	}
+	np := tv.NPinned()
+	if tab.Pinned {
+		to = ints.MinInt(to, np-1)
+	} else {
+		to = ints.MaxInt(to, np)
+	}
+	to = ints.MaxInt(0, ints.MinInt(to, tv.NTabs()-1))
+	if to == from {
 		return true
 	}
+	tv.Mu.Lock()
+	fr := tv.Frame()
+	tbs := tv.Tabs()
+	updt := tv.UpdateStart()
+	tv.SetFullReRender()
+	var cur ki.Ki
+	if fr.StackTop >= 0 {
+		cur = fr.Child(fr.StackTop)
+	}
+	fr.Kids.Move(from, to)
+	tbs.Kids.Move(from, to)
+	if cur != nil {
+		fr.StackTop, _ = fr.Kids.IndexOf(cur, 0)
+	}
+	tv.RenumberTabs()
+	tv.Mu.Unlock()
+	tv.UpdateEnd(updt)
+	tv.TabViewSig.Emit(tv.This(), int64(TabMoved), to)
+	return true
+}
+
+// MoveTabTo moves the tab at given index to given index in another tab
+// view, and selects it there -- pinned tabs cannot be moved to another tab
+// view.  Emits TabDeleted from this tab view and TabAdded from the other.
+func (tv *TabView) MoveTabTo(from int, dst *TabView, to int) bool {
+	widg, tab, ok := tv.TabAtIndex(from)
+	if !ok || tab.Pinned || dst == tv {
+		return false
+	}
+	label := tab.Nm
+	mod := tab.Modified
+	widg.AsNode2D().DisconnectAllEvents(AllPris) // reconnected in new window / viewport
+	tv.DeleteTabIndex(from, false)
+	widg.ClearFlag(int(ki.NodeDeleted))
+	tv.TabViewSig.Emit(tv.This(), int64(TabDeleted), label)
+	to = ints.MaxInt(to, dst.NPinned())
+	to = ints.MinInt(to, dst.NTabs())
+	dst.InsertTab(widg, label, to)
+	if mod {
+		dst.SetTabModified(to, true)
+	}
+	dst.SelectTabIndex(to)
+	dst.TabViewSig.Emit(dst.This(), int64(TabAdded), to)
+	return true
+}
+
+// TabModifiedMark is shown before the label of tabs marked as modified
+var TabModifiedMark = "● "
+
+// TabModifier is an optional interface for widgets shown in tabs, which
+// have changes that have not been saved -- see UpdateModified
+type TabModifier interface {
+	// TabModified returns true if the widget has unsaved changes
+	TabModified() bool
+}
+
+// SetTabModified sets whether the tab at given index is marked as having
+// unsaved changes
+func (tv *TabView) SetTabModified(idx int, mod bool) {
+	_, tab, ok := tv.TabAtIndex(idx)
+	if !ok || tab.Modified == mod {
+		return
+	}
+	tab.Modified = mod
+	lbl := tab.Nm
+	if mod {
+		lbl = TabModifiedMark + lbl
+	}
+	tab.SetText(lbl)
+}
+
+// IsTabModified returns true if the tab at given index is marked as modified
+func (tv *TabView) IsTabModified(idx int) bool {
+	_, tab, ok := tv.TabAtIndex(idx)
+	return ok && tab.Modified
+}
+
+// UpdateModified updates the modified mark on all the tabs whose widget
+// implements the TabModifier interface -- call this when their state changes
+func (tv *TabView) UpdateModified() {
+	sz := tv.NTabs()
+	for i := 0; i < sz; i++ {
+		widg, _, ok := tv.TabAtIndex(i)
+		if !ok {
+			continue
+		}
+		if tm, ok := widg.(TabModifier); ok {
+			tv.SetTabModified(i, tm.TabModified())
+		}
+	}
 }
 
 // TabViewSignals are signals that the TabView can send
@@ -394,6 +638,10 @@ const (
 	// TabDeleted indicates tab was deleted -- data is the tab name
 	TabDeleted
 
+	// TabMoved indicates tab was moved to a new position -- data is the new
+	// tab index
+	TabMoved
+
 	TabViewSignalsN
 )
 
@@ -479,6 +727,12 @@ func (tv *TabView) Style2D() {
 	tv.Layout.Style2D()
 }
 
+func (tv *TabView) Layout2D(parBBox image.Rectangle, iter int) bool {
+	redo := tv.Layout.Layout2D(parBBox, iter)
+	tv.CheckTabsOverflow()
+	return redo
+}
+
 // RenderTabSeps renders the separators between tabs
 func (tv *TabView) RenderTabSeps() {
 	rs, pc, st := tv.RenderLock()
@@ -516,6 +770,189 @@ func (tv *TabView) Render2D() {
 	}
 }
 
+////////////////////////////////////////////////////////////////////////////////////////
+//    Drag-n-Drop
+
+// TabMimeType is the mime type used for drag-n-drop of tabs -- the data is
+// the index of the tab within the source TabView
+const TabMimeType = "application/x-gogi-tab"
+
+// TabDropHintSpriteName is the name of the sprite showing where a dragged
+// tab will go
+const TabDropHintSpriteName = "gi.TabView:DropHint"
+
+// TabIndexAt returns the index of the tab at given window position, -1 if none
+func (tv *TabView) TabIndexAt(pos image.Point) int {
+	tbs := tv.Tabs()
+	sz := tv.NTabs()
+	for i := 0; i < sz && i < len(tbs.Kids); i++ {
+		if _, ni := KiToNode2D(tbs.Kids[i]); ni != nil && ni.PosInWinBBox(pos) {
+			return i
+		}
+	}
+	return -1
+}
+
+// TabDropIndex returns the index where a tab dropped at given window
+// position goes, along with the region for showing that in a drop hint
+func (tv *TabView) TabDropIndex(pos image.Point) (int, image.Rectangle) {
+	tbs := tv.Tabs()
+	sz := tv.NTabs()
+	var bb image.Rectangle
+	for i := 0; i < sz; i++ {
+		_, ni := KiToNode2D(tbs.Kids[i])
+		ni.BBoxMu.RLock()
+		bb = ni.WinBBox
+		ni.BBoxMu.RUnlock()
+		if pos.Y < bb.Min.Y || pos.Y >= bb.Max.Y || pos.X >= bb.Max.X {
+			continue
+		}
+		if pos.X < (bb.Min.X+bb.Max.X)/2 {
+			return i, image.Rect(bb.Min.X-2, bb.Min.Y, bb.Min.X+2, bb.Max.Y)
+		}
+		return i + 1, image.Rect(bb.Max.X-2, bb.Min.Y, bb.Max.X+2, bb.Max.Y)
+	}
+	return sz, image.Rect(bb.Max.X-2, bb.Min.Y, bb.Max.X+2, bb.Max.Y)
+}
+
+// ShowDropHint shows the given region of the window as the place where a
+// dragged item will be dropped
+func (tv *TabView) ShowDropHint(r image.Rectangle) {
+	win := tv.ParentWindow()
+	if win == nil || r.Empty() {
+		return
+	}
+	win.DeleteSprite(TabDropHintSpriteName)
+	sp := &Sprite{Name: TabDropHintSpriteName, On: true}
+	sp.Resize(r.Size())
+	sp.Geom.Pos = r.Min
+	clr := Prefs.Colors.Select.Clearer(50)
+	draw.Draw(sp.Pixels, sp.Pixels.Bounds(), &image.Uniform{clr}, image.ZP, draw.Src)
+	win.AddSprite(sp)
+}
+
+// HideDropHint hides the drop hint, if shown
+func (tv *TabView) HideDropHint() {
+	win := tv.ParentWindow()
+	if win == nil {
+		return
+	}
+	if win.DeleteSprite(TabDropHintSpriteName) {
+		win.RenderOverlays()
+	}
+}
+
+// IsDragOf returns true if data of given mime type is being dragged
+func (tv *TabView) IsDragOf(mimeType string) bool {
+	win := tv.ParentWindow()
+	if win == nil {
+		return false
+	}
+	return win.EventMgr.DNDData.HasType(mimeType)
+}
+
+// DragNDropStart starts a drag-n-drop of the tab at given index
+func (tv *TabView) DragNDropStart(idx int) {
+	_, tab, ok := tv.TabAtIndex(idx)
+	if !ok {
+		return
+	}
+	md := mimedata.NewMime(TabMimeType, []byte(strconv.Itoa(idx)))
+	sp := &Sprite{}
+	sp.GrabRenderFrom(tab)
+	ImageClearer(sp.Pixels, 50.0)
+	tv.ParentWindow().StartDragNDrop(tv.This(), md, sp)
+}
+
+// DragNDropTarget handles a drop of a tab onto our tabs, moving it from
+// its source TabView, which can be this one
+func (tv *TabView) DragNDropTarget(de *dnd.Event) {
+	tv.HideDropHint()
+	if de.Source == nil || de.Source.This() == nil {
+		return
+	}
+	src, ok := de.Source.Embed(KiT_TabView).(*TabView)
+	if !ok || src.NoDragTabs {
+		return
+	}
+	from, err := strconv.Atoi(de.Data.Text(TabMimeType))
+	if err != nil {
+		return
+	}
+	de.Target = tv.This()
+	de.Mod = dnd.DropMove
+	de.SetProcessed()
+	to, _ := tv.TabDropIndex(de.Pos())
+	tv.ParentWindow().FinalizeDragNDrop(dnd.DropMove)
+	if src == tv {
+		if to > from {
+			to--
+		}
+		tv.MoveTab(from, to)
+	} else {
+		src.MoveTabTo(from, tv, to)
+	}
+}
+
+// TabViewEvents connects the drag-n-drop events for the tabs
+func (tv *TabView) TabViewEvents() {
+	tv.ConnectEvent(oswin.DNDEvent, RegPri, func(recv, send ki.Ki, sig int64, d interface{}) {
+		de := d.(*dnd.Event)
+		tvv := recv.Embed(KiT_TabView).(*TabView)
+		if tvv.NoDragTabs {
+			return
+		}
+		switch de.Action {
+		case dnd.Start:
+			if idx := tvv.TabIndexAt(de.Pos()); idx >= 0 {
+				de.SetProcessed()
+				tvv.DragNDropStart(idx)
+			}
+		case dnd.DropOnTarget:
+			if de.Data.HasType(TabMimeType) && tvv.Tabs().PosInWinBBox(de.Pos()) {
+				tvv.DragNDropTarget(de)
+			}
+		}
+	})
+	tv.ConnectEvent(oswin.DNDMoveEvent, RegPri, func(recv, send ki.Ki, sig int64, d interface{}) {
+		tvv := recv.Embed(KiT_TabView).(*TabView)
+		if tvv.NoDragTabs || !tvv.IsDragOf(TabMimeType) {
+			return
+		}
+		de := d.(*dnd.MoveEvent)
+		if !tvv.Tabs().PosInWinBBox(de.Pos()) {
+			tvv.HideDropHint()
+			return
+		}
+		_, r := tvv.TabDropIndex(de.Pos())
+		tvv.ShowDropHint(r)
+	})
+	tv.ConnectEvent(oswin.DNDFocusEvent, RegPri, func(recv, send ki.Ki, sig int64, d interface{}) {
+		tvv := recv.Embed(KiT_TabView).(*TabView)
+		if tvv.NoDragTabs || !tvv.IsDragOf(TabMimeType) {
+			return
+		}
+		de := d.(*dnd.FocusEvent)
+		switch de.Action {
+		case dnd.Enter:
+			tvv.ParentWindow().DNDSetCursor(dnd.DropMove)
+		case dnd.Exit:
+			tvv.ParentWindow().DNDNotCursor()
+			tvv.HideDropHint()
+		}
+	})
+}
+
+func (tv *TabView) ConnectEvents2D() {
+	tv.Layout.ConnectEvents2D()
+	tv.TabViewEvents()
+}
+
+func (tv *TabView) AccessInfo2D(ai *AccessInfo) {
+	tv.Layout.AccessInfo2D(ai)
+	ai.Role = AccessTabList
+}
+
 ////////////////////////////////////////////////////////////////////////////////////////
 // TabButton
 
@@ -524,6 +961,8 @@ func (tv *TabView) Render2D() {
 type TabButton struct {
 	Action
 	NoDelete bool `desc:"if true, this tab does not have the delete button avail"`
+	Pinned   bool `desc:"if true, this tab is pinned -- see TabView.PinTab"`
+	Modified bool `desc:"if true, this tab is marked as having unsaved changes -- see TabView.SetTabModified"`
 }
 
 var KiT_TabButton = kit.Types.AddType(&TabButton{}, TabButtonProps)
@@ -604,6 +1043,23 @@ func (tb *TabButton) TabView() *TabView {
 	return tv.Embed(KiT_TabView).(*TabView)
 }
 
+// AccessInfo2D exposes the tab as controlling its page
+func (tb *TabButton) AccessInfo2D(ai *AccessInfo) {
+	tb.Action.AccessInfo2D(ai)
+	ai.Role = AccessTab
+	ai.SetState(true, AccessSelectable)
+	ai.SetState(tb.Modified, AccessEditable)
+	tv := tb.TabView()
+	if tv == nil {
+		return
+	}
+	if idx, ok := tv.Tabs().Kids.IndexOf(tb.This(), 0); ok {
+		if pg, err := tv.Frame().ChildTry(idx); err == nil {
+			ai.AddRelation(AccessControllerFor, pg)
+		}
+	}
+}
+
 func (tb *TabButton) ConfigParts() {
 	tb.Parts.SetProp("overflow", OverflowHidden) // no scrollbars!
 	if !tb.NoDelete {
@@ -16,12 +16,13 @@ func _() {
 	_ = x[TabSelected-0]
 	_ = x[TabAdded-1]
 	_ = x[TabDeleted-2]
-	_ = x[TabViewSignalsN-3]
+	_ = x[TabMoved-3]
+	_ = x[TabViewSignalsN-4]
 }
 
-const _TabViewSignals_name = "TabSelectedTabAddedTabDeletedTabViewSignalsN"
+const _TabViewSignals_name = "TabSelectedTabAddedTabDeletedTabMovedTabViewSignalsN"
 
-var _TabViewSignals_index = [...]uint8{0, 11, 19, 29, 44}
+var _TabViewSignals_index = [...]uint8{0, 11, 19, 29, 37, 52}
 
 func (i TabViewSignals) String() string {
 	if i < 0 || i >= TabViewSignals(len(_TabViewSignals_index)-1) {
@@ -0,0 +1,428 @@
+// Copyright (c) 2018, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"sync"
+
+	"github.com/goki/gi/units"
+	"github.com/goki/ki/ki"
+	"github.com/goki/ki/kit"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////
+// Task
+
+// TaskFunc is the function run by a Task, in its own goroutine -- it should
+// call t.Report to report progress, check t.Canceled() periodically and
+// return promptly when it is true, and return any error that occurred.
+type TaskFunc func(t *Task) error
+
+// Task runs a TaskFunc in a separate goroutine, relaying the progress and
+// status reports from that goroutine to the window event-processing
+// goroutine, where the Task fields are updated and TaskSig is emitted --
+// thus receivers of TaskSig can safely update widgets.  Use a TaskView to
+// show the progress (e.g., in a status bar), or TaskDialog for a modal
+// dialog with a Cancel button.
+type Task struct {
+	ki.Node
+	Func       TaskFunc        `json:"-" xml:"-" view:"-" desc:"function that does the work, run in a separate goroutine"`
+	State      TaskStates      `inactive:"+" desc:"current state of the task"`
+	Progress   float32         `inactive:"+" desc:"proportion complete, from 0 to 1 -- negative means unknown (indeterminate)"`
+	Status     string          `inactive:"+" desc:"current status message"`
+	Err        error           `json:"-" xml:"-" inactive:"+" desc:"error returned by Func, if any"`
+	Win        *Window         `json:"-" xml:"-" view:"-" desc:"window on whose event-processing goroutine updates are applied -- if nil, they are applied directly on the relay goroutine"`
+	TaskSig    ki.Signal       `json:"-" xml:"-" view:"-" desc:"signal for task -- see TaskSignals for the types -- emitted on the window goroutine"`
+	Updates    chan TaskUpdate `json:"-" xml:"-" view:"-" desc:"channel on which updates are sent from the task goroutine to the relay goroutine"`
+	cancel     chan struct{}
+	cancelOnce *sync.Once
+}
+
+var KiT_Task = kit.Types.AddType(&Task{}, nil)
+
+// NewTask returns a new task with given name, which will run given function
+// when started.
+func NewTask(name string, fun TaskFunc) *Task {
+	t := &Task{Func: fun}
+	t.InitName(t, name)
+	return t
+}
+
+func (t *Task) Disconnect() {
+	t.Node.Disconnect()
+	t.TaskSig.DisconnectAll()
+}
+
+// TaskStates are the states of a Task
+type TaskStates int32
+
+const (
+	// TaskPending means the task has not been started
+	TaskPending TaskStates = iota
+
+	// TaskRunning means the task function is running
+	TaskRunning
+
+	// TaskDone means the task function returned without error
+	TaskDone
+
+	// TaskCanceled means the task was canceled by Cancel
+	TaskCanceled
+
+	// TaskFailed means the task function returned an error, in Err
+	TaskFailed
+
+	TaskStatesN
+)
+
+//go:generate stringer -type=TaskStates
+
+var KiT_TaskStates = kit.Enums.AddEnum(TaskStatesN, kit.NotBitFlag, nil)
+
+// TaskSignals are signals that a Task sends -- always on the window goroutine
+type TaskSignals int64
+
+const (
+	// TaskStarted means the task has been started
+	TaskStarted TaskSignals = iota
+
+	// TaskUpdated means the Progress and / or Status has been updated
+	TaskUpdated
+
+	// TaskFinished means the task function has returned -- see State for
+	// how, and the data is the Err
+	TaskFinished
+
+	TaskSignalsN
+)
+
+//go:generate stringer -type=TaskSignals
+
+// TaskUpdate is a progress / status update sent from the task goroutine
+type TaskUpdate struct {
+	Progress float32 `desc:"proportion complete, from 0 to 1 -- negative means unknown"`
+	Status   string  `desc:"status message -- if empty, the status is not changed"`
+	Done     bool    `desc:"the task function has returned"`
+	Err      error   `desc:"error returned by the task function"`
+}
+
+// TaskUpdateBuf is the buffer size of the Task Updates channel -- Report
+// blocks when the buffer is full, until the window catches up
+var TaskUpdateBuf = 32
+
+// IsRunning returns true if the task is running
+func (t *Task) IsRunning() bool {
+	return t.State == TaskRunning
+}
+
+// Start starts running the task function in a new goroutine, with updates
+// applied on given window's event-processing goroutine (nil for none) --
+// Start itself should be called on the window goroutine.  Does nothing if
+// already running.
+func (t *Task) Start(win *Window) {
+	if t.IsRunning() || t.Func == nil {
+		return
+	}
+	t.Win = win
+	t.State = TaskRunning
+	t.Progress = -1
+	t.Status = ""
+	t.Err = nil
+	t.Updates = make(chan TaskUpdate, TaskUpdateBuf)
+	t.cancel = make(chan struct{})
+	t.cancelOnce = &sync.Once{}
+	t.TaskSig.Emit(t.This(), int64(TaskStarted), nil)
+	go t.Relay(t.Updates)
+	go t.Run(t.Updates)
+}
+
+// Run runs the task function and sends the final update -- called in its
+// own goroutine by Start
+func (t *Task) Run(upc chan TaskUpdate) {
+	err := t.Func(t)
+	upc <- TaskUpdate{Progress: 1, Done: true, Err: err}
+	close(upc)
+}
+
+// Relay relays updates from the task goroutine to the window goroutine --
+// called in its own goroutine by Start.  Progress updates are dropped once
+// the window is closed, but the final Done update is always applied and
+// TaskFinished emitted -- directly on the relay goroutine if the window is
+// closed, or closes before the update runs there.
+func (t *Task) Relay(upc chan TaskUpdate) {
+	for up := range upc {
+		up := up
+		win := t.Win
+		switch {
+		case win == nil || (up.Done && win.IsClosed()):
+			t.ApplyUpdate(up)
+		case win.IsClosed():
+			continue
+		case up.Done:
+			var once sync.Once
+			fin := func() {
+				once.Do(func() { t.ApplyUpdate(up) })
+			}
+			win.RunOnWin(fin)
+			if win.IsClosed() { // may have been dropped
+				fin()
+			}
+		default:
+			win.RunOnWin(func() {
+				t.ApplyUpdate(up)
+			})
+		}
+	}
+}
+
+// ApplyUpdate applies given update to the task fields and emits TaskSig
+func (t *Task) ApplyUpdate(up TaskUpdate) {
+	if up.Done {
+		t.setDone(up)
+		t.TaskSig.Emit(t.This(), int64(TaskFinished), t.Err)
+		return
+	}
+	t.Progress = up.Progress
+	if up.Status != "" {
+		t.Status = up.Status
+	}
+	t.TaskSig.Emit(t.This(), int64(TaskUpdated), nil)
+}
+
+// setDone sets the final state from the Done update
+func (t *Task) setDone(up TaskUpdate) {
+	t.Err = up.Err
+	switch {
+	case t.Canceled():
+		t.State = TaskCanceled
+	case up.Err != nil:
+		t.State = TaskFailed
+		t.Status = up.Err.Error()
+	default:
+		t.State = TaskDone
+		t.Progress = 1
+	}
+}
+
+// Report reports the progress (proportion complete from 0 to 1, negative
+// if unknown) and status (empty to leave unchanged) -- must only be called
+// from within the task function.
+func (t *Task) Report(progress float32, status string) {
+	t.Updates <- TaskUpdate{Progress: progress, Status: status}
+}
+
+// Cancel requests that the task be canceled -- the task function must
+// check Canceled and return -- it can be called from any goroutine.
+func (t *Task) Cancel() {
+	if t.cancelOnce == nil {
+		return
+	}
+	t.cancelOnce.Do(func() {
+		close(t.cancel)
+	})
+}
+
+// Canceled returns true if Cancel has been called on the task
+func (t *Task) Canceled() bool {
+	if t.cancel == nil {
+		return false
+	}
+	select {
+	case <-t.cancel:
+		return true
+	default:
+		return false
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////
+// TaskView
+
+// TaskView shows the progress of a Task, with a ProgressBar, a status Label
+// and a Cancel button -- it can be embedded anywhere, e.g., in a status bar,
+// and is used in TaskDialog.
+type TaskView struct {
+	Layout
+	Task     *Task `json:"-" xml:"-" desc:"the task being shown"`
+	NoCancel bool  `desc:"do not show the Cancel button"`
+}
+
+var KiT_TaskView = kit.Types.AddType(&TaskView{}, TaskViewProps)
+
+// AddNewTaskView adds a new task view to given parent node, with given name.
+func AddNewTaskView(parent ki.Ki, name string) *TaskView {
+	return parent.AddNewChild(KiT_TaskView, name).(*TaskView)
+}
+
+func (tv *TaskView) CopyFieldsFrom(frm interface{}) {
+	fr := frm.(*TaskView)
+	tv.Layout.CopyFieldsFrom(&fr.Layout)
+	tv.Task = fr.Task
+	tv.NoCancel = fr.NoCancel
+}
+
+var TaskViewProps = ki.Props{
+	"EnumType:Flag":  KiT_NodeFlags,
+	"max-width":      -1,
+	"vertical-align": AlignMiddle,
+	"spacing":        units.NewEx(0.5),
+}
+
+// SetTask sets the task to show, and connects to its signals to update
+func (tv *TaskView) SetTask(t *Task) {
+	if tv.Task == t {
+		return
+	}
+	if tv.Task != nil {
+		tv.Task.TaskSig.Disconnect(tv.This())
+	}
+	tv.Task = t
+	if t != nil {
+		t.TaskSig.Connect(tv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+			tvv := recv.Embed(KiT_TaskView).(*TaskView)
+			tvv.UpdateFromTask()
+		})
+	}
+	tv.Config()
+}
+
+// Run sets the task to show and starts it, on the window of this view
+func (tv *TaskView) Run(t *Task) {
+	tv.SetTask(t)
+	t.Start(tv.ParentWindow())
+}
+
+// Config configures the progress bar, status label and cancel button
+func (tv *TaskView) Config() {
+	tv.Lay = LayoutHoriz
+	config := kit.TypeAndNameList{}
+	config.Add(KiT_ProgressBar, "progress")
+	config.Add(KiT_Label, "status")
+	if !tv.NoCancel {
+		config.Add(KiT_Action, "cancel")
+	}
+	mods, updt := tv.ConfigChildren(config, ki.UniqueNames)
+	if mods {
+		pb := tv.ProgressBar()
+		pb.Max = 1
+		pb.SetMinPrefWidth(units.NewEm(10))
+		lb := tv.StatusLabel()
+		lb.SetProp("vertical-align", AlignMiddle)
+		lb.SetMinPrefWidth(units.NewCh(20))
+		if ca, ok := tv.ChildByName("cancel", 2).(*Action); ok {
+			ca.SetText(TrText(ca, "Cancel"))
+			ca.Tooltip = TrTooltip(ca, "cancel the task")
+			ca.ActionSig.ConnectOnly(tv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+				tvv := recv.Embed(KiT_TaskView).(*TaskView)
+				if tvv.Task != nil {
+					tvv.Task.Cancel()
+				}
+			})
+		}
+		tv.UpdateEnd(updt)
+	}
+	tv.UpdateFromTask()
+}
+
+// ProgressBar returns the progress bar
+func (tv *TaskView) ProgressBar() *ProgressBar {
+	return tv.ChildByName("progress", 0).(*ProgressBar)
+}
+
+// StatusLabel returns the status label
+func (tv *TaskView) StatusLabel() *Label {
+	return tv.ChildByName("status", 1).(*Label)
+}
+
+// UpdateFromTask updates the display from the current Task state
+func (tv *TaskView) UpdateFromTask() {
+	if tv.NumChildren() == 0 {
+		return
+	}
+	t := tv.Task
+	pb := tv.ProgressBar()
+	lb := tv.StatusLabel()
+	ca, _ := tv.ChildByName("cancel", 2).(*Action)
+	if t == nil {
+		pb.SetValue(0)
+		lb.SetText("")
+		if ca != nil {
+			ca.SetInactiveState(true)
+		}
+		return
+	}
+	updt := tv.UpdateStart()
+	if t.Progress < 0 && t.IsRunning() {
+		pb.SetIndeterminate(true)
+	} else {
+		pb.SetValue(t.Progress)
+	}
+	lb.SetText(t.Status)
+	if ca != nil {
+		ca.SetInactiveState(!t.IsRunning() || t.Canceled())
+	}
+	tv.UpdateEnd(updt)
+}
+
+func (tv *TaskView) Init2D() {
+	tv.Layout.Init2D()
+	tv.Config()
+}
+
+////////////////////////////////////////////////////////////////////////////////////////
+// TaskDialog
+
+// TaskDialog opens a modal dialog showing the progress of given task in a
+// TaskView, and starts the task -- the Cancel button (or the Abort key)
+// cancels the task and closes the dialog right away.  Otherwise the dialog
+// closes when the task finishes: accepted if done, canceled if canceled, and
+// an error prompt is shown if it failed.
+// Viewport is optional to properly contextualize dialog to given master window.
+func TaskDialog(avp *Viewport2D, t *Task, opts DlgOpts) *Dialog {
+	dlg := NewStdDialog(opts, NoOk, NoCancel)
+	dlg.Modal = true
+
+	frame := dlg.Frame()
+	_, prIdx := dlg.PromptWidget(frame)
+	tv := frame.InsertNewChild(KiT_TaskView, prIdx+1, "task-view").(*TaskView)
+	tv.SetMinPrefWidth(units.NewCh(50))
+	tv.SetTask(t)
+
+	t.TaskSig.Connect(dlg.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+		if sig != int64(TaskFinished) {
+			return
+		}
+		ddlg := recv.Embed(KiT_Dialog).(*Dialog)
+		tsk := send.Embed(KiT_Task).(*Task)
+		tsk.TaskSig.Disconnect(ddlg.This())
+		switch tsk.State {
+		case TaskDone:
+			ddlg.Accept()
+		case TaskFailed:
+			ddlg.Cancel()
+			PromptDialog(avp, DlgOpts{Title: opts.Title + ": Failed", Prompt: tsk.Err.Error()}, AddOk, NoCancel, nil, nil)
+		default:
+			ddlg.Cancel()
+		}
+	})
+	dlg.DialogSig.Connect(dlg.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+		// the dialog is closed, so it must not be closed again when the task finishes
+		t.TaskSig.Disconnect(recv)
+		if sig == int64(DialogCanceled) {
+			t.Cancel()
+		}
+	})
+
+	dlg.UpdateEndNoSig(true)
+	dlg.Open(0, 0, avp, nil)
+	win := dlg.ParentWindow()
+	if win == nil {
+		if vp := ValidViewport(avp); vp != nil {
+			win = vp.Win
+		}
+	}
+	t.Start(win)
+	return dlg
+}
@@ -805,6 +805,21 @@ type TextRender struct {
 	Links []TextLink     `desc:"hyperlinks within rendered text"`
 }
 
+// Clone returns a copy of the text, with its own spans that are not
+// affected by later changes to this one
+func (tr *TextRender) Clone() *TextRender {
+	ntr := *tr
+	ntr.Spans = make([]SpanRender, len(tr.Spans))
+	for i := range tr.Spans {
+		sr := tr.Spans[i]
+		sr.Text = append([]rune(nil), sr.Text...)
+		sr.Render = append([]RuneRender(nil), sr.Render...)
+		ntr.Spans[i] = sr
+	}
+	ntr.Links = append([]TextLink(nil), tr.Links...)
+	return &ntr
+}
+
 // InsertSpan inserts a new span at given index
 func (tr *TextRender) InsertSpan(at int, ns *SpanRender) {
 	sz := len(tr.Spans)
@@ -828,6 +843,13 @@ func (tr *TextRender) Render(rs *RenderState, pos mat32.Vec2) {
 	// pr := prof.Start("RenderText")
 	// defer pr.End()
 
+	if rs.Record != nil {
+		rs.Record.recordText(tr, rs, pos)
+		rec := rs.Record
+		rs.Record = nil // backgrounds and lines are part of the text
+		defer func() { rs.Record = rec }()
+	}
+
 	rs.BackupPaint()
 	defer rs.RestorePaint()
 
@@ -0,0 +1,1514 @@
+// Copyright (c) 2018, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"fmt"
+	"image"
+	"image/draw"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/chewxy/math32"
+	"github.com/goki/gi/oswin"
+	"github.com/goki/gi/oswin/cursor"
+	"github.com/goki/gi/oswin/key"
+	"github.com/goki/gi/oswin/mimedata"
+	"github.com/goki/gi/oswin/mouse"
+	"github.com/goki/gi/units"
+	"github.com/goki/ki/ints"
+	"github.com/goki/ki/ki"
+	"github.com/goki/ki/kit"
+	"github.com/goki/mat32"
+	"github.com/goki/pi/filecat"
+)
+
+// TextAreaUndoMax is the maximum number of undo steps saved for each TextArea
+var TextAreaUndoMax = 100
+
+////////////////////////////////////////////////////////////////////////////////////////
+// TextArea
+
+// TextAreaPos is a position in the text of a TextArea: line and character
+// (rune) within the line
+type TextAreaPos struct {
+	Ln int
+	Ch int
+}
+
+// IsLess returns true if this position is before the other one
+func (ps TextAreaPos) IsLess(cmp TextAreaPos) bool {
+	if ps.Ln != cmp.Ln {
+		return ps.Ln < cmp.Ln
+	}
+	return ps.Ch < cmp.Ch
+}
+
+// textAreaUndo is a saved state for undo / redo
+type textAreaUndo struct {
+	Txt    string
+	Cursor TextAreaPos
+}
+
+// TextArea is a widget for editing multiple lines of plain text, with word
+// wrapping, selection, undo, and scrolling within a given number of rows.
+// It is a lightweight alternative to giv.TextView for comment boxes, dialogs
+// etc, and sends the same TextFieldSignals as TextField on its TextAreaSig.
+// Enter inserts a new line, while Ctrl+Enter (Accept) or Tab completes the
+// edit.
+type TextArea struct {
+	WidgetBase
+	Txt         string                  `json:"-" xml:"text" desc:"the last saved value of the text string being edited"`
+	Placeholder string                  `json:"-" xml:"placeholder" desc:"text that is displayed when the area is empty, in a lower-contrast manner"`
+	Rows        int                     `xml:"rows" desc:"number of rows of text shown, which determines the height of the area -- more text scrolls -- set from rows property -- 4 by default"`
+	Cols        int                     `xml:"cols" desc:"width of the area in characters -- set from cols property -- 40 by default"`
+	CursorWidth units.Value             `xml:"cursor-width" desc:"width of cursor -- set from cursor-width property (inherited)"`
+	Edited      bool                    `json:"-" xml:"-" desc:"true if the text has been edited relative to the original"`
+	Lines       [][]rune                `json:"-" xml:"-" desc:"the live text being edited, with latest modifications, as lines of runes"`
+	CursorPos   TextAreaPos             `copy:"-" json:"-" xml:"-" desc:"current cursor position"`
+	CursorCol   float32                 `copy:"-" json:"-" xml:"-" desc:"horizontal position that the cursor aims for when moving up and down between rows"`
+	SelectStart TextAreaPos             `copy:"-" json:"-" xml:"-" desc:"starting position of selection"`
+	SelectEnd   TextAreaPos             `copy:"-" json:"-" xml:"-" desc:"ending position of selection"`
+	SelectInit  TextAreaPos             `copy:"-" json:"-" xml:"-" desc:"initial selection position -- where it started"`
+	SelectMode  bool                    `copy:"-" json:"-" xml:"-" desc:"if true, select text as cursor moves"`
+	TopRow      int                     `copy:"-" json:"-" xml:"-" desc:"first visible row -- rows are the wrapped lines of text"`
+	NRows       int                     `copy:"-" json:"-" xml:"-" desc:"total number of rows of wrapped text"`
+	LineRows    []int                   `copy:"-" json:"-" xml:"-" desc:"starting row for each line"`
+	Renders     []TextRender            `copy:"-" json:"-" xml:"-" desc:"render of each line, wrapped into spans for each row"`
+	LineHeight  float32                 `copy:"-" json:"-" xml:"-" desc:"height of each row, including line spacing, cached during layout"`
+	FontHeight  float32                 `copy:"-" json:"-" xml:"-" desc:"font height, cached during layout"`
+	TextAreaSig ki.Signal               `copy:"-" json:"-" xml:"-" view:"-" desc:"signal for text area -- see TextFieldSignals for the types"`
+	StateStyles [TextFieldStatesN]Style `copy:"-" json:"-" xml:"-" desc:"normal style and focus style"`
+	BlinkOn     bool                    `copy:"-" json:"-" xml:"-" desc:"oscillates between on and off for blinking"`
+	CursorMu    sync.Mutex              `copy:"-" json:"-" xml:"-" view:"-" desc:"mutex for updating cursor between blinker and area"`
+	Undos       []textAreaUndo          `copy:"-" json:"-" xml:"-" view:"-" desc:"saved states for undo"`
+	Redos       []textAreaUndo          `copy:"-" json:"-" xml:"-" view:"-" desc:"saved states for redo"`
+	undoGroup   string
+	placeRender TextRender
+}
+
+var KiT_TextArea = kit.Types.AddType(&TextArea{}, TextAreaProps)
+
+// AddNewTextArea adds a new textarea to given parent node, with given name.
+func AddNewTextArea(parent ki.Ki, name string) *TextArea {
+	return parent.AddNewChild(KiT_TextArea, name).(*TextArea)
+}
+
+func (ta *TextArea) CopyFieldsFrom(frm interface{}) {
+	fr := frm.(*TextArea)
+	ta.WidgetBase.CopyFieldsFrom(&fr.WidgetBase)
+	ta.Txt = fr.Txt
+	ta.Placeholder = fr.Placeholder
+	ta.Rows = fr.Rows
+	ta.Cols = fr.Cols
+	ta.CursorWidth = fr.CursorWidth
+	ta.Edited = fr.Edited
+}
+
+func (ta *TextArea) Disconnect() {
+	ta.WidgetBase.Disconnect()
+	ta.TextAreaSig.DisconnectAll()
+}
+
+var TextAreaProps = ki.Props{
+	"EnumType:Flag":    KiT_NodeFlags,
+	"border-width":     units.NewPx(1),
+	"cursor-width":     units.NewPx(3),
+	"border-color":     &Prefs.Colors.Border,
+	"padding":          units.NewPx(4),
+	"margin":           units.NewPx(1),
+	"text-align":       AlignLeft,
+	"color":            &Prefs.Colors.Font,
+	"background-color": &Prefs.Colors.Control,
+	"rows":             4,
+	"cols":             40,
+	TextFieldSelectors[TextFieldActive]: ki.Props{
+		"background-color": "lighter-0",
+	},
+	TextFieldSelectors[TextFieldFocus]: ki.Props{
+		"border-width":     units.NewPx(2),
+		"background-color": "samelight-80",
+	},
+	TextFieldSelectors[TextFieldInactive]: ki.Props{
+		"background-color": "highlight-10",
+	},
+	TextFieldSelectors[TextFieldSel]: ki.Props{
+		"background-color": &Prefs.Colors.Select,
+	},
+}
+
+// these extend NodeBase NodeFlags to hold TextArea state
+const (
+	// TextAreaFocusActive indicates that the focus is active in this area
+	TextAreaFocusActive NodeFlags = NodeFlagsN + iota
+)
+
+// IsFocusActive returns true if we have active focus for keyboard input
+func (ta *TextArea) IsFocusActive() bool {
+	return ta.HasFlag(int(TextAreaFocusActive))
+}
+
+// Text returns the current text -- applies any unapplied changes first, and
+// sends a signal if so -- this is the end-user method to get the current
+// value of the area.
+func (ta *TextArea) Text() string {
+	ta.EditDone()
+	return ta.Txt
+}
+
+// SetText sets the text to be edited and reverts any current edit to reflect this new text
+func (ta *TextArea) SetText(txt string) {
+	if ta.Txt == txt && !ta.Edited {
+		return
+	}
+	ta.Txt = txt
+	ta.Revert()
+	ta.Undos = nil
+	ta.Redos = nil
+}
+
+// EditText returns the live text being edited, including any unapplied changes
+func (ta *TextArea) EditText() string {
+	ss := make([]string, len(ta.Lines))
+	for i, l := range ta.Lines {
+		ss[i] = string(l)
+	}
+	return strings.Join(ss, "\n")
+}
+
+// SetEditText sets the live text being edited, from given string
+func (ta *TextArea) SetEditText(txt string) {
+	ss := strings.Split(txt, "\n")
+	ta.Lines = make([][]rune, len(ss))
+	for i, s := range ss {
+		ta.Lines[i] = []rune(s)
+	}
+}
+
+// EditDone completes editing and copies the active edited text to the text --
+// called when Ctrl+Enter or tab is pressed or goes out of focus
+func (ta *TextArea) EditDone() {
+	if ta.Edited {
+		ta.Edited = false
+		ta.Txt = ta.EditText()
+		ta.TextAreaSig.Emit(ta.This(), int64(TextFieldDone), ta.Txt)
+	}
+	ta.ClearSelected()
+	ta.ClearCursor()
+}
+
+// EditDeFocused completes editing and copies the active edited text to the text --
+// called when area is made inactive due to interactions elsewhere.
+func (ta *TextArea) EditDeFocused() {
+	if ta.Edited {
+		ta.Edited = false
+		ta.Txt = ta.EditText()
+		ta.TextAreaSig.Emit(ta.This(), int64(TextFieldDeFocused), ta.Txt)
+	}
+	ta.ClearSelected()
+	ta.ClearCursor()
+}
+
+// Revert aborts editing and reverts to last saved text
+func (ta *TextArea) Revert() {
+	updt := ta.UpdateStart()
+	defer ta.UpdateEnd(updt)
+	ta.SetEditText(ta.Txt)
+	ta.Edited = false
+	ta.TopRow = 0
+	ta.CursorPos = TextAreaPos{}
+	ta.SelectReset()
+	ta.LayoutText()
+}
+
+// Clear clears any existing text
+func (ta *TextArea) Clear() {
+	updt := ta.UpdateStart()
+	defer ta.UpdateEnd(updt)
+	ta.SaveUndo("")
+	ta.Edited = true
+	ta.Lines = [][]rune{{}}
+	ta.TopRow = 0
+	ta.CursorPos = TextAreaPos{}
+	ta.SelectReset()
+	ta.LayoutText()
+	ta.GrabFocus() // this is essential for ensuring that the clear applies after focus is lost..
+	ta.TextAreaSig.Emit(ta.This(), int64(TextFieldCleared), ta.Txt)
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////
+//  Positions
+
+// ValidPos returns given position constrained to be within the text
+func (ta *TextArea) ValidPos(pos TextAreaPos) TextAreaPos {
+	if len(ta.Lines) == 0 {
+		return TextAreaPos{}
+	}
+	pos.Ln = ints.MinInt(ints.MaxInt(pos.Ln, 0), len(ta.Lines)-1)
+	pos.Ch = ints.MinInt(ints.MaxInt(pos.Ch, 0), len(ta.Lines[pos.Ln]))
+	return pos
+}
+
+// EndPos returns the position at the end of the text
+func (ta *TextArea) EndPos() TextAreaPos {
+	if len(ta.Lines) == 0 {
+		return TextAreaPos{}
+	}
+	ln := len(ta.Lines) - 1
+	return TextAreaPos{ln, len(ta.Lines[ln])}
+}
+
+// PosForward returns the position given number of characters after given
+// position, where each line break counts as one character
+func (ta *TextArea) PosForward(pos TextAreaPos, steps int) TextAreaPos {
+	for ; steps > 0; steps-- {
+		if pos.Ch < len(ta.Lines[pos.Ln]) {
+			pos.Ch++
+		} else if pos.Ln < len(ta.Lines)-1 {
+			pos.Ln++
+			pos.Ch = 0
+		} else {
+			break
+		}
+	}
+	return pos
+}
+
+// PosBackward returns the position given number of characters before given
+// position, where each line break counts as one character
+func (ta *TextArea) PosBackward(pos TextAreaPos, steps int) TextAreaPos {
+	for ; steps > 0; steps-- {
+		if pos.Ch > 0 {
+			pos.Ch--
+		} else if pos.Ln > 0 {
+			pos.Ln--
+			pos.Ch = len(ta.Lines[pos.Ln])
+		} else {
+			break
+		}
+	}
+	return pos
+}
+
+// TextRange returns the text between given positions, with line breaks
+func (ta *TextArea) TextRange(st, ed TextAreaPos) string {
+	if !st.IsLess(ed) {
+		return ""
+	}
+	if st.Ln == ed.Ln {
+		return string(ta.Lines[st.Ln][st.Ch:ed.Ch])
+	}
+	var sb strings.Builder
+	sb.WriteString(string(ta.Lines[st.Ln][st.Ch:]))
+	for ln := st.Ln + 1; ln < ed.Ln; ln++ {
+		sb.WriteString("\n")
+		sb.WriteString(string(ta.Lines[ln]))
+	}
+	sb.WriteString("\n")
+	sb.WriteString(string(ta.Lines[ed.Ln][:ed.Ch]))
+	return sb.String()
+}
+
+// RowLine returns the line and span within the line's render for given row
+func (ta *TextArea) RowLine(row int) (ln, si int) {
+	ln = sort.Search(len(ta.LineRows), func(i int) bool { return ta.LineRows[i] > row }) - 1
+	if ln < 0 {
+		return 0, 0
+	}
+	return ln, row - ta.LineRows[ln]
+}
+
+// RowRunes returns the line and range of characters within the line shown in
+// given row
+func (ta *TextArea) RowRunes(row int) (ln, st, ed int) {
+	ln, si := ta.RowLine(row)
+	if ln >= len(ta.Renders) || len(ta.Lines[ln]) == 0 {
+		return ln, 0, 0
+	}
+	tr := &ta.Renders[ln]
+	if si >= len(tr.Spans) {
+		return ln, 0, 0
+	}
+	st, _ = tr.SpanPosToRuneIdx(si, 0)
+	ed = st + len(tr.Spans[si].Render)
+	return ln, st, ed
+}
+
+// CharX returns the horizontal offset of given character in given line,
+// relative to the start of its row
+func (ta *TextArea) CharX(ln, ch int) float32 {
+	if ln >= len(ta.Renders) || len(ta.Lines[ln]) == 0 {
+		return 0
+	}
+	tr := &ta.Renders[ln]
+	if ch >= len(ta.Lines[ln]) {
+		pos, _, _, _ := tr.RuneEndPos(len(ta.Lines[ln]) - 1)
+		return pos.X
+	}
+	pos, _, _, _ := tr.RuneRelPos(ch)
+	return pos.X
+}
+
+// PosRow returns the row where given position is shown
+func (ta *TextArea) PosRow(pos TextAreaPos) int {
+	if pos.Ln >= len(ta.LineRows) {
+		return 0
+	}
+	row := ta.LineRows[pos.Ln]
+	if pos.Ln >= len(ta.Renders) || len(ta.Lines[pos.Ln]) == 0 {
+		return row
+	}
+	tr := &ta.Renders[pos.Ln]
+	if pos.Ch >= len(ta.Lines[pos.Ln]) {
+		return row + len(tr.Spans) - 1
+	}
+	si, _, _ := tr.RuneSpanPos(pos.Ch)
+	return row + si
+}
+
+// PosAtRowX returns the position of the character closest to given
+// horizontal offset in given row
+func (ta *TextArea) PosAtRowX(row int, x float32) TextAreaPos {
+	if ta.NRows == 0 {
+		return TextAreaPos{}
+	}
+	row = ints.MinInt(ints.MaxInt(row, 0), ta.NRows-1)
+	ln, st, ed := ta.RowRunes(row)
+	if ed == st {
+		return TextAreaPos{ln, st}
+	}
+	for ch := st; ch < ed; ch++ {
+		sx := ta.CharX(ln, ch)
+		ex := ta.CharX(ln, ch+1)
+		if ch+1 == ed {
+			pos, _, _, _ := ta.Renders[ln].RuneEndPos(ch)
+			ex = pos.X
+		}
+		if x < (sx+ex)/2 {
+			return TextAreaPos{ln, ch}
+		}
+	}
+	if ed < len(ta.Lines[ln]) { // wrapped row: stay before the next row
+		return TextAreaPos{ln, ed - 1}
+	}
+	return TextAreaPos{ln, ed}
+}
+
+// VisRows returns the number of rows that are visible
+func (ta *TextArea) VisRows() int {
+	if ta.LineHeight <= 0 {
+		return ints.MaxInt(ta.Rows, 1)
+	}
+	ht := ta.LayState.Alloc.Size.Y - 2*ta.Sty.BoxSpace()
+	return ints.MaxInt(int(ht/ta.LineHeight), 1)
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////
+//  Cursor Navigation
+
+// SetCursor sets the cursor to given position, extending the selection if
+// extend is true or in SelectMode, and resetting it otherwise
+func (ta *TextArea) SetCursor(pos TextAreaPos, extend bool) {
+	updt := ta.UpdateStart()
+	defer ta.UpdateEnd(updt)
+	pos = ta.ValidPos(pos)
+	if extend || ta.SelectMode {
+		if !ta.SelectMode && !ta.HasSelection() {
+			ta.SelectInit = ta.CursorPos
+		}
+		ta.CursorPos = pos
+		ta.SelectRegUpdate(pos)
+	} else {
+		ta.CursorPos = pos
+		ta.SelectReset()
+	}
+	ta.undoGroup = ""
+	ta.ScrollToCursor()
+}
+
+// SetCursorCol sets the cursor position and records its horizontal offset
+// as the target for moving up and down
+func (ta *TextArea) SetCursorCol(pos TextAreaPos, extend bool) {
+	ta.SetCursor(pos, extend)
+	ta.CursorCol = ta.CharX(ta.CursorPos.Ln, ta.CursorPos.Ch)
+}
+
+// CursorForward moves the cursor forward
+func (ta *TextArea) CursorForward(steps int, extend bool) {
+	ta.SetCursorCol(ta.PosForward(ta.CursorPos, steps), extend)
+}
+
+// CursorBackward moves the cursor backward
+func (ta *TextArea) CursorBackward(steps int, extend bool) {
+	ta.SetCursorCol(ta.PosBackward(ta.CursorPos, steps), extend)
+}
+
+// CursorDown moves the cursor down given number of rows
+func (ta *TextArea) CursorDown(steps int, extend bool) {
+	row := ta.PosRow(ta.CursorPos) + steps
+	if row >= ta.NRows {
+		ta.SetCursor(ta.EndPos(), extend)
+		return
+	}
+	ta.SetCursor(ta.PosAtRowX(row, ta.CursorCol), extend)
+}
+
+// CursorUp moves the cursor up given number of rows
+func (ta *TextArea) CursorUp(steps int, extend bool) {
+	row := ta.PosRow(ta.CursorPos) - steps
+	if row < 0 {
+		ta.SetCursor(TextAreaPos{}, extend)
+		return
+	}
+	ta.SetCursor(ta.PosAtRowX(row, ta.CursorCol), extend)
+}
+
+// CursorStartLine moves the cursor to the start of the line
+func (ta *TextArea) CursorStartLine(extend bool) {
+	ta.SetCursorCol(TextAreaPos{ta.CursorPos.Ln, 0}, extend)
+}
+
+// CursorEndLine moves the cursor to the end of the line
+func (ta *TextArea) CursorEndLine(extend bool) {
+	ta.SetCursorCol(TextAreaPos{ta.CursorPos.Ln, len(ta.Lines[ta.CursorPos.Ln])}, extend)
+}
+
+// CursorBackspace deletes character(s) immediately before cursor
+func (ta *TextArea) CursorBackspace(steps int) {
+	if ta.HasSelection() {
+		ta.DeleteSelection()
+		return
+	}
+	st := ta.PosBackward(ta.CursorPos, steps)
+	if st == ta.CursorPos {
+		return
+	}
+	ta.SaveUndo("backspace")
+	ta.DeleteRange(st, ta.CursorPos)
+	ta.TextAreaSig.Emit(ta.This(), int64(TextFieldBackspace), ta.Txt)
+}
+
+// CursorDelete deletes character(s) immediately after the cursor
+func (ta *TextArea) CursorDelete(steps int) {
+	if ta.HasSelection() {
+		ta.DeleteSelection()
+		return
+	}
+	ed := ta.PosForward(ta.CursorPos, steps)
+	if ed == ta.CursorPos {
+		return
+	}
+	ta.SaveUndo("delete")
+	ta.DeleteRange(ta.CursorPos, ed)
+	ta.TextAreaSig.Emit(ta.This(), int64(TextFieldDelete), ta.Txt)
+}
+
+// CursorKill deletes text from cursor to end of line, or the line break if
+// already at the end of the line
+func (ta *TextArea) CursorKill() {
+	steps := len(ta.Lines[ta.CursorPos.Ln]) - ta.CursorPos.Ch
+	if steps == 0 {
+		steps = 1
+	}
+	ta.CursorDelete(steps)
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////
+//  Editing
+
+// DeleteRange deletes the text between given positions, leaving the cursor
+// at the start -- returns the deleted text
+func (ta *TextArea) DeleteRange(st, ed TextAreaPos) string {
+	if !st.IsLess(ed) {
+		return ""
+	}
+	updt := ta.UpdateStart()
+	defer ta.UpdateEnd(updt)
+	del := ta.TextRange(st, ed)
+	ta.Edited = true
+	nl := append([]rune{}, ta.Lines[st.Ln][:st.Ch]...)
+	nl = append(nl, ta.Lines[ed.Ln][ed.Ch:]...)
+	ta.Lines[st.Ln] = nl
+	ta.Lines = append(ta.Lines[:st.Ln+1], ta.Lines[ed.Ln+1:]...)
+	ta.CursorPos = st
+	ta.SelectReset()
+	ta.LayoutText()
+	ta.ScrollToCursor()
+	ta.CursorCol = ta.CharX(ta.CursorPos.Ln, ta.CursorPos.Ch)
+	return del
+}
+
+// InsertAtCursor inserts given text at current cursor position, replacing
+// any selection -- the text can contain line breaks
+func (ta *TextArea) InsertAtCursor(str string) {
+	if str == "" {
+		return
+	}
+	updt := ta.UpdateStart()
+	defer ta.UpdateEnd(updt)
+	grp := "insert"
+	if strings.ContainsAny(str, "\n ") || len(str) > 1 || ta.HasSelection() {
+		grp = "" // new undo step at each word, line or paste
+	}
+	ta.SaveUndo(grp)
+	if ta.HasSelection() {
+		ta.DeleteRange(ta.SelectStart, ta.SelectEnd)
+	}
+	ta.Edited = true
+	str = strings.Replace(str, "\r\n", "\n", -1)
+	ss := strings.Split(str, "\n")
+	cp := ta.CursorPos
+	after := append([]rune{}, ta.Lines[cp.Ln][cp.Ch:]...)
+	line := append(ta.Lines[cp.Ln][:cp.Ch], []rune(ss[0])...)
+	if len(ss) == 1 {
+		ta.Lines[cp.Ln] = append(line, after...)
+		ta.CursorPos.Ch += len([]rune(ss[0]))
+	} else {
+		nls := make([][]rune, 0, len(ss))
+		nls = append(nls, line)
+		for _, s := range ss[1 : len(ss)-1] {
+			nls = append(nls, []rune(s))
+		}
+		last := []rune(ss[len(ss)-1])
+		nls = append(nls, append(last, after...))
+		tail := append([][]rune{}, ta.Lines[cp.Ln+1:]...)
+		ta.Lines = append(append(ta.Lines[:cp.Ln], nls...), tail...)
+		ta.CursorPos = TextAreaPos{cp.Ln + len(ss) - 1, len(last)}
+	}
+	ta.LayoutText()
+	ta.ScrollToCursor()
+	ta.CursorCol = ta.CharX(ta.CursorPos.Ln, ta.CursorPos.Ch)
+	ta.TextAreaSig.Emit(ta.This(), int64(TextFieldInsert), ta.Txt)
+}
+
+// SaveUndo saves the current state for undo, before an edit -- successive
+// edits with the same non-empty group (e.g., typing within a word) are undone
+// together
+func (ta *TextArea) SaveUndo(group string) {
+	if group != "" && group == ta.undoGroup {
+		return
+	}
+	ta.undoGroup = group
+	ta.Undos = append(ta.Undos, textAreaUndo{Txt: ta.EditText(), Cursor: ta.CursorPos})
+	if len(ta.Undos) > TextAreaUndoMax {
+		ta.Undos = ta.Undos[len(ta.Undos)-TextAreaUndoMax:]
+	}
+	ta.Redos = nil
+}
+
+// restoreUndo sets the text and cursor from given saved state
+func (ta *TextArea) restoreUndo(un textAreaUndo) {
+	updt := ta.UpdateStart()
+	defer ta.UpdateEnd(updt)
+	ta.SetEditText(un.Txt)
+	ta.Edited = true
+	ta.undoGroup = ""
+	ta.SelectReset()
+	ta.LayoutText()
+	ta.SetCursorCol(un.Cursor, false)
+}
+
+// Undo undoes the last edit
+func (ta *TextArea) Undo() {
+	n := len(ta.Undos)
+	if n == 0 {
+		return
+	}
+	ta.Redos = append(ta.Redos, textAreaUndo{Txt: ta.EditText(), Cursor: ta.CursorPos})
+	un := ta.Undos[n-1]
+	ta.Undos = ta.Undos[:n-1]
+	ta.restoreUndo(un)
+}
+
+// Redo redoes the last undone edit
+func (ta *TextArea) Redo() {
+	n := len(ta.Redos)
+	if n == 0 {
+		return
+	}
+	ta.Undos = append(ta.Undos, textAreaUndo{Txt: ta.EditText(), Cursor: ta.CursorPos})
+	un := ta.Redos[n-1]
+	ta.Redos = ta.Redos[:n-1]
+	ta.restoreUndo(un)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+//    Selection
+
+// ClearSelected resets both the global selected flag and any current selection
+func (ta *TextArea) ClearSelected() {
+	ta.WidgetBase.ClearSelected()
+	ta.SelectReset()
+}
+
+// HasSelection returns whether there is a selected region of text
+func (ta *TextArea) HasSelection() bool {
+	return ta.SelectStart.IsLess(ta.SelectEnd)
+}
+
+// Selection returns the currently selected text
+func (ta *TextArea) Selection() string {
+	if ta.HasSelection() {
+		return ta.TextRange(ta.SelectStart, ta.SelectEnd)
+	}
+	return ""
+}
+
+// SelectModeToggle toggles the SelectMode, updating selection with cursor movement
+func (ta *TextArea) SelectModeToggle() {
+	if ta.SelectMode {
+		ta.SelectMode = false
+	} else {
+		ta.SelectMode = true
+		ta.SelectInit = ta.CursorPos
+		ta.SelectStart = ta.CursorPos
+		ta.SelectEnd = ta.SelectStart
+	}
+}
+
+// SelectRegUpdate updates current select region based on given cursor position
+// relative to SelectInit position
+func (ta *TextArea) SelectRegUpdate(pos TextAreaPos) {
+	if pos.IsLess(ta.SelectInit) {
+		ta.SelectStart = pos
+		ta.SelectEnd = ta.SelectInit
+	} else {
+		ta.SelectStart = ta.SelectInit
+		ta.SelectEnd = pos
+	}
+}
+
+// SelectAll selects all the text
+func (ta *TextArea) SelectAll() {
+	updt := ta.UpdateStart()
+	ta.SelectStart = TextAreaPos{}
+	ta.SelectInit = ta.SelectStart
+	ta.SelectEnd = ta.EndPos()
+	ta.UpdateEnd(updt)
+}
+
+// SelectWord selects the word that the cursor is on
+func (ta *TextArea) SelectWord() {
+	updt := ta.UpdateStart()
+	defer ta.UpdateEnd(updt)
+	line := ta.Lines[ta.CursorPos.Ln]
+	st := ta.CursorPos.Ch
+	ed := st
+	isw := func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' }
+	for st > 0 && isw(line[st-1]) {
+		st--
+	}
+	for ed < len(line) && isw(line[ed]) {
+		ed++
+	}
+	if st == ed && ed < len(line) {
+		ed++
+	}
+	ta.SelectStart = TextAreaPos{ta.CursorPos.Ln, st}
+	ta.SelectInit = ta.SelectStart
+	ta.SelectEnd = TextAreaPos{ta.CursorPos.Ln, ed}
+}
+
+// SelectReset resets the selection
+func (ta *TextArea) SelectReset() {
+	ta.SelectMode = false
+	if !ta.HasSelection() {
+		return
+	}
+	updt := ta.UpdateStart()
+	ta.SelectStart = TextAreaPos{}
+	ta.SelectEnd = ta.SelectStart
+	ta.UpdateEnd(updt)
+}
+
+// DeleteSelection deletes any selected text, without adding to clipboard --
+// returns text deleted
+func (ta *TextArea) DeleteSelection() string {
+	if !ta.HasSelection() {
+		return ""
+	}
+	ta.SaveUndo("")
+	return ta.DeleteRange(ta.SelectStart, ta.SelectEnd)
+}
+
+// Cut cuts any selected text and adds it to the clipboard
+func (ta *TextArea) Cut() {
+	wupdt := ta.TopUpdateStart()
+	defer ta.TopUpdateEnd(wupdt)
+	cut := ta.DeleteSelection()
+	if cut != "" {
+		oswin.TheApp.ClipBoard(ta.ParentWindow().OSWin).Write(mimedata.NewText(cut))
+	}
+}
+
+// MimeData adds selection to mimedata.
+// Satisfies Clipper interface -- can be extended in subtypes.
+func (ta *TextArea) MimeData(md *mimedata.Mimes) {
+	*md = append(*md, mimedata.NewTextData(ta.Selection()))
+}
+
+// Copy copies any selected text to the clipboard.
+// Satisfies Clipper interface -- can be extended in subtypes.
+// optionally resetting the current selection
+func (ta *TextArea) Copy(reset bool) {
+	if !ta.HasSelection() {
+		return
+	}
+	md := mimedata.NewMimes(0, 1)
+	ta.This().(Clipper).MimeData(&md)
+	oswin.TheApp.ClipBoard(ta.ParentWindow().OSWin).Write(md)
+	if reset {
+		ta.SelectReset()
+	}
+}
+
+// Paste inserts text from the clipboard at current cursor position,
+// replacing any selection.
+// Satisfies Clipper interface -- can be extended in subtypes.
+func (ta *TextArea) Paste() {
+	wupdt := ta.TopUpdateStart()
+	defer ta.TopUpdateEnd(wupdt)
+	data := oswin.TheApp.ClipBoard(ta.ParentWindow().OSWin).Read([]string{filecat.TextPlain})
+	if data != nil {
+		ta.InsertAtCursor(data.Text(filecat.TextPlain))
+	}
+}
+
+func (ta *TextArea) MakeContextMenu(m *Menu) {
+	cpsc := ActiveKeyMap.ChordForFun(KeyFunCopy)
+	ac := m.AddAction(ActOpts{Label: "Copy", Shortcut: cpsc},
+		ta.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+			taa := recv.Embed(KiT_TextArea).(*TextArea)
+			taa.This().(Clipper).Copy(true)
+		})
+	ac.SetActiveState(ta.HasSelection())
+	if !ta.IsInactive() {
+		ctsc := ActiveKeyMap.ChordForFun(KeyFunCut)
+		ptsc := ActiveKeyMap.ChordForFun(KeyFunPaste)
+		ac = m.AddAction(ActOpts{Label: "Cut", Shortcut: ctsc},
+			ta.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+				taa := recv.Embed(KiT_TextArea).(*TextArea)
+				taa.This().(Clipper).Cut()
+			})
+		ac.SetActiveState(ta.HasSelection())
+		ac = m.AddAction(ActOpts{Label: "Paste", Shortcut: ptsc},
+			ta.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+				taa := recv.Embed(KiT_TextArea).(*TextArea)
+				taa.This().(Clipper).Paste()
+			})
+		ac.SetInactiveState(oswin.TheApp.ClipBoard(ta.ParentWindow().OSWin).IsEmpty())
+		m.AddSeparator("sep-undo")
+		ac = m.AddAction(ActOpts{Label: "Undo", Shortcut: ActiveKeyMap.ChordForFun(KeyFunUndo)},
+			ta.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+				taa := recv.Embed(KiT_TextArea).(*TextArea)
+				taa.Undo()
+			})
+		ac.SetActiveState(len(ta.Undos) > 0)
+		ac = m.AddAction(ActOpts{Label: "Redo", Shortcut: ActiveKeyMap.ChordForFun(KeyFunRedo)},
+			ta.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+				taa := recv.Embed(KiT_TextArea).(*TextArea)
+				taa.Redo()
+			})
+		ac.SetActiveState(len(ta.Redos) > 0)
+	}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+//    Scrolling
+
+// ScrollToCursor scrolls the rows so that the cursor is visible
+func (ta *TextArea) ScrollToCursor() {
+	row := ta.PosRow(ta.CursorPos)
+	vis := ta.VisRows()
+	if row < ta.TopRow {
+		ta.TopRow = row
+	} else if row >= ta.TopRow+vis {
+		ta.TopRow = row - vis + 1
+	}
+	ta.ClampTopRow()
+}
+
+// ClampTopRow ensures the TopRow is in range
+func (ta *TextArea) ClampTopRow() {
+	ta.TopRow = ints.MaxInt(ints.MinInt(ta.TopRow, ta.NRows-ta.VisRows()), 0)
+}
+
+// ScrollRows scrolls given number of rows down (negative = up)
+func (ta *TextArea) ScrollRows(rows int) bool {
+	cur := ta.TopRow
+	ta.TopRow += rows
+	ta.ClampTopRow()
+	if ta.TopRow == cur {
+		return false
+	}
+	ta.UpdateSig()
+	return true
+}
+
+///////////////////////////////////////////////////////////////////////////////
+//    Cursor rendering
+
+// CharStartPos returns the starting (top-left) render coords for the given
+// position -- if wincoords is true, then adds window box offset -- for
+// cursor, popups
+func (ta *TextArea) CharStartPos(pos TextAreaPos, wincoords bool) mat32.Vec2 {
+	st := &ta.Sty
+	spos := ta.LayState.Alloc.Pos.AddScalar(st.BoxSpace())
+	if wincoords {
+		mvp := ta.ViewportSafe()
+		mvp.BBoxMu.RLock()
+		spos = spos.Add(mat32.NewVec2FmPoint(mvp.WinBBox.Min))
+		mvp.BBoxMu.RUnlock()
+	}
+	spos.X += ta.CharX(pos.Ln, pos.Ch)
+	spos.Y += float32(ta.PosRow(pos)-ta.TopRow)*ta.LineHeight + 0.5*(ta.LineHeight-ta.FontHeight)
+	return spos
+}
+
+// TextAreaBlinkMu is mutex protecting TextAreaBlink updating and access
+var TextAreaBlinkMu sync.Mutex
+
+// TextAreaBlinker is the time.Ticker for blinking cursors for text areas,
+// only one of which can be active at at a time
+var TextAreaBlinker *time.Ticker
+
+// BlinkingTextArea is the text area that is blinking
+var BlinkingTextArea *TextArea
+
+// TextAreaSpriteName is the name of the window sprite used for the cursor
+var TextAreaSpriteName = "gi.TextArea.Cursor"
+
+// TextAreaBlink is function that blinks text area cursor
+func TextAreaBlink() {
+	for {
+		TextAreaBlinkMu.Lock()
+		if TextAreaBlinker == nil {
+			TextAreaBlinkMu.Unlock()
+			return // shutdown..
+		}
+		TextAreaBlinkMu.Unlock()
+		<-TextAreaBlinker.C
+		TextAreaBlinkMu.Lock()
+		if BlinkingTextArea == nil || BlinkingTextArea.This() == nil {
+			TextAreaBlinkMu.Unlock()
+			continue
+		}
+		if BlinkingTextArea.IsDestroyed() || BlinkingTextArea.IsDeleted() {
+			BlinkingTextArea = nil
+			TextAreaBlinkMu.Unlock()
+			continue
+		}
+		ta := BlinkingTextArea
+		if ta.Viewport == nil || !ta.HasFocus() || !ta.IsFocusActive() || !ta.This().(Node2D).IsVisible() {
+			BlinkingTextArea = nil
+			TextAreaBlinkMu.Unlock()
+			continue
+		}
+		win := ta.ParentWindow()
+		if win == nil || win.IsResizing() || win.IsClosed() || !win.IsWindowInFocus() || win.IsUpdating() {
+			TextAreaBlinkMu.Unlock()
+			continue
+		}
+		ta.BlinkOn = !ta.BlinkOn
+		ta.RenderCursor(ta.BlinkOn)
+		TextAreaBlinkMu.Unlock()
+	}
+}
+
+// StartCursor starts the cursor blinking and renders it
+func (ta *TextArea) StartCursor() {
+	if ta == nil || ta.This() == nil {
+		return
+	}
+	if !ta.This().(Node2D).IsVisible() {
+		return
+	}
+	ta.BlinkOn = true
+	if CursorBlinkMSec == 0 {
+		ta.RenderCursor(true)
+		return
+	}
+	TextAreaBlinkMu.Lock()
+	if TextAreaBlinker == nil {
+		TextAreaBlinker = time.NewTicker(time.Duration(CursorBlinkMSec) * time.Millisecond)
+		go TextAreaBlink()
+	}
+	win := ta.ParentWindow()
+	if win != nil && !win.IsResizing() {
+		ta.RenderCursor(true)
+	}
+	BlinkingTextArea = ta
+	TextAreaBlinkMu.Unlock()
+}
+
+// ClearCursor turns off cursor and stops it from blinking
+func (ta *TextArea) ClearCursor() {
+	if ta.IsInactive() {
+		return
+	}
+	ta.StopCursor()
+	ta.RenderCursor(false)
+}
+
+// StopCursor stops the cursor from blinking
+func (ta *TextArea) StopCursor() {
+	if ta == nil || ta.This() == nil {
+		return
+	}
+	TextAreaBlinkMu.Lock()
+	if BlinkingTextArea == ta {
+		BlinkingTextArea = nil
+	}
+	TextAreaBlinkMu.Unlock()
+}
+
+// RenderCursor renders the cursor on or off, as a sprite that is either on
+// or off -- it is always off when the cursor row is scrolled out of view
+func (ta *TextArea) RenderCursor(on bool) {
+	if ta == nil || ta.This() == nil {
+		return
+	}
+	if !ta.This().(Node2D).IsVisible() {
+		return
+	}
+	ta.CursorMu.Lock()
+	defer ta.CursorMu.Unlock()
+
+	win := ta.ParentWindow()
+	sp := ta.CursorSprite()
+	if sp == nil {
+		return
+	}
+	row := ta.PosRow(ta.CursorPos)
+	if on && row >= ta.TopRow && row < ta.TopRow+ta.VisRows() {
+		win.ActivateSprite(sp.Name)
+	} else {
+		win.InactivateSprite(sp.Name)
+	}
+	sp.Geom.Pos = ta.CharStartPos(ta.CursorPos, true).ToPointFloor()
+	win.RenderOverlays() // needs an explicit call!
+	win.UpdateSig()      // publish
+}
+
+// CursorSprite returns the Sprite for the cursor (which is
+// only rendered once with a vertical bar, and just activated and inactivated
+// depending on render status)
+func (ta *TextArea) CursorSprite() *Sprite {
+	win := ta.ParentWindow()
+	if win == nil {
+		return nil
+	}
+	sty := &ta.StateStyles[TextFieldActive]
+	spnm := fmt.Sprintf("%v-%v", TextAreaSpriteName, ta.FontHeight)
+	sp, ok := win.SpriteByName(spnm)
+	if !ok {
+		bbsz := image.Point{int(math32.Ceil(ta.CursorWidth.Dots)), int(math32.Ceil(ta.FontHeight))}
+		if bbsz.X < 2 { // at least 2
+			bbsz.X = 2
+		}
+		sp = win.AddNewSprite(spnm, bbsz, image.ZP)
+		draw.Draw(sp.Pixels, sp.Pixels.Bounds(), &image.Uniform{sty.Font.Color}, image.ZP, draw.Src)
+	}
+	return sp
+}
+
+///////////////////////////////////////////////////////////////////////////////
+//    Events
+
+// ShiftSelect returns true if the shift key is down, to extend the selection
+// with cursor movement
+func (ta *TextArea) ShiftSelect(kt *key.ChordEvent) bool {
+	return kt.HasAnyModifier(key.Shift)
+}
+
+// KeyInput handles keyboard input into the text area
+func (ta *TextArea) KeyInput(kt *key.ChordEvent) {
+	if KeyEventTrace {
+		fmt.Printf("TextArea KeyInput: %v\n", ta.PathUnique())
+	}
+	kf := KeyFun(kt.Chord())
+
+	if !ta.IsFocusActive() && kf == KeyFunAbort {
+		return
+	}
+
+	// first all the keys that work for both inactive and active
+	switch kf {
+	case KeyFunMoveRight:
+		kt.SetProcessed()
+		ta.CursorForward(1, ta.ShiftSelect(kt))
+	case KeyFunMoveLeft:
+		kt.SetProcessed()
+		ta.CursorBackward(1, ta.ShiftSelect(kt))
+	case KeyFunMoveDown:
+		kt.SetProcessed()
+		ta.CursorDown(1, ta.ShiftSelect(kt))
+	case KeyFunMoveUp:
+		kt.SetProcessed()
+		ta.CursorUp(1, ta.ShiftSelect(kt))
+	case KeyFunPageDown:
+		kt.SetProcessed()
+		ta.CursorDown(ta.VisRows(), ta.ShiftSelect(kt))
+	case KeyFunPageUp:
+		kt.SetProcessed()
+		ta.CursorUp(ta.VisRows(), ta.ShiftSelect(kt))
+	case KeyFunHome:
+		kt.SetProcessed()
+		ta.CursorStartLine(ta.ShiftSelect(kt))
+	case KeyFunEnd:
+		kt.SetProcessed()
+		ta.CursorEndLine(ta.ShiftSelect(kt))
+	case KeyFunDocHome:
+		kt.SetProcessed()
+		ta.SetCursorCol(TextAreaPos{}, ta.ShiftSelect(kt))
+	case KeyFunDocEnd:
+		kt.SetProcessed()
+		ta.SetCursorCol(ta.EndPos(), ta.ShiftSelect(kt))
+	case KeyFunSelectMode:
+		kt.SetProcessed()
+		ta.SelectModeToggle()
+	case KeyFunCancelSelect:
+		kt.SetProcessed()
+		ta.SelectReset()
+	case KeyFunSelectAll:
+		kt.SetProcessed()
+		ta.SelectAll()
+	case KeyFunCopy:
+		kt.SetProcessed()
+		ta.This().(Clipper).Copy(true) // reset
+	}
+	if ta.IsInactive() || kt.IsProcessed() {
+		return
+	}
+	switch kf {
+	case KeyFunEnter:
+		kt.SetProcessed()
+		ta.InsertAtCursor("\n")
+	case KeyFunFocusNext: // we process tab to make it EditDone as opposed to other ways of losing focus
+		fallthrough
+	case KeyFunAccept: // ctrl+enter
+		kt.SetProcessed()
+		ta.EditDone()
+		ta.FocusNext()
+	case KeyFunFocusPrev:
+		kt.SetProcessed()
+		ta.EditDone()
+		ta.FocusPrev()
+	case KeyFunAbort: // esc
+		kt.SetProcessed()
+		ta.Revert()
+		ta.FocusChanged2D(FocusInactive)
+	case KeyFunBackspace:
+		kt.SetProcessed()
+		ta.CursorBackspace(1)
+	case KeyFunKill:
+		kt.SetProcessed()
+		ta.CursorKill()
+	case KeyFunDelete:
+		kt.SetProcessed()
+		ta.CursorDelete(1)
+	case KeyFunCut:
+		kt.SetProcessed()
+		ta.This().(Clipper).Cut()
+	case KeyFunPaste:
+		kt.SetProcessed()
+		ta.This().(Clipper).Paste()
+	case KeyFunUndo:
+		kt.SetProcessed()
+		ta.Undo()
+	case KeyFunRedo:
+		kt.SetProcessed()
+		ta.Redo()
+	case KeyFunNil:
+		if unicode.IsPrint(kt.Rune) && !kt.HasAnyModifier(key.Control, key.Meta) {
+			kt.SetProcessed()
+			ta.InsertAtCursor(string(kt.Rune))
+		}
+	}
+}
+
+// PixelToCursor finds the cursor position that corresponds to the given
+// pixel location, relative to the area
+func (ta *TextArea) PixelToCursor(pt image.Point) TextAreaPos {
+	spc := ta.Sty.BoxSpace()
+	if ta.LineHeight <= 0 {
+		return TextAreaPos{}
+	}
+	y := float32(pt.Y) - spc
+	row := ta.TopRow
+	if y > 0 {
+		row += int(y / ta.LineHeight)
+	}
+	return ta.PosAtRowX(row, float32(pt.X)-spc)
+}
+
+// HandleMouseEvent handles the mouse.Event
+func (ta *TextArea) HandleMouseEvent(me *mouse.Event) {
+	if ta.ParentWindow() == nil {
+		return
+	}
+	if !ta.IsInactive() && !ta.HasFocus() {
+		ta.GrabFocus()
+	}
+	me.SetProcessed()
+	switch me.Button {
+	case mouse.Left:
+		if me.Action == mouse.Press {
+			if ta.IsInactive() {
+				ta.SetSelectedState(!ta.IsSelected())
+				ta.EmitSelectedSignal()
+				ta.UpdateSig()
+			} else {
+				pt := ta.PointToRelPos(me.Pos())
+				ta.SetCursorCol(ta.PixelToCursor(pt), me.SelectMode() == mouse.ExtendContinuous)
+			}
+		} else if me.Action == mouse.DoubleClick {
+			if ta.HasSelection() {
+				ta.SelectAll()
+			} else {
+				ta.SelectWord()
+			}
+		}
+	case mouse.Middle:
+		if !ta.IsInactive() && me.Action == mouse.Press {
+			pt := ta.PointToRelPos(me.Pos())
+			ta.SetCursorCol(ta.PixelToCursor(pt), false)
+			ta.Paste()
+		}
+	case mouse.Right:
+		if me.Action == mouse.Press {
+			ta.EmitContextMenuSignal()
+			ta.This().(Node2D).ContextMenu()
+		}
+	}
+}
+
+func (ta *TextArea) MouseDragEvent() {
+	ta.ConnectEvent(oswin.MouseDragEvent, RegPri, func(recv, send ki.Ki, sig int64, d interface{}) {
+		me := d.(*mouse.DragEvent)
+		me.SetProcessed()
+		taa := recv.Embed(KiT_TextArea).(*TextArea)
+		if taa.IsInactive() {
+			return
+		}
+		pt := taa.PointToRelPos(me.Pos())
+		taa.SetCursorCol(taa.PixelToCursor(pt), true)
+	})
+}
+
+func (ta *TextArea) MouseEvent() {
+	ta.ConnectEvent(oswin.MouseEvent, RegPri, func(recv, send ki.Ki, sig int64, d interface{}) {
+		taa := recv.Embed(KiT_TextArea).(*TextArea)
+		me := d.(*mouse.Event)
+		taa.HandleMouseEvent(me)
+	})
+}
+
+func (ta *TextArea) MouseScrollEvent() {
+	ta.ConnectEvent(oswin.MouseScrollEvent, RegPri, func(recv, send ki.Ki, sig int64, d interface{}) {
+		taa := recv.Embed(KiT_TextArea).(*TextArea)
+		me := d.(*mouse.ScrollEvent)
+		if me.Delta.Y == 0 || taa.LineHeight <= 0 {
+			return
+		}
+		rows := int(float32(me.Delta.Y) / taa.LineHeight)
+		if rows == 0 {
+			rows = ints.MaxInt(ints.MinInt(me.Delta.Y, 1), -1)
+		}
+		if taa.ScrollRows(rows) {
+			me.SetProcessed() // otherwise let the enclosing layout scroll
+		}
+	})
+}
+
+func (ta *TextArea) MouseFocusEvent() {
+	ta.ConnectEvent(oswin.MouseFocusEvent, RegPri, func(recv, send ki.Ki, sig int64, d interface{}) {
+		taa := recv.Embed(KiT_TextArea).(*TextArea)
+		if taa.IsInactive() {
+			return
+		}
+		me := d.(*mouse.FocusEvent)
+		me.SetProcessed()
+		if me.Action == mouse.Enter {
+			oswin.TheApp.Cursor(taa.ParentWindow().OSWin).PushIfNot(cursor.IBeam)
+		} else {
+			oswin.TheApp.Cursor(taa.ParentWindow().OSWin).PopIf(cursor.IBeam)
+		}
+	})
+}
+
+func (ta *TextArea) KeyChordEvent() {
+	ta.ConnectEvent(oswin.KeyChordEvent, RegPri, func(recv, send ki.Ki, sig int64, d interface{}) {
+		taa := recv.Embed(KiT_TextArea).(*TextArea)
+		kt := d.(*key.ChordEvent)
+		taa.KeyInput(kt)
+	})
+	if dlg, ok := ta.Viewport.This().(*Dialog); ok {
+		dlg.DialogSig.Connect(ta.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+			taa, _ := recv.Embed(KiT_TextArea).(*TextArea)
+			if sig == int64(DialogAccepted) {
+				taa.EditDone()
+			}
+		})
+	}
+}
+
+func (ta *TextArea) TextAreaEvents() {
+	ta.HoverTooltipEvent()
+	ta.MouseDragEvent()
+	ta.MouseEvent()
+	ta.MouseScrollEvent()
+	ta.MouseFocusEvent()
+	ta.KeyChordEvent()
+}
+
+////////////////////////////////////////////////////
+//  Node2D Interface
+
+func (ta *TextArea) Init2D() {
+	ta.Init2DWidget()
+	ta.SetEditText(ta.Txt)
+	ta.Edited = false
+}
+
+// StyleTextArea does text area styling -- sets StyMu Lock
+func (ta *TextArea) StyleTextArea() {
+	ta.StyMu.Lock()
+	defer ta.StyMu.Unlock()
+
+	ta.SetCanFocusIfActive()
+	ta.Style2DWidget()
+	pst := &(ta.Par.(Node2D).AsWidget().Sty)
+	for i := 0; i < int(TextFieldStatesN); i++ {
+		ta.StateStyles[i].CopyFrom(&ta.Sty)
+		ta.StateStyles[i].SetStyleProps(pst, ta.StyleProps(TextFieldSelectors[i]), ta.Viewport)
+		ta.StateStyles[i].StyleCSS(ta.This().(Node2D), ta.CSSAgg, TextFieldSelectors[i], ta.Viewport)
+		ta.StateStyles[i].CopyUnitContext(&ta.Sty.UnContext)
+	}
+	ta.CursorWidth.SetFmInheritProp("cursor-width", ta.This(), ki.Inherit, ki.TypeProps) // get type defaults
+	ta.CursorWidth.ToDots(&ta.Sty.UnContext)
+	if pv, ok := ta.PropInherit("rows", ki.NoInherit, ki.TypeProps); ok {
+		if iv, ok := kit.ToInt(pv); ok {
+			ta.Rows = int(iv)
+		}
+	}
+	if pv, ok := ta.PropInherit("cols", ki.NoInherit, ki.TypeProps); ok {
+		if iv, ok := kit.ToInt(pv); ok {
+			ta.Cols = int(iv)
+		}
+	}
+}
+
+func (ta *TextArea) Style2D() {
+	ta.StyleTextArea()
+	ta.StyMu.Lock()
+	ta.LayState.SetFromStyle(&ta.Sty.Layout) // also does reset
+	ta.StyMu.Unlock()
+}
+
+// LayoutText lays out each line of text, wrapping within the current
+// allocated width, and computes the rows -- called after each edit
+func (ta *TextArea) LayoutText() {
+	st := &ta.Sty
+	if st.Font.Size.Val == 0 { // not yet styled
+		return
+	}
+	st.Font.OpenFont(&st.UnContext)
+	ta.FontHeight = st.Font.Face.Metrics.Height
+	ta.LineHeight = ta.FontHeight * st.Text.EffLineHeight()
+	wd := ta.LayState.Alloc.Size.X - 2*st.BoxSpace() - ta.CursorWidth.Dots
+	nln := len(ta.Lines)
+	if cap(ta.Renders) >= nln {
+		ta.Renders = ta.Renders[:nln]
+	} else {
+		ta.Renders = make([]TextRender, nln)
+	}
+	if cap(ta.LineRows) >= nln {
+		ta.LineRows = ta.LineRows[:nln]
+	} else {
+		ta.LineRows = make([]int, nln)
+	}
+	row := 0
+	for ln, line := range ta.Lines {
+		tr := &ta.Renders[ln]
+		tr.SetRunes(line, &st.Font, &st.UnContext, &st.Text, true, 0, 0)
+		tr.LayoutStdLR(&st.Text, &st.Font, &st.UnContext, mat32.Vec2{wd, 0})
+		ta.LineRows[ln] = row
+		row += ints.MaxInt(len(tr.Spans), 1)
+	}
+	ta.NRows = row
+}
+
+func (ta *TextArea) Size2D(iter int) {
+	st := &ta.Sty
+	st.Font.OpenFont(&st.UnContext)
+	fht := st.Font.Face.Metrics.Height
+	rows := ints.MaxInt(ta.Rows, 1)
+	cols := ints.MaxInt(ta.Cols, 1)
+	w := float32(cols) * st.Font.Face.Metrics.Ch
+	h := float32(rows) * fht * st.Text.EffLineHeight()
+	ta.Size2DFromWH(w, h)
+}
+
+func (ta *TextArea) Layout2D(parBBox image.Rectangle, iter int) bool {
+	ta.Layout2DBase(parBBox, true, iter) // init style
+	for i := 0; i < int(TextFieldStatesN); i++ {
+		ta.StateStyles[i].CopyUnitContext(&ta.Sty.UnContext)
+	}
+	ta.LayoutText()
+	ta.ClampTopRow()
+	return ta.Layout2DChildren(iter)
+}
+
+// RenderSelect renders the selected region, if any, underneath the text
+func (ta *TextArea) RenderSelect(pos mat32.Vec2) {
+	if !ta.HasSelection() {
+		return
+	}
+	rs := &ta.Viewport.Render
+	pc := &rs.Paint
+	st := &ta.StateStyles[TextFieldSel]
+	nlw := 0.5 * ta.Sty.Font.Face.Metrics.Ch // shows selected line breaks
+	erow := ints.MinInt(ta.TopRow+ta.VisRows(), ta.NRows)
+	for row := ta.TopRow; row < erow; row++ {
+		ln, rst, red := ta.RowRunes(row)
+		if ln < ta.SelectStart.Ln || ln > ta.SelectEnd.Ln {
+			continue
+		}
+		c0 := 0
+		if ln == ta.SelectStart.Ln {
+			c0 = ta.SelectStart.Ch
+		}
+		c1 := len(ta.Lines[ln])
+		if ln == ta.SelectEnd.Ln {
+			c1 = ta.SelectEnd.Ch
+		}
+		lo := ints.MaxInt(c0, rst)
+		hi := ints.MinInt(c1, red)
+		lastRow := red == len(ta.Lines[ln])
+		extra := float32(0)
+		if ln < ta.SelectEnd.Ln && lastRow {
+			extra = nlw
+		}
+		if hi < lo || (hi == lo && extra == 0) {
+			continue
+		}
+		x0 := ta.CharX(ln, lo)
+		x1 := x0
+		if hi > lo {
+			x1 = ta.CharX(ln, hi)
+			if hi == red && hi > 0 {
+				ep, _, _, _ := ta.Renders[ln].RuneEndPos(hi - 1)
+				x1 = ep.X
+			}
+		}
+		y := pos.Y + float32(row-ta.TopRow)*ta.LineHeight
+		pc.FillBox(rs, mat32.Vec2{pos.X + x0, y}, mat32.Vec2{x1 - x0 + extra, ta.LineHeight}, &st.Font.BgColor)
+	}
+}
+
+func (ta *TextArea) RenderTextArea() {
+	rs, _, st := ta.RenderLock()
+	defer ta.RenderUnlock(rs)
+
+	if ta.IsInactive() {
+		if ta.IsSelected() {
+			ta.Sty = ta.StateStyles[TextFieldSel]
+		} else {
+			ta.Sty = ta.StateStyles[TextFieldInactive]
+		}
+	} else if ta.HasFocus() && ta.IsFocusActive() {
+		ta.Sty = ta.StateStyles[TextFieldFocus]
+	} else if ta.IsSelected() {
+		ta.Sty = ta.StateStyles[TextFieldSel]
+	} else {
+		ta.Sty = ta.StateStyles[TextFieldActive]
+	}
+	st = &ta.Sty // update
+	ta.LayoutText()
+	ta.ClampTopRow()
+	ta.RenderStdBox(st)
+	pos := ta.LayState.Alloc.Pos.AddScalar(st.BoxSpace())
+	if ta.NRows <= 1 && len(ta.Lines) == 1 && len(ta.Lines[0]) == 0 && len(ta.Placeholder) > 0 {
+		st.Font.Color = st.Font.Color.Highlight(50)
+		ta.placeRender.SetString(ta.Placeholder, &st.Font, &st.UnContext, &st.Text, true, 0, 0)
+		ta.placeRender.LayoutStdLR(&st.Text, &st.Font, &st.UnContext, mat32.Vec2{ta.LayState.Alloc.Size.X - 2*st.BoxSpace(), 0})
+		ta.placeRender.Render(rs, pos)
+		return
+	}
+	ta.RenderSelect(pos)
+	erow := ints.MinInt(ta.TopRow+ta.VisRows(), ta.NRows)
+	for row := ta.TopRow; row < erow; row++ {
+		ln, si := ta.RowLine(row)
+		tr := &ta.Renders[ln]
+		if si >= len(tr.Spans) || tr.Spans[si].IsValid() != nil {
+			continue
+		}
+		vr := TextRender{Spans: tr.Spans[si : si+1]} // just this row
+		vr.Render(rs, mat32.Vec2{pos.X, pos.Y + float32(row-ta.TopRow-si)*ta.LineHeight})
+	}
+}
+
+func (ta *TextArea) Render2D() {
+	if ta.FullReRenderIfNeeded() {
+		return
+	}
+	if ta.PushBounds() {
+		ta.This().(Node2D).ConnectEvents2D()
+		ta.RenderTextArea()
+		if ta.IsActive() {
+			if ta.HasFocus() && ta.IsFocusActive() {
+				ta.StartCursor()
+			} else {
+				ta.StopCursor()
+			}
+		}
+		ta.Render2DChildren()
+		ta.PopBounds()
+	} else {
+		ta.DisconnectAllEvents(RegPri)
+	}
+}
+
+func (ta *TextArea) ConnectEvents2D() {
+	ta.TextAreaEvents()
+}
+
+func (ta *TextArea) AccessInfo2D(ai *AccessInfo) {
+	ta.WidgetBase.AccessInfo2D(ai)
+	ai.Role = AccessTextArea
+	ai.Label = ta.Placeholder
+	lns := make([]string, len(ta.Lines))
+	for i, ln := range ta.Lines {
+		lns[i] = string(ln)
+	}
+	ai.Value = strings.Join(lns, "\n")
+	ai.SetState(!ta.IsInactive(), AccessEditable)
+	ai.SetState(true, AccessMultiLine)
+}
+
+func (ta *TextArea) FocusChanged2D(change FocusChanges) {
+	switch change {
+	case FocusLost:
+		ta.ClearFlag(int(TextAreaFocusActive))
+		ta.EditDone()
+		ta.UpdateSig()
+	case FocusGot:
+		ta.SetFlag(int(TextAreaFocusActive))
+		ta.ScrollToMe()
+		ta.EmitFocusedSignal()
+		ta.UpdateSig()
+	case FocusInactive:
+		ta.ClearFlag(int(TextAreaFocusActive))
+		ta.EditDeFocused()
+		ta.UpdateSig()
+	case FocusActive:
+		ta.SetFlag(int(TextAreaFocusActive))
+		ta.ScrollToMe()
+	}
+}
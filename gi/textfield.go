@@ -44,6 +44,15 @@ type TextField struct {
 	Txt          string                  `json:"-" xml:"text" desc:"the last saved value of the text string being edited"`
 	Placeholder  string                  `json:"-" xml:"placeholder" desc:"text that is displayed when the field is empty, in a lower-contrast manner"`
 	ClearAct     bool                    `xml:"clear-act" desc:"add a clear action x at right side of edit, set from clear-act property (inherited) -- on by default"`
+	NoEcho       bool                    `xml:"no-echo" desc:"password mode: the text is displayed as EchoChar characters and cannot be copied, unless revealed with the reveal action (eye) at the right side of the field"`
+	EchoChar     rune                    `xml:"echo-char" desc:"character displayed in place of each character of the text in NoEcho mode -- • if 0"`
+	Revealed     bool                    `json:"-" xml:"-" desc:"in NoEcho mode, the text is currently revealed"`
+	Mask         string                  `xml:"mask" desc:"format mask that input must match, or the name of one of the TextFieldMasks (date, time, phone, ipv4...) -- see TextFieldMask for the syntax: characters that do not fit are rejected while typing, and literal characters are inserted automatically"`
+	MinLen       int                     `xml:"min-len" desc:"minimum length of the text in characters, if > 0 -- a MinLen of 1 requires a value"`
+	MaxLen       int                     `xml:"max-len" desc:"maximum length of the text in characters, if > 0 -- typing beyond this is blocked"`
+	Validator    TextValidator           `json:"-" xml:"-" view:"-" desc:"optional validator for the text, checked along with MinLen, MaxLen and Mask when the edit is done -- invalid text is not applied"`
+	ValidErr     error                   `copy:"-" json:"-" xml:"-" view:"-" desc:"current validation error, if the last edit was not valid -- shown as the tooltip, with the invalid-color border"`
+	InvalidColor Color                   `xml:"invalid-color" desc:"border color shown when the text is not valid -- set from invalid-color property"`
 	CursorWidth  units.Value             `xml:"cursor-width" desc:"width of cursor -- set from cursor-width property (inherited)"`
 	Edited       bool                    `json:"-" xml:"-" desc:"true if the text has been edited relative to the original"`
 	EditTxt      []rune                  `json:"-" xml:"-" desc:"the live text string being edited, with latest modifications -- encoded as runes"`
@@ -65,6 +74,7 @@ type TextField struct {
 	BlinkOn      bool                    `copy:"-" json:"-" xml:"-" desc:"oscillates between on and off for blinking"`
 	CursorMu     sync.Mutex              `copy:"-" json:"-" xml:"-" view:"-" desc:"mutex for updating cursor between blinker and field"`
 	Complete     *Complete               `copy:"-" json:"-" xml:"-" desc:"functions and data for textfield completion"`
+	validTip     string
 }
 
 var KiT_TextField = kit.Types.AddType(&TextField{}, TextFieldProps)
@@ -80,6 +90,12 @@ func (tf *TextField) CopyFieldsFrom(frm interface{}) {
 	tf.Txt = fr.Txt
 	tf.Placeholder = fr.Placeholder
 	tf.ClearAct = fr.ClearAct
+	tf.NoEcho = fr.NoEcho
+	tf.EchoChar = fr.EchoChar
+	tf.Mask = fr.Mask
+	tf.MinLen = fr.MinLen
+	tf.MaxLen = fr.MaxLen
+	tf.Validator = fr.Validator
 	tf.CursorWidth = fr.CursorWidth
 	tf.Edited = fr.Edited
 	tf.MaxWidthReq = fr.MaxWidthReq
@@ -101,6 +117,7 @@ var TextFieldProps = ki.Props{
 	"color":            &Prefs.Colors.Font,
 	"background-color": &Prefs.Colors.Control,
 	"clear-act":        true,
+	"invalid-color":    "#c00",
 	"#clear": ki.Props{
 		"width":          units.NewEx(0.5),
 		"height":         units.NewEx(0.5),
@@ -108,6 +125,13 @@ var TextFieldProps = ki.Props{
 		"padding":        units.NewPx(0),
 		"vertical-align": AlignMiddle,
 	},
+	"#reveal": ki.Props{
+		"width":          units.NewEx(0.5),
+		"height":         units.NewEx(0.5),
+		"margin":         units.NewPx(0),
+		"padding":        units.NewPx(0),
+		"vertical-align": AlignMiddle,
+	},
 	TextFieldSelectors[TextFieldActive]: ki.Props{
 		"background-color": "lighter-0",
 	},
@@ -156,6 +180,10 @@ const (
 	// TextFieldDelete is emitted when a character after cursor is deleted
 	TextFieldDelete
 
+	// TextFieldInvalid is emitted when the edit is done but the text is not
+	// valid (see Validate), so it was not applied.  data is the error.
+	TextFieldInvalid
+
 	TextFieldSignalsN
 )
 
@@ -223,6 +251,11 @@ func (tf *TextField) SetText(txt string) {
 // called when the return key is pressed or goes out of focus
 func (tf *TextField) EditDone() {
 	if tf.Edited {
+		if !tf.ValidateEdit() {
+			tf.ClearSelected()
+			tf.ClearCursor()
+			return
+		}
 		tf.Edited = false
 		tf.Txt = string(tf.EditTxt)
 		tf.TextFieldSig.Emit(tf.This(), int64(TextFieldDone), tf.Txt)
@@ -235,6 +268,11 @@ func (tf *TextField) EditDone() {
 // called when field is made inactive due to interactions elsewhere.
 func (tf *TextField) EditDeFocused() {
 	if tf.Edited {
+		if !tf.ValidateEdit() {
+			tf.ClearSelected()
+			tf.ClearCursor()
+			return
+		}
 		tf.Edited = false
 		tf.Txt = string(tf.EditTxt)
 		tf.TextFieldSig.Emit(tf.This(), int64(TextFieldDeFocused), tf.Txt)
@@ -252,6 +290,7 @@ func (tf *TextField) Revert() {
 	tf.StartPos = 0
 	tf.EndPos = tf.CharWidth
 	tf.SelectReset()
+	tf.SetValidErr(nil)
 }
 
 // Clear clears any existing text
@@ -264,6 +303,7 @@ func (tf *TextField) Clear() {
 	tf.EndPos = 0
 	tf.SelectReset()
 	tf.GrabFocus() // this is essential for ensuring that the clear applies after focus is lost..
+	tf.RevalidateEdit()
 	tf.TextFieldSig.Emit(tf.This(), int64(TextFieldCleared), tf.Txt)
 }
 
@@ -361,6 +401,7 @@ func (tf *TextField) CursorBackspace(steps int) {
 	tf.Edited = true
 	tf.EditTxt = append(tf.EditTxt[:tf.CursorPos-steps], tf.EditTxt[tf.CursorPos:]...)
 	tf.CursorBackward(steps)
+	tf.RevalidateEdit()
 	tf.TextFieldSig.Emit(tf.This(), int64(TextFieldBackspace), tf.Txt)
 }
 
@@ -382,6 +423,7 @@ func (tf *TextField) CursorDelete(steps int) {
 	defer tf.UpdateEnd(updt)
 	tf.Edited = true
 	tf.EditTxt = append(tf.EditTxt[:tf.CursorPos], tf.EditTxt[tf.CursorPos+steps:]...)
+	tf.RevalidateEdit()
 	tf.TextFieldSig.Emit(tf.This(), int64(TextFieldDelete), tf.Txt)
 }
 
@@ -533,12 +575,13 @@ func (tf *TextField) SelectUpdate() {
 	}
 }
 
-// Cut cuts any selected text and adds it to the clipboard
+// Cut cuts any selected text and adds it to the clipboard -- obscured
+// (NoEcho) text is deleted without being added to the clipboard
 func (tf *TextField) Cut() {
 	wupdt := tf.TopUpdateStart()
 	defer tf.TopUpdateEnd(wupdt)
 	cut := tf.DeleteSelection()
-	if cut != "" {
+	if cut != "" && !tf.IsObscured() {
 		oswin.TheApp.ClipBoard(tf.ParentWindow().OSWin).Write(mimedata.NewText(cut))
 	}
 }
@@ -563,6 +606,7 @@ func (tf *TextField) DeleteSelection() string {
 		}
 	}
 	tf.SelectReset()
+	tf.RevalidateEdit()
 	return cut
 }
 
@@ -573,14 +617,14 @@ func (tf *TextField) MimeData(md *mimedata.Mimes) {
 	*md = append(*md, mimedata.NewTextData(cpy))
 }
 
-// Copy copies any selected text to the clipboard.
+// Copy copies any selected text to the clipboard, unless obscured (NoEcho).
 // Satisfies Clipper interface -- can be extended in subtypes.
 // optionally resetting the current selection
 func (tf *TextField) Copy(reset bool) {
 	wupdt := tf.TopUpdateStart()
 	defer tf.TopUpdateEnd(wupdt)
 	tf.SelectUpdate()
-	if !tf.HasSelection() {
+	if !tf.HasSelection() || tf.IsObscured() {
 		return
 	}
 	md := mimedata.NewMimes(0, 1)
@@ -606,7 +650,8 @@ func (tf *TextField) Paste() {
 	}
 }
 
-// InsertAtCursor inserts given text at current cursor position
+// InsertAtCursor inserts given text at current cursor position -- the text
+// is filtered according to any Mask and MaxLen (see FilterInsert)
 func (tf *TextField) InsertAtCursor(str string) {
 	updt := tf.UpdateStart()
 	defer tf.UpdateEnd(updt)
@@ -615,6 +660,10 @@ func (tf *TextField) InsertAtCursor(str string) {
 	if tf.HasSelection() {
 		tf.Cut()
 	}
+	str = tf.FilterInsert(str)
+	if str == "" {
+		return
+	}
 	tf.Edited = true
 	rs := []rune(str)
 	rsl := len(rs)
@@ -624,6 +673,7 @@ func (tf *TextField) InsertAtCursor(str string) {
 	tf.EditTxt = nt
 	tf.EndPos += rsl
 	tf.CursorForward(rsl)
+	tf.RevalidateEdit()
 	tf.TextFieldSig.Emit(tf.This(), int64(TextFieldInsert), tf.EditTxt)
 }
 
@@ -634,7 +684,7 @@ func (tf *TextField) MakeContextMenu(m *Menu) {
 			tff := recv.Embed(KiT_TextField).(*TextField)
 			tff.This().(Clipper).Copy(true)
 		})
-	ac.SetActiveState(tf.HasSelection())
+	ac.SetActiveState(tf.HasSelection() && !tf.IsObscured())
 	if !tf.IsInactive() {
 		ctsc := ActiveKeyMap.ChordForFun(KeyFunCut)
 		ptsc := ActiveKeyMap.ChordForFun(KeyFunPaste)
@@ -1186,12 +1236,16 @@ func (tf *TextField) KeyInput(kt *key.ChordEvent) {
 		kt.SetProcessed()
 		tf.CancelComplete()
 		tf.EditDone()
-		tf.FocusNext()
+		if tf.ValidErr == nil { // stay to fix invalid text
+			tf.FocusNext()
+		}
 	case KeyFunFocusPrev:
 		kt.SetProcessed()
 		tf.CancelComplete()
 		tf.EditDone()
-		tf.FocusPrev()
+		if tf.ValidErr == nil { // stay to fix invalid text
+			tf.FocusPrev()
+		}
 	case KeyFunAbort: // esc
 		kt.SetProcessed()
 		tf.CancelComplete()
@@ -1345,25 +1399,43 @@ func (tf *TextField) TextFieldEvents() {
 
 func (tf *TextField) ConfigParts() {
 	tf.Parts.Lay = LayoutHoriz
-	if !tf.ClearAct || tf.IsInactive() {
+	clrAct := tf.ClearAct && !tf.IsInactive()
+	if !clrAct && !tf.NoEcho {
 		tf.Parts.DeleteChildren(ki.DestroyKids)
 		return
 	}
 	config := kit.TypeAndNameList{}
 	config.Add(KiT_Stretch, "clr-str")
-	config.Add(KiT_Action, "clear")
+	if tf.NoEcho {
+		config.Add(KiT_Action, "reveal")
+	}
+	if clrAct {
+		config.Add(KiT_Action, "clear")
+	}
 	mods, updt := tf.Parts.ConfigChildren(config, ki.NonUniqueNames)
 	if mods || RebuildDefaultStyles {
-		clr := tf.Parts.Child(1).(*Action)
-		tf.StylePart(Node2D(clr))
-		clr.SetIcon("close")
-		clr.SetProp("no-focus", true)
-		clr.ActionSig.ConnectOnly(tf.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
-			tff := recv.Embed(KiT_TextField).(*TextField)
-			if tff != nil {
-				tff.Clear()
-			}
-		})
+		if rv, ok := tf.Parts.ChildByName("reveal", 1).(*Action); ok {
+			tf.StylePart(Node2D(rv))
+			rv.SetProp("no-focus", true)
+			rv.ActionSig.ConnectOnly(tf.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+				tff := recv.Embed(KiT_TextField).(*TextField)
+				if tff != nil {
+					tff.SetRevealed(!tff.Revealed)
+				}
+			})
+			tf.SetRevealed(tf.Revealed)
+		}
+		if clr, ok := tf.Parts.ChildByName("clear", 1).(*Action); ok {
+			tf.StylePart(Node2D(clr))
+			clr.SetIcon("close")
+			clr.SetProp("no-focus", true)
+			clr.ActionSig.ConnectOnly(tf.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+				tff := recv.Embed(KiT_TextField).(*TextField)
+				if tff != nil {
+					tff.Clear()
+				}
+			})
+		}
 		tf.UpdateEnd(updt)
 	}
 }
@@ -1415,6 +1487,9 @@ func (tf *TextField) StyleTextField() {
 	if pv, ok := tf.PropInherit("clear-act", ki.Inherit, ki.TypeProps); ok {
 		tf.ClearAct, _ = kit.ToBool(pv)
 	}
+	if pv, ok := tf.PropInherit("invalid-color", ki.NoInherit, ki.TypeProps); ok {
+		tf.InvalidColor.SetIFace(pv, tf.Viewport, "invalid-color")
+	}
 	tf.StyMu.Unlock()
 	tf.ConfigParts()
 }
@@ -1429,7 +1504,7 @@ func (tf *TextField) Style2D() {
 func (tf *TextField) UpdateRenderAll() bool {
 	st := &tf.Sty
 	st.Font.OpenFont(&st.UnContext)
-	tf.RenderAll.SetRunes(tf.EditTxt, &st.Font, &st.UnContext, &st.Text, true, 0, 0)
+	tf.RenderAll.SetRunes(tf.DisplayRunes(tf.EditTxt), &st.Font, &st.UnContext, &st.Text, true, 0, 0)
 	return true
 }
 
@@ -1464,9 +1539,10 @@ func (tf *TextField) Layout2D(parBBox image.Rectangle, iter int) bool {
 	}
 	redo := tf.Layout2DChildren(iter)
 	sz := tf.LayState.Alloc.Size
-	if tf.ClearAct && len(*tf.Parts.Children()) == 2 {
-		clr := tf.Parts.Child(1).(*Action)
-		sz.X -= clr.LayState.Alloc.Size.X
+	for _, pk := range (*tf.Parts.Children())[1:] { // actions after clr-str stretch
+		if act, ok := pk.(*Action); ok {
+			sz.X -= act.LayState.Alloc.Size.X
+		}
 	}
 	tf.EffSize = sz
 	return redo
@@ -1495,9 +1571,12 @@ func (tf *TextField) RenderTextField() {
 		tf.Sty = tf.StateStyles[TextFieldActive]
 	}
 	st = &tf.Sty // update
+	if tf.ValidErr != nil && !tf.InvalidColor.IsNil() {
+		st.Border.Color = tf.InvalidColor
+	}
 	st.Font.OpenFont(&st.UnContext)
 	tf.RenderStdBox(st)
-	cur := tf.EditTxt[tf.StartPos:tf.EndPos]
+	cur := tf.DisplayRunes(tf.EditTxt[tf.StartPos:tf.EndPos])
 	tf.RenderSelect()
 	pos := tf.LayState.Alloc.Pos.AddScalar(st.BoxSpace())
 	if len(tf.EditTxt) == 0 && len(tf.Placeholder) > 0 {
@@ -1540,6 +1619,25 @@ func (tf *TextField) ConnectEvents2D() {
 	tf.TextFieldEvents()
 }
 
+// AccessInfo2D exposes the text being edited as the value, except in NoEcho
+// mode unless revealed, and the placeholder as the label
+func (tf *TextField) AccessInfo2D(ai *AccessInfo) {
+	tf.WidgetBase.AccessInfo2D(ai)
+	ai.Role = AccessTextField
+	ai.Label = tf.Placeholder
+	if tf.NoEcho {
+		ai.Role = AccessPasswordField
+	}
+	if !tf.NoEcho || tf.Revealed {
+		ai.Value = string(tf.EditTxt)
+	}
+	ai.SetState(!tf.IsInactive(), AccessEditable)
+	if tf.ValidErr != nil {
+		ai.SetState(true, AccessInvalid)
+		ai.Description = tf.ValidErr.Error()
+	}
+}
+
 func (tf *TextField) FocusChanged2D(change FocusChanges) {
 	switch change {
 	case FocusLost:
@@ -20,12 +20,13 @@ func _() {
 	_ = x[TextFieldInsert-4]
 	_ = x[TextFieldBackspace-5]
 	_ = x[TextFieldDelete-6]
-	_ = x[TextFieldSignalsN-7]
+	_ = x[TextFieldInvalid-7]
+	_ = x[TextFieldSignalsN-8]
 }
 
-const _TextFieldSignals_name = "TextFieldDoneTextFieldDeFocusedTextFieldSelectedTextFieldClearedTextFieldInsertTextFieldBackspaceTextFieldDeleteTextFieldSignalsN"
+const _TextFieldSignals_name = "TextFieldDoneTextFieldDeFocusedTextFieldSelectedTextFieldClearedTextFieldInsertTextFieldBackspaceTextFieldDeleteTextFieldInvalidTextFieldSignalsN"
 
-var _TextFieldSignals_index = [...]uint8{0, 13, 31, 48, 64, 79, 97, 112, 129}
+var _TextFieldSignals_index = [...]uint8{0, 13, 31, 48, 64, 79, 97, 112, 128, 145}
 
 func (i TextFieldSignals) String() string {
 	if i < 0 || i >= TextFieldSignals(len(_TextFieldSignals_index)-1) {
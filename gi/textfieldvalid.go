@@ -0,0 +1,410 @@
+// Copyright (c) 2018, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////
+// Validators
+
+// TextValidator validates the text entered into a TextField -- when the text
+// is not valid, the edit is not applied, and the field shows the error with
+// its invalid-color border and tooltip
+type TextValidator interface {
+	// ValidateText returns an error describing why the given text is not
+	// valid, or nil if it is valid
+	ValidateText(txt string) error
+}
+
+// TextValidatorFunc is a function that can be used as a TextValidator
+type TextValidatorFunc func(txt string) error
+
+// ValidateText calls the function
+func (fn TextValidatorFunc) ValidateText(txt string) error {
+	return fn(txt)
+}
+
+// TextValidators is a list of validators that all must pass, in order
+type TextValidators []TextValidator
+
+// ValidateText returns the error from the first validator that fails
+func (tv TextValidators) ValidateText(txt string) error {
+	for _, v := range tv {
+		if err := v.ValidateText(txt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Add adds given validator to the list, and returns the list -- nil
+// validators are ignored
+func (tv TextValidators) Add(v TextValidator) TextValidators {
+	if v == nil {
+		return tv
+	}
+	return append(tv, v)
+}
+
+// RangeValidator requires the text to be a number in the ActiveLocaleFormat
+// (an integer if Int is set), within the Min and/or Max range if HasMin / HasMax are set.  Empty text is
+// valid unless Required is set.
+type RangeValidator struct {
+	Min      float64 `desc:"minimum value, if HasMin"`
+	Max      float64 `desc:"maximum value, if HasMax"`
+	HasMin   bool    `desc:"enforce the Min value"`
+	HasMax   bool    `desc:"enforce the Max value"`
+	Int      bool    `desc:"the value must be an integer"`
+	Required bool    `desc:"empty text is not valid"`
+}
+
+// ValidateText satisfies the TextValidator interface
+func (rv *RangeValidator) ValidateText(txt string) error {
+	txt = strings.TrimSpace(txt)
+	if txt == "" {
+		if rv.Required {
+			return fmt.Errorf("a value is required")
+		}
+		return nil
+	}
+	lf := &ActiveLocaleFormat
+	var val float64
+	if rv.Int {
+		iv, err := lf.ParseInt(txt)
+		if err != nil {
+			return fmt.Errorf("%q is not an integer", txt)
+		}
+		val = float64(iv)
+	} else {
+		fv, err := lf.ParseFloat(txt)
+		if err != nil {
+			return fmt.Errorf("%q is not a number", txt)
+		}
+		val = fv
+	}
+	switch {
+	case rv.HasMin && rv.HasMax && (val < rv.Min || val > rv.Max):
+		return fmt.Errorf("must be between %v and %v", lf.FormatFloat(rv.Min, ""), lf.FormatFloat(rv.Max, ""))
+	case rv.HasMin && val < rv.Min:
+		return fmt.Errorf("must be at least %v", lf.FormatFloat(rv.Min, ""))
+	case rv.HasMax && val > rv.Max:
+		return fmt.Errorf("must be at most %v", lf.FormatFloat(rv.Max, ""))
+	}
+	return nil
+}
+
+// RegexpValidator requires the entire text to match the regular expression.
+// Empty text is always valid -- use TextField.MinLen to require a value.
+type RegexpValidator struct {
+	Regexp *regexp.Regexp `desc:"the regular expression, which must match the entire text"`
+	Msg    string         `desc:"error message if the text does not match -- a default message showing the expression is used if empty"`
+}
+
+// NewRegexpValidator returns a new validator for given regular expression,
+// which is anchored to match the entire text, with given error message
+// (can be empty)
+func NewRegexpValidator(expr, msg string) (*RegexpValidator, error) {
+	re, err := regexp.Compile(`^(?:` + expr + `)$`)
+	if err != nil {
+		return nil, err
+	}
+	return &RegexpValidator{Regexp: re, Msg: msg}, nil
+}
+
+// ValidateText satisfies the TextValidator interface
+func (rv *RegexpValidator) ValidateText(txt string) error {
+	if txt == "" || rv.Regexp == nil || rv.Regexp.MatchString(txt) {
+		return nil
+	}
+	if rv.Msg != "" {
+		return fmt.Errorf("%s", rv.Msg)
+	}
+	return fmt.Errorf("must match the pattern %v", rv.Regexp)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////
+// Masks
+
+// TextFieldMasks are named format masks that can be used for TextField.Mask
+// in place of the mask itself -- see TextFieldMask for the mask syntax
+var TextFieldMasks = map[string]string{
+	"date":     "9999-99-99",
+	"time":     "99:99",
+	"datetime": "9999-99-99 99:99",
+	"phone":    "(999) 999-9999",
+	"ipv4":     "##9.##9.##9.##9",
+}
+
+// TextFieldMask returns the format mask for given mask name or mask -- a mask
+// is a string where the following characters match classes of input
+// characters, and all others must be entered literally (and are inserted
+// automatically when typing):
+// 9 = digit, # = optional digit, A = letter, a = optional letter,
+// * = letter or digit, and \ escapes the next character as a literal.
+func TextFieldMask(mask string) string {
+	if nm, ok := TextFieldMasks[mask]; ok {
+		return nm
+	}
+	return mask
+}
+
+// maskItem is one element of a parsed mask
+type maskItem struct {
+	Class rune // 0 for literal
+	Lit   rune // literal value
+	Opt   bool // optional
+}
+
+// Matches returns true if given rune matches the item
+func (mi *maskItem) Matches(r rune) bool {
+	switch mi.Class {
+	case '9':
+		return unicode.IsDigit(r)
+	case 'A':
+		return unicode.IsLetter(r)
+	case '*':
+		return unicode.IsLetter(r) || unicode.IsDigit(r)
+	}
+	return r == mi.Lit
+}
+
+// parseMask parses the mask into items
+func parseMask(mask string) []maskItem {
+	rs := []rune(TextFieldMask(mask))
+	its := make([]maskItem, 0, len(rs))
+	for i := 0; i < len(rs); i++ {
+		r := rs[i]
+		switch r {
+		case '9', 'A', '*':
+			its = append(its, maskItem{Class: r})
+		case '#':
+			its = append(its, maskItem{Class: '9', Opt: true})
+		case 'a':
+			its = append(its, maskItem{Class: 'A', Opt: true})
+		case '\\':
+			if i+1 < len(rs) {
+				i++
+				its = append(its, maskItem{Lit: rs[i]})
+			}
+		default:
+			its = append(its, maskItem{Lit: r})
+		}
+	}
+	return its
+}
+
+// matchMask returns true if the text starting at tj matches the mask items
+// starting at mi -- if prefix is true, the text only needs to match up to its
+// end, otherwise all remaining mask items must be optional
+func matchMask(its []maskItem, mi int, txt []rune, tj int, prefix bool) bool {
+	if tj == len(txt) {
+		if prefix {
+			return true
+		}
+		for ; mi < len(its); mi++ {
+			if !its[mi].Opt {
+				return false
+			}
+		}
+		return true
+	}
+	if mi == len(its) {
+		return false
+	}
+	it := &its[mi]
+	if it.Opt && matchMask(its, mi+1, txt, tj, prefix) {
+		return true
+	}
+	if it.Matches(txt[tj]) {
+		return matchMask(its, mi+1, txt, tj+1, prefix)
+	}
+	return false
+}
+
+// maskLiterals returns the distinct runs of literal characters in the mask
+func maskLiterals(its []maskItem) []string {
+	var lits []string
+	cur := ""
+	for i := range its {
+		if its[i].Class == 0 {
+			cur += string(its[i].Lit)
+			continue
+		}
+		if cur != "" {
+			lits = append(lits, cur)
+			cur = ""
+		}
+	}
+	if cur != "" {
+		lits = append(lits, cur)
+	}
+	return lits
+}
+
+// MaskMatches returns true if the text fully matches the given mask (or mask name)
+func MaskMatches(mask, txt string) bool {
+	return matchMask(parseMask(mask), 0, []rune(txt), 0, false)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////
+// TextField validation
+
+// IsObscured returns true if the text is currently being displayed as echo
+// characters, in NoEcho mode without being revealed
+func (tf *TextField) IsObscured() bool {
+	return tf.NoEcho && !tf.Revealed
+}
+
+// DisplayRunes returns the runes to display for given text runes --
+// replaced with the echo character if obscured
+func (tf *TextField) DisplayRunes(rs []rune) []rune {
+	if !tf.IsObscured() {
+		return rs
+	}
+	ec := tf.EchoChar
+	if ec == 0 {
+		ec = '•'
+	}
+	ds := make([]rune, len(rs))
+	for i := range ds {
+		ds[i] = ec
+	}
+	return ds
+}
+
+// SetRevealed sets whether the text is revealed in NoEcho mode, and updates
+// the reveal action icon
+func (tf *TextField) SetRevealed(rev bool) {
+	updt := tf.UpdateStart()
+	tf.Revealed = rev
+	if rv, ok := tf.Parts.ChildByName("reveal", 1).(*Action); ok {
+		if rev {
+			rv.SetIcon("eye-slash")
+			rv.Tooltip = "hide the text"
+		} else {
+			rv.SetIcon("eye")
+			rv.Tooltip = "show the text"
+		}
+	}
+	tf.SetFullReRender()
+	tf.UpdateEnd(updt)
+}
+
+// Validate returns an error if the given text is not valid according to the
+// MinLen, MaxLen, Mask and Validator constraints, or nil if it is valid
+func (tf *TextField) Validate(txt string) error {
+	n := len([]rune(txt))
+	if tf.MinLen > 0 && n < tf.MinLen {
+		if tf.MinLen == 1 {
+			return fmt.Errorf("a value is required")
+		}
+		return fmt.Errorf("must be at least %d characters", tf.MinLen)
+	}
+	if tf.MaxLen > 0 && n > tf.MaxLen {
+		return fmt.Errorf("must be at most %d characters", tf.MaxLen)
+	}
+	if tf.Mask != "" && n > 0 && !MaskMatches(tf.Mask, txt) {
+		return fmt.Errorf("must have the format %s", TextFieldMask(tf.Mask))
+	}
+	if tf.Validator != nil {
+		return tf.Validator.ValidateText(txt)
+	}
+	return nil
+}
+
+// IsValid returns true if the current edit text is valid
+func (tf *TextField) IsValid() bool {
+	return tf.Validate(string(tf.EditTxt)) == nil
+}
+
+// SetValidErr sets the current validation error, which is shown as the
+// tooltip in place of the regular one, and with the invalid-color border,
+// until it is set back to nil
+func (tf *TextField) SetValidErr(err error) {
+	if err == nil && tf.ValidErr == nil {
+		return
+	}
+	if err != nil {
+		if tf.ValidErr == nil {
+			tf.validTip = tf.Tooltip
+		}
+		tf.Tooltip = err.Error()
+	} else {
+		tf.Tooltip = tf.validTip
+	}
+	tf.ValidErr = err
+	tf.UpdateSig()
+}
+
+// ValidateEdit validates the current edit text, setting ValidErr and emitting
+// the TextFieldInvalid signal if invalid -- returns true if valid
+func (tf *TextField) ValidateEdit() bool {
+	err := tf.Validate(string(tf.EditTxt))
+	tf.SetValidErr(err)
+	if err != nil {
+		tf.TextFieldSig.Emit(tf.This(), int64(TextFieldInvalid), err)
+		return false
+	}
+	return true
+}
+
+// RevalidateEdit re-checks the edit text after a change, if it was previously
+// invalid, so the error is cleared as soon as it is fixed
+func (tf *TextField) RevalidateEdit() {
+	if tf.ValidErr != nil {
+		tf.SetValidErr(tf.Validate(string(tf.EditTxt)))
+	}
+}
+
+// FilterInsert returns the text to actually insert at the cursor for given
+// inserted text, according to the Mask and MaxLen: characters that do not fit
+// the mask are dropped, and literal mask characters are inserted as needed
+func (tf *TextField) FilterInsert(str string) string {
+	if tf.Mask == "" && tf.MaxLen <= 0 {
+		return str
+	}
+	var its []maskItem
+	var lits []string
+	if tf.Mask != "" {
+		its = parseMask(tf.Mask)
+		lits = maskLiterals(its)
+	}
+	pre := tf.EditTxt[:tf.CursorPos]
+	post := tf.EditTxt[tf.CursorPos:]
+	var out []rune
+	for _, r := range str {
+		if tf.MaxLen > 0 && len(tf.EditTxt)+len(out) >= tf.MaxLen {
+			break
+		}
+		if its == nil {
+			out = append(out, r)
+			continue
+		}
+		cands := [][]rune{{r}}
+		for _, l := range lits {
+			cands = append(cands, append([]rune(l), r))
+		}
+		for _, c := range cands {
+			if tf.MaxLen > 0 && len(tf.EditTxt)+len(out)+len(c) > tf.MaxLen {
+				continue
+			}
+			test := make([]rune, 0, len(pre)+len(out)+len(c)+len(post))
+			test = append(test, pre...)
+			test = append(test, out...)
+			test = append(test, c...)
+			test = append(test, post...)
+			if matchMask(its, 0, test, 0, true) {
+				out = append(out, c...)
+				break
+			}
+		}
+	}
+	return string(out)
+}
@@ -0,0 +1,182 @@
+// Copyright (c) 2018, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestParseMask(t *testing.T) {
+	tests := []struct {
+		mask string
+		its  []maskItem
+		lits []string
+	}{
+		{"9#", []maskItem{{Class: '9'}, {Class: '9', Opt: true}}, nil},
+		{"Aa*", []maskItem{{Class: 'A'}, {Class: 'A', Opt: true}, {Class: '*'}}, nil},
+		{`\9-9`, []maskItem{{Lit: '9'}, {Lit: '-'}, {Class: '9'}}, []string{"9-"}},
+		{`9\`, []maskItem{{Class: '9'}}, nil}, // trailing escape is dropped
+		{"time", []maskItem{{Class: '9'}, {Class: '9'}, {Lit: ':'}, {Class: '9'}, {Class: '9'}}, []string{":"}},
+		{"phone", nil, []string{"(", ") ", "-"}},
+		{"é9", []maskItem{{Lit: 'é'}, {Class: '9'}}, []string{"é"}},
+	}
+	for _, tt := range tests {
+		its := parseMask(tt.mask)
+		if tt.its != nil && !reflect.DeepEqual(its, tt.its) {
+			t.Errorf("parseMask(%q) = %v, want %v", tt.mask, its, tt.its)
+		}
+		if lits := maskLiterals(its); !reflect.DeepEqual(lits, tt.lits) {
+			t.Errorf("maskLiterals(%q) = %q, want %q", tt.mask, lits, tt.lits)
+		}
+	}
+	if m := TextFieldMask("date"); m != "9999-99-99" {
+		t.Errorf("TextFieldMask(date) = %q", m)
+	}
+	if m := TextFieldMask("99/99"); m != "99/99" {
+		t.Errorf("TextFieldMask(99/99) = %q", m)
+	}
+}
+
+func TestMaskMatches(t *testing.T) {
+	tests := []struct {
+		mask, txt string
+		full, pre bool
+	}{
+		{"date", "2024-01-31", true, true},
+		{"date", "2024-01", false, true},
+		{"date", "2024-1-31", false, false},
+		{"date", "", false, true},
+		{"time", "12:3x", false, false},
+		{"phone", "(555) 123-4567", true, true},
+		{"phone", "555-123-4567", false, false},
+		{"ipv4", "192.168.0.1", true, true},
+		{"ipv4", "1.2.3.4", true, true},
+		{"ipv4", "1234.1.1.1", false, false},
+		{"ipv4", "1..1.1", false, false},
+		{"A9", "b7", true, true},
+		{"A9", "77", false, false},
+		{"a9", "5", true, true},
+		{"*9", "é5", true, true},
+		{"9", "٣", true, true}, // non-ascii digit
+		{`\9`, "9", true, true},
+		{`\9`, "5", false, false},
+	}
+	for _, tt := range tests {
+		if full := MaskMatches(tt.mask, tt.txt); full != tt.full {
+			t.Errorf("MaskMatches(%q, %q) = %v, want %v", tt.mask, tt.txt, full, tt.full)
+		}
+		if pre := matchMask(parseMask(tt.mask), 0, []rune(tt.txt), 0, true); pre != tt.pre {
+			t.Errorf("prefix matchMask(%q, %q) = %v, want %v", tt.mask, tt.txt, pre, tt.pre)
+		}
+	}
+}
+
+func TestTextFieldFilterInsert(t *testing.T) {
+	tests := []struct {
+		mask   string
+		maxLen int
+		txt    string
+		cur    int
+		ins    string
+		out    string
+	}{
+		{"date", 0, "", 0, "20240131", "2024-01-31"},
+		{"date", 0, "2024", 4, "01", "-01"},
+		{"date", 0, "", 0, "2024x01", "2024-01"},
+		{"date", 0, "2024-01-31", 10, "5", ""},
+		{"phone", 0, "", 0, "5551234567", "(555) 123-4567"},
+		{"ipv4", 0, "", 0, "10.0.0.1", "10.0.0.1"},
+		{"A9", 0, "", 0, "7b", "b"},
+		{"", 3, "", 0, "abcdef", "abc"},
+		{"", 3, "ab", 2, "éü", "é"},
+		{"99", 0, "5", 0, "4", "4"}, // insert before existing text
+		{"99", 0, "5", 0, "x", ""},
+	}
+	for _, tt := range tests {
+		tf := &TextField{Mask: tt.mask, MaxLen: tt.maxLen}
+		tf.EditTxt = []rune(tt.txt)
+		tf.CursorPos = tt.cur
+		if out := tf.FilterInsert(tt.ins); out != tt.out {
+			t.Errorf("mask %q max %v: insert %q into %q at %v = %q, want %q", tt.mask, tt.maxLen, tt.ins, tt.txt, tt.cur, out, tt.out)
+		}
+	}
+}
+
+func TestTextFieldValidate(t *testing.T) {
+	svlf := ActiveLocaleFormat
+	defer func() { ActiveLocaleFormat = svlf }()
+	ActiveLocaleFormat = StdLocaleFormat("C")
+
+	rng := &RangeValidator{Min: 0, Max: 10, HasMin: true, HasMax: true}
+	re, err := NewRegexpValidator("[a-z]+", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	odd := TextValidatorFunc(func(txt string) error {
+		if len(txt)%2 == 0 {
+			return fmt.Errorf("even")
+		}
+		return nil
+	})
+	tests := []struct {
+		tf  *TextField
+		txt string
+		ok  bool
+	}{
+		{&TextField{MinLen: 1}, "", false},
+		{&TextField{MinLen: 3}, "ab", false},
+		{&TextField{MinLen: 3}, "abc", true},
+		{&TextField{MaxLen: 2}, "abc", false},
+		{&TextField{MaxLen: 2}, "éü", true}, // counts runes
+		{&TextField{Mask: "date"}, "", true},
+		{&TextField{Mask: "date"}, "2024-01", false},
+		{&TextField{Mask: "date"}, "2024-01-31", true},
+		{&TextField{Validator: rng}, "5", true},
+		{&TextField{Validator: rng}, "11", false},
+		{&TextField{Validator: rng}, "x", false},
+		{&TextField{Validator: re}, "abc", true},
+		{&TextField{Validator: re}, "abc1", false}, // anchored
+		{&TextField{Validator: TextValidators{}.Add(re).Add(nil).Add(odd)}, "abc", true},
+		{&TextField{Validator: TextValidators{}.Add(re).Add(odd)}, "ab", false},
+		{&TextField{Mask: "99", Validator: rng}, "12", false},
+	}
+	for i, tt := range tests {
+		if err := tt.tf.Validate(tt.txt); (err == nil) != tt.ok {
+			t.Errorf("%d: Validate(%q) = %v, want ok %v", i, tt.txt, err, tt.ok)
+		}
+	}
+}
+
+func TestRangeValidator(t *testing.T) {
+	svlf := ActiveLocaleFormat
+	defer func() { ActiveLocaleFormat = svlf }()
+
+	tests := []struct {
+		loc string
+		rv  RangeValidator
+		txt string
+		ok  bool
+	}{
+		{"C", RangeValidator{}, "", true},
+		{"C", RangeValidator{Required: true}, " ", false},
+		{"C", RangeValidator{}, "abc", false},
+		{"C", RangeValidator{Int: true}, "1.5", false},
+		{"C", RangeValidator{Int: true}, "-3", true},
+		{"C", RangeValidator{Min: 1, HasMin: true}, "0.5", false},
+		{"C", RangeValidator{Max: 1, HasMax: true}, "1", true},
+		{"C", RangeValidator{Max: 1, HasMax: true}, "1.01", false},
+		{"de", RangeValidator{Max: 2000, HasMax: true}, "1.234,5", true},
+		{"de", RangeValidator{Max: 1000, HasMax: true}, "1.234,5", false},
+		{"de", RangeValidator{Int: true}, "1.234", true},
+	}
+	for _, tt := range tests {
+		ActiveLocaleFormat = StdLocaleFormat(tt.loc)
+		if err := tt.rv.ValidateText(tt.txt); (err == nil) != tt.ok {
+			t.Errorf("%v %+v ValidateText(%q) = %v, want ok %v", tt.loc, tt.rv, tt.txt, err, tt.ok)
+		}
+	}
+}
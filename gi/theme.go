@@ -0,0 +1,728 @@
+// Copyright (c) 2018, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"encoding/json"
+	"fmt"
+	"image/color"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aymerick/douceur/css"
+	"github.com/aymerick/douceur/parser"
+	"github.com/goki/gi/units"
+	"github.com/goki/ki/ki"
+	"github.com/goki/ki/kit"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////
+// Theme
+
+// ThemeName is the name of a theme, selected from the AvailThemes
+type ThemeName string
+
+// Theme bundles the colors, fonts, spacing, corner radii and widget style
+// overrides that determine the overall appearance of the GUI.  Themes are
+// selected in the Preferences (Theme), and can be loaded from JSON or CSS
+// files (see OpenTheme) -- apps can ship their own themes with AddTheme.
+//
+// In CSS form, the :root rule sets the colors by their lowercase names
+// (e.g., --control: #F8F8F8), the --font-family, --mono-font, --density,
+// --radius and --font-size-<keyword> (e.g., --font-size-medium: 13)
+// parameters, and any other --name tokens.  All other rules are style
+// overrides for widget types, e.g., button { border-radius: 8px }, with
+// states and parts as in button:hover and button #icon.  Style values can
+// refer to the colors and tokens as var(--name).
+type Theme struct {
+	Name      string             `width:"20" desc:"name of the theme"`
+	Desc      string             `desc:"description of the theme"`
+	Colors    ColorPrefs         `tableview:"-" desc:"colors used in the default styles of all widgets -- replace the Preferences Colors when the theme is chosen"`
+	Tokens    map[string]string  `tableview:"-" desc:"additional named values, e.g., colors or sizes, that can be used in the Styles as var(--name) -- the Colors can be used by their lowercase names, e.g., var(--control)"`
+	Font      FontName           `tableview:"-" desc:"default font family -- blank keeps the Preferences FontFamily"`
+	MonoFont  FontName           `tableview:"-" desc:"default mono-spaced font family -- blank keeps the Preferences MonoFont"`
+	FontSizes map[string]float32 `tableview:"-" desc:"point sizes of the font-size keywords (small, medium, large etc), overriding the standard FontSizePoints -- medium is the default size of text"`
+	Density   float32            `tableview:"-" min:"0" step:"0.1" desc:"scaling factor for the padding, margin and spacing of all widgets -- 1 (or 0) is the standard spacing, smaller values are more compact"`
+	Radius    string             `tableview:"-" desc:"corner radius for all widgets with rounded corners, e.g., 8px or 0 for square corners -- blank keeps the radius of each type of widget"`
+	Styles    ki.Props           `tableview:"-" desc:"style overrides for widget types, by lowercase type name, e.g., button, each with style properties and sub-selectors for states and parts, e.g., :hover and #icon -- these are applied to the default styles of the types"`
+	Filename  FileName           `tableview:"-" json:"-" xml:"-" desc:"file the theme was loaded from, if any -- it is reloaded automatically when the file changes while the theme is active"`
+	modTime   time.Time          `view:"-"`
+}
+
+var KiT_Theme = kit.Types.AddType(&Theme{}, nil)
+
+// NewTheme returns a new theme with given name and description, and the
+// default light Colors
+func NewTheme(name, desc string) *Theme {
+	th := &Theme{Name: name, Desc: desc}
+	th.Colors.Defaults()
+	return th
+}
+
+// Label satisfies the Labeler interface
+func (th *Theme) Label() string {
+	return th.Name
+}
+
+// OpenJSON opens the theme from a JSON-formatted file -- any colors not
+// given in the file are the default light colors
+func (th *Theme) OpenJSON(filename FileName) error {
+	b, err := ioutil.ReadFile(string(filename))
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	*th = Theme{}
+	th.Colors.Defaults()
+	err = json.Unmarshal(b, th)
+	if err != nil {
+		return fmt.Errorf("gi.Theme: %v: %v", filename, err)
+	}
+	th.Filename = filename
+	return nil
+}
+
+// SaveJSON saves the theme to a JSON-formatted file.
+func (th *Theme) SaveJSON(filename FileName) error {
+	b, err := json.MarshalIndent(th, "", "  ")
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	err = ioutil.WriteFile(string(filename), b, 0644)
+	if err != nil {
+		PromptDialog(nil, DlgOpts{Title: "Could not Save to File", Prompt: err.Error()}, AddOk, NoCancel, nil, nil)
+		log.Println(err)
+	}
+	return err
+}
+
+// ReadCSS reads the theme from a style sheet in the CSS form described for
+// Theme -- the theme keeps any values that are not set in the style sheet
+func (th *Theme) ReadCSS(str string) error {
+	ss, err := parser.Parse(str)
+	if err != nil {
+		return fmt.Errorf("gi.Theme: CSS parse error: %v", err)
+	}
+	var errs []string
+	for _, r := range ss.Rules {
+		if r.Kind == css.AtRule || len(r.Declarations) == 0 {
+			continue
+		}
+		for _, sel := range r.Selectors {
+			sel = strings.TrimSpace(sel)
+			if sel == ":root" {
+				for _, de := range r.Declarations {
+					if err := th.SetParam(strings.TrimPrefix(de.Property, "--"), de.Value); err != nil {
+						errs = append(errs, err.Error())
+					}
+				}
+				continue
+			}
+			sp, err := th.selectorProps(sel)
+			if err != nil {
+				errs = append(errs, err.Error())
+				continue
+			}
+			for _, de := range r.Declarations {
+				sp[de.Property] = de.Value
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("gi.Theme: %v", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// OpenCSS opens the theme from a CSS style sheet file -- see ReadCSS
+func (th *Theme) OpenCSS(filename FileName) error {
+	b, err := ioutil.ReadFile(string(filename))
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	*th = Theme{}
+	th.Colors.Defaults()
+	th.Name = strings.TrimSuffix(filepath.Base(string(filename)), filepath.Ext(string(filename)))
+	th.Filename = filename
+	err = th.ReadCSS(string(b))
+	if err != nil {
+		return fmt.Errorf("%v: %v", filename, err)
+	}
+	return nil
+}
+
+// themeColorNames are the lowercase names of the Colors, which can be used
+// as tokens
+var themeColorNames = []string{"font", "background", "shadow", "border", "control", "icon", "select", "highlight", "link"}
+
+// SetParam sets a theme parameter from a CSS :root rule, by name without the
+// -- prefix: a color, font-family, mono-font, density, radius, histyle,
+// name, desc, font-size-<keyword>, or else a token
+func (th *Theme) SetParam(name, val string) error {
+	val = strings.Trim(strings.TrimSpace(val), "\"'")
+	switch {
+	case name == "name":
+		th.Name = val
+	case name == "desc":
+		th.Desc = val
+	case name == "font-family":
+		th.Font = FontName(val)
+	case name == "mono-font":
+		th.MonoFont = FontName(val)
+	case name == "histyle":
+		th.Colors.HiStyle = HiStyleName(val)
+	case name == "radius":
+		th.Radius = val
+	case name == "density":
+		d, err := strconv.ParseFloat(val, 32)
+		if err != nil {
+			return fmt.Errorf("invalid density: %v", val)
+		}
+		th.Density = float32(d)
+	case strings.HasPrefix(name, "font-size-"):
+		sz, err := strconv.ParseFloat(strings.TrimSuffix(val, "pt"), 32)
+		if err != nil {
+			return fmt.Errorf("invalid %v: %v", name, val)
+		}
+		if th.FontSizes == nil {
+			th.FontSizes = map[string]float32{}
+		}
+		th.FontSizes[strings.TrimPrefix(name, "font-size-")] = float32(sz)
+	default:
+		for _, cn := range themeColorNames {
+			if name != cn {
+				continue
+			}
+			clr := th.Colors.PrefColor(cn)
+			var base color.Color
+			switch cn {
+			case "shadow":
+				base = &th.Colors.Background
+			case "icon":
+				base = th.Colors.Control
+			}
+			return clr.SetString(val, base)
+		}
+		if th.Tokens == nil {
+			th.Tokens = map[string]string{}
+		}
+		th.Tokens[name] = val
+	}
+	return nil
+}
+
+// selectorProps returns the Styles props for given CSS selector, e.g.,
+// button, button:hover, button #icon or button.primary, creating them if
+// needed
+func (th *Theme) selectorProps(sel string) (ki.Props, error) {
+	flds := strings.Fields(strings.Replace(sel, ">", " ", -1))
+	if len(flds) == 0 || len(flds) > 2 {
+		return nil, fmt.Errorf("unsupported selector: %v", sel)
+	}
+	tnm := flds[0]
+	var subs []string
+	if ci := strings.IndexAny(tnm, ":."); ci > 0 {
+		subs = append(subs, tnm[ci:])
+		tnm = tnm[:ci]
+	} else if ci == 0 {
+		return nil, fmt.Errorf("selector must start with a widget type: %v", sel)
+	}
+	if len(flds) == 2 {
+		pnm := flds[1]
+		if !strings.HasPrefix(pnm, "#") {
+			return nil, fmt.Errorf("only #part sub-selectors are supported: %v", sel)
+		}
+		subs = append(subs, pnm)
+	}
+	if th.Styles == nil {
+		th.Styles = ki.Props{}
+	}
+	sp := th.Styles
+	for _, key := range append([]string{strings.ToLower(tnm)}, subs...) {
+		np, ok := sp[key].(ki.Props)
+		if !ok {
+			np = ki.Props{}
+			sp[key] = np
+		}
+		sp = np
+	}
+	return sp, nil
+}
+
+// TokenValue returns the value of given token for use in styles: the
+// Preferences color for the name of one of the Colors (so that it follows
+// any changes), otherwise the value in Tokens
+func (th *Theme) TokenValue(name string) (interface{}, bool) {
+	for _, cn := range themeColorNames {
+		if name == cn {
+			return Prefs.Colors.PrefColor(cn), true
+		}
+	}
+	tv, ok := th.Tokens[name]
+	return tv, ok
+}
+
+// resolve resolves var(--name) token references in given style value
+func (th *Theme) resolve(val interface{}) interface{} {
+	s, ok := val.(string)
+	if !ok {
+		return val
+	}
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "var(--") || !strings.HasSuffix(s, ")") {
+		return val
+	}
+	nm := s[6 : len(s)-1]
+	if tv, ok := th.TokenValue(nm); ok {
+		return tv
+	}
+	log.Printf("gi.Theme: %v: token %v not found\n", th.Name, nm)
+	return val
+}
+
+// OpenTheme opens a theme from given .json or .css file
+func OpenTheme(filename string) (*Theme, error) {
+	th := &Theme{}
+	var err error
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".css":
+		err = th.OpenCSS(FileName(filename))
+	default:
+		err = th.OpenJSON(FileName(filename))
+	}
+	if err != nil {
+		return nil, err
+	}
+	if th.Name == "" {
+		th.Name = strings.TrimSuffix(filepath.Base(filename), filepath.Ext(filename))
+	}
+	if fi, err := os.Stat(filename); err == nil {
+		th.modTime = fi.ModTime()
+	}
+	return th, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////
+// Themes
+
+// Themes is a list of themes
+type Themes []*Theme
+
+var KiT_Themes = kit.Types.AddType(&Themes{}, nil)
+
+// ThemeByName returns a theme and its index by name -- false if not found
+func (ts *Themes) ThemeByName(name ThemeName) (*Theme, int, bool) {
+	for i, th := range *ts {
+		if th.Name == string(name) {
+			return th, i, true
+		}
+	}
+	return nil, -1, false
+}
+
+// Add adds given theme, replacing any existing one of the same name
+func (ts *Themes) Add(th *Theme) {
+	if _, i, ok := ts.ThemeByName(ThemeName(th.Name)); ok {
+		(*ts)[i] = th
+		return
+	}
+	*ts = append(*ts, th)
+}
+
+// StdThemes are the standard themes that are always available
+var StdThemes = Themes{}
+
+// AvailThemes are the themes available for selection in the Preferences:
+// the StdThemes, those added by the app with AddTheme, and those loaded
+// from the ThemesDirName directory in the GoGi prefs directory
+var AvailThemes = Themes{}
+
+// ThemesDirName is the name of the directory in the GoGi prefs directory
+// from which user themes are loaded at startup
+var ThemesDirName = "themes"
+
+// AddTheme adds given theme to the AvailThemes, replacing any of the same
+// name -- this is how apps ship their own themes, typically in an init
+// function, e.g., gi.AddTheme(th) with a theme from OpenTheme or NewTheme.
+func AddTheme(th *Theme) {
+	themeMu.Lock()
+	AvailThemes.Add(th)
+	themeMu.Unlock()
+}
+
+// LoadThemesDir loads all the .json and .css theme files in given directory
+// into the AvailThemes
+func LoadThemesDir(dir string) error {
+	fis, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	var errs []string
+	for _, fi := range fis {
+		ext := strings.ToLower(filepath.Ext(fi.Name()))
+		if fi.IsDir() || (ext != ".json" && ext != ".css") {
+			continue
+		}
+		th, err := OpenTheme(filepath.Join(dir, fi.Name()))
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		AddTheme(th)
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("gi.LoadThemesDir: %v", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+func init() {
+	lt := NewTheme("Light", "standard light colors")
+	dt := NewTheme("Dark", "standard dark colors")
+	dt.Colors.DarkDefaults()
+	ct := NewTheme("Compact", "light colors with less spacing and squarer corners, to fit more on the screen")
+	ct.Density = 0.6
+	ct.Radius = "2px"
+	ct.FontSizes = map[string]float32{"small": 9, "medium": 11, "large": 13}
+	hc := NewTheme("High Contrast", "black on white, with thicker borders and larger text")
+	hc.Colors.Border.SetColor(color.Black)
+	hc.Colors.Control.SetColor(color.White)
+	hc.Colors.Icon.SetColor(color.Black)
+	hc.Colors.Select.SetString("#FF0", nil)
+	hc.Colors.Highlight.SetString("#0FF", nil)
+	hc.Colors.Link.SetString("#00C", nil)
+	hc.FontSizes = map[string]float32{"small": 12, "medium": 14, "large": 16, "x-large": 20}
+	hc.Styles = ki.Props{
+		"button": ki.Props{
+			"border-width": "2px",
+			":hover": ki.Props{
+				"background-color": "var(--highlight)",
+			},
+			":focus": ki.Props{
+				"border-width": "3px",
+			},
+		},
+		"textfield": ki.Props{
+			"border-width": "2px",
+		},
+	}
+	StdThemes = Themes{lt, dt, ct, hc}
+	AvailThemes = append(Themes{}, StdThemes...)
+	for k, v := range FontSizePoints {
+		stdFontSizePoints[k] = v
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////
+// Applying
+
+var (
+	// themeMu protects the ActiveTheme and AvailThemes, which are updated by
+	// the hot reloading
+	themeMu sync.Mutex
+
+	// ActiveTheme is the theme currently applied -- nil if none
+	ActiveTheme *Theme
+
+	// themeApplied is set after the first ApplyTheme at startup
+	themeApplied bool
+
+	// themeOrigs are the original values of the type properties changed by
+	// the active theme
+	themeOrigs []themeOrig
+
+	// stdFontSizePoints are the standard FontSizePoints
+	stdFontSizePoints = map[string]float32{}
+)
+
+// themeOrig records the original value of a type property
+type themeOrig struct {
+	props ki.Props
+	key   string
+	val   interface{}
+	had   bool
+}
+
+// ThemeWatchMSec is the interval in milliseconds for checking whether the
+// file of the ActiveTheme has changed, to reload it -- 0 turns off reloading
+var ThemeWatchMSec = 1000
+
+// themeWatching is set once the goroutine checking for changes is started
+var themeWatching bool
+
+// Apply applies the theme, replacing any previous one -- if colors is true
+// the Colors replace the Preferences Colors (they are kept at startup, so
+// that any edits of the colors in the preferences are not lost).  Call
+// Prefs.UpdateAll to update the open windows.
+func (th *Theme) Apply(colors bool) {
+	themeMu.Lock()
+	defer themeMu.Unlock()
+	themeRestore()
+	if colors {
+		Prefs.Colors = th.Colors
+	}
+	if th.Font != "" {
+		Prefs.FontFamily = th.Font
+	}
+	if th.MonoFont != "" {
+		Prefs.MonoFont = th.MonoFont
+	}
+	for k, v := range th.FontSizes {
+		FontSizePoints[k] = v
+	}
+	th.applyTypeProps()
+	ActiveTheme = th
+	if th.Filename != "" && ThemeWatchMSec > 0 && !themeWatching {
+		themeWatching = true
+		go themeWatch()
+	}
+}
+
+// RemoveTheme removes the ActiveTheme, restoring the standard styles --
+// call Prefs.UpdateAll to update the open windows
+func RemoveTheme() {
+	themeMu.Lock()
+	themeRestore()
+	ActiveTheme = nil
+	themeMu.Unlock()
+}
+
+// themeRestore restores the type properties and font sizes changed by the
+// active theme -- must be called under themeMu
+func themeRestore() {
+	kit.TypesMu.Lock()
+	for i := len(themeOrigs) - 1; i >= 0; i-- {
+		to := themeOrigs[i]
+		if to.had {
+			to.props[to.key] = to.val
+		} else {
+			delete(to.props, to.key)
+		}
+	}
+	kit.TypesMu.Unlock()
+	themeOrigs = nil
+	for k := range FontSizePoints {
+		if _, ok := stdFontSizePoints[k]; !ok {
+			delete(FontSizePoints, k)
+		}
+	}
+	for k, v := range stdFontSizePoints {
+		FontSizePoints[k] = v
+	}
+}
+
+// themeSet sets a type property, recording its original value
+func themeSet(p ki.Props, key string, val interface{}) {
+	ov, had := p[key]
+	themeOrigs = append(themeOrigs, themeOrig{p, key, ov, had})
+	p[key] = val
+}
+
+// ThemeWidgetType returns the widget type for given lowercase type name as
+// used in the theme Styles, e.g., button -- types in package gi are
+// preferred over others of the same name
+func ThemeWidgetType(name string) reflect.Type {
+	var found reflect.Type
+	for _, typ := range kit.Types.Types {
+		if strings.ToLower(typ.Name()) != name || !themeIsWidget(typ) {
+			continue
+		}
+		if typ.PkgPath() == KiT_WidgetBase.PkgPath() {
+			return typ
+		}
+		found = typ
+	}
+	return found
+}
+
+// themeIsWidget returns true if given type is a widget, i.e., embeds WidgetBase
+func themeIsWidget(typ reflect.Type) bool {
+	return typ.Kind() == reflect.Struct && kit.TypeEmbeds(typ, KiT_WidgetBase)
+}
+
+// applyTypeProps applies the density, radius and styles to the properties
+// of the widget types -- must be called under themeMu
+func (th *Theme) applyTypeProps() {
+	scale := th.Density
+	if scale == 0 {
+		scale = 1
+	}
+	var rad interface{}
+	if th.Radius != "" {
+		rad = units.StringToValue(th.Radius)
+	}
+	var tps []ki.Props
+	if scale != 1 || rad != nil {
+		for _, typ := range kit.Types.Types {
+			if themeIsWidget(typ) {
+				tps = append(tps, ki.Props(*kit.Types.Properties(typ, true)))
+			}
+		}
+	}
+	styps := map[string]ki.Props{}
+	for sel := range th.Styles {
+		typ := ThemeWidgetType(strings.ToLower(sel))
+		if typ == nil {
+			log.Printf("gi.Theme: %v: widget type %v not found\n", th.Name, sel)
+			continue
+		}
+		styps[sel] = ki.Props(*kit.Types.Properties(typ, true))
+	}
+
+	kit.TypesMu.Lock()
+	defer kit.TypesMu.Unlock()
+	for _, tp := range tps {
+		themeScaleProps(tp, scale, rad)
+	}
+	for sel, tp := range styps {
+		if sp, ok := th.Styles[sel].(ki.Props); ok {
+			th.mergeProps(tp, sp)
+		}
+	}
+}
+
+// themeScaleProps scales the spacing and sets the radius in given style
+// properties and their sub-selectors
+func themeScaleProps(p ki.Props, scale float32, rad interface{}) {
+	for k, v := range p {
+		switch {
+		case strings.HasPrefix(k, "__"):
+		case k == "padding" || k == "margin" || k == "spacing":
+			if scale != 1 {
+				if sv, ok := themeScaleValue(v, scale); ok {
+					themeSet(p, k, sv)
+				}
+			}
+		case k == "border-radius":
+			if rad != nil {
+				if sv, ok := themeScaleValue(v, 1); ok && sv.Val != 0 {
+					themeSet(p, k, rad)
+				}
+			}
+		default:
+			if sp, ok := v.(ki.Props); ok {
+				themeScaleProps(sp, scale, rad)
+			}
+		}
+	}
+}
+
+// themeScaleValue returns given spacing value scaled by given factor, if it
+// is a units.Value, number, or string with a number
+func themeScaleValue(v interface{}, scale float32) (units.Value, bool) {
+	var uv units.Value
+	switch vv := v.(type) {
+	case units.Value:
+		uv = vv
+	case string:
+		s := strings.TrimSpace(vv)
+		if s == "" || !strings.ContainsAny(s[:1], "0123456789.-") {
+			return uv, false
+		}
+		uv = units.StringToValue(s)
+	default:
+		fv, ok := kit.ToFloat32(v)
+		if !ok {
+			return uv, false
+		}
+		uv = units.NewPx(fv)
+	}
+	uv.Val *= scale
+	return uv, true
+}
+
+// mergeProps merges the theme style props into given type props, resolving
+// tokens
+func (th *Theme) mergeProps(tp, sp ki.Props) {
+	for k, v := range sp {
+		if ssp, ok := v.(ki.Props); ok {
+			if dsp, ok := tp[k].(ki.Props); ok {
+				th.mergeProps(dsp, ssp)
+				continue
+			}
+			np := make(ki.Props, len(ssp))
+			th.mergeProps(np, ssp)
+			themeSet(tp, k, np)
+			continue
+		}
+		themeSet(tp, k, th.resolve(v))
+	}
+}
+
+// themeWatch is the goroutine that reloads the file of the ActiveTheme when
+// it changes, and updates all windows
+func themeWatch() {
+	for {
+		time.Sleep(time.Duration(ThemeWatchMSec) * time.Millisecond)
+		themeMu.Lock()
+		th := ActiveTheme
+		themeMu.Unlock()
+		if th == nil || th.Filename == "" || len(AllWindows) == 0 {
+			continue
+		}
+		fi, err := os.Stat(string(th.Filename))
+		if err != nil || !fi.ModTime().After(th.modTime) {
+			continue
+		}
+		th.modTime = fi.ModTime()
+		nt, err := OpenTheme(string(th.Filename))
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+		nt.Name = th.Name
+		win := AllWindows.Win(0)
+		if win == nil {
+			continue
+		}
+		win.RunOnWin(func() {
+			AddTheme(nt)
+			nt.Apply(true)
+			Prefs.UpdateAll()
+		})
+	}
+}
+
+// ApplyTheme applies the Theme if it is not already the ActiveTheme -- its
+// Colors replace the Preferences Colors, except at startup, where the saved
+// Colors are kept -- an empty Theme removes any active theme
+func (pf *Preferences) ApplyTheme() {
+	colors := themeApplied
+	themeApplied = true
+	themeMu.Lock()
+	at := ActiveTheme
+	th, _, ok := AvailThemes.ThemeByName(pf.Theme)
+	themeMu.Unlock()
+	if pf.Theme == "" {
+		if at != nil {
+			RemoveTheme()
+		}
+		return
+	}
+	if at != nil && at.Name == string(pf.Theme) {
+		return
+	}
+	if !ok {
+		log.Printf("gi.Preferences: Theme %v not found\n", pf.Theme)
+		return
+	}
+	th.Apply(colors)
+}
+
+// SetTheme sets the theme, and then does Save and UpdateAll
+func (pf *Preferences) SetTheme(theme ThemeName) {
+	pf.Theme = theme
+	pf.Save()
+	pf.UpdateAll()
+}
@@ -0,0 +1,707 @@
+// Copyright (c) 2018, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"fmt"
+	"image"
+	"time"
+
+	"github.com/goki/gi/oswin"
+	"github.com/goki/gi/oswin/mouse"
+	"github.com/goki/gi/units"
+	"github.com/goki/ki/ints"
+	"github.com/goki/ki/ki"
+	"github.com/goki/ki/kit"
+	"github.com/goki/mat32"
+)
+
+// ToastTypes are the types of toast notifications, which determine the
+// icon and accent color
+type ToastTypes int32
+
+const (
+	// ToastInfo is a general informational message
+	ToastInfo ToastTypes = iota
+
+	// ToastWarning is a warning that something may need attention
+	ToastWarning
+
+	// ToastError reports that something has failed
+	ToastError
+
+	ToastTypesN
+)
+
+//go:generate stringer -type=ToastTypes
+
+var KiT_ToastTypes = kit.Enums.AddEnumAltLower(ToastTypesN, kit.NotBitFlag, StylePropProps, "Toast")
+
+func (ev ToastTypes) MarshalJSON() ([]byte, error)  { return kit.EnumMarshalJSON(ev) }
+func (ev *ToastTypes) UnmarshalJSON(b []byte) error { return kit.EnumUnmarshalJSON(ev, b) }
+
+// ToastCorners are the corners of the window where toasts are shown
+type ToastCorners int32
+
+const (
+	ToastBottomRight ToastCorners = iota
+	ToastBottomLeft
+	ToastTopRight
+	ToastTopLeft
+	ToastCornersN
+)
+
+//go:generate stringer -type=ToastCorners
+
+var KiT_ToastCorners = kit.Enums.AddEnumAltLower(ToastCornersN, kit.NotBitFlag, StylePropProps, "Toast")
+
+func (ev ToastCorners) MarshalJSON() ([]byte, error)  { return kit.EnumMarshalJSON(ev) }
+func (ev *ToastCorners) UnmarshalJSON(b []byte) error { return kit.EnumUnmarshalJSON(ev, b) }
+
+// ToastIcons are the icons shown for each type of toast
+var ToastIcons = map[ToastTypes]string{
+	ToastInfo:    "info",
+	ToastWarning: "warning",
+	ToastError:   "error",
+}
+
+// ToastColors are the accent (border) colors for each type of toast
+var ToastColors = map[ToastTypes]string{
+	ToastInfo:    "#4080FF",
+	ToastWarning: "#E0A000",
+	ToastError:   "#E04040",
+}
+
+// ToastDefaultDuration is how long toasts are shown by default before being
+// dismissed automatically
+var ToastDefaultDuration = 5 * time.Second
+
+// ToastMaxShown is the maximum number of toasts shown at the same time --
+// the oldest are dismissed to make room for new ones
+var ToastMaxShown = 5
+
+// ToastHistoryMax is the maximum number of toasts kept in the history
+var ToastHistoryMax = 100
+
+// ToastSpacing is the spacing in dots between stacked toasts and from the
+// window edge
+var ToastSpacing = 8
+
+var ToastFrameProps = ki.Props{
+	"background-color":    &Prefs.Colors.Control,
+	"color":               &Prefs.Colors.Font,
+	"border-width":        units.NewPx(2),
+	"border-radius":       units.NewPx(4),
+	"margin":              units.NewPx(0),
+	"padding":             units.NewPx(4),
+	"spacing":             units.NewEx(0.5),
+	"box-shadow.h-offset": units.NewPx(0),
+	"box-shadow.v-offset": units.NewPx(0),
+	"box-shadow.blur":     units.NewPx(0),
+	"box-shadow.color":    &Prefs.Colors.Shadow,
+}
+
+////////////////////////////////////////////////////////////////////////////////////////
+// Toast
+
+// Toast is a transient, non-modal notification message shown in a corner
+// of a window, optionally with an action button.  Toasts are rendered into
+// window Sprite overlays, so they do not interfere with the window
+// contents or popups, and are stacked with the newest nearest the corner.
+// They are automatically dismissed after Duration, and all toasts are kept
+// in the ToastStack History for the window, which can be browsed using a
+// NotifyButton (e.g., in a status bar).
+type Toast struct {
+	Type     ToastTypes     `desc:"type of toast -- determines the icon and accent color"`
+	Message  string         `desc:"the message, which can contain full html formatting, and is word-wrapped"`
+	ActText  string         `desc:"text of the optional action button -- if empty there is no action button"`
+	ActFunc  func()         `json:"-" xml:"-" view:"-" desc:"function called when the action button is pressed -- the toast is then dismissed"`
+	Duration time.Duration  `desc:"how long to show the toast before dismissing it -- 0 = ToastDefaultDuration, < 0 = until dismissed by the user"`
+	Time     time.Time      `desc:"when the toast was posted"`
+	Read     bool           `desc:"whether the toast has been seen in the notification history"`
+	Stack    *ToastStack    `json:"-" xml:"-" view:"-" desc:"the stack that this toast belongs to"`
+	Vp       *ToastViewport `json:"-" xml:"-" view:"-" desc:"the viewport rendering the toast when it is shown"`
+	timer    *time.Timer
+}
+
+// SpriteName returns the unique name of the sprite for this toast
+func (t *Toast) SpriteName() string {
+	return fmt.Sprintf("gi.Toast:%p", t)
+}
+
+// IsShown returns true if the toast is currently being shown
+func (t *Toast) IsShown() bool {
+	return t.Vp != nil
+}
+
+// Label returns a one-line label for the toast, e.g., for the notification
+// history menu
+func (t *Toast) Label() string {
+	msg := t.Message
+	if len(msg) > 60 {
+		msg = msg[:57] + "..."
+	}
+	return t.Time.Format("15:04:05") + "  " + msg
+}
+
+// DismissLater dismisses the toast on the next pass through the window
+// event loop -- used from within the toast's own event processing, where
+// the toast viewport cannot yet be destroyed
+func (t *Toast) DismissLater() {
+	ts := t.Stack
+	if ts == nil || ts.Win == nil {
+		return
+	}
+	ts.Win.RunOnWin(func() {
+		ts.Dismiss(t)
+	})
+}
+
+// MakeViewport makes the viewport that renders the toast, sized to fit the
+// given window
+func (t *Toast) MakeViewport(win *Window) *ToastViewport {
+	mainVp := win.Viewport
+	vp := &ToastViewport{Toast: t}
+	vp.InitName(vp, "toast")
+	vp.Win = win
+	vp.EventMgr.Master = vp
+	updt := vp.UpdateStart()
+	vp.Fill = false
+	vp.SetFlag(int(VpFlagPopup))
+
+	frame := AddNewFrame(vp, "frame", LayoutHoriz)
+	frame.SetProps(ToastFrameProps, ki.NoUpdate)
+	frame.SetProp("border-color", ToastColors[t.Type])
+	ic := AddNewIcon(frame, "icon", ToastIcons[t.Type])
+	ic.SetProp("width", units.NewEm(1.5))
+	ic.SetProp("height", units.NewEm(1.5))
+	ic.SetProp("vertical-align", AlignMiddle)
+	ic.SetProp("fill", ToastColors[t.Type])
+	ic.SetProp("stroke", ToastColors[t.Type])
+
+	lbl := AddNewLabel(frame, "msg", t.Message)
+	lbl.SetProp("white-space", WhiteSpaceNormal) // wrap
+	lbl.SetProp("vertical-align", AlignMiddle)
+	mwdots := mainVp.Sty.UnContext.ToDots(30, units.Em)
+	mwdots = mat32.Min(mwdots, float32(mainVp.Geom.Size.X-4*ToastSpacing))
+	lbl.SetProp("max-width", units.NewValue(mwdots, units.Dot))
+
+	if t.ActText != "" {
+		ac := AddNewAction(frame, "act")
+		ac.SetText(t.ActText)
+		ac.SetProp("vertical-align", AlignMiddle)
+		ac.ActionSig.Connect(vp.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+			tvp := recv.Embed(KiT_ToastViewport).(*ToastViewport)
+			tt := tvp.Toast
+			if tt.ActFunc != nil {
+				tt.ActFunc()
+			}
+			tt.DismissLater()
+		})
+	}
+	cl := AddNewAction(frame, "close")
+	cl.SetIcon("close")
+	cl.Tooltip = "dismiss this notification"
+	cl.SetProp("vertical-align", AlignMiddle)
+	cl.ActionSig.Connect(vp.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+		tvp := recv.Embed(KiT_ToastViewport).(*ToastViewport)
+		tvp.Toast.DismissLater()
+	})
+
+	vpsz := vp.PrefSize(mainVp.Geom.Size)
+	vpsz.X = ints.MinInt(vpsz.X, mainVp.Geom.Size.X-2*ToastSpacing)
+	vpsz.Y = ints.MinInt(vpsz.Y, mainVp.Geom.Size.Y-2*ToastSpacing)
+	vp.Resize(vpsz)
+	vp.UpdateEndNoSig(updt)
+	return vp
+}
+
+////////////////////////////////////////////////////////////////////////////////////////
+// ToastStack
+
+// ToastStack manages the toasts for a Window -- the ones currently shown,
+// and the history of all toasts.  Its methods must be called on the window
+// event-processing goroutine -- use Window.RunOnWin from other goroutines.
+type ToastStack struct {
+	Win      *Window      `json:"-" xml:"-" view:"-" desc:"the window that the toasts are shown in"`
+	Corner   ToastCorners `desc:"corner of the window where toasts are shown"`
+	Shown    []*Toast     `json:"-" xml:"-" desc:"toasts currently shown, oldest first"`
+	History  []*Toast     `json:"-" xml:"-" desc:"history of toasts, oldest first, up to ToastHistoryMax"`
+	ToastSig ki.Signal    `json:"-" xml:"-" view:"-" desc:"signal emitted when a toast is added or the history changes -- has no signal types, data is the *Toast or nil, sent from the Window"`
+}
+
+// NUnread returns the number of toasts in the history that have not been read
+func (ts *ToastStack) NUnread() int {
+	n := 0
+	for _, t := range ts.History {
+		if !t.Read {
+			n++
+		}
+	}
+	return n
+}
+
+// MarkAllRead marks all toasts in the history as read
+func (ts *ToastStack) MarkAllRead() {
+	for _, t := range ts.History {
+		t.Read = true
+	}
+	ts.EmitSig(nil)
+}
+
+// ClearHistory clears the history of toasts
+func (ts *ToastStack) ClearHistory() {
+	ts.History = nil
+	ts.EmitSig(nil)
+}
+
+// EmitSig emits the ToastSig signal with given toast (can be nil)
+func (ts *ToastStack) EmitSig(t *Toast) {
+	if ts.Win == nil || ts.Win.This() == nil {
+		return
+	}
+	ts.ToastSig.Emit(ts.Win.This(), 0, t)
+}
+
+// Add adds given toast to the history and shows it
+func (ts *ToastStack) Add(t *Toast) {
+	t.Stack = ts
+	if t.Time.IsZero() {
+		t.Time = time.Now()
+	}
+	ts.History = append(ts.History, t)
+	if over := len(ts.History) - ToastHistoryMax; over > 0 {
+		ts.History = ts.History[over:]
+	}
+	ts.Show(t)
+	ts.EmitSig(t)
+}
+
+// Show shows given toast (again), with a new timer for auto-dismissal
+func (ts *ToastStack) Show(t *Toast) {
+	win := ts.Win
+	if win == nil || !win.IsVisible() || win.Viewport == nil {
+		return
+	}
+	t.Stack = ts
+	if t.IsShown() {
+		ts.Dismiss(t)
+	}
+	for len(ts.Shown) >= ToastMaxShown && len(ts.Shown) > 0 {
+		ts.Dismiss(ts.Shown[0])
+	}
+	t.Vp = t.MakeViewport(win)
+	sp := &Sprite{Name: t.SpriteName()}
+	sp.Resize(t.Vp.Geom.Size)
+	sp.On = true
+	win.UpMu.Lock()
+	win.AddSprite(sp)
+	win.UpMu.Unlock()
+	ts.Shown = append(ts.Shown, t)
+	ts.Layout()
+	t.Vp.FullRender2DTree()
+
+	dur := t.Duration
+	if dur == 0 {
+		dur = ToastDefaultDuration
+	}
+	if dur > 0 {
+		t.timer = time.AfterFunc(dur, func() {
+			win.RunOnWin(func() {
+				ts.Dismiss(t)
+			})
+		})
+	}
+}
+
+// Dismiss removes given toast from the window -- it remains in the History
+func (ts *ToastStack) Dismiss(t *Toast) {
+	if t.timer != nil {
+		t.timer.Stop()
+		t.timer = nil
+	}
+	idx := -1
+	for i, st := range ts.Shown {
+		if st == t {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		return
+	}
+	ts.Shown = append(ts.Shown[:idx], ts.Shown[idx+1:]...)
+	if t.Vp != nil {
+		t.Vp.Toast = nil
+		t.Vp.Destroy()
+		t.Vp = nil
+	}
+	if ts.Win != nil {
+		ts.Win.DeleteSprite(t.SpriteName())
+	}
+	ts.Layout()
+}
+
+// DismissAll removes all toasts from the window
+func (ts *ToastStack) DismissAll() {
+	for len(ts.Shown) > 0 {
+		ts.Dismiss(ts.Shown[0])
+	}
+}
+
+// Layout positions the sprites of the toasts currently shown, stacking
+// them from the Corner, and renders the overlays
+func (ts *ToastStack) Layout() {
+	win := ts.Win
+	if win == nil || win.Viewport == nil {
+		return
+	}
+	wsz := win.Viewport.Geom.Size
+	top := ts.Corner == ToastTopRight || ts.Corner == ToastTopLeft
+	left := ts.Corner == ToastBottomLeft || ts.Corner == ToastTopLeft
+	y := ToastSpacing
+	if !top {
+		y = wsz.Y - ToastSpacing
+	}
+	for i := len(ts.Shown) - 1; i >= 0; i-- { // newest nearest corner
+		t := ts.Shown[i]
+		sp, ok := win.SpriteByName(t.SpriteName())
+		if !ok || t.Vp == nil {
+			continue
+		}
+		sz := t.Vp.Geom.Size
+		x := ToastSpacing
+		if !left {
+			x = wsz.X - ToastSpacing - sz.X
+		}
+		if top {
+			sp.Geom.Pos = image.Point{x, y}
+			y += sz.Y + ToastSpacing
+		} else {
+			y -= sz.Y
+			sp.Geom.Pos = image.Point{x, y}
+			y -= ToastSpacing
+		}
+		win.ActivateSprite(t.SpriteName()) // resize inactivates
+	}
+	win.RenderOverlays()
+}
+
+// ToastAt returns the toast shown at given window point, and the point
+// in its local coordinates, or nil if none
+func (ts *ToastStack) ToastAt(pt image.Point) (*Toast, image.Point) {
+	win := ts.Win
+	if win == nil {
+		return nil, pt
+	}
+	for _, t := range ts.Shown {
+		sp, ok := win.SpriteByName(t.SpriteName())
+		if !ok || !sp.On || t.Vp == nil {
+			continue
+		}
+		r := image.Rectangle{Min: sp.Geom.Pos, Max: sp.Geom.Pos.Add(sp.Geom.Size)}
+		if pt.In(r) {
+			return t, pt.Sub(sp.Geom.Pos)
+		}
+	}
+	return nil, pt
+}
+
+// MouseEvent processes mouse events for the toasts currently shown,
+// returning true if the event was within a toast (and thus processed) --
+// called in Window.HiPriorityEvents.
+func (ts *ToastStack) MouseEvent(evi oswin.Event) bool {
+	if len(ts.Shown) == 0 {
+		return false
+	}
+	switch e := evi.(type) {
+	case *mouse.Event:
+		t, lpt := ts.ToastAt(e.Where)
+		if t == nil {
+			return false
+		}
+		md := &mouse.Event{}
+		*md = *e
+		md.Where = lpt
+		em := &t.Vp.EventMgr
+		em.MouseEvents(md)
+		em.SendEventSignal(md, false)
+		em.MouseEventReset(md)
+		e.SetProcessed()
+		return true
+	case *mouse.MoveEvent:
+		var hit *Toast
+		for _, t := range ts.Shown {
+			if t.Vp == nil {
+				continue
+			}
+			sp, ok := ts.Win.SpriteByName(t.SpriteName())
+			if !ok {
+				continue
+			}
+			md := &mouse.MoveEvent{}
+			*md = *e
+			md.Where = e.Where.Sub(sp.Geom.Pos)
+			md.From = e.From.Sub(sp.Geom.Pos)
+			if md.Where.In(image.Rectangle{Max: sp.Geom.Size}) {
+				hit = t
+			}
+			em := &t.Vp.EventMgr
+			em.MouseEvents(md)
+			em.SendEventSignal(md, false)
+			em.GenMouseFocusEvents(md, false)
+			em.MouseEventReset(md)
+		}
+		if hit != nil {
+			e.SetProcessed()
+			return true
+		}
+	}
+	return false
+}
+
+// ShowToast shows a toast notification of given type and message in the
+// window of given viewport, returning the toast.
+func ShowToast(avp *Viewport2D, typ ToastTypes, msg string) *Toast {
+	return ShowToastAction(avp, typ, msg, "", nil)
+}
+
+// ShowToastAction shows a toast notification of given type and message in
+// the window of given viewport, with an action button with given text that
+// calls given function when pressed, returning the toast.
+func ShowToastAction(avp *Viewport2D, typ ToastTypes, msg, actText string, fun func()) *Toast {
+	t := &Toast{Type: typ, Message: msg, ActText: actText, ActFunc: fun}
+	avp = ValidViewport(avp)
+	if avp == nil || avp.Win == nil {
+		return t
+	}
+	avp.Win.Toasts.Add(t)
+	return t
+}
+
+////////////////////////////////////////////////////////////////////////////////////////
+// ToastViewport
+
+// ToastViewport is the viewport that renders a Toast into its Sprite, with
+// its own event manager for the mouse events routed to it by the ToastStack.
+type ToastViewport struct {
+	Viewport2D
+	EventMgr   EventMgr `json:"-" xml:"-" desc:"event manager that handles dispersing events to nodes"`
+	Toast      *Toast   `json:"-" xml:"-" desc:"the toast we render"`
+	TopUpdated bool     `json:"-" xml:"-" desc:"update flag for top-level updates"`
+}
+
+var KiT_ToastViewport = kit.Types.AddType(&ToastViewport{}, ToastViewportProps)
+
+var ToastViewportProps = ki.Props{
+	"EnumType:Flag": KiT_VpFlags,
+	"color":         &Prefs.Colors.Font,
+}
+
+func (vp *ToastViewport) VpTop() Viewport {
+	return vp.This().(Viewport)
+}
+
+func (vp *ToastViewport) VpTopNode() Node {
+	return vp.This().(Node)
+}
+
+func (vp *ToastViewport) VpTopUpdateStart() bool {
+	if vp.TopUpdated {
+		return false
+	}
+	vp.TopUpdated = true
+	return true
+}
+
+func (vp *ToastViewport) VpTopUpdateEnd(updt bool) {
+	if !updt {
+		return
+	}
+	vp.VpUploadAll()
+	vp.TopUpdated = false
+}
+
+func (vp *ToastViewport) VpEventMgr() *EventMgr {
+	return &vp.EventMgr
+}
+
+func (vp *ToastViewport) VpIsVisible() bool {
+	if vp.Toast == nil || vp.Win == nil {
+		return false
+	}
+	return vp.Win.IsVisible()
+}
+
+// VpUploadAll copies our pixels into the toast sprite and renders the
+// window overlays
+func (vp *ToastViewport) VpUploadAll() {
+	if !vp.This().(Viewport).VpIsVisible() {
+		return
+	}
+	sp, ok := vp.Win.SpriteByName(vp.Toast.SpriteName())
+	if !ok {
+		return
+	}
+	sp.GrabRenderFrom(vp.This().(Node2D))
+	vp.Win.RenderOverlays()
+}
+
+func (vp *ToastViewport) VpUploadVp() {
+	vp.VpUploadAll()
+}
+
+func (vp *ToastViewport) VpUploadRegion(vpBBox, winBBox image.Rectangle) {
+	vp.VpUploadAll()
+}
+
+///////////////////////////////////////
+//  EventMaster API
+
+func (vp *ToastViewport) EventTopNode() ki.Ki {
+	return vp
+}
+
+func (vp *ToastViewport) FocusTopNode() ki.Ki {
+	return vp
+}
+
+func (vp *ToastViewport) EventTopUpdateStart() bool {
+	return vp.VpTopUpdateStart()
+}
+
+func (vp *ToastViewport) EventTopUpdateEnd(updt bool) {
+	vp.VpTopUpdateEnd(updt)
+}
+
+// IsInScope returns whether given node is in scope for receiving events
+func (vp *ToastViewport) IsInScope(node *Node2DBase, popup bool) bool {
+	return true
+}
+
+func (vp *ToastViewport) CurPopupIsTooltip() bool {
+	return false
+}
+
+// DeleteTooltip deletes any tooltip popup (called when hover ends)
+func (vp *ToastViewport) DeleteTooltip() {
+}
+
+// IsFocusActive returns true if focus is active in this master
+func (vp *ToastViewport) IsFocusActive() bool {
+	return false
+}
+
+// SetFocusActiveState sets focus active state
+func (vp *ToastViewport) SetFocusActiveState(active bool) {
+}
+
+////////////////////////////////////////////////////////////////////////////////////////
+// NotifyButton
+
+// NotifyButton is an Action showing the number of unread notifications
+// (toasts) for its window, which opens a menu of the notification history
+// -- typically added to a status bar or toolbar.  Selecting an item in the
+// menu shows that toast again.
+type NotifyButton struct {
+	Action
+	MaxItems int `desc:"maximum number of recent notifications shown in the menu"`
+}
+
+var KiT_NotifyButton = kit.Types.AddType(&NotifyButton{}, NotifyButtonProps)
+
+// AddNewNotifyButton adds a new notification button to given parent node, with given name.
+func AddNewNotifyButton(parent ki.Ki, name string) *NotifyButton {
+	return parent.AddNewChild(KiT_NotifyButton, name).(*NotifyButton)
+}
+
+func (nb *NotifyButton) CopyFieldsFrom(frm interface{}) {
+	fr := frm.(*NotifyButton)
+	nb.Action.CopyFieldsFrom(&fr.Action)
+	nb.MaxItems = fr.MaxItems
+}
+
+var NotifyButtonProps = ActionProps
+
+// Stack returns the ToastStack for our window, or nil if none
+func (nb *NotifyButton) Stack() *ToastStack {
+	win := nb.ParentWindow()
+	if win == nil {
+		return nil
+	}
+	return &win.Toasts
+}
+
+// UpdateCount updates the text to show the number of unread notifications
+func (nb *NotifyButton) UpdateCount() {
+	ts := nb.Stack()
+	if ts == nil {
+		return
+	}
+	n := ts.NUnread()
+	txt := ""
+	if n > 0 {
+		txt = fmt.Sprintf("%d", n)
+	}
+	if txt != nb.Text {
+		nb.SetText(txt)
+	}
+}
+
+// MakeNotifyMenu makes the menu of recent notifications
+func (nb *NotifyButton) MakeNotifyMenu(m *Menu) {
+	ts := nb.Stack()
+	*m = make(Menu, 0, 10)
+	if ts == nil {
+		return
+	}
+	mx := nb.MaxItems
+	if mx <= 0 {
+		mx = 20
+	}
+	if len(ts.History) == 0 {
+		m.AddLabel("No notifications")
+	}
+	for i := len(ts.History) - 1; i >= 0 && len(ts.History)-i <= mx; i-- {
+		t := ts.History[i]
+		m.AddAction(ActOpts{Label: t.Label(), Icon: ToastIcons[t.Type], Tooltip: t.Message, Data: t}, nb.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+			nbb := recv.Embed(KiT_NotifyButton).(*NotifyButton)
+			if ts := nbb.Stack(); ts != nil {
+				ts.Show(data.(*Toast))
+			}
+		})
+	}
+	m.AddSeparator("sep-clear")
+	m.AddAction(ActOpts{Label: "Dismiss All"}, nb.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+		nbb := recv.Embed(KiT_NotifyButton).(*NotifyButton)
+		if ts := nbb.Stack(); ts != nil {
+			ts.DismissAll()
+		}
+	})
+	m.AddAction(ActOpts{Label: "Clear History"}, nb.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+		nbb := recv.Embed(KiT_NotifyButton).(*NotifyButton)
+		if ts := nbb.Stack(); ts != nil {
+			ts.ClearHistory()
+		}
+	})
+	ts.MarkAllRead()
+}
+
+func (nb *NotifyButton) Init2D() {
+	nb.Icon = IconName("bell")
+	nb.Tooltip = "notifications"
+	nb.MakeMenuFunc = func(obj ki.Ki, m *Menu) {
+		nbb := obj.Embed(KiT_NotifyButton).(*NotifyButton)
+		nbb.MakeNotifyMenu(m)
+	}
+	nb.Action.Init2D()
+	if ts := nb.Stack(); ts != nil {
+		ts.ToastSig.Connect(nb.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+			nbb := recv.Embed(KiT_NotifyButton).(*NotifyButton)
+			nbb.UpdateCount()
+		})
+	}
+	nb.UpdateCount()
+}
@@ -0,0 +1,302 @@
+// Copyright (c) 2018, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"image"
+	"time"
+
+	"github.com/chewxy/math32"
+	"github.com/goki/gi/oswin"
+	"github.com/goki/gi/oswin/key"
+	"github.com/goki/gi/units"
+	"github.com/goki/ki/ints"
+	"github.com/goki/ki/ki"
+	"github.com/goki/ki/kit"
+	"github.com/goki/mat32"
+)
+
+// TooltipShowMSec is the default number of milliseconds to wait before
+// showing a tooltip, in addition to the HoverStartMSec delay for the hover
+// event that triggers it
+var TooltipShowMSec = 0
+
+// TooltipHideMSec is the default number of milliseconds after which a
+// tooltip is hidden automatically -- 0 = only hidden when the mouse moves
+// away or another event occurs
+var TooltipHideMSec = 0
+
+// TooltipCursorOffset is the offset from the mouse position at which
+// FollowCursor tooltips are shown
+var TooltipCursorOffset = image.Point{12, 16}
+
+// TooltipOpts are the options for a rich tooltip, which can have an icon,
+// an image and a keyboard shortcut in addition to HTML text, and can follow
+// the cursor or be interactive.  Set the TooltipOpts on a widget to use
+// these instead of a plain Tooltip string.
+type TooltipOpts struct {
+	Text         string      `desc:"text of the tooltip, which can use full HTML markup, including links that can be clicked in Interactive tooltips"`
+	Icon         IconName    `desc:"optional icon shown to the left of the text"`
+	Image        image.Image `json:"-" xml:"-" view:"-" desc:"optional image shown below the text"`
+	ImageFile    FileName    `desc:"optional image file shown below the text, if Image is not set"`
+	ImageSize    mat32.Vec2  `desc:"size of the image in dots -- 0 = actual size of the image for that dimension"`
+	Shortcut     key.Chord   `desc:"keyboard shortcut shown below the text -- set automatically from the Shortcut of buttons and actions"`
+	ShowMSec     int         `desc:"number of milliseconds to wait after the hover event before showing the tooltip -- defaults to TooltipShowMSec"`
+	HideMSec     int         `desc:"number of milliseconds after which the tooltip is hidden automatically -- 0 = only when the mouse moves away -- defaults to TooltipHideMSec"`
+	FollowCursor bool        `desc:"show the tooltip next to the mouse, and move it along with the mouse while it stays over the widget, instead of at a fixed position below the widget"`
+	Interactive  bool        `desc:"keep the tooltip open while the mouse is over it, and send mouse events to it, so that links in the text can be clicked"`
+}
+
+// NewTooltipOpts returns tooltip options for given text, with the default
+// show and hide delays
+func NewTooltipOpts(text string) *TooltipOpts {
+	return &TooltipOpts{Text: text, ShowMSec: TooltipShowMSec, HideMSec: TooltipHideMSec}
+}
+
+// HasContent returns true if the tooltip has anything to show
+func (tt *TooltipOpts) HasContent() bool {
+	return tt.Text != "" || tt.Icon != "" || tt.Image != nil || tt.ImageFile != "" || tt.Shortcut != ""
+}
+
+////////////////////////////////////////////////////////////////////////////////////////
+// TooltipViewport
+
+// TooltipViewport is the popup viewport that shows a tooltip, which retains
+// its options, and the window bounding box of the widget it is for, which
+// determines how long FollowCursor and Interactive tooltips stay open.
+type TooltipViewport struct {
+	Viewport2D
+	Opts  TooltipOpts     `desc:"options for the tooltip"`
+	Owner image.Rectangle `desc:"window bounding box of the widget that the tooltip is for"`
+}
+
+var KiT_TooltipViewport = kit.Types.AddType(&TooltipViewport{}, Viewport2DProps)
+
+// TooltipIconProps are the properties of the icon in a tooltip
+var TooltipIconProps = ki.Props{
+	"width":  units.NewEm(1.5),
+	"height": units.NewEm(1.5),
+	"margin": units.NewPx(0),
+}
+
+// ConfigTooltip configures the contents of the tooltip viewport from its
+// Opts, with labels wrapped at given max width in dots
+func (tv *TooltipViewport) ConfigTooltip(maxWidth float32) {
+	tt := &tv.Opts
+	frame := AddNewFrame(tv, "Frame", LayoutVert)
+	frame.SetProps(TooltipFrameProps, ki.NoUpdate)
+	if tt.Text != "" || tt.Icon != "" {
+		row := AddNewLayout(frame, "row", LayoutHoriz)
+		if tt.Icon != "" {
+			ic := AddNewIcon(row, "icon", string(tt.Icon))
+			ic.SetProps(TooltipIconProps, ki.NoUpdate)
+		}
+		if tt.Text != "" {
+			lbl := AddNewLabel(row, "ttlbl", tt.Text)
+			lbl.SetProp("white-space", WhiteSpaceNormal) // wrap
+			lbl.SetProp("max-width", units.NewValue(maxWidth, units.Dot))
+		}
+	}
+	if tt.Image != nil || tt.ImageFile != "" {
+		bm := AddNewBitmap(frame, "image")
+		if tt.Image != nil {
+			bm.SetImage(tt.Image, tt.ImageSize.X, tt.ImageSize.Y)
+		} else {
+			bm.OpenImage(tt.ImageFile, tt.ImageSize.X, tt.ImageSize.Y)
+		}
+	}
+	if tt.Shortcut != "" {
+		sc := AddNewLabel(frame, "shortcut", tt.Shortcut.Shortcut())
+		sc.SetProp("font-style", FontItalic)
+		sc.SetProp("horizontal-align", AlignRight)
+	}
+}
+
+// PopupTooltipOpts pops up a viewport displaying a tooltip with given
+// options, at given position, for a widget with given window bounding box
+// (owner).  If ShowMSec is > 0, the tooltip is only shown after that delay,
+// if the mouse has not moved away, and if HideMSec > 0 it is closed again
+// after that delay (unless the mouse is over an Interactive tooltip) -- the
+// delayed showing and hiding are done on the window goroutine (RunOnWin).
+func PopupTooltipOpts(tt *TooltipOpts, x, y int, owner image.Rectangle, parVp *Viewport2D, name string) *TooltipViewport {
+	win := parVp.Win
+	mainVp := win.Viewport
+	pvp := &TooltipViewport{}
+	pvp.InitName(pvp, name+"Tooltip")
+	pvp.Opts = *tt
+	pvp.Owner = owner
+	pvp.Win = win
+	updt := pvp.UpdateStart()
+	pvp.SetProp("color", &Prefs.Colors.Font)
+	pvp.Fill = false
+	pvp.SetFlag(int(VpFlagPopup))
+	pvp.SetFlag(int(VpFlagTooltip))
+
+	pvp.Geom.Pos = image.Point{x, y}
+	pvp.SetFlag(int(VpFlagPopupDestroyAll)) // nuke it all
+
+	mwdots := parVp.Sty.UnContext.ToDots(40, units.Em)
+	mwdots = mat32.Min(mwdots, float32(mainVp.Geom.Size.X-20))
+	pvp.ConfigTooltip(mwdots)
+
+	frame := pvp.Child(0).(*Frame)
+	frame.Init2DTree()
+	frame.Style2DTree()                                    // sufficient to get sizes
+	frame.LayState.Alloc.Size = mainVp.LayState.Alloc.Size // give it the whole vp initially
+	frame.Size2DTree(0)                                    // collect sizes
+	pvp.Win = nil
+	vpsz := frame.LayState.Size.Pref.Min(mainVp.LayState.Alloc.Size).ToPoint()
+	x = ints.MaxInt(0, ints.MinInt(x, mainVp.Geom.Size.X-vpsz.X)) // fit
+	y = ints.MaxInt(0, ints.MinInt(y, mainVp.Geom.Size.Y-vpsz.Y)) // fit
+	pvp.Resize(vpsz)
+	pvp.Geom.Pos = image.Point{x, y}
+	pvp.UpdateEndNoSig(updt)
+
+	if tt.ShowMSec <= 0 {
+		win.PushTooltip(pvp)
+		return pvp
+	}
+	mpos := win.EventMgr.LastMousePos
+	time.AfterFunc(time.Duration(tt.ShowMSec)*time.Millisecond, func() {
+		win.RunOnWin(func() {
+			cpos := win.EventMgr.LastMousePos
+			dst := int(math32.Hypot(float32(cpos.X-mpos.X), float32(cpos.Y-mpos.Y)))
+			if dst > HoverMaxPix || win.CurPopup() != nil {
+				pvp.Destroy()
+				return
+			}
+			win.PushTooltip(pvp)
+		})
+	})
+	return pvp
+}
+
+// PushTooltip pushes given tooltip viewport as the current popup, and
+// starts the timer to hide it if its HideMSec is > 0
+func (w *Window) PushTooltip(tv *TooltipViewport) {
+	w.PushPopup(tv.This())
+	if tv.Opts.HideMSec > 0 {
+		w.HideTooltipAfter(tv, tv.Opts.HideMSec)
+	}
+}
+
+// HideTooltipAfter closes given tooltip after given number of milliseconds,
+// if it is still the current popup -- the delay starts over while the mouse
+// is over an Interactive tooltip.  The tooltip is closed on the window
+// goroutine, via RunOnWin.
+func (w *Window) HideTooltipAfter(tv *TooltipViewport, msec int) {
+	time.AfterFunc(time.Duration(msec)*time.Millisecond, func() {
+		w.RunOnWin(func() {
+			if w.CurPopup() != tv.This() {
+				return
+			}
+			if tv.Opts.Interactive && w.EventMgr.LastMousePos.In(tv.Geom.Bounds()) {
+				w.HideTooltipAfter(tv, msec)
+				return
+			}
+			w.ClosePopup(tv.This())
+		})
+	})
+}
+
+// CurTooltip returns the current popup if it is a TooltipViewport, else nil
+func (w *Window) CurTooltip() *TooltipViewport {
+	cpop := w.CurPopup()
+	if cpop == nil {
+		return nil
+	}
+	tk := cpop.Embed(KiT_TooltipViewport)
+	if tk == nil {
+		return nil
+	}
+	return tk.(*TooltipViewport)
+}
+
+// TooltipHasMouse returns true if the current popup is an Interactive
+// tooltip and given event is a mouse event over it -- such events are sent
+// to the tooltip, and do not close it
+func (w *Window) TooltipHasMouse(evi oswin.Event) bool {
+	if !evi.HasPos() {
+		return false
+	}
+	tv := w.CurTooltip()
+	return tv != nil && tv.Opts.Interactive && evi.Pos().In(tv.Geom.Bounds())
+}
+
+// KeepTooltip returns true if the current tooltip should stay open with the
+// mouse at given position, after it has moved from where the tooltip was
+// shown: Interactive tooltips stay open while the mouse is over the tooltip
+// or its owner, and FollowCursor tooltips while it is over the owner.
+func (w *Window) KeepTooltip(pos image.Point) bool {
+	tv := w.CurTooltip()
+	if tv == nil {
+		return false
+	}
+	if tv.Opts.Interactive && pos.In(tv.Geom.Bounds()) {
+		return true
+	}
+	if !pos.In(tv.Owner) {
+		return false
+	}
+	return tv.Opts.Interactive || tv.Opts.FollowCursor
+}
+
+// FollowTooltip moves the current tooltip next to the given mouse position,
+// if it is a FollowCursor tooltip
+func (w *Window) FollowTooltip(pos image.Point) {
+	tv := w.CurTooltip()
+	if tv == nil || !tv.Opts.FollowCursor {
+		return
+	}
+	pos = pos.Add(TooltipCursorOffset)
+	sz := tv.Geom.Size
+	pos.X = ints.MaxInt(0, ints.MinInt(pos.X, w.Viewport.Geom.Size.X-sz.X))
+	pos.Y = ints.MaxInt(0, ints.MinInt(pos.Y, w.Viewport.Geom.Size.Y-sz.Y))
+	if pos == tv.Geom.Pos {
+		return
+	}
+	tv.Geom.Pos = pos
+	tv.FullRender2DTree() // updates all the bboxes for new position
+	w.UploadAllViewports()
+}
+
+////////////////////////////////////////////////////////////////////////////////////////
+// WidgetBase tooltip methods
+
+// TooltipOptions returns the tooltip options for this widget: a copy of
+// TooltipOpts if set (using Tooltip as its Text if it has none), or
+// default options for the Tooltip text -- nil if there is no tooltip
+func (wb *WidgetBase) TooltipOptions() *TooltipOpts {
+	if wb.TooltipOpts != nil {
+		tt := *wb.TooltipOpts
+		if tt.Text == "" {
+			tt.Text = wb.Tooltip
+		}
+		return &tt
+	}
+	if wb.Tooltip == "" {
+		return nil
+	}
+	return NewTooltipOpts(wb.Tooltip)
+}
+
+// PopupTooltipFor pops up given tooltip for this widget, for the mouse at
+// given position: just below the widget, or next to the mouse for
+// FollowCursor tooltips
+func (wb *WidgetBase) PopupTooltipFor(tt *TooltipOpts, mpos image.Point) *TooltipViewport {
+	mvp := wb.ViewportSafe()
+	if mvp == nil || mvp.Win == nil {
+		return nil
+	}
+	wb.BBoxMu.RLock()
+	bb := wb.WinBBox
+	wb.BBoxMu.RUnlock()
+	pos := bb.Max
+	pos.X -= 20
+	if tt.FollowCursor {
+		pos = mpos.Add(TooltipCursorOffset)
+	}
+	return PopupTooltipOpts(tt, pos.X, pos.Y, bb, mvp, wb.Nm)
+}
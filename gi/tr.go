@@ -0,0 +1,116 @@
+// Copyright (c) 2018, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"github.com/goki/gi/i18n"
+	"github.com/goki/ki/ki"
+)
+
+// TrTextProp is the property that records the untranslated source text of a
+// button or label whose text was set from TrText, so that it can be
+// translated again when the locale changes
+const TrTextProp = "tr-text"
+
+// TrTooltipProp is the property that records the untranslated source text
+// of a tooltip set from TrTooltip
+const TrTooltipProp = "tr-tooltip"
+
+func init() {
+	i18n.OnChange(RetranslateWindows)
+}
+
+// TrText returns the translation of given text for the current locale
+// (i18n.Tr), to be used as the text of given button or label, and records
+// the untranslated text on it, so that RetranslateWindows can re-label it
+// when the locale changes, e.g., ac.Text = TrText(ac, "Save As")
+func TrText(k ki.Ki, msgid string) string {
+	if msgid == "" {
+		k.DeleteProp(TrTextProp)
+		return ""
+	}
+	k.SetProp(TrTextProp, msgid)
+	return i18n.Tr(msgid)
+}
+
+// TrTooltip returns the translation of given tooltip for the current locale,
+// to be used as the tooltip of given widget, and records the untranslated
+// tooltip on it, as TrText does for the text
+func TrTooltip(k ki.Ki, msgid string) string {
+	if msgid == "" {
+		k.DeleteProp(TrTooltipProp)
+		return ""
+	}
+	k.SetProp(TrTooltipProp, msgid)
+	return i18n.Tr(msgid)
+}
+
+// Retranslate re-translates the text and tooltip of given node, if they were
+// set from TrText and TrTooltip, for the current locale -- the node must be
+// re-rendered to show the new text
+func Retranslate(k ki.Ki) {
+	if msgid, ok := k.Prop(TrTextProp).(string); ok {
+		if bw, ok := k.(ButtonWidget); ok {
+			bw.AsButtonBase().Text = i18n.Tr(msgid)
+		} else if lk := k.Embed(KiT_Label); lk != nil {
+			lk.(*Label).Text = i18n.Tr(msgid)
+		}
+	}
+	if msgid, ok := k.Prop(TrTooltipProp).(string); ok {
+		if wb := k.Embed(KiT_WidgetBase); wb != nil {
+			wb.(*WidgetBase).Tooltip = i18n.Tr(msgid)
+		}
+	}
+}
+
+// RetranslateTree re-translates all the nodes under given root (see
+// Retranslate), including the items of menus, which are not in the tree
+func RetranslateTree(root ki.Ki) {
+	if root == nil || root.This() == nil {
+		return
+	}
+	root.FuncDownMeFirst(0, nil, func(k ki.Ki, level int, d interface{}) bool {
+		Retranslate(k)
+		if bw, ok := k.(ButtonWidget); ok {
+			for _, mi := range bw.AsButtonBase().Menu {
+				RetranslateTree(mi)
+			}
+		}
+		return ki.Continue
+	})
+}
+
+// RetranslateWindows re-translates all the open windows, including their
+// popups and menus, and re-renders them -- this is called
+// automatically whenever the locale is changed with i18n.SetLocale, or
+// catalogs are added, from any goroutine: each window is re-translated on
+// its own goroutine, via RunOnWin
+func RetranslateWindows() {
+	WindowGlobalMu.Lock()
+	wins := append(WindowList{}, AllWindows...)
+	WindowGlobalMu.Unlock()
+	for _, w := range wins {
+		w := w
+		w.RunOnWin(func() {
+			RetranslateWindow(w)
+		})
+	}
+}
+
+// RetranslateWindow re-translates given window, including its popups and
+// menus, and re-renders it -- must be called on the window goroutine
+func RetranslateWindow(w *Window) {
+	RetranslateTree(w.Viewport)
+	w.PopMu.RLock()
+	pops := append([]ki.Ki{}, w.PopupStack...)
+	if w.Popup != nil {
+		pops = append(pops, w.Popup)
+	}
+	w.PopMu.RUnlock()
+	for _, pop := range pops {
+		RetranslateTree(pop)
+	}
+	w.FullReRender()
+}
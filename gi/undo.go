@@ -0,0 +1,417 @@
+// Copyright (c) 2018, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/goki/gi/i18n"
+	"github.com/goki/gi/oswin/key"
+	"github.com/goki/ki/ki"
+)
+
+// UndoTrace -- set to true to get a report of undo actions
+var UndoTrace = false
+
+// UndoGroupDelayMSec is the number of milliseconds above which a new group
+// is started when saving undo commands -- commands saved within this interval
+// (e.g., all the nodes of a multi-selection cut) are undone together
+var UndoGroupDelayMSec = 250
+
+// UndoMaxGroups is the maximum number of undo groups kept on an UndoStack --
+// the oldest are dropped beyond that
+var UndoMaxGroups = 100
+
+// UndoCmd is a reversible edit command saved on an UndoStack, e.g., the
+// setting of a field value in a StructView, or the deletion of a node in a
+// TreeView.  The command is saved after it has been done, so Redo must
+// re-do it after it has been undone.
+type UndoCmd interface {
+	// Undo reverses the edit
+	Undo()
+
+	// Redo re-does the edit after it has been undone
+	Redo()
+
+	// Label returns the label for the edit, used in menu items, e.g.,
+	// "Rename" for "Undo Rename"
+	Label() string
+}
+
+// UndoDiscarder is an optional interface for an UndoCmd that holds on to
+// resources that must be freed when it is discarded from the UndoStack,
+// e.g., the nodes deleted in a TreeView, which are kept so they can be
+// inserted back
+type UndoDiscarder interface {
+	// Discard is called when the command is discarded from the stack -- done
+	// is true if the command was done (not undone) at the time
+	Discard(done bool)
+}
+
+// UndoGroup is a group of undo commands that are undone and redone together
+type UndoGroup struct {
+	Label string    `desc:"label of the group -- label of its first command unless set by BeginGroup"`
+	Cmds  []UndoCmd `desc:"commands in the group, in the order they were done"`
+	Time  time.Time `desc:"time when the last command was saved in the group"`
+}
+
+// UndoStack is a stack of undo commands, for the edits made in the views of
+// a window or document (see UndoStackFor), with undo and redo of groups of
+// commands.  Commands saved within UndoGroupDelayMSec of each other are
+// grouped, as are all those between BeginGroup and EndGroup.
+type UndoStack struct {
+	Off      bool         `desc:"if true, saving and using undos is turned off"`
+	Groups   []*UndoGroup `desc:"undo stack of command groups"`
+	Pos      int          `desc:"undo position in stack -- groups before this have been done, those at and after it have been undone and can be redone"`
+	Mu       sync.Mutex   `json:"-" xml:"-" view:"-" desc:"mutex protecting all updates"`
+	grouping int          // depth of BeginGroup calls
+	applying bool         // true while undoing or redoing -- nothing is saved then
+}
+
+// Reset clears all undo records
+func (us *UndoStack) Reset() {
+	us.Mu.Lock()
+	undoDiscard(us.Groups[:us.Pos], true)
+	undoDiscard(us.Groups[us.Pos:], false)
+	us.Groups = nil
+	us.Pos = 0
+	us.Mu.Unlock()
+}
+
+// undoDiscard calls Discard on the UndoDiscarder commands of given groups,
+// which are being discarded from the stack -- done is true if the groups
+// were done (not undone)
+func undoDiscard(gps []*UndoGroup, done bool) {
+	for _, gp := range gps {
+		for _, cmd := range gp.Cmds {
+			if dc, ok := cmd.(UndoDiscarder); ok {
+				dc.Discard(done)
+			}
+		}
+	}
+}
+
+// Save saves given command to the stack, after it has been done -- it is
+// added to the current group if within BeginGroup / EndGroup or within
+// UndoGroupDelayMSec of the previous command, else it starts a new group.
+// Any commands that had been undone are discarded.  Nothing is saved while
+// undoing or redoing.
+func (us *UndoStack) Save(cmd UndoCmd) {
+	if us == nil || us.Off || cmd == nil {
+		return
+	}
+	us.Mu.Lock()
+	defer us.Mu.Unlock()
+	if us.applying {
+		return
+	}
+	now := time.Now()
+	if us.Pos < len(us.Groups) {
+		if UndoTrace {
+			fmt.Printf("Undo: resetting to pos: %v len was: %v\n", us.Pos, len(us.Groups))
+		}
+		undoDiscard(us.Groups[us.Pos:], false)
+		us.Groups = us.Groups[:us.Pos]
+		if us.grouping > 0 { // current group was undone -- start over
+			us.Groups = append(us.Groups, &UndoGroup{})
+		}
+	}
+	var gp *UndoGroup
+	if n := len(us.Groups); n > 0 {
+		lgp := us.Groups[n-1]
+		if us.grouping > 0 || int(now.Sub(lgp.Time)/time.Millisecond) <= UndoGroupDelayMSec {
+			gp = lgp
+		}
+	}
+	if gp == nil {
+		gp = &UndoGroup{}
+		us.Groups = append(us.Groups, gp)
+		if ex := len(us.Groups) - UndoMaxGroups; UndoMaxGroups > 0 && ex > 0 {
+			undoDiscard(us.Groups[:ex], true)
+			us.Groups = us.Groups[ex:]
+		}
+	}
+	if gp.Label == "" {
+		gp.Label = cmd.Label()
+	}
+	gp.Cmds = append(gp.Cmds, cmd)
+	gp.Time = now
+	us.Pos = len(us.Groups)
+	if UndoTrace {
+		fmt.Printf("Undo: save to pos: %v: group: %v cmd: %v\n", us.Pos, gp.Label, cmd.Label())
+	}
+}
+
+// BeginGroup starts a group of commands that are undone together, with given
+// label (if non-empty, else the label of its first command), until
+// the matching EndGroup -- groups can be nested, in which case the outer
+// one determines the group
+func (us *UndoStack) BeginGroup(label string) {
+	if us == nil || us.Off {
+		return
+	}
+	us.Mu.Lock()
+	defer us.Mu.Unlock()
+	if us.applying {
+		return
+	}
+	us.grouping++
+	if us.grouping > 1 {
+		return
+	}
+	undoDiscard(us.Groups[us.Pos:], false)
+	us.Groups = us.Groups[:us.Pos]
+	us.Groups = append(us.Groups, &UndoGroup{Label: label, Time: time.Now()})
+	us.Pos = len(us.Groups)
+}
+
+// ContinueGroup is like BeginGroup, except that the commands are added to
+// the last group if it has given label and has not been undone, e.g., for the
+// deletion of the nodes moved by drag-n-drop, which is done at the source
+// after their insertion at the target -- end it with EndGroup
+func (us *UndoStack) ContinueGroup(label string) {
+	if us == nil || us.Off {
+		return
+	}
+	us.Mu.Lock()
+	n := len(us.Groups)
+	if us.applying || us.grouping > 0 || n == 0 || us.Pos < n || us.Groups[n-1].Label != label {
+		us.Mu.Unlock()
+		us.BeginGroup(label)
+		return
+	}
+	us.grouping++
+	us.Mu.Unlock()
+}
+
+// EndGroup ends the group started by BeginGroup -- the group is removed if
+// no commands were saved in it
+func (us *UndoStack) EndGroup() {
+	if us == nil || us.Off {
+		return
+	}
+	us.Mu.Lock()
+	defer us.Mu.Unlock()
+	if us.applying || us.grouping == 0 {
+		return
+	}
+	us.grouping--
+	if us.grouping > 0 {
+		return
+	}
+	if n := len(us.Groups); n > 0 && len(us.Groups[n-1].Cmds) == 0 {
+		us.Groups = us.Groups[:n-1]
+		if us.Pos > len(us.Groups) {
+			us.Pos = len(us.Groups)
+		}
+	}
+	if n := len(us.Groups); n > 0 {
+		us.Groups[n-1].Time = time.Time{} // next command starts a new group
+	}
+}
+
+// CanUndo returns true if there is a group of commands to undo
+func (us *UndoStack) CanUndo() bool {
+	if us == nil || us.Off {
+		return false
+	}
+	us.Mu.Lock()
+	defer us.Mu.Unlock()
+	return us.Pos > 0
+}
+
+// CanRedo returns true if there is a group of undone commands to redo
+func (us *UndoStack) CanRedo() bool {
+	if us == nil || us.Off {
+		return false
+	}
+	us.Mu.Lock()
+	defer us.Mu.Unlock()
+	return us.Pos < len(us.Groups)
+}
+
+// UndoLabel returns the (translated) label for the Undo menu item, e.g.,
+// "Undo Rename", or just "Undo" if there is nothing to undo
+func (us *UndoStack) UndoLabel() string {
+	if !us.CanUndo() {
+		return i18n.Tr("Undo")
+	}
+	us.Mu.Lock()
+	lbl := us.Groups[us.Pos-1].Label
+	us.Mu.Unlock()
+	return fmt.Sprintf(i18n.Tr("Undo %v"), i18n.Tr(lbl))
+}
+
+// RedoLabel returns the (translated) label for the Redo menu item, e.g.,
+// "Redo Rename", or just "Redo" if there is nothing to redo
+func (us *UndoStack) RedoLabel() string {
+	if !us.CanRedo() {
+		return i18n.Tr("Redo")
+	}
+	us.Mu.Lock()
+	lbl := us.Groups[us.Pos].Label
+	us.Mu.Unlock()
+	return fmt.Sprintf(i18n.Tr("Redo %v"), i18n.Tr(lbl))
+}
+
+// Undo undoes the last group of commands, in reverse order, returning false
+// if there was nothing to undo
+func (us *UndoStack) Undo() bool {
+	if us == nil || us.Off {
+		return false
+	}
+	us.Mu.Lock()
+	if us.Pos == 0 || us.applying {
+		us.Mu.Unlock()
+		return false
+	}
+	us.Pos--
+	gp := us.Groups[us.Pos]
+	us.applying = true
+	us.Mu.Unlock()
+	if UndoTrace {
+		fmt.Printf("Undo: undo of group: %v at pos: %v\n", gp.Label, us.Pos)
+	}
+	for i := len(gp.Cmds) - 1; i >= 0; i-- {
+		gp.Cmds[i].Undo()
+	}
+	us.Mu.Lock()
+	us.applying = false
+	us.Mu.Unlock()
+	return true
+}
+
+// Redo re-does the last undone group of commands, returning false if there
+// was nothing to redo
+func (us *UndoStack) Redo() bool {
+	if us == nil || us.Off {
+		return false
+	}
+	us.Mu.Lock()
+	if us.Pos >= len(us.Groups) || us.applying {
+		us.Mu.Unlock()
+		return false
+	}
+	gp := us.Groups[us.Pos]
+	us.Pos++
+	us.applying = true
+	us.Mu.Unlock()
+	if UndoTrace {
+		fmt.Printf("Undo: redo of group: %v at pos: %v\n", gp.Label, us.Pos-1)
+	}
+	for _, cmd := range gp.Cmds {
+		cmd.Redo()
+	}
+	us.Mu.Lock()
+	us.applying = false
+	us.Mu.Unlock()
+	return true
+}
+
+// UndoStacker is implemented by nodes that have their own UndoStack for the
+// edits made in the views within them, e.g., the editor of a document, where
+// the default is the stack of the window (see UndoStackFor)
+type UndoStacker interface {
+	// UndoStack returns the undo stack for the edits made within this node
+	UndoStack() *UndoStack
+}
+
+// UndoStackFor returns the undo stack for the edits made in given node: that
+// of the closest UndoStacker at or above it, which is its window if no other
+// -- returns nil if none
+func UndoStackFor(k ki.Ki) *UndoStack {
+	if k == nil || k.This() == nil {
+		return nil
+	}
+	var us *UndoStack
+	k.FuncUp(0, nil, func(k ki.Ki, level int, d interface{}) bool {
+		if usr, ok := k.(UndoStacker); ok {
+			us = usr.UndoStack()
+			return ki.Break
+		}
+		return ki.Continue
+	})
+	if us != nil {
+		return us
+	}
+	if nii, ok := k.(Node2D); ok {
+		if win := nii.AsNode2D().ParentWindow(); win != nil {
+			return win.UndoStack()
+		}
+	}
+	return nil
+}
+
+// UndoStack returns the window's undo stack, used for the edits made in its
+// views unless they are within another UndoStacker -- see UndoStackFor
+func (w *Window) UndoStack() *UndoStack {
+	return &w.Undos
+}
+
+// UndoKeyFun does the KeyFunUndo or KeyFunRedo key function: it sends the
+// key event to the focus, for widgets that have their own undo (e.g.,
+// TextView), and otherwise undoes or redoes on the UndoStackFor the focus
+// (or the window, without focus)
+func (w *Window) UndoKeyFun(kf KeyFuns) {
+	foc := w.EventMgr.CurFocus()
+	if foc != nil {
+		chord := ActiveKeyMap.ChordForFun(kf)
+		if chord != "" {
+			if r, mods, err := chord.Decode(); err == nil {
+				ke := key.ChordEvent{}
+				ke.SetTime()
+				ke.Modifiers = mods
+				ke.Rune = r
+				ke.Action = key.Press
+				w.EventMgr.SendEventSignal(&ke, false)
+				if ke.IsProcessed() {
+					return
+				}
+			}
+		}
+	}
+	w.UndoFocus(kf)
+}
+
+// UndoFocus undoes (for KeyFunUndo) or redoes (for KeyFunRedo) on the
+// UndoStackFor the focus (or the window, without focus), returning false if
+// there was nothing to undo or redo
+func (w *Window) UndoFocus(kf KeyFuns) bool {
+	us := w.undoStackFocus()
+	if kf == KeyFunRedo {
+		return us.Redo()
+	}
+	return us.Undo()
+}
+
+// AddUndoRedo adds Undo and Redo actions, labeled with the edit to undo or
+// redo on the UndoStackFor the window focus (e.g., "Undo Rename"), which do
+// the KeyFunUndo and KeyFunRedo key functions (see Window.UndoKeyFun)
+func (m *Menu) AddUndoRedo(win *Window) {
+	m.AddAction(ActOpts{Label: "Undo", ShortcutKey: KeyFunUndo,
+		UpdateFunc: func(ac *Action) {
+			ac.SetText(win.undoStackFocus().UndoLabel())
+		}}, win, func(recv, send ki.Ki, sig int64, data interface{}) {
+		ww := recv.Embed(KiT_Window).(*Window)
+		ww.UndoKeyFun(KeyFunUndo)
+	})
+	m.AddAction(ActOpts{Label: "Redo", ShortcutKey: KeyFunRedo,
+		UpdateFunc: func(ac *Action) {
+			ac.SetText(win.undoStackFocus().RedoLabel())
+		}}, win, func(recv, send ki.Ki, sig int64, data interface{}) {
+		ww := recv.Embed(KiT_Window).(*Window)
+		ww.UndoKeyFun(KeyFunRedo)
+	})
+}
+
+// undoStackFocus returns the UndoStackFor the focus, or the window's stack
+func (w *Window) undoStackFocus() *UndoStack {
+	if us := UndoStackFor(w.EventMgr.CurFocus()); us != nil {
+		return us
+	}
+	return w.UndoStack()
+}
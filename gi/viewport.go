@@ -646,6 +646,26 @@ func (vp *Viewport2D) Render2D() {
 	}
 }
 
+// AccessInfo2D exposes the main viewport of a window as the window, popup
+// viewports as menus, tooltips or panels, and SVG viewports as images --
+// other viewports are not exposed themselves
+func (vp *Viewport2D) AccessInfo2D(ai *AccessInfo) {
+	vp.WidgetBase.AccessInfo2D(ai)
+	switch {
+	case vp.IsTooltip():
+		ai.Role = AccessToolTip
+	case vp.IsMenu() || vp.IsCompleter() || vp.IsCorrector():
+		ai.Role = AccessMenu
+	case vp.IsPopup():
+		ai.Role = AccessPanel
+	case vp.IsSVG():
+		ai.Role = AccessImage
+	case vp.Win != nil && vp.Win.Viewport == vp:
+		ai.Role = AccessWindow
+		ai.Label = vp.Win.Title
+	}
+}
+
 // PrefSize computes the preferred size of the viewport based on current contents.
 // initSz is the initial size -- e.g., size of screen.
 // Used for auto-sizing windows.
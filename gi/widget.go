@@ -14,7 +14,6 @@ import (
 	"github.com/goki/gi/oswin"
 	"github.com/goki/gi/oswin/mouse"
 	"github.com/goki/gi/units"
-	"github.com/goki/ki/ints"
 	"github.com/goki/ki/ki"
 	"github.com/goki/ki/kit"
 	"github.com/goki/mat32"
@@ -29,6 +28,7 @@ import (
 type WidgetBase struct {
 	Node2DBase
 	Tooltip      string       `desc:"text for tooltip for this widget -- can use HTML formatting"`
+	TooltipOpts  *TooltipOpts `json:"-" xml:"-" desc:"optional rich tooltip options for this widget, with icon, image, delays etc -- if set, these are used instead of the plain Tooltip text (which is used if the options have no Text)"`
 	Sty          Style        `json:"-" xml:"-" desc:"styling settings for this widget -- set in SetStyle2D during an initialization step, and when the structure changes"`
 	DefStyle     *Style       `copy:"-" view:"-" json:"-" xml:"-" desc:"default style values computed by a parent widget for us -- if set, we are a part of a parent widget and should use these as our starting styles instead of type-based defaults"`
 	LayState     LayoutState  `copy:"-" json:"-" xml:"-" desc:"all the layout state information for this item"`
@@ -53,6 +53,10 @@ func (wb *WidgetBase) CopyFieldsFrom(frm interface{}) {
 	}
 	wb.Node2DBase.CopyFieldsFrom(&fr.Node2DBase)
 	wb.Tooltip = fr.Tooltip
+	if fr.TooltipOpts != nil {
+		tt := *fr.TooltipOpts
+		wb.TooltipOpts = &tt
+	}
 	wb.Sty.CopyFrom(&fr.Sty)
 }
 
@@ -541,46 +545,11 @@ var TooltipFrameProps = ki.Props{
 	"box-shadow.color":    &Prefs.Colors.Shadow,
 }
 
-// PopupTooltip pops up a viewport displaying the tooltip text
+// PopupTooltip pops up a viewport displaying the tooltip text, which can
+// use HTML formatting -- see PopupTooltipOpts for rich tooltips
 func PopupTooltip(tooltip string, x, y int, parVp *Viewport2D, name string) *Viewport2D {
-	win := parVp.Win
-	mainVp := win.Viewport
-	pvp := Viewport2D{}
-	pvp.InitName(&pvp, name+"Tooltip")
-	pvp.Win = win
-	updt := pvp.UpdateStart()
-	pvp.SetProp("color", &Prefs.Colors.Font)
-	pvp.Fill = false
-	pvp.SetFlag(int(VpFlagPopup))
-	pvp.SetFlag(int(VpFlagTooltip))
-
-	pvp.Geom.Pos = image.Point{x, y}
-	pvp.SetFlag(int(VpFlagPopupDestroyAll)) // nuke it all
-	frame := pvp.AddNewChild(KiT_Frame, "Frame").(*Frame)
-	frame.Lay = LayoutVert
-	frame.SetProps(TooltipFrameProps, ki.NoUpdate)
-	lbl := frame.AddNewChild(KiT_Label, "ttlbl").(*Label)
-	lbl.SetProp("white-space", WhiteSpaceNormal) // wrap
-
-	mwdots := parVp.Sty.UnContext.ToDots(40, units.Em)
-	mwdots = mat32.Min(mwdots, float32(mainVp.Geom.Size.X-20))
-
-	lbl.SetProp("max-width", units.NewValue(mwdots, units.Dot))
-	lbl.Text = tooltip
-	frame.Init2DTree()
-	frame.Style2DTree()                                    // sufficient to get sizes
-	frame.LayState.Alloc.Size = mainVp.LayState.Alloc.Size // give it the whole vp initially
-	frame.Size2DTree(0)                                    // collect sizes
-	pvp.Win = nil
-	vpsz := frame.LayState.Size.Pref.Min(mainVp.LayState.Alloc.Size).ToPoint()
-	x = ints.MinInt(x, mainVp.Geom.Size.X-vpsz.X) // fit
-	y = ints.MinInt(y, mainVp.Geom.Size.Y-vpsz.Y) // fit
-	pvp.Resize(vpsz)
-	pvp.Geom.Pos = image.Point{x, y}
-	pvp.UpdateEndNoSig(updt)
-
-	win.PushPopup(pvp.This())
-	return &pvp
+	tv := PopupTooltipOpts(&TooltipOpts{Text: tooltip}, x, y, image.Rectangle{}, parVp, name)
+	return &tv.Viewport2D
 }
 
 // WidgetSignals are general signals that all widgets can send, via WidgetSig
@@ -629,12 +598,9 @@ func (wb *WidgetBase) HoverTooltipEvent() {
 	wb.ConnectEvent(oswin.MouseHoverEvent, RegPri, func(recv, send ki.Ki, sig int64, d interface{}) {
 		me := d.(*mouse.HoverEvent)
 		wbb := recv.Embed(KiT_WidgetBase).(*WidgetBase)
-		if wbb.Tooltip != "" {
+		if tt := wbb.TooltipOptions(); tt != nil && tt.HasContent() {
 			me.SetProcessed()
-			pos := wbb.WinBBox.Max
-			pos.X -= 20
-			mvp := wbb.ViewportSafe()
-			PopupTooltip(wbb.Tooltip, pos.X, pos.Y, mvp, wbb.Nm)
+			wbb.PopupTooltipFor(tt, me.Where)
 		}
 	})
 }
@@ -145,16 +145,20 @@ type Window struct {
 	MainMenu          *MenuBar          `json:"-" xml:"-" desc:"main menu -- is first element of MasterVLay always -- leave empty to not render.  On MacOS, this drives screen main menu"`
 	OverTex           oswin.Texture     `json:"-" xml:"-" view:"-" desc:"overlay texture that is updated from Sprites"`
 	Sprites           Sprites           `json:"-" xml:"-" desc:"sprites are named images that are rendered into the overtex."`
+	Undos             UndoStack         `json:"-" xml:"-" view:"-" desc:"undo stack for the edits made in the views of this window, unless they are within another UndoStacker -- see UndoStackFor"`
 	ActiveSprites     int               `json:"-" xml:"-" desc:"number of currently active sprites -- must use ActivateSprite to keep track of whether there are active sprites."`
+	Toasts            ToastStack        `json:"-" xml:"-" desc:"toast notifications shown in this window (rendered as sprites), and their history"`
 	DirectUps         map[Node2D]Node2D `json:"-" xml:"-" view:"-" desc:"list of objects that do direct upload rendering to window (e.g., gi3d.Scene)"`
 	UpMu              sync.Mutex        `json:"-" xml:"-" view:"-" desc:"mutex that protects all updating / uploading of Textures"`
 	Shortcuts         Shortcuts         `json:"-" xml:"-" desc:"currently active shortcuts for this window (shortcuts are always window-wide -- use widget key event processing for more local key functions)"`
+	Commands          CommandList       `json:"-" xml:"-" desc:"commands registered for the command palette of this window, in addition to its menu, toolbar and shortcut actions, and AppCommands"`
 	Popup             ki.Ki             `json:"-" xml:"-" desc:"Current popup viewport that gets all events"`
 	PopupStack        []ki.Ki           `json:"-" xml:"-" desc:"stack of popups"`
 	NextPopup         ki.Ki             `json:"-" xml:"-" desc:"this popup will be pushed at the end of the current event cycle -- use SetNextPopup"`
 	PopupFocus        ki.Ki             `json:"-" xml:"-" desc:"node to focus on when next popup is activated -- use SetNextPopup"`
 	DelPopup          ki.Ki             `json:"-" xml:"-" desc:"this popup will be popped at the end of the current event cycle -- use SetDelPopup"`
 	PopMu             sync.RWMutex      `json:"-" xml:"-" view:"-" desc:"read-write mutex that protects popup updating and access"`
+	Access            *AccessTree       `json:"-" xml:"-" view:"-" desc:"accessibility tree mirroring the widgets in this window, for assistive technology such as screen readers -- nil unless EnableAccess has been called"`
 	lastWinMenuUpdate time.Time
 	// below are internal vars used during the event loop
 	delPop        bool
@@ -309,6 +313,7 @@ func NewWindow(name, title string, opts *oswin.NewWindowOptions) *Window {
 	win := &Window{}
 	win.InitName(win, name)
 	win.EventMgr.Master = win
+	win.Toasts.Win = win
 	win.Title = title
 	win.SetOnlySelfUpdate() // has its own PublishImage update logic
 	var err error
@@ -691,6 +696,7 @@ func (w *Window) Resized(sz image.Point) {
 	WinGeomPrefs.RecordPref(w)
 	w.UpMu.Unlock()
 	w.FullReRender()
+	w.Toasts.Layout() // sprites were inactivated
 }
 
 // Close closes the window -- this is not a request -- it means:
@@ -713,6 +719,7 @@ func (w *Window) CloseReq() {
 
 // Closed frees any resources after the window has been closed.
 func (w *Window) Closed() {
+	Prefs.SaveTableViews()
 	w.UpMu.Lock()
 	AllWindows.Delete(w)
 	MainWindows.Delete(w)
@@ -877,6 +884,18 @@ func (w *Window) SendCustomEvent(data interface{}) {
 	oswin.SendCustomEvent(w.OSWin, data)
 }
 
+// RunOnWin arranges for given function to be called on the window's own
+// event-processing goroutine, in the order received relative to other
+// events -- this is the safe way for background goroutines (e.g., a Task)
+// to update widgets.  It is sent as a CustomEvent with the function as its
+// Data, which is intercepted in HiPriorityEvents and not otherwise delivered.
+func (w *Window) RunOnWin(fun func()) {
+	if w == nil || w.IsClosed() {
+		return
+	}
+	w.SendCustomEvent(fun)
+}
+
 /////////////////////////////////////////////////////////////////////////////
 //                   Rendering
 
@@ -1136,6 +1155,9 @@ func (w *Window) Publish() {
 	// pr.End()
 	w.ClearWinUpdating()
 	w.UpMu.Unlock()
+	if w.Access != nil {
+		w.Access.Update()
+	}
 }
 
 // SignalWindowPublish is the signal receiver function that publishes the
@@ -1530,11 +1552,11 @@ func (w *Window) ProcessEvent(evi oswin.Event) {
 	}
 
 	if hasFocus && !evi.IsProcessed() {
-		evToPopup := !w.CurPopupIsTooltip() // don't send events to tooltips!
+		evToPopup := !w.CurPopupIsTooltip() || w.TooltipHasMouse(evi) // don't send events to tooltips, except interactive ones!
 		w.EventMgr.SendEventSignal(evi, evToPopup)
 		if !w.delPop && et == oswin.MouseMoveEvent && !evi.IsProcessed() {
 			didFocus := w.EventMgr.GenMouseFocusEvents(evi.(*mouse.MoveEvent), evToPopup)
-			if didFocus && w.CurPopupIsTooltip() {
+			if didFocus && w.CurPopupIsTooltip() && !w.KeepTooltip(evi.Pos()) {
 				w.delPop = true
 			}
 		}
@@ -1570,7 +1592,7 @@ func (w *Window) ProcessEvent(evi oswin.Event) {
 		cpop := w.CurPopup()
 		if cpop != nil && !w.delPop {
 			if PopupIsTooltip(cpop) {
-				if et != oswin.MouseMoveEvent {
+				if et != oswin.MouseMoveEvent && !w.TooltipHasMouse(evi) {
 					w.delPop = true
 				}
 			} else if me, ok := evi.(*mouse.Event); ok {
@@ -1652,6 +1674,12 @@ func (w *Window) FilterEvent(evi oswin.Event) bool {
 // Window gets first crack at these events, and handles window-specific ones
 // returns true if processing should continue and false if was handled
 func (w *Window) HiPriorityEvents(evi oswin.Event) bool {
+	if w.Toasts.MouseEvent(evi) {
+		return false
+	}
+	if w.InspectEvent(evi) {
+		return false
+	}
 	switch e := evi.(type) {
 	case *window.Event:
 		switch e.Action {
@@ -1754,6 +1782,12 @@ func (w *Window) HiPriorityEvents(evi oswin.Event) bool {
 		if e.Action == dnd.External {
 			w.EventMgr.DNDDropMod = e.Mod
 		}
+	case *oswin.CustomEvent:
+		if fun, ok := e.Data.(func()); ok {
+			e.SetProcessed()
+			fun()
+			return false
+		}
 	case *key.ChordEvent:
 		keyDelPop := w.KeyChordEventHiPri(e)
 		if keyDelPop {
@@ -1964,8 +1998,13 @@ func (w *Window) CurPopupIsTooltip() bool {
 	return PopupIsTooltip(w.CurPopup())
 }
 
-// DeleteTooltip deletes any tooltip popup (called when hover ends)
+// DeleteTooltip deletes any tooltip popup (called when hover ends), unless
+// it should be kept open (see KeepTooltip)
 func (w *Window) DeleteTooltip() {
+	if pos := w.EventMgr.LastMousePos; w.KeepTooltip(pos) {
+		w.FollowTooltip(pos)
+		return
+	}
 	w.PopMu.RLock()
 	if w.CurPopupIsTooltip() {
 		w.delPop = true
@@ -2144,6 +2183,9 @@ func (w *Window) KeyChordEventLowPri(e *key.ChordEvent) bool {
 		SaveImage(fnm, w.Viewport.Pixels)
 		fmt.Printf("Saved Window Image to: %s\n", fnm)
 		e.SetProcessed()
+	case KeyFunCommandPalette:
+		e.SetProcessed()
+		w.CommandPalette()
 	case KeyFunZoomIn:
 		w.ZoomDPI(1)
 		e.SetProcessed()
@@ -2159,6 +2201,10 @@ func (w *Window) KeyChordEventLowPri(e *key.ChordEvent) bool {
 	case KeyFunWinFocusNext:
 		e.SetProcessed()
 		AllWindows.FocusNext()
+	case KeyFunUndo, KeyFunRedo:
+		if w.UndoFocus(kf) {
+			e.SetProcessed()
+		}
 	}
 	switch cs { // some other random special codes, during dev..
 	case "Control+Alt+R":
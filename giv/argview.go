@@ -136,10 +136,11 @@ func (av *ArgView) ConfigArgsGrid() {
 			// note: updating here is redundant -- relevant field will have already updated
 			avv.ViewSig.Emit(avv.This(), 0, nil)
 		})
-		lbl.Text = ad.Name
-		lbl.Tooltip = ad.Desc
+		lbl.Text = gi.TrText(lbl, ad.Name)
+		lbl.Tooltip = gi.TrTooltip(lbl, ad.Desc)
 		widg := sg.Child((i * 2) + 1).(gi.Node2D)
 		widg.SetProp("horizontal-align", gi.AlignLeft)
+		lbl.AccessFor = widg
 		ad.View.ConfigWidget(widg)
 	}
 	sg.UpdateEnd(updt)
@@ -154,3 +155,9 @@ func (av *ArgView) UpdateArgs() {
 	}
 	av.UpdateEnd(updt)
 }
+
+func (av *ArgView) AccessInfo2D(ai *gi.AccessInfo) {
+	av.Frame.AccessInfo2D(ai)
+	ai.Role = gi.AccessForm
+	ai.Label = gi.AccessPlainText(av.Title)
+}
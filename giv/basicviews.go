@@ -554,6 +554,55 @@ func (vv *BoolValueView) ConfigWidget(widg gi.Node2D) {
 	vv.UpdateWidget()
 }
 
+////////////////////////////////////////////////////////////////////////////////////////
+//  TextAreaValueView
+
+// TextAreaValueView presents a multi-line gi.TextArea for a string, for
+// fields with view:"multiline" -- the rows tag sets the number of rows shown
+type TextAreaValueView struct {
+	ValueViewBase
+}
+
+var KiT_TextAreaValueView = kit.Types.AddType(&TextAreaValueView{}, nil)
+
+func (vv *TextAreaValueView) WidgetType() reflect.Type {
+	vv.WidgetTyp = gi.KiT_TextArea
+	return vv.WidgetTyp
+}
+
+func (vv *TextAreaValueView) UpdateWidget() {
+	if vv.Widget == nil {
+		return
+	}
+	ta := vv.Widget.(*gi.TextArea)
+	npv := kit.NonPtrValue(vv.Value)
+	ta.SetText(kit.ToString(npv.Interface()))
+}
+
+func (vv *TextAreaValueView) ConfigWidget(widg gi.Node2D) {
+	vv.Widget = widg
+	vv.StdConfigWidget(widg)
+	ta := vv.Widget.(*gi.TextArea)
+	ta.SetStretchMaxWidth()
+	ta.Tooltip, _ = vv.Tag("desc")
+	ta.SetInactiveState(vv.This().(ValueView).IsInactive())
+	if rowstag, ok := vv.Tag("rows"); ok {
+		if rows, ok := kit.ToInt(rowstag); ok {
+			ta.SetProp("rows", int(rows))
+		}
+	}
+	ta.TextAreaSig.ConnectOnly(vv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+		if sig == int64(gi.TextFieldDone) || sig == int64(gi.TextFieldDeFocused) {
+			vvv, _ := recv.Embed(KiT_TextAreaValueView).(*TextAreaValueView)
+			taa := send.(*gi.TextArea)
+			if vvv.SetValue(taa.Text()) {
+				vvv.UpdateWidget() // always update after setting value..
+			}
+		}
+	})
+	vv.UpdateWidget()
+}
+
 ////////////////////////////////////////////////////////////////////////////////////////
 //  IntValueView
 
@@ -617,6 +666,8 @@ func (vv *IntValueView) ConfigWidget(widg gi.Node2D) {
 	}
 	if fmttag, ok := vv.Tag("format"); ok {
 		sb.Format = fmttag
+	} else {
+		sb.Format = "%d" // only integers are valid input
 	}
 	sb.SpinBoxSig.ConnectOnly(vv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
 		vvv, _ := recv.Embed(KiT_IntValueView).(*IntValueView)
@@ -699,6 +750,106 @@ func (vv *FloatValueView) ConfigWidget(widg gi.Node2D) {
 	vv.UpdateWidget()
 }
 
+////////////////////////////////////////////////////////////////////////////////////////
+//  RangeValueView
+
+// RangeValueView presents a gi.RangeSlider for a gi.Range32, or a
+// [2]float32 with a view:"range" tag -- min, max and step tags set the
+// slider limits (0..1 by default), log:"+" uses a log scale, and
+// ticks:"<step>" adds labeled tick marks, formatted with any format tag
+type RangeValueView struct {
+	ValueViewBase
+}
+
+var KiT_RangeValueView = kit.Types.AddType(&RangeValueView{}, nil)
+
+func (vv *RangeValueView) WidgetType() reflect.Type {
+	vv.WidgetTyp = gi.KiT_RangeSlider
+	return vv.WidgetTyp
+}
+
+// RangeVals returns pointers to the low and high values, for a gi.Range32
+// or [2]float32 Value -- nil if not a supported type
+func (vv *RangeValueView) RangeVals() (low, high *float32) {
+	switch v := kit.PtrValue(vv.Value).Interface().(type) {
+	case *gi.Range32:
+		return &v.Min, &v.Max
+	case *[2]float32:
+		return &v[0], &v[1]
+	}
+	return nil, nil
+}
+
+func (vv *RangeValueView) UpdateWidget() {
+	if vv.Widget == nil {
+		return
+	}
+	rs := vv.Widget.(*gi.RangeSlider)
+	if low, high := vv.RangeVals(); low != nil {
+		rs.SetRange(*low, *high)
+	}
+}
+
+func (vv *RangeValueView) ConfigWidget(widg gi.Node2D) {
+	vv.Widget = widg
+	vv.StdConfigWidget(widg)
+	rs := vv.Widget.(*gi.RangeSlider)
+	rs.Tooltip, _ = vv.Tag("desc")
+	rs.SetInactiveState(vv.This().(ValueView).IsInactive())
+	rs.SetMinPrefWidth(units.NewCh(20))
+	rs.SetStretchMaxWidth()
+	rs.Defaults()
+	if mintag, ok := vv.Tag("min"); ok {
+		if minv, ok := kit.ToFloat32(mintag); ok {
+			rs.Min = minv
+		}
+	}
+	if maxtag, ok := vv.Tag("max"); ok {
+		if maxv, ok := kit.ToFloat32(maxtag); ok {
+			rs.Max = maxv
+		}
+	}
+	rs.Step = (rs.Max - rs.Min) / 100
+	rs.PageStep = (rs.Max - rs.Min) / 10
+	if steptag, ok := vv.Tag("step"); ok {
+		if step, ok := kit.ToFloat32(steptag); ok {
+			rs.Step = step
+			rs.PageStep = 10 * step
+			rs.Snap = true
+		}
+	}
+	if logtag, ok := vv.Tag("log"); ok {
+		rs.Log, _ = kit.ToBool(logtag)
+		if rs.IsLog() && !rs.Snap {
+			rs.Step = 0.05 // decades
+			rs.PageStep = 0.5
+		}
+	}
+	if tktag, ok := vv.Tag("ticks"); ok {
+		if tks, ok := kit.ToFloat32(tktag); ok {
+			rs.TickStep = tks
+			rs.TickLabels = true
+		}
+	}
+	if fmttag, ok := vv.Tag("format"); ok {
+		rs.TickFormat = fmttag
+	}
+	rs.SliderSig.ConnectOnly(vv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+		if sig != int64(gi.SliderValueChanged) {
+			return
+		}
+		vvv, _ := recv.Embed(KiT_RangeValueView).(*RangeValueView)
+		rg := data.(gi.Range32)
+		low, high := vvv.RangeVals()
+		if low == nil || (*low == rg.Min && *high == rg.Max) {
+			return
+		}
+		*low, *high = rg.Min, rg.Max
+		vvv.ViewSig.Emit(vvv.This(), 0, nil)
+	})
+	vv.UpdateWidget()
+}
+
 ////////////////////////////////////////////////////////////////////////////////////////
 //  EnumValueView
 
@@ -1034,9 +1185,16 @@ func (vv *NilValueView) ConfigWidget(widg gi.Node2D) {
 ////////////////////////////////////////////////////////////////////////////////////////
 //  TimeValueView
 
-var DefaultTimeFormat = "2006-01-02 15:04:05 MST"
+// DefaultTimeFormat is the time.Format layout used by TimeValueView for
+// full date and time values -- if blank, the date and time formats of the
+// gi.ActiveLocaleFormat (set in the Preferences) are used, with the seconds
+// and zone offset.  A format tag on the field overrides it.
+var DefaultTimeFormat = ""
 
-// TimeValueView presents a checkbox for a boolean
+// TimeValueView presents a gi.DateTimeField for editing a time.Time (or
+// FileTime), with a popup calendar -- view:"date" or view:"time" tags
+// restrict it to the date or time of day, and min / max tags in the
+// 2006-01-02 format limit the dates.
 type TimeValueView struct {
 	ValueViewBase
 }
@@ -1044,7 +1202,7 @@ type TimeValueView struct {
 var KiT_TimeValueView = kit.Types.AddType(&TimeValueView{}, nil)
 
 func (vv *TimeValueView) WidgetType() reflect.Type {
-	vv.WidgetTyp = gi.KiT_TextField
+	vv.WidgetTyp = gi.KiT_DateTimeField
 	return vv.WidgetTyp
 }
 
@@ -1064,32 +1222,106 @@ func (vv *TimeValueView) UpdateWidget() {
 	if vv.Widget == nil {
 		return
 	}
-	tf := vv.Widget.(*gi.TextField)
+	dt := vv.Widget.(*gi.DateTimeField)
 	tm := vv.TimeVal()
-	tf.SetText(tm.Format(DefaultTimeFormat))
+	dt.SetTime(*tm)
 }
 
 func (vv *TimeValueView) ConfigWidget(widg gi.Node2D) {
 	vv.Widget = widg
 	vv.StdConfigWidget(widg)
-	tf := vv.Widget.(*gi.TextField)
-	tf.SetStretchMaxWidth()
-	tf.Tooltip, _ = vv.Tag("desc")
-	tf.SetInactiveState(vv.This().(ValueView).IsInactive())
-	tf.SetProp("min-width", units.NewCh(float32(len(DefaultTimeFormat)+2)))
-	tf.TextFieldSig.ConnectOnly(vv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
-		if sig == int64(gi.TextFieldDone) || sig == int64(gi.TextFieldDeFocused) {
-			vvv, _ := recv.Embed(KiT_TimeValueView).(*TimeValueView)
-			tf := send.(*gi.TextField)
-			nt, err := time.Parse(DefaultTimeFormat, tf.Text())
-			if err != nil {
-				log.Println(err)
-			} else {
-				tm := vvv.TimeVal()
-				*tm = nt
-				vvv.ViewSig.Emit(vvv.This(), 0, nil)
-				vvv.UpdateWidget()
-			}
+	dt := vv.Widget.(*gi.DateTimeField)
+	dt.Tooltip, _ = vv.Tag("desc")
+	dt.SetInactiveState(vv.This().(ValueView).IsInactive())
+	dt.Mode = gi.DateTimeFieldDateTime
+	dt.Format = DefaultTimeFormat
+	dt.FirstWeekday = gi.ActiveLocaleFormat.FirstWeekday
+	if vtag, ok := vv.Tag("view"); ok {
+		switch vtag {
+		case "date":
+			dt.Mode = gi.DateTimeFieldDate
+			dt.Format = ""
+		case "time":
+			dt.Mode = gi.DateTimeFieldTime
+			dt.Format = ""
+		}
+	}
+	if ftag, ok := vv.Tag("format"); ok {
+		dt.Format = ftag
+	}
+	for _, lim := range []string{"min", "max"} {
+		ltag, ok := vv.Tag(lim)
+		if !ok {
+			continue
+		}
+		ld, err := time.Parse(gi.DateTimeFieldFormats[gi.DateTimeFieldDate], ltag)
+		if err != nil {
+			log.Printf("giv.TimeValueView: %v tag: %v\n", lim, err)
+			continue
+		}
+		if lim == "min" {
+			dt.MinDate = ld
+		} else {
+			dt.MaxDate = ld
+		}
+	}
+	dt.DateTimeSig.ConnectOnly(vv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+		vvv, _ := recv.Embed(KiT_TimeValueView).(*TimeValueView)
+		dtt := send.(*gi.DateTimeField)
+		tm := vvv.TimeVal()
+		*tm = dtt.Time
+		vvv.ViewSig.Emit(vvv.This(), 0, nil)
+		vvv.UpdateWidget()
+	})
+	vv.UpdateWidget()
+}
+
+////////////////////////////////////////////////////////////////////////////////////////
+//  DurationValueView
+
+// DurationValueView presents a gi.DurationField for editing a
+// time.Duration, either typed in the time.ParseDuration format, e.g., 1h30m
+// or 250ms, or chosen with the spin boxes of its popup gi.DurationPicker
+type DurationValueView struct {
+	ValueViewBase
+}
+
+var KiT_DurationValueView = kit.Types.AddType(&DurationValueView{}, nil)
+
+func (vv *DurationValueView) WidgetType() reflect.Type {
+	vv.WidgetTyp = gi.KiT_DurationField
+	return vv.WidgetTyp
+}
+
+// DurationVal decodes Value into a *time.Duration value
+func (vv *DurationValueView) DurationVal() *time.Duration {
+	dv, _ := kit.PtrValue(vv.Value).Interface().(*time.Duration)
+	return dv
+}
+
+func (vv *DurationValueView) UpdateWidget() {
+	if vv.Widget == nil {
+		return
+	}
+	df := vv.Widget.(*gi.DurationField)
+	if dv := vv.DurationVal(); dv != nil {
+		df.SetDuration(*dv)
+	}
+}
+
+func (vv *DurationValueView) ConfigWidget(widg gi.Node2D) {
+	vv.Widget = widg
+	vv.StdConfigWidget(widg)
+	df := vv.Widget.(*gi.DurationField)
+	df.Tooltip, _ = vv.Tag("desc")
+	df.SetInactiveState(vv.This().(ValueView).IsInactive())
+	df.DurationSig.ConnectOnly(vv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+		vvv, _ := recv.Embed(KiT_DurationValueView).(*DurationValueView)
+		dff := send.(*gi.DurationField)
+		if dv := vvv.DurationVal(); dv != nil {
+			*dv = dff.Dur
+			vvv.ViewSig.Emit(vvv.This(), 0, nil)
+			vvv.UpdateWidget()
 		}
 	})
 	vv.UpdateWidget()
@@ -328,6 +328,12 @@ func (cv *ColorView) Render2D() {
 	cv.Frame.Render2D()
 }
 
+func (cv *ColorView) AccessInfo2D(ai *gi.AccessInfo) {
+	cv.Frame.AccessInfo2D(ai)
+	ai.Role = gi.AccessColorChooser
+	ai.Value = cv.Color.HexString()
+}
+
 ////////////////////////////////////////////////////////////////////////////////////////
 //  ColorValueView
 
@@ -400,6 +400,7 @@ func FontInfoStyleFunc(tv *TableView, slice interface{}, widg gi.Node2D, row, co
 	if col == 4 {
 		finf, ok := slice.([]gi.FontInfo)
 		if ok {
+			row -= tv.SrcStart
 			widg.SetProp("font-family", (finf)[row].Name)
 			widg.SetProp("font-stretch", (finf)[row].Stretch)
 			widg.SetProp("font-weight", (finf)[row].Weight)
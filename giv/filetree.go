@@ -8,7 +8,6 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
-	"image/color"
 	"io/ioutil"
 	"log"
 	"os"
@@ -27,7 +26,6 @@ import (
 	"github.com/goki/gi/oswin/dnd"
 	"github.com/goki/gi/oswin/key"
 	"github.com/goki/gi/oswin/mimedata"
-	"github.com/goki/gi/oswin/mouse"
 	"github.com/goki/gi/units"
 	"github.com/goki/ki/bitflag"
 	"github.com/goki/ki/ints"
@@ -60,7 +58,7 @@ const (
 
 // FileTree is the root of a tree representing files in a given directory (and
 // subdirectories thereof), and has some overall management state for how to
-// view things.  The FileTree can be viewed by a TreeView to provide a GUI
+// view things.  The FileTree can be viewed by a FileTreeView to provide a GUI
 // interface into it.
 type FileTree struct {
 	FileNode
@@ -536,6 +534,10 @@ func (fn *FileNode) SetNodePath(path string) error {
 	}
 	if fn.IsDir() && !fn.IsIrregular() {
 		openAll := fn.FRoot.InOpenAll && !fn.Info.IsHidden()
+		if openAll {
+			fn.SetOpen()
+			fn.FRoot.SetDirOpen(fn.FPath)
+		}
 		if openAll || fn.FRoot.IsDirOpen(fn.FPath) {
 			fn.ReadDir(string(fn.FPath)) // keep going down..
 		}
@@ -622,7 +624,7 @@ func (fn *FileNode) OpenAll() {
 	fn.FRoot.SetDirOpen(fn.FPath)
 	fn.UpdateNode()
 	fn.FRoot.InOpenAll = false
-	// note: FileTreeView must actually do the open all too!
+	// note: FileTreeView gets the open state from the tree (OpenFromSource)
 }
 
 // CloseAll closes all directories under this one, this included
@@ -637,7 +639,7 @@ func (fn *FileNode) CloseAll() {
 		}
 		return ki.Continue
 	})
-	// note: FileTreeView must actually do the close all too!
+	// note: FileTreeView gets the open state from the tree (OpenFromSource)
 }
 
 // OpenBuf opens the file in its buffer if it is not already open.
@@ -1505,43 +1507,346 @@ func (dm *DirFlagMap) DeleteStale() {
 	}
 }
 
+//////////////////////////////////////////////////////////////////////////////
+//    FileTreeSource
+
+// FileTreeSource is the TreeSource for a FileTree, used by FileTreeView.
+// The contents of directories are only read when they are opened in the
+// view, and the open state of the directories is recorded in the Dirs of the
+// FileTree, so it persists along with the tree.  The labels of files are
+// styled according to their version control status (see FileTreeVcsColors).
+// Copying, pasting and dragging items copies the files themselves.
+type FileTreeSource struct {
+	KiTreeSource
+	Tree *FileTree `desc:"the file tree"`
+}
+
+// NewFileTreeSource returns a new FileTreeSource for given file tree
+func NewFileTreeSource(ft *FileTree) *FileTreeSource {
+	return &FileTreeSource{KiTreeSource: KiTreeSource{Root: ft.This()}, Tree: ft}
+}
+
+// ItemFileNode returns the FileNode for given item of a FileTreeSource --
+// nil if it is not a FileNode
+func ItemFileNode(item interface{}) *FileNode {
+	k, ok := item.(ki.Ki)
+	if !ok || k == nil {
+		return nil
+	}
+	fni := k.Embed(KiT_FileNode)
+	if fni == nil {
+		return nil
+	}
+	return fni.(*FileNode)
+}
+
+// IsRoot returns true if given node is the root of the tree
+func (fs *FileTreeSource) IsRoot(fn *FileNode) bool {
+	return fn.This() == fs.Tree.This()
+}
+
+func (fs *FileTreeSource) TreeHasChildren(item interface{}) bool {
+	fn := ItemFileNode(item)
+	return fn != nil && (fn.IsDir() || fn.HasChildren())
+}
+
+func (fs *FileTreeSource) TreeIsOpen(item interface{}) bool {
+	fn := ItemFileNode(item)
+	if fn == nil {
+		return false
+	}
+	if fs.IsRoot(fn) || fn.IsIrregular() {
+		return true
+	}
+	return fn.IsDir() && fs.Tree.IsDirOpen(fn.FPath)
+}
+
+func (fs *FileTreeSource) TreeSetOpen(item interface{}, open bool) {
+	fn := ItemFileNode(item)
+	if fn == nil || fs.IsRoot(fn) || !fn.IsDir() || fn.IsIrregular() {
+		return
+	}
+	if open {
+		if !fn.IsOpen() {
+			fn.OpenDir()
+		}
+	} else {
+		fn.CloseDir()
+	}
+}
+
+// FileTreeVcsColors are the colors of the labels of files in a FileTreeView
+// for each version control status -- the standard color is used otherwise
+var FileTreeVcsColors = map[vci.FileStatus]string{
+	vci.Untracked:  "#808080",
+	vci.Modified:   "#4b7fd1",
+	vci.Added:      "#008800",
+	vci.Deleted:    "#ff4252",
+	vci.Conflicted: "#ce8020",
+	vci.Updated:    "#008060",
+}
+
+// TreeStyle shows executable files in bold, files open in a buffer in
+// italic, and colors files according to their version control status
+func (fs *FileTreeSource) TreeStyle(item interface{}, fst *gi.FontStyle) {
+	fn := ItemFileNode(item)
+	if fn == nil || fn.IsDir() {
+		return
+	}
+	if fn.IsExec() {
+		fst.Weight = gi.WeightBold
+	}
+	if fn.IsOpen() {
+		fst.Style = gi.FontItalic
+	}
+	if clr, has := FileTreeVcsColors[fn.Info.Vcs]; has {
+		fst.Color.SetString(clr, nil)
+	}
+}
+
+// TreeMimeData adds mimedata for given file: a text/plain of the PathUnique,
+// text/plain of the file path, and the contents of the file, with its mime
+// type, if it is smaller than BigFileSize
+func (fs *FileTreeSource) TreeMimeData(item interface{}, md *mimedata.Mimes) {
+	fn := ItemFileNode(item)
+	if fn == nil {
+		return
+	}
+	path := string(fn.FPath)
+	*md = append(*md, mimedata.NewTextData(fn.PathFromUnique(fs.Tree.This())))
+	*md = append(*md, mimedata.NewTextData(path))
+	if int(fn.Info.Size) < gi.Prefs.Params.BigFileSize {
+		in, err := os.Open(path)
+		if err != nil {
+			log.Println(err)
+			return
+		}
+		b, err := ioutil.ReadAll(in)
+		in.Close()
+		if err != nil {
+			log.Println(err)
+			return
+		}
+		fd := &mimedata.Data{fn.Info.Mime, b}
+		*md = append(*md, fd)
+	} else {
+		*md = append(*md, mimedata.NewTextData("File exceeds BigFileSize"))
+	}
+}
+
+// TreePasteAt copies the files in mime data from TreeMimeData into the
+// directory containing given item -- existing files are overwritten
+func (fs *FileTreeSource) TreePasteAt(md mimedata.Mimes, item interface{}, rel int) interface{} {
+	fn := ItemFileNode(item)
+	if fn == nil || fn.IsExternal() || fs.IsRoot(fn) {
+		return nil
+	}
+	fs.CopyFilesToDir(fn.Parent().Embed(KiT_FileNode).(*FileNode), md, true)
+	return nil
+}
+
+// TreePasteChildren copies the files in mime data from TreeMimeData into
+// given directory -- existing files are overwritten
+func (fs *FileTreeSource) TreePasteChildren(md mimedata.Mimes, item interface{}) interface{} {
+	fn := ItemFileNode(item)
+	if fn == nil || fn.IsExternal() {
+		return nil
+	}
+	if !fn.IsDir() {
+		return fs.TreePasteAt(md, item, 1)
+	}
+	fs.CopyFilesToDir(fn, md, true)
+	return nil
+}
+
+// TreeDelete deletes given files -- any files within deleted directories
+// that are open in buffers are closed first
+func (fs *FileTreeSource) TreeDelete(items []interface{}) {
+	for i := len(items) - 1; i >= 0; i-- {
+		fn := ItemFileNode(items[i])
+		if fn == nil || fs.IsRoot(fn) {
+			continue
+		}
+		if fn.Info.IsDir() {
+			var fns []string
+			fn.Info.FileNames(&fns)
+			for _, filename := range fns {
+				if sfn, ok := fs.Tree.FindFile(filename); ok && sfn.Buf != nil {
+					sfn.CloseBuf()
+				}
+			}
+		}
+		fn.DeleteFile()
+	}
+}
+
+// TreeDragged deletes the files that were moved by a drag-n-drop
+func (fs *FileTreeSource) TreeDragged(md mimedata.Mimes) {
+	nf := len(md) / 3 // always internal
+	for i := 0; i < nf; i++ {
+		npath := string(md[i*3].Data)
+		sfni, err := fs.Tree.FindPathUniqueTry(npath)
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
+		sfn := ItemFileNode(sfni)
+		if sfn == nil {
+			continue
+		}
+		sfn.DeleteFile()
+	}
+}
+
+// ExistingFiles checks for existing files in target node directory if
+// that is non-nil (otherwise just uses absolute path), for the files in
+// given mime data, which is from TreeMimeData if intl, else a list of paths
+// -- returns list of existing and node for last one if exists.
+func (fs *FileTreeSource) ExistingFiles(tfn *FileNode, md mimedata.Mimes, intl bool) ([]string, *FileNode) {
+	tpath := ""
+	if tfn != nil {
+		tpath = string(tfn.FPath)
+	}
+	nf := len(md)
+	if intl {
+		nf /= 3
+	}
+	var sfn *FileNode
+	var existing []string
+	for i := 0; i < nf; i++ {
+		var d *mimedata.Data
+		if intl {
+			d = md[i*3+1]
+			npath := string(md[i*3].Data)
+			sfni, err := fs.Tree.FindPathUniqueTry(npath)
+			if err == nil {
+				sfn = ItemFileNode(sfni)
+			}
+		} else {
+			d = md[i] // just a list
+		}
+		if d.Type != filecat.TextPlain {
+			continue
+		}
+		path := string(d.Data)
+		if strings.HasPrefix(path, "file://") {
+			path = path[7:]
+		}
+		if tfn != nil {
+			_, fnm := filepath.Split(path)
+			path = filepath.Join(tpath, fnm)
+		}
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			existing = append(existing, path)
+		}
+	}
+	return existing, sfn
+}
+
+// CopyFilesToDir copies the files in given mime data into given target
+// directory -- the mime data is from TreeMimeData if intl, else a list of
+// paths
+func (fs *FileTreeSource) CopyFilesToDir(tdir *FileNode, md mimedata.Mimes, intl bool) {
+	nf := len(md)
+	if intl {
+		nf /= 3
+	}
+	for i := 0; i < nf; i++ {
+		var d *mimedata.Data
+		mode := os.FileMode(0664)
+		if intl {
+			d = md[i*3+1]
+			npath := string(md[i*3].Data)
+			sfni, err := fs.Tree.FindPathUniqueTry(npath)
+			if err != nil {
+				fmt.Println(err)
+				continue
+			}
+			if sfn := ItemFileNode(sfni); sfn != nil {
+				mode = sfn.Info.Mode
+			}
+		} else {
+			d = md[i] // just a list
+		}
+		if d.Type != filecat.TextPlain {
+			continue
+		}
+		path := string(d.Data)
+		if strings.HasPrefix(path, "file://") {
+			path = path[7:]
+		}
+		tdir.CopyFileToDir(path, mode)
+	}
+}
+
 //////////////////////////////////////////////////////////////////////////////
 //    FileTreeView
 
-// FileTreeView is a TreeView that knows how to operate on FileNode nodes
+// FileTreeView is a VirtTreeView of a FileTree, through a FileTreeSource,
+// so only the rows in view are rendered and directories are only read when
+// they are opened.  It adds a context menu and keyboard shortcuts for
+// operating on the selected files, and copying, pasting and dragging of
+// files.  TreeViewSig signals are sent with the FileNode (as a ki.Ki) as the
+// data -- TreeViewOpened is sent for files too, e.g., to open them in an
+// editor.
 type FileTreeView struct {
-	TreeView
+	VirtTreeView
+	Tree *FileTree `copy:"-" json:"-" xml:"-" desc:"the file tree being viewed -- set with SetTree"`
 }
 
 var KiT_FileTreeView = kit.Types.AddType(&FileTreeView{}, nil)
 
 // AddNewFileTreeView adds a new filetreeview to given parent node, with given name.
 func AddNewFileTreeView(parent ki.Ki, name string) *FileTreeView {
-	tv := parent.AddNewChild(KiT_FileTreeView, name).(*FileTreeView)
-	tv.OpenDepth = 4
-	return tv
+	return parent.AddNewChild(KiT_FileTreeView, name).(*FileTreeView)
 }
 
 func init() {
 	kit.Types.SetProps(KiT_FileTreeView, FileTreeViewProps)
 }
 
-// FileNode returns the SrcNode as a FileNode
-func (ftv *FileTreeView) FileNode() *FileNode {
-	if ftv.This() == nil {
+// SetTree sets the file tree to view, opening the directories that are
+// recorded as open in the tree
+func (ftv *FileTreeView) SetTree(ft *FileTree) {
+	ftv.Tree = ft
+	ftv.SetSource(NewFileTreeSource(ft))
+}
+
+// FileSrc returns the FileTreeSource of the view, nil if not set
+func (ftv *FileTreeView) FileSrc() *FileTreeSource {
+	fs, _ := ftv.Src.(*FileTreeSource)
+	return fs
+}
+
+// FileNodeAt returns the FileNode at given index in Nodes, nil if none
+func (ftv *FileTreeView) FileNodeAt(idx int) *FileNode {
+	if idx < 0 || idx >= len(ftv.Nodes) {
 		return nil
 	}
-	fni := ftv.SrcNode.Embed(KiT_FileNode)
-	if fni == nil {
-		return nil
+	return ItemFileNode(ftv.Nodes[idx].Item)
+}
+
+// SelectedFileNode returns the FileNode of the current (last-selected)
+// item, nil if none
+func (ftv *FileTreeView) SelectedFileNode() *FileNode {
+	return ItemFileNode(ftv.SelectedItem())
+}
+
+// SelectedFileNodes returns the FileNodes of the selected items, in display order
+func (ftv *FileTreeView) SelectedFileNodes() []*FileNode {
+	sels := ftv.SelectedItemsList()
+	fns := make([]*FileNode, 0, len(sels))
+	for _, it := range sels {
+		if fn := ItemFileNode(it); fn != nil {
+			fns = append(fns, fn)
+		}
 	}
-	return fni.(*FileNode)
+	return fns
 }
 
 func (ftv *FileTreeView) UpdateAllFiles() {
-	fn := ftv.FileNode()
-	if fn != nil {
-		fn.FRoot.UpdateAll()
+	if ftv.Tree != nil {
+		ftv.Tree.UpdateAll()
 	}
 }
 
@@ -1550,100 +1855,35 @@ func (ftv *FileTreeView) ConnectEvents2D() {
 }
 
 func (ftv *FileTreeView) FileTreeViewEvents() {
-	ftv.ConnectEvent(oswin.KeyChordEvent, gi.RegPri, func(recv, send ki.Ki, sig int64, d interface{}) {
-		tvv := recv.Embed(KiT_FileTreeView).(*FileTreeView)
+	ftv.VirtTreeViewEvents()
+	// these replace the VirtTreeView connections
+	ftv.ConnectEvent(oswin.KeyChordEvent, gi.HiPri, func(recv, send ki.Ki, sig int64, d interface{}) {
+		ftvv := recv.Embed(KiT_FileTreeView).(*FileTreeView)
 		kt := d.(*key.ChordEvent)
-		tvv.KeyInput(kt)
+		ftvv.KeyInput(kt)
 	})
 	ftv.ConnectEvent(oswin.DNDEvent, gi.RegPri, func(recv, send ki.Ki, sig int64, d interface{}) {
 		de := d.(*dnd.Event)
-		tvvi := recv.Embed(KiT_FileTreeView)
-		if tvvi == nil {
-			return
-		}
-		tvv := tvvi.(*FileTreeView)
+		ftvv := recv.Embed(KiT_FileTreeView).(*FileTreeView)
 		switch de.Action {
 		case dnd.Start:
-			tvv.DragNDropStart()
+			ftvv.DragNDropStart()
 		case dnd.DropOnTarget:
-			tvv.DragNDropTarget(de)
+			ftvv.DragNDropTarget(de)
 		case dnd.DropFmSource:
-			tvv.This().(gi.DragNDropper).Dragged(de)
+			ftvv.Dragged(de)
 		case dnd.External:
-			tvv.DragNDropExternal(de)
+			ftvv.DragNDropExternal(de)
 		}
 	})
-	ftv.ConnectEvent(oswin.DNDFocusEvent, gi.RegPri, func(recv, send ki.Ki, sig int64, d interface{}) {
-		de := d.(*dnd.FocusEvent)
-		tvvi := recv.Embed(KiT_FileTreeView)
-		if tvvi == nil {
-			return
-		}
-		tvv := tvvi.(*FileTreeView)
-		switch de.Action {
-		case dnd.Enter:
-			tvv.ParentWindow().DNDSetCursor(de.Mod)
-		case dnd.Exit:
-			tvv.ParentWindow().DNDNotCursor()
-		case dnd.Hover:
-			tvv.Open()
-		}
-	})
-	if ftv.HasChildren() {
-		if wb, ok := ftv.BranchPart(); ok {
-			wb.ButtonSig.ConnectOnly(ftv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
-				if sig == int64(gi.ButtonToggled) {
-					ftvv, _ := recv.Embed(KiT_FileTreeView).(*FileTreeView)
-					ftvv.ToggleClose()
-				}
-			})
-		}
-	}
-	if lbl, ok := ftv.LabelPart(); ok {
-		// HiPri is needed to override label's native processing
-		lbl.ConnectEvent(oswin.MouseEvent, gi.HiPri, func(recv, send ki.Ki, sig int64, d interface{}) {
-			lb, _ := recv.(*gi.Label)
-			ftvvi := lb.Parent().Parent()
-			if ftvvi == nil || ftvvi.This() == nil { // deleted
-				return
-			}
-			ftvv := ftvvi.Embed(KiT_FileTreeView).(*FileTreeView)
-			me := d.(*mouse.Event)
-			switch me.Button {
-			case mouse.Left:
-				switch me.Action {
-				case mouse.DoubleClick:
-					ftvv.ToggleClose()
-					me.SetProcessed()
-				case mouse.Release:
-					ftvv.SelectAction(me.SelectMode())
-					me.SetProcessed()
-				}
-			case mouse.Right:
-				if me.Action == mouse.Release {
-					me.SetProcessed()
-					ftvv.This().(gi.Node2D).ContextMenu()
-				}
-			}
-		})
-	}
 }
 
 func (ftv *FileTreeView) KeyInput(kt *key.ChordEvent) {
 	if gi.KeyEventTrace {
-		fmt.Printf("TreeView KeyInput: %v\n", ftv.PathUnique())
+		fmt.Printf("FileTreeView KeyInput: %v\n", ftv.PathUnique())
 	}
 	kf := gi.KeyFun(kt.Chord())
-	selMode := mouse.SelectModeBits(kt.Modifiers)
-
-	if selMode == mouse.SelectOne {
-		if ftv.SelectMode() {
-			selMode = mouse.ExtendContinuous
-		}
-	}
-
-	// first all the keys that work for inactive and active
-	if !ftv.IsInactive() && !kt.IsProcessed() {
+	if !ftv.IsInactive() {
 		switch kf {
 		case gi.KeyFunDelete:
 			ftv.DeleteFiles()
@@ -1661,101 +1901,72 @@ func (ftv *FileTreeView) KeyInput(kt *key.ChordEvent) {
 		case gi.KeyFunInsertAfter: // New Folder
 			CallMethod(ftv, "NewFolder", ftv.ViewportSafe())
 			kt.SetProcessed()
+		case gi.KeyFunCut:
+			ftv.Cut()
+			kt.SetProcessed()
+		case gi.KeyFunPaste:
+			ftv.Paste()
+			kt.SetProcessed()
 		}
 	}
 	if !kt.IsProcessed() {
-		ftv.TreeView.KeyInput(kt)
+		ftv.VirtTreeView.KeyInput(kt)
 	}
 }
 
+// MakeContextMenu makes the context menu of file actions, from the
+// CtxtMenuActive props, which apply to the selected files
+func (ftv *FileTreeView) MakeContextMenu(m *gi.Menu) {
+	if ftv.CtxtMenuFunc != nil {
+		ftv.CtxtMenuFunc(ftv.This().(gi.Node2D), m)
+	}
+	CtxtMenuView(ftv.This(), ftv.IsInactive(), ftv.ViewportSafe(), m)
+}
+
 // ShowFileInfo calls ViewFile on selected files
 func (ftv *FileTreeView) ShowFileInfo() {
-	sels := ftv.SelectedViews()
+	sels := ftv.SelectedFileNodes()
 	for i := len(sels) - 1; i >= 0; i-- {
-		sn := sels[i]
-		fftv := sn.Embed(KiT_FileTreeView).(*FileTreeView)
-		fn := fftv.FileNode()
-		if fn != nil {
-			StructViewDialog(ftv.ViewportSafe(), &fn.Info, DlgOpts{Title: "File Info", Inactive: true}, nil, nil)
-		}
+		fn := sels[i]
+		StructViewDialog(ftv.ViewportSafe(), &fn.Info, DlgOpts{Title: "File Info", Inactive: true}, nil, nil)
 	}
 }
 
 // OpenFileDefault opens file with default app for that file type (os defined)
 // runs open on Mac, xdg-open on Linux, and start on Windows
 func (ftv *FileTreeView) OpenFileDefault() {
-	sels := ftv.SelectedViews()
+	sels := ftv.SelectedFileNodes()
 	for i := len(sels) - 1; i >= 0; i-- {
-		sn := sels[i]
-		fftv := sn.Embed(KiT_FileTreeView).(*FileTreeView)
-		fn := fftv.FileNode()
-		if fn != nil {
-			fn.OpenFileDefault()
-		}
+		sels[i].OpenFileDefault()
 	}
 }
 
 // OpenFileWith opens file with user-specified command.
 func (ftv *FileTreeView) OpenFileWith() {
-	sels := ftv.SelectedViews()
+	sels := ftv.SelectedFileNodes()
 	for i := len(sels) - 1; i >= 0; i-- {
-		sn := sels[i]
-		fftv := sn.Embed(KiT_FileTreeView).(*FileTreeView)
-		fn := fftv.FileNode()
-		if fn != nil {
-			CallMethod(fn, "OpenFileWith", ftv.ViewportSafe())
-		}
+		CallMethod(sels[i], "OpenFileWith", ftv.ViewportSafe())
 	}
 }
 
 // DuplicateFiles calls DuplicateFile on any selected nodes
 func (ftv *FileTreeView) DuplicateFiles() {
-	sels := ftv.SelectedViews()
+	sels := ftv.SelectedFileNodes()
 	for i := len(sels) - 1; i >= 0; i-- {
-		sn := sels[i]
-		ftvv := sn.Embed(KiT_FileTreeView).(*FileTreeView)
-		fn := ftvv.FileNode()
-		if fn != nil {
-			fn.DuplicateFile()
-		}
+		sels[i].DuplicateFile()
 	}
 }
 
 // DeleteFilesImpl does the actual deletion, no prompts
 func (ftv *FileTreeView) DeleteFilesImpl() {
-	sels := ftv.SelectedViews()
-	for i := len(sels) - 1; i >= 0; i-- {
-		sn := sels[i]
-		ftvv := sn.Embed(KiT_FileTreeView).(*FileTreeView)
-		fn := ftvv.FileNode()
-		if fn == nil {
-			return
-		}
-		if fn.Info.IsDir() {
-			openList := []string{}
-			var fns []string
-			fn.Info.FileNames(&fns)
-			ft := fn.FRoot
-			for _, filename := range fns {
-				fn, ok := ft.FindFile(filename)
-				if !ok {
-					return
-				}
-				if fn.Buf != nil {
-					openList = append(openList, filename)
-				}
-			}
-			if len(openList) > 0 {
-				for _, filename := range openList {
-					fn, _ := ft.FindFile(filename)
-					fn.CloseBuf()
-				}
-			}
-			fn.DeleteFile()
-		} else {
-			fn.DeleteFile()
-		}
+	fs := ftv.FileSrc()
+	if fs == nil {
+		return
 	}
+	sels := ftv.SelectedItemsList()
+	ftv.SelectedItems = make(map[interface{}]struct{})
+	fs.TreeDelete(sels)
+	ftv.SetChanged()
 }
 
 // DeleteFiles calls DeleteFile on any selected nodes. If any directory is selected
@@ -1776,90 +1987,63 @@ func (ftv *FileTreeView) DeleteFiles() {
 
 // RenameFiles calls RenameFile on any selected nodes
 func (ftv *FileTreeView) RenameFiles() {
-	sels := ftv.SelectedViews()
+	sels := ftv.SelectedFileNodes()
 	for i := len(sels) - 1; i >= 0; i-- {
-		sn := sels[i]
-		ftvv := sn.Embed(KiT_FileTreeView).(*FileTreeView)
-		fn := ftvv.FileNode()
-		if fn != nil {
-			if fn.IsExternal() {
-				continue
-			}
-			CallMethod(fn, "RenameFile", ftv.ViewportSafe())
+		fn := sels[i]
+		if fn.IsExternal() {
+			continue
 		}
+		CallMethod(fn, "RenameFile", ftv.ViewportSafe())
 	}
 }
 
 // OpenDir opens given directory
 func (ftv *FileTreeView) OpenDir() {
-	sels := ftv.SelectedViews()
+	sels := ftv.SelectedFileNodes()
 	for i := len(sels) - 1; i >= 0; i-- {
-		sn := sels[i]
-		ftvv := sn.Embed(KiT_FileTreeView).(*FileTreeView)
-		fn := ftvv.FileNode()
-		if fn != nil {
-			fn.OpenDir()
-		}
+		sels[i].OpenDir()
 	}
+	ftv.OpenFromSource(0)
 }
 
-// OpenAll opens all directories under this one
+// OpenAll opens all directories under the current one
 func (ftv *FileTreeView) OpenAll() {
-	fn := ftv.FileNode()
+	fn := ftv.SelectedFileNode()
 	if fn != nil {
 		fn.OpenAll()
-		ftv.TreeView.OpenAll() // view has to do it too
+		ftv.OpenFromSource(ftv.SelectedIdx) // view has to do it too
 	}
 }
 
-// CloseAll closes all directories under this one
+// CloseAll closes all directories under the current one
 func (ftv *FileTreeView) CloseAll() {
-	fn := ftv.FileNode()
+	fn := ftv.SelectedFileNode()
 	if fn != nil {
 		fn.CloseAll()
-		ftv.TreeView.CloseAll() // view has to do it too
+		ftv.OpenFromSource(ftv.SelectedIdx) // view has to do it too
 	}
 }
 
 // SortBy determines how to sort the files in the directory -- default is alpha by name,
 // optionally can be sorted by modification time.
 func (ftv *FileTreeView) SortBy(modTime bool) {
-	sels := ftv.SelectedViews()
+	sels := ftv.SelectedFileNodes()
 	for i := len(sels) - 1; i >= 0; i-- {
-		sn := sels[i]
-		ftvv := sn.Embed(KiT_FileTreeView).(*FileTreeView)
-		fn := ftvv.FileNode()
-		if fn != nil {
-			fn.SortBy(modTime)
-		}
+		sels[i].SortBy(modTime)
 	}
 }
 
-// NewFile makes a new file in given selected directory node
+// NewFile makes a new file in the current selected directory node
 func (ftv *FileTreeView) NewFile(filename string, addToVcs bool) {
-	sels := ftv.SelectedViews()
-	sz := len(sels)
-	if sz == 0 { // shouldn't happen
-		return
-	}
-	sn := sels[sz-1]
-	ftvv := sn.Embed(KiT_FileTreeView).(*FileTreeView)
-	fn := ftvv.FileNode()
+	fn := ftv.SelectedFileNode()
 	if fn != nil {
 		fn.NewFile(filename, addToVcs)
 	}
 }
 
-// NewFolder makes a new file in given selected directory node
+// NewFolder makes a new folder in the current selected directory node
 func (ftv *FileTreeView) NewFolder(foldername string) {
-	sels := ftv.SelectedViews()
-	sz := len(sels)
-	if sz == 0 { // shouldn't happen
-		return
-	}
-	sn := sels[sz-1]
-	ftvv := sn.Embed(KiT_FileTreeView).(*FileTreeView)
-	fn := ftvv.FileNode()
+	fn := ftv.SelectedFileNode()
 	if fn != nil {
 		fn.NewFolder(foldername)
 	}
@@ -1867,48 +2051,23 @@ func (ftv *FileTreeView) NewFolder(foldername string) {
 
 // AddToVcs adds the file to version control system
 func (ftv *FileTreeView) AddToVcs() {
-	sels := ftv.SelectedViews()
-	sz := len(sels)
-	if sz == 0 { // shouldn't happen
-		return
-	}
+	sels := ftv.SelectedFileNodes()
 	for i := len(sels) - 1; i >= 0; i-- {
-		sn := sels[i]
-		ftvv := sn.Embed(KiT_FileTreeView).(*FileTreeView)
-		fn := ftvv.FileNode()
-		if fn != nil {
-			fn.AddToVcs()
-		}
+		sels[i].AddToVcs()
 	}
 }
 
 // DeleteFromVcs removes the file from version control system
 func (ftv *FileTreeView) DeleteFromVcs() {
-	sels := ftv.SelectedViews()
-	sz := len(sels)
-	if sz == 0 { // shouldn't happen
-		return
-	}
+	sels := ftv.SelectedFileNodes()
 	for i := len(sels) - 1; i >= 0; i-- {
-		sn := sels[i]
-		ftvv := sn.Embed(KiT_FileTreeView).(*FileTreeView)
-		fn := ftvv.FileNode()
-		if fn != nil {
-			fn.DeleteFromVcs()
-		}
+		sels[i].DeleteFromVcs()
 	}
 }
 
 // CommitToVcs commits the file from version control system
 func (ftv *FileTreeView) CommitToVcs() {
-	sels := ftv.SelectedViews()
-	sz := len(sels)
-	if sz == 0 { // shouldn't happen
-		return
-	}
-	sn := sels[sz-1]
-	ftvv := sn.Embed(KiT_FileTreeView).(*FileTreeView)
-	fn := ftvv.FileNode()
+	fn := ftv.SelectedFileNode()
 	if fn != nil {
 		CallMethod(fn, "CommitToVcs", ftv.ViewportSafe())
 	}
@@ -1916,18 +2075,9 @@ func (ftv *FileTreeView) CommitToVcs() {
 
 // RevertVcs removes the file from version control system
 func (ftv *FileTreeView) RevertVcs() {
-	sels := ftv.SelectedViews()
-	sz := len(sels)
-	if sz == 0 { // shouldn't happen
-		return
-	}
+	sels := ftv.SelectedFileNodes()
 	for i := len(sels) - 1; i >= 0; i-- {
-		sn := sels[i]
-		ftvv := sn.Embed(KiT_FileTreeView).(*FileTreeView)
-		fn := ftvv.FileNode()
-		if fn != nil {
-			fn.RevertVcs()
-		}
+		sels[i].RevertVcs()
 	}
 }
 
@@ -1936,18 +2086,9 @@ func (ftv *FileTreeView) RevertVcs() {
 // -1, -2 etc also work as universal ways of specifying prior revisions.
 // Diffs are shown in a DiffViewDialog.
 func (ftv *FileTreeView) DiffVcs(rev_a, rev_b string) {
-	sels := ftv.SelectedViews()
-	sz := len(sels)
-	if sz == 0 { // shouldn't happen
-		return
-	}
+	sels := ftv.SelectedFileNodes()
 	for i := len(sels) - 1; i >= 0; i-- {
-		sn := sels[i]
-		ftvv := sn.Embed(KiT_FileTreeView).(*FileTreeView)
-		fn := ftvv.FileNode()
-		if fn != nil {
-			fn.DiffVcs(rev_a, rev_b)
-		}
+		sels[i].DiffVcs(rev_a, rev_b)
 	}
 }
 
@@ -1960,47 +2101,27 @@ func (ftv *FileTreeView) DiffVcs(rev_a, rev_b string) {
 // this one.
 // Returns the Log and also shows it in a VCSLogView which supports further actions.
 func (ftv *FileTreeView) LogVcs(allFiles bool, since string) {
-	sels := ftv.SelectedViews()
-	sz := len(sels)
-	if sz == 0 { // shouldn't happen
-		return
-	}
+	sels := ftv.SelectedFileNodes()
 	for i := len(sels) - 1; i >= 0; i-- {
-		sn := sels[i]
-		ftvv := sn.Embed(KiT_FileTreeView).(*FileTreeView)
-		fn := ftvv.FileNode()
-		if fn != nil {
-			fn.LogVcs(allFiles, since)
-		}
+		sels[i].LogVcs(allFiles, since)
 	}
 }
 
 // BlameVcs shows the VCS blame report for this file, reporting for each line
 // the revision and author of the last change.
 func (ftv *FileTreeView) BlameVcs() {
-	sels := ftv.SelectedViews()
-	sz := len(sels)
-	if sz == 0 { // shouldn't happen
-		return
-	}
+	sels := ftv.SelectedFileNodes()
 	for i := len(sels) - 1; i >= 0; i-- {
-		sn := sels[i]
-		ftvv := sn.Embed(KiT_FileTreeView).(*FileTreeView)
-		fn := ftvv.FileNode()
-		if fn != nil {
-			fn.BlameVcs()
-		}
+		sels[i].BlameVcs()
 	}
 }
 
 // RemoveFromExterns removes file from list of external files
 func (ftv *FileTreeView) RemoveFromExterns() {
-	sels := ftv.SelectedViews()
+	sels := ftv.SelectedFileNodes()
 	for i := len(sels) - 1; i >= 0; i-- {
-		sn := sels[i]
-		ftvv := sn.Embed(KiT_FileTreeView).(*FileTreeView)
-		fn := ftvv.FileNode()
-		if fn != nil && fn.IsExternal() {
+		fn := sels[i]
+		if fn.IsExternal() {
 			fn.FRoot.RemoveExtFile(string(fn.FPath))
 			fn.CloseBuf()
 			fn.Delete(true)
@@ -2011,36 +2132,11 @@ func (ftv *FileTreeView) RemoveFromExterns() {
 ///////////////////////////////////////////////////////////////////////////////
 //   Clipboard
 
-// MimeData adds mimedata for this node: a text/plain of the PathUnique,
-// text/plain of filename, and text/
-func (ftv *FileTreeView) MimeData(md *mimedata.Mimes) {
-	sroot := ftv.RootView.SrcNode
-	fn := ftv.SrcNode.Embed(KiT_FileNode).(*FileNode)
-	path := string(fn.FPath)
-	*md = append(*md, mimedata.NewTextData(fn.PathFromUnique(sroot)))
-	*md = append(*md, mimedata.NewTextData(path))
-	if int(fn.Info.Size) < gi.Prefs.Params.BigFileSize {
-		in, err := os.Open(path)
-		if err != nil {
-			log.Println(err)
-			return
-		}
-		b, err := ioutil.ReadAll(in)
-		if err != nil {
-			log.Println(err)
-			return
-		}
-		fd := &mimedata.Data{fn.Info.Mime, b}
-		*md = append(*md, fd)
-	} else {
-		*md = append(*md, mimedata.NewTextData("File exceeds BigFileSize"))
-	}
-}
-
-// Cut copies to clip.Board and deletes selected items
-// satisfies gi.Clipper interface and can be overridden by subtypes
+// Cut copies the selected files to the clipboard -- the files are not
+// deleted, as their contents are not placed on the clipboard
 func (ftv *FileTreeView) Cut() {
-	if ftv.IsRootOrField("Cut") {
+	fn := ftv.SelectedFileNode()
+	if fn == nil || ftv.FileSrc().IsRoot(fn) {
 		return
 	}
 	ftv.Copy(false)
@@ -2048,111 +2144,70 @@ func (ftv *FileTreeView) Cut() {
 	gi.PromptDialog(ftv.ViewportSafe(), gi.DlgOpts{Title: "Cut Not Supported", Prompt: "File names were copied to clipboard and can be pasted to copy elsewhere, but files are not deleted because contents of files are not placed on the clipboard and thus cannot be pasted as such.  Use Delete to delete files."}, gi.AddOk, gi.NoCancel, nil, nil)
 }
 
-// Paste pastes clipboard at given node
-// satisfies gi.Clipper interface and can be overridden by subtypes
+// Paste pastes the files on the clipboard at the current item
 func (ftv *FileTreeView) Paste() {
 	md := oswin.TheApp.ClipBoard(ftv.ParentWindow().OSWin).Read([]string{filecat.TextPlain})
 	if md != nil {
-		ftv.PasteMime(md)
+		ftv.PasteMime(md, ftv.SelectedIdx)
 	}
 }
 
-// Drop pops up a menu to determine what specifically to do with dropped items
+// Drop copies the dropped files onto the item at CurIdx
 // satisfies gi.DragNDropper interface and can be overridden by subtypes
 func (ftv *FileTreeView) Drop(md mimedata.Mimes, mod dnd.DropMods) {
-	ftv.PasteMime(md)
+	ftv.PasteMime(md, ftv.CurIdx)
 }
 
-// DropExternal is not handled by base case but could be in derived
+// DropExternal copies the dropped files onto the item at CurIdx
 func (ftv *FileTreeView) DropExternal(md mimedata.Mimes, mod dnd.DropMods) {
-	ftv.PasteMime(md)
+	ftv.PasteMime(md, ftv.CurIdx)
 }
 
-// PasteCheckExisting checks for existing files in target node directory if
-// that is non-nil (otherwise just uses absolute path), and returns list of existing
-// and node for last one if exists.
-func (ftv *FileTreeView) PasteCheckExisting(tfn *FileNode, md mimedata.Mimes) ([]string, *FileNode) {
-	sroot := ftv.RootView.SrcNode
-	tpath := ""
-	if tfn != nil {
-		tpath = string(tfn.FPath)
-	}
-	intl := ftv.ParentWindow().EventMgr.DNDIsInternalSrc()
-	nf := len(md)
-	if intl {
-		nf /= 3
+// Dragged is called after target accepts the drop -- we just remove
+// files that were moved
+// satisfies gi.DragNDropper interface and can be overridden by subtypes
+func (ftv *FileTreeView) Dragged(de *dnd.Event) {
+	ftv.DragNDropSource(de)
+}
+
+// DragNDropExternal handles a drop of files from outside the app
+func (ftv *FileTreeView) DragNDropExternal(de *dnd.Event) {
+	idx, ok := ftv.IdxFromPos(de.Where.Y)
+	if !ok {
+		return
 	}
-	var sfn *FileNode
-	var existing []string
-	for i := 0; i < nf; i++ {
-		var d *mimedata.Data
-		if intl {
-			d = md[i*3+1]
-			npath := string(md[i*3].Data)
-			sfni, err := sroot.FindPathUniqueTry(npath)
-			if err == nil {
-				sfn = sfni.Embed(KiT_FileNode).(*FileNode)
-			}
-		} else {
-			d = md[i] // just a list
-		}
-		if d.Type != filecat.TextPlain {
-			continue
-		}
-		path := string(d.Data)
-		if strings.HasPrefix(path, "file://") {
-			path = path[7:]
-		}
-		if tfn != nil {
-			_, fnm := filepath.Split(path)
-			path = filepath.Join(tpath, fnm)
-		}
-		if _, err := os.Stat(path); !os.IsNotExist(err) {
-			existing = append(existing, path)
-		}
+	de.Target = ftv.This()
+	if de.Mod == dnd.DropLink {
+		de.Mod = dnd.DropCopy // link not supported -- revert to copy
 	}
-	return existing, sfn
+	de.SetProcessed()
+	ftv.CurIdx = idx
+	ftv.DropExternal(de.Data, de.Mod)
 }
 
-// PasteCopyFiles copies files in given data into given target directory
-func (ftv *FileTreeView) PasteCopyFiles(tdir *FileNode, md mimedata.Mimes) {
-	sroot := ftv.RootView.SrcNode
-	intl := ftv.ParentWindow().EventMgr.DNDIsInternalSrc()
-	nf := len(md)
-	if intl {
-		nf /= 3
-	}
-	for i := 0; i < nf; i++ {
-		var d *mimedata.Data
-		mode := os.FileMode(0664)
-		if intl {
-			d = md[i*3+1]
-			npath := string(md[i*3].Data)
-			sfni, err := sroot.FindPathUniqueTry(npath)
-			if err != nil {
-				fmt.Println(err)
-				continue
-			}
-			sfn := sfni.Embed(KiT_FileNode).(*FileNode)
-			mode = sfn.Info.Mode
-		} else {
-			d = md[i] // just a list
-		}
-		if d.Type != filecat.TextPlain {
-			continue
-		}
-		path := string(d.Data)
-		if strings.HasPrefix(path, "file://") {
-			path = path[7:]
-		}
-		tdir.CopyFileToDir(path, mode)
+// DragNDropFinalizeDefMod is called to finalize a drop with the default
+// drop mod of the window, once the files have been copied
+func (ftv *FileTreeView) DragNDropFinalizeDefMod() {
+	win := ftv.ParentWindow()
+	if win == nil {
+		return
 	}
+	ftv.UnselectAll()
+	win.FinalizeDragNDrop(win.EventMgr.DNDDropMod)
+}
+
+// dndIsInternal returns true if the current drag-n-drop is from within the
+// app, so the mime data is from TreeMimeData
+func (ftv *FileTreeView) dndIsInternal() bool {
+	return ftv.ParentWindow().EventMgr.DNDIsInternalSrc()
 }
 
 // PasteMimeCopyFilesCheck copies files into given directory node,
 // first checking if any already exist -- if they exist, prompts.
 func (ftv *FileTreeView) PasteMimeCopyFilesCheck(tdir *FileNode, md mimedata.Mimes) {
-	existing, _ := ftv.PasteCheckExisting(tdir, md)
+	fs := ftv.FileSrc()
+	intl := ftv.dndIsInternal()
+	existing, _ := fs.ExistingFiles(tdir, md, intl)
 	if len(existing) > 0 {
 		gi.ChoiceDialog(nil, gi.DlgOpts{Title: "File(s) Exist in Target Dir, Overwrite?",
 			Prompt: fmt.Sprintf("File(s): %v exist, do you want to overwrite?", existing)},
@@ -2162,30 +2217,27 @@ func (ftv *FileTreeView) PasteMimeCopyFilesCheck(tdir *FileNode, md mimedata.Mim
 				case 0:
 					ftv.DropCancel()
 				case 1:
-					ftv.PasteCopyFiles(tdir, md)
+					fs.CopyFilesToDir(tdir, md, intl)
 					ftv.DragNDropFinalizeDefMod()
 				}
 			})
 	} else {
-		ftv.PasteCopyFiles(tdir, md)
+		fs.CopyFilesToDir(tdir, md, intl)
 		ftv.DragNDropFinalizeDefMod()
 	}
 }
 
-// PasteMime applies a paste / drop of mime data onto this node
-// always does a copy of files into / onto target
-func (ftv *FileTreeView) PasteMime(md mimedata.Mimes) {
-	if len(md) == 0 {
-		ftv.DropCancel()
-		return
-	}
-	tfn := ftv.FileNode()
-	if tfn == nil || tfn.IsExternal() {
+// PasteMime applies a paste / drop of mime data onto the item at given index
+// -- always does a copy of files into / onto target
+func (ftv *FileTreeView) PasteMime(md mimedata.Mimes, idx int) {
+	fs := ftv.FileSrc()
+	tfn := ftv.FileNodeAt(idx)
+	if len(md) == 0 || fs == nil || tfn == nil || tfn.IsExternal() {
 		ftv.DropCancel()
 		return
 	}
-	tupdt := ftv.RootView.UpdateStart()
-	defer ftv.RootView.UpdateEnd(tupdt)
+	tupdt := ftv.UpdateStart()
+	defer ftv.UpdateEnd(tupdt)
 	tpath := string(tfn.FPath)
 	isdir := tfn.IsDir()
 	if isdir {
@@ -2199,7 +2251,7 @@ func (ftv *FileTreeView) PasteMime(md mimedata.Mimes) {
 	}
 	// single file dropped onto a single target file
 	srcpath := ""
-	intl := ftv.ParentWindow().EventMgr.DNDIsInternalSrc()
+	intl := ftv.dndIsInternal()
 	if intl {
 		srcpath = string(md[1].Data) // 1 has file path, 0 = ki path, 2 = file data
 	} else {
@@ -2207,7 +2259,7 @@ func (ftv *FileTreeView) PasteMime(md mimedata.Mimes) {
 	}
 	fname := filepath.Base(srcpath)
 	tdir := tfn.Parent().Embed(KiT_FileNode).(*FileNode)
-	existing, sfn := ftv.PasteCheckExisting(tdir, md)
+	existing, sfn := fs.ExistingFiles(tdir, md, intl)
 	mode := os.FileMode(0664)
 	if sfn != nil {
 		mode = sfn.Info.Mode
@@ -2248,41 +2300,10 @@ func (ftv *FileTreeView) PasteMime(md mimedata.Mimes) {
 	}
 }
 
-// Dragged is called after target accepts the drop -- we just remove
-// elements that were moved
-// satisfies gi.DragNDropper interface and can be overridden by subtypes
-func (ftv *FileTreeView) Dragged(de *dnd.Event) {
-	// fmt.Printf("ftv dragged: %v\n", ftv.PathUnique())
-	if de.Mod != dnd.DropMove {
-		return
-	}
-	sroot := ftv.RootView.SrcNode
-	tfn := ftv.FileNode()
-	if tfn == nil || tfn.IsExternal() {
-		return
-	}
-	md := de.Data
-	nf := len(md) / 3 // always internal
-	for i := 0; i < nf; i++ {
-		npath := string(md[i*3].Data)
-		sfni, err := sroot.FindPathUniqueTry(npath)
-		if err != nil {
-			fmt.Println(err)
-			continue
-		}
-		sfn := sfni.Embed(KiT_FileNode).(*FileNode)
-		if sfn == nil {
-			continue
-		}
-		// fmt.Printf("dnd deleting: %v  path: %v\n", sfn.PathUnique(), sfn.FPath)
-		sfn.DeleteFile()
-	}
-}
-
 // FileTreeInactiveExternFunc is an ActionUpdateFunc that inactivates action if node is external
 var FileTreeInactiveExternFunc = ActionUpdateFunc(func(fni interface{}, act *gi.Action) {
 	ftv := fni.(ki.Ki).Embed(KiT_FileTreeView).(*FileTreeView)
-	fn := ftv.FileNode()
+	fn := ftv.SelectedFileNode()
 	if fn != nil {
 		act.SetInactiveState(fn.IsExternal())
 	}
@@ -2291,7 +2312,7 @@ var FileTreeInactiveExternFunc = ActionUpdateFunc(func(fni interface{}, act *gi.
 // FileTreeActiveExternFunc is an ActionUpdateFunc that activates action if node is external
 var FileTreeActiveExternFunc = ActionUpdateFunc(func(fni interface{}, act *gi.Action) {
 	ftv := fni.(ki.Ki).Embed(KiT_FileTreeView).(*FileTreeView)
-	fn := ftv.FileNode()
+	fn := ftv.SelectedFileNode()
 	if fn != nil {
 		act.SetActiveState(fn.IsExternal() && !fn.IsIrregular())
 	}
@@ -2300,7 +2321,7 @@ var FileTreeActiveExternFunc = ActionUpdateFunc(func(fni interface{}, act *gi.Ac
 // FileTreeInactiveDirFunc is an ActionUpdateFunc that inactivates action if node is a dir
 var FileTreeInactiveDirFunc = ActionUpdateFunc(func(fni interface{}, act *gi.Action) {
 	ftv := fni.(ki.Ki).Embed(KiT_FileTreeView).(*FileTreeView)
-	fn := ftv.FileNode()
+	fn := ftv.SelectedFileNode()
 	if fn != nil {
 		act.SetInactiveState(fn.IsDir() || fn.IsExternal())
 	}
@@ -2309,7 +2330,7 @@ var FileTreeInactiveDirFunc = ActionUpdateFunc(func(fni interface{}, act *gi.Act
 // FileTreeActiveDirFunc is an ActionUpdateFunc that activates action if node is a dir
 var FileTreeActiveDirFunc = ActionUpdateFunc(func(fni interface{}, act *gi.Action) {
 	ftv := fni.(ki.Ki).Embed(KiT_FileTreeView).(*FileTreeView)
-	fn := ftv.FileNode()
+	fn := ftv.SelectedFileNode()
 	if fn != nil {
 		act.SetActiveState(fn.IsDir() && !fn.IsExternal())
 	}
@@ -2318,7 +2339,7 @@ var FileTreeActiveDirFunc = ActionUpdateFunc(func(fni interface{}, act *gi.Actio
 // FileTreeActiveNotInVcsFunc is an ActionUpdateFunc that inactivates action if node is not under version control
 var FileTreeActiveNotInVcsFunc = ActionUpdateFunc(func(fni interface{}, act *gi.Action) {
 	ftv := fni.(ki.Ki).Embed(KiT_FileTreeView).(*FileTreeView)
-	fn := ftv.FileNode()
+	fn := ftv.SelectedFileNode()
 	if fn != nil {
 		repo, _ := fn.Repo()
 		if repo == nil || fn.IsDir() {
@@ -2332,7 +2353,7 @@ var FileTreeActiveNotInVcsFunc = ActionUpdateFunc(func(fni interface{}, act *gi.
 // FileTreeActiveInVcsFunc is an ActionUpdateFunc that activates action if node is under version control
 var FileTreeActiveInVcsFunc = ActionUpdateFunc(func(fni interface{}, act *gi.Action) {
 	ftv := fni.(ki.Ki).Embed(KiT_FileTreeView).(*FileTreeView)
-	fn := ftv.FileNode()
+	fn := ftv.SelectedFileNode()
 	if fn != nil {
 		repo, _ := fn.Repo()
 		if repo == nil || fn.IsDir() {
@@ -2347,7 +2368,7 @@ var FileTreeActiveInVcsFunc = ActionUpdateFunc(func(fni interface{}, act *gi.Act
 // and the file has been modified
 var FileTreeActiveInVcsModifiedFunc = ActionUpdateFunc(func(fni interface{}, act *gi.Action) {
 	ftv := fni.(ki.Ki).Embed(KiT_FileTreeView).(*FileTreeView)
-	fn := ftv.FileNode()
+	fn := ftv.SelectedFileNode()
 	if fn != nil {
 		repo, _ := fn.Repo()
 		if repo == nil || fn.IsDir() {
@@ -2361,7 +2382,7 @@ var FileTreeActiveInVcsModifiedFunc = ActionUpdateFunc(func(fni interface{}, act
 // VcsGetRemoveLabelFunc gets the appropriate label for removing from version control
 var VcsLabelFunc = LabelFunc(func(fni interface{}, act *gi.Action) string {
 	ftv := fni.(ki.Ki).Embed(KiT_FileTreeView).(*FileTreeView)
-	fn := ftv.FileNode()
+	fn := ftv.SelectedFileNode()
 	label := act.Text
 	if fn != nil {
 		repo, _ := fn.Repo()
@@ -2373,77 +2394,12 @@ var VcsLabelFunc = LabelFunc(func(fni interface{}, act *gi.Action) string {
 })
 
 var FileTreeViewProps = ki.Props{
-	"EnumType:Flag":    KiT_TreeViewFlags,
+	"EnumType:Flag":    gi.KiT_NodeFlags,
 	"indent":           units.NewCh(2),
-	"spacing":          units.NewCh(.5),
-	"border-width":     units.NewPx(0),
-	"border-radius":    units.NewPx(0),
-	"padding":          units.NewPx(0),
-	"margin":           units.NewPx(1),
-	"text-align":       gi.AlignLeft,
-	"vertical-align":   gi.AlignTop,
+	"background-color": &gi.Prefs.Colors.Background,
 	"color":            &gi.Prefs.Colors.Font,
-	"background-color": "inherit",
-	"no-templates":     true,
-	".exec": ki.Props{
-		"font-weight": gi.WeightBold,
-	},
-	".open": ki.Props{
-		"font-style": gi.FontItalic,
-	},
-	".untracked": ki.Props{
-		"color": "#808080",
-	},
-	".modified": ki.Props{
-		"color": "#4b7fd1",
-	},
-	".added": ki.Props{
-		"color": "#008800",
-	},
-	".deleted": ki.Props{
-		"color": "#ff4252",
-	},
-	".conflicted": ki.Props{
-		"color": "#ce8020",
-	},
-	".updated": ki.Props{
-		"color": "#008060",
-	},
-	"#icon": ki.Props{
-		"width":   units.NewEm(1),
-		"height":  units.NewEm(1),
-		"margin":  units.NewPx(0),
-		"padding": units.NewPx(0),
-		"fill":    &gi.Prefs.Colors.Icon,
-		"stroke":  &gi.Prefs.Colors.Font,
-	},
-	"#branch": ki.Props{
-		"icon":             "wedge-down",
-		"icon-off":         "wedge-right",
-		"margin":           units.NewPx(0),
-		"padding":          units.NewPx(0),
-		"background-color": color.Transparent,
-		"max-width":        units.NewEm(.8),
-		"max-height":       units.NewEm(.8),
-	},
-	"#space": ki.Props{
-		"width": units.NewEm(.5),
-	},
-	"#label": ki.Props{
-		"margin":    units.NewPx(0),
-		"padding":   units.NewPx(0),
-		"min-width": units.NewCh(16),
-	},
-	"#menu": ki.Props{
-		"indicator": "none",
-	},
-	TreeViewSelectors[TreeViewActive]: ki.Props{},
-	TreeViewSelectors[TreeViewSel]: ki.Props{
-		"background-color": &gi.Prefs.Colors.Select,
-	},
-	TreeViewSelectors[TreeViewFocus]: ki.Props{
-		"background-color": &gi.Prefs.Colors.Control,
-	},
+	"max-width":        -1,
+	"max-height":       -1,
 	"CtxtMenuActive": ki.PropSlice{
 		{"ShowFileInfo", ki.Props{
 			"label": "File Info",
@@ -2560,56 +2516,6 @@ var FileTreeViewProps = ki.Props{
 	},
 }
 
-var fnFolderProps = ki.Props{
-	"icon":     "folder-open",
-	"icon-off": "folder",
-}
-
-func (ft *FileTreeView) Style2D() {
-	fn := ft.FileNode()
-	ft.Class = ""
-	if fn != nil {
-		if fn.IsDir() {
-			if fn.HasChildren() {
-				ft.Icon = gi.IconName("")
-			} else {
-				ft.Icon = gi.IconName("folder")
-			}
-			ft.SetProp("#branch", fnFolderProps)
-			ft.AddClass("folder")
-		} else {
-			ft.Icon = fn.Info.Ic
-			if ft.Icon == "" || ft.Icon == "none" {
-				ft.Icon = "blank"
-			}
-			if fn.IsExec() {
-				ft.AddClass("exec")
-			}
-			if fn.IsOpen() {
-				ft.AddClass("open")
-			}
-			switch fn.Info.Vcs {
-			case vci.Untracked:
-				ft.AddClass("untracked")
-			case vci.Stored:
-				ft.AddClass("stored")
-			case vci.Modified:
-				ft.AddClass("modified")
-			case vci.Added:
-				ft.AddClass("added")
-			case vci.Deleted:
-				ft.AddClass("deleted")
-			case vci.Conflicted:
-				ft.AddClass("conflicted")
-			case vci.Updated:
-				ft.AddClass("updated")
-			}
-		}
-		ft.StyleTreeView()
-		ft.LayState.SetFromStyle(&ft.Sty.Layout) // also does reset
-	}
-}
-
 // FileNodeBufSigRecv receives a signal from the buffer and updates view accordingly
 func FileNodeBufSigRecv(rvwki, sbufki ki.Ki, sig int64, data interface{}) {
 	fn := rvwki.Embed(KiT_FileNode).(*FileNode)
@@ -166,6 +166,7 @@ const (
 func FileViewStyleFunc(tv *TableView, slice interface{}, widg gi.Node2D, row, col int, vv ValueView) {
 	finf, ok := slice.([]*FileInfo)
 	if ok {
+		row -= tv.SrcStart // slice is the page of filtered rows when filtering
 		wi := widg.AsNode2D()
 		if clr, got := FileViewKindColorMap[finf[row].Kind]; got {
 			if _, err := wi.PropTry("color"); err != nil {
@@ -702,6 +703,12 @@ func (fv *FileView) ConnectEvents2D() {
 	fv.FileViewEvents()
 }
 
+func (fv *FileView) AccessInfo2D(ai *gi.AccessInfo) {
+	fv.Frame.AccessInfo2D(ai)
+	ai.Role = gi.AccessFileChooser
+	ai.Value = fv.SelectedFile()
+}
+
 func (fv *FileView) FileViewEvents() {
 	fv.ConnectEvent(oswin.KeyChordEvent, gi.LowPri, func(recv, send ki.Ki, sig int64, d interface{}) {
 		fvv := recv.Embed(KiT_FileView).(*FileView)
@@ -9,6 +9,7 @@ import (
 
 	"github.com/goki/gi/gi"
 	"github.com/goki/gi/oswin"
+	"github.com/goki/gi/oswin/mouse"
 	"github.com/goki/ki/ki"
 	"github.com/goki/ki/kit"
 	"github.com/goki/mat32"
@@ -20,9 +21,12 @@ import (
 // box at the bottom where methods can be invoked
 type GiEditor struct {
 	gi.Frame
-	KiRoot   ki.Ki       `desc:"root of tree being edited"`
-	Changed  bool        `desc:"has the root changed via gui actions?  updated from treeview and structview for changes"`
-	Filename gi.FileName `desc:"current filename for saving / loading"`
+	KiRoot      ki.Ki          `desc:"root of tree being edited"`
+	Changed     bool           `desc:"has the root changed via gui actions?  updated from treeview and structview for changes"`
+	Filename    gi.FileName    `desc:"current filename for saving / loading"`
+	InspectNode ki.Ki          `json:"-" xml:"-" view:"-" desc:"node selected in the tree, whose style is shown in the style inspector"`
+	StyleProps  []gi.StyleProp `json:"-" xml:"-" view:"-" desc:"style properties of the InspectNode, with their sources"`
+	Undos       gi.UndoStack   `json:"-" xml:"-" view:"-" desc:"undo stack for the edits of the tree being edited"`
 }
 
 var KiT_GiEditor = kit.Types.AddType(&GiEditor{}, GiEditorProps)
@@ -32,6 +36,12 @@ func AddNewGiEditor(parent ki.Ki, name string) *GiEditor {
 	return parent.AddNewChild(KiT_GiEditor, name).(*GiEditor)
 }
 
+// UndoStack returns the undo stack for the edits of the tree being edited,
+// satisfying the gi.UndoStacker interface
+func (ge *GiEditor) UndoStack() *gi.UndoStack {
+	return &ge.Undos
+}
+
 // Update updates the objects being edited (e.g., updating display changes)
 func (ge *GiEditor) Update() {
 	if ge.KiRoot == nil {
@@ -70,6 +80,7 @@ func (ge *GiEditor) Open(filename gi.FileName) {
 		return
 	}
 	ge.KiRoot.OpenJSON(string(filename))
+	ge.Undos.Reset()
 	ge.Filename = filename
 	ge.SetFullReRender()
 	ge.UpdateSig() // notify our editor
@@ -81,6 +92,7 @@ func (ge *GiEditor) SetRoot(root ki.Ki) {
 	if ge.KiRoot != root {
 		updt = ge.UpdateStart()
 		ge.KiRoot = root
+		ge.Undos.Reset()
 		// ge.GetAllUpdates(root)
 	}
 	ge.Config()
@@ -114,6 +126,7 @@ func (ge *GiEditor) Config() {
 	config.Add(gi.KiT_SplitView, "splitview")
 	mods, updt := ge.ConfigChildren(config, ki.UniqueNames)
 	ge.SetTitle(fmt.Sprintf("GoGi Editor of Ki Node Tree: %v", ge.KiRoot.Name()))
+	ge.TitleWidget().Redrawable = true
 	ge.ConfigSplitView()
 	ge.ConfigToolbar()
 	if mods {
@@ -148,6 +161,12 @@ func (ge *GiEditor) StructView() *StructView {
 	return ge.SplitView().Child(1).(*StructView)
 }
 
+// StyleFrame returns the frame of the style inspector, showing the style of
+// the InspectNode
+func (ge *GiEditor) StyleFrame() *gi.Frame {
+	return ge.SplitView().Child(2).(*gi.Frame)
+}
+
 // ToolBar returns the toolbar widget
 func (ge *GiEditor) ToolBar() *gi.ToolBar {
 	return ge.ChildByName("toolbar", 1).(*gi.ToolBar)
@@ -176,6 +195,7 @@ func (ge *GiEditor) ConfigSplitView() {
 		tvfr := gi.AddNewFrame(split, "tvfr", gi.LayoutHoriz)
 		tv := AddNewTreeView(tvfr, "tv")
 		sv := AddNewStructView(split, "sv")
+		ge.ConfigStyleFrame(gi.AddNewFrame(split, "styfr", gi.LayoutVert))
 		tv.TreeViewSig.Connect(ge.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
 			if data == nil {
 				return
@@ -185,6 +205,7 @@ func (ge *GiEditor) ConfigSplitView() {
 			tvn, _ := data.(ki.Ki).Embed(KiT_TreeView).(*TreeView)
 			if sig == int64(TreeViewSelected) {
 				svr.SetStruct(tvn.SrcNode)
+				gee.SetInspectNode(tvn.SrcNode)
 			} else if sig == int64(TreeViewChanged) {
 				gee.SetChanged()
 			}
@@ -192,8 +213,9 @@ func (ge *GiEditor) ConfigSplitView() {
 		sv.ViewSig.Connect(ge.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
 			gee, _ := recv.Embed(KiT_GiEditor).(*GiEditor)
 			gee.SetChanged()
+			gee.RestyleInspectNode()
 		})
-		split.SetSplits(.3, .7)
+		split.SetSplits(.25, .4, .35)
 	}
 	tv := ge.TreeView()
 	tv.SetRootNode(ge.KiRoot)
@@ -206,6 +228,160 @@ func (ge *GiEditor) SetChanged() {
 	ge.ToolBar().UpdateActions() // nil safe
 }
 
+// ConfigStyleFrame configures the style inspector in given frame: the box
+// model and the style properties of the InspectNode with their sources,
+// and its properties, which can be edited live
+func (ge *GiEditor) ConfigStyleFrame(fr *gi.Frame) {
+	fr.SetStretchMax()
+	fr.SetProp("spacing", gi.StdDialogVSpaceUnits)
+	tlbl := gi.AddNewLabel(fr, "stytitle", "Style")
+	tlbl.Redrawable = true
+	tlbl.SetProp("font-weight", gi.WeightBold)
+	blbl := gi.AddNewLabel(fr, "boxmodel", "")
+	blbl.Redrawable = true
+	blbl.SetProp("white-space", gi.WhiteSpacePre)
+	tv := AddNewTableView(fr, "styles")
+	tv.SetInactive()
+	tv.SetStretchMax()
+	tv.SetSlice(&ge.StyleProps)
+	plbl := gi.AddNewLabel(fr, "propstitle", "Properties")
+	plbl.SetProp("font-weight", gi.WeightBold)
+	mv := AddNewMapView(fr, "props")
+	mv.SetStretchMaxWidth()
+	mv.ViewSig.Connect(ge.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+		gee, _ := recv.Embed(KiT_GiEditor).(*GiEditor)
+		gee.SetChanged()
+		gee.RestyleInspectNode()
+	})
+}
+
+// SetInspectNode sets the node whose style is shown in the style inspector,
+// and highlights it in its window
+func (ge *GiEditor) SetInspectNode(k ki.Ki) {
+	ge.InspectNode = k
+	nii, _ := gi.KiToNode2D(k)
+	if nii != nil && nii.AsNode2D().ParentWindow() != ge.ParentWindow() {
+		gi.InspectHighlight(nii)
+	} else {
+		gi.InspectHighlight(nil)
+	}
+	mv := ge.StyleFrame().ChildByName("props", 3).(*MapView)
+	if k != nil && k.This() != nil {
+		mv.SetMap(k.Properties())
+	} else {
+		mv.SetMap(nil)
+	}
+	ge.UpdateStyleView()
+}
+
+// UpdateStyleView updates the style inspector for the current style of the
+// InspectNode
+func (ge *GiEditor) UpdateStyleView() {
+	fr := ge.StyleFrame()
+	updt := fr.UpdateStart()
+	defer fr.UpdateEnd(updt)
+	tlbl := fr.ChildByName("stytitle", 0).(*gi.Label)
+	blbl := fr.ChildByName("boxmodel", 1).(*gi.Label)
+	tv := fr.ChildByName("styles", 2).(*TableView)
+	ge.StyleProps = nil
+	nii, _ := gi.KiToNode2D(ge.InspectNode)
+	if nii == nil || nii.AsWidget() == nil {
+		tlbl.SetText("Style")
+		blbl.SetText("")
+		tv.SetSlice(&ge.StyleProps)
+		return
+	}
+	tlbl.SetText(fmt.Sprintf("Style of %v (%v)", nii.Name(), nii.Type().Name()))
+	bx := gi.BoxModel(nii)
+	blbl.SetText(fmt.Sprintf("margin:  %v x %v\nborder:  %v x %v\npadding: %v x %v\ncontent: %v x %v",
+		bx[0].Dx(), bx[0].Dy(), bx[1].Dx(), bx[1].Dy(), bx[2].Dx(), bx[2].Dy(), bx[3].Dx(), bx[3].Dy()))
+	ge.StyleProps = gi.StyleSources(nii)
+	tv.SetSlice(&ge.StyleProps)
+}
+
+// RestyleInspectNode re-styles and re-renders the InspectNode after its
+// properties or fields were edited, and updates the style inspector
+func (ge *GiEditor) RestyleInspectNode() {
+	nii, _ := gi.KiToNode2D(ge.InspectNode)
+	if nii == nil {
+		return
+	}
+	nii.AsNode2D().SetFullReRender()
+	nii.UpdateSig()
+	ge.UpdateStyleView()
+	if nii.AsNode2D().ParentWindow() != ge.ParentWindow() {
+		gi.InspectHighlight(nii)
+	}
+}
+
+// Inspect turns on inspecting, to pick a widget in any window with the
+// mouse: the widget under the mouse is highlighted, and clicking selects it
+// in the tree -- Escape or Inspect again cancels
+func (ge *GiEditor) Inspect() {
+	if gi.IsInspecting() {
+		gi.InspectStop()
+		ge.SetTitle(fmt.Sprintf("GoGi Editor of Ki Node Tree: %v", ge.KiRoot.Name()))
+		ge.TitleWidget().UpdateSig()
+		return
+	}
+	gi.InspectStart(ge)
+	ge.SetTitle("Inspecting: click on a widget in any window to select it, Escape to cancel")
+	ge.TitleWidget().UpdateSig()
+}
+
+// InspectWin returns our window, for the gi.Inspector interface
+func (ge *GiEditor) InspectWin() *gi.Window {
+	return ge.ParentWindow()
+}
+
+// InspectHover shows the widget under the mouse in the title, for the
+// gi.Inspector interface
+func (ge *GiEditor) InspectHover(win *gi.Window, nii gi.Node2D) {
+	gw := ge.ParentWindow()
+	if gw == nil || nii == nil {
+		return
+	}
+	title := fmt.Sprintf("Inspecting: %v (%v)", nii.PathUnique(), nii.Type().Name())
+	gw.RunOnWin(func() {
+		ge.SetTitle(title)
+		ge.TitleWidget().UpdateSig()
+	})
+}
+
+// InspectPick selects the picked widget in the tree, for the gi.Inspector
+// interface -- the tree shows the window of the widget if it is not in the
+// current tree
+func (ge *GiEditor) InspectPick(win *gi.Window, nii gi.Node2D) {
+	gw := ge.ParentWindow()
+	if gw == nil || nii == nil {
+		return
+	}
+	gw.RunOnWin(func() {
+		ge.SelectNode(nii, win)
+	})
+}
+
+// SelectNode selects given node in the tree, opening its parents -- if it
+// is not under the KiRoot, the root is set to the viewport of given window
+func (ge *GiEditor) SelectNode(k ki.Ki, win *gi.Window) {
+	root := ge.KiRoot
+	if root == nil || (k != root && k.ParentLevel(root) < 0) {
+		if win == nil {
+			return
+		}
+		root = win.Viewport
+	}
+	ge.SetRoot(root) // also updates the tree for any changes
+	ge.TitleWidget().UpdateSig()
+	tv := ge.TreeView().FindSrcNode(k)
+	if tv == nil {
+		return
+	}
+	tv.OpenParents()
+	tv.SelectAction(mouse.SelectOne)
+	tv.ScrollToMe()
+}
+
 func (ge *GiEditor) Render2D() {
 	ge.ToolBar().UpdateActions()
 	if win := ge.ParentWindow(); win != nil {
@@ -235,6 +411,10 @@ var GiEditorProps = ki.Props{
 				act.SetActiveStateUpdt(ge.Changed)
 			}),
 		}},
+		{"Inspect", ki.Props{
+			"icon": "search",
+			"desc": "Pick a widget in any window with the mouse, to select it in the tree and show its style -- the widget under the mouse is highlighted with its margin, border, padding and content -- Escape cancels",
+		}},
 		{"sep-file", ki.BlankProp{}},
 		{"Open", ki.Props{
 			"label": "Open",
@@ -276,6 +456,9 @@ var GiEditorProps = ki.Props{
 					act.SetActiveState(ge.Changed)
 				}),
 			}},
+			{"Inspect", ki.Props{
+				"desc": "Pick a widget in any window with the mouse, to select it in the tree and show its style -- Escape cancels",
+			}},
 			{"sep-file", ki.BlankProp{}},
 			{"Open", ki.Props{
 				"shortcut": gi.KeyFunMenuOpen,
@@ -309,7 +492,7 @@ var GiEditorProps = ki.Props{
 			{"sep-close", ki.BlankProp{}},
 			{"Close Window", ki.BlankProp{}},
 		}},
-		{"Edit", "Copy Cut Paste Dupe"},
+		{"Edit", "Undo Redo Copy Cut Paste Dupe"},
 		{"Window", "Windows"},
 	},
 }
@@ -328,7 +328,9 @@ func (mv *MapView) MapAdd() {
 	updt := mv.UpdateStart()
 	defer mv.UpdateEnd(updt)
 
+	undo := NewValueUndo("Add Item", kit.NonPtrValue(reflect.ValueOf(mv.Map)), mv.This())
 	kit.MapAdd(mv.Map)
+	undo.Save()
 
 	if mv.TmpSave != nil {
 		mv.TmpSave.SaveTmp()
@@ -348,7 +350,9 @@ func (mv *MapView) MapDelete(key reflect.Value) {
 
 	kvi := kit.NonPtrValue(key).Interface()
 
+	undo := NewValueUndo("Delete Item", kit.NonPtrValue(reflect.ValueOf(mv.Map)), mv.This())
 	kit.MapDeleteValue(mv.Map, kit.NonPtrValue(key))
+	undo.Save()
 
 	if mv.TmpSave != nil {
 		mv.TmpSave.SaveTmp()
@@ -418,3 +422,8 @@ func (mv *MapView) Render2D() {
 	}
 	mv.Frame.Render2D()
 }
+
+func (mv *MapView) AccessInfo2D(ai *gi.AccessInfo) {
+	mv.Frame.AccessInfo2D(ai)
+	ai.Role = gi.AccessForm
+}
@@ -0,0 +1,620 @@
+// Copyright (c) 2019, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package giv
+
+import (
+	"fmt"
+	"image"
+	"log"
+	"math"
+	"strconv"
+
+	"github.com/goki/gi/gi"
+	"github.com/goki/gi/oswin"
+	"github.com/goki/gi/oswin/key"
+	"github.com/goki/gi/oswin/mouse"
+	"github.com/goki/gi/units"
+	"github.com/goki/ki/ints"
+	"github.com/goki/ki/ki"
+	"github.com/goki/ki/kit"
+	"github.com/goki/mat32"
+	"golang.org/x/image/draw"
+)
+
+// MatrixGridView is a widget that displays a 2D matrix of values as a grid
+// of cells colored through a ColorMap, with a color bar legend showing the
+// range of values, and optional row and column labels.  Values are stored
+// in a flat row-major slice, and each cell is rendered as one pixel of an
+// image that is scaled up to the cell size, so that large matrices can be
+// updated at interactive rates -- call UpdateValues after changing Values in
+// place.  NaN values are shown in the NoColor of the ColorMap.  The mouse
+// scroll wheel zooms in and out around the mouse (with Shift for columns
+// only and Alt for rows only), dragging pans the view, and double-click
+// resets it.  Hovering over a cell shows its value in a tooltip.
+type MatrixGridView struct {
+	gi.WidgetBase
+	Values      []float32    `view:"-" desc:"matrix values, Rows x Cols in row-major order -- see SetMatrix and SetValues"`
+	Rows        int          `inactive:"+" desc:"number of rows in the matrix"`
+	Cols        int          `inactive:"+" desc:"number of columns in the matrix"`
+	ColorMap    ColorMapName `desc:"name of the color map used to color the cells -- default is ColdHot"`
+	FixMin      bool         `desc:"use Min as the value mapped to the lowest color, instead of the minimum of the values"`
+	Min         float32      `desc:"value mapped to the lowest color, if FixMin is set"`
+	FixMax      bool         `desc:"use Max as the value mapped to the highest color, instead of the maximum of the values"`
+	Max         float32      `desc:"value mapped to the highest color, if FixMax is set"`
+	Symmetric   bool         `desc:"make the automatic range symmetric around 0, e.g., for color maps that show 0 in the middle"`
+	RowLabels   []string     `desc:"optional labels shown to the left of the rows"`
+	ColLabels   []string     `desc:"optional labels shown above the columns"`
+	SquareCells bool         `desc:"keep the cells square, instead of filling the available space"`
+	NoColorBar  bool         `desc:"do not show the color bar legend"`
+	ViewRow     int          `copy:"-" json:"-" xml:"-" view:"-" desc:"first visible row, after zooming and panning"`
+	ViewCol     int          `copy:"-" json:"-" xml:"-" view:"-" desc:"first visible column, after zooming and panning"`
+	ViewRows    int          `copy:"-" json:"-" xml:"-" view:"-" desc:"number of visible rows -- 0 = all"`
+	ViewCols    int          `copy:"-" json:"-" xml:"-" view:"-" desc:"number of visible columns -- 0 = all"`
+	RangeMin    float32      `copy:"-" json:"-" xml:"-" view:"-" desc:"value mapped to the lowest color, from the last update"`
+	RangeMax    float32      `copy:"-" json:"-" xml:"-" view:"-" desc:"value mapped to the highest color, from the last update"`
+	GridPos     mat32.Vec2   `copy:"-" json:"-" xml:"-" view:"-" desc:"position of the grid of cells, from the last render"`
+	CellSize    mat32.Vec2   `copy:"-" json:"-" xml:"-" view:"-" desc:"size of each cell, from the last render"`
+	HoverRow    int          `copy:"-" json:"-" xml:"-" view:"-" desc:"row of the cell whose value is shown in the hover tooltip"`
+	HoverCol    int          `copy:"-" json:"-" xml:"-" view:"-" desc:"column of the cell whose value is shown in the hover tooltip"`
+	img         *image.RGBA
+	imgDirty    bool
+	dragRem     mat32.Vec2
+	tr          gi.TextRender
+}
+
+var KiT_MatrixGridView = kit.Types.AddType(&MatrixGridView{}, MatrixGridViewProps)
+
+// AddNewMatrixGridView adds a new matrix grid view to given parent node, with given name.
+func AddNewMatrixGridView(parent ki.Ki, name string) *MatrixGridView {
+	return parent.AddNewChild(KiT_MatrixGridView, name).(*MatrixGridView)
+}
+
+func (mg *MatrixGridView) CopyFieldsFrom(frm interface{}) {
+	fr := frm.(*MatrixGridView)
+	mg.WidgetBase.CopyFieldsFrom(&fr.WidgetBase)
+	mg.Values = append([]float32{}, fr.Values...)
+	mg.Rows = fr.Rows
+	mg.Cols = fr.Cols
+	mg.ColorMap = fr.ColorMap
+	mg.FixMin = fr.FixMin
+	mg.Min = fr.Min
+	mg.FixMax = fr.FixMax
+	mg.Max = fr.Max
+	mg.Symmetric = fr.Symmetric
+	mg.RowLabels = append([]string{}, fr.RowLabels...)
+	mg.ColLabels = append([]string{}, fr.ColLabels...)
+	mg.SquareCells = fr.SquareCells
+	mg.NoColorBar = fr.NoColorBar
+	mg.imgDirty = true
+}
+
+var MatrixGridViewProps = ki.Props{
+	"EnumType:Flag":    gi.KiT_NodeFlags,
+	"padding":          units.NewPx(2),
+	"margin":           units.NewPx(2),
+	"color":            &gi.Prefs.Colors.Font,
+	"background-color": &gi.Prefs.Colors.Background,
+	"min-width":        units.NewEm(10),
+	"min-height":       units.NewEm(10),
+	"width":            units.NewEm(20),
+	"height":           units.NewEm(20),
+	"max-width":        -1,
+	"max-height":       -1,
+}
+
+// SetMatrix sets the values from given 2D matrix, indexed [row][col] --
+// rows shorter than the longest one are filled out with NaN
+func (mg *MatrixGridView) SetMatrix(m [][]float32) {
+	cols := 0
+	for _, r := range m {
+		cols = ints.MaxInt(cols, len(r))
+	}
+	rows := len(m)
+	vals := mg.Values
+	if cap(vals) >= rows*cols {
+		vals = vals[:rows*cols]
+	} else {
+		vals = make([]float32, rows*cols)
+	}
+	nan := float32(math.NaN())
+	for ri, r := range m {
+		copy(vals[ri*cols:], r)
+		for ci := len(r); ci < cols; ci++ {
+			vals[ri*cols+ci] = nan
+		}
+	}
+	mg.SetValues(vals, rows, cols)
+}
+
+// SetValues sets the values to given flat slice of rows x cols values in
+// row-major order -- the slice is used directly, not copied, so it can be
+// changed in place followed by a call to UpdateValues
+func (mg *MatrixGridView) SetValues(vals []float32, rows, cols int) {
+	if len(vals) < rows*cols {
+		log.Printf("giv.MatrixGridView SetValues: %v values is less than shape %v x %v\n", len(vals), rows, cols)
+		return
+	}
+	if rows != mg.Rows || cols != mg.Cols {
+		mg.ViewRow, mg.ViewCol, mg.ViewRows, mg.ViewCols = 0, 0, 0, 0
+	}
+	mg.Values = vals
+	mg.Rows = rows
+	mg.Cols = cols
+	mg.UpdateValues()
+}
+
+// UpdateValues updates the display after the values have changed
+func (mg *MatrixGridView) UpdateValues() {
+	mg.imgDirty = true
+	mg.UpdateSig()
+}
+
+// SetColorMap sets the name of the color map and updates the display
+func (mg *MatrixGridView) SetColorMap(name ColorMapName) {
+	mg.ColorMap = name
+	mg.UpdateValues()
+}
+
+// Value returns the value at given row and column, NaN if out of range
+func (mg *MatrixGridView) Value(row, col int) float32 {
+	if row < 0 || row >= mg.Rows || col < 0 || col >= mg.Cols {
+		return float32(math.NaN())
+	}
+	return mg.Values[row*mg.Cols+col]
+}
+
+// Map returns the ColorMap used to color the cells
+func (mg *MatrixGridView) Map() *ColorMap {
+	if cm, ok := AvailColorMaps[string(mg.ColorMap)]; ok {
+		return cm
+	}
+	return StdColorMaps["ColdHot"]
+}
+
+// UpdateRange updates RangeMin and RangeMax from the values, and the
+// fixed Min, Max settings
+func (mg *MatrixGridView) UpdateRange() {
+	min, max := float32(math.Inf(1)), float32(math.Inf(-1))
+	if !mg.FixMin || !mg.FixMax {
+		for _, v := range mg.Values[:mg.Rows*mg.Cols] {
+			if v != v { // NaN
+				continue
+			}
+			if v < min {
+				min = v
+			}
+			if v > max {
+				max = v
+			}
+		}
+		if min > max {
+			min, max = 0, 1
+		}
+		if mg.Symmetric {
+			amax := mat32.Max(mat32.Abs(min), mat32.Abs(max))
+			min, max = -amax, amax
+		}
+	}
+	if mg.FixMin {
+		min = mg.Min
+	}
+	if mg.FixMax {
+		max = mg.Max
+	}
+	mg.RangeMin, mg.RangeMax = min, max
+}
+
+// NormValue returns the normalized value in the current range of given value
+func (mg *MatrixGridView) NormValue(v float32) float32 {
+	if mg.RangeMax <= mg.RangeMin {
+		return 0.5
+	}
+	return (v - mg.RangeMin) / (mg.RangeMax - mg.RangeMin)
+}
+
+// MatrixGridColors is the number of colors looked up from the color map for
+// the cells -- values are mapped onto the nearest of these colors
+var MatrixGridColors = 256
+
+// UpdateImage updates the image of the cells, with one pixel per cell
+func (mg *MatrixGridView) UpdateImage() {
+	mg.imgDirty = false
+	if mg.Rows == 0 || mg.Cols == 0 {
+		mg.img = nil
+		return
+	}
+	mg.UpdateRange()
+	cm := mg.Map()
+	nc := MatrixGridColors
+	lut := make([]gi.Color, nc)
+	for i := range lut {
+		lut[i] = cm.Map(float64(i) / float64(nc-1))
+	}
+	if mg.img == nil || mg.img.Rect.Dx() != mg.Cols || mg.img.Rect.Dy() != mg.Rows {
+		mg.img = image.NewRGBA(image.Rect(0, 0, mg.Cols, mg.Rows))
+	}
+	pix := mg.img.Pix
+	for i, v := range mg.Values[:mg.Rows*mg.Cols] {
+		var clr gi.Color
+		if v != v { // NaN
+			clr = cm.NoColor
+		} else {
+			ci := int(mg.NormValue(v)*float32(nc-1) + 0.5)
+			clr = lut[ints.MaxInt(0, ints.MinInt(ci, nc-1))]
+		}
+		pi := i * 4
+		pix[pi], pix[pi+1], pix[pi+2], pix[pi+3] = clr.R, clr.G, clr.B, clr.A
+	}
+}
+
+// VisRows returns the number of visible rows
+func (mg *MatrixGridView) VisRows() int {
+	if mg.ViewRows <= 0 || mg.ViewRows > mg.Rows {
+		return mg.Rows
+	}
+	return mg.ViewRows
+}
+
+// VisCols returns the number of visible columns
+func (mg *MatrixGridView) VisCols() int {
+	if mg.ViewCols <= 0 || mg.ViewCols > mg.Cols {
+		return mg.Cols
+	}
+	return mg.ViewCols
+}
+
+// ClampView keeps the visible window of cells within the matrix
+func (mg *MatrixGridView) ClampView() {
+	mg.ViewRow = ints.MaxInt(0, ints.MinInt(mg.ViewRow, mg.Rows-mg.VisRows()))
+	mg.ViewCol = ints.MaxInt(0, ints.MinInt(mg.ViewCol, mg.Cols-mg.VisCols()))
+}
+
+// ResetView resets any zooming and panning, to show the whole matrix
+func (mg *MatrixGridView) ResetView() {
+	mg.ViewRow, mg.ViewCol, mg.ViewRows, mg.ViewCols = 0, 0, 0, 0
+	mg.UpdateSig()
+}
+
+// ZoomView zooms the visible window by given factor (< 1 zooms in), around
+// given cell
+func (mg *MatrixGridView) ZoomView(factor float32, row, col int, rows, cols bool) {
+	if rows {
+		vr := mg.VisRows()
+		nr := ints.MaxInt(1, ints.MinInt(mg.Rows, int(mat32.Round(float32(vr)*factor))))
+		if nr == vr && factor > 1 {
+			nr = ints.MinInt(mg.Rows, vr+1)
+		} else if nr == vr && factor < 1 {
+			nr = ints.MaxInt(1, vr-1)
+		}
+		mg.ViewRow = row - int(float32(row-mg.ViewRow)*float32(nr)/float32(vr))
+		mg.ViewRows = nr
+	}
+	if cols {
+		vc := mg.VisCols()
+		nc := ints.MaxInt(1, ints.MinInt(mg.Cols, int(mat32.Round(float32(vc)*factor))))
+		if nc == vc && factor > 1 {
+			nc = ints.MinInt(mg.Cols, vc+1)
+		} else if nc == vc && factor < 1 {
+			nc = ints.MaxInt(1, vc-1)
+		}
+		mg.ViewCol = col - int(float32(col-mg.ViewCol)*float32(nc)/float32(vc))
+		mg.ViewCols = nc
+	}
+	mg.ClampView()
+	mg.UpdateSig()
+}
+
+// CellAt returns the row and column of the cell at given window position,
+// false if not over a cell
+func (mg *MatrixGridView) CellAt(pt image.Point) (row, col int, ok bool) {
+	if mg.CellSize.X <= 0 || mg.CellSize.Y <= 0 {
+		return 0, 0, false
+	}
+	mg.BBoxMu.RLock()
+	off := mg.WinBBox.Min
+	mg.BBoxMu.RUnlock()
+	p := mat32.NewVec2FmPoint(pt.Sub(off)).Add(mg.LayState.Alloc.Pos).Sub(mg.GridPos)
+	if p.X < 0 || p.Y < 0 {
+		return 0, 0, false
+	}
+	col = int(p.X / mg.CellSize.X)
+	row = int(p.Y / mg.CellSize.Y)
+	if row >= mg.VisRows() || col >= mg.VisCols() {
+		return 0, 0, false
+	}
+	return mg.ViewRow + row, mg.ViewCol + col, true
+}
+
+// CellTooltip returns the tooltip text for given cell
+func (mg *MatrixGridView) CellTooltip(row, col int) string {
+	rl := strconv.Itoa(row)
+	if row < len(mg.RowLabels) {
+		rl = mg.RowLabels[row]
+	}
+	cl := strconv.Itoa(col)
+	if col < len(mg.ColLabels) {
+		cl = mg.ColLabels[col]
+	}
+	return fmt.Sprintf("[%v, %v]: %v", rl, cl, mg.Value(row, col))
+}
+
+// PopupCellTooltip pops up the tooltip with the value of given cell
+func (mg *MatrixGridView) PopupCellTooltip(row, col int, mpos image.Point) {
+	mg.HoverRow, mg.HoverCol = row, col
+	tt := gi.NewTooltipOpts(mg.CellTooltip(row, col))
+	tt.FollowCursor = true
+	mg.PopupTooltipFor(tt, mpos)
+}
+
+// MouseEvent handles double-click to reset the view
+func (mg *MatrixGridView) MouseEvent() {
+	mg.ConnectEvent(oswin.MouseEvent, gi.RegPri, func(recv, send ki.Ki, sig int64, d interface{}) {
+		me := d.(*mouse.Event)
+		mgv := recv.Embed(KiT_MatrixGridView).(*MatrixGridView)
+		if me.Button == mouse.Left && me.Action == mouse.DoubleClick {
+			me.SetProcessed()
+			mgv.ResetView()
+		}
+	})
+}
+
+// MouseScrollEvent zooms around the mouse position
+func (mg *MatrixGridView) MouseScrollEvent() {
+	mg.ConnectEvent(oswin.MouseScrollEvent, gi.RegPri, func(recv, send ki.Ki, sig int64, d interface{}) {
+		me := d.(*mouse.ScrollEvent)
+		mgv := recv.Embed(KiT_MatrixGridView).(*MatrixGridView)
+		row, col, ok := mgv.CellAt(me.Where)
+		if !ok {
+			return
+		}
+		del := me.NonZeroDelta(false)
+		if del == 0 {
+			return
+		}
+		me.SetProcessed()
+		factor := float32(1.25)
+		if del < 0 {
+			factor = 0.8
+		}
+		mgv.ZoomView(factor, row, col, !me.HasAnyModifier(key.Shift), !me.HasAnyModifier(key.Alt))
+	})
+}
+
+// MouseDragEvent pans the view
+func (mg *MatrixGridView) MouseDragEvent() {
+	mg.ConnectEvent(oswin.MouseDragEvent, gi.RegPri, func(recv, send ki.Ki, sig int64, d interface{}) {
+		me := d.(*mouse.DragEvent)
+		mgv := recv.Embed(KiT_MatrixGridView).(*MatrixGridView)
+		if mgv.CellSize.X <= 0 || mgv.CellSize.Y <= 0 {
+			return
+		}
+		me.SetProcessed()
+		mgv.dragRem = mgv.dragRem.Sub(mat32.NewVec2FmPoint(me.Delta()).Div(mgv.CellSize))
+		dc, dr := int(mgv.dragRem.X), int(mgv.dragRem.Y)
+		if dc == 0 && dr == 0 {
+			return
+		}
+		mgv.dragRem.X -= float32(dc)
+		mgv.dragRem.Y -= float32(dr)
+		mgv.ViewRow += dr
+		mgv.ViewCol += dc
+		mgv.ClampView()
+		mgv.UpdateSig()
+	})
+}
+
+// MouseHoverEvent shows the value of the cell under the mouse in a tooltip
+func (mg *MatrixGridView) MouseHoverEvent() {
+	mg.ConnectEvent(oswin.MouseHoverEvent, gi.RegPri, func(recv, send ki.Ki, sig int64, d interface{}) {
+		me := d.(*mouse.HoverEvent)
+		mgv := recv.Embed(KiT_MatrixGridView).(*MatrixGridView)
+		if row, col, ok := mgv.CellAt(me.Where); ok {
+			me.SetProcessed()
+			mgv.PopupCellTooltip(row, col, me.Where)
+		}
+	})
+}
+
+// MouseMoveEvent updates the value tooltip when the mouse moves to another
+// cell while it is shown
+func (mg *MatrixGridView) MouseMoveEvent() {
+	mg.ConnectEvent(oswin.MouseMoveEvent, gi.RegPri, func(recv, send ki.Ki, sig int64, d interface{}) {
+		me := d.(*mouse.MoveEvent)
+		mgv := recv.Embed(KiT_MatrixGridView).(*MatrixGridView)
+		mvp := mgv.ViewportSafe()
+		if mvp == nil || mvp.Win == nil {
+			return
+		}
+		tv := mvp.Win.CurTooltip()
+		if tv == nil || tv.Nm != mgv.Nm+"Tooltip" {
+			return
+		}
+		row, col, ok := mgv.CellAt(me.Where)
+		if !ok || (row == mgv.HoverRow && col == mgv.HoverCol) {
+			return
+		}
+		mvp.Win.ClosePopup(tv.This())
+		mgv.PopupCellTooltip(row, col, me.Where)
+	})
+}
+
+func (mg *MatrixGridView) ConnectEvents2D() {
+	mg.MouseEvent()
+	mg.MouseScrollEvent()
+	mg.MouseDragEvent()
+	mg.MouseHoverEvent()
+	mg.MouseMoveEvent()
+}
+
+func (mg *MatrixGridView) AccessInfo2D(ai *gi.AccessInfo) {
+	mg.WidgetBase.AccessInfo2D(ai)
+	ai.Role = gi.AccessChart
+	ai.Label = ai.Description
+	ai.Value = fmt.Sprintf("%d x %d", mg.Rows, mg.Cols)
+}
+
+// labelText renders given text at given position -- rotated 90 degrees
+// clockwise if vert
+func (mg *MatrixGridView) labelText(rs *gi.RenderState, st *gi.Style, str string, pos mat32.Vec2, vert bool) {
+	if vert {
+		mg.tr.SetStringRot90(str, &st.Font, &st.UnContext, &st.Text, true, 0)
+		asc := mat32.FromFixed(st.Font.Face.Face.Metrics().Ascent)
+		mg.tr.Render(rs, mat32.Vec2{pos.X + st.Font.Face.Metrics.Height - asc, pos.Y})
+	} else {
+		mg.tr.SetString(str, &st.Font, &st.UnContext, &st.Text, true, 0, 0)
+		mg.tr.RenderTopPos(rs, pos)
+	}
+}
+
+// textWidth returns the width of given text
+func (mg *MatrixGridView) textWidth(st *gi.Style, str string) float32 {
+	mg.tr.SetString(str, &st.Font, &st.UnContext, &st.Text, true, 0, 0)
+	return mg.tr.Size.X
+}
+
+// maxTextWidth returns the maximum width of given texts
+func (mg *MatrixGridView) maxTextWidth(st *gi.Style, strs []string) float32 {
+	w := float32(0)
+	for _, s := range strs {
+		w = mat32.Max(w, mg.textWidth(st, s))
+	}
+	return w
+}
+
+// RenderMatrixGrid renders the cells, labels and color bar
+func (mg *MatrixGridView) RenderMatrixGrid() {
+	rs, pc, st := mg.RenderLock()
+	defer mg.RenderUnlock(rs)
+	mg.RenderStdBox(st)
+	if st.Font.Face == nil {
+		st.Font.OpenFont(&st.UnContext)
+	}
+	if mg.imgDirty || mg.img == nil {
+		mg.UpdateImage()
+	}
+	mg.ClampView()
+	spc := st.BoxSpace()
+	pos := mg.LayState.Alloc.Pos.AddScalar(spc)
+	sz := mg.LayState.Alloc.Size.AddScalar(-2 * spc)
+	fht := st.Font.Face.Metrics.Height
+	gap := mat32.Round(0.3 * fht)
+	fg := st.Font.Color
+
+	vr, vc := mg.VisRows(), mg.VisCols()
+	rlabs := len(mg.RowLabels) > 0
+	clabs := len(mg.ColLabels) > 0
+
+	// color bar on the right
+	cbw := float32(0)
+	var cbLabels []string
+	if !mg.NoColorBar {
+		mid := 0.5 * (mg.RangeMin + mg.RangeMax)
+		cbLabels = []string{mg.rangeLabel(mg.RangeMax), mg.rangeLabel(mid), mg.rangeLabel(mg.RangeMin)}
+		cbw = fht + gap + mg.maxTextWidth(st, cbLabels) + gap
+	}
+
+	gpos := pos
+	gsz := mat32.Vec2{sz.X - cbw, sz.Y}
+	var rlw, clh float32
+	var cvert bool
+	if rlabs {
+		rlw = mg.maxTextWidth(st, mg.RowLabels[mg.ViewRow:ints.MinInt(len(mg.RowLabels), mg.ViewRow+vr)]) + gap
+	}
+	if clabs && vc > 0 {
+		cls := mg.ColLabels[mg.ViewCol:ints.MinInt(len(mg.ColLabels), mg.ViewCol+vc)]
+		clw := mg.maxTextWidth(st, cls)
+		cvert = clw+gap > (gsz.X-rlw)/float32(vc)
+		if cvert {
+			clh = clw + gap
+		} else {
+			clh = fht + gap
+		}
+	}
+	gpos.X += rlw
+	gpos.Y += clh
+	gsz.X -= rlw
+	gsz.Y -= clh
+	if vr == 0 || vc == 0 || gsz.X < 1 || gsz.Y < 1 || mg.img == nil {
+		mg.CellSize = mat32.Vec2{}
+		return
+	}
+	csz := mat32.Vec2{gsz.X / float32(vc), gsz.Y / float32(vr)}
+	if mg.SquareCells {
+		csz.X = mat32.Min(csz.X, csz.Y)
+		csz.Y = csz.X
+	}
+	mg.GridPos, mg.CellSize = gpos, csz
+
+	// cells: the image region of visible cells, scaled to the grid
+	sr := image.Rect(mg.ViewCol, mg.ViewRow, mg.ViewCol+vc, mg.ViewRow+vr)
+	dr := image.Rect(int(gpos.X), int(gpos.Y), int(gpos.X+csz.X*float32(vc)), int(gpos.Y+csz.Y*float32(vr)))
+	if dst, ok := rs.Image.SubImage(rs.Bounds).(*image.RGBA); ok {
+		draw.NearestNeighbor.Scale(dst, dr, mg.img, sr, draw.Src, nil)
+	}
+
+	// labels, skipping some when cells are smaller than the text
+	if rlabs {
+		step := ints.MaxInt(1, int(mat32.Ceil(fht/csz.Y)))
+		for r := mg.ViewRow; r < mg.ViewRow+vr && r < len(mg.RowLabels); r += step {
+			y := gpos.Y + float32(r-mg.ViewRow)*csz.Y + 0.5*(csz.Y-fht)
+			lb := mg.RowLabels[r]
+			mg.labelText(rs, st, lb, mat32.Vec2{gpos.X - gap - mg.textWidth(st, lb), y}, false)
+		}
+	}
+	if clabs {
+		step := 1
+		if cvert {
+			step = ints.MaxInt(1, int(mat32.Ceil(fht/csz.X)))
+		}
+		for c := mg.ViewCol; c < mg.ViewCol+vc && c < len(mg.ColLabels); c += step {
+			x := gpos.X + float32(c-mg.ViewCol)*csz.X
+			lb := mg.ColLabels[c]
+			if cvert {
+				mg.labelText(rs, st, lb, mat32.Vec2{x + 0.5*(csz.X-fht), pos.Y}, true)
+			} else {
+				mg.labelText(rs, st, lb, mat32.Vec2{x + 0.5*(csz.X-mg.textWidth(st, lb)), pos.Y}, false)
+			}
+		}
+	}
+
+	// color bar
+	if !mg.NoColorBar {
+		cm := mg.Map()
+		cx := gpos.X + csz.X*float32(vc) + gap
+		ch := csz.Y * float32(vr)
+		for y := float32(0); y < ch; y++ {
+			clr := cm.Map(float64(1 - y/(ch-1)))
+			pc.FillBoxColor(rs, mat32.Vec2{cx, gpos.Y + y}, mat32.Vec2{fht, 1}, clr)
+		}
+		lx := cx + fht + gap
+		mg.labelText(rs, st, cbLabels[0], mat32.Vec2{lx, gpos.Y}, false)
+		mg.labelText(rs, st, cbLabels[1], mat32.Vec2{lx, gpos.Y + 0.5*(ch-fht)}, false)
+		mg.labelText(rs, st, cbLabels[2], mat32.Vec2{lx, gpos.Y + ch - fht}, false)
+		pc.StrokeStyle.SetColor(fg)
+		pc.StrokeStyle.Width.Dots = 1
+		pc.FillStyle.SetColor(nil)
+		pc.DrawRectangle(rs, cx, gpos.Y, fht, ch)
+		pc.Stroke(rs)
+	}
+}
+
+// rangeLabel returns the color bar label for given value
+func (mg *MatrixGridView) rangeLabel(v float32) string {
+	return strconv.FormatFloat(float64(v), 'g', 4, 32)
+}
+
+func (mg *MatrixGridView) Render2D() {
+	if mg.FullReRenderIfNeeded() {
+		return
+	}
+	if mg.PushBounds() {
+		mg.This().(gi.Node2D).ConnectEvents2D()
+		mg.RenderMatrixGrid()
+		mg.Render2DChildren()
+		mg.PopBounds()
+	} else {
+		mg.DisconnectAllEvents(gi.RegPri)
+	}
+}
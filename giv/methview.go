@@ -23,6 +23,7 @@ import (
 // these are special menus that we ignore
 var specialMenus = map[string]struct{}{
 	"AppMenu": {}, "Copy Cut Paste": {}, "Copy Cut Paste Dupe": {}, "Windows": {},
+	"Undo Redo Copy Cut Paste": {}, "Undo Redo Copy Cut Paste Dupe": {},
 }
 
 // MainMenuView configures the given MenuBar according to the "MainMenu"
@@ -66,12 +67,17 @@ func MainMenuView(val interface{}, win *gi.Window, mbar *gi.MenuBar) bool {
 		}
 		if mm.Name == "Edit" {
 			if ms, ok := mm.Value.(string); ok {
+				if strings.HasPrefix(ms, "Undo Redo ") {
+					ma.Menu.AddUndoRedo(win)
+					ma.Menu.AddSeparator("sep-undo")
+					ms = strings.TrimPrefix(ms, "Undo Redo ")
+				}
 				if ms == "Copy Cut Paste" {
 					ma.Menu.AddCopyCutPaste(win)
 				} else if ms == "Copy Cut Paste Dupe" {
 					ma.Menu.AddCopyCutPasteDupe(win)
 				} else {
-					MethViewErr(vtyp, fmt.Sprintf("Unrecognized Edit menu special string: %v -- `Copy Cut Paste` is standard", ms))
+					MethViewErr(vtyp, fmt.Sprintf("Unrecognized Edit menu special string: %v -- `Copy Cut Paste` is standard, optionally preceded by `Undo Redo`", ms))
 				}
 				continue
 			}
@@ -306,7 +312,7 @@ func MethViewCompileActions(cmp ki.Props, val interface{}, vtyp reflect.Type, vp
 		}
 		ac := &gi.Action{}
 		ac.InitName(ac, pnm)
-		ac.Text = strings.Replace(strings.Join(camelcase.Split(ac.Nm), " "), "  ", " ", -1)
+		ac.Text = gi.TrText(ac, strings.Replace(strings.Join(camelcase.Split(ac.Nm), " "), "  ", " ", -1))
 		cmp[pnm] = ac
 		rv := false
 		switch pv := pp.(type) {
@@ -384,7 +390,7 @@ var MethodViewCallMethsProp = "__MethViewCallMeths"
 // val of given type -- could have a sub-menu of further actions or might just
 // be a single action
 func ActionsView(val interface{}, vtyp reflect.Type, vp *gi.Viewport2D, pa *gi.Action, pp interface{}) bool {
-	pa.Text = strings.Replace(strings.Join(camelcase.Split(pa.Nm), " "), "  ", " ", -1)
+	pa.Text = gi.TrText(pa, strings.Replace(strings.Join(camelcase.Split(pa.Nm), " "), "  ", " ", -1))
 	rval := true
 	switch pv := pp.(type) {
 	case ki.PropSlice:
@@ -485,8 +491,9 @@ func ActionView(val interface{}, vtyp reflect.Type, vp *gi.Viewport2D, ac *gi.Ac
 				bitflag.Set32((*int32)(&md.Flags), int(MethViewKeyFun))
 			}
 		case "label":
-			ac.Text = kit.ToString(pv)
+			ac.Text = gi.TrText(ac, kit.ToString(pv))
 		case "label-func":
+			ac.DeleteProp(gi.TrTextProp) // label is dynamic
 			if sf, ok := pv.(LabelFunc); ok {
 				str := sf(md.Val, ac)
 				ac.Text = str
@@ -499,7 +506,7 @@ func ActionView(val interface{}, vtyp reflect.Type, vp *gi.Viewport2D, ac *gi.Ac
 			ac.Icon = gi.IconName(kit.ToString(pv))
 		case "desc":
 			md.Desc = kit.ToString(pv)
-			ac.Tooltip = md.Desc
+			ac.Tooltip = gi.TrTooltip(ac, md.Desc)
 		case "confirm":
 			bitflag.Set32((*int32)(&md.Flags), int(MethViewConfirm))
 		case "show-return":
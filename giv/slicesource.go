@@ -0,0 +1,500 @@
+// Copyright (c) 2018, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package giv
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"io"
+	"log"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/goki/ki/ints"
+	"github.com/goki/ki/kit"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////
+//  SliceSource
+
+// SliceSource is a data-source interface for SliceViewBase and TableView,
+// providing access to rows of data held in external storage (a database
+// table, a large file, a paged network API, etc), instead of a Go slice held
+// in memory.  The view only ever fetches the rows that are currently
+// displayed, so the total number of rows can be arbitrarily large.  Set with
+// SetSource -- all of the existing row widget recycling, styling (StyleFunc)
+// and selection logic applies as usual.
+type SliceSource interface {
+	// SrcLen returns the current number of rows, after any filtering
+	SrcLen() int
+
+	// SrcRows returns the rows in index range [st, ed) as a slice -- this
+	// must always return the same type of slice (e.g., []*MyRec or []MyRec),
+	// and SrcRows(0, 0) must return an empty slice of that type, which is
+	// used to determine the element type when configuring the view
+	SrcRows(st, ed int) interface{}
+}
+
+// SliceSourceSorter is an optional interface for a SliceSource that supports
+// sorting by a given field (struct field name for TableView)
+type SliceSourceSorter interface {
+	// SrcSort sorts the rows by given field, in ascending or descending order
+	SrcSort(field string, ascending bool)
+}
+
+// SliceSourceFilterer is an optional interface for a SliceSource that supports
+// filtering -- the interpretation of the filter string is up to the source
+// (e.g., a substring match or a SQL WHERE clause) -- empty = no filter
+type SliceSourceFilterer interface {
+	// SrcFilter sets the filter to apply -- SrcLen then returns the number of
+	// rows that pass the filter, and indexes are in terms of those rows
+	SrcFilter(filter string)
+}
+
+// SliceSourceEditor is an optional interface for a SliceSource that supports
+// editing -- if the source does not implement this interface, the view is
+// set to Inactive (select-only).  row values are pointers to the element
+// type, as returned in the SrcRows slice.
+type SliceSourceEditor interface {
+	// SrcSetRow saves the (edited) row value at given index
+	SrcSetRow(idx int, row interface{}) error
+
+	// SrcInsertRow inserts given row value at given index (-1 = end)
+	SrcInsertRow(idx int, row interface{}) error
+
+	// SrcDeleteRow deletes the row at given index
+	SrcDeleteRow(idx int) error
+}
+
+// SetSource sets a SliceSource as the source of data for the view, instead
+// of a Slice -- only the rows that are displayed are fetched from the source.
+// If the source does not implement SliceSourceEditor, the view is Inactive.
+// Call SetSource(nil) before calling SetSlice to go back to viewing a slice.
+func (sv *SliceViewBase) SetSource(src SliceSource) {
+	if kit.IfaceIsNil(src) {
+		sv.Src = nil
+		sv.Slice = nil
+		return
+	}
+	sv.Src = src
+	sv.SrcStart = 0
+	sv.SrcStale = true
+	if _, ok := src.(SliceSourceEditor); !ok {
+		sv.SetInactive()
+	}
+	sv.This().(SliceViewer).SetSlice(sv.SrcPage(0, ints.MinInt(1, src.SrcLen())))
+}
+
+// SrcPage fetches rows [st, ed) from the Src into a new page slice, and
+// returns a pointer to that slice, which is used as the Slice for the view,
+// with SrcStart = st
+func (sv *SliceViewBase) SrcPage(st, ed int) interface{} {
+	rows := sv.Src.SrcRows(st, ed)
+	pg := reflect.New(reflect.TypeOf(rows))
+	pg.Elem().Set(reflect.ValueOf(rows))
+	sv.SrcStart = st
+	sv.SrcStale = false
+	return pg.Interface()
+}
+
+// SrcFetch ensures that the current page of rows fetched from the Src covers
+// the displayed rows -- called in UpdateSliceGrid
+func (sv *SliceViewBase) SrcFetch() {
+	if sv.Src == nil {
+		return
+	}
+	if !sv.SrcStale && sv.SrcStart == sv.StartIdx && sv.SliceNPVal.Len() == sv.DispRows {
+		return
+	}
+	sv.Slice = sv.SrcPage(sv.StartIdx, sv.StartIdx+sv.DispRows)
+	sv.SliceNPVal = kit.NonPtrValue(reflect.ValueOf(sv.Slice))
+	sv.DispRows = ints.MinInt(sv.DispRows, sv.SliceNPVal.Len()) // in case source shrank
+}
+
+// SrcRowVal returns the reflect.Value of the element at given slice index,
+// which is relative to SrcStart when viewing a Src
+func (sv *SliceViewBase) SrcRowVal(idx int) reflect.Value {
+	return sv.SliceNPVal.Index(idx - sv.SrcStart)
+}
+
+// SrcSaveRow saves the row at given slice index back to an editable Src --
+// called when a value has been edited
+func (sv *SliceViewBase) SrcSaveRow(idx int) {
+	ed, ok := sv.Src.(SliceSourceEditor)
+	if !ok || idx < sv.SrcStart || idx-sv.SrcStart >= sv.SliceNPVal.Len() {
+		return
+	}
+	val := kit.OnePtrUnderlyingValue(sv.SrcRowVal(idx))
+	if err := ed.SrcSetRow(idx, val.Interface()); err != nil {
+		log.Printf("giv.SliceViewBase SrcSaveRow error: %v\n", err)
+	}
+}
+
+// SrcInsertRows inserts given row values (pointers to elements) at given
+// index in an editable Src
+func (sv *SliceViewBase) SrcInsertRows(idx int, rows []interface{}) {
+	ed, ok := sv.Src.(SliceSourceEditor)
+	if !ok {
+		return
+	}
+	for i, rw := range rows {
+		at := idx
+		if idx >= 0 {
+			at += i
+		}
+		if err := ed.SrcInsertRow(at, rw); err != nil {
+			log.Printf("giv.SliceViewBase SrcInsertRows error: %v\n", err)
+		}
+	}
+	sv.SrcStale = true
+}
+
+// SrcDeleteRow deletes row at given index from an editable Src
+func (sv *SliceViewBase) SrcDeleteRow(idx int) {
+	ed, ok := sv.Src.(SliceSourceEditor)
+	if !ok {
+		return
+	}
+	if err := ed.SrcDeleteRow(idx); err != nil {
+		log.Printf("giv.SliceViewBase SrcDeleteRow error: %v\n", err)
+	}
+	sv.SrcStale = true
+}
+
+// SrcNewRow returns a pointer to a new blank element of the Src row type
+func (sv *SliceViewBase) SrcNewRow() interface{} {
+	return reflect.New(kit.NonPtrType(kit.SliceElType(sv.Slice))).Interface()
+}
+
+// SetSrcFilter sets the filter on the Src if it implements SliceSourceFilterer,
+// and updates the display
+func (sv *SliceViewBase) SetSrcFilter(filter string) {
+	flt, ok := sv.Src.(SliceSourceFilterer)
+	if !ok {
+		return
+	}
+	flt.SrcFilter(filter)
+	sv.StartIdx = 0
+	sv.ResetSelectedIdxs()
+	sv.SrcRefresh()
+}
+
+// SrcRefresh re-fetches the displayed rows from the Src and updates the
+// display -- call when the underlying data has changed
+func (sv *SliceViewBase) SrcRefresh() {
+	sv.SrcStale = true
+	sv.ScrollBar().SetFullReRender()
+	sv.Update()
+}
+
+////////////////////////////////////////////////////////////////////////////////////////
+//  CSVSource
+
+// CSVSource is a read-only SliceSource for a CSV (or TSV) file, which
+// presents each row of the file as a struct of given type, with columns
+// assigned to fields by matching header names to field names (case
+// insensitive).  It only records the file offset of each line when
+// opened, and reads rows from the file as they are displayed, so it can
+// browse files that are much too large to load into memory.  Quoted fields
+// containing newlines are not supported.  It implements SliceSourceFilterer
+// as a case-insensitive substring match on the raw line, and
+// SliceSourceSorter with a stable sort of the line order on the values of a
+// column, which reads the whole file but only keeps the sorted values.
+type CSVSource struct {
+	FileName string       `desc:"file name of the CSV file"`
+	Comma    rune         `desc:"field delimiter -- ',' for CSV, '\t' for TSV"`
+	RowType  reflect.Type `desc:"non-pointer struct type for each row"`
+	Header   []string     `desc:"column names from the first line of file"`
+	Filter   string       `desc:"current filter"`
+	ColFlds  [][]int      `desc:"field index for each column (nil if no matching field)"`
+	Offs     []int64      `desc:"file offset of the start of each line, after the header, and the end of file at the end"`
+	Order    []int        `desc:"indexes into Offs of all lines in the current sort order -- nil if not sorted (file order)"`
+	Rows     []int        `desc:"indexes into Offs of rows passing the current filter, in sort order -- nil if no filter"`
+	Mu       sync.Mutex   `desc:"mutex protecting file access"`
+	file     *os.File
+}
+
+// NewCSVSource opens given CSV file, indexing its lines, for viewing rows as
+// structs of the same type as rowType (a struct or pointer to struct) --
+// comma is the delimiter (e.g., ',' or '\t')
+func NewCSVSource(fname string, rowType interface{}, comma rune) (*CSVSource, error) {
+	cs := &CSVSource{FileName: fname, Comma: comma}
+	cs.RowType = kit.NonPtrType(reflect.TypeOf(rowType))
+	err := cs.Open()
+	return cs, err
+}
+
+// Open opens the file and indexes the line offsets
+func (cs *CSVSource) Open() error {
+	cs.Mu.Lock()
+	defer cs.Mu.Unlock()
+	if cs.file != nil {
+		cs.file.Close()
+	}
+	f, err := os.Open(cs.FileName)
+	if err != nil {
+		return err
+	}
+	cs.file = f
+	br := bufio.NewReader(f)
+	var off int64
+	cs.Offs = cs.Offs[:0]
+	first := true
+	for {
+		ln, err := br.ReadBytes('\n')
+		if len(ln) > 0 {
+			if first {
+				cs.SetHeader(string(ln))
+				first = false
+			} else if len(bytes.TrimSpace(ln)) > 0 {
+				cs.Offs = append(cs.Offs, off)
+			}
+			off += int64(len(ln))
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+	cs.Offs = append(cs.Offs, off)
+	cs.Order = nil
+	cs.Rows = nil
+	return nil
+}
+
+// Close closes the file
+func (cs *CSVSource) Close() {
+	cs.Mu.Lock()
+	if cs.file != nil {
+		cs.file.Close()
+		cs.file = nil
+	}
+	cs.Mu.Unlock()
+}
+
+// SetHeader sets the Header and ColFlds from the given header line
+func (cs *CSVSource) SetHeader(ln string) {
+	cs.Header = cs.ParseLine(ln)
+	cs.ColFlds = make([][]int, len(cs.Header))
+	for ci, hd := range cs.Header {
+		hd = strings.TrimSpace(hd)
+		if fld, ok := cs.RowType.FieldByNameFunc(func(nm string) bool {
+			return strings.EqualFold(nm, hd)
+		}); ok {
+			cs.ColFlds[ci] = fld.Index
+		}
+	}
+}
+
+// ParseLine parses one line of the file into fields
+func (cs *CSVSource) ParseLine(ln string) []string {
+	cr := csv.NewReader(strings.NewReader(ln))
+	cr.Comma = cs.Comma
+	cr.FieldsPerRecord = -1
+	cr.LazyQuotes = true
+	rec, err := cr.Read()
+	if err != nil {
+		return nil
+	}
+	return rec
+}
+
+// NLines returns the total number of lines (rows) in the file, unfiltered
+func (cs *CSVSource) NLines() int {
+	return ints.MaxInt(0, len(cs.Offs)-1)
+}
+
+// Line returns the raw text of given line (row) in the file
+func (cs *CSVSource) Line(li int) string {
+	st, ed := cs.Offs[li], cs.Offs[li+1]
+	b := make([]byte, ed-st)
+	_, err := cs.file.ReadAt(b, st)
+	if err != nil && err != io.EOF {
+		log.Printf("giv.CSVSource: %v\n", err)
+	}
+	return string(b)
+}
+
+// RowFromLine returns a new pointer to a struct set from given line of text
+func (cs *CSVSource) RowFromLine(ln string) reflect.Value {
+	rv := reflect.New(cs.RowType)
+	rec := cs.ParseLine(ln)
+	for ci, cv := range rec {
+		if ci >= len(cs.ColFlds) || cs.ColFlds[ci] == nil {
+			continue
+		}
+		fv := rv.Elem().FieldByIndex(cs.ColFlds[ci])
+		kit.SetRobust(fv.Addr().Interface(), strings.TrimSpace(cv))
+	}
+	return rv
+}
+
+// LineIdx returns the index of the line in the file for given row index, in
+// terms of the current filter and sort order
+func (cs *CSVSource) LineIdx(idx int) int {
+	switch {
+	case cs.Rows != nil:
+		return cs.Rows[idx]
+	case cs.Order != nil:
+		return cs.Order[idx]
+	}
+	return idx
+}
+
+// ColForField returns the index of the column assigned to given struct
+// field name, or -1 if none
+func (cs *CSVSource) ColForField(field string) int {
+	fld, ok := cs.RowType.FieldByName(field)
+	if !ok {
+		return -1
+	}
+	for ci, fi := range cs.ColFlds {
+		if reflect.DeepEqual(fi, fld.Index) {
+			return ci
+		}
+	}
+	return -1
+}
+
+func (cs *CSVSource) SrcLen() int {
+	if cs.Rows != nil {
+		return len(cs.Rows)
+	}
+	return cs.NLines()
+}
+
+func (cs *CSVSource) SrcRows(st, ed int) interface{} {
+	cs.Mu.Lock()
+	defer cs.Mu.Unlock()
+	sz := cs.SrcLen()
+	ed = ints.MinInt(ed, sz)
+	st = ints.MaxInt(0, ints.MinInt(st, ed))
+	sl := reflect.MakeSlice(reflect.SliceOf(reflect.PtrTo(cs.RowType)), 0, ed-st)
+	if cs.file == nil {
+		return sl.Interface()
+	}
+	for i := st; i < ed; i++ {
+		sl = reflect.Append(sl, cs.RowFromLine(cs.Line(cs.LineIdx(i))))
+	}
+	return sl.Interface()
+}
+
+func (cs *CSVSource) SrcFilter(filter string) {
+	cs.Mu.Lock()
+	defer cs.Mu.Unlock()
+	cs.Filter = filter
+	if filter == "" || cs.file == nil {
+		cs.Rows = nil
+		return
+	}
+	flt := strings.ToLower(filter)
+	nl := cs.NLines()
+	cs.Rows = make([]int, 0, nl/4)
+	for i := 0; i < nl; i++ {
+		li := i
+		if cs.Order != nil {
+			li = cs.Order[i]
+		}
+		if strings.Contains(strings.ToLower(cs.Line(li)), flt) {
+			cs.Rows = append(cs.Rows, li)
+		}
+	}
+}
+
+// SrcSort stably sorts the lines by the values of the column for given
+// field, converted to the type of the field and compared with CompareValues
+// -- the current filter is kept, in the new order
+func (cs *CSVSource) SrcSort(field string, ascending bool) {
+	cs.Mu.Lock()
+	defer cs.Mu.Unlock()
+	ci := cs.ColForField(field)
+	if ci < 0 || cs.file == nil {
+		return
+	}
+	ftyp := cs.RowType.FieldByIndex(cs.ColFlds[ci]).Type
+	nl := cs.NLines()
+	vals := make([]interface{}, nl)
+	for li := 0; li < nl; li++ {
+		fv := reflect.New(ftyp)
+		if rec := cs.ParseLine(cs.Line(li)); ci < len(rec) {
+			kit.SetRobust(fv.Interface(), strings.TrimSpace(rec[ci]))
+		}
+		vals[li] = fv.Elem().Interface()
+	}
+	if cs.Order == nil {
+		cs.Order = make([]int, nl)
+		for li := range cs.Order {
+			cs.Order[li] = li
+		}
+	}
+	sort.SliceStable(cs.Order, func(i, j int) bool {
+		cmp := CompareValues(vals[cs.Order[i]], vals[cs.Order[j]])
+		if ascending {
+			return cmp < 0
+		}
+		return cmp > 0
+	})
+	if cs.Rows == nil {
+		return
+	}
+	pass := make(map[int]bool, len(cs.Rows))
+	for _, li := range cs.Rows {
+		pass[li] = true
+	}
+	cs.Rows = cs.Rows[:0]
+	for _, li := range cs.Order {
+		if pass[li] {
+			cs.Rows = append(cs.Rows, li)
+		}
+	}
+}
+
+// SrcNewAt inserts a new blank row at given index in an editable Src -- -1
+// means the end
+func (sv *SliceViewBase) SrcNewAt(idx int) {
+	wupdt := sv.TopUpdateStart()
+	defer sv.TopUpdateEnd(wupdt)
+
+	updt := sv.UpdateStart()
+	defer sv.UpdateEnd(updt)
+
+	sv.SrcInsertRows(idx, []interface{}{sv.SrcNewRow()})
+	if idx < 0 {
+		idx = sv.SliceSize
+	}
+	sv.SetChanged()
+	sv.ScrollBar().SetFullReRender()
+	sv.This().(SliceViewer).LayoutSliceGrid()
+	sv.This().(SliceViewer).UpdateSliceGrid()
+	sv.SliceViewSig.Emit(sv.This(), int64(SliceViewInserted), idx)
+}
+
+// SrcDeleteAt deletes the row at given index in an editable Src -- doupdt
+// means call UpdateSliceGrid to update display
+func (sv *SliceViewBase) SrcDeleteAt(idx int, doupdt bool) {
+	if idx < 0 {
+		return
+	}
+	wupdt := sv.TopUpdateStart()
+	defer sv.TopUpdateEnd(wupdt)
+
+	updt := sv.UpdateStart()
+	defer sv.UpdateEnd(updt)
+
+	sv.SrcDeleteRow(idx)
+	sv.SetChanged()
+	if doupdt {
+		sv.ScrollBar().SetFullReRender()
+		sv.This().(SliceViewer).LayoutSliceGrid()
+		sv.This().(SliceViewer).UpdateSliceGrid()
+	}
+	sv.SliceViewSig.Emit(sv.This(), int64(SliceViewDeleted), idx)
+}
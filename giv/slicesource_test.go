@@ -0,0 +1,152 @@
+// Copyright (c) 2018, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package giv
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/goki/ki/kit"
+)
+
+type csvTestRec struct {
+	ID    int
+	Name  string
+	Score float64
+}
+
+// csvTestN is the number of rows in the test CSV file
+const csvTestN = 10000
+
+// newCSVTestSource writes a CSV file with csvTestN rows to a temp dir and
+// returns a CSVSource for it -- row i has ID i, Name "name<i>" and Score i % 7
+func newCSVTestSource(t *testing.T) *CSVSource {
+	t.Helper()
+	fn := filepath.Join(t.TempDir(), "recs.csv")
+	f, err := os.Create(fn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bw := bufio.NewWriter(f)
+	fmt.Fprintln(bw, "id,name,score")
+	for i := 0; i < csvTestN; i++ {
+		fmt.Fprintf(bw, "%d,name%d,%d\n", i, i, i%7)
+	}
+	bw.Flush()
+	f.Close()
+	cs, err := NewCSVSource(fn, csvTestRec{}, ',')
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(cs.Close)
+	return cs
+}
+
+// srcPageIDs returns the IDs of the rows in the current page of given view
+func srcPageIDs(sv *SliceViewBase) []int {
+	ids := make([]int, sv.SliceNPVal.Len())
+	for i := range ids {
+		ids[i] = sv.SrcRowVal(sv.SrcStart + i).Interface().(*csvTestRec).ID
+	}
+	return ids
+}
+
+func TestCSVSourcePaging(t *testing.T) {
+	cs := newCSVTestSource(t)
+	if cs.SrcLen() != csvTestN {
+		t.Fatalf("SrcLen = %v, want %v", cs.SrcLen(), csvTestN)
+	}
+	sv := &SliceViewBase{}
+	sv.Src = cs
+	sv.Slice = sv.SrcPage(0, 0)
+	sv.SliceNPVal = kit.NonPtrValue(reflect.ValueOf(sv.Slice))
+	if sv.SliceNPVal.Len() != 0 {
+		t.Errorf("SrcPage(0, 0) len = %v, want 0", sv.SliceNPVal.Len())
+	}
+
+	tests := []struct {
+		start, disp int
+		wst, wlen   int
+	}{
+		{0, 20, 0, 20},
+		{20, 20, 20, 20},
+		{5000, 35, 5000, 35},
+		{9990, 20, 9990, 10}, // past the end
+		{0, 20, 0, 20},
+	}
+	for _, tt := range tests {
+		sv.StartIdx, sv.DispRows = tt.start, tt.disp
+		sv.SrcFetch()
+		if sv.SrcStart != tt.wst || sv.SliceNPVal.Len() != tt.wlen || sv.DispRows != tt.wlen {
+			t.Errorf("fetch %v+%v: SrcStart = %v, len = %v, DispRows = %v, want %v, %v", tt.start, tt.disp, sv.SrcStart, sv.SliceNPVal.Len(), sv.DispRows, tt.wst, tt.wlen)
+			continue
+		}
+		for i, id := range srcPageIDs(sv) {
+			if id != tt.start+i {
+				t.Errorf("fetch %v+%v: row %v ID = %v, want %v", tt.start, tt.disp, tt.start+i, id, tt.start+i)
+				break
+			}
+		}
+	}
+
+	// a fetch of the same page does not re-read, unless stale
+	sl := sv.Slice
+	sv.SrcFetch()
+	if sv.Slice != sl {
+		t.Errorf("SrcFetch of the current page fetched a new page")
+	}
+	sv.SrcStale = true
+	sv.SrcFetch()
+	if sv.Slice == sl {
+		t.Errorf("SrcFetch of a stale page did not fetch a new page")
+	}
+}
+
+func TestCSVSourceFilterSort(t *testing.T) {
+	cs := newCSVTestSource(t)
+	sv := &SliceViewBase{}
+	sv.Src = cs
+	sv.Slice = sv.SrcPage(0, 0)
+	sv.SliceNPVal = kit.NonPtrValue(reflect.ValueOf(sv.Slice))
+
+	tests := []struct {
+		filter    string
+		sort      string
+		ascending bool
+		wlen      int
+		start     int
+		wids      []int
+	}{
+		{"NAME999", "", true, 11, 0, []int{999, 9990, 9991, 9992}},
+		{"name999", "ID", false, 11, 0, []int{9999, 9998, 9997, 9996}},
+		{"name999", "ID", false, 11, 8, []int{9991, 9990, 999}},
+		{"", "ID", false, csvTestN, 0, []int{9999, 9998}},
+		{"", "Score", true, csvTestN, 0, []int{9996, 9989, 9982}}, // stable: keeps ID descending within score 0
+		{"", "Score", false, csvTestN, 0, []int{9995, 9988}},
+		{"", "ID", true, csvTestN, 5000, []int{5000, 5001, 5002}},
+		{"name12", "Score", true, 111, 0, []int{126, 1204, 1211}},
+		{"zzz", "", true, 0, 0, []int{}},
+	}
+	for _, tt := range tests {
+		cs.SrcFilter(tt.filter)
+		if tt.sort != "" {
+			cs.SrcSort(tt.sort, tt.ascending)
+		}
+		if cs.SrcLen() != tt.wlen {
+			t.Errorf("filter %q sort %v: SrcLen = %v, want %v", tt.filter, tt.sort, cs.SrcLen(), tt.wlen)
+			continue
+		}
+		sv.StartIdx, sv.DispRows = tt.start, len(tt.wids)
+		sv.SrcStale = true
+		sv.SrcFetch()
+		if ids := srcPageIDs(sv); !reflect.DeepEqual(ids, tt.wids) {
+			t.Errorf("filter %q sort %v: IDs from %v = %v, want %v", tt.filter, tt.sort, tt.start, ids, tt.wids)
+		}
+	}
+}
@@ -38,7 +38,9 @@ var _ SliceViewer = (*SliceView)(nil)
 // SliceViewStyleFunc is a styling function for custom styling /
 // configuration of elements in the view.  If style properties are set
 // then you must call widg.AsNode2dD().SetFullReRender() to trigger
-// re-styling during re-render
+// re-styling during re-render.  When viewing a Src (see SetSource), slice is
+// the page of rows currently fetched, starting at SrcStart, while row is the
+// overall row index.
 type SliceViewStyleFunc func(sv *SliceView, slice interface{}, widg gi.Node2D, row int, vv ValueView)
 
 var SliceViewProps = ki.Props{
@@ -37,6 +37,9 @@ type SliceViewer interface {
 	// AsSliceViewBase returns the base for direct access to relevant fields etc
 	AsSliceViewBase() *SliceViewBase
 
+	// SetSlice sets the source slice that we are viewing
+	SetSlice(sl interface{})
+
 	// Config configures the view
 	Config()
 
@@ -99,6 +102,21 @@ type SliceViewer interface {
 	// PasteAtIdx inserts object(s) from mime data at (before) given slice index
 	PasteAtIdx(md mimedata.Mimes, idx int)
 
+	// NCellCols returns the number of columns of cells per row, for copying
+	// and pasting cells as table text (TSV, CSV, html)
+	NCellCols() int
+
+	// CellCols returns the columns of cells that are copied for the selected
+	// rows, the first of which is where pasting cells starts
+	CellCols() []int
+
+	// CellText returns the text of the cell at given slice index and column
+	CellText(idx, col int) string
+
+	// SetCellText sets the cell at given slice index and column from given
+	// text, returning an error if the text could not be converted
+	SetCellText(idx, col int, txt string) error
+
 	// ItemCtxtMenu pulls up the context menu for given slice index
 	ItemCtxtMenu(idx int)
 }
@@ -136,6 +154,9 @@ type SliceViewBase struct {
 	ViewPath         string           `desc:"a record of parent View names that have led up to this view -- displayed as extra contextual information in view dialog windows"`
 	TmpSave          ValueView        `copy:"-" json:"-" xml:"-" desc:"value view that needs to have SaveTmp called on it whenever a change is made to one of the underlying values -- pass this down to any sub-views created from a parent"`
 	ToolbarSlice     interface{}      `copy:"-" view:"-" json:"-" xml:"-" desc:"the slice that we successfully set a toolbar for"`
+	Src              SliceSource      `copy:"-" view:"-" json:"-" xml:"-" desc:"optional source of data held in external storage, instead of a Slice -- set with SetSource -- Slice is then the page of rows currently fetched from the source"`
+	SrcStart         int              `copy:"-" view:"-" json:"-" xml:"-" desc:"index of the first row of the current page of rows fetched from Src"`
+	SrcStale         bool             `copy:"-" view:"-" json:"-" xml:"-" desc:"if true, the current page of rows must be re-fetched from Src"`
 
 	SliceSize     int     `view:"inactive" copy:"-" json:"-" xml:"-" desc:"size of slice"`
 	DispRows      int     `view:"inactive" copy:"-" json:"-" xml:"-" desc:"actual number of rows displayed = min(VisRows, SliceSize)"`
@@ -324,6 +345,10 @@ func (sv *SliceViewBase) RowWidgetNs() (nWidgPerRow, idxOff int) {
 
 // UpdtSliceSize updates and returns the size of the slice and sets SliceSize
 func (sv *SliceViewBase) UpdtSliceSize() int {
+	if sv.Src != nil {
+		sv.SliceSize = sv.Src.SrcLen()
+		return sv.SliceSize
+	}
 	sz := sv.SliceNPVal.Len()
 	sv.SliceSize = sz
 	return sz
@@ -579,12 +604,13 @@ func (sv *SliceViewBase) UpdateSliceGrid() {
 	} else {
 		sv.StartIdx = 0
 	}
+	sv.SrcFetch()
 
 	for i := 0; i < sv.DispRows; i++ {
 		ridx := i * nWidgPerRow
 		si := sv.StartIdx + i // slice idx
 		issel := sv.IdxIsSelected(si)
-		val := kit.OnePtrUnderlyingValue(sv.SliceNPVal.Index(si)) // deal with pointer lists
+		val := kit.OnePtrUnderlyingValue(sv.SrcRowVal(si)) // deal with pointer lists
 		var vv ValueView
 		if sv.Values[i] == nil {
 			vv = ToValueView(val.Interface(), "")
@@ -656,8 +682,12 @@ func (sv *SliceViewBase) UpdateSliceGrid() {
 				}
 			} else {
 				vvb := vv.AsValueViewBase()
+				row := i
 				vvb.ViewSig.ConnectOnly(sv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
 					svv, _ := recv.Embed(KiT_SliceViewBase).(*SliceViewBase)
+					if svv.Src != nil {
+						svv.SrcSaveRow(svv.StartIdx + row)
+					}
 					svv.SetChanged()
 				})
 				if !sv.isArray {
@@ -700,7 +730,7 @@ func (sv *SliceViewBase) UpdateSliceGrid() {
 		}
 		sv.This().(SliceViewer).StyleRow(sv.SliceNPVal, widg, si, 0, vv)
 	}
-	if sv.SelVal != nil {
+	if sv.SelVal != nil && sv.Src == nil {
 		sv.SelectedIdx, _ = SliceIdxByValue(sv.Slice, sv.SelVal)
 	}
 	if sv.IsInactive() && sv.SelectedIdx >= 0 {
@@ -730,6 +760,10 @@ func (sv *SliceViewBase) SliceNewAt(idx int) {
 	if sv.isArray {
 		return
 	}
+	if sv.Src != nil {
+		sv.SrcNewAt(idx)
+		return
+	}
 
 	sv.ViewMuLock() // no return!  must unlock before return below
 
@@ -757,10 +791,14 @@ func (sv *SliceViewBase) SliceNewAt(idx int) {
 							dlg, _ := send.(*gi.Dialog)
 							n, typ := gi.NewKiDialogValues(dlg)
 							updt := ownki.UpdateStart()
+							us := gi.UndoStackFor(sv.This())
+							us.BeginGroup("Insert Item")
 							for i := 0; i < n; i++ {
 								nm := fmt.Sprintf("New%v%v", typ.Name(), idx+1+i)
-								ownki.InsertNewChild(typ, idx+1+i, nm)
+								nki := ownki.InsertNewChild(typ, idx+1+i, nm)
+								SaveTreeInsert(us, "Insert Item", nki)
 							}
+							us.EndGroup()
 							sv.SetChanged()
 							ownki.UpdateEnd(updt)
 						}
@@ -768,6 +806,7 @@ func (sv *SliceViewBase) SliceNewAt(idx int) {
 			}
 		}
 	} else {
+		undo := NewValueUndo("Insert Item", svl.Elem(), sv.This())
 		nval := reflect.New(kit.NonPtrType(sltyp)) // make the concrete el
 		if !slptr {
 			nval = nval.Elem() // use concrete value
@@ -778,6 +817,7 @@ func (sv *SliceViewBase) SliceNewAt(idx int) {
 			svnp.Index(idx).Set(nval)
 		}
 		svl.Elem().Set(svnp)
+		undo.Save()
 	}
 	if idx < 0 {
 		idx = sz
@@ -811,13 +851,19 @@ func (sv *SliceViewBase) SliceDeleteAt(idx int, doupdt bool) {
 	if sv.isArray {
 		return
 	}
+	if sv.Src != nil {
+		sv.SrcDeleteAt(idx, doupdt)
+		return
+	}
 
 	sv.ViewMuLock()
 
 	updt := sv.UpdateStart()
 	defer sv.UpdateEnd(updt)
 
+	undo := NewValueUndo("Delete Item", kit.NonPtrValue(reflect.ValueOf(sv.Slice)), sv.This())
 	kit.SliceDeleteAt(sv.Slice, idx)
+	undo.Save()
 
 	if sv.TmpSave != nil {
 		sv.TmpSave.SaveTmp()
@@ -939,6 +985,12 @@ func (sv *SliceViewBase) ConnectEvents2D() {
 	sv.SliceViewBaseEvents()
 }
 
+func (sv *SliceViewBase) AccessInfo2D(ai *gi.AccessInfo) {
+	sv.Frame.AccessInfo2D(ai)
+	ai.Role = gi.AccessList
+	ai.SetState(!sv.IsInactive(), gi.AccessEditable)
+}
+
 func (sv *SliceViewBase) HasFocus2D() bool {
 	if !sv.ContainsFocus() {
 		return false
@@ -960,7 +1012,14 @@ func (sv *SliceViewBase) SliceVal(idx int) interface{} {
 		fmt.Printf("giv.SliceViewBase: slice index out of range: %v\n", idx)
 		return nil
 	}
-	val := kit.OnePtrUnderlyingValue(sv.SliceNPVal.Index(idx)) // deal with pointer lists
+	if sv.Src != nil && (idx < sv.SrcStart || idx-sv.SrcStart >= sv.SliceNPVal.Len()) {
+		rows := reflect.ValueOf(sv.Src.SrcRows(idx, idx+1))
+		if rows.Len() == 0 {
+			return nil
+		}
+		return kit.OnePtrUnderlyingValue(rows.Index(0)).Interface()
+	}
+	val := kit.OnePtrUnderlyingValue(sv.SrcRowVal(idx)) // deal with pointer lists
 	vali := val.Interface()
 	return vali
 }
@@ -1510,6 +1569,10 @@ func (sv *SliceViewBase) Copy(reset bool) {
 	}
 	md := sv.This().(SliceViewer).CopySelToMime()
 	if md != nil {
+		if len(md) == 1 { // text version keeps the json type on the clipboard
+			tbl := sv.CellsText(sv.SelectedIdxsList(false), sv.This().(SliceViewer).CellCols())
+			md = append(md, mimedata.NewTextData(TableToCSV(tbl, '\t')))
+		}
 		oswin.TheApp.ClipBoard(sv.ParentWindow().OSWin).Write(md)
 	}
 	if reset {
@@ -1576,14 +1639,23 @@ func (sv *SliceViewBase) CutIdxs() {
 	}
 }
 
-// Paste pastes clipboard at CurIdx
+// Paste pastes clipboard at CurIdx -- table text copied from a spreadsheet
+// or other app (TSV, CSV or an html table) is pasted into the cells starting
+// at CurIdx (see PasteCells), and otherwise a menu of paste options is shown
+// for the slice elements copied by Copy (see IsTableText).
 // satisfies gi.Clipper interface and can be overridden by subtypes
 func (sv *SliceViewBase) Paste() {
 	dt := sv.This().(SliceViewer).MimeDataType()
-	md := oswin.TheApp.ClipBoard(sv.ParentWindow().OSWin).Read([]string{dt})
-	if md != nil {
-		sv.PasteMenu(md, sv.CurIdx)
+	// untyped text from other apps comes back as the first type
+	md := oswin.TheApp.ClipBoard(sv.ParentWindow().OSWin).Read([]string{filecat.TextPlain, dt})
+	if md == nil {
+		return
 	}
+	if IsTableText(md) {
+		sv.PasteCellsAction(TableFromText(string(md[0].Data)), sv.CurIdx)
+		return
+	}
+	sv.PasteMenu(md, sv.CurIdx)
 }
 
 // PasteIdx pastes clipboard at given idx
@@ -1635,7 +1707,16 @@ func (sv *SliceViewBase) PasteAssign(md mimedata.Mimes, idx int) {
 	}
 	updt := sv.UpdateStart()
 	ns := sl[0]
-	sv.SliceNPVal.Index(idx).Set(reflect.ValueOf(ns).Elem())
+	if sv.Src != nil {
+		if ed, ok := sv.Src.(SliceSourceEditor); ok {
+			if err := ed.SrcSetRow(idx, kit.OnePtrUnderlyingValue(reflect.ValueOf(ns)).Interface()); err != nil {
+				log.Printf("giv.SliceViewBase PasteAssign error: %v\n", err)
+			}
+			sv.SrcStale = true
+		}
+	} else {
+		sv.SliceNPVal.Index(idx).Set(reflect.ValueOf(ns).Elem())
+	}
 	if sv.TmpSave != nil {
 		sv.TmpSave.SaveTmp()
 	}
@@ -1650,6 +1731,18 @@ func (sv *SliceViewBase) PasteAtIdx(md mimedata.Mimes, idx int) {
 	if len(sl) == 0 {
 		return
 	}
+	if sv.Src != nil {
+		rows := make([]interface{}, len(sl))
+		for i, ns := range sl {
+			rows[i] = kit.OnePtrUnderlyingValue(reflect.ValueOf(ns)).Interface()
+		}
+		sv.SrcInsertRows(idx, rows)
+		sv.SetChanged()
+		sv.This().(SliceViewer).LayoutSliceGrid()
+		sv.This().(SliceViewer).UpdateSliceGrid()
+		sv.SelectIdxAction(idx+len(sl), mouse.SelectOne)
+		return
+	}
 	svl := reflect.ValueOf(sv.Slice)
 	svnp := sv.SliceNPVal
 	wupdt := sv.TopUpdateStart()
@@ -1877,6 +1970,8 @@ func (sv *SliceViewBase) StdCtxtMenu(m *gi.Menu, idx int) {
 			svv := recv.Embed(KiT_SliceViewBase).(*SliceViewBase)
 			svv.Duplicate()
 		})
+	m.AddSeparator("sep-cells")
+	sv.CellsCtxtMenu(m, idx)
 }
 
 func (sv *SliceViewBase) ItemCtxtMenu(idx int) {
@@ -0,0 +1,436 @@
+// Copyright (c) 2018, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package giv
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/csv"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/goki/gi/gi"
+	"github.com/goki/gi/oswin"
+	"github.com/goki/gi/oswin/mimedata"
+	"github.com/goki/ki/ki"
+	"github.com/goki/ki/kit"
+	"github.com/goki/pi/filecat"
+	"golang.org/x/net/html"
+)
+
+// TextHtml is the mime type for html text, used for copying cells as an
+// html table
+const TextHtml = "text/html"
+
+// SliceViewMaxCellErrs is the maximum number of per-cell conversion errors
+// listed in the error dialog after pasting or importing cells
+var SliceViewMaxCellErrs = 20
+
+//////////////////////////////////////////////////////////////////////////////
+//    Cell values
+
+// CellValueText returns the text representation of given value for a cell
+// in a copied table -- uses MarshalText if available (e.g., time.Time)
+func CellValueText(v reflect.Value) string {
+	v = kit.NonPtrValue(v)
+	if !v.IsValid() {
+		return ""
+	}
+	if tm, ok := v.Interface().(encoding.TextMarshaler); ok {
+		if b, err := tm.MarshalText(); err == nil {
+			return string(b)
+		}
+	}
+	return kit.ToString(v.Interface())
+}
+
+// SetCellValueText sets given value, which must be addressable, from the
+// text of a cell in a pasted or imported table, using UnmarshalText if
+// available, then enum names, then kit.SetRobust, then a number in the
+// gi.ActiveLocaleFormat (e.g., 1.234,5 from a German spreadsheet) -- an
+// empty string sets the zero value.  Returns an error if the text could not
+// be converted.
+func SetCellValueText(v reflect.Value, txt string) error {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		v = v.Elem()
+	}
+	if !v.CanAddr() {
+		return fmt.Errorf("value of type %v is not settable", v.Type())
+	}
+	if v.Kind() == reflect.String {
+		v.SetString(txt)
+		return nil
+	}
+	txt = strings.TrimSpace(txt)
+	if txt == "" {
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}
+	if tu, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return tu.UnmarshalText([]byte(txt))
+	}
+	if kit.Enums.TypeRegistered(v.Type()) {
+		return kit.Enums.SetAnyEnumValueFromString(v.Addr(), txt)
+	}
+	if setCellLocaleNumber(v, txt) {
+		return nil
+	}
+	if !kit.SetRobust(v.Addr().Interface(), txt) {
+		return fmt.Errorf("cannot convert %q to %v", txt, v.Type())
+	}
+	return nil
+}
+
+// setCellLocaleNumber sets a numeric value from text in the format of the
+// gi.ActiveLocaleFormat, if it differs from the Go syntax (e.g., 1.234,5 for
+// 1234.5) -- returns false if the value is not numeric, or the text did not
+// parse in the locale format, including text that is not an integer for
+// integer values
+func setCellLocaleNumber(v reflect.Value, txt string) bool {
+	lf := &gi.ActiveLocaleFormat
+	if lf.DecimalSep == "." && lf.GroupSep == "" {
+		return false
+	}
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		iv, err := strconv.ParseInt(lf.DelocalizeNumber(txt), 10, 64)
+		return err == nil && kit.SetRobust(v.Addr().Interface(), iv)
+	case reflect.Float32, reflect.Float64:
+		fv, err := lf.ParseFloat(txt)
+		return err == nil && kit.SetRobust(v.Addr().Interface(), fv)
+	}
+	return false
+}
+
+// NCellCols returns the number of columns of cells per row -- 1 for a
+// SliceView, where each element is one cell
+func (sv *SliceViewBase) NCellCols() int {
+	return 1
+}
+
+// CellCols returns the columns of cells that are copied for the selected
+// rows, the first of which is where pasting cells starts -- all of them
+func (sv *SliceViewBase) CellCols() []int {
+	nc := sv.This().(SliceViewer).NCellCols()
+	cols := make([]int, nc)
+	for i := range cols {
+		cols[i] = i
+	}
+	return cols
+}
+
+// CellText returns the text of the cell at given slice index and column
+func (sv *SliceViewBase) CellText(idx, col int) string {
+	val := sv.SliceVal(idx)
+	if val == nil {
+		return ""
+	}
+	return CellValueText(reflect.ValueOf(val))
+}
+
+// SetCellText sets the cell at given slice index and column from given
+// text, returning an error if the text could not be converted
+func (sv *SliceViewBase) SetCellText(idx, col int, txt string) error {
+	val := sv.SliceVal(idx)
+	if val == nil {
+		return fmt.Errorf("row %d is out of range", idx)
+	}
+	if err := SetCellValueText(reflect.ValueOf(val), txt); err != nil {
+		return err
+	}
+	return sv.CellSaveRow(idx, val)
+}
+
+// CellSaveRow saves given row value (as returned by SliceVal) back to the
+// Src after one of its cells has been set -- does nothing if not viewing a Src
+func (sv *SliceViewBase) CellSaveRow(idx int, val interface{}) error {
+	if sv.Src == nil {
+		return nil
+	}
+	ed, ok := sv.Src.(SliceSourceEditor)
+	if !ok {
+		return fmt.Errorf("source is not editable")
+	}
+	sv.SrcStale = true
+	return ed.SrcSetRow(idx, val)
+}
+
+// CellsText returns the text of the cells at given slice indexes and columns,
+// as rows of columns
+func (sv *SliceViewBase) CellsText(idxs, cols []int) [][]string {
+	svr := sv.This().(SliceViewer)
+	sv.ViewMuLock()
+	defer sv.ViewMuUnlock()
+	tbl := make([][]string, len(idxs))
+	for ri, idx := range idxs {
+		rw := make([]string, len(cols))
+		for ci, col := range cols {
+			rw[ci] = svr.CellText(idx, col)
+		}
+		tbl[ri] = rw
+	}
+	return tbl
+}
+
+//////////////////////////////////////////////////////////////////////////////
+//    Table text formats
+
+// TableToCSV returns the given table of cells as CSV text, using given
+// field delimiter -- '\t' gives the TSV format used by spreadsheet apps
+func TableToCSV(tbl [][]string, comma rune) string {
+	var b bytes.Buffer
+	cw := csv.NewWriter(&b)
+	cw.Comma = comma
+	cw.WriteAll(tbl)
+	return b.String()
+}
+
+// TableToHTML returns the given table of cells as an html table, with an
+// optional header row
+func TableToHTML(tbl [][]string, header []string) string {
+	var b strings.Builder
+	b.WriteString("<table>\n")
+	if len(header) > 0 {
+		b.WriteString("<tr>")
+		for _, h := range header {
+			b.WriteString("<th>" + html.EscapeString(h) + "</th>")
+		}
+		b.WriteString("</tr>\n")
+	}
+	for _, rw := range tbl {
+		b.WriteString("<tr>")
+		for _, c := range rw {
+			b.WriteString("<td>" + html.EscapeString(c) + "</td>")
+		}
+		b.WriteString("</tr>\n")
+	}
+	b.WriteString("</table>\n")
+	return b.String()
+}
+
+// TableFromText parses a table of cells from text copied from a spreadsheet
+// or other app: an html table, TSV if there are any tabs, and otherwise CSV
+func TableFromText(txt string) [][]string {
+	if strings.Contains(strings.ToLower(txt), "<table") {
+		return TableFromHTML(txt)
+	}
+	comma := ','
+	if strings.ContainsRune(txt, '\t') {
+		comma = '\t'
+	}
+	cr := csv.NewReader(strings.NewReader(txt))
+	cr.Comma = comma
+	cr.FieldsPerRecord = -1
+	cr.LazyQuotes = true
+	tbl, err := cr.ReadAll()
+	if err != nil { // fall back on simple splitting
+		tbl = nil
+		for _, ln := range strings.Split(strings.TrimRight(txt, "\r\n"), "\n") {
+			tbl = append(tbl, strings.Split(strings.TrimRight(ln, "\r"), string(comma)))
+		}
+	}
+	return tbl
+}
+
+// TableFromHTML parses the cells of the first table in given html text
+func TableFromHTML(txt string) [][]string {
+	var tbl [][]string
+	var rw []string
+	var cell strings.Builder
+	inCell := false
+	z := html.NewTokenizer(strings.NewReader(txt))
+	for {
+		tt := z.Next()
+		switch tt {
+		case html.ErrorToken:
+			if rw != nil {
+				tbl = append(tbl, rw)
+			}
+			return tbl
+		case html.StartTagToken:
+			nm, _ := z.TagName()
+			switch string(nm) {
+			case "tr":
+				if rw != nil {
+					tbl = append(tbl, rw)
+				}
+				rw = []string{}
+			case "td", "th":
+				inCell = true
+				cell.Reset()
+			case "br":
+				if inCell {
+					cell.WriteString("\n")
+				}
+			}
+		case html.EndTagToken:
+			nm, _ := z.TagName()
+			switch string(nm) {
+			case "td", "th":
+				if inCell {
+					rw = append(rw, strings.TrimSpace(cell.String()))
+					inCell = false
+				}
+			case "tr":
+				if rw != nil {
+					tbl = append(tbl, rw)
+					rw = nil
+				}
+			case "table":
+				if rw != nil {
+					tbl = append(tbl, rw)
+				}
+				return tbl
+			}
+		case html.TextToken:
+			if inCell {
+				cell.Write(z.Text())
+			}
+		}
+	}
+}
+
+// IsTableText returns true if given clipboard data is table text (TSV, CSV
+// or an html table, as filecat.TextPlain, filecat.DataCsv or TextHtml) rather
+// than the filecat.DataJson encoding of slice elements from CopySelToMime --
+// the decision is based only on the mime types, not the content
+func IsTableText(md mimedata.Mimes) bool {
+	if len(md) == 0 || md.HasType(filecat.DataJson) {
+		return false
+	}
+	switch md[0].Type {
+	case filecat.TextPlain, filecat.DataCsv, TextHtml:
+		return len(strings.TrimSpace(string(md[0].Data))) > 0
+	}
+	return false
+}
+
+//////////////////////////////////////////////////////////////////////////////
+//    Copy / Paste cells
+
+// CopyCells copies the cells of the selected rows (or the given idx if none
+// are selected) to the clipboard as a table in given mime type: TSV as
+// filecat.TextPlain (for pasting into spreadsheet apps), filecat.DataCsv,
+// or TextHtml
+func (sv *SliceViewBase) CopyCells(mimeType string, idx int) {
+	idxs := sv.SelectedIdxsList(false) // ascending
+	if len(idxs) == 0 {
+		if idx < 0 || idx >= sv.SliceSize {
+			return
+		}
+		idxs = []int{idx}
+	}
+	tbl := sv.CellsText(idxs, sv.This().(SliceViewer).CellCols())
+	var txt string
+	switch mimeType {
+	case filecat.DataCsv:
+		txt = TableToCSV(tbl, ',')
+	case TextHtml:
+		txt = TableToHTML(tbl, nil)
+	default:
+		mimeType = filecat.TextPlain
+		txt = TableToCSV(tbl, '\t')
+	}
+	oswin.TheApp.ClipBoard(sv.ParentWindow().OSWin).Write(mimedata.NewMime(mimeType, []byte(txt)))
+}
+
+// PasteCells sets the cells starting at given slice index and the first of
+// CellCols from given table of cells, adding new rows at the end as needed --
+// returns a list of per-cell conversion errors
+func (sv *SliceViewBase) PasteCells(tbl [][]string, idx int) []string {
+	svr := sv.This().(SliceViewer)
+	if idx < 0 {
+		idx = 0
+	}
+	col0 := 0
+	if cols := svr.CellCols(); len(cols) > 0 {
+		col0 = cols[0]
+	}
+	nc := svr.NCellCols()
+	var errs []string
+	for ri, rw := range tbl {
+		ri += idx
+		if ri >= svr.UpdtSliceSize() {
+			if sv.isArray || sv.NoAdd {
+				errs = append(errs, fmt.Sprintf("row %d: no room to add rows", ri))
+				break
+			}
+			svr.SliceNewAt(-1)
+			if ri >= svr.UpdtSliceSize() {
+				break
+			}
+		}
+		for ci, txt := range rw {
+			ci += col0
+			if ci >= nc {
+				break
+			}
+			if err := svr.SetCellText(ri, ci, txt); err != nil {
+				errs = append(errs, fmt.Sprintf("row %d, col %d: %v", ri, ci, err))
+			}
+		}
+	}
+	return errs
+}
+
+// PasteCellsAction pastes given table of cells at given slice index (see
+// PasteCells), updating the display and reporting any conversion errors
+func (sv *SliceViewBase) PasteCellsAction(tbl [][]string, idx int) {
+	if len(tbl) == 0 {
+		return
+	}
+	wupdt := sv.TopUpdateStart()
+	updt := sv.UpdateStart()
+	errs := sv.PasteCells(tbl, idx)
+	if sv.TmpSave != nil {
+		sv.TmpSave.SaveTmp()
+	}
+	sv.SetChanged()
+	if sv.Src != nil {
+		sv.SrcStale = true
+	}
+	sv.This().(SliceViewer).UpdateSliceGrid()
+	sv.UpdateEnd(updt)
+	sv.TopUpdateEnd(wupdt)
+	sv.CellErrorsDialog("Paste Errors", errs)
+}
+
+// CellErrorsDialog shows a dialog listing given per-cell conversion errors,
+// if there are any, up to SliceViewMaxCellErrs of them
+func (sv *SliceViewBase) CellErrorsDialog(title string, errs []string) {
+	if len(errs) == 0 {
+		return
+	}
+	ne := len(errs)
+	if ne > SliceViewMaxCellErrs {
+		errs = append(errs[:SliceViewMaxCellErrs:SliceViewMaxCellErrs], fmt.Sprintf("... and %d more", ne-SliceViewMaxCellErrs))
+	}
+	gi.PromptDialog(sv.ViewportSafe(), gi.DlgOpts{Title: title, Prompt: fmt.Sprintf("%d values could not be converted and were skipped:\n", ne) + strings.Join(errs, "\n")}, gi.AddOk, gi.NoCancel, nil, nil)
+}
+
+// CellsCtxtMenu adds the actions for copying cells in table formats to given menu
+func (sv *SliceViewBase) CellsCtxtMenu(m *gi.Menu, idx int) {
+	m.AddAction(gi.ActOpts{Label: "Copy Cells", Tooltip: "copy the cells of the selected rows as tab-separated values, for pasting into spreadsheet apps", Data: idx},
+		sv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+			svv := recv.Embed(KiT_SliceViewBase).(*SliceViewBase)
+			svv.CopyCells(filecat.TextPlain, data.(int))
+		})
+	m.AddAction(gi.ActOpts{Label: "Copy As CSV", Tooltip: "copy the cells of the selected rows as comma-separated values", Data: idx},
+		sv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+			svv := recv.Embed(KiT_SliceViewBase).(*SliceViewBase)
+			svv.CopyCells(filecat.DataCsv, data.(int))
+		})
+	m.AddAction(gi.ActOpts{Label: "Copy As HTML", Tooltip: "copy the cells of the selected rows as an html table", Data: idx},
+		sv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+			svv := recv.Embed(KiT_SliceViewBase).(*SliceViewBase)
+			svv.CopyCells(TextHtml, data.(int))
+		})
+}
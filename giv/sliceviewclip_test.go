@@ -0,0 +1,95 @@
+// Copyright (c) 2018, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package giv
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/goki/gi/gi"
+)
+
+func TestSetCellValueTextLocale(t *testing.T) {
+	svlf := gi.ActiveLocaleFormat
+	defer func() { gi.ActiveLocaleFormat = svlf }()
+
+	tests := []struct {
+		loc, txt string
+		f        float64
+		i        int
+		iok      bool
+	}{
+		{"C", "1234", 1234, 1234, true},
+		{"de", "1.234", 1234, 1234, true},
+		{"de", "1.234,5", 1234.5, 0, false},
+		{"de", "-0,25", -0.25, 0, false},
+		{"en_US", "1,234", 1234, 1234, true},
+		{"en_US", "1,234.5", 1234.5, 0, false},
+	}
+	for _, ts := range tests {
+		gi.ActiveLocaleFormat = gi.StdLocaleFormat(ts.loc)
+		var f float64
+		if err := SetCellValueText(reflect.ValueOf(&f).Elem(), ts.txt); err != nil || f != ts.f {
+			t.Errorf("%v float %q = %v (%v), want %v", ts.loc, ts.txt, f, err, ts.f)
+		}
+		var i int
+		err := SetCellValueText(reflect.ValueOf(&i).Elem(), ts.txt)
+		switch {
+		case ts.iok && (err != nil || i != ts.i):
+			t.Errorf("%v int %q = %v (%v), want %v", ts.loc, ts.txt, i, err, ts.i)
+		case !ts.iok && err == nil:
+			t.Errorf("%v int %q = %v, want error", ts.loc, ts.txt, i)
+		}
+	}
+}
+
+func TestTableFromText(t *testing.T) {
+	tests := []struct {
+		name, txt string
+		tbl       [][]string
+	}{
+		{"csv", "a,b\n1,2\n", [][]string{{"a", "b"}, {"1", "2"}}},
+		{"csv crlf", "a,b\r\n1,2\r\n", [][]string{{"a", "b"}, {"1", "2"}}},
+		{"csv quoted", "\"x, y\",\"say \"\"hi\"\"\"\n", [][]string{{"x, y", `say "hi"`}}},
+		{"csv ragged", "a\n1,2,3\n", [][]string{{"a"}, {"1", "2", "3"}}},
+		{"tsv", "a\tb,c\n1\t2\n", [][]string{{"a", "b,c"}, {"1", "2"}}},
+		{"tsv empty cells", "\t\tx\n", [][]string{{"", "", "x"}}},
+		{"single", "42", [][]string{{"42"}}},
+		{"unicode", "日本,ü\n", [][]string{{"日本", "ü"}}},
+		{"html", "<table><tr><td>a</td><td>b</td></tr><tr><td>1</td><td>2</td></tr></table>", [][]string{{"a", "b"}, {"1", "2"}}},
+		{"html upper", "<TABLE><TR><TD>a</TD></TR></TABLE>", [][]string{{"a"}}},
+	}
+	for _, tt := range tests {
+		if tbl := TableFromText(tt.txt); !reflect.DeepEqual(tbl, tt.tbl) {
+			t.Errorf("%v: TableFromText(%q) = %q, want %q", tt.name, tt.txt, tbl, tt.tbl)
+		}
+	}
+}
+
+func TestTableFromHTML(t *testing.T) {
+	tests := []struct {
+		name, txt string
+		tbl       [][]string
+	}{
+		{"header", "<table><tr><th>Name</th><th>Age</th></tr><tr><td>Ann</td><td>42</td></tr></table>",
+			[][]string{{"Name", "Age"}, {"Ann", "42"}}},
+		{"spreadsheet", "<html><body><table>\n<tbody>\n<tr>\n  <td class=\"x\"> a </td>\n  <td><b>b</b></td>\n</tr>\n</tbody></table></body></html>",
+			[][]string{{"a", "b"}}},
+		{"entities", "<table><tr><td>a &amp; b</td><td>&lt;x&gt;</td><td>caf&eacute;</td></tr></table>",
+			[][]string{{"a & b", "<x>", "café"}}},
+		{"br", "<table><tr><td>one<br>two</td></tr></table>", [][]string{{"one\ntwo"}}},
+		{"empty cell", "<table><tr><td></td><td>x</td></tr></table>", [][]string{{"", "x"}}},
+		{"unclosed", "<table><tr><td>a</td><tr><td>b</td>", [][]string{{"a"}, {"b"}}},
+		{"first table only", "<table><tr><td>a</td></tr></table><table><tr><td>b</td></tr></table>", [][]string{{"a"}}},
+		{"no table", "<p>hi</p>", nil},
+		{"round trip", TableToHTML([][]string{{"<a>", "b & c"}}, []string{"H1", "H2"}),
+			[][]string{{"H1", "H2"}, {"<a>", "b & c"}}},
+	}
+	for _, tt := range tests {
+		if tbl := TableFromHTML(tt.txt); !reflect.DeepEqual(tbl, tt.tbl) {
+			t.Errorf("%v: TableFromHTML(%q) = %q, want %q", tt.name, tt.txt, tbl, tt.tbl)
+		}
+	}
+}
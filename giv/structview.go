@@ -287,6 +287,7 @@ func (sv *StructView) ConfigStructGrid() {
 		lbl.Redrawable = true
 		widg := sg.Child((i * 2) + 1).(gi.Node2D)
 		widg.SetProp("horizontal-align", gi.AlignLeft)
+		lbl.AccessFor = widg
 		hasDef, inactTag := StructViewFieldTags(vv, lbl, widg, sv.IsInactive())
 		if hasDef {
 			sv.HasDefs = true
@@ -354,6 +355,11 @@ func (sv *StructView) Render2D() {
 	sv.Frame.Render2D()
 }
 
+func (sv *StructView) AccessInfo2D(ai *gi.AccessInfo) {
+	sv.Frame.AccessInfo2D(ai)
+	ai.Role = gi.AccessForm
+}
+
 /////////////////////////////////////////////////////////////////////////
 //  Tag parsing
 
@@ -363,9 +369,9 @@ func (sv *StructView) Render2D() {
 func StructViewFieldTags(vv ValueView, lbl *gi.Label, widg gi.Node2D, isInact bool) (hasDef, inactTag bool) {
 	vvb := vv.AsValueViewBase()
 	if lbltag, has := vv.Tag("label"); has {
-		lbl.Text = lbltag
+		lbl.Text = gi.TrText(lbl, lbltag)
 	} else {
-		lbl.Text = vvb.Field.Name
+		lbl.Text = gi.TrText(lbl, vvb.Field.Name)
 	}
 	if _, has := vv.Tag("inactive"); has {
 		inactTag = true
@@ -13,8 +13,6 @@ import (
 
 	"github.com/chewxy/math32"
 	"github.com/goki/gi/gi"
-	"github.com/goki/gi/oswin"
-	"github.com/goki/gi/oswin/cursor"
 	"github.com/goki/gi/units"
 	"github.com/goki/ki/ints"
 	"github.com/goki/ki/ki"
@@ -37,13 +35,30 @@ import (
 // taken using the TableViewSig signals
 type TableView struct {
 	SliceViewBase
-	StyleFunc  TableViewStyleFunc    `copy:"-" view:"-" json:"-" xml:"-" desc:"optional styling function"`
-	SelField   string                `copy:"-" view:"-" json:"-" xml:"-" desc:"current selection field -- initially select value in this field"`
-	SortIdx    int                   `desc:"current sort index"`
-	SortDesc   bool                  `desc:"whether current sort order is descending"`
-	StruType   reflect.Type          `copy:"-" view:"-" json:"-" xml:"-" desc:"struct type for each row"`
-	VisFields  []reflect.StructField `copy:"-" view:"-" json:"-" xml:"-" desc:"the visible fields"`
-	NVisFields int                   `copy:"-" view:"-" json:"-" xml:"-" desc:"number of visible fields"`
+	StyleFunc       TableViewStyleFunc    `copy:"-" view:"-" json:"-" xml:"-" desc:"optional styling function"`
+	SelField        string                `copy:"-" view:"-" json:"-" xml:"-" desc:"current selection field -- initially select value in this field"`
+	SortIdx         int                   `desc:"current sort index -- the primary sort key, if sorting on multiple columns"`
+	SortDesc        bool                  `desc:"whether current sort order is descending"`
+	ShowFilters     bool                  `desc:"show a row of filter fields below the header, for filtering the rows by the values in each column -- see TableColFilter for syntax"`
+	CellMode        bool                  `desc:"cell-cursor mode, for using the table as a lightweight spreadsheet: arrow keys move a cursor between cells (Shift extends the selected range, and Ctrl / Meta+click adds a range), Enter or F2 edits the current cell, Tab commits the edit and moves to the next cell, and the selected cells can be copied, pasted, filled down and cleared -- use SetCellMode to change"`
+	CellCol         int                   `copy:"-" view:"-" json:"-" xml:"-" desc:"visible field index of the cell cursor in CellMode -- the cursor row is SelectedIdx"`
+	CellSel         []TableCellRange      `copy:"-" view:"-" json:"-" xml:"-" desc:"selected ranges of cells in CellMode"`
+	NoColPrefs      bool                  `desc:"if true, the column order, visibility, widths, sorting and filters are not saved to or restored from gi.Prefs.TableViews"`
+	SortFilterPrefs bool                  `desc:"if true, the sort keys and column filters saved in gi.Prefs.TableViews are also restored, along with the column layout -- each slice viewed is then sorted in place, and filtered with the filter row shown"`
+	Cols            *gi.TableViewPrefs    `copy:"-" view:"-" json:"-" xml:"-" desc:"current column order, visibility, widths, sort keys and filters -- saved to gi.Prefs.TableViews for the struct type unless NoColPrefs, and the layout is restored from it (and the sorting and filters if SortFilterPrefs)"`
+	FiltSrc         *TableFilterSource    `copy:"-" view:"-" json:"-" xml:"-" desc:"source used to view the rows of the slice that pass the column filters -- nil if not filtering"`
+	StruType        reflect.Type          `copy:"-" view:"-" json:"-" xml:"-" desc:"struct type for each row"`
+	AllFields       []reflect.StructField `copy:"-" view:"-" json:"-" xml:"-" desc:"all the fields that can be shown, in struct order"`
+	VisFields       []reflect.StructField `copy:"-" view:"-" json:"-" xml:"-" desc:"the visible fields, in display order"`
+	VisIdxs         []int                 `copy:"-" view:"-" json:"-" xml:"-" desc:"index of each visible field in AllFields -- the col passed to StyleFunc"`
+	NVisFields      int                   `copy:"-" view:"-" json:"-" xml:"-" desc:"number of visible fields"`
+	colsType        reflect.Type          `desc:"struct type that Cols is for"`
+	inFiltSet       bool                  `desc:"true while switching to the FiltSrc"`
+	dragCol         int                   `desc:"visible field index of header being dragged"`
+	dragResize      bool                  `desc:"true if resizing, false if reordering the dragged column"`
+	dragging        bool                  `desc:"true once header dragging has started"`
+	dragStX         int                   `desc:"starting x position of resizing"`
+	dragStWd        float32               `desc:"starting width of resized column"`
 }
 
 var KiT_TableView = kit.Types.AddType(&TableView{}, TableViewProps)
@@ -59,16 +74,34 @@ var _ SliceViewer = (*TableView)(nil)
 // TableViewStyleFunc is a styling function for custom styling /
 // configuration of elements in the view.  If style properties are set
 // then you must call widg.AsNode2dD().SetFullReRender() to trigger
-// re-styling during re-render
+// re-styling during re-render.  When viewing a Src (see SetSource), slice is
+// the page of rows currently fetched, starting at SrcStart, while row is the
+// overall row index.  col is the index of the field among all the fields
+// that can be shown, in struct order, regardless of column order or hiding.
 type TableViewStyleFunc func(tv *TableView, slice interface{}, widg gi.Node2D, row, col int, vv ValueView)
 
 // SetSlice sets the source slice that we are viewing -- rebuilds the children
-// to represent this slice (does Update if already viewing).
+// to represent this slice (does Update if already viewing).  The column
+// layout (see Cols) is restored from gi.Prefs.TableViews when the struct type
+// changes, and any column filters are applied -- a new slice starts out
+// unsorted, and is never sorted just by being viewed, unless
+// SortFilterPrefs is set.
 func (tv *TableView) SetSlice(sl interface{}) {
 	if kit.IfaceIsNil(sl) {
 		tv.Slice = nil
 		return
 	}
+	if tv.FiltSrc != nil && !tv.inFiltSet {
+		if tv.Src == SliceSource(tv.FiltSrc) {
+			if sl == tv.FiltSrc.Slice {
+				tv.FiltSrc.Refilter()
+				tv.SrcRefresh()
+				return
+			}
+			tv.Src = nil
+		}
+		tv.FiltSrc = nil
+	}
 	if tv.Slice == sl && tv.IsConfiged() {
 		tv.Update()
 		return
@@ -79,6 +112,12 @@ func (tv *TableView) SetSlice(sl interface{}) {
 	tv.StartIdx = 0
 	tv.SortIdx = -1
 	tv.SortDesc = false
+	if tv.Cols != nil && !tv.inFiltSet && !tv.SortFilterPrefs {
+		tv.Cols.Sort = nil
+	}
+	tv.dragCol = -1
+	tv.CellSel = nil
+	tv.CellCol = 0
 	slpTyp := reflect.TypeOf(sl)
 	if slpTyp.Kind() != reflect.Ptr {
 		log.Printf("TableView requires that you pass a pointer to a slice of struct elements -- type is not a Ptr: %v\n", slpTyp.String())
@@ -110,6 +149,9 @@ func (tv *TableView) SetSlice(sl interface{}) {
 	}
 	tv.Config()
 	tv.UpdateEnd(updt)
+	if !tv.inFiltSet && tv.HasFilters() {
+		tv.ApplyFilters()
+	}
 }
 
 var TableViewProps = ki.Props{
@@ -118,6 +160,26 @@ var TableViewProps = ki.Props{
 	"color":            &gi.Prefs.Colors.Font,
 	"max-width":        -1,
 	"max-height":       -1,
+	"CallMethods": ki.PropSlice{
+		{"ExportCSV", ki.Props{
+			"label": "Export CSV...",
+			"desc":  "save the rows of the table as viewed (sorted and filtered) to a CSV file, or TSV if the extension is .tsv",
+			"Args": ki.PropSlice{
+				{"CSV File Name", ki.Props{
+					"ext": ".csv,.tsv",
+				}},
+			},
+		}},
+		{"ImportCSV", ki.Props{
+			"label": "Import CSV...",
+			"desc":  "append rows to the table from a CSV file (or TSV if the extension is .tsv) with a header row of field names",
+			"Args": ki.PropSlice{
+				{"CSV File Name", ki.Props{
+					"ext": ".csv,.tsv",
+				}},
+			},
+		}},
+	},
 }
 
 // StructType sets the StruType and returns the type of the struct within the
@@ -127,11 +189,14 @@ func (tv *TableView) StructType() reflect.Type {
 	return tv.StruType
 }
 
-// CacheVisFields computes the number of visible fields in nVisFields and
-// caches those to skip in fieldSkip
+// CacheVisFields computes the fields that can be shown in AllFields, and
+// the visible fields in display order in VisFields, according to Cols
 func (tv *TableView) CacheVisFields() {
 	styp := tv.StructType()
-	tv.VisFields = make([]reflect.StructField, 0, 20)
+	if tv.Cols == nil || tv.colsType != styp {
+		tv.LoadColPrefs()
+	}
+	tv.AllFields = make([]reflect.StructField, 0, 20)
 	kit.FlatFieldsTypeFunc(styp, func(typ reflect.Type, fld reflect.StructField) bool {
 		tvtag := fld.Tag.Get("tableview")
 		add := true
@@ -152,17 +217,18 @@ func (tv *TableView) CacheVisFields() {
 			if typ != styp {
 				rfld, has := styp.FieldByName(fld.Name)
 				if has {
-					tv.VisFields = append(tv.VisFields, rfld)
+					tv.AllFields = append(tv.AllFields, rfld)
 				} else {
 					fmt.Printf("TableView: Field name: %v is ambiguous from base struct type: %v, cannot be used in view!\n", fld.Name, styp.String())
 				}
 			} else {
-				tv.VisFields = append(tv.VisFields, fld)
+				tv.AllFields = append(tv.AllFields, fld)
 			}
 		}
 		return true
 	})
-	tv.NVisFields = len(tv.VisFields)
+	tv.OrderVisFields()
+	tv.UpdateSortIdx()
 }
 
 // IsConfiged returns true if the widget is fully configured
@@ -277,6 +343,9 @@ func (tv *TableView) ConfigSliceGrid() {
 
 	sgcfg := kit.TypeAndNameList{}
 	sgcfg.Add(gi.KiT_ToolBar, "header")
+	if tv.ShowFilters {
+		sgcfg.Add(gi.KiT_ToolBar, "filters")
+	}
 	sgcfg.Add(gi.KiT_Layout, "grid-lay")
 	sg.ConfigChildren(sgcfg, ki.UniqueNames)
 
@@ -340,16 +409,8 @@ func (tv *TableView) ConfigSliceGrid() {
 	for fli := 0; fli < tv.NVisFields; fli++ {
 		field := tv.VisFields[fli]
 		hdr := sgh.Child(idxOff + fli).(*gi.Action)
-		hdr.SetText(field.Name)
-		if fli == tv.SortIdx {
-			if tv.SortDesc {
-				hdr.SetIcon("wedge-down")
-			} else {
-				hdr.SetIcon("wedge-up")
-			}
-		}
 		hdr.Data = fli
-		hdr.Tooltip = field.Name + " (click to sort by)"
+		hdr.Tooltip = field.Name + " (click to sort by, Shift+click to add to sort, drag to move or resize, right-click for column menu)"
 		dsc := field.Tag.Get("desc")
 		if dsc != "" {
 			hdr.Tooltip += ": " + dsc
@@ -358,7 +419,7 @@ func (tv *TableView) ConfigSliceGrid() {
 			tvv := recv.Embed(KiT_TableView).(*TableView)
 			act := send.(*gi.Action)
 			fldIdx := act.Data.(int)
-			tvv.SortSliceAction(fldIdx)
+			tvv.HeaderClickAction(fldIdx)
 		})
 
 		val := kit.OnePtrUnderlyingValue(tv.SliceNPVal.Index(0)) // deal with pointer lists
@@ -375,7 +436,10 @@ func (tv *TableView) ConfigSliceGrid() {
 		widg := ki.NewOfType(vtyp).(gi.Node2D)
 		sgf.SetChild(widg, cidx, valnm)
 		vv.ConfigWidget(widg)
+		tv.SetColWidthProps(widg, field.Name)
 	}
+	tv.ConfigHeaderSort()
+	tv.ConfigFilterBar()
 
 	if !tv.IsInactive() {
 		cidx := tv.NVisFields + idxOff
@@ -401,7 +465,7 @@ func (tv *TableView) ConfigSliceGrid() {
 		}
 	}
 
-	if tv.SortIdx >= 0 {
+	if len(tv.Cols.Sort) > 0 {
 		tv.SortSlice()
 	}
 	tv.ConfigScroll()
@@ -466,9 +530,17 @@ func (tv *TableView) LayoutSliceGrid() bool {
 
 // LayoutHeader updates the header layout based on field widths
 func (tv *TableView) LayoutHeader() {
+	tv.LayoutHeaderBar(tv.SliceHeader())
+	if fb := tv.FilterBar(); fb != nil {
+		tv.LayoutHeaderBar(fb)
+	}
+}
+
+// LayoutHeaderBar updates the layout of given header bar (header or filter
+// bar) based on field widths
+func (tv *TableView) LayoutHeaderBar(sgh *gi.ToolBar) {
 	_, idxOff := tv.RowWidgetNs()
 	nfld := tv.NVisFields + idxOff
-	sgh := tv.SliceHeader()
 	sgf := tv.SliceGrid()
 	spc := sgh.Spacing.Dots
 	gd := sgf.GridData[gi.Col]
@@ -537,12 +609,14 @@ func (tv *TableView) UpdateSliceGrid() {
 	} else {
 		tv.StartIdx = 0
 	}
+	tv.SrcFetch()
 
 	for i := 0; i < tv.DispRows; i++ {
 		ridx := i * nWidgPerRow
 		si := tv.StartIdx + i // slice idx
 		issel := tv.IdxIsSelected(si)
-		val := kit.OnePtrUnderlyingValue(tv.SliceNPVal.Index(si)) // deal with pointer lists
+		csel := issel
+		val := kit.OnePtrUnderlyingValue(tv.SrcRowVal(si)) // deal with pointer lists
 		stru := val.Interface()
 
 		itxt := fmt.Sprintf("%05d", i)
@@ -564,6 +638,10 @@ func (tv *TableView) UpdateSliceGrid() {
 						wbb := send.(gi.Node2D).AsWidget()
 						row := wbb.Prop("tv-row").(int)
 						tvv := recv.Embed(KiT_TableView).(*TableView)
+						if tvv.CellMode {
+							tvv.SelectRowCells(tvv.StartIdx + row)
+							return
+						}
 						tvv.UpdateSelectRow(row, wbb.IsSelected())
 					}
 				})
@@ -574,7 +652,7 @@ func (tv *TableView) UpdateSliceGrid() {
 		}
 
 		vpath := tv.ViewPath + "[" + sitxt + "]"
-		if lblr, ok := tv.Slice.(gi.SliceLabeler); ok {
+		if lblr, ok := tv.Slice.(gi.SliceLabeler); ok && tv.Src == nil {
 			slbl := lblr.ElemLabel(si)
 			if slbl != "" {
 				vpath = tv.ViewPath + "[" + slbl + "]"
@@ -584,6 +662,9 @@ func (tv *TableView) UpdateSliceGrid() {
 			field := tv.VisFields[fli]
 			fval := val.Elem().FieldByIndex(field.Index)
 			vvi := i*tv.NVisFields + fli
+			if tv.CellMode {
+				csel = tv.CellIsSelected(si, fli)
+			}
 			var vv ValueView
 			if tv.Values[vvi] == nil {
 				tags := ""
@@ -611,22 +692,30 @@ func (tv *TableView) UpdateSliceGrid() {
 				if tv.IsInactive() {
 					widg.AsNode2D().SetInactive()
 				}
-				widg.AsNode2D().SetSelectedState(issel)
+				widg.AsNode2D().SetSelectedState(csel)
 			} else {
 				widg = ki.NewOfType(vtyp).(gi.Node2D)
 				sg.SetChild(widg, cidx, valnm)
 				vv.ConfigWidget(widg)
+				tv.SetColWidthProps(widg, field.Name)
 				wb := widg.AsWidget()
 				if wb != nil {
 					// totally not worth it now:
 					// wb.Sty.Template = "giv.TableViewView.ItemWidget." + vtyp.Name()
 					wb.SetProp("tv-row", i)
-					wb.ClearSelected()
+					wb.SetProp("tv-col", fli)
+					wb.SetSelectedState(csel)
 					wb.WidgetSig.ConnectOnly(tv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
 						if sig == int64(gi.WidgetSelected) || sig == int64(gi.WidgetFocused) {
 							wbb := send.(gi.Node2D).AsWidget()
 							row := wbb.Prop("tv-row").(int)
 							tvv := recv.Embed(KiT_TableView).(*TableView)
+							if tvv.CellMode {
+								if sig == int64(gi.WidgetFocused) {
+									tvv.CellFocused(row, wbb.Prop("tv-col").(int))
+								}
+								return
+							}
 							if sig != int64(gi.WidgetFocused) || !tvv.InFocusGrab {
 								tvv.UpdateSelectRow(row, wbb.IsSelected())
 							}
@@ -637,14 +726,18 @@ func (tv *TableView) UpdateSliceGrid() {
 					widg.AsNode2D().SetInactive()
 				} else {
 					vvb := vv.AsValueViewBase()
+					row := i
 					vvb.ViewSig.ConnectOnly(tv.This(), // todo: do we need this?
 						func(recv, send ki.Ki, sig int64, data interface{}) {
 							tvv, _ := recv.Embed(KiT_TableView).(*TableView)
+							if tvv.Src != nil {
+								tvv.SrcSaveRow(tvv.StartIdx + row)
+							}
 							tvv.SetChanged()
 						})
 				}
 			}
-			tv.This().(SliceViewer).StyleRow(tv.SliceNPVal, widg, si, fli, vv)
+			tv.This().(SliceViewer).StyleRow(tv.SliceNPVal, widg, si, tv.VisIdxs[fli], vv)
 		}
 
 		if !tv.IsInactive() {
@@ -686,7 +779,7 @@ func (tv *TableView) UpdateSliceGrid() {
 		}
 	}
 
-	if tv.SelField != "" && tv.SelVal != nil {
+	if tv.SelField != "" && tv.SelVal != nil && tv.Src == nil {
 		tv.SelectedIdx, _ = StructSliceIdxByValue(tv.Slice, tv.SelField, tv.SelVal)
 	}
 	if tv.IsInactive() && tv.SelectedIdx >= 0 {
@@ -704,6 +797,10 @@ func (tv *TableView) StyleRow(svnp reflect.Value, widg gi.Node2D, idx, fidx int,
 // SliceNewAt inserts a new blank element at given index in the slice -- -1
 // means the end
 func (tv *TableView) SliceNewAt(idx int) {
+	if tv.Src != nil {
+		tv.SrcNewAt(idx)
+		return
+	}
 	wupdt := tv.TopUpdateStart()
 	defer tv.TopUpdateEnd(wupdt)
 
@@ -732,6 +829,10 @@ func (tv *TableView) SliceDeleteAt(idx int, doupdt bool) {
 	if idx < 0 {
 		return
 	}
+	if tv.Src != nil {
+		tv.SrcDeleteAt(idx, doupdt)
+		return
+	}
 	wupdt := tv.TopUpdateStart()
 	defer tv.TopUpdateEnd(wupdt)
 
@@ -753,54 +854,51 @@ func (tv *TableView) SliceDeleteAt(idx int, doupdt bool) {
 	tv.SliceViewSig.Emit(tv.This(), int64(SliceViewDeleted), idx)
 }
 
-// SortSlice sorts the slice according to current settings
+// SortSlice sorts the slice according to current sort keys (Cols.Sort) --
+// when viewing a Src, the sort is done by the Src if it implements
+// SliceSourceSorter, sorting on each key from lowest to highest priority
+// (which requires a stable sort in the Src)
 func (tv *TableView) SortSlice() {
-	if tv.SortIdx < 0 || tv.SortIdx >= len(tv.VisFields) {
+	if len(tv.Cols.Sort) == 0 {
+		return
+	}
+	if tv.Src != nil && (tv.FiltSrc == nil || tv.Src != SliceSource(tv.FiltSrc)) {
+		if srt, ok := tv.Src.(SliceSourceSorter); ok {
+			for i := len(tv.Cols.Sort) - 1; i >= 0; i-- {
+				spnm := strings.Split(tv.Cols.Sort[i], ":")
+				srt.SrcSort(spnm[0], !(len(spnm) == 2 && spnm[1] == "down"))
+			}
+			tv.SrcStale = true
+		}
 		return
 	}
-	rawIdx := tv.VisFields[tv.SortIdx].Index
-	kit.StructSliceSort(tv.Slice, rawIdx, !tv.SortDesc)
+	sl := tv.Slice
+	if tv.FiltSrc != nil {
+		sl = tv.FiltSrc.Slice
+	}
+	fldIdxs, ascending := tv.SortFields()
+	if len(fldIdxs) == 1 {
+		kit.StructSliceSort(sl, fldIdxs[0], ascending[0])
+	} else {
+		StructSliceSortMulti(sl, fldIdxs, ascending)
+	}
+	if tv.FiltSrc != nil {
+		tv.FiltSrc.Refilter()
+		tv.SrcStale = true
+	}
 }
 
 // SortSliceAction sorts the slice for given field index -- toggles ascending
-// vs. descending if already sorting on this dimension
+// vs. descending if already sorting on this dimension -- any other sort keys
+// are removed (see AddSortSliceAction for sorting on multiple columns)
 func (tv *TableView) SortSliceAction(fldIdx int) {
-	oswin.TheApp.Cursor(tv.ParentWindow().OSWin).Push(cursor.Wait)
-	defer oswin.TheApp.Cursor(tv.ParentWindow().OSWin).Pop()
-
-	wupdt := tv.TopUpdateStart()
-	defer tv.TopUpdateEnd(wupdt)
-
-	updt := tv.UpdateStart()
-	sgh := tv.SliceHeader()
-	sgh.SetFullReRender()
-	_, idxOff := tv.RowWidgetNs()
-
-	ascending := true
-
-	for fli := 0; fli < tv.NVisFields; fli++ {
-		hdr := sgh.Child(idxOff + fli).(*gi.Action)
-		if fli == fldIdx {
-			if tv.SortIdx == fli {
-				tv.SortDesc = !tv.SortDesc
-				ascending = !tv.SortDesc
-			} else {
-				tv.SortDesc = false
-			}
-			if ascending {
-				hdr.SetIcon("wedge-up")
-			} else {
-				hdr.SetIcon("wedge-down")
-			}
-		} else {
-			hdr.SetIcon("none")
-		}
+	if fldIdx < 0 || fldIdx >= tv.NVisFields {
+		return
 	}
-
-	tv.SortIdx = fldIdx
-	tv.SortSlice()
-	tv.UpdateSliceGrid()
-	tv.UpdateEnd(updt)
+	fld := tv.VisFields[fldIdx].Name
+	pri, desc := tv.SortKeyIdx(fld)
+	tv.Cols.Sort = []string{sortKey(fld, pri == 0 && !desc)}
+	tv.SortColsAction()
 }
 
 // ConfigToolbar configures the toolbar actions
@@ -812,9 +910,9 @@ func (tv *TableView) ConfigToolbar() {
 		return
 	}
 	tb := tv.ToolBar()
-	ndef := 2 // number of default actions
+	ndef := 4 // number of default actions
 	if tv.isArray || tv.IsInactive() || tv.NoAdd {
-		ndef = 1
+		ndef = 2
 	}
 	if len(*tb.Children()) < ndef {
 		tb.SetStretchMaxWidth()
@@ -823,12 +921,22 @@ func (tv *TableView) ConfigToolbar() {
 				tvv := recv.Embed(KiT_TableView).(*TableView)
 				tvv.UpdateSliceGrid()
 			})
-		if ndef > 1 {
+		tb.AddAction(gi.ActOpts{Label: "Export CSV", Icon: "file-save", Tooltip: "save the rows of the table as viewed to a CSV or TSV file"},
+			tv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+				tvv := recv.Embed(KiT_TableView).(*TableView)
+				CallMethod(tvv, "ExportCSV", tvv.ViewportSafe())
+			})
+		if ndef > 2 {
 			tb.AddAction(gi.ActOpts{Label: "Add", Icon: "plus", Tooltip: "add a new element to the table"},
 				tv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
 					tvv := recv.Embed(KiT_TableView).(*TableView)
 					tvv.SliceNewAt(-1)
 				})
+			tb.AddAction(gi.ActOpts{Label: "Import CSV", Icon: "file-open", Tooltip: "append rows to the table from a CSV or TSV file, matching columns to fields by name"},
+				tv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+					tvv := recv.Embed(KiT_TableView).(*TableView)
+					CallMethod(tvv, "ImportCSV", tvv.ViewportSafe())
+				})
 		}
 	}
 	sz := len(*tb.Children())
@@ -845,7 +953,7 @@ func (tv *TableView) ConfigToolbar() {
 }
 
 // SortFieldName returns the name of the field being sorted, along with :up or
-// :down depending on descending
+// :down depending on descending -- this is the primary sort key
 func (tv *TableView) SortFieldName() string {
 	if tv.SortIdx >= 0 && tv.SortIdx < tv.NVisFields {
 		nm := tv.VisFields[tv.SortIdx].Name
@@ -860,7 +968,7 @@ func (tv *TableView) SortFieldName() string {
 }
 
 // SetSortField sets sorting to happen on given field and direction -- see
-// SortFieldName for details
+// SortFieldName for details -- this replaces any other sort keys
 func (tv *TableView) SetSortFieldName(nm string) {
 	if nm == "" {
 		return
@@ -879,6 +987,9 @@ func (tv *TableView) SetSortFieldName(nm string) {
 			tv.SortDesc = false
 		}
 	}
+	if tv.Cols != nil {
+		tv.Cols.Sort = []string{sortKey(spnm[0], tv.SortDesc)}
+	}
 }
 
 func (tv *TableView) Layout2D(parBBox image.Rectangle, iter int) bool {
@@ -888,9 +999,23 @@ func (tv *TableView) Layout2D(parBBox image.Rectangle, iter int) bool {
 	}
 	tv.LayoutHeader()
 	tv.SliceHeader().Layout2D(parBBox, iter)
+	if fb := tv.FilterBar(); fb != nil {
+		fb.Layout2D(parBBox, iter)
+	}
 	return redo
 }
 
+func (tv *TableView) ConnectEvents2D() {
+	tv.SliceViewBaseEvents()
+	tv.TableViewHeaderEvents()
+	tv.TableViewCellEvents()
+}
+
+func (tv *TableView) AccessInfo2D(ai *gi.AccessInfo) {
+	tv.SliceViewBase.AccessInfo2D(ai)
+	ai.Role = gi.AccessTable
+}
+
 // RowFirstVisWidget returns the first visible widget for given row (could be
 // index or not) -- false if out of range
 func (tv *TableView) RowFirstVisWidget(row int) (*gi.WidgetBase, bool) {
@@ -957,7 +1082,11 @@ func (tv *TableView) SelectRowWidgets(row int, sel bool) {
 		seldx := ridx + idxOff + fli
 		if sg.Kids.IsValidIndex(seldx) == nil {
 			widg := sg.Child(seldx).(gi.Node2D).AsNode2D()
-			widg.SetSelectedState(sel)
+			if tv.CellMode {
+				widg.SetSelectedState(sel && tv.CellIsSelected(tv.StartIdx+row, fli))
+			} else {
+				widg.SetSelectedState(sel)
+			}
 			widg.UpdateSig()
 		}
 	}
@@ -976,7 +1105,7 @@ func (tv *TableView) SelectRowWidgets(row int, sel bool) {
 func (tv *TableView) SelectFieldVal(fld, val string) bool {
 	tv.SelField = fld
 	tv.SelVal = val
-	if tv.SelField != "" && tv.SelVal != nil {
+	if tv.SelField != "" && tv.SelVal != nil && tv.Src == nil {
 		idx, _ := StructSliceIdxByValue(tv.Slice, tv.SelField, tv.SelVal)
 		if idx >= 0 {
 			tv.ScrollToIdx(idx)
@@ -0,0 +1,568 @@
+// Copyright (c) 2018, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package giv
+
+import (
+	"fmt"
+	"image"
+	"reflect"
+
+	"github.com/goki/gi/gi"
+	"github.com/goki/gi/oswin"
+	"github.com/goki/gi/oswin/key"
+	"github.com/goki/gi/oswin/mouse"
+	"github.com/goki/ki/ints"
+	"github.com/goki/ki/ki"
+	"github.com/goki/pi/filecat"
+)
+
+// TableCellRange is a rectangular range of cells in a TableView in CellMode,
+// from the anchor cell at StIdx, StCol to the cell at EdIdx, EdCol, inclusive
+// -- indexes are slice indexes and cols are visible field indexes, and Ed
+// can be before St
+type TableCellRange struct {
+	StIdx int `desc:"slice index of the anchor cell"`
+	StCol int `desc:"visible field index of the anchor cell"`
+	EdIdx int `desc:"slice index of the end cell"`
+	EdCol int `desc:"visible field index of the end cell"`
+}
+
+// Rows returns the first and last slice indexes in the range
+func (cr TableCellRange) Rows() (st, ed int) {
+	return ints.MinInt(cr.StIdx, cr.EdIdx), ints.MaxInt(cr.StIdx, cr.EdIdx)
+}
+
+// Cols returns the first and last visible field indexes in the range
+func (cr TableCellRange) Cols() (st, ed int) {
+	return ints.MinInt(cr.StCol, cr.EdCol), ints.MaxInt(cr.StCol, cr.EdCol)
+}
+
+// Contains returns true if the range contains the cell at given slice index
+// and visible field index
+func (cr TableCellRange) Contains(idx, col int) bool {
+	rs, re := cr.Rows()
+	cs, ce := cr.Cols()
+	return idx >= rs && idx <= re && col >= cs && col <= ce
+}
+
+// SetCellMode turns the cell-cursor mode on or off -- see CellMode
+func (tv *TableView) SetCellMode(on bool) {
+	tv.CellMode = on
+	tv.CellSel = nil
+	tv.CellCol = 0
+	if on {
+		tv.SetCanFocus()
+	}
+	tv.UnselectAllIdxs()
+	if tv.IsConfiged() {
+		tv.UpdateSliceGrid()
+	}
+}
+
+// CellIsSelected returns true if the cell at given slice index and visible
+// field index is in any of the selected ranges
+func (tv *TableView) CellIsSelected(idx, col int) bool {
+	for _, cr := range tv.CellSel {
+		if cr.Contains(idx, col) {
+			return true
+		}
+	}
+	return false
+}
+
+// CellCols returns the columns of cells that are copied for the selected
+// rows: all the visible fields, or in CellMode, the visible fields in any of
+// the selected ranges
+func (tv *TableView) CellCols() []int {
+	if !tv.CellMode {
+		return tv.SliceViewBase.CellCols()
+	}
+	if len(tv.CellSel) == 0 {
+		return []int{tv.CellCol}
+	}
+	used := make([]bool, tv.NVisFields)
+	for _, cr := range tv.CellSel {
+		cs, ce := cr.Cols()
+		for c := cs; c <= ce && c < tv.NVisFields; c++ {
+			used[c] = true
+		}
+	}
+	var cols []int
+	for c, u := range used {
+		if u {
+			cols = append(cols, c)
+		}
+	}
+	return cols
+}
+
+// CellWidget returns the widget and ValueView for the cell at given slice
+// index and visible field index, if it is currently displayed
+func (tv *TableView) CellWidget(idx, col int) (*gi.WidgetBase, ValueView) {
+	row := idx - tv.StartIdx
+	if !tv.IsRowInBounds(row) || col < 0 || col >= tv.NVisFields {
+		return nil, nil
+	}
+	nWidgPerRow, idxOff := tv.RowWidgetNs()
+	sg := tv.SliceGrid()
+	cidx := row*nWidgPerRow + idxOff + col
+	if sg.Kids.IsValidIndex(cidx) != nil || sg.Kids[cidx] == nil {
+		return nil, nil
+	}
+	vvi := row*tv.NVisFields + col
+	if vvi >= len(tv.Values) {
+		return nil, nil
+	}
+	return sg.Kids[cidx].(gi.Node2D).AsWidget(), tv.Values[vvi]
+}
+
+// CellAtPos returns the slice index and visible field index of the displayed
+// cell at given window position -- false if none
+func (tv *TableView) CellAtPos(pos image.Point) (idx, col int, ok bool) {
+	for row := 0; row < tv.DispRows; row++ {
+		for col := 0; col < tv.NVisFields; col++ {
+			wb, _ := tv.CellWidget(tv.StartIdx+row, col)
+			if wb != nil && wb.PosInWinBBox(pos) {
+				return tv.StartIdx + row, col, true
+			}
+		}
+	}
+	return -1, -1, false
+}
+
+// CellEditing returns true if the current cell is being edited, i.e., its
+// widget has the focus
+func (tv *TableView) CellEditing() bool {
+	wb, _ := tv.CellWidget(tv.SelectedIdx, tv.CellCol)
+	if wb == nil {
+		return false
+	}
+	return wb.HasFocus() || wb.ContainsFocus()
+}
+
+// SetCellCursor moves the cell cursor to given slice index and visible field
+// index, updating the selected ranges according to selMode: SelectOne
+// selects just this cell, ExtendContinuous extends the last range to it, and
+// ExtendOne adds a new range
+func (tv *TableView) SetCellCursor(idx, col int, selMode mouse.SelectModes) {
+	if tv.SliceSize == 0 || tv.NVisFields == 0 {
+		return
+	}
+	idx = ints.MaxInt(0, ints.MinInt(idx, tv.SliceSize-1))
+	col = ints.MaxInt(0, ints.MinInt(col, tv.NVisFields-1))
+	tv.SelectedIdx = ints.MaxInt(0, ints.MinInt(tv.SelectedIdx, tv.SliceSize-1))
+	switch selMode {
+	case mouse.ExtendContinuous:
+		if len(tv.CellSel) == 0 {
+			tv.CellSel = append(tv.CellSel, TableCellRange{tv.SelectedIdx, tv.CellCol, tv.SelectedIdx, tv.CellCol})
+		}
+		cr := &tv.CellSel[len(tv.CellSel)-1]
+		cr.EdIdx, cr.EdCol = idx, col
+	case mouse.ExtendOne:
+		tv.CellSel = append(tv.CellSel, TableCellRange{idx, col, idx, col})
+	default:
+		tv.CellSel = []TableCellRange{{idx, col, idx, col}}
+	}
+	tv.SelectedIdx = idx
+	tv.CellCol = col
+	tv.ScrollToIdx(idx)
+	tv.UpdateCellSel()
+	tv.WidgetSig.Emit(tv.This(), int64(gi.WidgetSelected), tv.SelectedIdx)
+}
+
+// MoveCellAction moves the cell cursor by given number of rows and columns,
+// using given select mode (from keyboard modifiers) -- ExtendContinuous
+// moves the end of the last selected range instead
+func (tv *TableView) MoveCellAction(drow, dcol int, selMode mouse.SelectModes) {
+	if selMode == mouse.ExtendContinuous && len(tv.CellSel) > 0 {
+		cr := &tv.CellSel[len(tv.CellSel)-1]
+		cr.EdIdx = ints.MaxInt(0, ints.MinInt(cr.EdIdx+drow, tv.SliceSize-1))
+		cr.EdCol = ints.MaxInt(0, ints.MinInt(cr.EdCol+dcol, tv.NVisFields-1))
+		tv.ScrollToIdx(cr.EdIdx)
+		tv.UpdateCellSel()
+		return
+	}
+	if selMode == mouse.ExtendOne {
+		selMode = mouse.SelectOne
+	}
+	tv.SetCellCursor(tv.SelectedIdx+drow, tv.CellCol+dcol, selMode)
+}
+
+// AdvanceCellAction moves the cell cursor to the next cell (or previous if
+// back), wrapping to the next (previous) row at the end of a row
+func (tv *TableView) AdvanceCellAction(back bool) {
+	idx, col := tv.SelectedIdx, tv.CellCol
+	if back {
+		col--
+		if col < 0 && idx > 0 {
+			idx--
+			col = tv.NVisFields - 1
+		}
+	} else {
+		col++
+		if col >= tv.NVisFields && idx < tv.SliceSize-1 {
+			idx++
+			col = 0
+		}
+	}
+	tv.SetCellCursor(idx, col, mouse.SelectOne)
+}
+
+// SelectRowCells selects all the cells in the row at given slice index, e.g.,
+// when its index label is clicked, using the selection mode from the last
+// mouse event
+func (tv *TableView) SelectRowCells(idx int) {
+	if tv.SliceSize == 0 || tv.NVisFields == 0 {
+		return
+	}
+	selMode := mouse.SelectOne
+	if em := tv.EventMgr2D(); em != nil {
+		selMode = em.LastSelMode
+	}
+	if selMode == mouse.ExtendContinuous {
+		tv.SetCellCursor(idx, tv.NVisFields-1, selMode)
+		tv.CellSel[len(tv.CellSel)-1].StCol = 0
+	} else {
+		tv.SetCellCursor(idx, 0, selMode)
+		if len(tv.CellSel) > 0 {
+			tv.CellSel[len(tv.CellSel)-1].EdCol = tv.NVisFields - 1
+		}
+	}
+	tv.UpdateCellSel()
+}
+
+// SelectAllCells selects all the cells in the table
+func (tv *TableView) SelectAllCells() {
+	if tv.SliceSize == 0 || tv.NVisFields == 0 {
+		return
+	}
+	tv.CellSel = []TableCellRange{{0, 0, tv.SliceSize - 1, tv.NVisFields - 1}}
+	tv.UpdateCellSel()
+}
+
+// UpdateCellSel updates the selected rows (SelectedIdxs) to be those in the
+// selected cell ranges, and the selection state of the displayed cells
+func (tv *TableView) UpdateCellSel() {
+	tv.ResetSelectedIdxs()
+	for _, cr := range tv.CellSel {
+		rs, re := cr.Rows()
+		for idx := rs; idx <= re && idx < tv.SliceSize; idx++ {
+			tv.SelectedIdxs[idx] = struct{}{}
+		}
+	}
+	tv.CurIdx = tv.SelectedIdx
+	if ixs := tv.SelectedIdxsList(false); len(ixs) > 0 {
+		tv.CurIdx = ixs[0] // paste at top of selection
+	}
+	wupdt := tv.TopUpdateStart()
+	defer tv.TopUpdateEnd(wupdt)
+	for row := 0; row < tv.DispRows; row++ {
+		tv.SelectRowWidgets(row, tv.IdxIsSelected(tv.StartIdx+row))
+	}
+}
+
+// CellFocused is called when the widget for given displayed row and visible
+// field index gets the focus in CellMode, to move the cursor to it
+func (tv *TableView) CellFocused(row, col int) {
+	idx := tv.StartIdx + row
+	if idx == tv.SelectedIdx && col == tv.CellCol {
+		return
+	}
+	tv.SetCellCursor(idx, col, mouse.SelectOne)
+}
+
+// CellEditAction begins editing the current cell, by giving its widget the
+// focus, or by activating its ValueView action (e.g., a dialog) if the widget
+// cannot take the focus
+func (tv *TableView) CellEditAction() {
+	if tv.IsInactive() {
+		return
+	}
+	tv.ScrollToIdx(tv.SelectedIdx)
+	wb, vv := tv.CellWidget(tv.SelectedIdx, tv.CellCol)
+	if wb == nil {
+		return
+	}
+	if wb.CanFocus() && !wb.IsInactive() {
+		wb.GrabFocus()
+		return
+	}
+	if vv != nil && vv.HasAction() {
+		vv.Activate(tv.ViewportSafe(), nil, nil)
+	}
+}
+
+// CellCommitEdit ends editing of the current cell, committing the edited
+// value (which happens when the widget loses the focus) -- if cancel, the
+// widget is first reset to the current value, discarding the edit
+func (tv *TableView) CellCommitEdit(cancel bool) {
+	if cancel {
+		if _, vv := tv.CellWidget(tv.SelectedIdx, tv.CellCol); vv != nil {
+			vv.UpdateWidget()
+		}
+	}
+	tv.GrabFocus()
+}
+
+// CellsEdited updates everything after cells have been edited in bulk
+func (tv *TableView) CellsEdited() {
+	if tv.TmpSave != nil {
+		tv.TmpSave.SaveTmp()
+	}
+	if tv.Src != nil {
+		tv.SrcStale = true
+	}
+	tv.SetChanged()
+	tv.UpdateSliceGrid()
+	tv.ViewSig.Emit(tv.This(), 0, nil)
+}
+
+// FillDownAction sets all the cells in each selected range to the value of
+// the cell in the first row of the range, in the same column
+func (tv *TableView) FillDownAction() {
+	if tv.IsInactive() || len(tv.CellSel) == 0 {
+		return
+	}
+	var errs []string
+	tv.ViewMuLock()
+	for _, cr := range tv.CellSel {
+		rs, re := cr.Rows()
+		cs, ce := cr.Cols()
+		for col := cs; col <= ce; col++ {
+			sfv, _ := tv.CellField(rs, col)
+			if !sfv.IsValid() {
+				continue
+			}
+			for idx := rs + 1; idx <= re; idx++ {
+				fv, val := tv.CellField(idx, col)
+				if !fv.IsValid() {
+					continue
+				}
+				fv.Set(sfv)
+				if err := tv.CellSaveRow(idx, val); err != nil {
+					errs = append(errs, fmt.Sprintf("row %d: %v", idx, err))
+				}
+			}
+		}
+	}
+	tv.ViewMuUnlock()
+	tv.CellsEdited()
+	tv.CellErrorsDialog("Fill Down Errors", errs)
+}
+
+// ClearCellsAction sets all the cells in the selected ranges to the zero
+// value for their field type
+func (tv *TableView) ClearCellsAction() {
+	if tv.IsInactive() || len(tv.CellSel) == 0 {
+		return
+	}
+	var errs []string
+	tv.ViewMuLock()
+	for _, cr := range tv.CellSel {
+		rs, re := cr.Rows()
+		cs, ce := cr.Cols()
+		for idx := rs; idx <= re; idx++ {
+			for col := cs; col <= ce; col++ {
+				fv, val := tv.CellField(idx, col)
+				if !fv.IsValid() {
+					continue
+				}
+				fv.Set(reflect.Zero(fv.Type()))
+				if err := tv.CellSaveRow(idx, val); err != nil {
+					errs = append(errs, fmt.Sprintf("row %d: %v", idx, err))
+				}
+			}
+		}
+	}
+	tv.ViewMuUnlock()
+	tv.CellsEdited()
+	tv.CellErrorsDialog("Clear Errors", errs)
+}
+
+// ItemCtxtMenu pulls up the context menu for given slice index -- in
+// CellMode, it has the actions for the selected cells
+func (tv *TableView) ItemCtxtMenu(idx int) {
+	if !tv.CellMode {
+		tv.SliceViewBase.ItemCtxtMenu(idx)
+		return
+	}
+	var men gi.Menu
+	tv.CellsCtxtMenu(&men, idx)
+	if !tv.IsInactive() {
+		men.AddAction(gi.ActOpts{Label: "Paste Cells", Tooltip: "paste cells copied from a spreadsheet or other app, starting at the top-left selected cell"},
+			tv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+				tvv := recv.Embed(KiT_TableView).(*TableView)
+				tvv.Paste()
+			})
+		men.AddSeparator("sep-edit")
+		men.AddAction(gi.ActOpts{Label: "Edit Cell", Tooltip: "edit the current cell (Enter or F2)"},
+			tv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+				tvv := recv.Embed(KiT_TableView).(*TableView)
+				tvv.CellEditAction()
+			})
+		men.AddAction(gi.ActOpts{Label: "Fill Down", Tooltip: "set the selected cells to the value in the first row of each selected range"},
+			tv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+				tvv := recv.Embed(KiT_TableView).(*TableView)
+				tvv.FillDownAction()
+			})
+		men.AddAction(gi.ActOpts{Label: "Clear Cells", Tooltip: "set the selected cells to empty (zero) values"},
+			tv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+				tvv := recv.Embed(KiT_TableView).(*TableView)
+				tvv.ClearCellsAction()
+			})
+	}
+	pos := tv.IdxPos(idx)
+	gi.PopupMenu(men, pos.X, pos.Y, tv.ViewportSafe(), tv.Nm+"-menu")
+}
+
+// KeyInputCell handles key events in CellMode
+func (tv *TableView) KeyInputCell(kt *key.ChordEvent) {
+	if gi.KeyEventTrace {
+		fmt.Printf("TableView KeyInputCell: %v\n", tv.PathUnique())
+	}
+	kf := gi.KeyFun(kt.Chord())
+	if tv.CellEditing() {
+		switch kf {
+		case gi.KeyFunFocusNext:
+			tv.CellCommitEdit(false)
+			tv.AdvanceCellAction(false)
+			kt.SetProcessed()
+		case gi.KeyFunFocusPrev:
+			tv.CellCommitEdit(false)
+			tv.AdvanceCellAction(true)
+			kt.SetProcessed()
+		case gi.KeyFunEnter, gi.KeyFunAccept:
+			tv.CellCommitEdit(false)
+			tv.MoveCellAction(1, 0, mouse.SelectOne)
+			kt.SetProcessed()
+		case gi.KeyFunAbort:
+			tv.CellCommitEdit(true)
+			kt.SetProcessed()
+		}
+		return
+	}
+	selMode := mouse.SelectModeBits(kt.Modifiers)
+	if kt.Code == key.CodeF2 {
+		tv.CellEditAction()
+		kt.SetProcessed()
+		return
+	}
+	switch kf {
+	case gi.KeyFunMoveDown:
+		tv.MoveCellAction(1, 0, selMode)
+		kt.SetProcessed()
+	case gi.KeyFunMoveUp:
+		tv.MoveCellAction(-1, 0, selMode)
+		kt.SetProcessed()
+	case gi.KeyFunMoveRight:
+		tv.MoveCellAction(0, 1, selMode)
+		kt.SetProcessed()
+	case gi.KeyFunMoveLeft:
+		tv.MoveCellAction(0, -1, selMode)
+		kt.SetProcessed()
+	case gi.KeyFunPageDown:
+		tv.MoveCellAction(ints.MaxInt(1, tv.DispRows-1), 0, selMode)
+		kt.SetProcessed()
+	case gi.KeyFunPageUp:
+		tv.MoveCellAction(-ints.MaxInt(1, tv.DispRows-1), 0, selMode)
+		kt.SetProcessed()
+	case gi.KeyFunHome:
+		tv.MoveCellAction(0, -tv.CellCol, selMode)
+		kt.SetProcessed()
+	case gi.KeyFunEnd:
+		tv.MoveCellAction(0, tv.NVisFields-1-tv.CellCol, selMode)
+		kt.SetProcessed()
+	case gi.KeyFunFocusNext:
+		tv.AdvanceCellAction(false)
+		kt.SetProcessed()
+	case gi.KeyFunFocusPrev:
+		tv.AdvanceCellAction(true)
+		kt.SetProcessed()
+	case gi.KeyFunEnter:
+		tv.CellEditAction()
+		kt.SetProcessed()
+	case gi.KeyFunCancelSelect:
+		tv.SetCellCursor(tv.SelectedIdx, tv.CellCol, mouse.SelectOne)
+		kt.SetProcessed()
+	case gi.KeyFunSelectAll:
+		tv.SelectAllCells()
+		kt.SetProcessed()
+	case gi.KeyFunDelete, gi.KeyFunBackspace:
+		tv.ClearCellsAction()
+		kt.SetProcessed()
+	case gi.KeyFunDuplicate:
+		tv.FillDownAction()
+		kt.SetProcessed()
+	case gi.KeyFunCopy:
+		tv.CopyCells(filecat.TextPlain, tv.SelectedIdx)
+		kt.SetProcessed()
+	case gi.KeyFunCut:
+		tv.CopyCells(filecat.TextPlain, tv.SelectedIdx)
+		tv.ClearCellsAction()
+		kt.SetProcessed()
+	case gi.KeyFunPaste:
+		tv.Paste()
+		kt.SetProcessed()
+	default:
+		tv.KeyInputActive(kt)
+	}
+}
+
+// CellMouseEvent handles mouse events on the cells in CellMode: clicking
+// moves the cell cursor (Shift extends the last range, Ctrl / Meta adds a
+// range) and double-clicking edits the cell
+func (tv *TableView) CellMouseEvent(me *mouse.Event) {
+	if !tv.CellMode || me.Button != mouse.Left {
+		return
+	}
+	idx, col, ok := tv.CellAtPos(me.Where)
+	if !ok {
+		return
+	}
+	if idx == tv.SelectedIdx && col == tv.CellCol && tv.CellEditing() {
+		return // editing widget gets all events
+	}
+	me.SetProcessed()
+	switch me.Action {
+	case mouse.Press:
+		if tv.CellEditing() {
+			tv.CellCommitEdit(false)
+		}
+		tv.SetCellCursor(idx, col, mouse.SelectModeBits(me.Modifiers))
+		tv.GrabFocus()
+	case mouse.DoubleClick:
+		tv.SetCellCursor(idx, col, mouse.SelectOne)
+		tv.CellEditAction()
+	}
+}
+
+// TableViewCellEvents connects the events for CellMode, replacing the
+// SliceViewBase key handler with one that handles either mode
+func (tv *TableView) TableViewCellEvents() {
+	if tv.IsInactive() {
+		return
+	}
+	if tv.CellMode {
+		tv.SetCanFocus()
+	}
+	tv.ConnectEvent(oswin.KeyChordEvent, gi.HiPri, func(recv, send ki.Ki, sig int64, d interface{}) {
+		tvv := recv.Embed(KiT_TableView).(*TableView)
+		kt := d.(*key.ChordEvent)
+		if tvv.CellMode {
+			tvv.KeyInputCell(kt)
+		} else {
+			tvv.KeyInputActive(kt)
+		}
+	})
+	if !tv.IsConfiged() {
+		return
+	}
+	sg := tv.SliceGrid()
+	sg.ConnectEvent(oswin.MouseEvent, gi.HiPri, func(recv, send ki.Ki, sig int64, d interface{}) {
+		me := d.(*mouse.Event)
+		tvv := recv.ParentByType(KiT_TableView, ki.Embeds).Embed(KiT_TableView).(*TableView)
+		tvv.CellMouseEvent(me)
+	})
+}
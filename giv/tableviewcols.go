@@ -0,0 +1,1035 @@
+// Copyright (c) 2018, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package giv
+
+import (
+	"fmt"
+	"image"
+	"log"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/chewxy/math32"
+	"github.com/goki/gi/gi"
+	"github.com/goki/gi/oswin"
+	"github.com/goki/gi/oswin/cursor"
+	"github.com/goki/gi/oswin/key"
+	"github.com/goki/gi/oswin/mouse"
+	"github.com/goki/gi/units"
+	"github.com/goki/ki/ints"
+	"github.com/goki/ki/ki"
+	"github.com/goki/ki/kit"
+)
+
+// TableViewResizeMargin is the distance in dots from the right edge of a
+// column header within which a mouse press starts resizing the column
+var TableViewResizeMargin = 4
+
+// TableViewMinColWidth is the minimum width in dots of a resized column
+var TableViewMinColWidth = float32(16)
+
+////////////////////////////////////////////////////////////////////////////////////////
+//  TableColFilter
+
+// TableColFilter is a filter on the values of one field (column) of a
+// TableView.  The filter expression can be:
+//   - /regexp/ -- a regular expression matched against the value as a string
+//   - lo..hi -- a range (inclusive), where either end can be omitted --
+//     compared numerically if the bounds and value are numbers, otherwise as strings
+//   - anything else -- a case-insensitive substring of the value as a string
+type TableColFilter struct {
+	Field   string         `desc:"name of the field"`
+	Expr    string         `desc:"filter expression"`
+	Index   []int          `desc:"index of the field in the struct"`
+	Re      *regexp.Regexp `desc:"compiled regexp, for /regexp/ expressions"`
+	IsRange bool           `desc:"true for lo..hi range expressions"`
+	Lo      string         `desc:"lower bound of range -- empty = none"`
+	Hi      string         `desc:"upper bound of range -- empty = none"`
+}
+
+// NewTableColFilter returns a new filter on given field with given
+// expression -- returns an error if the expression is an invalid regexp
+func NewTableColFilter(fld reflect.StructField, expr string) (*TableColFilter, error) {
+	cf := &TableColFilter{Field: fld.Name, Expr: expr, Index: fld.Index}
+	switch {
+	case len(expr) > 1 && strings.HasPrefix(expr, "/") && strings.HasSuffix(expr, "/"):
+		re, err := regexp.Compile(expr[1 : len(expr)-1])
+		if err != nil {
+			return nil, err
+		}
+		cf.Re = re
+	case strings.Contains(expr, ".."):
+		rng := strings.SplitN(expr, "..", 2)
+		cf.IsRange = true
+		cf.Lo = strings.TrimSpace(rng[0])
+		cf.Hi = strings.TrimSpace(rng[1])
+	default:
+		cf.Expr = strings.ToLower(expr)
+	}
+	return cf, nil
+}
+
+// Match returns true if given struct value (non-pointer) passes the filter
+func (cf *TableColFilter) Match(stru reflect.Value) bool {
+	fv := stru.FieldByIndex(cf.Index).Interface()
+	str := kit.ToString(fv)
+	switch {
+	case cf.Re != nil:
+		return cf.Re.MatchString(str)
+	case cf.IsRange:
+		if fval, ok := kit.ToFloat(fv); ok && kit.NonPtrValue(reflect.ValueOf(fv)).Kind() != reflect.String {
+			if cf.Lo != "" {
+				if lo, err := strconv.ParseFloat(cf.Lo, 64); err == nil && fval < lo {
+					return false
+				}
+			}
+			if cf.Hi != "" {
+				if hi, err := strconv.ParseFloat(cf.Hi, 64); err == nil && fval > hi {
+					return false
+				}
+			}
+			return true
+		}
+		if cf.Lo != "" && str < cf.Lo {
+			return false
+		}
+		if cf.Hi != "" && str > cf.Hi {
+			return false
+		}
+		return true
+	}
+	return strings.Contains(strings.ToLower(str), cf.Expr)
+}
+
+// SliceSourceColFilterer is an optional interface for a SliceSource that
+// supports the per-column filters of TableView -- filts are the filter
+// expressions by field name (see TableColFilter for the standard syntax)
+type SliceSourceColFilterer interface {
+	// SrcColFilters sets the filters to apply -- empty map = no filters
+	SrcColFilters(filts map[string]string)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////
+//  TableFilterSource
+
+// TableFilterSource is the SliceSource that TableView uses to show the
+// subset of rows of a slice that pass its column filters -- edits, inserts
+// and deletes are applied to the underlying slice.
+type TableFilterSource struct {
+	Slice   interface{}       `desc:"pointer to the slice being filtered"`
+	Filters []*TableColFilter `desc:"filters that each row must pass"`
+	Idxs    []int             `desc:"indexes of the rows in Slice that pass the filters"`
+}
+
+// NewTableFilterSource returns a new TableFilterSource for given slice
+// (pointer to a slice of structs or pointers to structs)
+func NewTableFilterSource(sl interface{}) *TableFilterSource {
+	return &TableFilterSource{Slice: sl}
+}
+
+// SetFilters sets the filters from given filter expressions by field name,
+// for given struct type, and re-filters -- invalid expressions are logged
+// and ignored
+func (fs *TableFilterSource) SetFilters(struTyp reflect.Type, filts map[string]string) {
+	fs.Filters = fs.Filters[:0]
+	for fnm, expr := range filts {
+		if expr == "" {
+			continue
+		}
+		fld, ok := struTyp.FieldByName(fnm)
+		if !ok {
+			continue
+		}
+		cf, err := NewTableColFilter(fld, expr)
+		if err != nil {
+			log.Printf("giv.TableView filter on field: %v: %v\n", fnm, err)
+			continue
+		}
+		fs.Filters = append(fs.Filters, cf)
+	}
+	fs.Refilter()
+}
+
+// Refilter updates the Idxs of rows that pass the filters -- call when the
+// slice has changed
+func (fs *TableFilterSource) Refilter() {
+	svnp := kit.NonPtrValue(reflect.ValueOf(fs.Slice))
+	sz := svnp.Len()
+	fs.Idxs = fs.Idxs[:0]
+	for i := 0; i < sz; i++ {
+		stru := kit.OnePtrUnderlyingValue(svnp.Index(i)).Elem()
+		pass := true
+		for _, cf := range fs.Filters {
+			if !cf.Match(stru) {
+				pass = false
+				break
+			}
+		}
+		if pass {
+			fs.Idxs = append(fs.Idxs, i)
+		}
+	}
+}
+
+// OrigIdx returns the index in Slice for given filtered row index -- rows
+// inserted at the end (idx < 0 or >= SrcLen) go after the last filtered row
+func (fs *TableFilterSource) OrigIdx(idx int) int {
+	if idx >= 0 && idx < len(fs.Idxs) {
+		return fs.Idxs[idx]
+	}
+	if idx >= 0 && len(fs.Idxs) > 0 {
+		return fs.Idxs[len(fs.Idxs)-1] + 1
+	}
+	return kit.NonPtrValue(reflect.ValueOf(fs.Slice)).Len()
+}
+
+func (fs *TableFilterSource) SrcLen() int {
+	return len(fs.Idxs)
+}
+
+func (fs *TableFilterSource) SrcRows(st, ed int) interface{} {
+	svnp := kit.NonPtrValue(reflect.ValueOf(fs.Slice))
+	ed = ints.MinInt(ed, len(fs.Idxs))
+	st = ints.MaxInt(0, ints.MinInt(st, ed))
+	sl := reflect.MakeSlice(svnp.Type(), ed-st, ed-st)
+	for i := st; i < ed; i++ {
+		sl.Index(i - st).Set(svnp.Index(fs.Idxs[i]))
+	}
+	return sl.Interface()
+}
+
+// rowVal returns the value to store in the slice for given row, which is a
+// pointer to a struct
+func (fs *TableFilterSource) rowVal(svnp reflect.Value, row interface{}) reflect.Value {
+	rv := reflect.ValueOf(row)
+	if svnp.Type().Elem().Kind() != reflect.Ptr {
+		rv = rv.Elem()
+	}
+	return rv
+}
+
+func (fs *TableFilterSource) SrcSetRow(idx int, row interface{}) error {
+	if idx < 0 || idx >= len(fs.Idxs) {
+		return fmt.Errorf("giv.TableFilterSource SrcSetRow: index out of range: %v", idx)
+	}
+	svnp := kit.NonPtrValue(reflect.ValueOf(fs.Slice))
+	svnp.Index(fs.Idxs[idx]).Set(fs.rowVal(svnp, row))
+	return nil
+}
+
+func (fs *TableFilterSource) SrcInsertRow(idx int, row interface{}) error {
+	svl := reflect.ValueOf(fs.Slice)
+	svnp := kit.NonPtrValue(svl)
+	oi := fs.OrigIdx(idx)
+	sz := svnp.Len()
+	svnp = reflect.Append(svnp, fs.rowVal(svnp, row))
+	if oi < sz {
+		reflect.Copy(svnp.Slice(oi+1, sz+1), svnp.Slice(oi, sz))
+		svnp.Index(oi).Set(fs.rowVal(svnp, row))
+	}
+	svl.Elem().Set(svnp)
+	// the new row stays visible even if it does not pass the filters
+	for i, si := range fs.Idxs {
+		if si >= oi {
+			fs.Idxs[i]++
+		}
+	}
+	if idx < 0 || idx > len(fs.Idxs) {
+		idx = len(fs.Idxs)
+	}
+	fs.Idxs = append(fs.Idxs, 0)
+	copy(fs.Idxs[idx+1:], fs.Idxs[idx:])
+	fs.Idxs[idx] = oi
+	return nil
+}
+
+func (fs *TableFilterSource) SrcDeleteRow(idx int) error {
+	if idx < 0 || idx >= len(fs.Idxs) {
+		return fmt.Errorf("giv.TableFilterSource SrcDeleteRow: index out of range: %v", idx)
+	}
+	oi := fs.Idxs[idx]
+	kit.SliceDeleteAt(fs.Slice, oi)
+	fs.Idxs = append(fs.Idxs[:idx], fs.Idxs[idx+1:]...)
+	for i, si := range fs.Idxs {
+		if si > oi {
+			fs.Idxs[i]--
+		}
+	}
+	return nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////
+//  Multi-key sorting
+
+// CompareValues compares two values for sorting, returning -1 if a < b, 0 if
+// equal, and 1 if a > b -- numbers (including types that convert to
+// numbers) are compared numerically, time.Time values chronologically, and
+// everything else as strings
+func CompareValues(a, b interface{}) int {
+	if at, ok := a.(time.Time); ok {
+		if bt, ok := b.(time.Time); ok {
+			switch {
+			case at.Before(bt):
+				return -1
+			case at.After(bt):
+				return 1
+			}
+			return 0
+		}
+	}
+	if _, ok := a.(string); !ok {
+		af, aok := kit.ToFloat(a)
+		bf, bok := kit.ToFloat(b)
+		if aok && bok {
+			switch {
+			case af < bf:
+				return -1
+			case af > bf:
+				return 1
+			}
+			return 0
+		}
+	}
+	return strings.Compare(kit.ToString(a), kit.ToString(b))
+}
+
+// StructSliceSortMulti sorts a slice of a struct according to the given
+// fields, in priority order -- fldIdxs are the field indexes and ascending
+// gives the direction for each field.  The sort is stable.
+func StructSliceSortMulti(struSlice interface{}, fldIdxs [][]int, ascending []bool) {
+	svnp := kit.NonPtrValue(reflect.ValueOf(struSlice))
+	if svnp.Len() == 0 || len(fldIdxs) == 0 {
+		return
+	}
+	sort.SliceStable(svnp.Interface(), func(i, j int) bool {
+		ival := kit.OnePtrUnderlyingValue(svnp.Index(i)).Elem()
+		jval := kit.OnePtrUnderlyingValue(svnp.Index(j)).Elem()
+		for si, fi := range fldIdxs {
+			cmp := CompareValues(ival.FieldByIndex(fi).Interface(), jval.FieldByIndex(fi).Interface())
+			if cmp == 0 {
+				continue
+			}
+			if ascending[si] {
+				return cmp < 0
+			}
+			return cmp > 0
+		}
+		return false
+	})
+}
+
+////////////////////////////////////////////////////////////////////////////////////////
+//  TableView columns
+
+// ColPrefsKey returns the key for the column state of the current struct
+// type in gi.Prefs.TableViews
+func (tv *TableView) ColPrefsKey() string {
+	return kit.LongTypeName(tv.StruType)
+}
+
+// LoadColPrefs sets the column state (Cols) for the current struct type to
+// the state saved in gi.Prefs.TableViews, or a blank state if none has been
+// saved or NoColPrefs -- the sorting and filters are only restored if
+// SortFilterPrefs is set, and otherwise start out empty
+func (tv *TableView) LoadColPrefs() {
+	tv.colsType = tv.StruType
+	if !tv.NoColPrefs {
+		if cp, has := gi.Prefs.TableViews[tv.ColPrefsKey()]; has && cp != nil {
+			if tv.SortFilterPrefs {
+				tv.Cols = cp.Copy()
+				if tv.HasFilters() {
+					tv.ShowFilters = true
+				}
+			} else {
+				tv.Cols = cp.Layout()
+			}
+			return
+		}
+	}
+	tv.Cols = &gi.TableViewPrefs{}
+}
+
+// SaveColPrefs saves the current column state, including the sorting and
+// filters, to gi.Prefs.TableViews, unless NoColPrefs is set -- called
+// automatically whenever it is changed.  The prefs are only marked as
+// changed, and are written to their own file when a window is closed, or on
+// an explicit save.
+func (tv *TableView) SaveColPrefs() {
+	if tv.NoColPrefs || tv.Cols == nil || tv.StruType == nil {
+		return
+	}
+	if gi.Prefs.TableViews == nil {
+		gi.Prefs.TableViews = make(map[string]*gi.TableViewPrefs)
+	}
+	gi.Prefs.TableViews[tv.ColPrefsKey()] = tv.Cols.Copy()
+	gi.Prefs.TableViewsChanged = true
+}
+
+// ColOrder returns the indexes into AllFields of all the columns, including
+// hidden ones, in display order according to Cols.Order
+func (tv *TableView) ColOrder() []int {
+	nf := len(tv.AllFields)
+	order := make([]int, 0, nf)
+	used := make([]bool, nf)
+	for _, nm := range tv.Cols.Order {
+		for i := range tv.AllFields {
+			if !used[i] && tv.AllFields[i].Name == nm {
+				used[i] = true
+				order = append(order, i)
+				break
+			}
+		}
+	}
+	for i := range tv.AllFields {
+		if !used[i] {
+			order = append(order, i)
+		}
+	}
+	return order
+}
+
+// OrderVisFields sets VisFields and VisIdxs from AllFields, according to
+// the order and hidden columns in Cols
+func (tv *TableView) OrderVisFields() {
+	nf := len(tv.AllFields)
+	tv.VisFields = make([]reflect.StructField, 0, nf)
+	tv.VisIdxs = make([]int, 0, nf)
+	for _, i := range tv.ColOrder() {
+		if !tv.Cols.IsHidden(tv.AllFields[i].Name) {
+			tv.VisFields = append(tv.VisFields, tv.AllFields[i])
+			tv.VisIdxs = append(tv.VisIdxs, i)
+		}
+	}
+	if len(tv.VisFields) == 0 && nf > 0 { // never hide everything
+		tv.Cols.Hidden = nil
+		tv.OrderVisFields()
+		return
+	}
+	tv.NVisFields = len(tv.VisFields)
+}
+
+// ReConfigCols rebuilds the header and grid after a change in the columns
+func (tv *TableView) ReConfigCols() {
+	wupdt := tv.TopUpdateStart()
+	defer tv.TopUpdateEnd(wupdt)
+
+	updt := tv.UpdateStart()
+	tv.Values = nil
+	tv.SetFullReRender()
+	tv.Config()
+	tv.UpdateEnd(updt)
+}
+
+// MoveColAction moves the column at visible field index from to index to,
+// and updates the display
+func (tv *TableView) MoveColAction(from, to int) {
+	if from == to || from < 0 || to < 0 || from >= tv.NVisFields || to >= tv.NVisFields {
+		return
+	}
+	mv := tv.VisFields[from].Name
+	trg := tv.VisFields[to].Name
+	order := make([]string, 0, len(tv.AllFields))
+	for _, i := range tv.ColOrder() { // includes hidden, which keep their place
+		if nm := tv.AllFields[i].Name; nm != mv {
+			order = append(order, nm)
+		}
+	}
+	for i, nm := range order {
+		if nm == trg {
+			if from < to {
+				i++ // after target when moving right
+			}
+			order = append(order, "")
+			copy(order[i+1:], order[i:])
+			order[i] = mv
+			break
+		}
+	}
+	tv.Cols.Order = order
+	tv.SaveColPrefs()
+	tv.ReConfigCols()
+}
+
+// SetColHiddenAction sets whether the column for given field is hidden, and
+// updates the display -- the last visible column cannot be hidden
+func (tv *TableView) SetColHiddenAction(fld string, hide bool) {
+	if hide && tv.NVisFields <= 1 {
+		return
+	}
+	tv.Cols.SetHidden(fld, hide)
+	tv.SaveColPrefs()
+	tv.ReConfigCols()
+}
+
+// ResetColsAction resets the column order, visibility and widths to the
+// defaults, keeping sorting and filters
+func (tv *TableView) ResetColsAction() {
+	tv.Cols.Order = nil
+	tv.Cols.Hidden = nil
+	tv.Cols.Widths = nil
+	tv.SaveColPrefs()
+	tv.ReConfigCols()
+}
+
+// SetColWidthProps sets the width properties of given widget to the width
+// set for given field by resizing, if any
+func (tv *TableView) SetColWidthProps(widg gi.Node2D, fld string) {
+	wd, has := tv.Cols.Widths[fld]
+	if !has {
+		return
+	}
+	nb := widg.AsNode2D()
+	nb.SetMinPrefWidth(units.NewValue(wd, units.Dot))
+	nb.SetProp("max-width", units.NewValue(wd, units.Dot))
+}
+
+// SetColWidth sets the width of the column at given visible field index, in
+// dots, and updates the display
+func (tv *TableView) SetColWidth(fli int, wd float32) {
+	if fli < 0 || fli >= tv.NVisFields {
+		return
+	}
+	wd = math32.Max(wd, TableViewMinColWidth)
+	fld := tv.VisFields[fli].Name
+	if tv.Cols.Widths == nil {
+		tv.Cols.Widths = make(map[string]float32)
+	}
+	tv.Cols.Widths[fld] = wd
+
+	wupdt := tv.TopUpdateStart()
+	defer tv.TopUpdateEnd(wupdt)
+
+	updt := tv.UpdateStart()
+	sg := tv.SliceGrid()
+	nWidgPerRow, idxOff := tv.RowWidgetNs()
+	for cidx := idxOff + fli; cidx < sg.NumChildren(); cidx += nWidgPerRow {
+		if widg, ok := sg.Kids[cidx].(gi.Node2D); ok {
+			tv.SetColWidthProps(widg, fld)
+		}
+	}
+	tv.SetFullReRender()
+	tv.UpdateEnd(updt)
+}
+
+// ColWidth returns the current allocated width of the column at given
+// visible field index, in dots
+func (tv *TableView) ColWidth(fli int) float32 {
+	_, idxOff := tv.RowWidgetNs()
+	sg := tv.SliceGrid()
+	gd := sg.GridData[gi.Col]
+	ci := idxOff + fli
+	if ci < 0 || ci >= len(gd) {
+		return 0
+	}
+	return gd[ci].AllocSize - sg.Spacing.Dots
+}
+
+////////////////////////////////////////////////////////////////////////////////////////
+//  Sorting
+
+// SortKeyIdx returns the priority of given field in the sort keys (0 =
+// primary), and whether it is descending -- -1 if not sorting on the field
+func (tv *TableView) SortKeyIdx(fld string) (int, bool) {
+	for i, sk := range tv.Cols.Sort {
+		spnm := strings.Split(sk, ":")
+		if spnm[0] == fld {
+			return i, len(spnm) == 2 && spnm[1] == "down"
+		}
+	}
+	return -1, false
+}
+
+// sortKey returns the sort key string for given field and direction
+func sortKey(fld string, desc bool) string {
+	if desc {
+		return fld + ":down"
+	}
+	return fld + ":up"
+}
+
+// UpdateSortIdx sets SortIdx and SortDesc from the primary sort key
+func (tv *TableView) UpdateSortIdx() {
+	tv.SortIdx = -1
+	tv.SortDesc = false
+	if len(tv.Cols.Sort) == 0 {
+		return
+	}
+	spnm := strings.Split(tv.Cols.Sort[0], ":")
+	for fli, fld := range tv.VisFields {
+		if fld.Name == spnm[0] {
+			tv.SortIdx = fli
+			tv.SortDesc = len(spnm) == 2 && spnm[1] == "down"
+			return
+		}
+	}
+}
+
+// SortFields returns the field indexes and directions of the sort keys,
+// skipping any that are not fields of the struct type
+func (tv *TableView) SortFields() (fldIdxs [][]int, ascending []bool) {
+	for _, sk := range tv.Cols.Sort {
+		spnm := strings.Split(sk, ":")
+		fld, ok := tv.StruType.FieldByName(spnm[0])
+		if !ok {
+			continue
+		}
+		fldIdxs = append(fldIdxs, fld.Index)
+		ascending = append(ascending, !(len(spnm) == 2 && spnm[1] == "down"))
+	}
+	return
+}
+
+// ConfigHeaderSort sets the header labels and icons to indicate the sort
+// keys and directions -- secondary keys show their priority
+func (tv *TableView) ConfigHeaderSort() {
+	sgh := tv.SliceHeader()
+	_, idxOff := tv.RowWidgetNs()
+	nsk := len(tv.Cols.Sort)
+	for fli := 0; fli < tv.NVisFields; fli++ {
+		fld := tv.VisFields[fli]
+		hdr := sgh.Child(idxOff + fli).(*gi.Action)
+		pri, desc := tv.SortKeyIdx(fld.Name)
+		switch {
+		case pri < 0:
+			hdr.SetIcon("none")
+			hdr.SetText(fld.Name)
+		case desc:
+			hdr.SetIcon("wedge-down")
+		default:
+			hdr.SetIcon("wedge-up")
+		}
+		if pri >= 0 {
+			if nsk > 1 {
+				hdr.SetText(fmt.Sprintf("%v %d", fld.Name, pri+1))
+			} else {
+				hdr.SetText(fld.Name)
+			}
+		}
+	}
+}
+
+// SortColsAction sorts the slice according to the current sort keys, saves
+// them with the column prefs, and updates the display
+func (tv *TableView) SortColsAction() {
+	tv.SaveColPrefs()
+	oswin.TheApp.Cursor(tv.ParentWindow().OSWin).Push(cursor.Wait)
+	defer oswin.TheApp.Cursor(tv.ParentWindow().OSWin).Pop()
+
+	wupdt := tv.TopUpdateStart()
+	defer tv.TopUpdateEnd(wupdt)
+
+	updt := tv.UpdateStart()
+	tv.SliceHeader().SetFullReRender()
+	tv.UpdateSortIdx()
+	tv.ConfigHeaderSort()
+	tv.SortSlice()
+	tv.UpdateSliceGrid()
+	tv.UpdateEnd(updt)
+}
+
+// AddSortSliceAction adds the field at given index as the lowest-priority
+// sort key, or toggles its direction if it is already a sort key
+func (tv *TableView) AddSortSliceAction(fldIdx int) {
+	if fldIdx < 0 || fldIdx >= tv.NVisFields {
+		return
+	}
+	fld := tv.VisFields[fldIdx].Name
+	pri, desc := tv.SortKeyIdx(fld)
+	if pri >= 0 {
+		tv.Cols.Sort[pri] = sortKey(fld, !desc)
+	} else {
+		tv.Cols.Sort = append(tv.Cols.Sort, sortKey(fld, false))
+	}
+	tv.SortColsAction()
+}
+
+// RemoveSortKeyAction removes the field at given index from the sort keys
+func (tv *TableView) RemoveSortKeyAction(fldIdx int) {
+	if fldIdx < 0 || fldIdx >= tv.NVisFields {
+		return
+	}
+	pri, _ := tv.SortKeyIdx(tv.VisFields[fldIdx].Name)
+	if pri < 0 {
+		return
+	}
+	tv.Cols.Sort = append(tv.Cols.Sort[:pri], tv.Cols.Sort[pri+1:]...)
+	tv.SortColsAction()
+}
+
+// SetSortAction sorts by only the field at given index, in given direction
+func (tv *TableView) SetSortAction(fldIdx int, desc bool) {
+	if fldIdx < 0 || fldIdx >= tv.NVisFields {
+		return
+	}
+	tv.Cols.Sort = []string{sortKey(tv.VisFields[fldIdx].Name, desc)}
+	tv.SortColsAction()
+}
+
+// ClearSortAction removes all sort keys -- the slice keeps its current order
+func (tv *TableView) ClearSortAction() {
+	tv.Cols.Sort = nil
+	tv.SortColsAction()
+}
+
+////////////////////////////////////////////////////////////////////////////////////////
+//  Filtering
+
+// HasFilters returns true if any column filters are set
+func (tv *TableView) HasFilters() bool {
+	if tv.Cols == nil {
+		return false
+	}
+	for _, expr := range tv.Cols.Filters {
+		if expr != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// SetColFilter sets the filter expression for given field (see
+// TableColFilter for syntax), and applies the filters
+func (tv *TableView) SetColFilter(fld, expr string) {
+	if tv.Cols.Filters[fld] == expr {
+		return
+	}
+	if tv.Cols.Filters == nil {
+		tv.Cols.Filters = make(map[string]string)
+	}
+	if expr == "" {
+		delete(tv.Cols.Filters, fld)
+	} else {
+		tv.Cols.Filters[fld] = expr
+	}
+	tv.SaveColPrefs()
+	tv.ApplyFilters()
+}
+
+// ClearFiltersAction clears all the column filters
+func (tv *TableView) ClearFiltersAction() {
+	tv.Cols.Filters = nil
+	tv.SaveColPrefs()
+	tv.ApplyFilters()
+	if tv.ShowFilters {
+		tv.ReConfigCols()
+	}
+}
+
+// SetShowFiltersAction sets whether the filter row is shown
+func (tv *TableView) SetShowFiltersAction(show bool) {
+	tv.ShowFilters = show
+	tv.ReConfigCols()
+}
+
+// ApplyFilters applies the current column filters: when viewing a slice,
+// the view switches to a TableFilterSource showing the rows that pass the
+// filters, and back to the slice itself when there are no filters.  When
+// viewing a Src, the filters are passed on to it if it implements
+// SliceSourceColFilterer.
+func (tv *TableView) ApplyFilters() {
+	if tv.Src != nil && (tv.FiltSrc == nil || tv.Src != SliceSource(tv.FiltSrc)) {
+		if cf, ok := tv.Src.(SliceSourceColFilterer); ok {
+			cf.SrcColFilters(tv.Cols.Filters)
+			tv.StartIdx = 0
+			tv.ResetSelectedIdxs()
+			tv.SrcRefresh()
+		}
+		return
+	}
+	if !tv.HasFilters() {
+		if tv.FiltSrc != nil {
+			sl := tv.FiltSrc.Slice
+			tv.FiltSrc = nil
+			tv.Src = nil
+			tv.Slice = nil
+			tv.inFiltSet = true // keeps the sort keys
+			tv.SetSlice(sl)
+			tv.inFiltSet = false
+		}
+		return
+	}
+	if tv.FiltSrc == nil {
+		if kit.IfaceIsNil(tv.Slice) {
+			return
+		}
+		tv.FiltSrc = NewTableFilterSource(tv.Slice)
+		tv.FiltSrc.SetFilters(tv.StruType, tv.Cols.Filters)
+		tv.inFiltSet = true
+		tv.SetSource(tv.FiltSrc)
+		tv.inFiltSet = false
+		return
+	}
+	tv.FiltSrc.SetFilters(tv.StruType, tv.Cols.Filters)
+	tv.StartIdx = 0
+	tv.ResetSelectedIdxs()
+	tv.SrcRefresh()
+}
+
+// FilterBar returns the toolbar of filter fields below the header, or nil
+// if not showing filters
+func (tv *TableView) FilterBar() *gi.ToolBar {
+	fb := tv.SliceFrame().ChildByName("filters", 1)
+	if fb == nil {
+		return nil
+	}
+	return fb.(*gi.ToolBar)
+}
+
+// ConfigFilterBar configures the filter fields, one for each visible column
+func (tv *TableView) ConfigFilterBar() {
+	fb := tv.FilterBar()
+	if fb == nil {
+		return
+	}
+	fb.Lay = gi.LayoutHoriz
+	fb.SetProp("overflow", gi.OverflowHidden)
+	fb.SetProp("spacing", 0)
+	fcfg := kit.TypeAndNameList{}
+	if tv.ShowIndex {
+		fcfg.Add(gi.KiT_Label, "filt-idx")
+	}
+	for fli := 0; fli < tv.NVisFields; fli++ {
+		fcfg.Add(gi.KiT_TextField, "filt-"+tv.VisFields[fli].Name)
+	}
+	if !tv.IsInactive() {
+		fcfg.Add(gi.KiT_Label, "filt-add")
+		fcfg.Add(gi.KiT_Label, "filt-del")
+	}
+	fb.ConfigChildren(fcfg, ki.UniqueNames)
+	_, idxOff := tv.RowWidgetNs()
+	for fli := 0; fli < tv.NVisFields; fli++ {
+		fld := tv.VisFields[fli]
+		tf := fb.Child(idxOff + fli).(*gi.TextField)
+		tf.Placeholder = "filter"
+		tf.Tooltip = "filter " + fld.Name + " by: text (substring), /regexp/, or lo..hi range"
+		tf.SetText(tv.Cols.Filters[fld.Name])
+		tf.TextFieldSig.ConnectOnly(tv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+			if sig == int64(gi.TextFieldDone) {
+				tvv := recv.Embed(KiT_TableView).(*TableView)
+				tff := send.(*gi.TextField)
+				tvv.SetColFilter(strings.TrimPrefix(tff.Nm, "filt-"), tff.Text())
+			}
+		})
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////
+//  Header events
+
+// ColsMenu adds the column menu actions to given menu, for the column at
+// given visible field index (-1 = none): showing / hiding each column,
+// sorting, and filters
+func (tv *TableView) ColsMenu(fldIdx int, men *gi.Menu) {
+	for _, fld := range tv.AllFields {
+		ic := "checked-box"
+		if tv.Cols.IsHidden(fld.Name) {
+			ic = "unchecked-box"
+		}
+		men.AddAction(gi.ActOpts{Label: fld.Name, Icon: ic, Data: fld.Name, Tooltip: "show / hide this column"},
+			tv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+				tvv := recv.Embed(KiT_TableView).(*TableView)
+				fnm := data.(string)
+				tvv.SetColHiddenAction(fnm, !tvv.Cols.IsHidden(fnm))
+			})
+	}
+	men.AddAction(gi.ActOpts{Label: "Reset Columns", Tooltip: "restore the default column order, visibility and widths"},
+		tv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+			tvv := recv.Embed(KiT_TableView).(*TableView)
+			tvv.ResetColsAction()
+		})
+	men.AddSeparator("sep-sort")
+	if fldIdx >= 0 && fldIdx < tv.NVisFields {
+		men.AddAction(gi.ActOpts{Label: "Sort Ascending", Icon: "wedge-up", Data: fldIdx},
+			tv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+				tvv := recv.Embed(KiT_TableView).(*TableView)
+				tvv.SetSortAction(data.(int), false)
+			})
+		men.AddAction(gi.ActOpts{Label: "Sort Descending", Icon: "wedge-down", Data: fldIdx},
+			tv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+				tvv := recv.Embed(KiT_TableView).(*TableView)
+				tvv.SetSortAction(data.(int), true)
+			})
+		if pri, _ := tv.SortKeyIdx(tv.VisFields[fldIdx].Name); pri >= 0 {
+			men.AddAction(gi.ActOpts{Label: "Remove From Sort", Data: fldIdx},
+				tv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+					tvv := recv.Embed(KiT_TableView).(*TableView)
+					tvv.RemoveSortKeyAction(data.(int))
+				})
+		} else {
+			men.AddAction(gi.ActOpts{Label: "Add To Sort", Tooltip: "sort by this column after the current sort columns (or Shift+click the header)", Data: fldIdx},
+				tv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+					tvv := recv.Embed(KiT_TableView).(*TableView)
+					tvv.AddSortSliceAction(data.(int))
+				})
+		}
+	}
+	men.AddAction(gi.ActOpts{Label: "Clear Sort"},
+		tv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+			tvv := recv.Embed(KiT_TableView).(*TableView)
+			tvv.ClearSortAction()
+		})
+	men.AddSeparator("sep-filt")
+	flbl := "Show Filters"
+	if tv.ShowFilters {
+		flbl = "Hide Filters"
+	}
+	men.AddAction(gi.ActOpts{Label: flbl, Icon: "search"},
+		tv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+			tvv := recv.Embed(KiT_TableView).(*TableView)
+			tvv.SetShowFiltersAction(!tvv.ShowFilters)
+		})
+	if tv.HasFilters() {
+		men.AddAction(gi.ActOpts{Label: "Clear Filters"},
+			tv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+				tvv := recv.Embed(KiT_TableView).(*TableView)
+				tvv.ClearFiltersAction()
+			})
+	}
+}
+
+// HeaderColAt returns the visible field index of the header at given
+// window position, and whether the position is on its right edge (for
+// resizing) -- -1 if not on a field header
+func (tv *TableView) HeaderColAt(pos image.Point) (int, bool) {
+	sgh := tv.SliceHeader()
+	_, idxOff := tv.RowWidgetNs()
+	for fli := 0; fli < tv.NVisFields; fli++ {
+		hdr := sgh.Child(idxOff + fli).(gi.Node2D).AsWidget()
+		bb := hdr.WinBBox
+		if pos.Y < bb.Min.Y || pos.Y >= bb.Max.Y {
+			return -1, false
+		}
+		if ints.AbsInt(pos.X-bb.Max.X) <= TableViewResizeMargin {
+			return fli, true
+		}
+		if pos.X >= bb.Min.X && pos.X < bb.Max.X {
+			return fli, false
+		}
+	}
+	return -1, false
+}
+
+// HeaderMouseEvent handles mouse press / release on the header, for
+// resizing, reordering and the column menu
+func (tv *TableView) HeaderMouseEvent(me *mouse.Event) {
+	win := tv.ParentWindow()
+	switch {
+	case me.Button == mouse.Right && me.Action == mouse.Release:
+		me.SetProcessed()
+		fli, _ := tv.HeaderColAt(me.Where)
+		var men gi.Menu
+		tv.ColsMenu(fli, &men)
+		gi.PopupMenu(men, me.Where.X, me.Where.Y, tv.ViewportSafe(), tv.Nm+"-cols-menu")
+	case me.Button == mouse.Left && me.Action == mouse.Press:
+		fli, edge := tv.HeaderColAt(me.Where)
+		tv.dragCol = fli
+		tv.dragResize = edge
+		tv.dragging = false
+		if fli >= 0 && edge {
+			me.SetProcessed() // don't sort
+			tv.dragStX = me.Where.X
+			tv.dragStWd = tv.ColWidth(fli)
+		}
+	case me.Action == mouse.Release:
+		fli := tv.dragCol
+		tv.dragCol = -1
+		if !tv.dragging || fli < 0 {
+			return
+		}
+		me.SetProcessed() // don't sort
+		tv.dragging = false
+		if tv.dragResize {
+			oswin.TheApp.Cursor(win.OSWin).PopIf(cursor.LeftRight)
+			tv.SaveColPrefs()
+			return
+		}
+		oswin.TheApp.Cursor(win.OSWin).PopIf(cursor.HandClosed)
+		_, idxOff := tv.RowWidgetNs()
+		hdr := tv.SliceHeader().Child(idxOff + fli).(*gi.Action)
+		hdr.SetButtonState(gi.ButtonActive)
+		to, _ := tv.HeaderColAt(image.Point{me.Where.X, hdr.WinBBox.Min.Y})
+		if to < 0 {
+			if me.Where.X < tv.SliceHeader().WinBBox.Min.X {
+				to = 0
+			} else {
+				to = tv.NVisFields - 1
+			}
+		}
+		tv.MoveColAction(fli, to)
+	}
+}
+
+// HeaderDragEvent handles mouse drag on the header, for resizing and
+// reordering columns
+func (tv *TableView) HeaderDragEvent(me *mouse.DragEvent) {
+	if tv.dragCol < 0 {
+		return
+	}
+	me.SetProcessed()
+	win := tv.ParentWindow()
+	if tv.dragResize {
+		if !tv.dragging {
+			oswin.TheApp.Cursor(win.OSWin).PushIfNot(cursor.LeftRight)
+		}
+		tv.dragging = true
+		tv.SetColWidth(tv.dragCol, tv.dragStWd+float32(me.Where.X-tv.dragStX))
+		return
+	}
+	if !tv.dragging {
+		oswin.TheApp.Cursor(win.OSWin).PushIfNot(cursor.HandClosed)
+	}
+	tv.dragging = true
+}
+
+// HeaderMoveEvent shows the resize cursor when the mouse is over the edge
+// of a column header
+func (tv *TableView) HeaderMoveEvent(me *mouse.MoveEvent) {
+	if tv.dragging {
+		return
+	}
+	win := tv.ParentWindow()
+	if _, edge := tv.HeaderColAt(me.Where); edge {
+		oswin.TheApp.Cursor(win.OSWin).PushIfNot(cursor.LeftRight)
+	} else {
+		oswin.TheApp.Cursor(win.OSWin).PopIf(cursor.LeftRight)
+	}
+}
+
+// TableViewHeaderEvents connects to the mouse events on the header
+func (tv *TableView) TableViewHeaderEvents() {
+	if !tv.IsConfiged() || tv.SliceFrame().NumChildren() == 0 {
+		return
+	}
+	sgh := tv.SliceHeader()
+	sgh.ConnectEvent(oswin.MouseEvent, gi.HiPri, func(recv, send ki.Ki, sig int64, d interface{}) {
+		me := d.(*mouse.Event)
+		tvv := recv.ParentByType(KiT_TableView, ki.Embeds).Embed(KiT_TableView).(*TableView)
+		tvv.HeaderMouseEvent(me)
+	})
+	sgh.ConnectEvent(oswin.MouseDragEvent, gi.HiPri, func(recv, send ki.Ki, sig int64, d interface{}) {
+		me := d.(*mouse.DragEvent)
+		tvv := recv.ParentByType(KiT_TableView, ki.Embeds).Embed(KiT_TableView).(*TableView)
+		tvv.HeaderDragEvent(me)
+	})
+	sgh.ConnectEvent(oswin.MouseMoveEvent, gi.HiPri, func(recv, send ki.Ki, sig int64, d interface{}) {
+		me := d.(*mouse.MoveEvent)
+		tvv := recv.ParentByType(KiT_TableView, ki.Embeds).Embed(KiT_TableView).(*TableView)
+		tvv.HeaderMoveEvent(me)
+	})
+}
+
+// HeaderClickAction is called when a column header is clicked -- sorts by
+// the column, or adds it to the sort keys if Shift is held
+func (tv *TableView) HeaderClickAction(fldIdx int) {
+	if win := tv.ParentWindow(); win != nil && key.HasAnyModifierBits(win.EventMgr.LastModBits, key.Shift) {
+		tv.AddSortSliceAction(fldIdx)
+		return
+	}
+	tv.SortSliceAction(fldIdx)
+}
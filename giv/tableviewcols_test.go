@@ -0,0 +1,65 @@
+// Copyright (c) 2018, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package giv
+
+import (
+	"reflect"
+	"testing"
+)
+
+type colFilterTestRec struct {
+	Name  string
+	Age   int
+	Score float64
+	Code  string
+}
+
+func TestTableColFilterMatch(t *testing.T) {
+	rec := colFilterTestRec{Name: "Zoë Smith", Age: 42, Score: 7.5, Code: "42"}
+	typ := reflect.TypeOf(rec)
+	tests := []struct {
+		field, expr string
+		match       bool
+	}{
+		{"Name", "", true},
+		{"Name", "smith", true}, // case insensitive substring
+		{"Name", "ZOË", true},   // non-ascii case folding
+		{"Name", "jones", false},
+		{"Name", "/^Zo/", true},
+		{"Name", "/^zo/", false}, // regexp is case sensitive
+		{"Name", "/(?i)^zo/", true},
+		{"Name", "/", false}, // a lone slash is a substring, not a regexp
+		{"Age", "4", true},
+		{"Age", "40..50", true},
+		{"Age", "42..42", true}, // inclusive
+		{"Age", "43..", false},  // open high end
+		{"Age", "..42", true},   // open low end
+		{"Age", "5..100", true}, // numeric, not string, compare
+		{"Age", " 1 .. 9 ", false},
+		{"Score", "7..8", true},
+		{"Score", "7.6..", false},
+		{"Score", "/^7\\.5$/", true},
+		{"Code", "5..100", false}, // strings compare as strings
+		{"Code", "4..5", true},
+		{"Code", "..41", false},
+		{"Name", "A..Zz", true},
+		{"Name", "a..z", false},
+	}
+	for _, tt := range tests {
+		fld, _ := typ.FieldByName(tt.field)
+		cf, err := NewTableColFilter(fld, tt.expr)
+		if err != nil {
+			t.Errorf("%v %q: %v", tt.field, tt.expr, err)
+			continue
+		}
+		if m := cf.Match(reflect.ValueOf(rec)); m != tt.match {
+			t.Errorf("%v %q: Match = %v, want %v", tt.field, tt.expr, m, tt.match)
+		}
+	}
+	fld, _ := typ.FieldByName("Name")
+	if _, err := NewTableColFilter(fld, "/(/"); err == nil {
+		t.Errorf("NewTableColFilter with invalid regexp did not return an error")
+	}
+}
@@ -0,0 +1,244 @@
+// Copyright (c) 2018, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package giv
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/goki/gi/gi"
+	"github.com/goki/ki/kit"
+)
+
+//////////////////////////////////////////////////////////////////////////////
+//    Cells
+
+// NCellCols returns the number of visible fields, which are the columns of
+// cells for copying and pasting
+func (tv *TableView) NCellCols() int {
+	return tv.NVisFields
+}
+
+// CellField returns the field value for given slice index and visible field
+// index, along with the row value as returned by SliceVal
+func (tv *TableView) CellField(idx, col int) (reflect.Value, interface{}) {
+	if col < 0 || col >= tv.NVisFields {
+		return reflect.Value{}, nil
+	}
+	val := tv.SliceVal(idx)
+	if val == nil {
+		return reflect.Value{}, nil
+	}
+	stru := kit.NonPtrValue(reflect.ValueOf(val))
+	return stru.FieldByIndex(tv.VisFields[col].Index), val
+}
+
+// CellText returns the text of the cell at given slice index and visible field index
+func (tv *TableView) CellText(idx, col int) string {
+	fv, _ := tv.CellField(idx, col)
+	if !fv.IsValid() {
+		return ""
+	}
+	return CellValueText(fv)
+}
+
+// SetCellText sets the cell at given slice index and visible field index
+// from given text, returning an error if the text could not be converted
+func (tv *TableView) SetCellText(idx, col int, txt string) error {
+	fv, val := tv.CellField(idx, col)
+	if !fv.IsValid() {
+		return fmt.Errorf("cell %d, %d is out of range", idx, col)
+	}
+	if err := SetCellValueText(fv, txt); err != nil {
+		return fmt.Errorf("%v: %v", tv.VisFields[col].Name, err)
+	}
+	return tv.CellSaveRow(idx, val)
+}
+
+//////////////////////////////////////////////////////////////////////////////
+//    CSV import / export
+
+// CSVComma returns the field delimiter for given file name: '\t' for .tsv
+// files and ',' otherwise
+func CSVComma(filename string) rune {
+	if strings.ToLower(filepath.Ext(filename)) == ".tsv" {
+		return '\t'
+	}
+	return ','
+}
+
+// CSVFieldName returns the field name normalized for matching to CSV column
+// headers: lower case, without any spaces, underscores or dashes
+func CSVFieldName(nm string) string {
+	nm = strings.ToLower(strings.TrimSpace(nm))
+	return strings.NewReplacer(" ", "", "_", "", "-", "").Replace(nm)
+}
+
+// ExportCSV saves the rows of the table as viewed (i.e., sorted and filtered)
+// to given CSV file (or TSV, if the extension is .tsv), with a header row of
+// field names.  All the fields that can be shown are saved, including hidden
+// ones, in column order.
+func (tv *TableView) ExportCSV(filename gi.FileName) error {
+	if kit.IfaceIsNil(tv.Slice) {
+		return nil
+	}
+	f, err := os.Create(string(filename))
+	if err != nil {
+		gi.PromptDialog(tv.ViewportSafe(), gi.DlgOpts{Title: "Export CSV Error", Prompt: err.Error()}, gi.AddOk, gi.NoCancel, nil, nil)
+		return err
+	}
+	defer f.Close()
+	err = tv.WriteCSV(f, CSVComma(string(filename)))
+	if err != nil {
+		gi.PromptDialog(tv.ViewportSafe(), gi.DlgOpts{Title: "Export CSV Error", Prompt: err.Error()}, gi.AddOk, gi.NoCancel, nil, nil)
+	}
+	return err
+}
+
+// WriteCSV writes the rows of the table as viewed, with a header row, to
+// given writer using given field delimiter -- see ExportCSV
+func (tv *TableView) WriteCSV(w io.Writer, comma rune) error {
+	order := tv.ColOrder()
+	cw := csv.NewWriter(w)
+	cw.Comma = comma
+	rec := make([]string, len(order))
+	for i, fi := range order {
+		rec[i] = tv.AllFields[fi].Name
+	}
+	if err := cw.Write(rec); err != nil {
+		return err
+	}
+	rows := tv.SliceNPVal
+	if tv.Src != nil {
+		rows = reflect.ValueOf(tv.Src.SrcRows(0, tv.Src.SrcLen()))
+	}
+	tv.ViewMuLock()
+	for ri := 0; ri < rows.Len(); ri++ {
+		stru := kit.NonPtrValue(rows.Index(ri))
+		for i, fi := range order {
+			rec[i] = CellValueText(stru.FieldByIndex(tv.AllFields[fi].Index))
+		}
+		if err := cw.Write(rec); err != nil {
+			tv.ViewMuUnlock()
+			return err
+		}
+	}
+	tv.ViewMuUnlock()
+	cw.Flush()
+	return cw.Error()
+}
+
+// ImportCSV appends rows to the table from given CSV file (or TSV, if the
+// extension is .tsv), which must have a header row: columns are assigned to
+// fields by matching header names to field names, ignoring case, spaces,
+// underscores and dashes, and columns that do not match any field that can be
+// shown (e.g., view:"-" or tableview:"-" fields) are skipped.  Values that
+// cannot be converted to the field type are reported per cell.
+func (tv *TableView) ImportCSV(filename gi.FileName) error {
+	if kit.IfaceIsNil(tv.Slice) {
+		return nil
+	}
+	f, err := os.Open(string(filename))
+	if err != nil {
+		gi.PromptDialog(tv.ViewportSafe(), gi.DlgOpts{Title: "Import CSV Error", Prompt: err.Error()}, gi.AddOk, gi.NoCancel, nil, nil)
+		return err
+	}
+	defer f.Close()
+	errs, err := tv.ReadCSV(f, CSVComma(string(filename)))
+	if err != nil {
+		gi.PromptDialog(tv.ViewportSafe(), gi.DlgOpts{Title: "Import CSV Error", Prompt: err.Error()}, gi.AddOk, gi.NoCancel, nil, nil)
+		return err
+	}
+	tv.CellErrorsDialog("Import CSV Errors", errs)
+	return nil
+}
+
+// ReadCSV appends rows to the table from given reader of CSV data with a
+// header row, using given field delimiter -- returns the per-cell conversion
+// errors, and any error reading the data -- see ImportCSV
+func (tv *TableView) ReadCSV(r io.Reader, comma rune) ([]string, error) {
+	if _, ok := tv.Src.(SliceSourceEditor); tv.Src != nil && !ok {
+		return nil, fmt.Errorf("cannot import rows into a source that is not editable")
+	}
+	cr := csv.NewReader(r)
+	cr.Comma = comma
+	cr.FieldsPerRecord = -1
+	cr.LazyQuotes = true
+	hdr, err := cr.Read()
+	if err != nil {
+		return nil, err
+	}
+	flds := make([]*reflect.StructField, len(hdr))
+	for ci, hd := range hdr {
+		hd = CSVFieldName(hd)
+		for fi := range tv.AllFields {
+			if CSVFieldName(tv.AllFields[fi].Name) == hd {
+				flds[ci] = &tv.AllFields[fi]
+				break
+			}
+		}
+	}
+	ptrs := kit.SliceElType(tv.Slice).Kind() == reflect.Ptr
+	var rows []interface{}
+	var errs []string
+	for ln := 2; ; ln++ {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return errs, err
+		}
+		nv := reflect.New(tv.StruType)
+		for ci, cv := range rec {
+			if ci >= len(flds) || flds[ci] == nil {
+				continue
+			}
+			if err := SetCellValueText(nv.Elem().FieldByIndex(flds[ci].Index), cv); err != nil {
+				errs = append(errs, fmt.Sprintf("line %d, %v: %v", ln, flds[ci].Name, err))
+			}
+		}
+		if ptrs || tv.Src != nil {
+			rows = append(rows, nv.Interface())
+		} else {
+			rows = append(rows, nv.Elem().Interface())
+		}
+	}
+	if len(rows) == 0 {
+		return errs, nil
+	}
+	if tv.Src != nil {
+		tv.SrcInsertRows(-1, rows)
+		tv.SetChanged()
+		tv.SrcRefresh()
+		return errs, nil
+	}
+	wupdt := tv.TopUpdateStart()
+	defer tv.TopUpdateEnd(wupdt)
+	updt := tv.UpdateStart()
+	defer tv.UpdateEnd(updt)
+	tv.ViewMuLock()
+	svnp := tv.SliceNPVal
+	for _, rw := range rows {
+		svnp = reflect.Append(svnp, reflect.ValueOf(rw))
+	}
+	reflect.ValueOf(tv.Slice).Elem().Set(svnp)
+	tv.SliceNPVal = kit.NonPtrValue(reflect.ValueOf(tv.Slice))
+	tv.ViewMuUnlock()
+	if tv.TmpSave != nil {
+		tv.TmpSave.SaveTmp()
+	}
+	tv.SetChanged()
+	tv.ScrollBar().SetFullReRender()
+	tv.This().(SliceViewer).LayoutSliceGrid()
+	tv.This().(SliceViewer).UpdateSliceGrid()
+	tv.ViewSig.Emit(tv.This(), 0, nil)
+	return errs, nil
+}
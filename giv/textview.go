@@ -9,6 +9,7 @@ import (
 	"image"
 	"image/draw"
 	"log"
+	"path/filepath"
 	"strings"
 	"sync"
 	"time"
@@ -4826,6 +4827,22 @@ func (tv *TextView) ConnectEvents2D() {
 	tv.TextViewEvents()
 }
 
+// AccessInfo2D exposes the line at the cursor as the value, as the whole
+// text of the buffer can be arbitrarily large
+func (tv *TextView) AccessInfo2D(ai *gi.AccessInfo) {
+	tv.WidgetBase.AccessInfo2D(ai)
+	ai.Role = gi.AccessTextArea
+	ai.SetState(!tv.IsInactive(), gi.AccessEditable)
+	ai.SetState(true, gi.AccessMultiLine)
+	if tv.Buf == nil {
+		return
+	}
+	ai.Label = filepath.Base(string(tv.Buf.Filename))
+	if tv.CursorPos.Ln < tv.Buf.NumLines() {
+		ai.Value = string(tv.Buf.Line(tv.CursorPos.Ln))
+	}
+}
+
 // FocusChanged2D appropriate actions for various types of focus changes
 func (tv *TextView) FocusChanged2D(change gi.FocusChanges) {
 	switch change {
@@ -0,0 +1,331 @@
+// Copyright (c) 2018, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package giv
+
+import (
+	"bytes"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/goki/gi/gi"
+	"github.com/goki/gi/oswin/mimedata"
+	"github.com/goki/ki/bitflag"
+	"github.com/goki/ki/ki"
+	"github.com/goki/pi/filecat"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////
+//  TreeSource
+
+// TreeSource is the data-source interface used by VirtTreeView to lazily
+// fetch the structure of a tree, so that a view never needs to materialize
+// any more of the tree than the parts the user has actually opened.  Items
+// are opaque values supplied by the source, and must be comparable (usable
+// as map keys) -- typically a pointer (e.g., ki.Ki) or a string path.
+type TreeSource interface {
+	// TreeRoot returns the root item of the tree
+	TreeRoot() interface{}
+
+	// TreeHasChildren returns true if given item has (or may have) children
+	// -- this is called for every visible row and should be cheap to compute
+	TreeHasChildren(item interface{}) bool
+
+	// TreeChildren returns the children of given item -- it is only called
+	// when the item is opened, and the result is cached by the view until
+	// it is invalidated via VirtTreeView.UpdateItem or Refresh
+	TreeChildren(item interface{}) []interface{}
+
+	// TreeLabel returns the text label to display for given item
+	TreeLabel(item interface{}) string
+}
+
+// TreeSourceNotifier is an optional interface for a TreeSource that can
+// notify the view when the children of a given item have changed -- the view
+// calls TreeSetNotify when the source is set, passing itself as a receiver
+// for any signal connections, and the function to call with the changed item.
+// A nil item means that only the labels or styles of items have changed, so
+// the view just needs to be redrawn.
+type TreeSourceNotifier interface {
+	TreeSetNotify(recv ki.Ki, fun func(item interface{}))
+}
+
+// TreeSourceOpener is an optional interface for a TreeSource that keeps its
+// own record of which items are open, e.g., to only read the contents of
+// directories when they are opened.  The view calls TreeIsOpen to get the
+// open state of items when the source is set, and TreeSetOpen whenever it
+// opens or closes an item, before getting its children.
+type TreeSourceOpener interface {
+	TreeIsOpen(item interface{}) bool
+	TreeSetOpen(item interface{}, open bool)
+}
+
+// TreeSourceStyler is an optional interface for a TreeSource that styles
+// the labels of its items, e.g., with a color indicating their status.
+type TreeSourceStyler interface {
+	// TreeStyle sets the font style used to render the label of given item,
+	// which starts out as a copy of the style of the view -- the font is
+	// re-opened by the view if the style or weight are changed
+	TreeStyle(item interface{}, fs *gi.FontStyle)
+}
+
+// TreeSourceEditor is an optional interface for a TreeSource that supports
+// copy / paste and drag-n-drop editing of its structure.  If not implemented
+// the view is effectively read-only, supporting selection and navigation.
+type TreeSourceEditor interface {
+	// TreeMimeData adds mime data for given item to md -- the first record
+	// is used to identify the item when it is dragged (see TreeDragged)
+	TreeMimeData(item interface{}, md *mimedata.Mimes)
+
+	// TreePasteAt inserts item(s) from mime data at rel position relative to
+	// given item: 0 = before, 1 = after -- returns the last inserted item, or nil
+	TreePasteAt(md mimedata.Mimes, item interface{}, rel int) interface{}
+
+	// TreePasteChildren adds item(s) from mime data to the end of the
+	// children of given item -- returns the last inserted item, or nil
+	TreePasteChildren(md mimedata.Mimes, item interface{}) interface{}
+
+	// TreeDelete deletes given items from the source
+	TreeDelete(items []interface{})
+
+	// TreeDragged is called on the source of a drag-n-drop Move after the
+	// target has accepted the drop, to remove the items that were moved
+	TreeDragged(md mimedata.Mimes)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////
+//  KiTreeSource
+
+// KiTreeSource is a TreeSource for a Ki tree, providing the same view of the
+// tree as the standard TreeView (including fields), but only visiting the
+// nodes that are actually opened in the view.
+type KiTreeSource struct {
+	Root   ki.Ki                  `desc:"root of the tree being viewed"`
+	recv   ki.Ki                  `desc:"receiver for node signals"`
+	notify func(item interface{}) `desc:"function called when node structure changes"`
+}
+
+// NewKiTreeSource returns a new KiTreeSource for given root node
+func NewKiTreeSource(root ki.Ki) *KiTreeSource {
+	return &KiTreeSource{Root: root}
+}
+
+func (ks *KiTreeSource) TreeRoot() interface{} {
+	return ks.Root
+}
+
+func (ks *KiTreeSource) TreeHasChildren(item interface{}) bool {
+	k := item.(ki.Ki)
+	if k.HasChildren() {
+		return true
+	}
+	return len(k.AsNode().KiFieldOffs()) > 0
+}
+
+func (ks *KiTreeSource) TreeChildren(item interface{}) []interface{} {
+	k := item.(ki.Ki)
+	kids := make([]interface{}, 0, k.NumChildren())
+	k.FuncFields(0, nil, func(fk ki.Ki, level int, d interface{}) bool {
+		kids = append(kids, fk)
+		return ki.Continue
+	})
+	for _, kid := range *k.Children() {
+		kids = append(kids, kid)
+	}
+	if ks.recv != nil {
+		k.NodeSignal().Connect(ks.recv, func(recv, send ki.Ki, sig int64, data interface{}) {
+			if data == nil || ks.notify == nil {
+				return
+			}
+			dflags := data.(int64)
+			if bitflag.HasAnyMask(dflags, int64(ki.StruUpdateFlagsMask)) {
+				ks.notify(send)
+			} else {
+				ks.notify(nil) // labels may have changed
+			}
+		})
+	}
+	return kids
+}
+
+func (ks *KiTreeSource) TreeLabel(item interface{}) string {
+	k := item.(ki.Ki)
+	if lbl, has := gi.ToLabeler(k); has {
+		return lbl
+	}
+	return k.Name()
+}
+
+func (ks *KiTreeSource) TreeSetNotify(recv ki.Ki, fun func(item interface{})) {
+	ks.recv = recv
+	ks.notify = fun
+}
+
+func (ks *KiTreeSource) TreeMimeData(item interface{}, md *mimedata.Mimes) {
+	src := item.(ki.Ki)
+	*md = append(*md, mimedata.NewTextData(src.PathFromUnique(ks.Root)))
+	var buf bytes.Buffer
+	err := src.WriteJSON(&buf, ki.Indent)
+	if err == nil {
+		*md = append(*md, &mimedata.Data{Type: filecat.DataJson, Data: buf.Bytes()})
+	} else {
+		log.Printf("giv.KiTreeSource TreeMimeData SaveJSON error: %v\n", err)
+	}
+}
+
+// NodesFromMimeData creates a slice of Ki node(s) from given mime data
+func (ks *KiTreeSource) NodesFromMimeData(md mimedata.Mimes) ki.Slice {
+	sl := make(ki.Slice, 0, len(md)/2)
+	for _, d := range md {
+		if d.Type == filecat.DataJson {
+			nki, err := ki.ReadNewJSON(bytes.NewReader(d.Data))
+			if err == nil {
+				sl = append(sl, nki)
+			} else {
+				log.Printf("giv.KiTreeSource NodesFromMimeData: JSON load error: %v\n", err)
+			}
+		}
+	}
+	return sl
+}
+
+func (ks *KiTreeSource) TreePasteAt(md mimedata.Mimes, item interface{}, rel int) interface{} {
+	sk := item.(ki.Ki)
+	par := sk.Parent()
+	if par == nil || sk.IsField() {
+		return nil
+	}
+	myidx, ok := sk.IndexInParent()
+	if !ok {
+		return nil
+	}
+	myidx += rel
+	sl := ks.NodesFromMimeData(md)
+	var last ki.Ki
+	updt := par.UpdateStart()
+	for i, ns := range sl {
+		if cn := par.ChildByName(ns.Name(), 0); cn != nil {
+			ns.SetName(ns.Name() + "_Copy")
+		}
+		par.InsertChild(ns, myidx+i)
+		last = ns
+	}
+	par.UpdateEnd(updt)
+	if last == nil {
+		return nil
+	}
+	return last
+}
+
+func (ks *KiTreeSource) TreePasteChildren(md mimedata.Mimes, item interface{}) interface{} {
+	sk := item.(ki.Ki)
+	sl := ks.NodesFromMimeData(md)
+	var last ki.Ki
+	updt := sk.UpdateStart()
+	for _, ns := range sl {
+		sk.AddChild(ns)
+		last = ns
+	}
+	sk.UpdateEnd(updt)
+	if last == nil {
+		return nil
+	}
+	return last
+}
+
+func (ks *KiTreeSource) TreeDelete(items []interface{}) {
+	for _, it := range items {
+		sk := it.(ki.Ki)
+		if sk == ks.Root || sk.IsField() {
+			continue
+		}
+		sk.Delete(true)
+	}
+}
+
+func (ks *KiTreeSource) TreeDragged(md mimedata.Mimes) {
+	for _, d := range md {
+		if d.Type == filecat.TextPlain { // link
+			path := string(d.Data)
+			sn := ks.Root.FindPathUnique(path)
+			if sn != nil {
+				sn.Delete(true)
+			}
+		}
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////
+//  DirTreeSource
+
+// DirTreeSource is a read-only TreeSource for a file system directory, where
+// items are the full path strings -- directories are only read when they are
+// opened in the view, so it scales to arbitrarily large directory trees,
+// unlike FileTree which reads the entire structure of all open directories.
+type DirTreeSource struct {
+	Root       string          `desc:"root path of the tree"`
+	ShowHidden bool            `desc:"if true, show files and directories that start with a ."`
+	DirsOnTop  bool            `desc:"if true, directories are listed before files"`
+	isDir      map[string]bool `desc:"cache of whether each path that has been listed is a directory"`
+}
+
+// NewDirTreeSource returns a new DirTreeSource for given root path, with
+// directories listed on top.
+func NewDirTreeSource(root string) *DirTreeSource {
+	ds := &DirTreeSource{Root: root, DirsOnTop: true}
+	ds.isDir = make(map[string]bool)
+	ds.isDir[root] = true
+	return ds
+}
+
+func (ds *DirTreeSource) TreeRoot() interface{} {
+	return ds.Root
+}
+
+func (ds *DirTreeSource) TreeHasChildren(item interface{}) bool {
+	path := item.(string)
+	if isd, has := ds.isDir[path]; has {
+		return isd
+	}
+	fi, err := os.Stat(path)
+	isd := err == nil && fi.IsDir()
+	ds.isDir[path] = isd
+	return isd
+}
+
+func (ds *DirTreeSource) TreeChildren(item interface{}) []interface{} {
+	path := item.(string)
+	files, err := ioutil.ReadDir(path)
+	if err != nil {
+		log.Printf("giv.DirTreeSource: %v\n", err)
+		return nil
+	}
+	if ds.DirsOnTop {
+		sort.SliceStable(files, func(i, j int) bool {
+			return files[i].IsDir() && !files[j].IsDir()
+		})
+	}
+	kids := make([]interface{}, 0, len(files))
+	for _, fi := range files {
+		if !ds.ShowHidden && strings.HasPrefix(fi.Name(), ".") {
+			continue
+		}
+		fp := filepath.Join(path, fi.Name())
+		ds.isDir[fp] = fi.IsDir()
+		kids = append(kids, fp)
+	}
+	return kids
+}
+
+func (ds *DirTreeSource) TreeLabel(item interface{}) string {
+	path := item.(string)
+	if path == ds.Root {
+		return path
+	}
+	return filepath.Base(path)
+}
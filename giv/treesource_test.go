@@ -0,0 +1,72 @@
+// Copyright (c) 2018, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package giv
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/goki/gi/gi"
+)
+
+// testIconMgr stands in for the svg icon manager, which imports giv
+type testIconMgr struct{}
+
+func (im *testIconMgr) IsValid(iconName string) bool               { return false }
+func (im *testIconMgr) SetIcon(ic *gi.Icon, iconName string) error { return nil }
+func (im *testIconMgr) IconList(alphaSort bool) []gi.IconName      { return nil }
+
+func TestFileTreeSourceOpen(t *testing.T) {
+	dir, err := ioutil.TempDir("", "filetreesrc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if gi.TheIconMgr == nil {
+		gi.TheIconMgr = &testIconMgr{}
+	}
+	sub := filepath.Join(dir, "sub")
+	os.Mkdir(sub, 0755)
+	ioutil.WriteFile(filepath.Join(dir, "a.txt"), []byte("a"), 0644)
+	ioutil.WriteFile(filepath.Join(sub, "b.txt"), []byte("b"), 0644)
+
+	ft := &FileTree{}
+	ft.InitName(ft, "tree")
+	ft.OpenPath(dir)
+	fs := NewFileTreeSource(ft)
+
+	if !fs.TreeIsOpen(fs.TreeRoot()) {
+		t.Errorf("root is not open")
+	}
+	var sn interface{}
+	for _, kid := range fs.TreeChildren(fs.TreeRoot()) {
+		if fs.TreeLabel(kid) == "sub" {
+			sn = kid
+		}
+	}
+	if sn == nil {
+		t.Fatalf("sub dir not found in children of root")
+	}
+	if !fs.TreeHasChildren(sn) {
+		t.Errorf("closed dir has no children")
+	}
+	if fs.TreeIsOpen(sn) || ItemFileNode(sn).HasChildren() {
+		t.Errorf("closed dir was read: open: %v", fs.TreeIsOpen(sn))
+	}
+	fs.TreeSetOpen(sn, true)
+	if !fs.TreeIsOpen(sn) || !ft.IsDirOpen(ItemFileNode(sn).FPath) {
+		t.Errorf("dir not recorded as open")
+	}
+	kids := fs.TreeChildren(sn)
+	if len(kids) != 1 || fs.TreeLabel(kids[0]) != "b.txt" || fs.TreeHasChildren(kids[0]) {
+		t.Errorf("opened dir children: %v", kids)
+	}
+	fs.TreeSetOpen(sn, false)
+	if fs.TreeIsOpen(sn) {
+		t.Errorf("dir still open after close")
+	}
+}
@@ -833,6 +833,37 @@ func (tv *TreeView) MoveEndAction(selMode mouse.SelectModes) *TreeView {
 	return fnn
 }
 
+// FindSrcNode returns the TreeView viewing given source node, among this
+// node and all those below it -- nil if not found
+func (tv *TreeView) FindSrcNode(kn ki.Ki) *TreeView {
+	var ttv *TreeView
+	tv.FuncDownMeFirst(0, tv.This(), func(k ki.Ki, level int, d interface{}) bool {
+		tvki := k.Embed(KiT_TreeView)
+		if tvki == nil {
+			return ki.Continue
+		}
+		ctv := tvki.(*TreeView)
+		if ctv.SrcNode == kn {
+			ttv = ctv
+			return ki.Break
+		}
+		return ttv == nil
+	})
+	return ttv
+}
+
+// OpenParents opens all the parents of this node, so that it is visible
+func (tv *TreeView) OpenParents() {
+	tv.FuncUpParent(0, tv.This(), func(k ki.Ki, level int, d interface{}) bool {
+		tvki := k.Embed(KiT_TreeView)
+		if tvki == nil {
+			return ki.Break
+		}
+		tvki.(*TreeView).Open()
+		return ki.Continue
+	})
+}
+
 // Close closes the given node and updates the view accordingly (if it is not already closed)
 func (tv *TreeView) Close() {
 	if !tv.IsClosed() {
@@ -993,14 +1024,18 @@ func (tv *TreeView) SrcInsertAt(rel int, actNm string) {
 				dlg, _ := send.(*gi.Dialog)
 				n, typ := gi.NewKiDialogValues(dlg)
 				updt := par.UpdateStart()
+				us := gi.UndoStackFor(tvv.This())
+				us.BeginGroup(actNm)
 				var ski ki.Ki
 				for i := 0; i < n; i++ {
 					nm := fmt.Sprintf("New%v%v", typ.Name(), myidx+rel+i)
 					nki := par.InsertNewChild(typ, myidx+i, nm)
+					SaveTreeInsert(us, actNm, nki)
 					if i == n-1 {
 						ski = nki
 					}
 				}
+				us.EndGroup()
 				tvv.SetChanged()
 				par.UpdateEnd(updt)
 				if ski != nil {
@@ -1031,14 +1066,18 @@ func (tv *TreeView) SrcAddChild() {
 				dlg, _ := send.(*gi.Dialog)
 				n, typ := gi.NewKiDialogValues(dlg)
 				updt := sk.UpdateStart()
+				us := gi.UndoStackFor(tvv.This())
+				us.BeginGroup(ttl)
 				var ski ki.Ki
 				for i := 0; i < n; i++ {
 					nm := fmt.Sprintf("New%v%v", typ.Name(), i)
 					nki := sk.AddNewChild(typ, nm)
+					SaveTreeInsert(us, ttl, nki)
 					if i == n-1 {
 						ski = nki
 					}
 				}
+				us.EndGroup()
 				tvv.SetChanged()
 				sk.UpdateEnd(updt)
 				if ski != nil {
@@ -1066,7 +1105,7 @@ func (tv *TreeView) SrcDelete() {
 		log.Printf("TreeView %v nil SrcNode in: %v\n", ttl, tv.PathUnique())
 		return
 	}
-	sk.Delete(true)
+	DeleteTreeNode(gi.UndoStackFor(tv.This()), ttl, sk)
 	tv.SetChanged()
 }
 
@@ -1100,6 +1139,7 @@ func (tv *TreeView) SrcDuplicate() {
 	nwkid := sk.Clone()
 	nwkid.SetName(nm)
 	par.InsertChild(nwkid, myidx+1)
+	SaveTreeInsert(gi.UndoStackFor(tv.This()), "Duplicate", nwkid)
 	tvpar.SetChanged()
 	if tvk := tvpar.ChildByName("tv_"+nm, 0); tvk != nil {
 		stv, _ := tvk.Embed(KiT_TreeView).(*TreeView)
@@ -1190,9 +1230,12 @@ func (tv *TreeView) Cut() {
 	tv.Copy(false)
 	sels := tv.SelectedSrcNodes()
 	tv.UnselectAll()
+	us := gi.UndoStackFor(tv.This())
+	us.BeginGroup("Cut")
 	for _, sn := range sels {
-		sn.Delete(true)
+		DeleteTreeNode(us, "Cut", sn)
 	}
+	us.EndGroup()
 	tv.SetChanged()
 }
 
@@ -1258,7 +1301,9 @@ func (tv *TreeView) PasteAssign(md mimedata.Mimes) {
 		log.Printf("TreeView PasteAssign nil SrcNode in: %v\n", tv.PathUnique())
 		return
 	}
+	tu := NewTreeUndoAssign(gi.UndoStackFor(tv.This()), "Paste Assign", sk)
 	sk.CopyFrom(sl[0])
+	tu.Save()
 	tv.SetChanged()
 }
 
@@ -1301,9 +1346,15 @@ func (tv *TreeView) PasteAt(md mimedata.Mimes, mod dnd.DropMods, rel int, actNm
 		return
 	}
 	myidx += rel
+	us := gi.UndoStackFor(tv.This())
+	ulbl := actNm
+	if mod == dnd.DropMove {
+		ulbl = "Move"
+	}
 	updt := par.UpdateStart()
 	sz := len(sl)
 	var ski ki.Ki
+	us.BeginGroup(ulbl)
 	for i, ns := range sl {
 		if mod != dnd.DropMove {
 			if cn := par.ChildByName(ns.Name(), 0); cn != nil {
@@ -1311,10 +1362,12 @@ func (tv *TreeView) PasteAt(md mimedata.Mimes, mod dnd.DropMods, rel int, actNm
 			}
 		}
 		par.InsertChild(ns, myidx+i)
+		SaveTreeInsert(us, ulbl, ns)
 		if i == sz-1 {
 			ski = ns
 		}
 	}
+	us.EndGroup()
 	par.UpdateEnd(updt)
 	tvpar.SetChanged()
 	if ski != nil {
@@ -1336,9 +1389,17 @@ func (tv *TreeView) PasteChildren(md mimedata.Mimes, mod dnd.DropMods) {
 		return
 	}
 	updt := sk.UpdateStart()
+	us := gi.UndoStackFor(tv.This())
+	ulbl := "Paste"
+	if mod == dnd.DropMove {
+		ulbl = "Move"
+	}
+	us.BeginGroup(ulbl)
 	for _, ns := range sl {
 		sk.AddChild(ns)
+		SaveTreeInsert(us, ulbl, ns)
 	}
+	us.EndGroup()
 	sk.UpdateEnd(updt)
 	tv.SetChanged()
 }
@@ -1416,16 +1477,19 @@ func (tv *TreeView) Dragged(de *dnd.Event) {
 		return
 	}
 	sroot := tv.RootView.SrcNode
+	us := gi.UndoStackFor(tv.This())
+	us.ContinueGroup("Move") // undone together with the insertion at the target
 	md := de.Data
 	for _, d := range md {
 		if d.Type == filecat.TextPlain { // link
 			path := string(d.Data)
 			sn := sroot.FindPathUnique(path)
 			if sn != nil {
-				sn.Delete(true)
+				DeleteTreeNode(us, "Move", sn)
 			}
 		}
 	}
+	us.EndGroup()
 }
 
 // MakeDropMenu makes the menu of options for dropping on a target
@@ -2227,6 +2291,37 @@ func (tv *TreeView) ConnectEvents2D() {
 	tv.TreeViewEvents()
 }
 
+func (tv *TreeView) AccessInfo2D(ai *gi.AccessInfo) {
+	tv.PartsWidgetBase.AccessInfo2D(ai)
+	ai.Role = gi.AccessTreeItem
+	ai.Label = tv.Label()
+	ai.SetState(true, gi.AccessSelectable)
+	ai.Actions = []string{gi.AccessActSelect}
+	if tv.HasChildren() {
+		ai.SetState(true, gi.AccessExpandable)
+		ai.SetState(!tv.IsClosed(), gi.AccessExpanded)
+		if tv.IsClosed() {
+			ai.Actions = append(ai.Actions, gi.AccessActExpand)
+		} else {
+			ai.Actions = append(ai.Actions, gi.AccessActCollapse)
+		}
+	}
+}
+
+func (tv *TreeView) AccessAction2D(act string) bool {
+	switch act {
+	case gi.AccessActSelect:
+		tv.SelectAction(mouse.SelectOne)
+	case gi.AccessActExpand:
+		tv.Open()
+	case gi.AccessActCollapse:
+		tv.Close()
+	default:
+		return false
+	}
+	return true
+}
+
 func (tv *TreeView) FocusChanged2D(change gi.FocusChanges) {
 	switch change {
 	case gi.FocusLost:
@@ -0,0 +1,311 @@
+// Copyright (c) 2018, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package giv
+
+import (
+	"reflect"
+
+	"github.com/goki/gi/gi"
+	"github.com/goki/ki/ki"
+	"github.com/goki/ki/kit"
+)
+
+// This file has the gi.UndoCmd commands for the edits made in the views:
+// ValueUndo for the values set in StructView, MapView and SliceView fields
+// and elements, and for the elements added to and deleted from maps and
+// slices, and TreeUndo for the insertion, deletion and assignment of nodes
+// in TreeView.  They are saved on the gi.UndoStackFor the view.
+
+// ValueUndo is the undo command for the setting of a value, or the adding or
+// deleting of map or slice elements, in a StructView, MapView or SliceView --
+// it records copies of the value (or the whole map or slice) before and
+// after the edit
+type ValueUndo struct {
+	Lbl   string        `desc:"label of the edit, e.g., Rename"`
+	Val   reflect.Value `desc:"the value that was edited, which must be settable (or a map)"`
+	Old   reflect.Value `desc:"copy of the value before the edit"`
+	New   reflect.Value `desc:"copy of the value after the edit"`
+	Owner ki.Ki         `desc:"if the value is a field of a Ki node, the node, which is set using SetField, to send its update signals"`
+	Field string        `desc:"name of the field of the Owner Ki node"`
+	View  ki.Ki         `desc:"the view showing the value, which is updated after undo and redo"`
+	us    *gi.UndoStack
+}
+
+// NewValueUndo returns a new ValueUndo command for an edit of given value,
+// shown in given view, recording a copy of the value before the edit -- use
+// Save to save it to the undo stack after the edit -- returns nil if there is
+// no undo stack for the view (nil safe)
+func NewValueUndo(lbl string, val reflect.Value, view ki.Ki) *ValueUndo {
+	us := gi.UndoStackFor(view)
+	if us == nil || us.Off || !val.IsValid() {
+		return nil
+	}
+	if val.Kind() != reflect.Map && !val.CanSet() {
+		return nil
+	}
+	return &ValueUndo{Lbl: lbl, Val: val, Old: undoCopyValue(val), View: view, us: us}
+}
+
+// Save saves the command to the undo stack, recording a copy of the value
+// after the edit (nil safe)
+func (vu *ValueUndo) Save() {
+	if vu == nil {
+		return
+	}
+	vu.New = undoCopyValue(vu.Val)
+	vu.us.Save(vu)
+}
+
+func (vu *ValueUndo) Label() string {
+	return vu.Lbl
+}
+
+func (vu *ValueUndo) Undo() {
+	vu.set(vu.Old)
+}
+
+func (vu *ValueUndo) Redo() {
+	vu.set(vu.New)
+}
+
+// set sets the value to a copy of given value, and updates the view
+func (vu *ValueUndo) set(val reflect.Value) {
+	if vu.Owner != nil && !vu.Owner.IsDestroyed() {
+		vu.Owner.SetField(vu.Field, val.Interface())
+	} else {
+		undoSetValue(vu.Val, undoCopyValue(val))
+	}
+	UndoUpdateView(vu.View)
+}
+
+// undoCopyValue returns a copy of given value -- maps and slices are copied
+// one level deep, so that later changes to their elements are not reflected
+// in the copy
+func undoCopyValue(val reflect.Value) reflect.Value {
+	switch val.Kind() {
+	case reflect.Map:
+		if val.IsNil() {
+			return reflect.Zero(val.Type())
+		}
+		cp := reflect.MakeMapWithSize(val.Type(), val.Len())
+		for _, k := range val.MapKeys() {
+			cp.SetMapIndex(k, val.MapIndex(k))
+		}
+		return cp
+	case reflect.Slice:
+		if val.IsNil() {
+			return reflect.Zero(val.Type())
+		}
+		cp := reflect.MakeSlice(val.Type(), val.Len(), val.Len())
+		reflect.Copy(cp, val)
+		return cp
+	}
+	cp := reflect.New(val.Type()).Elem()
+	cp.Set(val)
+	return cp
+}
+
+// undoSetValue sets given value to given copy of a value -- maps are
+// updated in place, as they can be referred to elsewhere
+func undoSetValue(val, cp reflect.Value) {
+	if val.Kind() != reflect.Map || val.IsNil() {
+		if val.CanSet() {
+			val.Set(cp)
+		}
+		return
+	}
+	for _, k := range val.MapKeys() {
+		val.SetMapIndex(k, reflect.Value{})
+	}
+	if !cp.IsNil() {
+		for _, k := range cp.MapKeys() {
+			val.SetMapIndex(k, cp.MapIndex(k))
+		}
+	}
+}
+
+// UndoViewFor returns the StructView, MapView or SliceView (or TableView)
+// containing given widget, which is updated after undo and redo of the
+// edits made in the widget -- nil if none
+func UndoViewFor(k ki.Ki) ki.Ki {
+	if k == nil || k.This() == nil {
+		return nil
+	}
+	var view ki.Ki
+	k.FuncUp(0, nil, func(k ki.Ki, level int, d interface{}) bool {
+		if k.Embed(KiT_StructView) != nil || k.Embed(KiT_MapView) != nil || k.Embed(KiT_SliceViewBase) != nil {
+			view = k
+			return ki.Break
+		}
+		return ki.Continue
+	})
+	return view
+}
+
+// UndoUpdateView updates the display of given StructView, MapView or
+// SliceView after its values have been changed by undo or redo, and marks it
+// as changed
+func UndoUpdateView(view ki.Ki) {
+	if view == nil || view.This() == nil || view.IsDestroyed() {
+		return
+	}
+	switch {
+	case view.Embed(KiT_StructView) != nil:
+		sv := view.Embed(KiT_StructView).(*StructView)
+		if sv.TmpSave != nil {
+			sv.TmpSave.SaveTmp()
+		}
+		sv.UpdateFields()
+		sv.Changed = true
+		sv.ViewSig.Emit(sv.This(), 0, nil)
+	case view.Embed(KiT_MapView) != nil:
+		mv := view.Embed(KiT_MapView).(*MapView)
+		if mv.TmpSave != nil {
+			mv.TmpSave.SaveTmp()
+		}
+		mv.ConfigMapGrid()
+		mv.SetChanged()
+	case view.Embed(KiT_SliceViewBase) != nil:
+		sv := view.Embed(KiT_SliceViewBase).(*SliceViewBase)
+		if sv.TmpSave != nil {
+			sv.TmpSave.SaveTmp()
+		}
+		sv.SliceNPVal = kit.NonPtrValue(reflect.ValueOf(sv.Slice))
+		sv.SetChanged()
+		sv.ScrollBar().SetFullReRender()
+		sv.This().(gi.Updater).Update()
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////
+//  TreeUndo
+
+// TreeUndoActs are the kinds of tree edits recorded by TreeUndo
+type TreeUndoActs int
+
+const (
+	// TreeUndoInsert is the insertion of the node in the parent at the index
+	TreeUndoInsert TreeUndoActs = iota
+
+	// TreeUndoDelete is the deletion of the node from the parent at the index
+	TreeUndoDelete
+
+	// TreeUndoAssign is the assignment (CopyFrom) of the node from another
+	TreeUndoAssign
+)
+
+// TreeUndo is the undo command for the insertion, deletion or assignment of
+// a Ki node in a TreeView -- deleted nodes are kept, so they can be inserted
+// back, until the command is discarded from the undo stack, and assignments
+// record clones of the node before and after
+type TreeUndo struct {
+	Lbl  string       `desc:"label of the edit, e.g., Cut"`
+	Act  TreeUndoActs `desc:"the kind of edit"`
+	Par  ki.Ki        `desc:"parent of the node, for insert and delete"`
+	Node ki.Ki        `desc:"the inserted, deleted or assigned node"`
+	Idx  int          `desc:"index of the node in the parent, for insert and delete"`
+	Old  ki.Ki        `desc:"clone of the assigned node before the edit"`
+	New  ki.Ki        `desc:"clone of the assigned node after the edit"`
+	us   *gi.UndoStack
+}
+
+func (tu *TreeUndo) Label() string {
+	return tu.Lbl
+}
+
+func (tu *TreeUndo) Undo() {
+	switch tu.Act {
+	case TreeUndoInsert:
+		tu.Par.DeleteChild(tu.Node, false)
+	case TreeUndoDelete:
+		tu.insert()
+	case TreeUndoAssign:
+		tu.Node.CopyFrom(tu.Old)
+	}
+}
+
+func (tu *TreeUndo) Redo() {
+	switch tu.Act {
+	case TreeUndoInsert:
+		tu.insert()
+	case TreeUndoDelete:
+		tu.Par.DeleteChild(tu.Node, false)
+	case TreeUndoAssign:
+		tu.Node.CopyFrom(tu.New)
+	}
+}
+
+// insert inserts the node back in the parent at the index, clearing the
+// NodeDeleted flag set when it was deleted
+func (tu *TreeUndo) insert() {
+	tu.Par.InsertChild(tu.Node, tu.Idx)
+	tu.Node.ClearFlag(int(ki.NodeDeleted))
+}
+
+// Discard destroys the node if it is out of the tree when the command is
+// discarded from the undo stack: a deletion that was done, or an insertion
+// that was undone -- satisfies gi.UndoDiscarder
+func (tu *TreeUndo) Discard(done bool) {
+	if tu.Node == nil || tu.Node.IsDestroyed() || tu.Node.Parent() != nil {
+		return
+	}
+	if (tu.Act == TreeUndoDelete && done) || (tu.Act == TreeUndoInsert && !done) {
+		tu.Node.Destroy()
+	}
+}
+
+// SaveTreeInsert saves the insertion of given node, which is now in its
+// parent, on given undo stack (nil safe), with given label
+func SaveTreeInsert(us *gi.UndoStack, lbl string, node ki.Ki) {
+	par := node.Parent()
+	if us == nil || us.Off || par == nil {
+		return
+	}
+	idx, ok := node.IndexInParent()
+	if !ok {
+		return
+	}
+	us.Save(&TreeUndo{Lbl: lbl, Act: TreeUndoInsert, Par: par, Node: node, Idx: idx})
+}
+
+// DeleteTreeNode deletes given node from its parent, saving the deletion on
+// given undo stack, with given label -- the node is destroyed if the stack is
+// nil or off, as it cannot be inserted back, and otherwise when the deletion
+// is discarded from the stack (see TreeUndo.Discard)
+func DeleteTreeNode(us *gi.UndoStack, lbl string, node ki.Ki) {
+	par := node.Parent()
+	if us == nil || us.Off || par == nil {
+		node.Delete(true)
+		return
+	}
+	idx, ok := node.IndexInParent()
+	if !ok {
+		node.Delete(true)
+		return
+	}
+	par.DeleteChild(node, false)
+	us.Save(&TreeUndo{Lbl: lbl, Act: TreeUndoDelete, Par: par, Node: node, Idx: idx})
+}
+
+// NewTreeUndoAssign returns a new TreeUndo command for the assignment of
+// given node, recording a clone of it before the edit -- use Save to save it
+// to given undo stack after the edit -- returns nil if the stack is nil or
+// off (nil safe)
+func NewTreeUndoAssign(us *gi.UndoStack, lbl string, node ki.Ki) *TreeUndo {
+	if us == nil || us.Off {
+		return nil
+	}
+	return &TreeUndo{Lbl: lbl, Act: TreeUndoAssign, Node: node, Old: node.Clone(), us: us}
+}
+
+// Save saves the assignment command to its undo stack, recording a clone of
+// the node after the edit (nil safe)
+func (tu *TreeUndo) Save() {
+	if tu == nil {
+		return
+	}
+	tu.New = tu.Node.Clone()
+	tu.us.Save(tu)
+}
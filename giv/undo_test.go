@@ -0,0 +1,71 @@
+// Copyright (c) 2018, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package giv
+
+import (
+	"testing"
+
+	"github.com/goki/gi/gi"
+	"github.com/goki/ki/ki"
+)
+
+func TestTreeUndoDelete(t *testing.T) {
+	root := &ki.Node{}
+	root.InitName(root, "root")
+	root.AddNewChild(ki.KiT_Node, "a")
+	kid := root.AddNewChild(ki.KiT_Node, "b")
+	root.AddNewChild(ki.KiT_Node, "c")
+
+	us := &gi.UndoStack{}
+	DeleteTreeNode(us, "Delete", kid)
+	if !kid.IsDeleted() || kid.Parent() != nil || root.NumChildren() != 2 {
+		t.Fatalf("node not deleted: deleted: %v children: %v", kid.IsDeleted(), root.NumChildren())
+	}
+	if !us.Undo() {
+		t.Fatalf("nothing to undo")
+	}
+	if kid.IsDeleted() {
+		t.Errorf("node restored by undo is still flagged deleted")
+	}
+	if idx, ok := kid.IndexInParent(); !ok || idx != 1 || kid.Parent() != root.This() {
+		t.Errorf("node not restored at index 1: %v %v", idx, ok)
+	}
+	if !us.Redo() || !kid.IsDeleted() || kid.Parent() != nil {
+		t.Errorf("node not deleted again by redo")
+	}
+	us.Reset()
+	if !kid.IsDestroyed() {
+		t.Errorf("deleted node not destroyed when discarded from the stack")
+	}
+}
+
+func TestTreeUndoInsert(t *testing.T) {
+	root := &ki.Node{}
+	root.InitName(root, "root")
+	root.AddNewChild(ki.KiT_Node, "a")
+
+	us := &gi.UndoStack{}
+	us.BeginGroup("Insert")
+	kid := root.AddNewChild(ki.KiT_Node, "b")
+	SaveTreeInsert(us, "Insert", kid)
+	kid2 := root.AddNewChild(ki.KiT_Node, "c")
+	SaveTreeInsert(us, "Insert", kid2)
+	us.EndGroup()
+
+	if !us.Undo() || root.NumChildren() != 1 {
+		t.Fatalf("inserts not undone together: children: %v", root.NumChildren())
+	}
+	if !us.Redo() || root.NumChildren() != 3 {
+		t.Fatalf("inserts not redone: children: %v", root.NumChildren())
+	}
+	if kid.IsDeleted() || kid2.IsDeleted() {
+		t.Errorf("node restored by redo is still flagged deleted")
+	}
+	us.Undo()
+	us.Save(&ValueUndo{Lbl: "Other"}) // discards the undone inserts
+	if !kid.IsDestroyed() || !kid2.IsDestroyed() {
+		t.Errorf("undone inserted nodes not destroyed when discarded from the stack")
+	}
+}
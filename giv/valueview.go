@@ -41,6 +41,11 @@ func init() {
 		vv.Init(vv)
 		return vv
 	})
+	ValueViewMapAdd(kit.LongTypeName(reflect.TypeOf(gi.ThemeName(""))), func() ValueView {
+		vv := &ThemeValueView{}
+		vv.Init(vv)
+		return vv
+	})
 	ValueViewMapAdd(kit.LongTypeName(reflect.TypeOf(gi.ColorName(""))), func() ValueView {
 		vv := &ColorNameValueView{}
 		vv.Init(vv)
@@ -66,6 +71,16 @@ func init() {
 		vv.Init(vv)
 		return vv
 	})
+	ValueViewMapAdd(kit.LongTypeName(reflect.TypeOf(gi.Range32{})), func() ValueView {
+		vv := &RangeValueView{}
+		vv.Init(vv)
+		return vv
+	})
+	ValueViewMapAdd(kit.LongTypeName(reflect.TypeOf(time.Duration(0))), func() ValueView {
+		vv := &DurationValueView{}
+		vv.Init(vv)
+		return vv
+	})
 }
 
 // MapInlineLen is the number of map elements at or below which an inline
@@ -177,6 +192,8 @@ func ToValueView(it interface{}, tags string) ValueView {
 
 	forceInline := false
 	forceNoInline := false
+	multiline := false
+	rangeView := false
 
 	tprops := kit.Types.Properties(typ, false) // don't make
 	if tprops != nil {
@@ -196,11 +213,23 @@ func ToValueView(it interface{}, tags string) ValueView {
 				forceInline = true
 			case "no-inline":
 				forceNoInline = true
+			case "multiline":
+				multiline = true
+			case "range":
+				rangeView = true
 			}
 		}
 	}
 
 	switch {
+	case multiline && vk == reflect.String:
+		vv := &TextAreaValueView{}
+		vv.Init(vv)
+		return vv
+	case rangeView && nptyp == reflect.TypeOf([2]float32{}):
+		vv := &RangeValueView{}
+		vv.Init(vv)
+		return vv
 	case vk >= reflect.Int && vk <= reflect.Uint64:
 		if kit.Enums.TypeRegistered(nptyp) {
 			if kit.Enums.IsBitFlag(nptyp) {
@@ -616,6 +645,7 @@ func (vv *ValueViewBase) SetValue(val interface{}) bool {
 		return false
 	}
 	rval := false
+	undo := vv.NewUndo()
 	if vv.Owner != nil {
 		switch vv.OwnKind {
 		case reflect.Struct:
@@ -654,6 +684,7 @@ func (vv *ValueViewBase) SetValue(val interface{}) bool {
 								ov.SetMapIndex(nv, cv)              // set new key to current value
 								vv.Value = nv                       // update value to new key
 								vv.This().(ValueView).SaveTmp()
+								undo.Save()
 								vv.ViewSig.Emit(vv.This(), 0, nil)
 								if vp != nil {
 									vp.SetNeedsFullRender()
@@ -688,12 +719,58 @@ func (vv *ValueViewBase) SetValue(val interface{}) bool {
 	}
 	if rval {
 		vv.This().(ValueView).SaveTmp()
+		undo.Save()
 	}
 	// fmt.Printf("value view: %T sending for setting val %v\n", vv.This(), val)
 	vv.ViewSig.Emit(vv.This(), 0, nil)
 	return rval
 }
 
+// NewUndo returns a new ValueUndo command for setting the value, for
+// SetValue, with a label based on the field name or map key -- a value in a
+// map is recorded by a copy of the whole map -- returns nil if there is no
+// undo stack for the widget
+func (vv *ValueViewBase) NewUndo() *ValueUndo {
+	if vv.Widget == nil || !vv.Value.IsValid() {
+		return nil
+	}
+	view := UndoViewFor(vv.Widget)
+	if view == nil {
+		view = vv.Widget
+	}
+	var vu *ValueUndo
+	switch {
+	case vv.Owner != nil && vv.OwnKind == reflect.Map:
+		lbl := "Rename Key"
+		if !vv.IsMapKey {
+			lbl = "Edit " + kit.ToString(vv.Key)
+			if vv.KeyView != nil {
+				lbl = "Edit " + kit.ToString(kit.NonPtrValue(vv.KeyView.Val()).Interface())
+			}
+		}
+		vu = NewValueUndo(lbl, kit.NonPtrValue(reflect.ValueOf(vv.Owner)), view)
+	case vv.Owner != nil && vv.OwnKind == reflect.Struct:
+		lbl := "Rename"
+		if vv.Field.Name != "Nm" {
+			flbl, ok := vv.Tag("label")
+			if !ok {
+				flbl = vv.Field.Name
+			}
+			lbl = "Edit " + flbl
+		}
+		vu = NewValueUndo(lbl, kit.PtrValue(vv.Value).Elem(), view)
+		if kiv, ok := vv.Owner.(ki.Ki); ok && vu != nil {
+			vu.Owner = kiv
+			vu.Field = vv.Field.Name
+		}
+	case vv.Owner != nil && vv.OwnKind == reflect.Slice:
+		vu = NewValueUndo("Edit Item", kit.PtrValue(vv.Value).Elem(), view)
+	default:
+		vu = NewValueUndo("Edit", kit.PtrValue(vv.Value).Elem(), view)
+	}
+	return vu
+}
+
 func (vv *ValueViewBase) SaveTmp() {
 	if vv.TmpSave == nil {
 		return
@@ -886,6 +963,7 @@ func (vv *ValueViewBase) ConfigWidget(widg gi.Node2D) {
 			cmpfv.Call(in)
 		}
 	}
+	vv.ConfigTextValidation(tf)
 
 	tf.TextFieldSig.ConnectOnly(vv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
 		if sig == int64(gi.TextFieldDone) || sig == int64(gi.TextFieldDeFocused) {
@@ -899,6 +977,56 @@ func (vv *ValueViewBase) ConfigWidget(widg gi.Node2D) {
 	vv.UpdateWidget()
 }
 
+// ConfigTextValidation configures the input constraints of given text field
+// from the tags of the value: min-len, max-len, mask (a mask or mask name, see
+// gi.TextFieldMask), regexp (which the whole text must match), min and max (the
+// text must be a number in range), and view:"password" for NoEcho mode
+func (vv *ValueViewBase) ConfigTextValidation(tf *gi.TextField) {
+	if lentag, ok := vv.Tag("min-len"); ok {
+		if ln, ok := kit.ToInt(lentag); ok {
+			tf.MinLen = int(ln)
+		}
+	}
+	if lentag, ok := vv.Tag("max-len"); ok {
+		if ln, ok := kit.ToInt(lentag); ok {
+			tf.MaxLen = int(ln)
+		}
+	}
+	if masktag, ok := vv.Tag("mask"); ok {
+		tf.Mask = masktag
+	}
+	if vtag, ok := vv.Tag("view"); ok && strings.Contains(vtag, "password") {
+		tf.NoEcho = true
+	}
+	var vals gi.TextValidators
+	if retag, ok := vv.Tag("regexp"); ok {
+		rv, err := gi.NewRegexpValidator(retag, "")
+		if err != nil {
+			log.Printf("giv.ValueViewBase: programmer error -- invalid regexp tag: %q: %v\n", retag, err)
+		} else {
+			vals = vals.Add(rv)
+		}
+	}
+	mintag, hasMin := vv.Tag("min")
+	maxtag, hasMax := vv.Tag("max")
+	if hasMin || hasMax {
+		rv := &gi.RangeValidator{}
+		rv.Min, rv.HasMin = kit.ToFloat(mintag)
+		rv.Max, rv.HasMax = kit.ToFloat(maxtag)
+		vk := kit.NonPtrValue(vv.Value).Kind()
+		rv.Int = vk >= reflect.Int && vk <= reflect.Uint64
+		vals = vals.Add(rv)
+	}
+	switch len(vals) {
+	case 0:
+		tf.Validator = nil
+	case 1:
+		tf.Validator = vals[0]
+	default:
+		tf.Validator = vals
+	}
+}
+
 // StdConfigWidget does all of the standard widget configuration tag options
 func (vv *ValueViewBase) StdConfigWidget(widg gi.Node2D) {
 	nb := widg.AsNode2D()
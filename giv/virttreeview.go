@@ -0,0 +1,1512 @@
+// Copyright (c) 2018, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package giv
+
+import (
+	"fmt"
+	"image"
+
+	"github.com/chewxy/math32"
+	"github.com/goki/gi/gi"
+	"github.com/goki/gi/oswin"
+	"github.com/goki/gi/oswin/dnd"
+	"github.com/goki/gi/oswin/key"
+	"github.com/goki/gi/oswin/mimedata"
+	"github.com/goki/gi/oswin/mouse"
+	"github.com/goki/gi/units"
+	"github.com/goki/ki/ints"
+	"github.com/goki/ki/ki"
+	"github.com/goki/ki/kit"
+	"github.com/goki/mat32"
+	"github.com/goki/pi/filecat"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////
+//  VirtTreeView
+
+// VirtTreeNode is one entry in the flattened list of currently-visible
+// (i.e., all parents open) items in a VirtTreeView
+type VirtTreeNode struct {
+	Item  interface{} `desc:"the item, as provided by the TreeSource"`
+	Depth int         `desc:"depth of item in the tree -- root is 0"`
+}
+
+// VirtTreeView is a virtualized tree view that gets its structure from a
+// TreeSource, which is only queried for the children of items that are
+// actually opened.  The currently-visible items are maintained in a flat
+// list of Nodes, and only as many VirtTreeRow widgets as fit in the
+// allocated display are created, which are recycled as the view is scrolled,
+// in the same way as SliceViewBase does.  Thus, it can display trees with
+// millions of nodes, unlike TreeView which creates a full widget tree
+// mirroring the source Ki tree.  It supports the same selection, keyboard
+// navigation, copy / paste and drag-n-drop behavior as TreeView, with the
+// editing functions depending on the source implementing TreeSourceEditor.
+// TreeViewSig signals are sent with the item as the data.
+type VirtTreeView struct {
+	gi.Frame
+	Src           TreeSource                    `copy:"-" json:"-" xml:"-" desc:"the source of the tree data -- set with SetSource"`
+	Indent        units.Value                   `xml:"indent" desc:"styled amount to indent each level of the tree"`
+	OpenDepth     int                           `xml:"open-depth" desc:"depth to which the tree is initially opened when the source is set"`
+	TreeViewSig   ki.Signal                     `copy:"-" json:"-" xml:"-" desc:"signal for tree view -- see TreeViewSignals for the types -- data is the item"`
+	Nodes         []VirtTreeNode                `copy:"-" view:"-" json:"-" xml:"-" desc:"flat list of currently-visible items, in display order"`
+	OpenItems     map[interface{}]struct{}      `copy:"-" view:"-" json:"-" xml:"-" desc:"items that are currently open"`
+	KidsCache     map[interface{}][]interface{} `copy:"-" view:"-" json:"-" xml:"-" desc:"cache of children for items that have been opened"`
+	SelectedItems map[interface{}]struct{}      `copy:"-" view:"-" json:"-" xml:"-" desc:"currently-selected items"`
+	SelectedIdx   int                           `copy:"-" json:"-" xml:"-" desc:"index in Nodes of current (last-selected) item"`
+	SelectMode    bool                          `copy:"-" desc:"editing-mode select rows mode"`
+
+	DispRows      int     `view:"inactive" copy:"-" json:"-" xml:"-" desc:"actual number of rows displayed = min(VisRows, len(Nodes))"`
+	StartIdx      int     `view:"inactive" copy:"-" json:"-" xml:"-" desc:"starting index in Nodes of visible rows"`
+	RowHeight     float32 `view:"inactive" copy:"-" json:"-" xml:"-" desc:"height of a single row"`
+	VisRows       int     `view:"inactive" copy:"-" json:"-" xml:"-" desc:"total number of rows visible in allocated display size"`
+	LayoutHeight  float32 `copy:"-" view:"-" json:"-" xml:"-" desc:"the height of grid from last layout -- determines when update needed"`
+	RenderedRows  int     `copy:"-" view:"-" json:"-" xml:"-" desc:"the number of rows rendered -- determines update"`
+	InFullRebuild bool    `copy:"-" view:"-" json:"-" xml:"-" desc:"guard for recursive rebuild"`
+	CurIdx        int     `copy:"-" view:"-" json:"-" xml:"-" desc:"temp idx state for e.g., dnd"`
+}
+
+var KiT_VirtTreeView = kit.Types.AddType(&VirtTreeView{}, VirtTreeViewProps)
+
+// AddNewVirtTreeView adds a new virttreeview to given parent node, with given name.
+func AddNewVirtTreeView(parent ki.Ki, name string) *VirtTreeView {
+	return parent.AddNewChild(KiT_VirtTreeView, name).(*VirtTreeView)
+}
+
+func (vw *VirtTreeView) Disconnect() {
+	vw.Frame.Disconnect()
+	vw.TreeViewSig.DisconnectAll()
+}
+
+var VirtTreeViewProps = ki.Props{
+	"EnumType:Flag":    gi.KiT_NodeFlags,
+	"indent":           units.NewEm(1),
+	"background-color": &gi.Prefs.Colors.Background,
+	"color":            &gi.Prefs.Colors.Font,
+	"max-width":        -1,
+	"max-height":       -1,
+}
+
+// SetSource sets the source of the tree data, and rebuilds the view,
+// opening the tree to OpenDepth, or to the items that are open in the
+// source if it is a TreeSourceOpener
+func (vw *VirtTreeView) SetSource(src TreeSource) {
+	updt := vw.UpdateStart()
+	vw.Src = src
+	vw.OpenItems = make(map[interface{}]struct{})
+	vw.KidsCache = make(map[interface{}][]interface{})
+	vw.SelectedItems = make(map[interface{}]struct{})
+	vw.SelectedIdx = -1
+	vw.StartIdx = 0
+	if nt, ok := src.(TreeSourceNotifier); ok {
+		nt.TreeSetNotify(vw.This(), func(item interface{}) {
+			if item == nil {
+				vw.UpdateGrid()
+				return
+			}
+			vw.UpdateItem(item)
+		})
+	}
+	vw.Config()
+	if op, ok := src.(TreeSourceOpener); ok {
+		vw.openFromSource(op, src.TreeRoot())
+	} else {
+		vw.OpenToDepth(src.TreeRoot(), 0)
+	}
+	vw.RebuildNodes()
+	vw.SetFullReRender()
+	vw.UpdateEnd(updt)
+}
+
+// OpenToDepth marks item and its children as open, down to OpenDepth
+func (vw *VirtTreeView) OpenToDepth(item interface{}, depth int) {
+	if depth >= vw.OpenDepth || !vw.Src.TreeHasChildren(item) {
+		return
+	}
+	vw.OpenItems[item] = struct{}{}
+	for _, kid := range vw.ItemChildren(item) {
+		vw.OpenToDepth(kid, depth+1)
+	}
+}
+
+// OpenFromSource sets the open state of the item at given index and all of
+// its descendants from the source, if it is a TreeSourceOpener -- call after
+// the source has opened or closed items itself
+func (vw *VirtTreeView) OpenFromSource(idx int) {
+	op, ok := vw.Src.(TreeSourceOpener)
+	if !ok || idx < 0 || idx >= len(vw.Nodes) {
+		return
+	}
+	vw.openFromSource(op, vw.Nodes[idx].Item)
+	vw.RebuildNodes()
+	vw.UpdateGrid()
+}
+
+func (vw *VirtTreeView) openFromSource(op TreeSourceOpener, item interface{}) {
+	if !vw.Src.TreeHasChildren(item) || !op.TreeIsOpen(item) {
+		delete(vw.OpenItems, item)
+		return
+	}
+	vw.OpenItems[item] = struct{}{}
+	for _, kid := range vw.ItemChildren(item) {
+		vw.openFromSource(op, kid)
+	}
+}
+
+// setSourceOpen records the open state of given item in the source, if it
+// is a TreeSourceOpener
+func (vw *VirtTreeView) setSourceOpen(item interface{}, open bool) {
+	if op, ok := vw.Src.(TreeSourceOpener); ok {
+		op.TreeSetOpen(item, open)
+	}
+}
+
+// Config configures the view
+func (vw *VirtTreeView) Config() {
+	vw.Lay = gi.LayoutVert
+	config := kit.TypeAndNameList{}
+	config.Add(gi.KiT_Layout, "grid-lay")
+	mods, updt := vw.ConfigChildren(config, ki.UniqueNames)
+
+	gl := vw.GridLayout()
+	gl.Lay = gi.LayoutHoriz
+	gl.SetStretchMax() // for this to work, ALL layers above need it too
+	gconfig := kit.TypeAndNameList{}
+	gconfig.Add(gi.KiT_Frame, "grid")
+	gconfig.Add(gi.KiT_ScrollBar, "scrollbar")
+	gl.ConfigChildren(gconfig, ki.UniqueNames) // covered by above
+
+	vw.ConfigGrid()
+	if mods {
+		vw.SetFullReRender()
+		vw.UpdateEnd(updt)
+	}
+}
+
+// IsConfiged returns true if the widget is fully configured
+func (vw *VirtTreeView) IsConfiged() bool {
+	if len(vw.Kids) == 0 {
+		return false
+	}
+	return true
+}
+
+// GridLayout returns the Layout containing the Grid and the scrollbar
+func (vw *VirtTreeView) GridLayout() *gi.Layout {
+	return vw.ChildByName("grid-lay", 0).(*gi.Layout)
+}
+
+// RowGrid returns the grid frame widget, which contains the rows
+func (vw *VirtTreeView) RowGrid() *gi.Frame {
+	return vw.GridLayout().ChildByName("grid", 0).(*gi.Frame)
+}
+
+// ScrollBar returns the scrollbar
+func (vw *VirtTreeView) ScrollBar() *gi.ScrollBar {
+	return vw.GridLayout().ChildByName("scrollbar", 1).(*gi.ScrollBar)
+}
+
+// ConfigGrid configures the grid of rows
+func (vw *VirtTreeView) ConfigGrid() {
+	sg := vw.RowGrid()
+	sg.Lay = gi.LayoutVert
+	sg.SetProp("spacing", units.NewPx(0))
+	// setting a pref here is key for giving it a scrollbar in larger context
+	sg.SetMinPrefHeight(units.NewEm(6))
+	sg.SetMinPrefWidth(units.NewCh(20))
+	sg.SetStretchMax()                        // for this to work, ALL layers above need it too
+	sg.SetProp("overflow", gi.OverflowScroll) // this still gives it true size during PrefSize
+	vw.ConfigScroll()
+}
+
+// ConfigScroll configures the scrollbar
+func (vw *VirtTreeView) ConfigScroll() {
+	sb := vw.ScrollBar()
+	sb.Dim = mat32.Y
+	sb.Defaults()
+	sb.Tracking = true
+	if vw.Sty.Layout.ScrollBarWidth.Dots == 0 {
+		sb.SetFixedWidth(units.NewPx(16))
+	} else {
+		sb.SetFixedWidth(vw.Sty.Layout.ScrollBarWidth)
+	}
+	sb.SetStretchMaxHeight()
+	sb.Min = 0
+	sb.Step = 1
+	vw.UpdateScroll()
+
+	sb.SliderSig.Connect(vw.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+		if sig != int64(gi.SliderValueChanged) {
+			return
+		}
+		vww := recv.Embed(KiT_VirtTreeView).(*VirtTreeView)
+		wupdt := vww.TopUpdateStart()
+		vww.UpdateGrid()
+		vww.ViewportSafe().ReRender2DNode(vww.This().(gi.Node2D))
+		vww.TopUpdateEnd(wupdt)
+	})
+}
+
+// UpdateScroll updates grid scrollbar based on display
+func (vw *VirtTreeView) UpdateScroll() {
+	sb := vw.ScrollBar()
+	updt := sb.UpdateStart()
+	sz := len(vw.Nodes)
+	sb.Max = float32(sz) + 0.01 // bit of extra to ensure last line always shows up
+	if vw.DispRows > 0 {
+		sb.PageStep = float32(vw.DispRows) * sb.Step
+		sb.ThumbVal = float32(vw.DispRows)
+	} else {
+		sb.PageStep = 10 * sb.Step
+		sb.ThumbVal = 10
+	}
+	sb.TrackThr = sb.Step
+	sb.SetValue(float32(vw.StartIdx)) // essential for updating pos from value
+	if vw.DispRows == sz {
+		sb.Off = true
+	} else {
+		sb.Off = false
+	}
+	sb.UpdateEnd(updt)
+}
+
+// AvailHeight returns the height available for rows
+func (vw *VirtTreeView) AvailHeight() float32 {
+	sg := vw.RowGrid()
+	sgHt := sg.LayState.Alloc.Size.Y
+	if sgHt == 0 {
+		return 0
+	}
+	sgHt -= sg.ExtraSize.Y + sg.Sty.BoxSpace()*2
+	return sgHt
+}
+
+// LayoutGrid computes the number of rows that fit within the allocated
+// display, and creates that many row widgets -- returns true if UpdateGrid
+// should be called after this
+func (vw *VirtTreeView) LayoutGrid() bool {
+	sg := vw.RowGrid()
+	if vw.Src == nil {
+		sg.DeleteChildren(ki.DestroyKids)
+		return false
+	}
+	if sg.HasChildren() && sg.Kids[0] != nil {
+		vw.RowHeight = sg.Kids[0].(gi.Node2D).AsWidget().LayState.Alloc.Size.Y
+	}
+	if vw.Sty.Font.Face == nil {
+		vw.Sty.Font.OpenFont(&vw.Sty.UnContext)
+	}
+	vw.RowHeight = math32.Max(vw.RowHeight, vw.Sty.Font.Face.Metrics.Height)
+
+	mvp := vw.ViewportSafe()
+	if mvp != nil && mvp.HasFlag(int(gi.VpFlagPrefSizing)) {
+		vw.VisRows = gi.LayoutPrefMaxRows
+		vw.LayoutHeight = float32(vw.VisRows) * vw.RowHeight
+	} else {
+		sgHt := vw.AvailHeight()
+		vw.LayoutHeight = sgHt
+		if sgHt == 0 {
+			return false
+		}
+		vw.VisRows = int(math32.Floor(sgHt / vw.RowHeight))
+	}
+	vw.DispRows = ints.MinInt(len(vw.Nodes), vw.VisRows)
+
+	updt := sg.UpdateStart()
+	defer sg.UpdateEnd(updt)
+	if sg.NumChildren() != vw.VisRows {
+		sg.DeleteChildren(ki.DestroyKids)
+		sg.Kids = make(ki.Slice, vw.VisRows)
+	}
+	vw.ConfigScroll()
+	return true
+}
+
+// GridNeedsLayout returns true if the allocated size or number of rows
+// has changed since the last layout
+func (vw *VirtTreeView) GridNeedsLayout() bool {
+	sgHt := vw.AvailHeight()
+	if sgHt != vw.LayoutHeight {
+		return true
+	}
+	return vw.RenderedRows != vw.DispRows
+}
+
+// UpdateGrid updates grid display -- robust to any time calling
+func (vw *VirtTreeView) UpdateGrid() {
+	if vw.Src == nil || !vw.IsConfiged() {
+		return
+	}
+	sg := vw.RowGrid()
+	wupdt := vw.TopUpdateStart()
+	defer vw.TopUpdateEnd(wupdt)
+
+	updt := sg.UpdateStart()
+	defer sg.UpdateEnd(updt)
+
+	sz := len(vw.Nodes)
+	vw.DispRows = ints.MinInt(sz, vw.VisRows)
+	if sz > vw.DispRows {
+		sb := vw.ScrollBar()
+		vw.StartIdx = int(sb.Value)
+		lastSt := sz - vw.DispRows
+		vw.StartIdx = ints.MinInt(lastSt, vw.StartIdx)
+		vw.StartIdx = ints.MaxInt(0, vw.StartIdx)
+	} else {
+		vw.StartIdx = 0
+	}
+
+	for i := range sg.Kids {
+		if sg.Kids[i] != nil {
+			sg.Kids[i].(*VirtTreeRow).SetFullReRender()
+			continue
+		}
+		vr := &VirtTreeRow{}
+		sg.SetChild(vr, i, fmt.Sprintf("row-%05d", i))
+		vr.View = vw
+		vr.Row = i
+	}
+	vw.UpdateScroll()
+}
+
+//////////////////////////////////////////////////////////////////////////////
+//  Nodes
+
+// ItemChildren returns the children of given item, using the cache if
+// available, else getting them from the source
+func (vw *VirtTreeView) ItemChildren(item interface{}) []interface{} {
+	if kids, has := vw.KidsCache[item]; has {
+		return kids
+	}
+	kids := vw.Src.TreeChildren(item)
+	vw.KidsCache[item] = kids
+	return kids
+}
+
+// IsItemOpen returns true if given item is open
+func (vw *VirtTreeView) IsItemOpen(item interface{}) bool {
+	_, open := vw.OpenItems[item]
+	return open
+}
+
+// AppendNodes appends item and all of its visible descendants at given
+// depth to nds, returning the updated list
+func (vw *VirtTreeView) AppendNodes(nds []VirtTreeNode, item interface{}, depth int) []VirtTreeNode {
+	nds = append(nds, VirtTreeNode{Item: item, Depth: depth})
+	if !vw.IsItemOpen(item) {
+		return nds
+	}
+	for _, kid := range vw.ItemChildren(item) {
+		nds = vw.AppendNodes(nds, kid, depth+1)
+	}
+	return nds
+}
+
+// RebuildNodes rebuilds the flat list of visible nodes from the open state,
+// preserving the current item and dropping any selected items that are no
+// longer visible
+func (vw *VirtTreeView) RebuildNodes() {
+	var cur interface{}
+	if vw.SelectedIdx >= 0 && vw.SelectedIdx < len(vw.Nodes) {
+		cur = vw.Nodes[vw.SelectedIdx].Item
+	}
+	vw.Nodes = vw.Nodes[:0]
+	if vw.Src == nil {
+		return
+	}
+	vw.Nodes = vw.AppendNodes(vw.Nodes, vw.Src.TreeRoot(), 0)
+	vis := make(map[interface{}]struct{}, len(vw.SelectedItems))
+	vw.SelectedIdx = -1
+	for i, nd := range vw.Nodes {
+		if _, sel := vw.SelectedItems[nd.Item]; sel {
+			vis[nd.Item] = struct{}{}
+		}
+		if cur != nil && nd.Item == cur {
+			vw.SelectedIdx = i
+		}
+	}
+	vw.SelectedItems = vis
+}
+
+// IdxOfItem returns the index in Nodes of given item, false if not visible
+func (vw *VirtTreeView) IdxOfItem(item interface{}) (int, bool) {
+	for i, nd := range vw.Nodes {
+		if nd.Item == item {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+// ParentIdx returns the index in Nodes of the parent of item at given idx,
+// -1 if it is the root
+func (vw *VirtTreeView) ParentIdx(idx int) int {
+	dp := vw.Nodes[idx].Depth
+	for i := idx - 1; i >= 0; i-- {
+		if vw.Nodes[i].Depth < dp {
+			return i
+		}
+	}
+	return -1
+}
+
+// UpdateItem is called when the children of given item have changed --
+// it clears the cached children and rebuilds the display
+func (vw *VirtTreeView) UpdateItem(item interface{}) {
+	delete(vw.KidsCache, item)
+	vw.RebuildNodes()
+	vw.UpdateGrid()
+}
+
+// Refresh clears all cached children, and rebuilds the display -- call
+// if the source has changed and does not support TreeSourceNotifier
+func (vw *VirtTreeView) Refresh() {
+	vw.KidsCache = make(map[interface{}][]interface{})
+	vw.RebuildNodes()
+	vw.UpdateGrid()
+}
+
+// OpenIdx opens the item at given index -- TreeViewOpened is sent for items
+// without children too, as TreeView does, e.g., to open a file
+func (vw *VirtTreeView) OpenIdx(idx int) {
+	if idx < 0 || idx >= len(vw.Nodes) {
+		return
+	}
+	nd := vw.Nodes[idx]
+	if vw.IsItemOpen(nd.Item) {
+		return
+	}
+	if !vw.Src.TreeHasChildren(nd.Item) {
+		vw.TreeViewSig.Emit(vw.This(), int64(TreeViewOpened), nd.Item)
+		return
+	}
+	vw.setSourceOpen(nd.Item, true)
+	vw.OpenItems[nd.Item] = struct{}{}
+	if op, ok := vw.Src.(TreeSourceOpener); ok {
+		for _, kid := range vw.ItemChildren(nd.Item) {
+			vw.openFromSource(op, kid)
+		}
+	}
+	var sub []VirtTreeNode
+	for _, kid := range vw.ItemChildren(nd.Item) {
+		sub = vw.AppendNodes(sub, kid, nd.Depth+1)
+	}
+	nds := make([]VirtTreeNode, 0, len(vw.Nodes)+len(sub))
+	nds = append(nds, vw.Nodes[:idx+1]...)
+	nds = append(nds, sub...)
+	nds = append(nds, vw.Nodes[idx+1:]...)
+	vw.Nodes = nds
+	if vw.SelectedIdx > idx {
+		vw.SelectedIdx += len(sub)
+	}
+	vw.TreeViewSig.Emit(vw.This(), int64(TreeViewOpened), nd.Item)
+	vw.UpdateGrid()
+}
+
+// CloseIdx closes the item at given index
+func (vw *VirtTreeView) CloseIdx(idx int) {
+	if idx < 0 || idx >= len(vw.Nodes) {
+		return
+	}
+	nd := vw.Nodes[idx]
+	if !vw.IsItemOpen(nd.Item) {
+		return
+	}
+	delete(vw.OpenItems, nd.Item)
+	vw.setSourceOpen(nd.Item, false)
+	end := idx + 1
+	for end < len(vw.Nodes) && vw.Nodes[end].Depth > nd.Depth {
+		delete(vw.SelectedItems, vw.Nodes[end].Item)
+		end++
+	}
+	vw.Nodes = append(vw.Nodes[:idx+1], vw.Nodes[end:]...)
+	switch {
+	case vw.SelectedIdx >= end:
+		vw.SelectedIdx -= end - (idx + 1)
+	case vw.SelectedIdx > idx:
+		vw.SelectedIdx = idx
+	}
+	vw.TreeViewSig.Emit(vw.This(), int64(TreeViewClosed), nd.Item)
+	vw.UpdateGrid()
+}
+
+// ToggleOpenIdx toggles the open / closed state of item at given index
+func (vw *VirtTreeView) ToggleOpenIdx(idx int) {
+	if idx < 0 || idx >= len(vw.Nodes) {
+		return
+	}
+	if vw.IsItemOpen(vw.Nodes[idx].Item) {
+		vw.CloseIdx(idx)
+	} else {
+		vw.OpenIdx(idx)
+	}
+}
+
+// OpenAll opens the item at given index and all of its descendants --
+// this requires visiting the entire subtree, so use with caution on
+// very large trees
+func (vw *VirtTreeView) OpenAll(idx int) {
+	if idx < 0 || idx >= len(vw.Nodes) {
+		return
+	}
+	vw.openAllItem(vw.Nodes[idx].Item)
+	vw.RebuildNodes()
+	vw.UpdateGrid()
+}
+
+func (vw *VirtTreeView) openAllItem(item interface{}) {
+	if !vw.Src.TreeHasChildren(item) {
+		return
+	}
+	vw.setSourceOpen(item, true)
+	vw.OpenItems[item] = struct{}{}
+	for _, kid := range vw.ItemChildren(item) {
+		vw.openAllItem(kid)
+	}
+}
+
+// CloseAll closes the item at given index and all of its open descendants
+func (vw *VirtTreeView) CloseAll(idx int) {
+	if idx < 0 || idx >= len(vw.Nodes) {
+		return
+	}
+	dp := vw.Nodes[idx].Depth
+	for i := idx; i < len(vw.Nodes); i++ {
+		if i > idx && vw.Nodes[i].Depth <= dp {
+			break
+		}
+		if vw.IsItemOpen(vw.Nodes[i].Item) {
+			delete(vw.OpenItems, vw.Nodes[i].Item)
+			vw.setSourceOpen(vw.Nodes[i].Item, false)
+		}
+	}
+	vw.RebuildNodes()
+	vw.UpdateGrid()
+}
+
+//////////////////////////////////////////////////////////////////////////////
+//  Selection
+
+// IdxIsSelected returns the selected status of item at given index
+func (vw *VirtTreeView) IdxIsSelected(idx int) bool {
+	if idx < 0 || idx >= len(vw.Nodes) {
+		return false
+	}
+	_, ok := vw.SelectedItems[vw.Nodes[idx].Item]
+	return ok
+}
+
+// SelectedItemsList returns the currently-selected items, in display order
+func (vw *VirtTreeView) SelectedItemsList() []interface{} {
+	sl := make([]interface{}, 0, len(vw.SelectedItems))
+	for _, nd := range vw.Nodes {
+		if _, sel := vw.SelectedItems[nd.Item]; sel {
+			sl = append(sl, nd.Item)
+		}
+	}
+	return sl
+}
+
+// SelectedItem returns the current (last-selected) item, nil if none
+func (vw *VirtTreeView) SelectedItem() interface{} {
+	if vw.SelectedIdx < 0 || vw.SelectedIdx >= len(vw.Nodes) {
+		return nil
+	}
+	return vw.Nodes[vw.SelectedIdx].Item
+}
+
+// SelectIdx adds item at given index to the selection, without any signal
+func (vw *VirtTreeView) SelectIdx(idx int) {
+	if idx < 0 || idx >= len(vw.Nodes) {
+		return
+	}
+	vw.SelectedItems[vw.Nodes[idx].Item] = struct{}{}
+}
+
+// UnselectIdx removes item at given index from the selection, without any signal
+func (vw *VirtTreeView) UnselectIdx(idx int) {
+	if idx < 0 || idx >= len(vw.Nodes) {
+		return
+	}
+	delete(vw.SelectedItems, vw.Nodes[idx].Item)
+}
+
+// UnselectAll unselects all items, and emits TreeViewAllUnselected
+func (vw *VirtTreeView) UnselectAll() {
+	vw.SelectedItems = make(map[interface{}]struct{})
+	vw.TreeViewSig.Emit(vw.This(), int64(TreeViewAllUnselected), nil)
+	vw.UpdateGrid()
+}
+
+// SelectAll selects all visible items, and emits TreeViewAllSelected
+func (vw *VirtTreeView) SelectAll() {
+	for _, nd := range vw.Nodes {
+		vw.SelectedItems[nd.Item] = struct{}{}
+	}
+	vw.TreeViewSig.Emit(vw.This(), int64(TreeViewAllSelected), nil)
+	vw.UpdateGrid()
+}
+
+// SelectIdxAction is called when a select action has been received (e.g., a
+// mouse click) -- translates into selection updates -- gets selection mode
+// from mouse event (ExtendContinuous, ExtendOne)
+func (vw *VirtTreeView) SelectIdxAction(idx int, mode mouse.SelectModes) {
+	if mode == mouse.NoSelect || len(vw.Nodes) == 0 {
+		return
+	}
+	idx = ints.MinInt(idx, len(vw.Nodes)-1)
+	if idx < 0 {
+		idx = 0
+	}
+	item := vw.Nodes[idx].Item
+	switch mode {
+	case mouse.SelectOne:
+		vw.SelectedItems = make(map[interface{}]struct{})
+		vw.SelectedIdx = idx
+		vw.SelectIdx(idx)
+		vw.TreeViewSig.Emit(vw.This(), int64(TreeViewSelected), item)
+	case mouse.ExtendContinuous:
+		if len(vw.SelectedItems) == 0 || vw.SelectedIdx < 0 {
+			vw.SelectedIdx = idx
+			vw.SelectIdx(idx)
+		} else {
+			st, ed := vw.SelectedIdx, idx
+			if st > ed {
+				st, ed = ed, st
+			}
+			for i := st; i <= ed; i++ {
+				vw.SelectIdx(i)
+			}
+			vw.SelectedIdx = idx
+		}
+		vw.TreeViewSig.Emit(vw.This(), int64(TreeViewSelected), item)
+	case mouse.ExtendOne:
+		vw.SelectedIdx = idx
+		if vw.IdxIsSelected(idx) {
+			vw.UnselectIdx(idx)
+			vw.TreeViewSig.Emit(vw.This(), int64(TreeViewUnselected), item)
+		} else {
+			vw.SelectIdx(idx)
+			vw.TreeViewSig.Emit(vw.This(), int64(TreeViewSelected), item)
+		}
+	case mouse.Unselect:
+		vw.SelectedIdx = idx
+		vw.UnselectIdx(idx)
+		vw.TreeViewSig.Emit(vw.This(), int64(TreeViewUnselected), item)
+	case mouse.SelectQuiet:
+		vw.SelectedIdx = idx
+		vw.SelectIdx(idx)
+	case mouse.UnselectQuiet:
+		vw.SelectedIdx = idx
+		vw.UnselectIdx(idx)
+	}
+	vw.ScrollToIdxNoUpdt(idx)
+	vw.UpdateGrid()
+}
+
+// SelectItem selects given item if it is visible, returning true if so
+func (vw *VirtTreeView) SelectItem(item interface{}) bool {
+	idx, ok := vw.IdxOfItem(item)
+	if !ok {
+		return false
+	}
+	vw.SelectIdxAction(idx, mouse.SelectOne)
+	return true
+}
+
+// ScrollToIdxNoUpdt ensures that given idx is visible by scrolling display
+// as needed -- does not update the grid, just computes StartIdx and
+// updates the scrollbar
+func (vw *VirtTreeView) ScrollToIdxNoUpdt(idx int) bool {
+	if vw.DispRows == 0 {
+		return false
+	}
+	if idx < vw.StartIdx {
+		vw.StartIdx = ints.MaxInt(0, idx)
+		vw.UpdateScroll()
+		return true
+	} else if idx >= vw.StartIdx+vw.DispRows {
+		vw.StartIdx = ints.MaxInt(0, idx-(vw.DispRows-1))
+		vw.UpdateScroll()
+		return true
+	}
+	return false
+}
+
+// ScrollToIdx ensures that given idx is visible by scrolling display as needed
+func (vw *VirtTreeView) ScrollToIdx(idx int) bool {
+	updt := vw.ScrollToIdxNoUpdt(idx)
+	if updt {
+		vw.UpdateGrid()
+	}
+	return updt
+}
+
+func (vw *VirtTreeView) AccessInfo2D(ai *gi.AccessInfo) {
+	vw.Frame.AccessInfo2D(ai)
+	ai.Role = gi.AccessTreeView
+}
+
+// MoveAction moves the current selection by given delta, using given
+// selection mode -- returns new index
+func (vw *VirtTreeView) MoveAction(del int, selMode mouse.SelectModes) int {
+	if len(vw.Nodes) == 0 {
+		return -1
+	}
+	idx := vw.SelectedIdx + del
+	idx = ints.MaxInt(0, ints.MinInt(idx, len(vw.Nodes)-1))
+	vw.SelectIdxAction(idx, selMode)
+	return idx
+}
+
+// MoveLeftAction closes the current item if open, else moves to its parent
+func (vw *VirtTreeView) MoveLeftAction() {
+	idx := vw.SelectedIdx
+	if idx < 0 || idx >= len(vw.Nodes) {
+		return
+	}
+	if vw.IsItemOpen(vw.Nodes[idx].Item) {
+		vw.CloseIdx(idx)
+		return
+	}
+	if pi := vw.ParentIdx(idx); pi >= 0 {
+		vw.SelectIdxAction(pi, mouse.SelectOne)
+	}
+}
+
+// MoveRightAction opens the current item if closed, else moves to its first child
+func (vw *VirtTreeView) MoveRightAction() {
+	idx := vw.SelectedIdx
+	if idx < 0 || idx >= len(vw.Nodes) {
+		return
+	}
+	if !vw.IsItemOpen(vw.Nodes[idx].Item) {
+		vw.OpenIdx(idx)
+		return
+	}
+	if idx+1 < len(vw.Nodes) && vw.Nodes[idx+1].Depth > vw.Nodes[idx].Depth {
+		vw.SelectIdxAction(idx+1, mouse.SelectOne)
+	}
+}
+
+//////////////////////////////////////////////////////////////////////////////
+//  Row positions
+
+// IsIdxVisible returns true if item at given index is currently displayed
+func (vw *VirtTreeView) IsIdxVisible(idx int) bool {
+	return idx >= vw.StartIdx && idx < vw.StartIdx+vw.DispRows
+}
+
+// RowWidget returns the row widget at given display row, false if out of range
+func (vw *VirtTreeView) RowWidget(row int) (*VirtTreeRow, bool) {
+	sg := vw.RowGrid()
+	if row < 0 || row >= vw.DispRows || row >= len(sg.Kids) || sg.Kids[row] == nil {
+		return nil, false
+	}
+	return sg.Kids[row].(*VirtTreeRow), true
+}
+
+// IdxPos returns the window position of the row for given index, for
+// popup menus etc
+func (vw *VirtTreeView) IdxPos(idx int) image.Point {
+	row := ints.MaxInt(0, ints.MinInt(idx-vw.StartIdx, vw.DispRows-1))
+	var pos image.Point
+	if vr, ok := vw.RowWidget(row); ok {
+		pos = vr.ContextMenuPos()
+	}
+	return pos
+}
+
+// IdxFromPos returns the index of the row that contains given vertical
+// position, false if not found
+func (vw *VirtTreeView) IdxFromPos(posY int) (int, bool) {
+	for rw := 0; rw < vw.DispRows; rw++ {
+		if vr, ok := vw.RowWidget(rw); ok {
+			if vr.WinBBox.Min.Y < posY && posY < vr.WinBBox.Max.Y {
+				return rw + vw.StartIdx, true
+			}
+		}
+	}
+	return -1, false
+}
+
+//////////////////////////////////////////////////////////////////////////////
+//    Copy / Cut / Paste
+
+// Editor returns the source as a TreeSourceEditor, if it supports editing
+// and the view is not inactive -- else nil
+func (vw *VirtTreeView) Editor() TreeSourceEditor {
+	if vw.IsInactive() {
+		return nil
+	}
+	ed, _ := vw.Src.(TreeSourceEditor)
+	return ed
+}
+
+// SelToMime returns mime data for the selected items (current item first)
+func (vw *VirtTreeView) SelToMime() mimedata.Mimes {
+	ed, ok := vw.Src.(TreeSourceEditor)
+	if !ok {
+		return nil
+	}
+	sels := vw.SelectedItemsList()
+	md := make(mimedata.Mimes, 0, 2*len(sels))
+	cur := vw.SelectedItem()
+	if cur != nil {
+		ed.TreeMimeData(cur, &md) // source is always first..
+	}
+	for _, it := range sels {
+		if it != cur {
+			ed.TreeMimeData(it, &md)
+		}
+	}
+	return md
+}
+
+// Copy copies selected items to clip.Board
+func (vw *VirtTreeView) Copy(reset bool) {
+	md := vw.SelToMime()
+	if len(md) == 0 {
+		return
+	}
+	oswin.TheApp.ClipBoard(vw.ParentWindow().OSWin).Write(md)
+	if reset {
+		vw.UnselectAll()
+	}
+}
+
+// Cut copies to clip.Board and deletes selected items
+func (vw *VirtTreeView) Cut() {
+	if vw.Editor() == nil {
+		return
+	}
+	vw.Copy(false)
+	vw.DeleteSel()
+}
+
+// DeleteSel deletes the selected items from the source
+func (vw *VirtTreeView) DeleteSel() {
+	ed := vw.Editor()
+	if ed == nil {
+		return
+	}
+	sels := vw.SelectedItemsList()
+	vw.SelectedItems = make(map[interface{}]struct{})
+	ed.TreeDelete(sels)
+	vw.SetChanged()
+}
+
+// Paste pastes clipboard at current item, via a menu of options
+func (vw *VirtTreeView) Paste() {
+	if vw.Editor() == nil {
+		return
+	}
+	md := oswin.TheApp.ClipBoard(vw.ParentWindow().OSWin).Read([]string{filecat.DataJson})
+	if md != nil {
+		vw.PasteMenu(md, vw.SelectedIdx)
+	}
+}
+
+// MakePasteMenu makes the menu of options for paste events
+func (vw *VirtTreeView) MakePasteMenu(m *gi.Menu, data interface{}, idx int) {
+	if len(*m) > 0 {
+		return
+	}
+	m.AddAction(gi.ActOpts{Label: "Add to Children", Data: data}, vw.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+		vww := recv.Embed(KiT_VirtTreeView).(*VirtTreeView)
+		vww.PasteAt(data.(mimedata.Mimes), idx, -1)
+	})
+	if idx > 0 {
+		m.AddAction(gi.ActOpts{Label: "Insert Before", Data: data}, vw.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+			vww := recv.Embed(KiT_VirtTreeView).(*VirtTreeView)
+			vww.PasteAt(data.(mimedata.Mimes), idx, 0)
+		})
+		m.AddAction(gi.ActOpts{Label: "Insert After", Data: data}, vw.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+			vww := recv.Embed(KiT_VirtTreeView).(*VirtTreeView)
+			vww.PasteAt(data.(mimedata.Mimes), idx, 1)
+		})
+	}
+	m.AddAction(gi.ActOpts{Label: "Cancel", Data: data}, vw.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+	})
+}
+
+// PasteMenu performs a paste from the clipboard using given data -- pops up
+// a menu to determine what specifically to do
+func (vw *VirtTreeView) PasteMenu(md mimedata.Mimes, idx int) {
+	var men gi.Menu
+	vw.MakePasteMenu(&men, md, idx)
+	pos := vw.IdxPos(idx)
+	gi.PopupMenu(men, pos.X, pos.Y, vw.ViewportSafe(), "vtvPasteMenu")
+}
+
+// PasteAt inserts items from mime data relative to item at given index:
+// rel = 0 = before, 1 = after, -1 = add to children -- the last inserted
+// item is selected
+func (vw *VirtTreeView) PasteAt(md mimedata.Mimes, idx int, rel int) {
+	ed := vw.Editor()
+	if ed == nil || idx < 0 || idx >= len(vw.Nodes) {
+		return
+	}
+	item := vw.Nodes[idx].Item
+	var last interface{}
+	if rel < 0 {
+		last = ed.TreePasteChildren(md, item)
+		vw.OpenItems[item] = struct{}{}
+	} else {
+		last = ed.TreePasteAt(md, item, rel)
+	}
+	vw.SetChanged()
+	if last != nil {
+		vw.SelectItem(last)
+	}
+}
+
+// SetChanged is called after edits -- refreshes the display if the source
+// does not notify of changes, and emits TreeViewChanged
+func (vw *VirtTreeView) SetChanged() {
+	if _, ok := vw.Src.(TreeSourceNotifier); !ok {
+		vw.Refresh()
+	}
+	vw.TreeViewSig.Emit(vw.This(), int64(TreeViewChanged), nil)
+}
+
+//////////////////////////////////////////////////////////////////////////////
+//    Drag-n-Drop
+
+// DragNDropStart starts a drag-n-drop of the selected items
+func (vw *VirtTreeView) DragNDropStart() {
+	if vw.Editor() == nil || len(vw.SelectedItems) == 0 {
+		return
+	}
+	md := vw.SelToMime()
+	vr, ok := vw.RowWidget(vw.SelectedIdx - vw.StartIdx)
+	if !ok {
+		return
+	}
+	sp := &gi.Sprite{}
+	sp.GrabRenderFrom(vr)
+	gi.ImageClearer(sp.Pixels, 50.0)
+	vw.ParentWindow().StartDragNDrop(vw.This(), md, sp)
+}
+
+// DragNDropTarget handles a drag-n-drop drop
+func (vw *VirtTreeView) DragNDropTarget(de *dnd.Event) {
+	de.Target = vw.This()
+	if de.Mod == dnd.DropLink {
+		de.Mod = dnd.DropCopy // link not supported -- revert to copy
+	}
+	idx, ok := vw.IdxFromPos(de.Where.Y)
+	if ok {
+		de.SetProcessed()
+		vw.CurIdx = idx
+		if dpr, ok := vw.This().(gi.DragNDropper); ok {
+			dpr.Drop(de.Data, de.Mod)
+		} else {
+			vw.Drop(de.Data, de.Mod)
+		}
+	}
+}
+
+// MakeDropMenu makes the menu of options for dropping on a target
+func (vw *VirtTreeView) MakeDropMenu(m *gi.Menu, data interface{}, mod dnd.DropMods, idx int) {
+	if len(*m) > 0 {
+		return
+	}
+	switch mod {
+	case dnd.DropCopy:
+		m.AddLabel("Copy (Shift=Move):")
+	case dnd.DropMove:
+		m.AddLabel("Move:")
+	}
+	m.AddAction(gi.ActOpts{Label: "Add to Children", Data: data}, vw.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+		vww := recv.Embed(KiT_VirtTreeView).(*VirtTreeView)
+		vww.DropAt(data.(mimedata.Mimes), mod, idx, -1)
+	})
+	if idx > 0 {
+		m.AddAction(gi.ActOpts{Label: "Insert Before", Data: data}, vw.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+			vww := recv.Embed(KiT_VirtTreeView).(*VirtTreeView)
+			vww.DropAt(data.(mimedata.Mimes), mod, idx, 0)
+		})
+		m.AddAction(gi.ActOpts{Label: "Insert After", Data: data}, vw.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+			vww := recv.Embed(KiT_VirtTreeView).(*VirtTreeView)
+			vww.DropAt(data.(mimedata.Mimes), mod, idx, 1)
+		})
+	}
+	m.AddAction(gi.ActOpts{Label: "Cancel", Data: data}, vw.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+		vww := recv.Embed(KiT_VirtTreeView).(*VirtTreeView)
+		vww.DropCancel()
+	})
+}
+
+// Drop pops up a menu to determine what specifically to do with dropped items
+// this satisfies gi.DragNDropper interface, and can be overwritten in subtypes
+func (vw *VirtTreeView) Drop(md mimedata.Mimes, mod dnd.DropMods) {
+	var men gi.Menu
+	vw.MakeDropMenu(&men, md, mod, vw.CurIdx)
+	pos := vw.IdxPos(vw.CurIdx)
+	gi.PopupMenu(men, pos.X, pos.Y, vw.ViewportSafe(), "vtvDropMenu")
+}
+
+// DropAt inserts dropped items relative to item at given index (see PasteAt)
+func (vw *VirtTreeView) DropAt(md mimedata.Mimes, mod dnd.DropMods, idx int, rel int) {
+	vw.PasteAt(md, idx, rel)
+	vw.DragNDropFinalize(mod)
+}
+
+// DropCancel cancels the drop action e.g., preventing deleting of source
+// items in a Move case
+func (vw *VirtTreeView) DropCancel() {
+	vw.DragNDropFinalize(dnd.DropIgnore)
+}
+
+// DragNDropFinalize is called to finalize actions on the Source node prior to
+// performing target actions -- mod must indicate actual action taken by the
+// target, including ignore -- ends up calling DragNDropSource if us..
+func (vw *VirtTreeView) DragNDropFinalize(mod dnd.DropMods) {
+	vw.ParentWindow().FinalizeDragNDrop(mod)
+}
+
+// DragNDropSource is called after target accepts the drop -- we just remove
+// elements that were moved
+func (vw *VirtTreeView) DragNDropSource(de *dnd.Event) {
+	ed := vw.Editor()
+	if de.Mod != dnd.DropMove || ed == nil {
+		return
+	}
+	ed.TreeDragged(de.Data)
+	vw.SetChanged()
+}
+
+//////////////////////////////////////////////////////////////////////////////
+//    Events
+
+// StdCtxtMenu makes the standard context menu for item at given index
+func (vw *VirtTreeView) StdCtxtMenu(m *gi.Menu, idx int) {
+	m.AddAction(gi.ActOpts{Label: "Open All", Data: idx},
+		vw.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+			vww := recv.Embed(KiT_VirtTreeView).(*VirtTreeView)
+			vww.OpenAll(data.(int))
+		})
+	m.AddAction(gi.ActOpts{Label: "Close All", Data: idx},
+		vw.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+			vww := recv.Embed(KiT_VirtTreeView).(*VirtTreeView)
+			vww.CloseAll(data.(int))
+		})
+	if _, ok := vw.Src.(TreeSourceEditor); !ok {
+		return
+	}
+	m.AddSeparator("sep-edit")
+	m.AddAction(gi.ActOpts{Label: "Copy", Data: idx},
+		vw.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+			vww := recv.Embed(KiT_VirtTreeView).(*VirtTreeView)
+			vww.Copy(true)
+		})
+	if vw.Editor() == nil {
+		return
+	}
+	m.AddAction(gi.ActOpts{Label: "Cut", Data: idx},
+		vw.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+			vww := recv.Embed(KiT_VirtTreeView).(*VirtTreeView)
+			vww.Cut()
+		})
+	m.AddAction(gi.ActOpts{Label: "Paste", Data: idx},
+		vw.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+			vww := recv.Embed(KiT_VirtTreeView).(*VirtTreeView)
+			vww.Paste()
+		})
+	m.AddAction(gi.ActOpts{Label: "Delete", Data: idx},
+		vw.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+			vww := recv.Embed(KiT_VirtTreeView).(*VirtTreeView)
+			vww.DeleteSel()
+		})
+}
+
+// MakeContextMenu makes the context menu for the item at CurIdx: the
+// standard menu followed by any CtxtMenuFunc items -- derived types can
+// override this to provide their own menu
+func (vw *VirtTreeView) MakeContextMenu(m *gi.Menu) {
+	vw.StdCtxtMenu(m, vw.CurIdx)
+	if vw.CtxtMenuFunc != nil {
+		vw.CtxtMenuFunc(vw.This().(gi.Node2D), m)
+	}
+}
+
+// ItemCtxtMenu pulls up the context menu for item at given index
+func (vw *VirtTreeView) ItemCtxtMenu(idx int) {
+	if idx < 0 || idx >= len(vw.Nodes) {
+		return
+	}
+	vw.CurIdx = idx
+	var men gi.Menu
+	vw.This().(gi.Node2D).MakeContextMenu(&men)
+	if len(men) > 0 {
+		pos := vw.IdxPos(idx)
+		gi.PopupMenu(men, pos.X, pos.Y, vw.ViewportSafe(), vw.Nm+"-menu")
+	}
+}
+
+func (vw *VirtTreeView) KeyInput(kt *key.ChordEvent) {
+	if gi.KeyEventTrace {
+		fmt.Printf("VirtTreeView KeyInput: %v\n", vw.PathUnique())
+	}
+	kf := gi.KeyFun(kt.Chord())
+	selMode := mouse.SelectModeBits(kt.Modifiers)
+	if selMode == mouse.SelectOne {
+		if vw.SelectMode {
+			selMode = mouse.ExtendContinuous
+		}
+	}
+
+	switch kf {
+	case gi.KeyFunCancelSelect:
+		vw.UnselectAll()
+		vw.SelectMode = false
+		kt.SetProcessed()
+	case gi.KeyFunMoveRight:
+		vw.MoveRightAction()
+		kt.SetProcessed()
+	case gi.KeyFunMoveLeft:
+		vw.MoveLeftAction()
+		kt.SetProcessed()
+	case gi.KeyFunMoveDown:
+		vw.MoveAction(1, selMode)
+		kt.SetProcessed()
+	case gi.KeyFunMoveUp:
+		vw.MoveAction(-1, selMode)
+		kt.SetProcessed()
+	case gi.KeyFunPageDown:
+		vw.MoveAction(ints.MaxInt(1, vw.VisRows-1), selMode)
+		kt.SetProcessed()
+	case gi.KeyFunPageUp:
+		vw.MoveAction(-ints.MaxInt(1, vw.VisRows-1), selMode)
+		kt.SetProcessed()
+	case gi.KeyFunHome:
+		vw.MoveAction(-len(vw.Nodes), selMode)
+		kt.SetProcessed()
+	case gi.KeyFunEnd:
+		vw.MoveAction(len(vw.Nodes), selMode)
+		kt.SetProcessed()
+	case gi.KeyFunSelectMode:
+		vw.SelectMode = !vw.SelectMode
+		kt.SetProcessed()
+	case gi.KeyFunSelectAll:
+		vw.SelectAll()
+		vw.SelectMode = false
+		kt.SetProcessed()
+	case gi.KeyFunEnter:
+		vw.ToggleOpenIdx(vw.SelectedIdx)
+		kt.SetProcessed()
+	case gi.KeyFunCopy:
+		vw.Copy(true)
+		kt.SetProcessed()
+	}
+	if vw.Editor() != nil && !kt.IsProcessed() {
+		switch kf {
+		case gi.KeyFunDelete:
+			vw.DeleteSel()
+			kt.SetProcessed()
+		case gi.KeyFunCut:
+			vw.Cut()
+			kt.SetProcessed()
+		case gi.KeyFunPaste:
+			vw.Paste()
+			kt.SetProcessed()
+		}
+	}
+}
+
+func (vw *VirtTreeView) VirtTreeViewEvents() {
+	// LowPri to allow other focal widgets to capture
+	vw.ConnectEvent(oswin.MouseScrollEvent, gi.LowPri, func(recv, send ki.Ki, sig int64, d interface{}) {
+		me := d.(*mouse.ScrollEvent)
+		vww := recv.Embed(KiT_VirtTreeView).(*VirtTreeView)
+		me.SetProcessed()
+		sbb := vww.ScrollBar()
+		cur := float32(sbb.Pos)
+		sbb.SliderMove(cur, cur+float32(me.NonZeroDelta(false))) // preferY
+	})
+	vw.ConnectEvent(oswin.KeyChordEvent, gi.HiPri, func(recv, send ki.Ki, sig int64, d interface{}) {
+		vww := recv.Embed(KiT_VirtTreeView).(*VirtTreeView)
+		kt := d.(*key.ChordEvent)
+		vww.KeyInput(kt)
+	})
+	vw.ConnectEvent(oswin.DNDEvent, gi.RegPri, func(recv, send ki.Ki, sig int64, d interface{}) {
+		de := d.(*dnd.Event)
+		vww := recv.Embed(KiT_VirtTreeView).(*VirtTreeView)
+		switch de.Action {
+		case dnd.Start:
+			vww.DragNDropStart()
+		case dnd.DropOnTarget:
+			vww.DragNDropTarget(de)
+		case dnd.DropFmSource:
+			vww.DragNDropSource(de)
+		}
+	})
+	sg := vw.RowGrid()
+	sg.ConnectEvent(oswin.DNDFocusEvent, gi.RegPri, func(recv, send ki.Ki, sig int64, d interface{}) {
+		de := d.(*dnd.FocusEvent)
+		sgg := recv.Embed(gi.KiT_Frame).(*gi.Frame)
+		switch de.Action {
+		case dnd.Enter:
+			sgg.ParentWindow().DNDSetCursor(de.Mod)
+		case dnd.Exit:
+			sgg.ParentWindow().DNDNotCursor()
+		}
+	})
+}
+
+func (vw *VirtTreeView) ConnectEvents2D() {
+	vw.VirtTreeViewEvents()
+}
+
+func (vw *VirtTreeView) HasFocus2D() bool {
+	return vw.ContainsFocus()
+}
+
+func (vw *VirtTreeView) Style2D() {
+	vw.Frame.Style2D()
+	vw.Indent.SetFmInheritProp("indent", vw.This(), ki.NoInherit, ki.TypeProps)
+	vw.Indent.ToDots(&vw.Sty.UnContext)
+	vw.SetCanFocus()
+	if !vw.IsConfiged() {
+		return
+	}
+	mvp := vw.ViewportSafe()
+	if mvp != nil && (mvp.IsDoingFullRender() || mvp.HasFlag(int(gi.VpFlagPrefSizing))) {
+		if vw.LayoutGrid() {
+			vw.UpdateGrid()
+		}
+	}
+}
+
+func (vw *VirtTreeView) Render2D() {
+	if !vw.IsConfiged() {
+		return
+	}
+	if !vw.GridNeedsLayout() && vw.FullReRenderIfNeeded() {
+		return
+	}
+	if vw.PushBounds() {
+		if !vw.InFullRebuild && vw.GridNeedsLayout() {
+			// note: same logic as SliceViewBase -- we only know the size of
+			// the grid at this point, so we rebuild the rows as needed here
+			vw.RenderedRows = vw.DispRows
+			vw.LayoutGrid()
+			if vw.SelectedIdx > -1 {
+				vw.ScrollToIdxNoUpdt(vw.SelectedIdx)
+			}
+			vw.UpdateGrid()
+			vw.InFullRebuild = true
+			vw.ReRender2DTree()
+			vw.InFullRebuild = false
+			vw.PopBounds()
+			return
+		}
+		vw.FrameStdRender()
+		vw.This().(gi.Node2D).ConnectEvents2D()
+		vw.RenderScrolls()
+		vw.Render2DChildren()
+		vw.PopBounds()
+	} else {
+		vw.DisconnectAllEvents(gi.AllPris)
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////
+//  VirtTreeRow
+
+// VirtTreeRow is a single display row in a VirtTreeView -- it renders
+// whatever item is at its row position in the view's current scroll window,
+// so rows are recycled as the view scrolls
+type VirtTreeRow struct {
+	gi.WidgetBase
+	View   *VirtTreeView `copy:"-" json:"-" xml:"-" view:"-" desc:"the view that we are a row of"`
+	Row    int           `copy:"-" json:"-" xml:"-" desc:"display row index"`
+	Render gi.TextRender `copy:"-" json:"-" xml:"-" desc:"render data for the label text"`
+}
+
+var KiT_VirtTreeRow = kit.Types.AddType(&VirtTreeRow{}, VirtTreeRowProps)
+
+var VirtTreeRowProps = ki.Props{
+	"EnumType:Flag": gi.KiT_NodeFlags,
+	"padding":       units.NewPx(1),
+	"margin":        units.NewPx(0),
+	"border-width":  units.NewPx(0),
+	"max-width":     -1,
+}
+
+// Node returns the VirtTreeNode currently displayed in this row, false if
+// the row is beyond the end of the visible items
+func (vr *VirtTreeRow) Node() (VirtTreeNode, int, bool) {
+	vw := vr.View
+	if vw == nil {
+		return VirtTreeNode{}, -1, false
+	}
+	idx := vw.StartIdx + vr.Row
+	if idx < 0 || idx >= len(vw.Nodes) {
+		return VirtTreeNode{}, idx, false
+	}
+	return vw.Nodes[idx], idx, true
+}
+
+// BranchRight returns the right-most window x coordinate of the branch
+// (open / close) control for given depth
+func (vr *VirtTreeRow) BranchRight(depth int) int {
+	ind := vr.View.Indent.Dots
+	return vr.WinBBox.Min.X + int(float32(depth)*ind+vr.Sty.Font.Face.Metrics.Height+vr.Sty.BoxSpace())
+}
+
+func (vr *VirtTreeRow) Size2D(iter int) {
+	vr.InitLayout2D()
+	if vr.Sty.Font.Face == nil {
+		vr.Sty.Font.OpenFont(&vr.Sty.UnContext)
+	}
+	vr.Size2DFromWH(0, vr.Sty.Font.Face.Metrics.Height)
+}
+
+// RenderRow renders the row: background, branch, and label
+func (vr *VirtTreeRow) RenderRow() {
+	rs, pc, st := vr.RenderLock()
+	defer vr.RenderUnlock(rs)
+
+	nd, idx, ok := vr.Node()
+	pos := vr.LayState.Alloc.Pos
+	sz := vr.LayState.Alloc.Size
+	if !ok {
+		return
+	}
+	vw := vr.View
+	if vw.IdxIsSelected(idx) {
+		pc.FillBoxColor(rs, pos, sz, &gi.Prefs.Colors.Select)
+	}
+	if st.Font.Face == nil {
+		return
+	}
+	ht := st.Font.Face.Metrics.Height
+	tpos := pos.AddScalar(st.BoxSpace())
+	tpos.X += float32(nd.Depth) * vw.Indent.Dots
+	if vw.Src.TreeHasChildren(nd.Item) {
+		cx := tpos.X + 0.5*ht
+		cy := tpos.Y + 0.5*ht
+		r := 0.25 * ht
+		pc.StrokeStyle.SetColor(nil)
+		pc.FillStyle.SetColor(&st.Font.Color)
+		if vw.IsItemOpen(nd.Item) {
+			pc.MoveTo(rs, cx-r, cy-0.5*r)
+			pc.LineTo(rs, cx+r, cy-0.5*r)
+			pc.LineTo(rs, cx, cy+0.7*r)
+		} else {
+			pc.MoveTo(rs, cx-0.5*r, cy-r)
+			pc.LineTo(rs, cx-0.5*r, cy+r)
+			pc.LineTo(rs, cx+0.7*r, cy)
+		}
+		pc.ClosePath(rs)
+		pc.Fill(rs)
+	}
+	tpos.X += ht
+	fs := &st.Font
+	if sty, ok := vw.Src.(TreeSourceStyler); ok {
+		ifs := st.Font
+		sty.TreeStyle(nd.Item, &ifs)
+		if ifs.Style != st.Font.Style || ifs.Weight != st.Font.Weight {
+			ifs.OpenFont(&st.UnContext)
+		}
+		fs = &ifs
+	}
+	vr.Render.SetString(vw.Src.TreeLabel(nd.Item), fs, &st.UnContext, &st.Text, true, 0, 1)
+	vr.Render.Render(rs, tpos)
+}
+
+// MouseEvent handles mouse events on the row
+func (vr *VirtTreeRow) MouseEvent(me *mouse.Event) {
+	nd, idx, ok := vr.Node()
+	if !ok {
+		return
+	}
+	vw := vr.View
+	switch {
+	case me.Button == mouse.Left && me.Action == mouse.Press:
+		me.SetProcessed()
+		vw.GrabFocus()
+		if me.Where.X < vr.BranchRight(nd.Depth) && vw.Src.TreeHasChildren(nd.Item) {
+			vw.ToggleOpenIdx(idx)
+		} else {
+			vw.SelectIdxAction(idx, me.SelectMode())
+		}
+	case me.Button == mouse.Left && me.Action == mouse.DoubleClick:
+		me.SetProcessed()
+		vw.ToggleOpenIdx(idx)
+	case me.Button == mouse.Right && me.Action == mouse.Release:
+		me.SetProcessed()
+		if !vw.IdxIsSelected(idx) {
+			vw.SelectIdxAction(idx, mouse.SelectOne)
+		}
+		vw.ItemCtxtMenu(idx)
+	}
+}
+
+func (vr *VirtTreeRow) ConnectEvents2D() {
+	vr.ConnectEvent(oswin.MouseEvent, gi.RegPri, func(recv, send ki.Ki, sig int64, d interface{}) {
+		me := d.(*mouse.Event)
+		vrr := recv.Embed(KiT_VirtTreeRow).(*VirtTreeRow)
+		vrr.MouseEvent(me)
+	})
+}
+
+// AccessInfo2D exposes the row as the item of the tree currently shown in it
+func (vr *VirtTreeRow) AccessInfo2D(ai *gi.AccessInfo) {
+	vr.WidgetBase.AccessInfo2D(ai)
+	nd, idx, ok := vr.Node()
+	if !ok || vr.View.Src == nil {
+		return
+	}
+	vw := vr.View
+	ai.Role = gi.AccessTreeItem
+	ai.Label = vw.Src.TreeLabel(nd.Item)
+	ai.Value = fmt.Sprintf("level %d", nd.Depth+1)
+	ai.SetState(true, gi.AccessSelectable)
+	ai.SetState(vw.IdxIsSelected(idx), gi.AccessSelected)
+	ai.Actions = []string{gi.AccessActSelect}
+	if vw.Src.TreeHasChildren(nd.Item) {
+		open := vw.IsItemOpen(nd.Item)
+		ai.SetState(true, gi.AccessExpandable)
+		ai.SetState(open, gi.AccessExpanded)
+		if open {
+			ai.Actions = append(ai.Actions, gi.AccessActCollapse)
+		} else {
+			ai.Actions = append(ai.Actions, gi.AccessActExpand)
+		}
+	}
+}
+
+func (vr *VirtTreeRow) AccessAction2D(act string) bool {
+	_, idx, ok := vr.Node()
+	if !ok {
+		return false
+	}
+	switch act {
+	case gi.AccessActSelect:
+		vr.View.SelectIdxAction(idx, mouse.SelectOne)
+	case gi.AccessActExpand:
+		vr.View.OpenIdx(idx)
+	case gi.AccessActCollapse:
+		vr.View.CloseIdx(idx)
+	default:
+		return false
+	}
+	return true
+}
+
+func (vr *VirtTreeRow) Render2D() {
+	if vr.FullReRenderIfNeeded() {
+		return
+	}
+	if vr.PushBounds() {
+		vr.This().(gi.Node2D).ConnectEvents2D()
+		vr.RenderRow()
+		vr.PopBounds()
+	} else {
+		vr.DisconnectAllEvents(gi.RegPri)
+	}
+}
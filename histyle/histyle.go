@@ -356,7 +356,7 @@ var StyleProps = ki.Props{
 				},
 			}},
 		}},
-		{"Edit", "Copy Cut Paste Dupe"},
+		{"Edit", "Undo Redo Copy Cut Paste Dupe"},
 		{"Window", "Windows"},
 	},
 	"ToolBar": ki.PropSlice{
@@ -232,7 +232,7 @@ var StylesProps = ki.Props{
 				},
 			}},
 		}},
-		{"Edit", "Copy Cut Paste Dupe"},
+		{"Edit", "Undo Redo Copy Cut Paste Dupe"},
 		{"Window", "Windows"},
 	},
 	"ToolBar": ki.PropSlice{
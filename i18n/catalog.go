@@ -0,0 +1,141 @@
+// Copyright (c) 2018, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package i18n
+
+// Message is one message of a catalog: the untranslated message id (with
+// its plural form and context if any), and its translations
+type Message struct {
+	Ctx      string   `desc:"context of the message, to distinguish the same text used with different meanings -- empty for none"`
+	ID       string   `desc:"the untranslated text, which identifies the message"`
+	IDPlural string   `desc:"the untranslated plural form of the text, for messages that depend on a count -- empty for none"`
+	Strs     []string `desc:"the translations -- one for each plural form if IDPlural is set, in the order of the plural rule of the catalog, otherwise just one"`
+	Refs     []string `desc:"references to where the message is used in the source code, as file:line"`
+	Comments []string `desc:"comments for translators, extracted from the source code"`
+	Fuzzy    bool     `desc:"the translation needs review, and is not used"`
+}
+
+// Key returns the key of the message in its catalog
+func (m *Message) Key() string {
+	return MsgKey(m.Ctx, m.ID)
+}
+
+// MsgKey returns the key for a message with given context and id -- as in
+// gettext, the context is separated from the id by an EOT character
+func MsgKey(ctx, id string) string {
+	if ctx == "" {
+		return id
+	}
+	return ctx + "\x04" + id
+}
+
+// Catalog is a message catalog: the translations of messages into one
+// language, as read from a gettext PO file
+type Catalog struct {
+	Lang     string              `desc:"language of the translations, as a locale name such as de or pt_BR"`
+	Header   map[string]string   `desc:"header fields, such as Language and Plural-Forms"`
+	NPlurals int                 `desc:"number of plural forms in the language"`
+	Plural   *PluralRule         `desc:"rule selecting the plural form for a count"`
+	Msgs     map[string]*Message `desc:"messages by key (see MsgKey)"`
+	Order    []string            `desc:"keys of the messages, in the order they were added -- used when writing"`
+}
+
+// NewCatalog returns a new empty catalog for given language, with the
+// default plural rule of the language
+func NewCatalog(lang string) *Catalog {
+	ct := &Catalog{Lang: lang}
+	ct.Header = make(map[string]string)
+	ct.Msgs = make(map[string]*Message)
+	ct.NPlurals, ct.Plural = DefaultPlural(lang)
+	return ct
+}
+
+// Add adds given message to the catalog, replacing any existing message
+// with the same key, and returns it
+func (ct *Catalog) Add(m *Message) *Message {
+	key := m.Key()
+	if _, has := ct.Msgs[key]; !has {
+		ct.Order = append(ct.Order, key)
+	}
+	ct.Msgs[key] = m
+	return m
+}
+
+// Ref records a use of the message with given context, id and plural id at
+// given reference (file:line), adding the message if not already in the
+// catalog -- used for extracting messages
+func (ct *Catalog) Ref(ctx, id, idPlural, ref string) *Message {
+	m, has := ct.Msgs[MsgKey(ctx, id)]
+	if !has {
+		m = ct.Add(&Message{Ctx: ctx, ID: id})
+	}
+	if idPlural != "" && m.IDPlural == "" {
+		m.IDPlural = idPlural
+	}
+	if ref != "" {
+		for _, r := range m.Refs {
+			if r == ref {
+				return m
+			}
+		}
+		m.Refs = append(m.Refs, ref)
+	}
+	return m
+}
+
+// Merge adds all the translated messages of the other catalog to this one,
+// replacing existing ones
+func (ct *Catalog) Merge(oc *Catalog) {
+	for _, key := range oc.Order {
+		m := oc.Msgs[key]
+		if m.Fuzzy || len(m.Strs) == 0 || m.Strs[0] == "" {
+			continue
+		}
+		ct.Add(m)
+	}
+}
+
+// SetPluralForms sets the plural rule from a Plural-Forms header value, such
+// as "nplurals=2; plural=(n != 1);"
+func (ct *Catalog) SetPluralForms(pf string) error {
+	np, pr, err := ParsePluralForms(pf)
+	if err != nil {
+		return err
+	}
+	ct.NPlurals, ct.Plural = np, pr
+	ct.Header["Plural-Forms"] = pf
+	return nil
+}
+
+// Lookup returns the translation of the message with given context and id,
+// and true if found -- otherwise the id and false
+func (ct *Catalog) Lookup(ctx, id string) (string, bool) {
+	if ct != nil && id != "" {
+		if m, ok := ct.Msgs[MsgKey(ctx, id)]; ok && !m.Fuzzy && len(m.Strs) > 0 && m.Strs[0] != "" {
+			return m.Strs[0], true
+		}
+	}
+	return id, false
+}
+
+// LookupN returns the translation of the message with given context, id and
+// plural id for given count, and true if found -- otherwise the id for a
+// count of 1 and the plural id for other counts, and false
+func (ct *Catalog) LookupN(ctx, id, idPlural string, n int) (string, bool) {
+	if ct != nil && id != "" {
+		if m, ok := ct.Msgs[MsgKey(ctx, id)]; ok && !m.Fuzzy && len(m.Strs) > 0 {
+			pi := 0
+			if ct.Plural != nil {
+				pi = ct.Plural.Index(n)
+			}
+			if pi < len(m.Strs) && m.Strs[pi] != "" {
+				return m.Strs[pi], true
+			}
+		}
+	}
+	if n == 1 {
+		return id, false
+	}
+	return idPlural, false
+}
@@ -0,0 +1,31 @@
+// Copyright (c) 2018, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package i18n provides the translation of user interface strings, using
+message catalogs in the gettext PO format, with plural forms and runtime
+switching of the locale.
+
+Strings are written in English in the source code, and translated with Tr,
+TrCtx for strings whose meaning depends on a context, or TrN for strings
+that depend on a count:
+
+	lbl.SetText(i18n.Tr("Untitled"))
+	msg := fmt.Sprintf(i18n.TrN("%d file deleted", "%d files deleted", n), n)
+
+Strings without a translation are returned as-is.  In GoGi, the standard
+dialog buttons, menus, MethView actions and StructView field labels are
+translated automatically, and re-labeled when the locale changes (see
+gi.TrText).
+
+Translations are loaded from PO files with LoadDir or OpenPO and
+AddCatalog.  The locale is the system locale (from LANG etc) by default,
+and is changed with SetLocale, which calls the functions registered with
+OnChange -- GoGi uses that to re-label all open windows.
+
+The gixtract command extracts the strings to translate from the source into
+a PO template, including the labels of ki.Props menus and toolbars and of
+struct fields -- see Extractor.
+*/
+package i18n
@@ -0,0 +1,299 @@
+// Copyright (c) 2018, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package i18n
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/camelcase"
+)
+
+// Extractor extracts the messages to translate from Go source code into a
+// Catalog, which can be written as a PO template for translators.  It
+// finds the string literals given to:
+//
+//   - the Tr, TrCtx and TrN functions, and gi.TrText and TrTooltip
+//   - the Label, Tooltip, Title and Prompt of ActOpts, DlgOpts and other
+//     struct literals (menu and toolbar actions, dialogs)
+//   - the choices of gi.ChoiceDialog
+//   - the "label" and "desc" entries of ki.Props, e.g., for the methods in
+//     ToolBar and MainMenu type properties -- and the labels that giv.MethView
+//     derives from the method names, where there is no "label"
+//   - the label tags of struct fields, and optionally the names of exported
+//     fields, which StructView shows as labels for fields without a label tag
+type Extractor struct {
+	Cat    *Catalog `desc:"catalog the messages are added to"`
+	Fields bool     `desc:"also extract the names of exported struct fields, which are the labels of fields in StructView without a label tag"`
+	fset   *token.FileSet
+	fname  string
+}
+
+// NewExtractor returns a new extractor with an empty catalog
+func NewExtractor() *Extractor {
+	ex := &Extractor{Cat: NewCatalog(SourceLang)}
+	ex.Cat.Header["Content-Type"] = "text/plain; charset=UTF-8"
+	ex.fset = token.NewFileSet()
+	return ex
+}
+
+// SpecialMenus are the names of MainMenu entries that are not method names
+// -- as in giv.MethView
+var SpecialMenus = map[string]bool{
+	"AppMenu": true, "Copy Cut Paste": true, "Copy Cut Paste Dupe": true, "Windows": true,
+	"Undo Redo Copy Cut Paste": true, "Undo Redo Copy Cut Paste Dupe": true,
+}
+
+// MethodLabel returns the label that giv.MethView uses for a method with
+// given name when there is no label property: the words of the camel-case
+// name, e.g., Save As for SaveAs
+func MethodLabel(name string) string {
+	return strings.Replace(strings.Join(camelcase.Split(name), " "), "  ", " ", -1)
+}
+
+// ExtractDir extracts the messages from all the Go files (excluding tests)
+// in given directory, and its subdirectories if recursive
+func (ex *Extractor) ExtractDir(dir string, recursive bool) error {
+	return filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if path != dir && (!recursive || strings.HasPrefix(info.Name(), ".") || info.Name() == "testdata") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(path) != ".go" || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+		return ex.ExtractFile(path, nil)
+	})
+}
+
+// ExtractFile extracts the messages from given Go file -- src can be nil
+// to read the file, or the source as for go/parser.ParseFile
+func (ex *Extractor) ExtractFile(filename string, src interface{}) error {
+	f, err := parser.ParseFile(ex.fset, filename, src, 0)
+	if err != nil {
+		return err
+	}
+	ex.fname = filename
+	ast.Inspect(f, func(n ast.Node) bool {
+		switch nd := n.(type) {
+		case *ast.CallExpr:
+			ex.call(nd)
+		case *ast.KeyValueExpr:
+			ex.keyValue(nd)
+		case *ast.StructType:
+			ex.structFields(nd)
+		}
+		return true
+	})
+	return nil
+}
+
+// ref returns the reference for given position
+func (ex *Extractor) ref(pos token.Pos) string {
+	p := ex.fset.Position(pos)
+	return fmt.Sprintf("%v:%v", filepath.ToSlash(ex.fname), p.Line)
+}
+
+// add adds a message at given position, if not empty
+func (ex *Extractor) add(pos token.Pos, ctx, id, idPlural string) *Message {
+	if strings.TrimSpace(id) == "" {
+		return nil
+	}
+	return ex.Cat.Ref(ctx, id, idPlural, ex.ref(pos))
+}
+
+// strLit returns the value of given expression if it is a string literal
+func strLit(e ast.Expr) (string, bool) {
+	bl, ok := e.(*ast.BasicLit)
+	if !ok || bl.Kind != token.STRING {
+		return "", false
+	}
+	s, err := strconv.Unquote(bl.Value)
+	return s, err == nil
+}
+
+// funName returns the name of the function called, without any package or
+// receiver
+func funName(e ast.Expr) string {
+	switch fn := e.(type) {
+	case *ast.Ident:
+		return fn.Name
+	case *ast.SelectorExpr:
+		return fn.Sel.Name
+	}
+	return ""
+}
+
+// call extracts the messages from the arguments of a function call
+func (ex *Extractor) call(ce *ast.CallExpr) {
+	arg := func(i int) (string, bool) {
+		if i >= len(ce.Args) {
+			return "", false
+		}
+		return strLit(ce.Args[i])
+	}
+	switch funName(ce.Fun) {
+	case "Tr":
+		if s, ok := arg(0); ok {
+			ex.add(ce.Pos(), "", s, "")
+		}
+	case "TrCtx":
+		ctx, ok1 := arg(0)
+		s, ok2 := arg(1)
+		if ok1 && ok2 {
+			ex.add(ce.Pos(), ctx, s, "")
+		}
+	case "TrN":
+		s, ok1 := arg(0)
+		pl, ok2 := arg(1)
+		if ok1 && ok2 {
+			ex.add(ce.Pos(), "", s, pl)
+		}
+	case "TrText", "TrTooltip":
+		if s, ok := arg(1); ok {
+			ex.add(ce.Pos(), "", s, "")
+		}
+	case "ChoiceDialog":
+		if len(ce.Args) > 2 {
+			if cl, ok := ce.Args[2].(*ast.CompositeLit); ok {
+				for _, el := range cl.Elts {
+					if s, ok := strLit(el); ok {
+						ex.add(el.Pos(), "", s, "")
+					}
+				}
+			}
+		}
+	}
+}
+
+// keyValue extracts the messages from a key: value element of a composite
+// literal -- struct fields and ki.Props entries
+func (ex *Extractor) keyValue(kv *ast.KeyValueExpr) {
+	if id, ok := kv.Key.(*ast.Ident); ok {
+		switch id.Name {
+		case "Label", "Tooltip", "Title", "Prompt":
+			if s, ok := strLit(kv.Value); ok {
+				ex.add(kv.Value.Pos(), "", s, "")
+			}
+		}
+		return
+	}
+	key, ok := strLit(kv.Key)
+	if !ok {
+		return
+	}
+	switch key {
+	case "label", "desc":
+		if s, ok := strLit(kv.Value); ok {
+			ex.add(kv.Value.Pos(), "", s, "")
+		}
+	case "ToolBar", "MainMenu", "CtxtMenu", "CallMethods":
+		if cl, ok := kv.Value.(*ast.CompositeLit); ok {
+			ex.methods(cl, key == "MainMenu")
+		}
+	}
+}
+
+// methods extracts the labels derived from method and menu names in a
+// ki.PropSlice of methods, for those without a "label" property
+func (ex *Extractor) methods(cl *ast.CompositeLit, mainMenu bool) {
+	for _, el := range cl.Elts {
+		ecl, ok := el.(*ast.CompositeLit)
+		if !ok || len(ecl.Elts) != 2 {
+			continue
+		}
+		nm, ok := strLit(ecl.Elts[0])
+		if !ok || strings.HasPrefix(nm, "sep-") || SpecialMenus[nm] {
+			continue
+		}
+		if mainMenu {
+			if _, isStr := strLit(ecl.Elts[1]); isStr { // Edit, Window special menus
+				ex.add(ecl.Elts[0].Pos(), "", nm, "")
+				continue
+			}
+		}
+		vcl, ok := ecl.Elts[1].(*ast.CompositeLit)
+		if !ok { // e.g., ki.BlankProp{}
+			ex.add(ecl.Elts[0].Pos(), "", MethodLabel(nm), "")
+			continue
+		}
+		hasLabel := false
+		isSub := false
+		for _, vel := range vcl.Elts {
+			switch ve := vel.(type) {
+			case *ast.KeyValueExpr:
+				if k, ok := strLit(ve.Key); ok && k == "label" {
+					hasLabel = true
+				}
+			case *ast.CompositeLit:
+				isSub = true // sub-menu of methods
+			}
+		}
+		if !hasLabel {
+			ex.add(ecl.Elts[0].Pos(), "", MethodLabel(nm), "")
+		}
+		if isSub {
+			ex.methods(vcl, false)
+		}
+	}
+}
+
+// structFields extracts the labels of the fields of a struct type
+func (ex *Extractor) structFields(st *ast.StructType) {
+	for _, fld := range st.Fields.List {
+		tag := reflect.StructTag("")
+		if fld.Tag != nil {
+			if s, err := strconv.Unquote(fld.Tag.Value); err == nil {
+				tag = reflect.StructTag(s)
+			}
+		}
+		if lbl, has := tag.Lookup("label"); has {
+			ex.add(fld.Pos(), "", lbl, "")
+			continue
+		}
+		if !ex.Fields || tag.Get("view") == "-" || tag.Get("json") == "-" {
+			continue
+		}
+		for _, nm := range fld.Names {
+			if nm.IsExported() {
+				if m := ex.add(nm.Pos(), "", nm.Name, ""); m != nil && len(m.Comments) == 0 {
+					m.Comments = append(m.Comments, "struct field name")
+				}
+			}
+		}
+	}
+}
+
+// MergeTemplate returns the catalog for updating the translations in po to
+// the messages of the template pot: it has the messages of the template,
+// in order, with their existing translations from po -- messages that are
+// no longer in the template are dropped
+func MergeTemplate(po, pot *Catalog) *Catalog {
+	nc := NewCatalog(po.Lang)
+	nc.Header = po.Header
+	nc.NPlurals, nc.Plural = po.NPlurals, po.Plural
+	for _, key := range pot.Order {
+		tm := pot.Msgs[key]
+		m := &Message{Ctx: tm.Ctx, ID: tm.ID, IDPlural: tm.IDPlural, Refs: tm.Refs, Comments: tm.Comments}
+		if om, has := po.Msgs[key]; has {
+			m.Strs = om.Strs
+			m.Fuzzy = om.Fuzzy || om.IDPlural != tm.IDPlural
+		}
+		nc.Add(m)
+	}
+	return nc
+}
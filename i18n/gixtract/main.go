@@ -0,0 +1,106 @@
+// Copyright (c) 2018, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Command gixtract extracts the messages to translate from the Go source of
+// a GoGi app (see i18n.Extractor for what it finds) into a gettext PO
+// template, or updates existing PO translation files with them.
+//
+// Usage:
+//
+//	gixtract [-o app.pot] [-fields] [-update de.po,fr.po] [dirs or files...]
+//
+// Directories are searched recursively, and the default is the current
+// directory.  Translators copy the template to a lang.po file and fill in
+// the translations, which the app loads with i18n.LoadDir.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/goki/gi/i18n"
+)
+
+func main() {
+	out := flag.String("o", "", "output template file (default stdout)")
+	fields := flag.Bool("fields", false, "also extract the names of exported struct fields, shown as labels in StructView")
+	update := flag.String("update", "", "comma-separated PO files to update with the extracted messages, keeping their translations")
+	flag.Parse()
+
+	ex := i18n.NewExtractor()
+	ex.Fields = *fields
+	args := flag.Args()
+	if len(args) == 0 {
+		args = []string{"."}
+	}
+	for _, a := range args {
+		fi, err := os.Stat(a)
+		if err == nil {
+			if fi.IsDir() {
+				err = ex.ExtractDir(a, true)
+			} else {
+				err = ex.ExtractFile(a, nil)
+			}
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+
+	if *update != "" {
+		for _, pf := range strings.Split(*update, ",") {
+			if err := updatePO(pf, ex.Cat); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(1)
+			}
+		}
+		if *out == "" {
+			return
+		}
+	}
+
+	var w io.Writer = os.Stdout
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		w = f
+	}
+	if err := ex.Cat.WritePO(w, true); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// updatePO updates given PO file to the messages of the template, creating
+// it if it does not exist
+func updatePO(filename string, pot *i18n.Catalog) error {
+	po, err := i18n.OpenPO(filename)
+	if os.IsNotExist(err) {
+		po = i18n.NewCatalog(strings.TrimSuffix(filename, ".po"))
+		po.Header["Content-Type"] = "text/plain; charset=UTF-8"
+	} else if err != nil {
+		return err
+	}
+	if po.Header["Language"] == "" {
+		po.Header["Language"] = po.Lang
+	}
+	if po.Header["Plural-Forms"] == "" {
+		po.Header["Plural-Forms"] = i18n.PluralForms[po.Lang]
+	}
+	nc := i18n.MergeTemplate(po, pot)
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return nc.WritePO(f, false)
+}
@@ -0,0 +1,226 @@
+// Copyright (c) 2018, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package i18n
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+var testPO = `# German translation
+msgid ""
+msgstr ""
+"Language: de\n"
+"Plural-Forms: nplurals=2; plural=(n != 1);\n"
+
+#: gi/dialogs.go:390
+msgid "Cancel"
+msgstr "Abbrechen"
+
+msgctxt "menu"
+msgid "Open"
+msgstr "Öffnen"
+
+#, fuzzy
+msgid "Save As"
+msgstr "Speichern als"
+
+msgid "%d file"
+msgid_plural "%d files"
+msgstr[0] "%d Datei"
+msgstr[1] "%d "
+"Dateien"
+
+#~ msgid "Old"
+#~ msgstr "Alt"
+`
+
+func TestPluralRules(t *testing.T) {
+	tests := []struct {
+		lang string
+		idx  map[int]int
+	}{
+		{"en", map[int]int{0: 1, 1: 0, 2: 1, 21: 1}},
+		{"fr", map[int]int{0: 0, 1: 0, 2: 1}},
+		{"ru", map[int]int{1: 0, 2: 1, 5: 2, 11: 2, 21: 0, 22: 1, 112: 2}},
+		{"pl", map[int]int{1: 0, 3: 1, 5: 2, 21: 2, 24: 1}},
+		{"ja", map[int]int{1: 0, 7: 0}},
+		{"ar", map[int]int{0: 0, 1: 1, 2: 2, 5: 3, 50: 4, 100: 5}},
+	}
+	for _, ts := range tests {
+		_, pr := DefaultPlural(ts.lang)
+		for n, idx := range ts.idx {
+			if got := pr.Index(n); got != idx {
+				t.Errorf("%v: plural index for %v = %v, want %v", ts.lang, n, got, idx)
+			}
+		}
+	}
+	for _, bad := range []string{"n ==", "(n > 1", "x", "n ? 1", "1 / 0"} {
+		if pr, err := ParsePlural(bad); err == nil && bad != "1 / 0" {
+			t.Errorf("ParsePlural(%q) should fail", bad)
+		} else if err == nil && pr.Index(3) != 0 {
+			t.Errorf("ParsePlural(%q): division by zero should give 0", bad)
+		}
+	}
+}
+
+func TestReadPO(t *testing.T) {
+	ct, err := ReadPO(strings.NewReader(testPO))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ct.Lang != "de" || ct.NPlurals != 2 {
+		t.Errorf("lang %v nplurals %v", ct.Lang, ct.NPlurals)
+	}
+	if s, ok := ct.Lookup("", "Cancel"); !ok || s != "Abbrechen" {
+		t.Errorf("Cancel: %v", s)
+	}
+	if s, _ := ct.Lookup("menu", "Open"); s != "Öffnen" {
+		t.Errorf("menu Open: %v", s)
+	}
+	if _, ok := ct.Lookup("", "Open"); ok {
+		t.Errorf("Open without context should not be found")
+	}
+	if s, _ := ct.LookupN("", "%d file", "%d files", 3); s != "%d Dateien" {
+		t.Errorf("plural: %v", s)
+	}
+	if _, has := ct.Msgs[MsgKey("", "Old")]; has {
+		t.Errorf("obsolete message should be skipped")
+	}
+	if m := ct.Msgs[MsgKey("", "Save As")]; m == nil || !m.Fuzzy {
+		t.Errorf("Save As should be fuzzy")
+	}
+
+	var buf bytes.Buffer
+	if err := ct.WritePO(&buf, false); err != nil {
+		t.Fatal(err)
+	}
+	ct2, err := ReadPO(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ct2.Msgs) != len(ct.Msgs) {
+		t.Errorf("round-trip: %v messages, want %v", len(ct2.Msgs), len(ct.Msgs))
+	}
+	if s, _ := ct2.LookupN("", "%d file", "%d files", 1); s != "%d Datei" {
+		t.Errorf("round-trip plural: %v", s)
+	}
+}
+
+func TestLocale(t *testing.T) {
+	if l := NormLocale("de_de.UTF-8"); l != "de_DE" {
+		t.Errorf("NormLocale: %v", l)
+	}
+	if fb := LocaleFallbacks("pt-BR"); len(fb) != 2 || fb[1] != "pt" {
+		t.Errorf("LocaleFallbacks: %v", fb)
+	}
+
+	ct, err := ReadPO(strings.NewReader(testPO))
+	if err != nil {
+		t.Fatal(err)
+	}
+	AddCatalog(ct)
+	at := NewCatalog("de_AT")
+	at.Add(&Message{ID: "Cancel", Strs: []string{"Abbrechen!"}})
+	AddCatalog(at)
+
+	changed := 0
+	OnChange(func() { changed++ })
+
+	SetLocale("de_AT.UTF-8")
+	if Locale() != "de_AT" || changed != 1 {
+		t.Errorf("locale %v changed %v", Locale(), changed)
+	}
+	if s := Tr("Cancel"); s != "Abbrechen!" {
+		t.Errorf("de_AT Cancel: %v", s)
+	}
+	if s := TrCtx("menu", "Open"); s != "Öffnen" {
+		t.Errorf("de_AT menu Open: %v", s)
+	}
+	if s := Tr("Save As"); s != "Save As" {
+		t.Errorf("fuzzy translation should not be used: %v", s)
+	}
+	if s := TrN("%d file", "%d files", 1); s != "%d Datei" {
+		t.Errorf("TrN: %v", s)
+	}
+
+	SetLocale("fr")
+	if s := Tr("Cancel"); s != "Cancel" {
+		t.Errorf("fr Cancel: %v", s)
+	}
+	if s := TrN("%d file", "%d files", 2); s != "%d files" {
+		t.Errorf("fr TrN: %v", s)
+	}
+	SetLocale("C")
+}
+
+var testSrc = `package test
+
+var KiT_Doc = kit.Types.AddType(&Doc{}, DocProps)
+
+type Doc struct {
+	Name  string
+	Size  int    ` + "`label:\"File Size\"`" + `
+	Hid   bool   ` + "`view:\"-\"`" + `
+}
+
+var DocProps = ki.Props{
+	"MainMenu": ki.PropSlice{
+		{"AppMenu", ki.BlankProp{}},
+		{"File", ki.PropSlice{
+			{"OpenFile", ki.Props{"shortcut": "Command+O"}},
+			{"sep-close", ki.BlankProp{}},
+			{"SaveAs", ki.Props{"label": "Save Copy..."}},
+		}},
+		{"Edit", "Copy Cut Paste"},
+	},
+}
+
+func (d *Doc) Config() {
+	tb.AddAction(gi.ActOpts{Label: "Update", Tooltip: "update the view"}, nil, nil)
+	gi.ChoiceDialog(vp, gi.DlgOpts{}, []string{"Save", "Discard"}, nil, nil)
+	fmt.Println(i18n.TrN("%d item", "%d items", n), i18n.TrCtx("verb", "Open"))
+}
+`
+
+func TestExtract(t *testing.T) {
+	ex := NewExtractor()
+	ex.Fields = true
+	if err := ex.ExtractFile("doc.go", testSrc); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"File Size", "Name", "File", "Open File", "Save Copy...", "Edit", "Update", "update the view", "Save", "Discard"}
+	for _, w := range want {
+		if _, has := ex.Cat.Msgs[MsgKey("", w)]; !has {
+			t.Errorf("message %q not extracted", w)
+		}
+	}
+	for _, nw := range []string{"Hid", "Save As", "sep-close", "AppMenu", "Copy Cut Paste"} {
+		if _, has := ex.Cat.Msgs[MsgKey("", nw)]; has {
+			t.Errorf("message %q should not be extracted", nw)
+		}
+	}
+	if m := ex.Cat.Msgs[MsgKey("", "%d item")]; m == nil || m.IDPlural != "%d items" {
+		t.Errorf("plural message not extracted")
+	}
+	if _, has := ex.Cat.Msgs[MsgKey("verb", "Open")]; !has {
+		t.Errorf("context message not extracted")
+	}
+	if m := ex.Cat.Msgs[MsgKey("", "Update")]; m == nil || len(m.Refs) != 1 || m.Refs[0] != "doc.go:24" {
+		t.Errorf("refs: %v", m)
+	}
+
+	po := NewCatalog("de")
+	po.Add(&Message{ID: "Save", Strs: []string{"Speichern"}})
+	po.Add(&Message{ID: "Gone", Strs: []string{"Weg"}})
+	nc := MergeTemplate(po, ex.Cat)
+	if s, _ := nc.Lookup("", "Save"); s != "Speichern" {
+		t.Errorf("merge kept translation: %v", s)
+	}
+	if _, has := nc.Msgs[MsgKey("", "Gone")]; has {
+		t.Errorf("merge should drop obsolete messages")
+	}
+}
@@ -0,0 +1,222 @@
+// Copyright (c) 2018, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+var (
+	// mu protects the global state
+	mu sync.RWMutex
+
+	// catalogs are all the catalogs loaded, by language
+	catalogs = map[string]*Catalog{}
+
+	// locale is the current locale
+	locale = ""
+
+	// active is the catalog for the current locale, merged from those of
+	// its fallbacks -- nil if none
+	active *Catalog
+
+	// changeFuncs are called when the locale changes
+	changeFuncs []func()
+)
+
+// SourceLang is the language of the untranslated messages in the source
+// code, for which no translation is needed
+var SourceLang = "en"
+
+// NormLocale returns the normalized form of a locale name as used for
+// catalogs: the language, optionally followed by _ and the territory, e.g.,
+// de_DE.UTF-8 and de-de become de_DE -- the C and POSIX locales are
+// returned as the SourceLang
+func NormLocale(loc string) string {
+	if ci := strings.IndexAny(loc, ".@"); ci >= 0 {
+		loc = loc[:ci]
+	}
+	loc = strings.Replace(strings.TrimSpace(loc), "-", "_", -1)
+	if loc == "" || loc == "C" || loc == "POSIX" {
+		return SourceLang
+	}
+	if ui := strings.Index(loc, "_"); ui > 0 {
+		return strings.ToLower(loc[:ui]) + "_" + strings.ToUpper(loc[ui+1:])
+	}
+	return strings.ToLower(loc)
+}
+
+// LocaleFallbacks returns the locale names to look for translations for
+// given locale, from most to least specific, e.g., de_DE and de for de_DE
+func LocaleFallbacks(loc string) []string {
+	loc = NormLocale(loc)
+	if ui := strings.Index(loc, "_"); ui > 0 {
+		return []string{loc, loc[:ui]}
+	}
+	return []string{loc}
+}
+
+// SystemLocale returns the locale of the user from the environment, as
+// given by the LC_ALL, LC_MESSAGES or LANG variables, or the SourceLang if
+// none are set
+func SystemLocale() string {
+	for _, ev := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if l := os.Getenv(ev); l != "" {
+			return NormLocale(l)
+		}
+	}
+	return SourceLang
+}
+
+// AddCatalog adds given catalog for its language, merging it into any
+// existing catalog for the language -- the translations are used
+// immediately if the language is used by the current locale
+func AddCatalog(ct *Catalog) {
+	lang := NormLocale(ct.Lang)
+	mu.Lock()
+	if ec, has := catalogs[lang]; has {
+		ec.Merge(ct)
+		if ct.Header["Plural-Forms"] != "" {
+			ec.NPlurals, ec.Plural = ct.NPlurals, ct.Plural
+		}
+	} else {
+		nc := NewCatalog(lang)
+		nc.NPlurals, nc.Plural = ct.NPlurals, ct.Plural
+		nc.Header = ct.Header
+		nc.Merge(ct)
+		catalogs[lang] = nc
+	}
+	mu.Unlock()
+	cur := Locale()
+	for _, l := range LocaleFallbacks(cur) {
+		if l == lang {
+			SetLocale(cur)
+			return
+		}
+	}
+}
+
+// LoadDir loads all the PO files in given directory and its
+// subdirectories, e.g., laid out as locale/de/LC_MESSAGES/app.po or
+// translations/de.po -- see OpenPO for how their language is determined
+func LoadDir(dir string) error {
+	var errs []string
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || filepath.Ext(path) != ".po" {
+			return nil
+		}
+		ct, err := OpenPO(path)
+		if err != nil {
+			errs = append(errs, err.Error())
+			return nil
+		}
+		AddCatalog(ct)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("i18n.LoadDir: %v", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// Languages returns the languages of all the catalogs loaded
+func Languages() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	langs := make([]string, 0, len(catalogs))
+	for l := range catalogs {
+		langs = append(langs, l)
+	}
+	return langs
+}
+
+// SetLocale sets the current locale, for which messages are translated --
+// an empty locale means the SystemLocale.  The functions added with
+// OnChange are then called, so that the GUI can be updated -- also when
+// the locale does not change, e.g., after loading new catalogs.
+func SetLocale(loc string) {
+	if loc == "" {
+		loc = SystemLocale()
+	}
+	loc = NormLocale(loc)
+	mu.Lock()
+	locale = loc
+	active = nil
+	fbs := LocaleFallbacks(loc)
+	for i := len(fbs) - 1; i >= 0; i-- { // more specific override less
+		ct, ok := catalogs[fbs[i]]
+		if !ok {
+			continue
+		}
+		if active == nil {
+			active = NewCatalog(loc)
+		}
+		active.Merge(ct)
+		active.NPlurals, active.Plural = ct.NPlurals, ct.Plural
+	}
+	fns := changeFuncs
+	mu.Unlock()
+	for _, f := range fns {
+		f()
+	}
+}
+
+// Locale returns the current locale
+func Locale() string {
+	mu.RLock()
+	defer mu.RUnlock()
+	if locale == "" {
+		return SystemLocale()
+	}
+	return locale
+}
+
+// OnChange adds a function that is called whenever SetLocale is called
+func OnChange(f func()) {
+	mu.Lock()
+	changeFuncs = append(changeFuncs, f)
+	mu.Unlock()
+}
+
+// Tr returns the translation of given message in the current locale -- the
+// message itself if there is no translation
+func Tr(msg string) string {
+	mu.RLock()
+	defer mu.RUnlock()
+	s, _ := active.Lookup("", msg)
+	return s
+}
+
+// TrCtx returns the translation of given message in given context, which
+// distinguishes the same text used with different meanings, e.g., "Open"
+// as a verb on a button vs. as a state
+func TrCtx(ctx, msg string) string {
+	mu.RLock()
+	defer mu.RUnlock()
+	s, _ := active.Lookup(ctx, msg)
+	return s
+}
+
+// TrN returns the translation of given message with given plural form for
+// count n, using the plural rule of the current locale -- without
+// translation, msg is returned for n == 1 and plural otherwise.  The count
+// is not formatted into the message -- use fmt.Sprintf for that, e.g.,
+// fmt.Sprintf(TrN("%d file", "%d files", n), n)
+func TrN(msg, plural string, n int) string {
+	mu.RLock()
+	defer mu.RUnlock()
+	s, _ := active.LookupN("", msg, plural, n)
+	return s
+}
@@ -0,0 +1,288 @@
+// Copyright (c) 2018, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package i18n
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// PluralRule selects the plural form to use for a count, according to a
+// gettext plural expression in C syntax, such as
+// (n==1 ? 0 : n%10>=2 && n%10<=4 && (n%100<10 || n%100>=20) ? 1 : 2)
+type PluralRule struct {
+	Expr string `desc:"the plural expression, in terms of the count n"`
+	eval func(n int64) int64
+}
+
+// Index returns the index of the plural form for count n
+func (pr *PluralRule) Index(n int) int {
+	if n < 0 {
+		n = -n
+	}
+	return int(pr.eval(int64(n)))
+}
+
+// ParsePlural parses a plural expression
+func ParsePlural(expr string) (*PluralRule, error) {
+	ps := &pluralParser{src: expr}
+	ps.next()
+	ev, err := ps.ternary()
+	if err != nil {
+		return nil, err
+	}
+	if ps.tok != "" {
+		return nil, fmt.Errorf("i18n: unexpected %q in plural expression: %v", ps.tok, expr)
+	}
+	return &PluralRule{Expr: expr, eval: ev}, nil
+}
+
+// ParsePluralForms parses the value of a Plural-Forms header, such as
+// "nplurals=2; plural=(n != 1);", returning the number of forms and the rule
+func ParsePluralForms(pf string) (int, *PluralRule, error) {
+	np := 0
+	var pr *PluralRule
+	for _, fld := range strings.Split(pf, ";") {
+		fld = strings.TrimSpace(fld)
+		switch {
+		case strings.HasPrefix(fld, "nplurals="):
+			var err error
+			if np, err = strconv.Atoi(strings.TrimSpace(fld[len("nplurals="):])); err != nil {
+				return 0, nil, fmt.Errorf("i18n: invalid nplurals in Plural-Forms: %v", pf)
+			}
+		case strings.HasPrefix(fld, "plural="):
+			var err error
+			if pr, err = ParsePlural(fld[len("plural="):]); err != nil {
+				return 0, nil, err
+			}
+		}
+	}
+	if np < 1 || pr == nil {
+		return 0, nil, fmt.Errorf("i18n: invalid Plural-Forms: %v", pf)
+	}
+	return np, pr, nil
+}
+
+// PluralForms are the standard Plural-Forms of languages, by language code
+// -- languages not listed use the English rule
+var PluralForms = map[string]string{
+	"en":    "nplurals=2; plural=(n != 1);",
+	"fr":    "nplurals=2; plural=(n > 1);",
+	"pt":    "nplurals=2; plural=(n != 1);",
+	"pt_BR": "nplurals=2; plural=(n > 1);",
+	"ja":    "nplurals=1; plural=0;",
+	"ko":    "nplurals=1; plural=0;",
+	"zh":    "nplurals=1; plural=0;",
+	"vi":    "nplurals=1; plural=0;",
+	"th":    "nplurals=1; plural=0;",
+	"id":    "nplurals=1; plural=0;",
+	"tr":    "nplurals=2; plural=(n != 1);",
+	"ru":    "nplurals=3; plural=(n%10==1 && n%100!=11 ? 0 : n%10>=2 && n%10<=4 && (n%100<10 || n%100>=20) ? 1 : 2);",
+	"uk":    "nplurals=3; plural=(n%10==1 && n%100!=11 ? 0 : n%10>=2 && n%10<=4 && (n%100<10 || n%100>=20) ? 1 : 2);",
+	"pl":    "nplurals=3; plural=(n==1 ? 0 : n%10>=2 && n%10<=4 && (n%100<10 || n%100>=20) ? 1 : 2);",
+	"cs":    "nplurals=3; plural=(n==1) ? 0 : (n>=2 && n<=4) ? 1 : 2;",
+	"sk":    "nplurals=3; plural=(n==1) ? 0 : (n>=2 && n<=4) ? 1 : 2;",
+	"ar":    "nplurals=6; plural=(n==0 ? 0 : n==1 ? 1 : n==2 ? 2 : n%100>=3 && n%100<=10 ? 3 : n%100>=11 ? 4 : 5);",
+	"ga":    "nplurals=5; plural=(n==1 ? 0 : n==2 ? 1 : n<7 ? 2 : n<11 ? 3 : 4);",
+	"lt":    "nplurals=3; plural=(n%10==1 && n%100!=11 ? 0 : n%10>=2 && (n%100<10 || n%100>=20) ? 1 : 2);",
+	"lv":    "nplurals=3; plural=(n%10==1 && n%100!=11 ? 0 : n != 0 ? 1 : 2);",
+	"ro":    "nplurals=3; plural=(n==1 ? 0 : (n==0 || (n%100 > 0 && n%100 < 20)) ? 1 : 2);",
+	"sl":    "nplurals=4; plural=(n%100==1 ? 0 : n%100==2 ? 1 : n%100==3 || n%100==4 ? 2 : 3);",
+}
+
+// DefaultPlural returns the number of plural forms and the plural rule of
+// given language, from PluralForms
+func DefaultPlural(lang string) (int, *PluralRule) {
+	for _, l := range LocaleFallbacks(lang) {
+		if pf, ok := PluralForms[l]; ok {
+			if np, pr, err := ParsePluralForms(pf); err == nil {
+				return np, pr
+			}
+		}
+	}
+	np, pr, _ := ParsePluralForms(PluralForms["en"])
+	return np, pr
+}
+
+// pluralParser is a recursive-descent parser for plural expressions,
+// producing a function of n for each sub-expression
+type pluralParser struct {
+	src string
+	pos int
+	tok string
+}
+
+// next reads the next token into tok -- empty at the end
+func (ps *pluralParser) next() {
+	for ps.pos < len(ps.src) && (ps.src[ps.pos] == ' ' || ps.src[ps.pos] == '\t') {
+		ps.pos++
+	}
+	if ps.pos >= len(ps.src) {
+		ps.tok = ""
+		return
+	}
+	st := ps.pos
+	c := ps.src[ps.pos]
+	switch {
+	case c >= '0' && c <= '9':
+		for ps.pos < len(ps.src) && ps.src[ps.pos] >= '0' && ps.src[ps.pos] <= '9' {
+			ps.pos++
+		}
+	case ps.pos+1 < len(ps.src) && pluralTwoCharOps[ps.src[ps.pos:ps.pos+2]]:
+		ps.pos += 2
+	default:
+		ps.pos++
+	}
+	ps.tok = ps.src[st:ps.pos]
+	if ps.tok == ";" { // terminates a plural= field
+		ps.tok = ""
+		ps.pos = len(ps.src)
+	}
+}
+
+// pluralTwoCharOps are the operators of two characters
+var pluralTwoCharOps = map[string]bool{"==": true, "!=": true, "<=": true, ">=": true, "&&": true, "||": true}
+
+type pluralFunc func(n int64) int64
+
+func boolInt(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// ternary parses cond ? a : b, the lowest precedence
+func (ps *pluralParser) ternary() (pluralFunc, error) {
+	cond, err := ps.binary(0)
+	if err != nil || ps.tok != "?" {
+		return cond, err
+	}
+	ps.next()
+	a, err := ps.ternary()
+	if err != nil {
+		return nil, err
+	}
+	if ps.tok != ":" {
+		return nil, fmt.Errorf("i18n: missing : in plural expression: %v", ps.src)
+	}
+	ps.next()
+	b, err := ps.ternary()
+	if err != nil {
+		return nil, err
+	}
+	return func(n int64) int64 {
+		if cond(n) != 0 {
+			return a(n)
+		}
+		return b(n)
+	}, nil
+}
+
+// pluralOps are the binary operators, by precedence level from lowest
+var pluralOps = [][]string{
+	{"||"}, {"&&"}, {"==", "!="}, {"<", "<=", ">", ">="}, {"+", "-"}, {"*", "/", "%"},
+}
+
+// binary parses the binary operators of given precedence level and higher
+func (ps *pluralParser) binary(level int) (pluralFunc, error) {
+	if level >= len(pluralOps) {
+		return ps.unary()
+	}
+	x, err := ps.binary(level + 1)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op := ""
+		for _, o := range pluralOps[level] {
+			if ps.tok == o {
+				op = o
+			}
+		}
+		if op == "" {
+			return x, nil
+		}
+		ps.next()
+		y, err := ps.binary(level + 1)
+		if err != nil {
+			return nil, err
+		}
+		a, b := x, y
+		switch op {
+		case "||":
+			x = func(n int64) int64 { return boolInt(a(n) != 0 || b(n) != 0) }
+		case "&&":
+			x = func(n int64) int64 { return boolInt(a(n) != 0 && b(n) != 0) }
+		case "==":
+			x = func(n int64) int64 { return boolInt(a(n) == b(n)) }
+		case "!=":
+			x = func(n int64) int64 { return boolInt(a(n) != b(n)) }
+		case "<":
+			x = func(n int64) int64 { return boolInt(a(n) < b(n)) }
+		case "<=":
+			x = func(n int64) int64 { return boolInt(a(n) <= b(n)) }
+		case ">":
+			x = func(n int64) int64 { return boolInt(a(n) > b(n)) }
+		case ">=":
+			x = func(n int64) int64 { return boolInt(a(n) >= b(n)) }
+		case "+":
+			x = func(n int64) int64 { return a(n) + b(n) }
+		case "-":
+			x = func(n int64) int64 { return a(n) - b(n) }
+		case "*":
+			x = func(n int64) int64 { return a(n) * b(n) }
+		case "/", "%":
+			div := op == "/"
+			x = func(n int64) int64 {
+				d := b(n)
+				if d == 0 {
+					return 0
+				}
+				if div {
+					return a(n) / d
+				}
+				return a(n) % d
+			}
+		}
+	}
+}
+
+// unary parses !x, n, numbers and parenthesized expressions
+func (ps *pluralParser) unary() (pluralFunc, error) {
+	tok := ps.tok
+	switch {
+	case tok == "!":
+		ps.next()
+		x, err := ps.unary()
+		if err != nil {
+			return nil, err
+		}
+		return func(n int64) int64 { return boolInt(x(n) == 0) }, nil
+	case tok == "(":
+		ps.next()
+		x, err := ps.ternary()
+		if err != nil {
+			return nil, err
+		}
+		if ps.tok != ")" {
+			return nil, fmt.Errorf("i18n: missing ) in plural expression: %v", ps.src)
+		}
+		ps.next()
+		return x, nil
+	case tok == "n":
+		ps.next()
+		return func(n int64) int64 { return n }, nil
+	case tok != "" && tok[0] >= '0' && tok[0] <= '9':
+		v, err := strconv.ParseInt(tok, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		ps.next()
+		return func(n int64) int64 { return v }, nil
+	}
+	return nil, fmt.Errorf("i18n: unexpected %q in plural expression: %v", tok, ps.src)
+}
@@ -0,0 +1,273 @@
+// Copyright (c) 2018, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package i18n
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ReadPO reads a catalog from a gettext PO file -- the language is taken
+// from the Language header field if present
+func ReadPO(r io.Reader) (*Catalog, error) {
+	ct := NewCatalog("")
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	var m *Message
+	var cur *string // string being continued
+	lnum := 0
+	hdr := ""
+	flush := func() {
+		if m == nil {
+			return
+		}
+		if m.ID == "" && m.Ctx == "" {
+			if len(m.Strs) > 0 {
+				hdr = m.Strs[0]
+			}
+		} else {
+			ct.Add(m)
+		}
+		m = nil
+		cur = nil
+	}
+	msg := func() *Message {
+		if m == nil {
+			m = &Message{}
+		}
+		return m
+	}
+	for sc.Scan() {
+		lnum++
+		ln := strings.TrimSpace(sc.Text())
+		switch {
+		case ln == "":
+			flush()
+		case strings.HasPrefix(ln, "#~"): // obsolete
+		case strings.HasPrefix(ln, "#"):
+			if m != nil && cur != nil { // comments start a new message
+				flush()
+			}
+			switch {
+			case strings.HasPrefix(ln, "#:"):
+				msg().Refs = append(msg().Refs, strings.Fields(ln[2:])...)
+			case strings.HasPrefix(ln, "#."):
+				msg().Comments = append(msg().Comments, strings.TrimSpace(ln[2:]))
+			case strings.HasPrefix(ln, "#,"):
+				for _, f := range strings.Split(ln[2:], ",") {
+					if strings.TrimSpace(f) == "fuzzy" {
+						msg().Fuzzy = true
+					}
+				}
+			}
+		case strings.HasPrefix(ln, "\""):
+			if cur == nil {
+				return nil, fmt.Errorf("i18n: PO line %v: string without keyword", lnum)
+			}
+			s, err := poUnquote(ln)
+			if err != nil {
+				return nil, fmt.Errorf("i18n: PO line %v: %v", lnum, err)
+			}
+			*cur += s
+		default:
+			kw := ln
+			val := ""
+			if si := strings.IndexAny(ln, " \t"); si > 0 {
+				kw, val = ln[:si], strings.TrimSpace(ln[si:])
+			}
+			s, err := poUnquote(val)
+			if err != nil {
+				return nil, fmt.Errorf("i18n: PO line %v: %v", lnum, err)
+			}
+			if (kw == "msgctxt" || kw == "msgid") && m != nil && len(m.Strs) > 0 {
+				flush() // no blank line between messages
+			}
+			mm := msg()
+			switch {
+			case kw == "msgctxt":
+				mm.Ctx = s
+				cur = &mm.Ctx
+			case kw == "msgid":
+				mm.ID = s
+				cur = &mm.ID
+			case kw == "msgid_plural":
+				mm.IDPlural = s
+				cur = &mm.IDPlural
+			case kw == "msgstr":
+				mm.Strs = []string{s}
+				cur = &mm.Strs[0]
+			case strings.HasPrefix(kw, "msgstr[") && strings.HasSuffix(kw, "]"):
+				idx, err := strconv.Atoi(kw[7 : len(kw)-1])
+				if err != nil || idx < 0 || idx > 100 {
+					return nil, fmt.Errorf("i18n: PO line %v: invalid keyword %v", lnum, kw)
+				}
+				for len(mm.Strs) <= idx {
+					mm.Strs = append(mm.Strs, "")
+				}
+				mm.Strs[idx] = s
+				cur = &mm.Strs[idx]
+			default:
+				return nil, fmt.Errorf("i18n: PO line %v: invalid keyword %v", lnum, kw)
+			}
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	flush()
+	for _, hl := range strings.Split(hdr, "\n") {
+		if ci := strings.Index(hl, ":"); ci > 0 {
+			ct.Header[strings.TrimSpace(hl[:ci])] = strings.TrimSpace(hl[ci+1:])
+		}
+	}
+	if lang := ct.Header["Language"]; lang != "" {
+		ct.Lang = NormLocale(lang)
+		ct.NPlurals, ct.Plural = DefaultPlural(ct.Lang)
+	}
+	if pf := ct.Header["Plural-Forms"]; pf != "" {
+		if err := ct.SetPluralForms(pf); err != nil {
+			return nil, err
+		}
+	}
+	return ct, nil
+}
+
+// OpenPO reads a catalog from given PO file -- if the file does not specify
+// its Language, it is taken from the path: the name of the directory
+// containing LC_MESSAGES if the file is in one, otherwise the file name
+// without extension, e.g., de.po
+func OpenPO(filename string) (*Catalog, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	ct, err := ReadPO(f)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %v", filename, err)
+	}
+	if ct.Lang == "" {
+		dir := filepath.Dir(filename)
+		if filepath.Base(dir) == "LC_MESSAGES" {
+			ct.Lang = NormLocale(filepath.Base(filepath.Dir(dir)))
+		} else {
+			ct.Lang = NormLocale(strings.TrimSuffix(filepath.Base(filename), filepath.Ext(filename)))
+		}
+		ct.NPlurals, ct.Plural = DefaultPlural(ct.Lang)
+		if pf := ct.Header["Plural-Forms"]; pf != "" {
+			ct.SetPluralForms(pf)
+		}
+	}
+	return ct, nil
+}
+
+// poUnquote unquotes a PO string
+func poUnquote(s string) (string, error) {
+	if len(s) < 2 || s[0] != '"' || s[len(s)-1] != '"' {
+		return "", fmt.Errorf("invalid string: %v", s)
+	}
+	s = s[1 : len(s)-1]
+	if !strings.Contains(s, "\\") {
+		return s, nil
+	}
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c != '\\' || i+1 >= len(s) {
+			sb.WriteByte(c)
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n':
+			sb.WriteByte('\n')
+		case 't':
+			sb.WriteByte('\t')
+		case 'r':
+			sb.WriteByte('\r')
+		default:
+			sb.WriteByte(s[i])
+		}
+	}
+	return sb.String(), nil
+}
+
+// poQuote quotes a string for a PO file, as one or more lines after the
+// keyword, splitting it after newlines
+func poQuote(s string) string {
+	esc := strings.NewReplacer("\\", "\\\\", "\"", "\\\"", "\t", "\\t", "\r", "\\r", "\n", "\\n")
+	if !strings.Contains(strings.TrimSuffix(s, "\n"), "\n") {
+		return "\"" + esc.Replace(s) + "\""
+	}
+	lines := strings.SplitAfter(s, "\n")
+	qs := "\"\""
+	for _, l := range lines {
+		if l != "" {
+			qs += "\n\"" + esc.Replace(l) + "\""
+		}
+	}
+	return qs
+}
+
+// WritePO writes the catalog in gettext PO format -- if template is true,
+// it is written as a template (POT) for translators, with empty
+// translations
+func (ct *Catalog) WritePO(w io.Writer, template bool) error {
+	bw := bufio.NewWriter(w)
+	hdrs := make([]string, 0, len(ct.Header))
+	for k := range ct.Header {
+		hdrs = append(hdrs, k)
+	}
+	sort.Strings(hdrs)
+	hdr := ""
+	for _, k := range hdrs {
+		hdr += k + ": " + ct.Header[k] + "\n"
+	}
+	fmt.Fprintf(bw, "msgid \"\"\nmsgstr %v\n", poQuote(hdr))
+	for _, key := range ct.Order {
+		m := ct.Msgs[key]
+		bw.WriteString("\n")
+		for _, c := range m.Comments {
+			fmt.Fprintf(bw, "#. %v\n", c)
+		}
+		if len(m.Refs) > 0 {
+			fmt.Fprintf(bw, "#: %v\n", strings.Join(m.Refs, " "))
+		}
+		if m.Fuzzy && !template {
+			bw.WriteString("#, fuzzy\n")
+		}
+		if m.Ctx != "" {
+			fmt.Fprintf(bw, "msgctxt %v\n", poQuote(m.Ctx))
+		}
+		fmt.Fprintf(bw, "msgid %v\n", poQuote(m.ID))
+		if m.IDPlural == "" {
+			str := ""
+			if !template && len(m.Strs) > 0 {
+				str = m.Strs[0]
+			}
+			fmt.Fprintf(bw, "msgstr %v\n", poQuote(str))
+			continue
+		}
+		fmt.Fprintf(bw, "msgid_plural %v\n", poQuote(m.IDPlural))
+		np := ct.NPlurals
+		if np < 1 || template {
+			np = 2
+		}
+		for i := 0; i < np; i++ {
+			str := ""
+			if !template && i < len(m.Strs) {
+				str = m.Strs[i]
+			}
+			fmt.Fprintf(bw, "msgstr[%v] %v\n", i, poQuote(str))
+		}
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,321 @@
+// Copyright (c) 2018, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot
+
+import (
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/goki/ki/kit"
+)
+
+// AxisScales are the scales of plot axes
+type AxisScales int32
+
+const (
+	// AxisLinear is a standard linear scale
+	AxisLinear AxisScales = iota
+
+	// AxisLog is a base 10 logarithmic scale -- values <= 0 are not shown
+	AxisLog
+
+	// AxisTime is a linear scale of time values, which are seconds since
+	// the Unix epoch (see TimeValue), with tick labels formatted as times
+	AxisTime
+
+	AxisScalesN
+)
+
+//go:generate stringer -type=AxisScales
+
+var KiT_AxisScales = kit.Enums.AddEnumAltLower(AxisScalesN, kit.NotBitFlag, nil, "Axis")
+
+func (ev AxisScales) MarshalJSON() ([]byte, error)  { return kit.EnumMarshalJSON(ev) }
+func (ev *AxisScales) UnmarshalJSON(b []byte) error { return kit.EnumUnmarshalJSON(ev, b) }
+
+// TimeValue returns the plot value for given time, for AxisTime axes:
+// seconds since the Unix epoch
+func TimeValue(t time.Time) float64 {
+	return float64(t.UnixNano()) / 1e9
+}
+
+// ValueTime returns the time for given plot value on an AxisTime axis
+func ValueTime(v float64) time.Time {
+	sec := math.Floor(v)
+	return time.Unix(int64(sec), int64((v-sec)*1e9))
+}
+
+// Axis has the parameters of one axis of a plot, and the currently visible
+// range of values on it
+type Axis struct {
+	Label      string     `desc:"label shown along the axis"`
+	Scale      AxisScales `desc:"scale of the axis"`
+	FixMin     bool       `desc:"use Min as the minimum value of the axis, instead of the minimum of the data"`
+	Min        float64    `desc:"minimum value of the axis, if FixMin is set"`
+	FixMax     bool       `desc:"use Max as the maximum value of the axis, instead of the maximum of the data"`
+	Max        float64    `desc:"maximum value of the axis, if FixMax is set"`
+	NTicks     int        `desc:"approximate number of major ticks to show -- 0 = default of 5"`
+	NoGrid     bool       `desc:"do not draw grid lines across the plot at the ticks"`
+	TimeFormat string     `desc:"format of tick labels on AxisTime axes, as in time.Time.Format -- if empty, a format is chosen according to the visible range"`
+	ViewMin    float64    `view:"-" json:"-" xml:"-" desc:"minimum of the currently visible range, after zooming and panning"`
+	ViewMax    float64    `view:"-" json:"-" xml:"-" desc:"maximum of the currently visible range, after zooming and panning"`
+	Zoomed     bool       `view:"-" json:"-" xml:"-" desc:"the visible range has been zoomed or panned away from the range of the data"`
+}
+
+// Tick is one tick mark on an axis
+type Tick struct {
+	Value float64
+	Label string
+}
+
+// SetRange sets the visible range from given data range, unless Zoomed,
+// using Min and Max when fixed, and making sure the range is not empty and
+// is valid for the scale
+func (ax *Axis) SetRange(min, max float64) {
+	if ax.Zoomed {
+		return
+	}
+	if ax.FixMin {
+		min = ax.Min
+	}
+	if ax.FixMax {
+		max = ax.Max
+	}
+	if ax.Scale == AxisLog {
+		if max <= 0 {
+			max = 10
+		}
+		if min <= 0 {
+			min = max / 100
+		}
+	}
+	if math.IsInf(min, 0) || math.IsNaN(min) || math.IsInf(max, 0) || math.IsNaN(max) {
+		min, max = 0, 1
+	}
+	if min == max {
+		if ax.Scale == AxisLog {
+			min /= 10
+			max *= 10
+		} else if min == 0 {
+			min, max = -1, 1
+		} else {
+			d := math.Abs(min) * 0.1
+			min -= d
+			max += d
+		}
+	}
+	ax.ViewMin = min
+	ax.ViewMax = max
+}
+
+// Norm returns the normalized position of given value along the visible
+// range of the axis: 0 = ViewMin, 1 = ViewMax -- NaN for invalid values
+// (e.g., <= 0 on a log scale)
+func (ax *Axis) Norm(v float64) float64 {
+	if ax.Scale == AxisLog {
+		if v <= 0 {
+			return math.NaN()
+		}
+		lmin := math.Log10(ax.ViewMin)
+		return (math.Log10(v) - lmin) / (math.Log10(ax.ViewMax) - lmin)
+	}
+	return (v - ax.ViewMin) / (ax.ViewMax - ax.ViewMin)
+}
+
+// FromNorm returns the value at given normalized position along the axis
+// (inverse of Norm)
+func (ax *Axis) FromNorm(n float64) float64 {
+	if ax.Scale == AxisLog {
+		lmin := math.Log10(ax.ViewMin)
+		return math.Pow(10, lmin+n*(math.Log10(ax.ViewMax)-lmin))
+	}
+	return ax.ViewMin + n*(ax.ViewMax-ax.ViewMin)
+}
+
+// AxisMinRangeRel is the minimum visible range of an axis when zooming in,
+// relative to the magnitude of the values -- far above the float64
+// precision, so that the ticks remain distinct
+var AxisMinRangeRel = 1e-9
+
+// AxisMaxTicks is the maximum number of ticks returned by Axis.Ticks
+var AxisMaxTicks = 1000
+
+// Zoom zooms the visible range by given factor (< 1 zooms in) around given
+// normalized position -- the range is kept above AxisMinRangeRel
+func (ax *Axis) Zoom(factor, at float64) {
+	min := ax.FromNorm(at - at*factor)
+	max := ax.FromNorm(at + (1-at)*factor)
+	ax.SetView(min, max)
+}
+
+// Pan moves the visible range by given normalized amount
+func (ax *Axis) Pan(delta float64) {
+	min := ax.FromNorm(delta)
+	max := ax.FromNorm(1 + delta)
+	ax.SetView(min, max)
+}
+
+// SetView sets the visible range to given values, as by zooming and panning,
+// expanding it around its center if it is smaller than AxisMinRangeRel of the
+// magnitude of the values -- invalid ranges are ignored
+func (ax *Axis) SetView(min, max float64) {
+	if math.IsNaN(min) || math.IsNaN(max) || math.IsInf(min, 0) || math.IsInf(max, 0) || min > max {
+		return
+	}
+	if ax.Scale == AxisLog {
+		if min <= 0 {
+			return
+		}
+		if max/min < 1+AxisMinRangeRel {
+			c := math.Sqrt(min * max)
+			hr := math.Sqrt(1 + AxisMinRangeRel)
+			min, max = c/hr, c*hr
+		}
+	} else {
+		mrng := math.Max(math.Abs(min), math.Abs(max)) * AxisMinRangeRel
+		if mrng < math.SmallestNonzeroFloat64*1e10 {
+			mrng = math.SmallestNonzeroFloat64 * 1e10
+		}
+		if max-min < mrng {
+			c := min + 0.5*(max-min)
+			min, max = c-0.5*mrng, c+0.5*mrng
+		}
+	}
+	ax.ViewMin, ax.ViewMax = min, max
+	ax.Zoomed = true
+}
+
+// NiceNum returns a "nice" number (1, 2, 5 times a power of 10) close to
+// given value, rounding to the nearest one if round, else the next larger
+func NiceNum(v float64, round bool) float64 {
+	exp := math.Floor(math.Log10(v))
+	f := v / math.Pow(10, exp)
+	var nf float64
+	if round {
+		switch {
+		case f < 1.5:
+			nf = 1
+		case f < 3:
+			nf = 2
+		case f < 7:
+			nf = 5
+		default:
+			nf = 10
+		}
+	} else {
+		switch {
+		case f <= 1:
+			nf = 1
+		case f <= 2:
+			nf = 2
+		case f <= 5:
+			nf = 5
+		default:
+			nf = 10
+		}
+	}
+	return nf * math.Pow(10, exp)
+}
+
+// TimeSteps are the steps between ticks on time axes, in seconds
+var TimeSteps = []float64{1, 2, 5, 10, 15, 30, 60, 2 * 60, 5 * 60, 10 * 60, 15 * 60, 30 * 60,
+	3600, 2 * 3600, 3 * 3600, 6 * 3600, 12 * 3600, 86400, 2 * 86400, 7 * 86400, 14 * 86400,
+	30 * 86400, 91 * 86400, 365 * 86400}
+
+// Ticks returns the ticks for the visible range of the axis -- at most
+// AxisMaxTicks
+func (ax *Axis) Ticks() []Tick {
+	n := ax.NTicks
+	if n <= 0 {
+		n = 5
+	}
+	rng := ax.ViewMax - ax.ViewMin
+	if !(rng > 0) || math.IsInf(rng, 0) {
+		return nil
+	}
+	var tks []Tick
+	switch ax.Scale {
+	case AxisLog:
+		lmin := math.Floor(math.Log10(ax.ViewMin))
+		lmax := math.Ceil(math.Log10(ax.ViewMax))
+		mults := []float64{1}
+		if lmax-lmin <= 3 {
+			mults = []float64{1, 2, 5}
+		}
+		lstep := math.Max(1, math.Floor((lmax-lmin)/float64(n)))
+		for i := 0; i < AxisMaxTicks; i++ {
+			e := lmin + float64(i)*lstep
+			if !(e <= lmax) {
+				break
+			}
+			for _, m := range mults {
+				v := m * math.Pow(10, e)
+				if v >= ax.ViewMin && v <= ax.ViewMax {
+					tks = append(tks, Tick{v, ax.FormatValue(v, 0)})
+				}
+			}
+		}
+	case AxisTime:
+		step := TimeSteps[len(TimeSteps)-1]
+		for _, ts := range TimeSteps {
+			if rng/ts <= float64(n) {
+				step = ts
+				break
+			}
+		}
+		start := math.Ceil(ax.ViewMin / step)
+		for i := 0; i < AxisMaxTicks; i++ {
+			v := (start + float64(i)) * step
+			if v > ax.ViewMax {
+				break
+			}
+			tks = append(tks, Tick{v, ax.FormatValue(v, step)})
+		}
+	default:
+		step := NiceNum(NiceNum(rng, false)/float64(n), true)
+		if !(step > 0) || math.IsInf(step, 0) {
+			return nil
+		}
+		start := math.Ceil(ax.ViewMin / step)
+		for i := 0; i < AxisMaxTicks; i++ {
+			v := (start + float64(i)) * step
+			if v > ax.ViewMax+step*1e-6 {
+				break
+			}
+			if math.Abs(v) < step*1e-6 {
+				v = 0
+			}
+			tks = append(tks, Tick{v, ax.FormatValue(v, step)})
+		}
+	}
+	return tks
+}
+
+// FormatValue returns given value formatted as a string for this axis, with
+// a precision appropriate for given step between values (0 = full precision)
+func (ax *Axis) FormatValue(v, step float64) string {
+	if ax.Scale == AxisTime {
+		fmt := ax.TimeFormat
+		if fmt == "" {
+			switch {
+			case step == 0 || step < 60:
+				fmt = "15:04:05"
+			case step < 86400:
+				fmt = "15:04"
+			case step < 365*86400:
+				fmt = "Jan 2"
+			default:
+				fmt = "2006"
+			}
+		}
+		return ValueTime(v).Format(fmt)
+	}
+	if step <= 0 || ax.Scale == AxisLog {
+		return strconv.FormatFloat(v, 'g', 4, 64)
+	}
+	prec := int(math.Max(0, -math.Floor(math.Log10(step))))
+	return strconv.FormatFloat(v, 'f', prec, 64)
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2018, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNiceNum(t *testing.T) {
+	tests := []struct {
+		v     float64
+		round bool
+		want  float64
+	}{
+		{1, false, 1},
+		{1.2, false, 2},
+		{3, false, 5},
+		{7, false, 10},
+		{1.2, true, 1},
+		{2.5, true, 2},
+		{4, true, 5},
+		{8, true, 10},
+		{0.034, true, 0.05},
+		{1234, false, 2000},
+		{1.7e9, true, 2e9},
+	}
+	for _, tt := range tests {
+		got := NiceNum(tt.v, tt.round)
+		if math.Abs(got-tt.want) > tt.want*1e-12 {
+			t.Errorf("NiceNum(%v, %v) = %v, want %v", tt.v, tt.round, got, tt.want)
+		}
+	}
+}
+
+func TestTicks(t *testing.T) {
+	tests := []struct {
+		name     string
+		scale    AxisScales
+		min, max float64
+		want     []float64
+	}{
+		{"linear", AxisLinear, 0, 10, []float64{0, 2, 4, 6, 8, 10}},
+		{"linear-neg", AxisLinear, -1, 1, []float64{-1, -0.5, 0, 0.5, 1}},
+		{"linear-frac", AxisLinear, 0.1, 0.35, []float64{0.1, 0.2, 0.3}},
+		{"log", AxisLog, 1, 1000, []float64{1, 2, 5, 10, 20, 50, 100, 200, 500, 1000}},
+		{"time", AxisTime, 0, 60, []float64{0, 15, 30, 45, 60}},
+		{"empty", AxisLinear, 5, 5, nil},
+	}
+	for _, tt := range tests {
+		ax := &Axis{Scale: tt.scale, ViewMin: tt.min, ViewMax: tt.max}
+		tks := ax.Ticks()
+		if len(tks) != len(tt.want) {
+			t.Errorf("%v: got %v ticks, want %v: %v", tt.name, len(tks), len(tt.want), tks)
+			continue
+		}
+		for i, tk := range tks {
+			if math.Abs(tk.Value-tt.want[i]) > 1e-9 {
+				t.Errorf("%v: tick %v = %v, want %v", tt.name, i, tk.Value, tt.want[i])
+			}
+		}
+	}
+}
+
+func TestTicksTinyRange(t *testing.T) {
+	for _, sc := range []AxisScales{AxisLinear, AxisTime, AxisLog} {
+		ax := &Axis{Scale: sc, ViewMin: 1.7e9, ViewMax: math.Nextafter(1.7e9, math.Inf(1))}
+		if tks := ax.Ticks(); len(tks) > AxisMaxTicks {
+			t.Errorf("%v: got %v ticks, more than AxisMaxTicks", sc, len(tks))
+		}
+	}
+}
+
+func TestZoomMinRange(t *testing.T) {
+	for _, sc := range []AxisScales{AxisLinear, AxisLog} {
+		ax := &Axis{Scale: sc, ViewMin: 1.7e9, ViewMax: 1.7e9 + 1}
+		for i := 0; i < 200; i++ {
+			ax.Zoom(0.5, 0.5)
+		}
+		if !(ax.ViewMax-ax.ViewMin >= 1.7e9*AxisMinRangeRel*0.99) {
+			t.Errorf("%v: zoomed range %v below minimum", sc, ax.ViewMax-ax.ViewMin)
+		}
+		if tks := ax.Ticks(); (sc == AxisLinear && len(tks) == 0) || len(tks) > 20 {
+			t.Errorf("%v: got %v ticks after zooming in", sc, len(tks))
+		}
+	}
+}
@@ -0,0 +1,258 @@
+// Copyright (c) 2018, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot
+
+import (
+	"fmt"
+	"html"
+	"image"
+	"image/color"
+	"strings"
+
+	"github.com/goki/gi/gi"
+	"github.com/goki/gi/units"
+	"github.com/goki/mat32"
+)
+
+// Canvas is the drawing interface that plots are drawn on, so the same
+// drawing code renders plots in the GUI and to SVG files.  All positions
+// are in dots.
+type Canvas interface {
+	// Polyline draws lines through given points, optionally dashed
+	Polyline(pts []mat32.Vec2, clr gi.Color, width float32, dashed bool)
+
+	// Polygon fills the polygon with given points
+	Polygon(pts []mat32.Vec2, clr gi.Color)
+
+	// Rect fills a rectangle at given position and size
+	Rect(pos, size mat32.Vec2, clr gi.Color)
+
+	// Circle fills a circle of given radius at given center
+	Circle(ctr mat32.Vec2, r float32, clr gi.Color)
+
+	// Text draws text with its top-left at given position -- if vert, the
+	// text is rotated 90 degrees clockwise, reading from top to bottom, with
+	// given position at the top-left of the rotated text
+	Text(str string, pos mat32.Vec2, clr gi.Color, vert bool)
+
+	// TextSize returns the size of given text, unrotated
+	TextSize(str string) mat32.Vec2
+
+	// FontHeight returns the height of a line of text
+	FontHeight() float32
+
+	// Clip restricts further drawing to given rectangle, until Unclip
+	Clip(pos, size mat32.Vec2)
+
+	// Unclip ends the restriction of drawing set by Clip
+	Unclip()
+}
+
+////////////////////////////////////////////////////////////////////////////////////////
+// PaintCanvas
+
+// PaintCanvas is a Canvas that draws using gi.Paint on a gi.RenderState
+type PaintCanvas struct {
+	Rs   *gi.RenderState
+	Font *gi.FontStyle
+	Ctxt *units.Context
+	Txt  *gi.TextStyle
+	tr   gi.TextRender
+	clip []image.Rectangle
+}
+
+// NewPaintCanvas returns a new canvas drawing on given render state, with
+// text in a copy of given font (which must be open) and text styles
+func NewPaintCanvas(rs *gi.RenderState, font *gi.FontStyle, ctxt *units.Context, txt *gi.TextStyle) *PaintCanvas {
+	fs := *font
+	return &PaintCanvas{Rs: rs, Font: &fs, Ctxt: ctxt, Txt: txt}
+}
+
+func (pc *PaintCanvas) Polyline(pts []mat32.Vec2, clr gi.Color, width float32, dashed bool) {
+	rs := pc.Rs
+	p := &rs.Paint
+	p.FillStyle.SetColor(nil)
+	p.StrokeStyle.SetColor(clr)
+	p.StrokeStyle.Width.Dots = width
+	if dashed {
+		p.StrokeStyle.Dashes = []float64{3, 3}
+	}
+	p.DrawPolyline(rs, pts)
+	p.Stroke(rs)
+	p.StrokeStyle.Dashes = nil
+}
+
+func (pc *PaintCanvas) Polygon(pts []mat32.Vec2, clr gi.Color) {
+	rs := pc.Rs
+	p := &rs.Paint
+	p.StrokeStyle.SetColor(nil)
+	p.FillStyle.SetColor(clr)
+	p.DrawPolygon(rs, pts)
+	p.Fill(rs)
+}
+
+func (pc *PaintCanvas) Rect(pos, size mat32.Vec2, clr gi.Color) {
+	rs := pc.Rs
+	p := &rs.Paint
+	if clr.A == 255 {
+		p.FillBoxColor(rs, pos, size, clr)
+		return
+	}
+	p.StrokeStyle.SetColor(nil)
+	p.FillStyle.SetColor(clr)
+	p.DrawRectangle(rs, pos.X, pos.Y, size.X, size.Y)
+	p.Fill(rs)
+}
+
+func (pc *PaintCanvas) Circle(ctr mat32.Vec2, r float32, clr gi.Color) {
+	rs := pc.Rs
+	p := &rs.Paint
+	p.StrokeStyle.SetColor(nil)
+	p.FillStyle.SetColor(clr)
+	p.DrawCircle(rs, ctr.X, ctr.Y, r)
+	p.Fill(rs)
+}
+
+func (pc *PaintCanvas) Text(str string, pos mat32.Vec2, clr gi.Color, vert bool) {
+	prv := pc.Font.Color
+	pc.Font.Color = clr
+	if vert {
+		// baseline is on the left, with the ascent to the right
+		pc.tr.SetStringRot90(str, pc.Font, pc.Ctxt, pc.Txt, true, 0)
+		asc := mat32.FromFixed(pc.Font.Face.Face.Metrics().Ascent)
+		pc.tr.Render(pc.Rs, mat32.Vec2{pos.X + pc.FontHeight() - asc, pos.Y})
+	} else {
+		pc.tr.SetString(str, pc.Font, pc.Ctxt, pc.Txt, true, 0, 0)
+		pc.tr.RenderTopPos(pc.Rs, pos)
+	}
+	pc.Font.Color = prv
+}
+
+func (pc *PaintCanvas) TextSize(str string) mat32.Vec2 {
+	pc.tr.SetString(str, pc.Font, pc.Ctxt, pc.Txt, true, 0, 0)
+	return pc.tr.Size
+}
+
+func (pc *PaintCanvas) FontHeight() float32 {
+	return pc.Font.Face.Metrics.Height
+}
+
+func (pc *PaintCanvas) Clip(pos, size mat32.Vec2) {
+	end := pos.Add(size)
+	b := image.Rect(int(pos.X), int(pos.Y), int(mat32.Ceil(end.X)), int(mat32.Ceil(end.Y)))
+	rs := pc.Rs
+	if rs.Bounds.Empty() {
+		rs.Bounds = rs.Image.Bounds()
+	}
+	// note: render state is already locked during rendering, so bounds are
+	// set directly instead of using PushBounds
+	pc.clip = append(pc.clip, rs.Bounds)
+	rs.Bounds = rs.Bounds.Intersect(b)
+}
+
+func (pc *PaintCanvas) Unclip() {
+	n := len(pc.clip)
+	if n == 0 {
+		return
+	}
+	pc.Rs.Bounds = pc.clip[n-1]
+	pc.clip = pc.clip[:n-1]
+}
+
+////////////////////////////////////////////////////////////////////////////////////////
+// SVGCanvas
+
+// SVGCanvas is a Canvas that writes SVG elements -- text is measured using
+// a PaintCanvas for the same font
+type SVGCanvas struct {
+	Buf     strings.Builder
+	Measure *PaintCanvas `desc:"canvas used to measure text"`
+	NClips  int          `desc:"number of clip paths defined so far"`
+}
+
+// NewSVGCanvas returns a new SVG canvas of given size, measuring text
+// using given canvas -- call End when done drawing
+func NewSVGCanvas(size mat32.Vec2, measure *PaintCanvas) *SVGCanvas {
+	sc := &SVGCanvas{Measure: measure}
+	fmt.Fprintf(&sc.Buf, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%g\" height=\"%g\" viewBox=\"0 0 %g %g\">\n", size.X, size.Y, size.X, size.Y)
+	return sc
+}
+
+// End ends the svg element, and returns the full svg text
+func (sc *SVGCanvas) End() string {
+	sc.Buf.WriteString("</svg>\n")
+	return sc.Buf.String()
+}
+
+// svgColor returns the SVG color and opacity for given (pre-multiplied) color
+func svgColor(clr gi.Color) (string, float32) {
+	nc := color.NRGBAModel.Convert(clr).(color.NRGBA)
+	return fmt.Sprintf("rgb(%d,%d,%d)", nc.R, nc.G, nc.B), float32(nc.A) / 255
+}
+
+// svgPoints returns the points attribute for given points
+func svgPoints(pts []mat32.Vec2) string {
+	ps := make([]string, len(pts))
+	for i, p := range pts {
+		ps[i] = fmt.Sprintf("%g,%g", p.X, p.Y)
+	}
+	return strings.Join(ps, " ")
+}
+
+func (sc *SVGCanvas) Polyline(pts []mat32.Vec2, clr gi.Color, width float32, dashed bool) {
+	c, op := svgColor(clr)
+	dash := ""
+	if dashed {
+		dash = " stroke-dasharray=\"3,3\""
+	}
+	fmt.Fprintf(&sc.Buf, "<polyline points=\"%s\" fill=\"none\" stroke=\"%s\" stroke-opacity=\"%g\" stroke-width=\"%g\"%s/>\n", svgPoints(pts), c, op, width, dash)
+}
+
+func (sc *SVGCanvas) Polygon(pts []mat32.Vec2, clr gi.Color) {
+	c, op := svgColor(clr)
+	fmt.Fprintf(&sc.Buf, "<polygon points=\"%s\" fill=\"%s\" fill-opacity=\"%g\" stroke=\"none\"/>\n", svgPoints(pts), c, op)
+}
+
+func (sc *SVGCanvas) Rect(pos, size mat32.Vec2, clr gi.Color) {
+	c, op := svgColor(clr)
+	fmt.Fprintf(&sc.Buf, "<rect x=\"%g\" y=\"%g\" width=\"%g\" height=\"%g\" fill=\"%s\" fill-opacity=\"%g\" stroke=\"none\"/>\n", pos.X, pos.Y, size.X, size.Y, c, op)
+}
+
+func (sc *SVGCanvas) Circle(ctr mat32.Vec2, r float32, clr gi.Color) {
+	c, op := svgColor(clr)
+	fmt.Fprintf(&sc.Buf, "<circle cx=\"%g\" cy=\"%g\" r=\"%g\" fill=\"%s\" fill-opacity=\"%g\" stroke=\"none\"/>\n", ctr.X, ctr.Y, r, c, op)
+}
+
+func (sc *SVGCanvas) Text(str string, pos mat32.Vec2, clr gi.Color, vert bool) {
+	c, op := svgColor(clr)
+	fs := sc.Measure.Font
+	asc := mat32.FromFixed(fs.Face.Face.Metrics().Ascent)
+	tf := ""
+	x, y := pos.X, pos.Y+asc
+	if vert {
+		x, y = pos.X+sc.FontHeight()-asc, pos.Y
+		tf = fmt.Sprintf(" transform=\"rotate(90 %g %g)\"", x, y)
+	}
+	fmt.Fprintf(&sc.Buf, "<text x=\"%g\" y=\"%g\" font-family=\"%s\" font-size=\"%gpx\" fill=\"%s\" fill-opacity=\"%g\"%s>%s</text>\n",
+		x, y, fs.Family, fs.Size.Dots, c, op, tf, html.EscapeString(str))
+}
+
+func (sc *SVGCanvas) TextSize(str string) mat32.Vec2 {
+	return sc.Measure.TextSize(str)
+}
+
+func (sc *SVGCanvas) FontHeight() float32 {
+	return sc.Measure.FontHeight()
+}
+
+func (sc *SVGCanvas) Clip(pos, size mat32.Vec2) {
+	sc.NClips++
+	fmt.Fprintf(&sc.Buf, "<clipPath id=\"clip%d\"><rect x=\"%g\" y=\"%g\" width=\"%g\" height=\"%g\"/></clipPath>\n", sc.NClips, pos.X, pos.Y, size.X, size.Y)
+	fmt.Fprintf(&sc.Buf, "<g clip-path=\"url(#clip%d)\">\n", sc.NClips)
+}
+
+func (sc *SVGCanvas) Unclip() {
+	sc.Buf.WriteString("</g>\n")
+}
@@ -0,0 +1,19 @@
+// Copyright (c) 2018, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package plot provides a Plot widget for 2D charts of data series: line,
+scatter, bar, histogram and area charts, with linear, log or time axes and
+automatic ticks, legends and error bars.
+
+Series are given as slices of X and Y values, or read from the fields of a
+slice of structs (as shown in a giv.TableView) using SetTable.  Colors for
+series that do not set their own are taken from a giv.ColorMap.
+
+In the GUI, the mouse scroll wheel zooms in and out around the mouse, dragging
+pans the view, and double-click resets it.  Hovering shows a read-out of the
+nearest data point.  The context menu can save the plot as a PNG image or an
+SVG file, which can also be done with the SavePNG and SaveSVG methods.
+*/
+package plot
@@ -0,0 +1,812 @@
+// Copyright (c) 2018, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"io/ioutil"
+	"log"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/goki/gi/gi"
+	"github.com/goki/gi/giv"
+	"github.com/goki/gi/oswin"
+	"github.com/goki/gi/oswin/key"
+	"github.com/goki/gi/oswin/mouse"
+	"github.com/goki/gi/units"
+	"github.com/goki/ki/ki"
+	"github.com/goki/ki/kit"
+	"github.com/goki/mat32"
+)
+
+// HoverDist is the maximum distance in dots from the mouse to a point for
+// the point to be shown in the hover read-out
+var HoverDist = float32(20)
+
+// Plot is a widget that draws a 2D chart of one or more data Series, with
+// axes, legend, pan / zoom via the mouse (scroll wheel to zoom -- with Shift
+// for X only and Alt for Y only -- drag to pan, double-click to reset), a
+// read-out of the point nearest the mouse, and export to PNG and SVG files
+// via the context menu.  The series can be set directly or read from a slice
+// of structs using SetTable.
+type Plot struct {
+	gi.WidgetBase
+	Title       string           `desc:"title shown above the plot"`
+	X           Axis             `desc:"the X (horizontal) axis"`
+	Y           Axis             `desc:"the Y (vertical) axis"`
+	Series      []*Series        `desc:"the data series shown in the plot"`
+	ColorMap    giv.ColorMapName `desc:"name of the color map that colors of series without their own Color are chosen from -- default is JetMuted"`
+	NoLegend    bool             `desc:"do not show the legend with the names of the series"`
+	Table       interface{}      `copy:"-" json:"-" xml:"-" view:"-" desc:"slice of structs that the series are read from -- see SetTable"`
+	TableType   SeriesTypes      `desc:"type of the series read from the Table"`
+	TableX      string           `desc:"field of the Table elements used for the X values -- empty = element index"`
+	TableY      []string         `desc:"fields of the Table elements used for the Y values of each series"`
+	HoverOn     bool             `copy:"-" json:"-" xml:"-" view:"-" desc:"a point is shown in the hover read-out"`
+	HoverSeries int              `copy:"-" json:"-" xml:"-" view:"-" desc:"index of the series of the hover point"`
+	HoverIdx    int              `copy:"-" json:"-" xml:"-" view:"-" desc:"index of the hover point within its series"`
+	PlotPos     mat32.Vec2       `copy:"-" json:"-" xml:"-" view:"-" desc:"position of the data area of the plot, from the last render"`
+	PlotSize    mat32.Vec2       `copy:"-" json:"-" xml:"-" view:"-" desc:"size of the data area of the plot, from the last render"`
+}
+
+var KiT_Plot = kit.Types.AddType(&Plot{}, PlotProps)
+
+// AddNewPlot adds a new plot to given parent node, with given name.
+func AddNewPlot(parent ki.Ki, name string) *Plot {
+	return parent.AddNewChild(KiT_Plot, name).(*Plot)
+}
+
+func (pl *Plot) CopyFieldsFrom(frm interface{}) {
+	fr := frm.(*Plot)
+	pl.WidgetBase.CopyFieldsFrom(&fr.WidgetBase)
+	pl.Title = fr.Title
+	pl.X = fr.X
+	pl.Y = fr.Y
+	pl.Series = make([]*Series, len(fr.Series))
+	for i, sr := range fr.Series {
+		cs := *sr
+		pl.Series[i] = &cs
+	}
+	pl.ColorMap = fr.ColorMap
+	pl.NoLegend = fr.NoLegend
+	pl.TableType = fr.TableType
+	pl.TableX = fr.TableX
+	pl.TableY = append([]string{}, fr.TableY...)
+}
+
+var PlotProps = ki.Props{
+	"EnumType:Flag":    gi.KiT_NodeFlags,
+	"border-width":     units.NewPx(1),
+	"border-color":     &gi.Prefs.Colors.Border,
+	"padding":          units.NewPx(4),
+	"margin":           units.NewPx(2),
+	"color":            &gi.Prefs.Colors.Font,
+	"background-color": &gi.Prefs.Colors.Background,
+	"min-width":        units.NewEm(20),
+	"min-height":       units.NewEm(12),
+	"width":            units.NewEm(40),
+	"height":           units.NewEm(25),
+	"max-width":        -1,
+	"max-height":       -1,
+}
+
+// AddSeries adds a new series with given name, type and values, and
+// returns it -- x can be nil to use the index of each Y value
+func (pl *Plot) AddSeries(name string, typ SeriesTypes, x, y []float64) *Series {
+	sr := NewSeries(name, typ, x, y)
+	pl.Series = append(pl.Series, sr)
+	return sr
+}
+
+// SeriesByName returns the series with given name, false if not found
+func (pl *Plot) SeriesByName(name string) (*Series, bool) {
+	for _, sr := range pl.Series {
+		if sr.Name == name {
+			return sr, true
+		}
+	}
+	return nil, false
+}
+
+// SetTable sets the series of the plot to be read from given fields of a
+// slice of structs (or pointers to them), as shown in a giv.TableView: one
+// series of given type for each of the yFields, with X values from xField
+// (empty = element index) -- if xField is a time.Time, the X axis is set to
+// AxisTime.  Call UpdateTable to re-read the values after they change (e.g.,
+// on the ViewSig of the TableView).
+func (pl *Plot) SetTable(slice interface{}, typ SeriesTypes, xField string, yFields ...string) error {
+	pl.Table = slice
+	pl.TableType = typ
+	pl.TableX = xField
+	pl.TableY = yFields
+	if xField != "" {
+		et := kit.NonPtrType(kit.SliceElType(slice))
+		if et.Kind() == reflect.Struct {
+			if fld, ok := et.FieldByName(xField); ok && fld.Type == reflect.TypeOf(time.Time{}) {
+				pl.X.Scale = AxisTime
+			}
+		}
+	}
+	return pl.UpdateTable()
+}
+
+// UpdateTable re-reads the series from the Table set by SetTable, keeping
+// the colors and other settings of existing series of the same name, and
+// updates the display
+func (pl *Plot) UpdateTable() error {
+	if pl.Table == nil {
+		return nil
+	}
+	srs := make([]*Series, 0, len(pl.TableY))
+	for _, yf := range pl.TableY {
+		nsr, err := NewSeriesFromSlice(yf, pl.TableType, pl.Table, pl.TableX, yf)
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+		if sr, ok := pl.SeriesByName(yf); ok {
+			sr.Type = pl.TableType
+			sr.X, sr.Y = nsr.X, nsr.Y
+			nsr = sr
+		}
+		srs = append(srs, nsr)
+	}
+	pl.Series = srs
+	pl.UpdateSig()
+	return nil
+}
+
+// SeriesColor returns the color of the series at given index: its own
+// Color if set, otherwise the color at the corresponding position in the
+// ColorMap
+func (pl *Plot) SeriesColor(idx int) gi.Color {
+	sr := pl.Series[idx]
+	if !sr.Color.IsNil() {
+		return sr.Color
+	}
+	cm, ok := giv.AvailColorMaps[string(pl.ColorMap)]
+	if !ok {
+		cm = giv.StdColorMaps["JetMuted"]
+	}
+	n := len(pl.Series)
+	if n <= 1 {
+		return cm.Map(0)
+	}
+	return cm.Map(float64(idx) / float64(n-1))
+}
+
+// ResetView resets any zooming and panning, to show the full range of the
+// data
+func (pl *Plot) ResetView() {
+	pl.X.Zoomed = false
+	pl.Y.Zoomed = false
+	pl.UpdateSig()
+}
+
+// BarSpacing returns the minimum spacing between successive X values of
+// the visible bar series, used for the width of bars -- 1 if none
+func (pl *Plot) BarSpacing() float64 {
+	var xs []float64
+	for _, sr := range pl.Series {
+		if sr.Hidden || sr.Type != SeriesBar {
+			continue
+		}
+		sxs, _ := sr.Points()
+		xs = append(xs, sxs...)
+	}
+	sort.Float64s(xs)
+	sp := math.Inf(1)
+	for i := 1; i < len(xs); i++ {
+		if d := xs[i] - xs[i-1]; d > 0 && d < sp {
+			sp = d
+		}
+	}
+	if math.IsInf(sp, 1) {
+		return 1
+	}
+	return sp
+}
+
+// UpdateRanges updates the visible ranges of the axes from the range of the
+// visible series, unless zoomed or panned
+func (pl *Plot) UpdateRanges() {
+	xmin, ymin := math.Inf(1), math.Inf(1)
+	xmax, ymax := math.Inf(-1), math.Inf(-1)
+	hasBars := false
+	for _, sr := range pl.Series {
+		if sr.Hidden {
+			continue
+		}
+		sxmin, sxmax, symin, symax := sr.Range()
+		xmin = math.Min(xmin, sxmin)
+		xmax = math.Max(xmax, sxmax)
+		ymin = math.Min(ymin, symin)
+		ymax = math.Max(ymax, symax)
+		if sr.Type == SeriesBar {
+			hasBars = true
+		}
+	}
+	if hasBars && pl.X.Scale != AxisLog {
+		hw := 0.5 * pl.BarSpacing()
+		xmin -= hw
+		xmax += hw
+	}
+	pl.X.SetRange(xmin, xmax)
+	pl.Y.SetRange(ymin, ymax)
+}
+
+// ToDots returns the position in dots of given data values, for a data
+// area at given position and size
+func (pl *Plot) ToDots(x, y float64, pos, size mat32.Vec2) mat32.Vec2 {
+	return mat32.Vec2{pos.X + float32(pl.X.Norm(x))*size.X, pos.Y + size.Y - float32(pl.Y.Norm(y))*size.Y}
+}
+
+// validPt returns whether given point in dots is valid for drawing
+func validPt(p mat32.Vec2) bool {
+	return !math.IsNaN(float64(p.X)) && !math.IsNaN(float64(p.Y)) && !math.IsInf(float64(p.X), 0) && !math.IsInf(float64(p.Y), 0)
+}
+
+// Draw draws the plot on given canvas, within given position and size, and
+// returns the position and size of the data area within it
+func (pl *Plot) Draw(cv Canvas, pos, size mat32.Vec2, fg, bg gi.Color) (mat32.Vec2, mat32.Vec2) {
+	pl.UpdateRanges()
+	fht := cv.FontHeight()
+	tlen := mat32.Round(0.4 * fht)
+	grid := fg.Blend(85, bg)
+
+	xtks := pl.X.Ticks()
+	ytks := pl.Y.Ticks()
+
+	top := pos.Y + 0.5*fht
+	if pl.Title != "" {
+		tsz := cv.TextSize(pl.Title)
+		cv.Text(pl.Title, mat32.Vec2{pos.X + 0.5*(size.X-tsz.X), pos.Y}, fg, false)
+		top = pos.Y + 1.5*fht
+	}
+	left := pos.X
+	if pl.Y.Label != "" {
+		left += 1.2 * fht
+	}
+	ytw := float32(0)
+	for _, tk := range ytks {
+		ytw = mat32.Max(ytw, cv.TextSize(tk.Label).X)
+	}
+	left += ytw + tlen + 2
+	bottom := pos.Y + size.Y - (fht + tlen + 2)
+	if pl.X.Label != "" {
+		bottom -= 1.2 * fht
+	}
+	right := pos.X + size.X - 0.5*fht
+	if len(xtks) > 0 {
+		right = pos.X + size.X - mat32.Max(4, 0.5*cv.TextSize(xtks[len(xtks)-1].Label).X)
+	}
+	pp := mat32.Vec2{left, top}
+	ps := mat32.Vec2{right - left, bottom - top}
+	if ps.X < 10 || ps.Y < 10 {
+		return pp, ps
+	}
+
+	// grid and ticks
+	for _, tk := range xtks {
+		x := pl.ToDots(tk.Value, 1, pp, ps).X
+		if !pl.X.NoGrid {
+			cv.Polyline([]mat32.Vec2{{x, top}, {x, bottom}}, grid, 1, true)
+		}
+		cv.Polyline([]mat32.Vec2{{x, bottom}, {x, bottom + tlen}}, fg, 1, false)
+		tsz := cv.TextSize(tk.Label)
+		cv.Text(tk.Label, mat32.Vec2{x - 0.5*tsz.X, bottom + tlen + 2}, fg, false)
+	}
+	for _, tk := range ytks {
+		y := pl.ToDots(pl.X.ViewMin, tk.Value, pp, ps).Y
+		if !pl.Y.NoGrid {
+			cv.Polyline([]mat32.Vec2{{left, y}, {right, y}}, grid, 1, true)
+		}
+		cv.Polyline([]mat32.Vec2{{left - tlen, y}, {left, y}}, fg, 1, false)
+		tsz := cv.TextSize(tk.Label)
+		cv.Text(tk.Label, mat32.Vec2{left - tlen - 2 - tsz.X, y - 0.5*fht}, fg, false)
+	}
+	if pl.X.Label != "" {
+		tsz := cv.TextSize(pl.X.Label)
+		cv.Text(pl.X.Label, mat32.Vec2{left + 0.5*(ps.X-tsz.X), bottom + tlen + 2 + 1.2*fht}, fg, false)
+	}
+	if pl.Y.Label != "" {
+		tsz := cv.TextSize(pl.Y.Label)
+		cv.Text(pl.Y.Label, mat32.Vec2{pos.X, top + 0.5*(ps.Y-tsz.X)}, fg, true)
+	}
+
+	cv.Clip(pp, ps)
+	pl.DrawSeries(cv, pp, ps)
+	cv.Unclip()
+
+	cv.Polyline([]mat32.Vec2{{left, top}, {right, top}, {right, bottom}, {left, bottom}, {left, top}}, fg, 1, false)
+
+	if !pl.NoLegend {
+		pl.DrawLegend(cv, pp, ps, fg, bg)
+	}
+	pl.DrawHover(cv, pp, ps, fg, bg)
+	return pp, ps
+}
+
+// DrawSeries draws the visible series in the data area at given position
+// and size
+func (pl *Plot) DrawSeries(cv Canvas, pp, ps mat32.Vec2) {
+	nbars := 0
+	for _, sr := range pl.Series {
+		if !sr.Hidden && sr.Type == SeriesBar {
+			nbars++
+		}
+	}
+	barw := 0.8 * pl.BarSpacing()
+	if nbars > 1 {
+		barw /= float64(nbars)
+	}
+	bari := 0
+	base := float32(pl.Y.Norm(0))
+	if math.IsNaN(float64(base)) || base < 0 {
+		base = 0
+	} else if base > 1 {
+		base = 1
+	}
+	basey := pp.Y + ps.Y - base*ps.Y
+
+	for si, sr := range pl.Series {
+		if sr.Hidden {
+			continue
+		}
+		clr := pl.SeriesColor(si)
+		lw := sr.LineWidth
+		if lw <= 0 {
+			lw = 2
+		}
+		xs, ys := sr.Points()
+		switch sr.Type {
+		case SeriesLine, SeriesArea:
+			var pts []mat32.Vec2
+			flush := func() {
+				if len(pts) > 1 {
+					if sr.Type == SeriesArea {
+						apts := append([]mat32.Vec2{{pts[0].X, basey}}, pts...)
+						apts = append(apts, mat32.Vec2{pts[len(pts)-1].X, basey})
+						cv.Polygon(apts, clr.Clearer(70))
+					}
+					cv.Polyline(pts, clr, lw, false)
+				}
+				pts = pts[:0]
+			}
+			for i, x := range xs {
+				p := pl.ToDots(x, ys[i], pp, ps)
+				if !validPt(p) {
+					flush()
+					continue
+				}
+				pts = append(pts, p)
+				if sr.PointSize > 0 {
+					cv.Circle(p, sr.PointSize, clr)
+				}
+			}
+			flush()
+		case SeriesScatter:
+			r := sr.PointSize
+			if r <= 0 {
+				r = 3
+			}
+			for i, x := range xs {
+				if p := pl.ToDots(x, ys[i], pp, ps); validPt(p) {
+					cv.Circle(p, r, clr)
+				}
+			}
+		case SeriesBar:
+			off := (float64(bari) - 0.5*float64(nbars-1)) * barw
+			for i, x := range xs {
+				p0 := pl.ToDots(x+off-0.5*barw, ys[i], pp, ps)
+				p1 := pl.ToDots(x+off+0.5*barw, ys[i], pp, ps)
+				if !validPt(p0) || !validPt(p1) {
+					continue
+				}
+				pl.drawBar(cv, p0.X, p1.X, p0.Y, basey, clr)
+			}
+			bari++
+		case SeriesHistogram:
+			edges, counts := sr.Histogram()
+			for i, c := range counts {
+				p0 := pl.ToDots(edges[i], c, pp, ps)
+				p1 := pl.ToDots(edges[i+1], c, pp, ps)
+				if !validPt(p0) || !validPt(p1) {
+					continue
+				}
+				pl.drawBar(cv, p0.X, p1.X-1, p0.Y, basey, clr)
+			}
+		}
+		// error bars
+		capw := mat32.Max(3, lw+1)
+		for i, x := range xs {
+			e := sr.ErrAt(i)
+			if e == 0 || math.IsNaN(e) {
+				continue
+			}
+			if sr.Type == SeriesBar {
+				x += (float64(bari-1) - 0.5*float64(nbars-1)) * barw
+			}
+			lo := pl.ToDots(x, ys[i]-math.Abs(e), pp, ps)
+			hi := pl.ToDots(x, ys[i]+math.Abs(e), pp, ps)
+			if !validPt(hi) {
+				continue
+			}
+			if !validPt(lo) { // e.g., below 0 on log scale
+				lo.X, lo.Y = hi.X, pp.Y+ps.Y
+			}
+			cv.Polyline([]mat32.Vec2{{lo.X, lo.Y}, {hi.X, hi.Y}}, clr, 1, false)
+			cv.Polyline([]mat32.Vec2{{lo.X - capw, lo.Y}, {lo.X + capw, lo.Y}}, clr, 1, false)
+			cv.Polyline([]mat32.Vec2{{hi.X - capw, hi.Y}, {hi.X + capw, hi.Y}}, clr, 1, false)
+		}
+	}
+}
+
+// drawBar draws a bar between given x positions, from given y to the base y
+func (pl *Plot) drawBar(cv Canvas, x0, x1, y, basey float32, clr gi.Color) {
+	if x1 < x0 {
+		x0, x1 = x1, x0
+	}
+	y0, y1 := y, basey
+	if y1 < y0 {
+		y0, y1 = y1, y0
+	}
+	cv.Rect(mat32.Vec2{x0, y0}, mat32.Vec2{mat32.Max(1, x1-x0), y1 - y0}, clr)
+}
+
+// DrawLegend draws the legend of the visible series at the top-right of the
+// data area at given position and size
+func (pl *Plot) DrawLegend(cv Canvas, pp, ps mat32.Vec2, fg, bg gi.Color) {
+	fht := cv.FontHeight()
+	sw := 1.5 * fht
+	var idxs []int
+	w := float32(0)
+	for si, sr := range pl.Series {
+		if sr.Hidden || sr.Name == "" {
+			continue
+		}
+		idxs = append(idxs, si)
+		w = mat32.Max(w, cv.TextSize(sr.Name).X)
+	}
+	if len(idxs) == 0 {
+		return
+	}
+	pad := 0.3 * fht
+	lsz := mat32.Vec2{w + sw + 3*pad, float32(len(idxs))*fht + 2*pad}
+	lpos := mat32.Vec2{pp.X + ps.X - lsz.X - pad, pp.Y + pad}
+	cv.Rect(lpos, lsz, bg.Clearer(20))
+	cv.Polyline([]mat32.Vec2{lpos, {lpos.X + lsz.X, lpos.Y}, lpos.Add(lsz), {lpos.X, lpos.Y + lsz.Y}, lpos}, fg.Blend(70, bg), 1, false)
+	y := lpos.Y + pad
+	for _, si := range idxs {
+		sr := pl.Series[si]
+		clr := pl.SeriesColor(si)
+		x := lpos.X + pad
+		switch sr.Type {
+		case SeriesLine:
+			cv.Polyline([]mat32.Vec2{{x, y + 0.5*fht}, {x + sw, y + 0.5*fht}}, clr, 2, false)
+		case SeriesScatter:
+			cv.Circle(mat32.Vec2{x + 0.5*sw, y + 0.5*fht}, 3, clr)
+		default:
+			cv.Rect(mat32.Vec2{x, y + 0.2*fht}, mat32.Vec2{sw, 0.6 * fht}, clr)
+		}
+		cv.Text(sr.Name, mat32.Vec2{x + sw + pad, y}, fg, false)
+		y += fht
+	}
+}
+
+// DrawHover draws the hover read-out of the point nearest the mouse, if any
+func (pl *Plot) DrawHover(cv Canvas, pp, ps mat32.Vec2, fg, bg gi.Color) {
+	if !pl.HoverOn || pl.HoverSeries >= len(pl.Series) {
+		return
+	}
+	sr := pl.Series[pl.HoverSeries]
+	xs, ys := sr.Points()
+	if pl.HoverIdx >= len(xs) {
+		return
+	}
+	x, y := xs[pl.HoverIdx], ys[pl.HoverIdx]
+	p := pl.ToDots(x, y, pp, ps)
+	if !validPt(p) {
+		return
+	}
+	cv.Circle(p, 4, fg)
+	cv.Circle(p, 2.5, pl.SeriesColor(pl.HoverSeries))
+	txt := fmt.Sprintf("%v: %v, %v", sr.Name, pl.X.FormatValue(x, 0), pl.Y.FormatValue(y, 0))
+	if e := sr.ErrAt(pl.HoverIdx); e != 0 {
+		txt += " ± " + pl.Y.FormatValue(math.Abs(e), 0)
+	}
+	fht := cv.FontHeight()
+	pad := 0.3 * fht
+	tsz := cv.TextSize(txt)
+	bsz := tsz.AddScalar(2 * pad)
+	bpos := mat32.Vec2{p.X + 8, p.Y - bsz.Y - 8}
+	if bpos.X+bsz.X > pp.X+ps.X {
+		bpos.X = p.X - 8 - bsz.X
+	}
+	if bpos.Y < pp.Y {
+		bpos.Y = p.Y + 8
+	}
+	cv.Rect(bpos, bsz, bg)
+	cv.Polyline([]mat32.Vec2{bpos, {bpos.X + bsz.X, bpos.Y}, bpos.Add(bsz), {bpos.X, bpos.Y + bsz.Y}, bpos}, fg, 1, false)
+	cv.Text(txt, bpos.AddScalar(pad), fg, false)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////
+//  Rendering, events
+
+// PlotDotsPos returns the position in dots, in the coordinates used for
+// PlotPos, of given window position
+func (pl *Plot) PlotDotsPos(pt image.Point) mat32.Vec2 {
+	pl.BBoxMu.RLock()
+	off := pl.WinBBox.Min
+	pl.BBoxMu.RUnlock()
+	return mat32.NewVec2FmPoint(pt.Sub(off)).Add(pl.LayState.Alloc.Pos)
+}
+
+// NormPos returns the normalized position within the data area of given
+// window position, and whether it is within the data area
+func (pl *Plot) NormPos(pt image.Point) (mat32.Vec2, bool) {
+	if pl.PlotSize.X <= 0 || pl.PlotSize.Y <= 0 {
+		return mat32.Vec2{}, false
+	}
+	n := pl.PlotDotsPos(pt).Sub(pl.PlotPos).Div(pl.PlotSize)
+	n.Y = 1 - n.Y
+	return n, n.X >= 0 && n.X <= 1 && n.Y >= 0 && n.Y <= 1
+}
+
+// UpdateHover updates the hover read-out to the point nearest given window
+// position, within HoverDist
+func (pl *Plot) UpdateHover(pt image.Point) {
+	_, in := pl.NormPos(pt)
+	on, hsi, hidx := false, 0, 0
+	if in {
+		mp := pl.PlotDotsPos(pt)
+		best := HoverDist
+		for si, sr := range pl.Series {
+			if sr.Hidden {
+				continue
+			}
+			xs, ys := sr.Points()
+			for i, x := range xs {
+				p := pl.ToDots(x, ys[i], pl.PlotPos, pl.PlotSize)
+				if !validPt(p) {
+					continue
+				}
+				if d := p.DistTo(mp); d < best {
+					best = d
+					on, hsi, hidx = true, si, i
+				}
+			}
+		}
+	}
+	if on == pl.HoverOn && hsi == pl.HoverSeries && hidx == pl.HoverIdx {
+		return
+	}
+	pl.HoverOn, pl.HoverSeries, pl.HoverIdx = on, hsi, hidx
+	pl.UpdateSig()
+}
+
+// MouseEvent handles double-click to reset the view, and the context menu
+func (pl *Plot) MouseEvent() {
+	pl.ConnectEvent(oswin.MouseEvent, gi.RegPri, func(recv, send ki.Ki, sig int64, d interface{}) {
+		me := d.(*mouse.Event)
+		plt := recv.Embed(KiT_Plot).(*Plot)
+		switch {
+		case me.Button == mouse.Left && me.Action == mouse.DoubleClick:
+			me.SetProcessed()
+			plt.ResetView()
+		case me.Button == mouse.Right && me.Action == mouse.Release:
+			me.SetProcessed()
+			plt.EmitContextMenuSignal()
+			plt.This().(gi.Node2D).ContextMenu()
+		}
+	})
+}
+
+// MouseScrollEvent zooms around the mouse position
+func (pl *Plot) MouseScrollEvent() {
+	pl.ConnectEvent(oswin.MouseScrollEvent, gi.RegPri, func(recv, send ki.Ki, sig int64, d interface{}) {
+		me := d.(*mouse.ScrollEvent)
+		plt := recv.Embed(KiT_Plot).(*Plot)
+		n, in := plt.NormPos(me.Where)
+		if !in {
+			return
+		}
+		del := me.NonZeroDelta(false)
+		if del == 0 {
+			return
+		}
+		me.SetProcessed()
+		factor := 1.1
+		if del < 0 {
+			factor = 1 / factor
+		}
+		if !me.HasAnyModifier(key.Alt) {
+			plt.X.Zoom(factor, float64(n.X))
+		}
+		if !me.HasAnyModifier(key.Shift) {
+			plt.Y.Zoom(factor, float64(n.Y))
+		}
+		plt.UpdateSig()
+	})
+}
+
+// MouseDragEvent pans the view
+func (pl *Plot) MouseDragEvent() {
+	pl.ConnectEvent(oswin.MouseDragEvent, gi.RegPri, func(recv, send ki.Ki, sig int64, d interface{}) {
+		me := d.(*mouse.DragEvent)
+		plt := recv.Embed(KiT_Plot).(*Plot)
+		if _, in := plt.NormPos(me.From); !in {
+			return
+		}
+		me.SetProcessed()
+		del := me.Delta()
+		plt.X.Pan(-float64(del.X) / float64(plt.PlotSize.X))
+		plt.Y.Pan(float64(del.Y) / float64(plt.PlotSize.Y))
+		plt.UpdateSig()
+	})
+}
+
+// MouseMoveEvent updates the hover read-out
+func (pl *Plot) MouseMoveEvent() {
+	pl.ConnectEvent(oswin.MouseMoveEvent, gi.RegPri, func(recv, send ki.Ki, sig int64, d interface{}) {
+		me := d.(*mouse.MoveEvent)
+		plt := recv.Embed(KiT_Plot).(*Plot)
+		plt.UpdateHover(me.Where)
+	})
+}
+
+func (pl *Plot) ConnectEvents2D() {
+	pl.MouseEvent()
+	pl.MouseScrollEvent()
+	pl.MouseDragEvent()
+	pl.MouseMoveEvent()
+}
+
+func (pl *Plot) AccessInfo2D(ai *gi.AccessInfo) {
+	pl.WidgetBase.AccessInfo2D(ai)
+	ai.Role = gi.AccessChart
+	ai.Label = pl.Title
+	names := make([]string, len(pl.Series))
+	for i, sr := range pl.Series {
+		names[i] = sr.Name
+	}
+	ai.Value = strings.Join(names, ", ")
+}
+
+func (pl *Plot) MakeContextMenu(m *gi.Menu) {
+	m.AddAction(gi.ActOpts{Label: "Reset View"}, pl.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+		recv.Embed(KiT_Plot).(*Plot).ResetView()
+	})
+	lbl := "Hide Legend"
+	if pl.NoLegend {
+		lbl = "Show Legend"
+	}
+	m.AddAction(gi.ActOpts{Label: lbl}, pl.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+		plt := recv.Embed(KiT_Plot).(*Plot)
+		plt.NoLegend = !plt.NoLegend
+		plt.UpdateSig()
+	})
+	m.AddSeparator("sep-save")
+	m.AddAction(gi.ActOpts{Label: "Save PNG..."}, pl.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+		recv.Embed(KiT_Plot).(*Plot).SaveDialog(".png")
+	})
+	m.AddAction(gi.ActOpts{Label: "Save SVG..."}, pl.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+		recv.Embed(KiT_Plot).(*Plot).SaveDialog(".svg")
+	})
+	pl.WidgetBase.MakeContextMenu(m)
+}
+
+// SaveDialog opens a file dialog to save the plot to a file of given
+// extension: .png or .svg
+func (pl *Plot) SaveDialog(ext string) {
+	giv.FileViewDialog(pl.ViewportSafe(), "plot"+ext, ext, giv.DlgOpts{Title: "Save Plot"}, nil,
+		pl.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+			if sig == int64(gi.DialogAccepted) {
+				dlg := send.Embed(gi.KiT_Dialog).(*gi.Dialog)
+				fn := gi.FileName(giv.FileViewDialogValue(dlg))
+				plt := recv.Embed(KiT_Plot).(*Plot)
+				var err error
+				if ext == ".svg" {
+					err = plt.SaveSVG(fn)
+				} else {
+					err = plt.SavePNG(fn)
+				}
+				if err != nil {
+					gi.PromptDialog(plt.ViewportSafe(), gi.DlgOpts{Title: "Save Plot Error", Prompt: err.Error()}, gi.AddOk, gi.NoCancel, nil, nil)
+				}
+			}
+		})
+}
+
+// ExportSize returns the size of exported images of the plot: the current
+// size of the widget, or 640x480 if it has not been rendered
+func (pl *Plot) ExportSize() mat32.Vec2 {
+	sz := pl.LayState.Alloc.Size
+	if sz.X < 10 || sz.Y < 10 {
+		return mat32.Vec2{640, 480}
+	}
+	return sz
+}
+
+// exportCanvas returns a paint canvas for rendering or measuring the plot
+// on given render state, along with the foreground and background colors
+func (pl *Plot) exportCanvas(rs *gi.RenderState) (*PaintCanvas, gi.Color, gi.Color) {
+	pl.StyMu.RLock()
+	defer pl.StyMu.RUnlock()
+	st := &pl.Sty
+	if st.Font.Face == nil {
+		st.Font.OpenFont(&st.UnContext)
+	}
+	return NewPaintCanvas(rs, &st.Font, &st.UnContext, &st.Text), st.Font.Color, st.Font.BgColor.Color
+}
+
+// SavePNG saves the plot to given PNG image file, at ExportSize
+func (pl *Plot) SavePNG(filename gi.FileName) error {
+	sz := pl.ExportSize()
+	vp := gi.NewViewport2D(int(sz.X), int(sz.Y))
+	cv, fg, bg := pl.exportCanvas(&vp.Render)
+	if bg.IsNil() {
+		bg.SetColor(color.White)
+	}
+	cv.Rect(mat32.Vec2{}, sz, bg)
+	pl.Draw(cv, mat32.Vec2{}, sz, fg, bg)
+	err := vp.SavePNG(string(filename))
+	if err != nil {
+		log.Println(err)
+	}
+	return err
+}
+
+// SaveSVG saves the plot to given SVG file, at ExportSize
+func (pl *Plot) SaveSVG(filename gi.FileName) error {
+	sz := pl.ExportSize()
+	mc, fg, bg := pl.exportCanvas(nil)
+	if bg.IsNil() {
+		bg.SetColor(color.White)
+	}
+	sc := NewSVGCanvas(sz, mc)
+	sc.Rect(mat32.Vec2{}, sz, bg)
+	pl.Draw(sc, mat32.Vec2{}, sz, fg, bg)
+	err := ioutil.WriteFile(string(filename), []byte(sc.End()), 0644)
+	if err != nil {
+		log.Println(err)
+	}
+	return err
+}
+
+// RenderPlot renders the plot within the content box of the widget
+func (pl *Plot) RenderPlot() {
+	rs, _, st := pl.RenderLock()
+	defer pl.RenderUnlock(rs)
+	pl.RenderStdBox(st)
+	if st.Font.Face == nil {
+		st.Font.OpenFont(&st.UnContext)
+	}
+	spc := st.BoxSpace()
+	pos := pl.LayState.Alloc.Pos.AddScalar(spc)
+	sz := pl.LayState.Alloc.Size.AddScalar(-2 * spc)
+	cv := NewPaintCanvas(rs, &st.Font, &st.UnContext, &st.Text)
+	pl.PlotPos, pl.PlotSize = pl.Draw(cv, pos, sz, st.Font.Color, st.Font.BgColor.Color)
+}
+
+func (pl *Plot) Render2D() {
+	if pl.FullReRenderIfNeeded() {
+		return
+	}
+	if pl.PushBounds() {
+		pl.This().(gi.Node2D).ConnectEvents2D()
+		pl.RenderPlot()
+		pl.Render2DChildren()
+		pl.PopBounds()
+	} else {
+		pl.DisconnectAllEvents(gi.RegPri)
+	}
+}
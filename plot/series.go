@@ -0,0 +1,227 @@
+// Copyright (c) 2018, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"time"
+
+	"github.com/goki/gi/gi"
+	"github.com/goki/ki/kit"
+)
+
+// SeriesTypes are the types of charts for a data series
+type SeriesTypes int32
+
+const (
+	// SeriesLine draws lines between successive points
+	SeriesLine SeriesTypes = iota
+
+	// SeriesScatter draws a point for each value
+	SeriesScatter
+
+	// SeriesBar draws a bar from 0 to each value -- bars of multiple bar
+	// series at the same X values are drawn side by side
+	SeriesBar
+
+	// SeriesHistogram draws the counts of the Y values in Bins equal-sized
+	// bins as bars -- X values are not used
+	SeriesHistogram
+
+	// SeriesArea draws lines between successive points, and fills the area
+	// between the lines and 0
+	SeriesArea
+
+	SeriesTypesN
+)
+
+//go:generate stringer -type=SeriesTypes
+
+var KiT_SeriesTypes = kit.Enums.AddEnumAltLower(SeriesTypesN, kit.NotBitFlag, nil, "Series")
+
+func (ev SeriesTypes) MarshalJSON() ([]byte, error)  { return kit.EnumMarshalJSON(ev) }
+func (ev *SeriesTypes) UnmarshalJSON(b []byte) error { return kit.EnumUnmarshalJSON(ev, b) }
+
+// Series is one series of data values shown in a plot
+type Series struct {
+	Name      string      `desc:"name of the series, shown in the legend"`
+	Type      SeriesTypes `desc:"type of chart for the series"`
+	X         []float64   `desc:"X values for each point -- if empty, the index of each Y value is used"`
+	Y         []float64   `desc:"Y values for each point -- for histograms, the values that are counted"`
+	Err       []float64   `desc:"optional error values for each point, drawn as error bars of +/- the value around Y"`
+	Color     gi.Color    `desc:"color of the series -- if not set, a color is chosen from the ColorMap of the plot"`
+	LineWidth float32     `desc:"width of lines in dots -- 0 = default of 2"`
+	PointSize float32     `desc:"radius of points in dots for scatter plots (0 = default of 3), and of markers on the points of line and area plots (0 = none)"`
+	Bins      int         `desc:"number of bins for histograms -- 0 = default of 10"`
+	Hidden    bool        `desc:"do not show this series"`
+}
+
+// NewSeries returns a new series with given name, type and values
+func NewSeries(name string, typ SeriesTypes, x, y []float64) *Series {
+	return &Series{Name: name, Type: typ, X: x, Y: y}
+}
+
+// NBins returns the number of bins for histograms
+func (sr *Series) NBins() int {
+	if sr.Bins <= 0 {
+		return 10
+	}
+	return sr.Bins
+}
+
+// Points returns the X and Y values of all the points of the series -- for
+// histograms the centers and counts of the bins
+func (sr *Series) Points() (xs, ys []float64) {
+	if sr.Type == SeriesHistogram {
+		edges, counts := sr.Histogram()
+		xs = make([]float64, len(counts))
+		for i := range counts {
+			xs[i] = 0.5 * (edges[i] + edges[i+1])
+		}
+		return xs, counts
+	}
+	xs = make([]float64, len(sr.Y))
+	for i := range xs {
+		if i < len(sr.X) {
+			xs[i] = sr.X[i]
+		} else {
+			xs[i] = float64(i)
+		}
+	}
+	return xs, sr.Y
+}
+
+// ErrAt returns the error value of the point at given index, 0 if none
+func (sr *Series) ErrAt(idx int) float64 {
+	if sr.Type == SeriesHistogram || idx >= len(sr.Err) {
+		return 0
+	}
+	return sr.Err[idx]
+}
+
+// Histogram returns the edges (NBins+1) and counts (NBins) of the bins of
+// the Y values, over their range
+func (sr *Series) Histogram() (edges, counts []float64) {
+	nb := sr.NBins()
+	edges = make([]float64, nb+1)
+	counts = make([]float64, nb)
+	min, max := math.Inf(1), math.Inf(-1)
+	for _, v := range sr.Y {
+		if math.IsNaN(v) {
+			continue
+		}
+		min = math.Min(min, v)
+		max = math.Max(max, v)
+	}
+	if min > max {
+		min, max = 0, 1
+	}
+	if min == max {
+		max = min + 1
+	}
+	w := (max - min) / float64(nb)
+	for i := range edges {
+		edges[i] = min + float64(i)*w
+	}
+	for _, v := range sr.Y {
+		if math.IsNaN(v) {
+			continue
+		}
+		bi := int((v - min) / w)
+		if bi >= nb {
+			bi = nb - 1
+		}
+		counts[bi]++
+	}
+	return
+}
+
+// Range returns the range of X and Y values of the series, including
+// error bars, and 0 for the Y values of bars and areas
+func (sr *Series) Range() (xmin, xmax, ymin, ymax float64) {
+	xmin, ymin = math.Inf(1), math.Inf(1)
+	xmax, ymax = math.Inf(-1), math.Inf(-1)
+	if sr.Type == SeriesHistogram {
+		edges, counts := sr.Histogram()
+		xmin, xmax = edges[0], edges[len(edges)-1]
+		ymin = 0
+		for _, c := range counts {
+			ymax = math.Max(ymax, c)
+		}
+		return
+	}
+	xs, ys := sr.Points()
+	for i, x := range xs {
+		y := ys[i]
+		if math.IsNaN(x) || math.IsNaN(y) {
+			continue
+		}
+		e := math.Abs(sr.ErrAt(i))
+		xmin = math.Min(xmin, x)
+		xmax = math.Max(xmax, x)
+		ymin = math.Min(ymin, y-e)
+		ymax = math.Max(ymax, y+e)
+	}
+	if sr.Type == SeriesBar || sr.Type == SeriesArea {
+		ymin = math.Min(ymin, 0)
+		ymax = math.Max(ymax, 0)
+	}
+	return
+}
+
+// NewSeriesFromSlice returns a new series of given type with X and Y values
+// read from given fields of a slice of structs (or pointers to structs), such
+// as those shown in a giv.TableView.  xField can be empty to use the index of
+// each element as X.  Fields can be any numeric type, or time.Time (see
+// TimeValue).
+func NewSeriesFromSlice(name string, typ SeriesTypes, slice interface{}, xField, yField string) (*Series, error) {
+	sv := kit.NonPtrValue(reflect.ValueOf(slice))
+	if sv.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("plot.NewSeriesFromSlice: not a slice: %T", slice)
+	}
+	sr := &Series{Name: name, Type: typ}
+	n := sv.Len()
+	sr.Y = make([]float64, n)
+	if xField != "" {
+		sr.X = make([]float64, n)
+	}
+	for i := 0; i < n; i++ {
+		ev := kit.NonPtrValue(sv.Index(i))
+		if ev.Kind() != reflect.Struct {
+			return nil, fmt.Errorf("plot.NewSeriesFromSlice: slice element is not a struct: %v", ev.Type())
+		}
+		y, err := fieldValue(ev, yField)
+		if err != nil {
+			return nil, err
+		}
+		sr.Y[i] = y
+		if xField != "" {
+			x, err := fieldValue(ev, xField)
+			if err != nil {
+				return nil, err
+			}
+			sr.X[i] = x
+		}
+	}
+	return sr, nil
+}
+
+// fieldValue returns the plot value of given field of given struct value
+func fieldValue(sv reflect.Value, field string) (float64, error) {
+	fv := sv.FieldByName(field)
+	if !fv.IsValid() {
+		return 0, fmt.Errorf("plot: field %v not found in type: %v", field, sv.Type())
+	}
+	if t, ok := fv.Interface().(time.Time); ok {
+		return TimeValue(t), nil
+	}
+	v, ok := kit.ToFloat(fv.Interface())
+	if !ok {
+		return 0, fmt.Errorf("plot: field %v in type: %v is not a number", field, sv.Type())
+	}
+	return v, nil
+}
@@ -424,6 +424,97 @@ func MakeDefaultIcons() *IconSet {
 			c2.SetProp("stroke-width", units.NewPct(5))
 			iset[ic.Nm] = ic
 		}
+		{
+			ic := &Icon{}
+			ic.InitName(ic, "eye")
+			ic.ViewBox.Size = mat32.Vec2{1, 1}
+			p := AddNewPath(ic, "p", "M 0.05 0.5 Q .5 .05 .95 .5 Q .5 .95 .05 .5 Z")
+			p.SetProp("fill", "none")
+			p.SetProp("stroke-width", units.NewPct(6))
+			pc := AddNewCircle(ic, "pc", 0.5, 0.5, 0.12)
+			pc.SetProp("stroke-width", units.NewPct(6))
+			iset[ic.Nm] = ic
+		}
+		{
+			ic := &Icon{}
+			ic.InitName(ic, "eye-slash")
+			ic.ViewBox.Size = mat32.Vec2{1, 1}
+			p := AddNewPath(ic, "p", "M 0.05 0.5 Q .5 .05 .95 .5 Q .5 .95 .05 .5 Z")
+			p.SetProp("fill", "none")
+			p.SetProp("stroke-width", units.NewPct(6))
+			pc := AddNewCircle(ic, "pc", 0.5, 0.5, 0.12)
+			pc.SetProp("stroke-width", units.NewPct(6))
+			sl := AddNewPath(ic, "sl", "M 0.15 0.15 .85 .85")
+			sl.SetProp("stroke-width", units.NewPct(8))
+			iset[ic.Nm] = ic
+		}
+		{
+			ic := &Icon{}
+			ic.InitName(ic, "calendar")
+			ic.ViewBox.Size = mat32.Vec2{1, 1}
+			bx := AddNewRect(ic, "bx", 0.1, 0.15, 0.8, 0.75)
+			bx.SetProp("fill", "none")
+			bx.SetProp("stroke-width", units.NewPct(6))
+			tp := AddNewPath(ic, "tp", "M 0.1 0.35 .9 .35 M 0.3 0.05 .3 .25 M 0.7 0.05 .7 .25")
+			tp.SetProp("stroke-width", units.NewPct(6))
+			iset[ic.Nm] = ic
+		}
+		{
+			ic := &Icon{}
+			ic.InitName(ic, "clock")
+			ic.ViewBox.Size = mat32.Vec2{1, 1}
+			fc := AddNewCircle(ic, "fc", 0.5, 0.5, 0.42)
+			fc.SetProp("fill", "none")
+			fc.SetProp("stroke-width", units.NewPct(6))
+			hd := AddNewPath(ic, "hd", "M 0.5 0.2 .5 .5 .72 .62")
+			hd.SetProp("fill", "none")
+			hd.SetProp("stroke-width", units.NewPct(6))
+			iset[ic.Nm] = ic
+		}
+		{
+			ic := &Icon{}
+			ic.InitName(ic, "bell")
+			ic.ViewBox.Size = mat32.Vec2{1, 1}
+			p := AddNewPath(ic, "p", "M 0.2 0.75 Q .25 .65 .25 .45 Q .25 .15 .5 .15 Q .75 .15 .75 .45 Q .75 .65 .8 .75 Z")
+			p.SetProp("fill", "none")
+			p.SetProp("stroke-width", units.NewPct(6))
+			cl := AddNewPath(ic, "cl", "M 0.42 0.85 .58 .85")
+			cl.SetProp("stroke-width", units.NewPct(8))
+			iset[ic.Nm] = ic
+		}
+		{
+			ic := &Icon{}
+			ic.InitName(ic, "warning")
+			ic.ViewBox.Size = mat32.Vec2{1, 1}
+			p := AddNewPath(ic, "p", "M 0.5 0.08 .95 .9 .05 .9 Z")
+			p.SetProp("fill", "none")
+			p.SetProp("stroke-width", units.NewPct(6))
+			ex := AddNewPath(ic, "ex", "M 0.5 0.35 .5 .62 M 0.5 0.72 .5 .78")
+			ex.SetProp("stroke-width", units.NewPct(8))
+			iset[ic.Nm] = ic
+		}
+		{
+			ic := &Icon{}
+			ic.InitName(ic, "error")
+			ic.ViewBox.Size = mat32.Vec2{1, 1}
+			fc := AddNewCircle(ic, "fc", 0.5, 0.5, 0.42)
+			fc.SetProp("fill", "none")
+			fc.SetProp("stroke-width", units.NewPct(6))
+			x := AddNewPath(ic, "x", "M 0.35 0.35 .65 .65 M 0.65 0.35 .35 .65")
+			x.SetProp("stroke-width", units.NewPct(8))
+			iset[ic.Nm] = ic
+		}
+		{
+			ic := &Icon{}
+			ic.InitName(ic, "pin")
+			ic.ViewBox.Size = mat32.Vec2{1, 1}
+			hd := AddNewPath(ic, "hd", "M 0.35 0.1 .65 .1 .6 .2 .6 .45 .75 .6 .25 .6 .4 .45 .4 .2 Z")
+			hd.SetProp("fill", "none")
+			hd.SetProp("stroke-width", units.NewPct(6))
+			pt := AddNewPath(ic, "pt", "M 0.5 0.6 .5 .92")
+			pt.SetProp("stroke-width", units.NewPct(6))
+			iset[ic.Nm] = ic
+		}
 	}
 	return &iset
 }